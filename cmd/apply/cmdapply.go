@@ -10,15 +10,22 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"sigs.k8s.io/cli-utils/cmd/flagutils"
 	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/cli-utils/pkg/apply/audit"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/verify"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/manifestreader"
+	"sigs.k8s.io/cli-utils/pkg/print/redact"
 	"sigs.k8s.io/cli-utils/pkg/printers"
+	"sigs.k8s.io/cli-utils/pkg/printers/events"
+	"sigs.k8s.io/cli-utils/pkg/printers/table"
 )
 
 func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
@@ -40,11 +47,32 @@ func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
 		"If true, apply merge patch is calculated on API server instead of client.")
 	cmd.Flags().BoolVar(&r.serverSideOptions.ForceConflicts, "force-conflicts", false,
 		"If true, overwrite applied fields on server if field manager conflict.")
+	cmd.Flags().StringSliceVar(&r.serverSideOptions.ForceConflictsWith, "force-conflicts-with", nil,
+		"Field managers whose fields are overwritten on conflict. Conflicts with other field managers fail the apply.")
 	cmd.Flags().StringVar(&r.serverSideOptions.FieldManager, "field-manager", common.DefaultFieldManager,
 		"The client owner of the fields being applied on the server-side.")
+	cmd.Flags().StringVar(&r.serverSideOptions.FieldValidation, "field-validation", "",
+		"How the server handles unknown or duplicate fields: Ignore, Warn, or Strict. If empty, the server default is used.")
+	cmd.Flags().BoolVar(&r.preValidate, "pre-validate", false,
+		"If true, validate all resources with a server-side dry-run before applying any of them.")
+	cmd.Flags().BoolVar(&r.updateOnly, "update-only", false,
+		"If true, only update resources that already exist in the cluster, and skip the missing ones.")
+	cmd.Flags().StringVar(&r.selector, "selector", "",
+		"If set, only apply and prune resources matching this label selector, and skip the others.")
+	cmd.Flags().StringSliceVar(&r.includeKinds, "include-kinds", nil,
+		"If set, only apply and prune resources of these kinds, in the format Kind.group (e.g. CustomResourceDefinition.apiextensions.k8s.io).")
+	cmd.Flags().StringSliceVar(&r.excludeKinds, "exclude-kinds", nil,
+		"Skip resources of these kinds, in the format Kind.group (e.g. Deployment.apps).")
 
 	cmd.Flags().StringVar(&r.output, "output", printers.DefaultPrinter(),
 		fmt.Sprintf("Output format, must be one of %s", strings.Join(printers.SupportedPrinters(), ",")))
+	cmd.Flags().BoolVar(&r.quiet, "quiet", false,
+		"If true, only print the objects that failed. Only supported with the events output.")
+	cmd.Flags().BoolVar(&r.summary, "summary", false,
+		"If true, only print the objects that failed and a summary of each phase. Only supported with the events output.")
+	cmd.Flags().StringSliceVar(&r.columns, "columns", nil,
+		fmt.Sprintf("Columns to print with the table output, any of %s. Defaults to all columns.",
+			strings.Join(table.SupportedColumns(), ",")))
 	cmd.Flags().DurationVar(&r.reconcileTimeout, "reconcile-timeout", time.Duration(0),
 		"Timeout threshold for waiting for all resources to reach the Current status.")
 	cmd.Flags().BoolVar(&r.noPrune, "no-prune", r.noPrune,
@@ -53,6 +81,26 @@ func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
 		"Background", "Propagation policy for pruning")
 	cmd.Flags().DurationVar(&r.pruneTimeout, "prune-timeout", time.Duration(0),
 		"Timeout threshold for waiting for all pruned resources to be deleted")
+	cmd.Flags().BoolVar(&r.pruneFirst, "prune-first", false,
+		"If true, prune previously applied objects, and wait for their deletion, before applying the resources.")
+	cmd.Flags().BoolVar(&r.forcePruneCRDs, "force-prune-crds", false,
+		"If true, prune CustomResourceDefinitions even if they still have custom resources.")
+	cmd.Flags().BoolVar(&r.forcePruneNamespaces, "force-prune-namespaces", false,
+		"If true, prune Namespaces even if they contain objects not owned by the inventory.")
+	cmd.Flags().StringSliceVar(&r.pruneAllowlist, "prune-allowlist", nil,
+		"If set, only prune objects of these kinds, in the format Kind.group (e.g. Deployment.apps).")
+	cmd.Flags().StringSliceVar(&r.pruneDenylist, "prune-denylist", nil,
+		"Never prune objects of these kinds, in the format Kind.group (e.g. PersistentVolumeClaim).")
+	cmd.Flags().StringSliceVar(&r.redactPatterns, "redact-pattern", nil,
+		"Regular expressions matching sensitive values to replace with REDACTED in the output. "+
+			"The values of Secrets are always redacted.")
+	cmd.Flags().StringVar(&r.policyHookURL, "policy-hook-url", "",
+		"If set, the URL of a policy service that reviews every object before it is applied or pruned.")
+	cmd.Flags().StringVar(&r.legacyPruneSelector, "legacy-prune-selector", "",
+		"If set, adopt the objects previously applied with 'kubectl apply --prune -l' using this label selector, "+
+			"and prune the ones no longer being applied.")
+	cmd.Flags().StringVar(&r.legacyPruneNamespace, "legacy-prune-namespace", "",
+		"The namespace of the objects previously applied with 'kubectl apply --prune'. Defaults to all namespaces.")
 	cmd.Flags().StringVar(&r.inventoryPolicy, flagutils.InventoryPolicyFlag, flagutils.InventoryPolicyStrict,
 		"It determines the behavior when the resources don't belong to current inventory. Available options "+
 			fmt.Sprintf("%q, %q and %q.", flagutils.InventoryPolicyStrict, flagutils.InventoryPolicyAdopt, flagutils.InventoryPolicyForceAdopt))
@@ -60,6 +108,27 @@ func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
 		"How long to wait before exiting")
 	cmd.Flags().BoolVar(&r.printStatusEvents, "status-events", false,
 		"Print status events (always enabled for table output)")
+	cmd.Flags().DurationVar(&r.waitSummaryInterval, "wait-summary-interval", 30*time.Second,
+		"How often to print a summary of the resources still being waited on. Zero disables the summary.")
+	cmd.Flags().IntVar(&r.applyConcurrency, "apply-concurrency", 1,
+		"The maximum number of resources without dependencies on each other to apply at the same time.")
+	cmd.Flags().IntVar(&r.maxRetries, "max-retries", 0,
+		"How many times to retry applying or pruning a resource after a transient API error (conflict, throttling, or server error).")
+	cmd.Flags().BoolVar(&r.timing, "timing", false,
+		"Record how long each resource took to apply, prune, and reconcile, and print the durations.")
+	cmd.Flags().DurationVar(&r.cancelGracePeriod, "cancel-grace-period", 0,
+		"If set, when the timeout is reached, wait up to this long for the running step to finish, "+
+			"then skip the remaining resources and print the summary.")
+	cmd.Flags().StringVar(&r.auditFile, "audit-file", "",
+		"If set, append an audit record of the run, with the operation and content digest of each resource, to this file as a line of JSON.")
+	cmd.Flags().StringVar(&r.auditActor, "audit-actor", "",
+		"Who started the run, recorded in the audit record.")
+	cmd.Flags().StringVar(&r.signature, "signature", "",
+		"If set, the detached signature of the manifest file or archive, which is verified before anything is applied.")
+	cmd.Flags().StringVar(&r.cosignKey, "cosign-key", "",
+		"The cosign public key file that verifies the --signature. If not set, the signature is verified with gpg.")
+	cmd.Flags().StringVar(&r.gpgKeyring, "gpg-keyring", "",
+		"The gpg keyring with the trusted keys that verifies the --signature. Defaults to the gpg default keyring.")
 
 	r.Command = cmd
 	return r
@@ -79,13 +148,57 @@ type Runner struct {
 
 	serverSideOptions      common.ServerSideOptions
 	output                 string
+	quiet                  bool
+	summary                bool
+	outputMode             events.Mode
+	columns                []string
 	reconcileTimeout       time.Duration
 	noPrune                bool
 	prunePropagationPolicy string
 	pruneTimeout           time.Duration
+	pruneFirst             bool
+	forcePruneCRDs         bool
+	forcePruneNamespaces   bool
+	policyHookURL          string
+	redactPatterns         []string
+	pruneAllowlist         []string
+	pruneDenylist          []string
+	legacyPruneSelector    string
+	legacyPruneNamespace   string
 	inventoryPolicy        string
 	timeout                time.Duration
 	printStatusEvents      bool
+	waitSummaryInterval    time.Duration
+	applyConcurrency       int
+	maxRetries             int
+	timing                 bool
+	cancelGracePeriod      time.Duration
+	preValidate            bool
+	updateOnly             bool
+	selector               string
+	includeKinds           []string
+	excludeKinds           []string
+	auditFile              string
+	auditActor             string
+	signature              string
+	cosignKey              string
+	gpgKeyring             string
+}
+
+// legacyPruneSet returns the LegacyPruneSet for the --legacy-prune-selector
+// flag, or nil if it is not set.
+func (r *Runner) legacyPruneSet() (*inventory.LegacyPruneSet, error) {
+	if r.legacyPruneSelector == "" {
+		return nil, nil
+	}
+	selector, err := labels.Parse(r.legacyPruneSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid legacy prune selector: %w", err)
+	}
+	return &inventory.LegacyPruneSet{
+		Selector:  selector,
+		Namespace: r.legacyPruneNamespace,
+	}, nil
 }
 
 func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
@@ -105,25 +218,85 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	pruneAllowed, err := flagutils.ConvertGroupKinds(r.pruneAllowlist)
+	if err != nil {
+		return err
+	}
+	pruneDenied, err := flagutils.ConvertGroupKinds(r.pruneDenylist)
+	if err != nil {
+		return err
+	}
+	legacyPruneSet, err := r.legacyPruneSet()
+	if err != nil {
+		return err
+	}
+	selector, err := labels.Parse(r.selector)
+	if err != nil {
+		return err
+	}
+	includeKinds, err := flagutils.ConvertGroupKinds(r.includeKinds)
+	if err != nil {
+		return err
+	}
+	excludeKinds, err := flagutils.ConvertGroupKinds(r.excludeKinds)
+	if err != nil {
+		return err
+	}
+
+	redactor, err := flagutils.ConvertRedactPatterns(r.redactPatterns)
+	if err != nil {
+		return err
+	}
 
 	if found := printers.ValidatePrinterType(r.output); !found {
 		return fmt.Errorf("unknown output type %q", r.output)
 	}
-
-	// TODO: Fix DemandOneDirectory to no longer return FileNameFlags
-	// since we are no longer using them.
-	_, err = common.DemandOneDirectory(args)
+	r.outputMode, err = flagutils.ConvertOutputMode(r.output, r.quiet, r.summary)
+	if err != nil {
+		return err
+	}
+	if err := table.ValidateColumns(r.columns); err != nil {
+		return err
+	}
+	verifiers, err := flagutils.ConvertVerifiers(r.signature, r.cosignKey, r.gpgKeyring)
 	if err != nil {
 		return err
 	}
-	reader, err := r.loader.ManifestReader(cmd.InOrStdin(), flagutils.PathFromArgs(args))
+
+	err = flagutils.DemandOneSource(args)
 	if err != nil {
 		return err
 	}
-	objs, err := reader.Read()
+	var reader manifestreader.ManifestReader
+	var verifiedReader *manifestreader.VerifiedManifestReader
+	if len(verifiers) > 0 {
+		// Parse the objects from the verified content, instead of reading
+		// the source again.
+		readerOptions, err := manifestreader.NewReaderOptions(r.factory)
+		if err != nil {
+			return err
+		}
+		verifiedReader = &manifestreader.VerifiedManifestReader{
+			Path:          flagutils.PathFromArgs(args),
+			Reader:        cmd.InOrStdin(),
+			Verifiers:     verifiers,
+			ReaderOptions: readerOptions,
+		}
+		reader = verifiedReader
+	} else {
+		reader, err = r.loader.ManifestReader(cmd.InOrStdin(), flagutils.PathFromArgs(args))
+		if err != nil {
+			return err
+		}
+	}
+	objs, err := manifestreader.ReadContext(ctx, reader)
 	if err != nil {
 		return err
 	}
+	var verified []verify.Result
+	if verifiedReader != nil {
+		verified = verifiedReader.Results
+	}
 
 	invObj, objs, err := inventory.SplitUnstructureds(objs)
 	if err != nil {
@@ -136,11 +309,17 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	pipeline, err := flagutils.PolicyHookPipeline(r.factory, r.policyHookURL)
+	if err != nil {
+		return err
+	}
+
 	// Run the applier. It will return a channel where we can receive updates
 	// to keep track of progress and any issues.
 	a, err := apply.NewApplierBuilder().
 		WithFactory(r.factory).
 		WithInventoryClient(invClient).
+		WithPipeline(pipeline).
 		Build()
 	if err != nil {
 		return err
@@ -157,15 +336,62 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 		// If we are not waiting for status, tell the applier to not
 		// emit the events.
 		EmitStatusEvents:       r.printStatusEvents,
-		NoPrune:                r.noPrune,
+		Prune:                  flagutils.ConvertPrunePolicy(r.noPrune),
 		DryRunStrategy:         common.DryRunNone,
 		PrunePropagationPolicy: prunePropPolicy,
 		PruneTimeout:           r.pruneTimeout,
+		PruneFirst:             r.pruneFirst,
+		ForcePruneCRDs:         r.forcePruneCRDs,
+		ForcePruneNamespaces:   r.forcePruneNamespaces,
+		PruneAllowedGroupKinds: pruneAllowed,
+		PruneDeniedGroupKinds:  pruneDenied,
+		LegacyPruneSet:         legacyPruneSet,
 		InventoryPolicy:        inventoryPolicy,
+		WaitSummaryInterval:    r.waitSummaryInterval,
+		ApplyConcurrency:       r.applyConcurrency,
+		RetryPolicy:            common.RetryPolicy{MaxRetries: r.maxRetries},
+		RecordTiming:           r.timing,
+		CancelGracePeriod:      r.cancelGracePeriod,
+		PreValidate:            r.preValidate,
+		UpdateOnly:             r.updateOnly,
+		Selector:               selector,
+		IncludeGroupKinds:      includeKinds,
+		ExcludeGroupKinds:      excludeKinds,
+		Verified:               verified,
 	})
 
 	// The printer will print updates from the channel. It will block
 	// until the channel is closed.
 	printer := printers.GetPrinter(r.output, r.ioStreams)
-	return printer.Print(ch, common.DryRunNone, r.printStatusEvents)
+	if r.outputMode != events.Verbose {
+		printer = events.NewPrinterWithMode(r.ioStreams, r.outputMode)
+	}
+	if tablePrinter, ok := printer.(*table.Printer); ok {
+		tablePrinter.Columns = r.columns
+	}
+	printer = &redact.Printer{Printer: printer, Redactor: redactor}
+	// Record the result of the run next to the printer, to exit with
+	// the code of its result class.
+	result := apply.NewRunResult()
+	mux := event.NewMultiplexer(result)
+	// The audit recorder receives every event before the printer, so the
+	// record is complete when the printer returns.
+	var recorder *audit.Recorder
+	if r.auditFile != "" {
+		recorder = audit.NewRecorder(audit.NewFileSink(r.auditFile), inv.ID(), audit.Options{
+			Actor:        r.auditActor,
+			FieldManager: r.serverSideOptions.FieldManager,
+			Redactor:     redactor,
+		})
+		mux.Subscribe(recorder)
+	}
+	printCh := mux.SubscribeChannel()
+	go mux.Run(ch)
+	err = printer.Print(printCh, common.DryRunNone, r.printStatusEvents)
+	if recorder != nil {
+		if auditErr := recorder.Write(ctx); auditErr != nil && err == nil {
+			err = fmt.Errorf("failed to write audit record: %w", auditErr)
+		}
+	}
+	return flagutils.FromRunResult(result, err)
 }
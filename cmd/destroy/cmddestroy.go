@@ -15,10 +15,14 @@ import (
 	"k8s.io/kubectl/pkg/util/i18n"
 	"sigs.k8s.io/cli-utils/cmd/flagutils"
 	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/manifestreader"
+	"sigs.k8s.io/cli-utils/pkg/print/redact"
 	"sigs.k8s.io/cli-utils/pkg/printers"
+	"sigs.k8s.io/cli-utils/pkg/printers/events"
+	"sigs.k8s.io/cli-utils/pkg/printers/table"
 )
 
 // GetRunner creates and returns the Runner which stores the cobra command.
@@ -39,6 +43,13 @@ func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
 
 	cmd.Flags().StringVar(&r.output, "output", printers.DefaultPrinter(),
 		fmt.Sprintf("Output format, must be one of %s", strings.Join(printers.SupportedPrinters(), ",")))
+	cmd.Flags().BoolVar(&r.quiet, "quiet", false,
+		"If true, only print the objects that failed. Only supported with the events output.")
+	cmd.Flags().BoolVar(&r.summary, "summary", false,
+		"If true, only print the objects that failed and a summary of each phase. Only supported with the events output.")
+	cmd.Flags().StringSliceVar(&r.columns, "columns", nil,
+		fmt.Sprintf("Columns to print with the table output, any of %s. Defaults to all columns.",
+			strings.Join(table.SupportedColumns(), ",")))
 	cmd.Flags().StringVar(&r.inventoryPolicy, flagutils.InventoryPolicyFlag, flagutils.InventoryPolicyStrict,
 		"It determines the behavior when the resources don't belong to current inventory. Available options "+
 			fmt.Sprintf("%q, %q and %q.", flagutils.InventoryPolicyStrict, flagutils.InventoryPolicyAdopt, flagutils.InventoryPolicyForceAdopt))
@@ -46,10 +57,29 @@ func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
 		"Timeout threshold for waiting for all deleted resources to complete deletion")
 	cmd.Flags().StringVar(&r.deletePropagationPolicy, "delete-propagation-policy",
 		"Background", "Propagation policy for deletion")
+	cmd.Flags().BoolVar(&r.retainInventory, "retain-inventory", false,
+		"If true, keep the inventory object after deleting the objects in it.")
+	cmd.Flags().StringSliceVar(&r.redactPatterns, "redact-pattern", nil,
+		"Regular expressions matching sensitive values to replace with REDACTED in the output. "+
+			"The values of Secrets are always redacted.")
+	cmd.Flags().StringVar(&r.policyHookURL, "policy-hook-url", "",
+		"If set, the URL of a policy service that reviews every object before it is deleted.")
+	cmd.Flags().DurationVar(&r.removeFinalizersAfter, "remove-finalizers-after", 0,
+		"If set, remove the finalizers of objects still terminating this long after deletion. "+
+			"This skips any cleanup the finalizers were waiting for.")
 	cmd.Flags().DurationVar(&r.timeout, "timeout", 0,
 		"How long to wait before exiting")
 	cmd.Flags().BoolVar(&r.printStatusEvents, "status-events", false,
 		"Print status events (always enabled for table output)")
+	cmd.Flags().DurationVar(&r.waitSummaryInterval, "wait-summary-interval", 30*time.Second,
+		"How often to print a summary of the resources still being waited on. Zero disables the summary.")
+	cmd.Flags().IntVar(&r.maxRetries, "max-retries", 0,
+		"How many times to retry deleting a resource after a transient API error (conflict, throttling, or server error).")
+	cmd.Flags().BoolVar(&r.timing, "timing", false,
+		"Record how long each resource took to delete, and print the durations.")
+	cmd.Flags().DurationVar(&r.cancelGracePeriod, "cancel-grace-period", 0,
+		"If set, when the timeout is reached, wait up to this long for the running step to finish, "+
+			"then skip the remaining resources and print the summary.")
 
 	r.Command = cmd
 	return r
@@ -70,11 +100,23 @@ type Runner struct {
 	loader     manifestreader.ManifestLoader
 
 	output                  string
+	quiet                   bool
+	summary                 bool
+	outputMode              events.Mode
+	columns                 []string
 	deleteTimeout           time.Duration
 	deletePropagationPolicy string
 	inventoryPolicy         string
+	retainInventory         bool
+	policyHookURL           string
+	redactPatterns          []string
+	removeFinalizersAfter   time.Duration
 	timeout                 time.Duration
 	printStatusEvents       bool
+	waitSummaryInterval     time.Duration
+	maxRetries              int
+	timing                  bool
+	cancelGracePeriod       time.Duration
 }
 
 func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
@@ -95,16 +137,28 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	redactor, err := flagutils.ConvertRedactPatterns(r.redactPatterns)
+	if err != nil {
+		return err
+	}
+
 	if found := printers.ValidatePrinterType(r.output); !found {
 		return fmt.Errorf("unknown output type %q", r.output)
 	}
+	r.outputMode, err = flagutils.ConvertOutputMode(r.output, r.quiet, r.summary)
+	if err != nil {
+		return err
+	}
+	if err := table.ValidateColumns(r.columns); err != nil {
+		return err
+	}
 
 	// Retrieve the inventory object.
 	reader, err := r.loader.ManifestReader(cmd.InOrStdin(), flagutils.PathFromArgs(args))
 	if err != nil {
 		return err
 	}
-	objs, err := reader.Read()
+	objs, err := manifestreader.ReadContext(ctx, reader)
 	if err != nil {
 		return err
 	}
@@ -118,7 +172,15 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	d, err := apply.NewDestroyer(r.factory, invClient)
+	pipeline, err := flagutils.PolicyHookPipeline(r.factory, r.policyHookURL)
+	if err != nil {
+		return err
+	}
+	d, err := apply.NewDestroyerBuilder().
+		WithFactory(r.factory).
+		WithInventoryClient(invClient).
+		WithPipeline(pipeline).
+		Build()
 	if err != nil {
 		return err
 	}
@@ -131,14 +193,34 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 	// Run the destroyer. It will return a channel where we can receive updates
 	// to keep track of progress and any issues.
 	ch := d.Run(ctx, inv, apply.DestroyerOptions{
+		RetainInventory:         r.retainInventory,
 		DeleteTimeout:           r.deleteTimeout,
 		DeletePropagationPolicy: deletePropPolicy,
 		InventoryPolicy:         inventoryPolicy,
 		EmitStatusEvents:        r.printStatusEvents,
+		WaitSummaryInterval:     r.waitSummaryInterval,
+		RemoveFinalizersAfter:   r.removeFinalizersAfter,
+		RetryPolicy:             common.RetryPolicy{MaxRetries: r.maxRetries},
+		RecordTiming:            r.timing,
+		CancelGracePeriod:       r.cancelGracePeriod,
 	})
 
 	// The printer will print updates from the channel. It will block
 	// until the channel is closed.
 	printer := printers.GetPrinter(r.output, r.ioStreams)
-	return printer.Print(ch, common.DryRunNone, r.printStatusEvents)
+	if r.outputMode != events.Verbose {
+		printer = events.NewPrinterWithMode(r.ioStreams, r.outputMode)
+	}
+	if tablePrinter, ok := printer.(*table.Printer); ok {
+		tablePrinter.Columns = r.columns
+	}
+	printer = &redact.Printer{Printer: printer, Redactor: redactor}
+	// Record the result of the run next to the printer, to exit with
+	// the code of its result class.
+	result := apply.NewRunResult()
+	mux := event.NewMultiplexer(result)
+	printCh := mux.SubscribeChannel()
+	go mux.Run(ch)
+	err = printer.Print(printCh, common.DryRunNone, r.printStatusEvents)
+	return flagutils.FromRunResult(result, err)
 }
@@ -4,109 +4,184 @@
 package diff
 
 import (
-	"io/ioutil"
-	"os"
+	"context"
+	"fmt"
+	"io"
+	"time"
 
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	"k8s.io/cli-runtime/pkg/resource"
-	"k8s.io/klog/v2"
-	"k8s.io/kubectl/pkg/cmd/diff"
-	"k8s.io/kubectl/pkg/cmd/util"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
+	"sigs.k8s.io/cli-utils/cmd/flagutils"
+	"sigs.k8s.io/cli-utils/pkg/apply"
 	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/errors"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/manifestreader"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/print/redact"
 )
 
-const tmpDirPrefix = "diff-cmd"
-
-// NewCommand returns cobra command to implement client-side diff of package
-// directory. For each local config file, get the resource in the cluster
-// and diff the local config resource against the resource in the cluster.
-func NewCommand(f util.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
-	options := diff.NewDiffOptions(ioStreams)
+// GetRunner creates and returns the Runner which stores the cobra command.
+func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
+	loader manifestreader.ManifestLoader, ioStreams genericclioptions.IOStreams) *Runner {
+	r := &Runner{
+		factory:    factory,
+		invFactory: invFactory,
+		loader:     loader,
+		ioStreams:  ioStreams,
+	}
 	cmd := &cobra.Command{
 		Use:                   "diff (DIRECTORY | STDIN)",
 		DisableFlagsInUseLine: true,
 		Short:                 i18n.T("Diff local config against cluster applied version"),
-		Args:                  cobra.MaximumNArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			cleanupFunc, err := Initialize(options, f, args)
-			defer cleanupFunc()
-			util.CheckErr(err)
-			util.CheckErr(options.Run())
-		},
+		Long: i18n.T(`Diff local config against cluster applied version.
+
+The objects are applied and pruned with a server-side dry-run, and a unified
+diff is printed for every object that would be created, updated, or pruned.
+The exit code is 6 if any object would change.`),
+		Args: cobra.MaximumNArgs(1),
+		RunE: r.RunE,
 	}
 
-	return cmd
+	cmd.Flags().BoolVar(&r.noPrune, "no-prune", false, "If true, do not diff the previously applied objects that would be pruned.")
+	cmd.Flags().BoolVar(&r.serverSideOptions.ServerSideApply, "server-side", false,
+		"If true, diff the result of a server-side apply instead of a client-side apply.")
+	cmd.Flags().BoolVar(&r.serverSideOptions.ForceConflicts, "force-conflicts", false,
+		"If true during server-side diff, do not report field conflicts.")
+	cmd.Flags().StringVar(&r.serverSideOptions.FieldManager, "field-manager", common.DefaultFieldManager,
+		"If true during server-side diff, sets field owner.")
+	cmd.Flags().StringVar(&r.inventoryPolicy, flagutils.InventoryPolicyFlag, flagutils.InventoryPolicyStrict,
+		"It determines the behavior when the resources don't belong to current inventory. Available options "+
+			fmt.Sprintf("%q, %q and %q.", flagutils.InventoryPolicyStrict, flagutils.InventoryPolicyAdopt, flagutils.InventoryPolicyForceAdopt))
+	cmd.Flags().DurationVar(&r.timeout, "timeout", 0,
+		"How long to wait before exiting")
+	cmd.Flags().StringSliceVar(&r.redactPatterns, "redact-pattern", nil,
+		"Regular expressions matching sensitive values to replace with REDACTED in the output. "+
+			"The values of Secrets are always redacted.")
+
+	r.Command = cmd
+	return r
+}
+
+// Command creates the Runner, returning the cobra command associated with it.
+func Command(f cmdutil.Factory, invFactory inventory.ClientFactory, loader manifestreader.ManifestLoader,
+	ioStreams genericclioptions.IOStreams) *cobra.Command {
+	return GetRunner(f, invFactory, loader, ioStreams).Command
 }
 
-// Initialize fills in the DiffOptions in preparation for DiffOptions.Run().
-// Returns a cleanup function for removing temp files after expanding stdin, or
-// error if there is an error filling in the options or if there
-// is not one argument that is a directory.
-func Initialize(o *diff.DiffOptions, f util.Factory, args []string) (func(), error) {
-	cleanupFunc := func() {}
-	// Validate the only argument is a (package) directory path.
-	filenameFlags, err := common.DemandOneDirectory(args)
+// Runner encapsulates data necessary to run the diff command.
+type Runner struct {
+	Command    *cobra.Command
+	factory    cmdutil.Factory
+	invFactory inventory.ClientFactory
+	loader     manifestreader.ManifestLoader
+	ioStreams  genericclioptions.IOStreams
+
+	noPrune           bool
+	serverSideOptions common.ServerSideOptions
+	inventoryPolicy   string
+	timeout           time.Duration
+	redactPatterns    []string
+}
+
+// RunE is the function run from the cobra command.
+func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	// If specified, cancel with timeout.
+	if r.timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	inventoryPolicy, err := flagutils.ConvertInventoryPolicy(r.inventoryPolicy)
 	if err != nil {
-		return cleanupFunc, err
+		return err
 	}
-	// Process input from stdin
-	if len(args) == 0 {
-		tmpDir, err := createTempDir()
-		if err != nil {
-			return cleanupFunc, err
-		}
-		cleanupFunc = func() {
-			os.RemoveAll(tmpDir)
-		}
-		filenameFlags.Filenames = &[]string{tmpDir}
-		klog.V(6).Infof("stdin diff command temp dir: %s", tmpDir)
-		if err := common.FilterInputFile(os.Stdin, tmpDir); err != nil {
-			return cleanupFunc, err
-		}
-	} else {
-		// We do not want to diff the inventory object. So we expand
-		// the config file paths, excluding the inventory object.
-		filenameFlags, err = common.ExpandPackageDir(filenameFlags)
-		if err != nil {
-			return cleanupFunc, err
-		}
+	redactor, err := flagutils.ConvertRedactPatterns(r.redactPatterns)
+	if err != nil {
+		return err
 	}
-	o.FilenameOptions = filenameFlags.ToOptions()
 
-	o.OpenAPISchema, err = f.OpenAPISchema()
+	err = flagutils.DemandOneSource(args)
 	if err != nil {
-		return cleanupFunc, err
+		return err
 	}
-
-	o.DynamicClient, err = f.DynamicClient()
+	reader, err := r.loader.ManifestReader(cmd.InOrStdin(), flagutils.PathFromArgs(args))
 	if err != nil {
-		return cleanupFunc, err
+		return err
+	}
+	objs, err := manifestreader.ReadContext(ctx, reader)
+	if err != nil {
+		return err
 	}
 
-	o.DryRunVerifier = resource.NewQueryParamVerifier(o.DynamicClient, f.OpenAPIGetter(), resource.QueryParamDryRun)
-
-	o.CmdNamespace, o.EnforceNamespace, err = f.ToRawKubeConfigLoader().Namespace()
+	invObj, objs, err := inventory.SplitUnstructureds(objs)
 	if err != nil {
-		return cleanupFunc, err
+		return err
 	}
+	inv := inventory.WrapInventoryInfoObj(invObj)
 
-	o.Builder = f.NewBuilder()
+	invClient, err := r.invFactory.NewClient(r.factory)
+	if err != nil {
+		return err
+	}
+	a, err := apply.NewApplierBuilder().
+		WithFactory(r.factory).
+		WithInventoryClient(invClient).
+		Build()
+	if err != nil {
+		return err
+	}
 
-	// We don't support server-side apply diffing yet.
-	o.ServerSideApply = false
-	o.ForceConflicts = false
+	diffs, err := a.Diff(ctx, inv, objs, apply.ApplierOptions{
+		Prune:             flagutils.ConvertPrunePolicy(r.noPrune),
+		ServerSideOptions: r.serverSideOptions,
+		InventoryPolicy:   inventoryPolicy,
+	})
+	if err != nil {
+		return redact.Error(redactor, err)
+	}
+	return printDiffs(r.ioStreams.Out, diffs, redactor)
+}
 
-	return cleanupFunc, nil
+// printDiffs prints the unified diff of every object that would change, and
+// returns a ChangesFoundError if there is any. Both sides of each diff are
+// redacted before they are compared.
+func printDiffs(w io.Writer, diffs []apply.ObjectDiff, redactor redact.Redactor) error {
+	changes := 0
+	for _, d := range diffs {
+		if d.Action == apply.DiffUnchanged {
+			continue
+		}
+		changes++
+		unified, err := d.Redact(redactor).UnifiedDiff()
+		if err != nil {
+			return err
+		}
+		unified = redactor.RedactMessage(unified)
+		if _, err := fmt.Fprintf(w, "%s %s\n%s", d.Action, objectName(d.Identifier), unified); err != nil {
+			return err
+		}
+	}
+	if changes > 0 {
+		return &errors.ChangesFoundError{Changes: changes}
+	}
+	return nil
 }
 
-func createTempDir() (string, error) {
-	// Create a temporary file with the passed prefix in
-	// the default temporary directory.
-	tmpDir, err := ioutil.TempDir("", tmpDirPrefix)
-	if err != nil {
-		return "", err
+// objectName returns the kind and name of the object, in the format of the
+// plan command.
+func objectName(id object.ObjMetadata) string {
+	name := id.Name
+	if id.Namespace != "" {
+		name = id.Namespace + "/" + name
+	}
+	kind := id.GroupKind.Kind
+	if id.GroupKind.Group != "" {
+		kind += "." + id.GroupKind.Group
 	}
-	return tmpDir, nil
+	return kind + " " + name
 }
@@ -4,10 +4,24 @@
 package flagutils
 
 import (
+	stderrors "errors"
 	"fmt"
+	"io/ioutil"
+	"regexp"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/cli-utils/pkg/apply/policyhook"
+	"sigs.k8s.io/cli-utils/pkg/apply/verify"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/errors"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/manifestreader"
+	"sigs.k8s.io/cli-utils/pkg/print/redact"
+	"sigs.k8s.io/cli-utils/pkg/printers"
+	"sigs.k8s.io/cli-utils/pkg/printers/events"
 )
 
 const (
@@ -33,6 +47,33 @@ func ConvertPropagationPolicy(propagationPolicy string) (metav1.DeletionPropagat
 	}
 }
 
+// ConvertPrunePolicy returns the PrunePolicy for the --no-prune flag.
+// Unlike the library, kapply prunes by default.
+func ConvertPrunePolicy(noPrune bool) apply.PrunePolicy {
+	if noPrune {
+		return apply.PruneDisabled
+	}
+	return apply.PruneEnabled
+}
+
+// PolicyHookPipeline returns the Pipeline for the --policy-hook-url flag, or
+// nil to use the default stages.
+func PolicyHookPipeline(factory cmdutil.Factory, policyHookURL string) (*apply.Pipeline, error) {
+	if policyHookURL == "" {
+		return nil, nil
+	}
+	client, err := factory.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := factory.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	hook := policyhook.NewHook(policyhook.NewHTTPReviewer(policyHookURL), client, mapper)
+	return apply.NewPipelineBuilder().WithPolicyHook(hook).Build(), nil
+}
+
 func ConvertInventoryPolicy(policy string) (inventory.Policy, error) {
 	switch policy {
 	case InventoryPolicyStrict:
@@ -47,6 +88,83 @@ func ConvertInventoryPolicy(policy string) (inventory.Policy, error) {
 	}
 }
 
+// ConvertRedactPatterns returns the Redactor for the printed output, which
+// redacts the values of Secrets, and the parts of messages that match any of
+// the regular expressions of the --redact-pattern flag.
+func ConvertRedactPatterns(patterns []string) (redact.Redactor, error) {
+	messages := redact.Messages{}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", p, err)
+		}
+		messages.Patterns = append(messages.Patterns, re)
+	}
+	return redact.Chain{redact.SecretData{}, messages}, nil
+}
+
+// ConvertGroupKinds converts a list of GroupKinds described as strings in
+// the "Kind.group" format (e.g. "Deployment.apps", or "Pod" for the core
+// group) to GroupKinds that are passed into the Applier.
+func ConvertGroupKinds(groupKinds []string) ([]schema.GroupKind, error) {
+	var result []schema.GroupKind
+	for _, s := range groupKinds {
+		gk := schema.ParseGroupKind(s)
+		if gk.Kind == "" {
+			return nil, fmt.Errorf("invalid group kind %q: must be in the format Kind.group", s)
+		}
+		result = append(result, gk)
+	}
+	return result, nil
+}
+
+// ConvertOutputMode converts the --quiet and --summary flags to the Mode
+// of the events printer. The flags are mutually exclusive, and only
+// supported with the events output.
+func ConvertOutputMode(output string, quiet, summary bool) (events.Mode, error) {
+	switch {
+	case quiet && summary:
+		return events.Verbose, fmt.Errorf("--quiet and --summary are mutually exclusive")
+	case !quiet && !summary:
+		return events.Verbose, nil
+	case output != printers.EventsPrinter:
+		return events.Verbose, fmt.Errorf(
+			"--quiet and --summary are only supported with --output=%s", printers.EventsPrinter)
+	case quiet:
+		return events.Quiet, nil
+	default:
+		return events.Summary, nil
+	}
+}
+
+// ConvertVerifiers returns the Verifiers of the detached signature of the
+// manifests, or nil if there is no signature. The signature is verified with
+// cosign if a cosign public key is set, and with gpg otherwise.
+func ConvertVerifiers(signature, cosignKey, gpgKeyring string) ([]verify.Verifier, error) {
+	switch {
+	case signature == "" && (cosignKey != "" || gpgKeyring != ""):
+		return nil, fmt.Errorf("--cosign-key and --gpg-keyring require --signature")
+	case signature == "":
+		return nil, nil
+	case cosignKey != "" && gpgKeyring != "":
+		return nil, fmt.Errorf("--cosign-key and --gpg-keyring are mutually exclusive")
+	case cosignKey != "":
+		publicKey, err := ioutil.ReadFile(cosignKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cosign public key: %w", err)
+		}
+		return []verify.Verifier{&verify.CosignVerifier{
+			SignaturePath: signature,
+			PublicKey:     publicKey,
+		}}, nil
+	default:
+		return []verify.Verifier{&verify.GPGVerifier{
+			SignaturePath: signature,
+			Keyring:       gpgKeyring,
+		}}, nil
+	}
+}
+
 // PathFromArgs returns the path which is a positional arg from args list
 // returns "-" if there is length of args is 0, which implies no path is provided
 func PathFromArgs(args []string) string {
@@ -55,3 +173,86 @@ func PathFromArgs(args []string) string {
 	}
 	return args[0]
 }
+
+// DemandOneSource returns an error if there is more than one argument, or
+// if the argument is not a directory, an archive, or a remote source that
+// the ManifestLoader can fetch. No argument means stdin.
+func DemandOneSource(args []string) error {
+	if len(args) == 1 && (manifestreader.IsRemote(args[0]) || manifestreader.IsArchive(args[0])) {
+		return nil
+	}
+	_, err := common.DemandOneDirectory(args)
+	return err
+}
+
+// RunError is returned by commands when an apply or destroy run did not
+// succeed. It carries the class of the run, which determines the exit code.
+type RunError struct {
+	Class apply.ResultClass
+	// Err is the error returned by the printer, if any.
+	Err error
+}
+
+func (e *RunError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	switch e.Class {
+	case apply.ResultPolicyViolation:
+		return "one or more objects were denied by policy"
+	default:
+		return fmt.Sprintf("run failed: %s", e.Class)
+	}
+}
+
+func (e *RunError) Unwrap() error {
+	return e.Err
+}
+
+func (e *RunError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*RunError)
+	if !ok {
+		return false
+	}
+	return e.Class == tErr.Class &&
+		stderrors.Is(e.Err, tErr.Err)
+}
+
+// ExitCode returns the exit code for the class of the run.
+func (e *RunError) ExitCode() int {
+	return ExitCodeForResult(e.Class)
+}
+
+// FromRunResult returns the error of a command that ran the Applier or
+// Destroyer. err is the error returned by the printer. If the run did not
+// succeed, the error is wrapped in a RunError with the class of the run.
+func FromRunResult(result *apply.RunResult, err error) error {
+	class := result.Class()
+	if class == apply.ResultSuccess {
+		return err
+	}
+	return &RunError{Class: class, Err: err}
+}
+
+// ExitCodeForResult returns the exit code for a run of the provided class.
+func ExitCodeForResult(class apply.ResultClass) int {
+	switch class {
+	case apply.ResultSuccess, apply.ResultSkipped:
+		return 0
+	case apply.ResultReconcileTimeout:
+		return errors.ReconcileTimeoutExitCode
+	case apply.ResultPolicyViolation:
+		return errors.PolicyViolationExitCode
+	case apply.ResultActuationFailed:
+		return errors.ActuationFailedExitCode
+	case apply.ResultReconcileFailed:
+		return errors.ReconcileFailedExitCode
+	case apply.ResultCancelled:
+		return errors.CancelledExitCode
+	default:
+		return errors.DefaultErrorExitCode
+	}
+}
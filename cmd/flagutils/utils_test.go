@@ -5,11 +5,25 @@ package flagutils
 
 import (
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/verify"
+	"sigs.k8s.io/cli-utils/pkg/errors"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/printers/events"
 )
 
+func TestConvertPrunePolicy(t *testing.T) {
+	assert.Equal(t, apply.PruneEnabled, ConvertPrunePolicy(false))
+	assert.Equal(t, apply.PruneDisabled, ConvertPrunePolicy(true))
+}
+
 func TestConvertInventoryPolicy(t *testing.T) {
 	testcases := []struct {
 		value  string
@@ -50,3 +64,289 @@ func TestConvertInventoryPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertRedactPatterns(t *testing.T) {
+	testcases := map[string]struct {
+		patterns []string
+		message  string
+		expected string
+		isError  bool
+	}{
+		"no patterns": {
+			message:  "apply failed: password=hunter2",
+			expected: "apply failed: password=hunter2",
+		},
+		"matching pattern": {
+			patterns: []string{`password=\S+`},
+			message:  "apply failed: password=hunter2",
+			expected: "apply failed: REDACTED",
+		},
+		"invalid pattern": {
+			patterns: []string{"("},
+			isError:  true,
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			redactor, err := ConvertRedactPatterns(tc.patterns)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, redactor.RedactMessage(tc.message))
+		})
+	}
+}
+
+func TestConvertVerifiers(t *testing.T) {
+	dir := t.TempDir()
+	cosignKey := filepath.Join(dir, "cosign.pub")
+	assert.NoError(t, ioutil.WriteFile(cosignKey, []byte("public key"), 0600))
+
+	testcases := map[string]struct {
+		signature  string
+		cosignKey  string
+		gpgKeyring string
+		expected   []verify.Verifier
+		isError    bool
+	}{
+		"no signature": {},
+		"cosign": {
+			signature: "manifests.yaml.sig",
+			cosignKey: cosignKey,
+			expected: []verify.Verifier{&verify.CosignVerifier{
+				SignaturePath: "manifests.yaml.sig",
+				PublicKey:     []byte("public key"),
+			}},
+		},
+		"gpg": {
+			signature:  "manifests.yaml.asc",
+			gpgKeyring: "trusted.gpg",
+			expected: []verify.Verifier{&verify.GPGVerifier{
+				SignaturePath: "manifests.yaml.asc",
+				Keyring:       "trusted.gpg",
+			}},
+		},
+		"gpg with the default keyring": {
+			signature: "manifests.yaml.asc",
+			expected: []verify.Verifier{&verify.GPGVerifier{
+				SignaturePath: "manifests.yaml.asc",
+			}},
+		},
+		"key without signature": {
+			cosignKey: cosignKey,
+			isError:   true,
+		},
+		"cosign key and gpg keyring": {
+			signature:  "manifests.yaml.sig",
+			cosignKey:  cosignKey,
+			gpgKeyring: "trusted.gpg",
+			isError:    true,
+		},
+		"missing cosign key": {
+			signature: "manifests.yaml.sig",
+			cosignKey: filepath.Join(dir, "missing.pub"),
+			isError:   true,
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			verifiers, err := ConvertVerifiers(tc.signature, tc.cosignKey, tc.gpgKeyring)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, verifiers)
+		})
+	}
+}
+
+func TestConvertGroupKinds(t *testing.T) {
+	testcases := map[string]struct {
+		values   []string
+		expected []schema.GroupKind
+		isError  bool
+	}{
+		"empty": {},
+		"core and non-core groups": {
+			values: []string{"Pod", "Deployment.apps", "Anvil.acme.com"},
+			expected: []schema.GroupKind{
+				{Kind: "Pod"},
+				{Group: "apps", Kind: "Deployment"},
+				{Group: "acme.com", Kind: "Anvil"},
+			},
+		},
+		"missing kind": {
+			values:  []string{".apps"},
+			isError: true,
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			groupKinds, err := ConvertGroupKinds(tc.values)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, groupKinds)
+		})
+	}
+}
+
+func TestConvertOutputMode(t *testing.T) {
+	testCases := map[string]struct {
+		output      string
+		quiet       bool
+		summary     bool
+		expected    events.Mode
+		expectedErr string
+	}{
+		"no flags": {
+			output:   "table",
+			expected: events.Verbose,
+		},
+		"quiet": {
+			output:   "events",
+			quiet:    true,
+			expected: events.Quiet,
+		},
+		"summary": {
+			output:   "events",
+			summary:  true,
+			expected: events.Summary,
+		},
+		"quiet and summary": {
+			output:      "events",
+			quiet:       true,
+			summary:     true,
+			expectedErr: "--quiet and --summary are mutually exclusive",
+		},
+		"quiet with json output": {
+			output:      "json",
+			quiet:       true,
+			expectedErr: "--quiet and --summary are only supported with --output=events",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			mode, err := ConvertOutputMode(tc.output, tc.quiet, tc.summary)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, mode)
+		})
+	}
+}
+
+func TestRunError_ExitCode(t *testing.T) {
+	testCases := map[string]struct {
+		err              error
+		expectedExitCode int
+	}{
+		"reconcile timeout": {
+			err:              &RunError{Class: apply.ResultReconcileTimeout},
+			expectedExitCode: 2,
+		},
+		"policy violation": {
+			err:              &RunError{Class: apply.ResultPolicyViolation},
+			expectedExitCode: 3,
+		},
+		"actuation failed": {
+			err:              &RunError{Class: apply.ResultActuationFailed},
+			expectedExitCode: 4,
+		},
+		"reconcile failed": {
+			err:              &RunError{Class: apply.ResultReconcileFailed},
+			expectedExitCode: 5,
+		},
+		"cancelled": {
+			err:              &RunError{Class: apply.ResultCancelled},
+			expectedExitCode: 130,
+		},
+		"fatal error": {
+			err:              &RunError{Class: apply.ResultError, Err: fmt.Errorf("inventory not found")},
+			expectedExitCode: errors.DefaultErrorExitCode,
+		},
+		"wrapped run error": {
+			err:              fmt.Errorf("apply: %w", &RunError{Class: apply.ResultReconcileTimeout}),
+			expectedExitCode: 2,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expectedExitCode, errors.ExitCode(tc.err))
+		})
+	}
+}
+
+func TestRunError_Error(t *testing.T) {
+	err := &RunError{Class: apply.ResultReconcileTimeout, Err: fmt.Errorf("1 resources failed to reconcile before timeout")}
+	assert.Equal(t, "1 resources failed to reconcile before timeout", err.Error())
+
+	err = &RunError{Class: apply.ResultPolicyViolation}
+	assert.Equal(t, "one or more objects were denied by policy", err.Error())
+}
+
+func TestFromRunResult(t *testing.T) {
+	result := apply.NewRunResult()
+	assert.NoError(t, FromRunResult(result, nil))
+
+	result.Send(event.Event{
+		Type: event.WaitType,
+		WaitEvent: event.WaitEvent{
+			Status: event.ReconcileTimeout,
+		},
+	})
+	printErr := fmt.Errorf("1 resources failed to reconcile before timeout")
+	err := FromRunResult(result, printErr)
+	assert.Equal(t, &RunError{Class: apply.ResultReconcileTimeout, Err: printErr}, err)
+	assert.Equal(t, errors.ReconcileTimeoutExitCode, errors.ExitCode(err))
+}
+
+func TestDemandOneSource(t *testing.T) {
+	dir := t.TempDir()
+	testCases := map[string]struct {
+		args    []string
+		isError bool
+	}{
+		"stdin": {},
+		"directory": {
+			args: []string{dir},
+		},
+		"oci reference": {
+			args: []string{"oci://ghcr.io/org/app:v1"},
+		},
+		"https url": {
+			args: []string{"https://example.com/manifests.yaml"},
+		},
+		"archive": {
+			args: []string{filepath.Join(dir, "bundle.tar.gz")},
+		},
+		"file": {
+			args:    []string{filepath.Join(dir, "missing.yaml")},
+			isError: true,
+		},
+		"two sources": {
+			args:    []string{dir, "oci://ghcr.io/org/app:v1"},
+			isError: true,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			err := DemandOneSource(tc.args)
+			if tc.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
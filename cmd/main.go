@@ -6,7 +6,6 @@ package main
 import (
 	"context"
 	"flag"
-	"fmt"
 	"os"
 	"strings"
 	"time"
@@ -21,8 +20,10 @@ import (
 	"sigs.k8s.io/cli-utils/cmd/destroy"
 	"sigs.k8s.io/cli-utils/cmd/diff"
 	"sigs.k8s.io/cli-utils/cmd/initcmd"
+	"sigs.k8s.io/cli-utils/cmd/plan"
 	"sigs.k8s.io/cli-utils/cmd/preview"
 	"sigs.k8s.io/cli-utils/cmd/status"
+	"sigs.k8s.io/cli-utils/pkg/errors"
 	"sigs.k8s.io/cli-utils/pkg/flowcontrol"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/manifestreader"
@@ -64,25 +65,44 @@ func main() {
 	loader := manifestreader.NewManifestLoader(f)
 	invFactory := inventory.ClusterClientFactory{StatusPolicy: inventory.StatusPolicyNone}
 
-	names := []string{"init", "apply", "destroy", "diff", "preview", "status"}
+	names := []string{"init", "apply", "destroy", "diff", "plan", "preview", "status"}
 	subCmds := []*cobra.Command{
 		initcmd.NewCmdInit(f, ioStreams),
 		apply.Command(f, invFactory, loader, ioStreams),
 		destroy.Command(f, invFactory, loader, ioStreams),
-		diff.NewCommand(f, ioStreams),
+		diff.Command(f, invFactory, loader, ioStreams),
+		plan.Command(f, invFactory, loader, ioStreams),
 		preview.Command(f, invFactory, loader, ioStreams),
 		status.Command(f, invFactory, loader),
 	}
+	var runErr error
 	for _, subCmd := range subCmds {
 		subCmd.PreRunE = preRunE
+		recordRunE(subCmd, &runErr)
 		updateHelp(names, subCmd)
 		cmd.AddCommand(subCmd)
 	}
 
 	code := cli.Run(cmd)
+	if code != 0 && runErr != nil {
+		code = errors.ExitCode(runErr)
+	}
 	os.Exit(code)
 }
 
+// recordRunE wraps the RunE of the command to record the error it returns,
+// so the exit code can be derived from it.
+func recordRunE(c *cobra.Command, err *error) {
+	if c.RunE == nil {
+		return
+	}
+	runE := c.RunE
+	c.RunE = func(cmd *cobra.Command, args []string) error {
+		*err = runE(cmd, args)
+		return *err
+	}
+}
+
 // updateHelp replaces `kubectl` help messaging with `kapply` help messaging
 func updateHelp(names []string, c *cobra.Command) {
 	for i := range names {
@@ -95,7 +115,8 @@ func updateHelp(names []string, c *cobra.Command) {
 
 // newConfigFilerPreRunE returns a cobra command PreRunE function that
 // performs a lookup to determine if server-side throttling is enabled. If so,
-// client-side throttling is disabled in the ConfigFlags.
+// client-side throttling is disabled in the ConfigFlags. Otherwise, the
+// default client-side rate limits are used.
 func newConfigFilerPreRunE(f util.Factory, configFlags *genericclioptions.ConfigFlags) func(*cobra.Command, []string) error {
 	return func(_ *cobra.Command, args []string) error {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -105,19 +126,21 @@ func newConfigFilerPreRunE(f util.Factory, configFlags *genericclioptions.Config
 		if err != nil {
 			return err
 		}
-		enabled, err := flowcontrol.IsEnabled(ctx, restConfig)
+		limits, enabled, err := flowcontrol.ConfigureRateLimits(ctx, rest.CopyConfig(restConfig),
+			flowcontrol.DefaultRateLimits)
 		if err != nil {
-			return fmt.Errorf("checking server-side throttling enablement: %w", err)
+			return err
 		}
 		if enabled {
-			// Disable client-side throttling.
 			klog.V(3).Infof("Client-side throttling disabled")
-			// WrapConfigFn will affect future Factory.ToRESTConfig() calls.
-			configFlags.WrapConfigFn = func(cfg *rest.Config) *rest.Config {
-				cfg.QPS = -1
-				cfg.Burst = -1
-				return cfg
-			}
+		} else {
+			klog.V(3).Infof("Client-side throttling enabled: %s", limits)
+		}
+		// WrapConfigFn will affect future Factory.ToRESTConfig() calls.
+		configFlags.WrapConfigFn = func(cfg *rest.Config) *rest.Config {
+			cfg.QPS = limits.QPS
+			cfg.Burst = limits.Burst
+			return cfg
 		}
 		return nil
 	}
@@ -0,0 +1,172 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package plan
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"sigs.k8s.io/cli-utils/cmd/flagutils"
+	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/cli-utils/pkg/apply/solver"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/manifestreader"
+)
+
+const (
+	// GraphDOT is the --graph value for the DOT format of Graphviz.
+	GraphDOT = "dot"
+	// GraphJSON is the --graph value for the JSON format.
+	GraphJSON = "json"
+)
+
+// GetRunner creates and returns the Runner which stores the cobra command.
+func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
+	loader manifestreader.ManifestLoader, ioStreams genericclioptions.IOStreams) *Runner {
+	r := &Runner{
+		factory:    factory,
+		invFactory: invFactory,
+		loader:     loader,
+		ioStreams:  ioStreams,
+	}
+	cmd := &cobra.Command{
+		Use:                   "plan (DIRECTORY | STDIN)",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("Show the apply, prune, and wait tasks of an apply"),
+		Args:                  cobra.MaximumNArgs(1),
+		RunE:                  r.RunE,
+	}
+
+	cmd.Flags().BoolVar(&r.noPrune, "no-prune", false, "If true, do not prune previously applied objects.")
+	cmd.Flags().StringVar(&r.graph, "graph", "",
+		fmt.Sprintf("If set, print the tasks and the dependencies between objects as a graph, in the %q or %q format.",
+			GraphDOT, GraphJSON))
+	cmd.Flags().StringVar(&r.inventoryPolicy, flagutils.InventoryPolicyFlag, flagutils.InventoryPolicyStrict,
+		"It determines the behavior when the resources don't belong to current inventory. Available options "+
+			fmt.Sprintf("%q, %q and %q.", flagutils.InventoryPolicyStrict, flagutils.InventoryPolicyAdopt, flagutils.InventoryPolicyForceAdopt))
+	cmd.Flags().DurationVar(&r.timeout, "timeout", 0,
+		"How long to wait before exiting")
+
+	r.Command = cmd
+	return r
+}
+
+// Command creates the Runner, returning the cobra command associated with it.
+func Command(f cmdutil.Factory, invFactory inventory.ClientFactory, loader manifestreader.ManifestLoader,
+	ioStreams genericclioptions.IOStreams) *cobra.Command {
+	return GetRunner(f, invFactory, loader, ioStreams).Command
+}
+
+// Runner encapsulates data necessary to run the plan command.
+type Runner struct {
+	Command    *cobra.Command
+	factory    cmdutil.Factory
+	invFactory inventory.ClientFactory
+	loader     manifestreader.ManifestLoader
+	ioStreams  genericclioptions.IOStreams
+
+	noPrune         bool
+	graph           string
+	inventoryPolicy string
+	timeout         time.Duration
+}
+
+// RunE is the function run from the cobra command.
+func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	// If specified, cancel with timeout.
+	if r.timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	switch r.graph {
+	case "", GraphDOT, GraphJSON:
+	default:
+		return fmt.Errorf("unknown graph format %q, must be one of %q or %q", r.graph, GraphDOT, GraphJSON)
+	}
+
+	inventoryPolicy, err := flagutils.ConvertInventoryPolicy(r.inventoryPolicy)
+	if err != nil {
+		return err
+	}
+
+	err = flagutils.DemandOneSource(args)
+	if err != nil {
+		return err
+	}
+	reader, err := r.loader.ManifestReader(cmd.InOrStdin(), flagutils.PathFromArgs(args))
+	if err != nil {
+		return err
+	}
+	objs, err := manifestreader.ReadContext(ctx, reader)
+	if err != nil {
+		return err
+	}
+
+	invObj, objs, err := inventory.SplitUnstructureds(objs)
+	if err != nil {
+		return err
+	}
+	inv := inventory.WrapInventoryInfoObj(invObj)
+
+	invClient, err := r.invFactory.NewClient(r.factory)
+	if err != nil {
+		return err
+	}
+	a, err := apply.NewApplierBuilder().
+		WithFactory(r.factory).
+		WithInventoryClient(invClient).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	tg, err := a.Plan(ctx, inv, objs, apply.ApplierOptions{
+		Prune:           flagutils.ConvertPrunePolicy(r.noPrune),
+		InventoryPolicy: inventoryPolicy,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch r.graph {
+	case GraphDOT:
+		return tg.WriteDOT(r.ioStreams.Out)
+	case GraphJSON:
+		return tg.WriteJSON(r.ioStreams.Out)
+	default:
+		return printTasks(r.ioStreams.Out, tg)
+	}
+}
+
+// printTasks prints the tasks in order, with their objects.
+func printTasks(w io.Writer, tg *solver.TaskGraph) error {
+	for i, t := range tg.Tasks {
+		if _, err := fmt.Fprintf(w, "%d. %s (%s)\n", i+1, t.Name, t.Action); err != nil {
+			return err
+		}
+		for _, ref := range t.Objects {
+			name := ref.Name
+			if ref.Namespace != "" {
+				name = ref.Namespace + "/" + name
+			}
+			kind := ref.Kind
+			if ref.Group != "" {
+				kind += "." + ref.Group
+			}
+			if _, err := fmt.Fprintf(w, "   %s %s\n", kind, name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
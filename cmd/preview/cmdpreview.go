@@ -19,7 +19,9 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/manifestreader"
+	"sigs.k8s.io/cli-utils/pkg/print/redact"
 	"sigs.k8s.io/cli-utils/pkg/printers"
+	"sigs.k8s.io/cli-utils/pkg/printers/events"
 )
 
 var (
@@ -51,14 +53,23 @@ func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
 		"If true during server-side preview, do not report field conflicts.")
 	cmd.Flags().StringVar(&r.serverSideOptions.FieldManager, "field-manager", common.DefaultFieldManager,
 		"If true during server-side preview, sets field owner.")
+	cmd.Flags().StringVar(&r.serverSideOptions.FieldValidation, "field-validation", "",
+		"How the server handles unknown or duplicate fields: Ignore, Warn, or Strict. If empty, the server default is used.")
 	cmd.Flags().BoolVar(&previewDestroy, "destroy", previewDestroy, "If true, preview of destroy operations will be displayed.")
 	cmd.Flags().StringVar(&r.output, "output", printers.DefaultPrinter(),
 		fmt.Sprintf("Output format, must be one of %s", strings.Join(printers.SupportedPrinters(), ",")))
+	cmd.Flags().BoolVar(&r.quiet, "quiet", false,
+		"If true, only print the objects that failed. Only supported with the events output.")
+	cmd.Flags().BoolVar(&r.summary, "summary", false,
+		"If true, only print the objects that failed and a summary of each phase. Only supported with the events output.")
 	cmd.Flags().StringVar(&r.inventoryPolicy, flagutils.InventoryPolicyFlag, flagutils.InventoryPolicyStrict,
 		"It determines the behavior when the resources don't belong to current inventory. Available options "+
 			fmt.Sprintf("%q, %q and %q.", flagutils.InventoryPolicyStrict, flagutils.InventoryPolicyAdopt, flagutils.InventoryPolicyForceAdopt))
 	cmd.Flags().DurationVar(&r.timeout, "timeout", 0,
 		"How long to wait before exiting")
+	cmd.Flags().StringSliceVar(&r.redactPatterns, "redact-pattern", nil,
+		"Regular expressions matching sensitive values to replace with REDACTED in the output. "+
+			"The values of Secrets are always redacted.")
 
 	r.Command = cmd
 	return r
@@ -80,8 +91,12 @@ type Runner struct {
 
 	serverSideOptions common.ServerSideOptions
 	output            string
+	quiet             bool
+	summary           bool
+	outputMode        events.Mode
 	inventoryPolicy   string
 	timeout           time.Duration
+	redactPatterns    []string
 }
 
 // RunE is the function run from the cobra command.
@@ -111,11 +126,20 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	redactor, err := flagutils.ConvertRedactPatterns(r.redactPatterns)
+	if err != nil {
+		return err
+	}
+
 	if found := printers.ValidatePrinterType(r.output); !found {
 		return fmt.Errorf("unknown output type %q", r.output)
 	}
+	r.outputMode, err = flagutils.ConvertOutputMode(r.output, r.quiet, r.summary)
+	if err != nil {
+		return err
+	}
 
-	objs, err := reader.Read()
+	objs, err := manifestreader.ReadContext(ctx, reader)
 	if err != nil {
 		return err
 	}
@@ -134,7 +158,7 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 	// if destroy flag is set in preview, transmit it to destroyer DryRunStrategy flag
 	// and pivot execution to destroy with dry-run
 	if !previewDestroy {
-		_, err = common.DemandOneDirectory(args)
+		err = flagutils.DemandOneSource(args)
 		if err != nil {
 			return err
 		}
@@ -150,7 +174,7 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 		// to keep track of progress and any issues.
 		ch = a.Run(ctx, inv, objs, apply.ApplierOptions{
 			EmitStatusEvents:  false,
-			NoPrune:           noPrune,
+			Prune:             flagutils.ConvertPrunePolicy(noPrune),
 			DryRunStrategy:    drs,
 			ServerSideOptions: r.serverSideOptions,
 			InventoryPolicy:   inventoryPolicy,
@@ -166,8 +190,10 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 		})
 	}
 
-	// Print the preview strategy unless the output format is json.
-	if r.output != printers.JSONPrinter {
+	// Print the preview strategy unless the output format is for machines
+	// or only failures should be printed.
+	if (r.output == printers.EventsPrinter && r.outputMode != events.Quiet) ||
+		r.output == printers.TablePrinter {
 		if drs.ServerDryRun() {
 			fmt.Println("Preview strategy: server")
 		} else {
@@ -178,5 +204,9 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 	// The printer will print updates from the channel. It will block
 	// until the channel is closed.
 	printer := printers.GetPrinter(r.output, r.ioStreams)
+	if r.outputMode != events.Verbose {
+		printer = events.NewPrinterWithMode(r.ioStreams, r.outputMode)
+	}
+	printer = &redact.Printer{Printer: printer, Redactor: redactor}
 	return printer.Print(ch, drs, false) // Do not print status
 }
@@ -5,6 +5,7 @@ package status
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"time"
 
@@ -13,23 +14,24 @@ import (
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"sigs.k8s.io/cli-utils/cmd/flagutils"
 	"sigs.k8s.io/cli-utils/cmd/status/printers"
+	"sigs.k8s.io/cli-utils/pkg/apply"
 	"sigs.k8s.io/cli-utils/pkg/apply/poller"
-	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
-	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/aggregator"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/collector"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
 	"sigs.k8s.io/cli-utils/pkg/manifestreader"
 )
 
 func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory, loader manifestreader.ManifestLoader) *Runner {
 	r := &Runner{
-		factory:           factory,
-		invFactory:        invFactory,
-		loader:            loader,
-		pollerFactoryFunc: pollerFactoryFunc,
+		factory:            factory,
+		invFactory:         invFactory,
+		loader:             loader,
+		pollerFactoryFunc:  pollerFactoryFunc,
+		watcherFactoryFunc: watcherFactoryFunc,
 	}
 	c := &cobra.Command{
 		Use:  "status (DIRECTORY | STDIN)",
@@ -39,9 +41,11 @@ func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory, load
 		"Polling period for resource statuses.")
 	c.Flags().StringVar(&r.pollUntil, "poll-until", "known",
 		"When to stop polling. Must be one of 'known', 'current', 'deleted', or 'forever'.")
+	c.Flags().BoolVar(&r.watch, "watch", false,
+		"Watch the resources for status changes, instead of polling them.")
 	c.Flags().StringVar(&r.output, "output", "events", "Output format.")
 	c.Flags().DurationVar(&r.timeout, "timeout", 0,
-		"How long to wait before exiting")
+		"How long to wait before exiting. If the resources did not reach the status of poll-until, the exit code is 2.")
 
 	r.Command = c
 	return r
@@ -63,15 +67,17 @@ type Runner struct {
 	pollUntil string
 	timeout   time.Duration
 	output    string
+	watch     bool
 
-	pollerFactoryFunc func(cmdutil.Factory) (poller.Poller, error)
+	pollerFactoryFunc  func(cmdutil.Factory) (poller.Poller, error)
+	watcherFactoryFunc func(cmdutil.Factory) (watcher.StatusWatcher, error)
 }
 
 // runE implements the logic of the command and will delegate to the
-// poller to compute status for each of the resources. One of the printer
+// status watcher to compute status for each of the resources. One of the printer
 // implementations takes care of printing the output.
 func (r *Runner) runE(cmd *cobra.Command, args []string) error {
-	_, err := common.DemandOneDirectory(args)
+	err := flagutils.DemandOneSource(args)
 	if err != nil {
 		return err
 	}
@@ -80,7 +86,7 @@ func (r *Runner) runE(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	objs, err := reader.Read()
+	objs, err := manifestreader.ReadContext(cmd.Context(), reader)
 	if err != nil {
 		return err
 	}
@@ -96,20 +102,7 @@ func (r *Runner) runE(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Based on the inventory template manifest we look up the inventory
-	// from the live state using the inventory client.
-	identifiers, err := invClient.GetClusterObjs(inv)
-	if err != nil {
-		return err
-	}
-
-	// Exit here if the inventory is empty.
-	if len(identifiers) == 0 {
-		_, _ = fmt.Fprint(cmd.OutOrStdout(), "no resources found in the inventory\n")
-		return nil
-	}
-
-	statusPoller, err := r.pollerFactoryFunc(r.factory)
+	statusWatcher, err := r.statusWatcher()
 	if err != nil {
 		return err
 	}
@@ -125,71 +118,88 @@ func (r *Runner) runE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error creating printer: %w", err)
 	}
 
-	// If the user has specified a timeout, we create a context with timeout,
-	// otherwise we create a context with cancel.
-	ctx := cmd.Context()
-	var cancel func()
-	if r.timeout != 0 {
-		ctx, cancel = context.WithTimeout(ctx, r.timeout)
-	} else {
-		ctx, cancel = context.WithCancel(ctx)
-	}
-	defer cancel()
-
-	// Choose the appropriate ObserverFunc based on the criteria for when
+	// Choose the appropriate StatusCondition based on the criteria for when
 	// the command should exit.
-	var cancelFunc collector.ObserverFunc
+	var until apply.StatusCondition
 	switch r.pollUntil {
 	case "known":
-		cancelFunc = allKnownNotifierFunc(cancel)
+		until = apply.AllKnown()
 	case "current":
-		cancelFunc = desiredStatusNotifierFunc(cancel, status.CurrentStatus)
+		until = apply.AllStatus(status.CurrentStatus)
 	case "deleted":
-		cancelFunc = desiredStatusNotifierFunc(cancel, status.NotFoundStatus)
+		until = apply.AllStatus(status.NotFoundStatus)
 	case "forever":
-		cancelFunc = func(*collector.ResourceStatusCollector, event.Event) {}
 	default:
 		return fmt.Errorf("unknown value for pollUntil: %q", r.pollUntil)
 	}
 
-	eventChannel := statusPoller.Poll(ctx, identifiers, polling.PollOptions{
-		PollInterval: r.period,
+	// Based on the inventory template manifest we look up the inventory
+	// from the live state using the inventory client, and watch the status
+	// of its objects until the condition is met or the timeout expires.
+	w, err := apply.WatchInventory(cmd.Context(), invClient, inv, statusWatcher, apply.InventoryWatchOptions{
+		Until:   until,
+		Timeout: r.timeout,
 	})
+	if err != nil {
+		return err
+	}
 
-	return printer.Print(eventChannel, identifiers, cancelFunc)
-}
+	// Exit here if the inventory is empty.
+	if len(w.Objects) == 0 {
+		_, _ = fmt.Fprint(cmd.OutOrStdout(), "no resources found in the inventory\n")
+		return nil
+	}
+
+	// The watch stops by itself, so the printer does not need to cancel it.
+	err = printer.Print(w.Events, w.Objects, func(*collector.ResourceStatusCollector, event.Event) {})
+	if err != nil {
+		return err
+	}
 
-// desiredStatusNotifierFunc returns an Observer function for the
-// ResourceStatusCollector that will cancel the context (using the cancelFunc)
-// when all resources have reached the desired status.
-func desiredStatusNotifierFunc(cancelFunc context.CancelFunc,
-	desired status.Status) collector.ObserverFunc {
-	return func(rsc *collector.ResourceStatusCollector, _ event.Event) {
-		var rss []*event.ResourceStatus
-		for _, rs := range rsc.ResourceStatuses {
-			rss = append(rss, rs)
-		}
-		aggStatus := aggregator.AggregateStatus(rss, desired)
-		if aggStatus == desired {
-			cancelFunc()
-		}
+	// Exit with the same codes as the apply command if the objects did not
+	// reach the desired status.
+	var timeoutErr *apply.InventoryWatchTimeoutError
+	switch err := w.Err(); {
+	case err == nil:
+		return nil
+	case stderrors.As(err, &timeoutErr):
+		return &flagutils.RunError{Class: apply.ResultReconcileTimeout, Err: err}
+	case stderrors.Is(err, context.Canceled):
+		return &flagutils.RunError{Class: apply.ResultCancelled, Err: err}
+	default:
+		return err
 	}
 }
 
-// allKnownNotifierFunc returns an Observer function for the
-// ResourceStatusCollector that will cancel the context (using the cancelFunc)
-// when all resources have a known status.
-func allKnownNotifierFunc(cancelFunc context.CancelFunc) collector.ObserverFunc {
-	return func(rsc *collector.ResourceStatusCollector, _ event.Event) {
-		for _, rs := range rsc.ResourceStatuses {
-			if rs.Status == status.UnknownStatus {
-				return
-			}
-		}
-		cancelFunc()
+// statusWatcher returns the StatusWatcher used to watch the objects: the
+// DefaultStatusWatcher if the watch flag is set, or a PollingStatusWatcher
+// that polls at the poll period otherwise.
+func (r *Runner) statusWatcher() (watcher.StatusWatcher, error) {
+	if r.watch {
+		return r.watcherFactoryFunc(r.factory)
 	}
+	statusPoller, err := r.pollerFactoryFunc(r.factory)
+	if err != nil {
+		return nil, err
+	}
+	return &watcher.PollingStatusWatcher{
+		Poller:       statusPoller,
+		PollInterval: r.period,
+	}, nil
 }
 
 func pollerFactoryFunc(f cmdutil.Factory) (poller.Poller, error) {
 	return polling.NewStatusPollerFromFactory(f, polling.Options{})
 }
+
+func watcherFactoryFunc(f cmdutil.Factory) (watcher.StatusWatcher, error) {
+	dynamicClient, err := f.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := f.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	return watcher.NewDefaultStatusWatcher(dynamicClient, mapper), nil
+}
@@ -6,6 +6,7 @@ package status
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -16,10 +17,12 @@ import (
 	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"sigs.k8s.io/cli-utils/pkg/apply/poller"
+	"sigs.k8s.io/cli-utils/pkg/errors"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
 	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
 	"sigs.k8s.io/cli-utils/pkg/manifestreader"
 	"sigs.k8s.io/cli-utils/pkg/object"
 )
@@ -55,16 +58,19 @@ metadata:
 
 func TestCommand(t *testing.T) {
 	testCases := map[string]struct {
-		pollUntil      string
-		printer        string
-		timeout        time.Duration
-		input          string
-		inventory      object.ObjMetadataSet
-		events         []pollevent.Event
-		expectedErrMsg string
-		expectedOutput string
+		pollUntil        string
+		watch            bool
+		printer          string
+		timeout          time.Duration
+		input            string
+		inventory        object.ObjMetadataSet
+		events           []pollevent.Event
+		expectedErrMsg   string
+		expectedExitCode int
+		expectedOutput   string
 	}{
 		"no inventory in live state": {
+			pollUntil:      "known",
 			input:          inventoryTemplate,
 			expectedOutput: "no resources found in the inventory\n",
 		},
@@ -179,6 +185,68 @@ statefulset.apps/bar is NotFound: notFound
 deployment.apps/foo is NotFound: notFound
 `,
 		},
+		"watch until all current": {
+			pollUntil: "current",
+			watch:     true,
+			printer:   "events",
+			input:     inventoryTemplate,
+			inventory: object.ObjMetadataSet{
+				depObject,
+				stsObject,
+			},
+			events: []pollevent.Event{
+				{
+					Type: pollevent.ResourceUpdateEvent,
+					Resource: &pollevent.ResourceStatus{
+						Identifier: depObject,
+						Status:     status.CurrentStatus,
+						Message:    "current",
+					},
+				},
+				{
+					Type: pollevent.ResourceUpdateEvent,
+					Resource: &pollevent.ResourceStatus{
+						Identifier: stsObject,
+						Status:     status.CurrentStatus,
+						Message:    "current",
+					},
+				},
+			},
+			expectedOutput: `
+deployment.apps/foo is Current: current
+statefulset.apps/bar is Current: current
+`,
+		},
+		"timeout before all current": {
+			pollUntil: "current",
+			printer:   "events",
+			timeout:   time.Second,
+			input:     inventoryTemplate,
+			inventory: object.ObjMetadataSet{
+				depObject,
+				stsObject,
+			},
+			events: []pollevent.Event{
+				{
+					Type: pollevent.ResourceUpdateEvent,
+					Resource: &pollevent.ResourceStatus{
+						Identifier: depObject,
+						Status:     status.CurrentStatus,
+						Message:    "current",
+					},
+				},
+				{
+					Type: pollevent.ResourceUpdateEvent,
+					Resource: &pollevent.ResourceStatus{
+						Identifier: stsObject,
+						Status:     status.InProgressStatus,
+						Message:    "inProgress",
+					},
+				},
+			},
+			expectedErrMsg:   "timeout after 1s waiting for the inventory objects",
+			expectedExitCode: errors.ReconcileTimeoutExitCode,
+		},
 		"forever with timeout": {
 			pollUntil: "forever",
 			printer:   "events",
@@ -224,10 +292,17 @@ deployment.apps/foo is InProgress: inProgress
 				invFactory: inventory.FakeClientFactory(tc.inventory),
 				loader:     loader,
 				pollerFactoryFunc: func(c cmdutil.Factory) (poller.Poller, error) {
+					if tc.watch {
+						return nil, fmt.Errorf("unexpected poller in watch mode")
+					}
 					return &fakePoller{tc.events}, nil
 				},
+				watcherFactoryFunc: func(c cmdutil.Factory) (watcher.StatusWatcher, error) {
+					return &watcher.PollingStatusWatcher{Poller: &fakePoller{tc.events}}, nil
+				},
 
 				pollUntil: tc.pollUntil,
+				watch:     tc.watch,
 				output:    tc.printer,
 				timeout:   tc.timeout,
 			}
@@ -247,6 +322,9 @@ deployment.apps/foo is InProgress: inProgress
 					t.FailNow()
 				}
 				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				if tc.expectedExitCode != 0 {
+					assert.Equal(t, tc.expectedExitCode, errors.ExitCode(err))
+				}
 				return
 			}
 
@@ -77,6 +77,20 @@ type ObjectStatus struct {
 	// Generation is not available for deleted objects.
 	// +optional
 	Generation int64 `json:"generation,omitempty"`
+
+	// Tombstone is set if the object was removed from the set of applied
+	// objects and deleted, but is still referenced by the inventory.
+	// +optional
+	Tombstone *Tombstone `json:"tombstone,omitempty"`
+}
+
+// Tombstone records the removal of an object from the set of applied objects.
+type Tombstone struct {
+	// RemovedAt is the time the object was removed.
+	RemovedAt metav1.Time `json:"removedAt"`
+	// SpecHash is a hash of the last known object spec, before removal.
+	// +optional
+	SpecHash string `json:"specHash,omitempty"`
 }
 
 //nolint:revive // consistent prefix improves tab-completion for enums
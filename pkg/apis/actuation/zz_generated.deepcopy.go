@@ -55,7 +55,9 @@ func (in *InventoryStatus) DeepCopyInto(out *InventoryStatus) {
 	if in.Objects != nil {
 		in, out := &in.Objects, &out.Objects
 		*out = make([]ObjectStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -90,6 +92,11 @@ func (in *ObjectReference) DeepCopy() *ObjectReference {
 func (in *ObjectStatus) DeepCopyInto(out *ObjectStatus) {
 	*out = *in
 	out.ObjectReference = in.ObjectReference
+	if in.Tombstone != nil {
+		in, out := &in.Tombstone, &out.Tombstone
+		*out = new(Tombstone)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -102,3 +109,20 @@ func (in *ObjectStatus) DeepCopy() *ObjectStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tombstone) DeepCopyInto(out *Tombstone) {
+	*out = *in
+	in.RemovedAt.DeepCopyInto(&out.RemovedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tombstone.
+func (in *Tombstone) DeepCopy() *Tombstone {
+	if in == nil {
+		return nil
+	}
+	out := new(Tombstone)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -8,12 +8,15 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
-	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
@@ -23,6 +26,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/apply/prune"
 	"sigs.k8s.io/cli-utils/pkg/apply/solver"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/apply/verify"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
@@ -46,51 +50,157 @@ type Applier struct {
 	invClient     inventory.Client
 	client        dynamic.Interface
 	openAPIGetter discovery.OpenAPISchemaInterface
+	discoClient   discovery.ServerResourcesInterface
 	mapper        meta.RESTMapper
 	infoHelper    info.Helper
+	pipeline      *Pipeline
+	// rateLimitEvent, if not nil, is sent at the start of each run.
+	rateLimitEvent *event.RateLimitEvent
+	// resourceCache, if not nil, is shared by all runs. Otherwise, each run
+	// uses a new ResourceCacheMap.
+	resourceCache cache.ResourceCache
+	// fieldManager, if not empty, is the default field manager for
+	// server-side apply.
+	fieldManager string
+	// logger, if set, is used instead of klog, unless the context passed to
+	// Run has a logger.
+	logger logr.Logger
 }
 
-// prepareObjects returns the set of objects to apply and to prune or
-// an error if one occurred.
-func (a *Applier) prepareObjects(localInv inventory.Info, localObjs object.UnstructuredSet,
-	o ApplierOptions) (object.UnstructuredSet, object.UnstructuredSet, error) {
+// prepareObjects returns the set of objects to apply, to prune, and to
+// delete on apply, or an error if one occurred. Objects to delete on apply
+// are retrieved from the cluster.
+func (a *Applier) prepareObjects(logger logr.Logger, localInv inventory.Info, localObjs object.UnstructuredSet,
+	o ApplierOptions) (object.UnstructuredSet, object.UnstructuredSet, object.UnstructuredSet, error) {
 	if localInv == nil {
-		return nil, nil, fmt.Errorf("the local inventory can't be nil")
+		return nil, nil, nil, fmt.Errorf("the local inventory can't be nil")
 	}
 	if err := inventory.ValidateNoInventory(localObjs); err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
+	localObjs, deleteIds := splitDeleteObjects(localObjs)
 	// Add the inventory annotation to the resources being applied.
 	for _, localObj := range localObjs {
 		inventory.AddInventoryIDAnnotation(localObj, localInv)
 	}
 	// If the inventory uses the Name strategy and an inventory ID is provided,
 	// verify that the existing inventory object (if there is one) has an ID
-	// label that matches.
-	// TODO(seans): This inventory id validation should happen in destroy and status.
+	// label that matches, or that the inventory policy allows adopting it.
+	// Clients that implement inventory.Validator have already validated the
+	// inventory in plan.
+	// TODO(seans): This inventory id validation should happen in status.
 	if localInv.Strategy() == inventory.NameStrategy && localInv.ID() != "" {
 		prevInvObjs, err := a.invClient.GetClusterInventoryObjs(localInv)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 		if len(prevInvObjs) > 1 {
 			panic(fmt.Errorf("found %d inv objects with Name strategy", len(prevInvObjs)))
 		}
 		if len(prevInvObjs) == 1 {
-			invObj := prevInvObjs[0]
-			val := invObj.GetLabels()[common.InventoryLabel]
-			if val != localInv.ID() {
-				return nil, nil, fmt.Errorf("inventory-id of inventory object in cluster doesn't match provided id %q", localInv.ID())
+			if _, err := inventory.CanUpdateInventory(localInv, prevInvObjs[0], o.InventoryPolicy); err != nil {
+				return nil, nil, nil, err
 			}
 		}
 	}
 	pruneObjs, err := a.pruner.GetPruneObjs(localInv, localObjs, prune.Options{
 		DryRunStrategy: o.DryRunStrategy,
 	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(deleteIds) == 0 {
+		return localObjs, pruneObjs, nil, nil
+	}
+	// Objects to delete on apply are deleted once, even if they are also
+	// in the inventory.
+	var keepPruneObjs object.UnstructuredSet
+	for _, obj := range pruneObjs {
+		if !deleteIds.Contains(object.UnstructuredToObjMetadata(obj)) {
+			keepPruneObjs = append(keepPruneObjs, obj)
+		}
+	}
+	deleteObjs, err := a.pruner.GetClusterObjs(deleteIds)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return localObjs, keepPruneObjs, deleteObjs, nil
+}
+
+// splitDeleteObjects returns the objects to apply, and the ids of the
+// objects with the on-apply delete annotation, which are deleted instead.
+func splitDeleteObjects(objs object.UnstructuredSet) (object.UnstructuredSet, object.ObjMetadataSet) {
+	var applyObjs object.UnstructuredSet
+	var deleteIds object.ObjMetadataSet
+	for _, obj := range objs {
+		if deleteOnApply(obj) {
+			deleteIds = append(deleteIds, object.UnstructuredToObjMetadata(obj))
+		} else {
+			applyObjs = append(applyObjs, obj)
+		}
+	}
+	if len(deleteIds) == 0 {
+		return objs, nil
+	}
+	return applyObjs, deleteIds
+}
+
+// deleteOnApply returns true if the object has the on-apply delete annotation.
+func deleteOnApply(obj *unstructured.Unstructured) bool {
+	for annotation, value := range obj.GetAnnotations() {
+		if common.DeleteOnApply(annotation, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// legacyPruneObjects finds the objects previously applied with
+// `kubectl apply --prune`, and adds the ones that are no longer being applied
+// to the prune objects, with the owning-inventory annotation of the
+// inventory. Nothing is changed in the cluster: the returned legacy objects
+// are adopted by the LegacyAdoptTask.
+func (a *Applier) legacyPruneObjects(ctx context.Context, logger logr.Logger, localInv inventory.Info, localObjs, pruneObjs object.UnstructuredSet,
+	set inventory.LegacyPruneSet) (object.UnstructuredSet, object.UnstructuredSet, error) {
+	legacyObjs, err := inventory.FindLegacyPruneObjects(ctx, a.client, a.mapper, set)
 	if err != nil {
 		return nil, nil, err
 	}
-	return localObjs, pruneObjs, nil
+	// Convert copies, so the legacy objects still need to be adopted.
+	copies := make(object.UnstructuredSet, len(legacyObjs))
+	for i, obj := range legacyObjs {
+		copies[i] = obj.DeepCopy()
+	}
+	converted, err := inventory.ConvertLegacyPruneObjects(ctx, a.client, a.mapper,
+		localInv, copies, common.DryRunClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	skipIds := object.UnstructuredSetToObjMetadataSet(localObjs).
+		Union(object.UnstructuredSetToObjMetadataSet(pruneObjs))
+	for _, obj := range converted {
+		if skipIds.Contains(object.UnstructuredToObjMetadata(obj)) {
+			continue
+		}
+		pruneObjs = append(pruneObjs, obj)
+	}
+	logger.V(4).Info("found legacy objects", "count", len(legacyObjs), "prune", len(converted))
+	return pruneObjs, legacyObjs, nil
+}
+
+// newResourceCache returns the ResourceCache for a run. If a shared cache was
+// provided, the objects to apply and prune are invalidated, so that their
+// status from previous runs is not used.
+func (a *Applier) newResourceCache(objSets ...object.UnstructuredSet) cache.ResourceCache {
+	if a.resourceCache == nil {
+		return cache.NewResourceCacheMap()
+	}
+	for _, objs := range objSets {
+		for _, obj := range objs {
+			a.resourceCache.Remove(object.UnstructuredToObjMetadata(obj))
+		}
+	}
+	return a.resourceCache
 }
 
 // Run performs the Apply step. This happens asynchronously with updates
@@ -102,115 +212,49 @@ func (a *Applier) prepareObjects(localInv inventory.Info, localObjs object.Unstr
 // cancellation or timeout will only affect how long we Wait for the
 // resources to become current.
 func (a *Applier) Run(ctx context.Context, invInfo inventory.Info, objects object.UnstructuredSet, options ApplierOptions) <-chan event.Event {
-	klog.V(4).Infof("apply run for %d objects", len(objects))
+	return a.run(ctx, invInfo, objects, options, false)
+}
+
+// run applies and prunes the objects. If pruneOnly is true, the objects are
+// not applied, only the previously applied objects that are no longer in the
+// objects are pruned.
+func (a *Applier) run(ctx context.Context, invInfo inventory.Info, objects object.UnstructuredSet,
+	options ApplierOptions, pruneOnly bool) <-chan event.Event {
+	logger := runLogger(ctx, a.logger, invInfo)
+	ctx = logr.NewContext(ctx, logger)
+	logger.V(4).Info("apply run starting", "objects", len(objects), "pruneOnly", pruneOnly)
 	eventChannel := make(chan event.Event)
 	setDefaults(&options)
+	if options.ServerSideOptions.FieldManager == "" {
+		options.ServerSideOptions.FieldManager = a.fieldManager
+	}
 	go func() {
 		defer close(eventChannel)
-		// Validate the resources to make sure we catch those problems early
-		// before anything has been updated in the cluster.
-		vCollector := &validation.Collector{}
-		validator := &validation.Validator{
-			Collector: vCollector,
-			Mapper:    a.mapper,
+		if a.rateLimitEvent != nil {
+			eventChannel <- event.Event{
+				Type:           event.RateLimitType,
+				RateLimitEvent: *a.rateLimitEvent,
+			}
 		}
-		validator.Validate(objects)
-
-		// Decide which objects to apply and which to prune
-		applyObjs, pruneObjs, err := a.prepareObjects(invInfo, objects, options)
+		sendVerificationEvents(eventChannel, options.Verified)
+		p, err := a.plan(ctx, logger, invInfo, objects, options, eventChannel, pruneOnly)
 		if err != nil {
 			handleError(eventChannel, err)
 			return
 		}
-		klog.V(4).Infof("calculated %d apply objs; %d prune objs", len(applyObjs), len(pruneObjs))
-
-		// Build a TaskContext for passing info between tasks
-		resourceCache := cache.NewResourceCacheMap()
-		taskContext := taskrunner.NewTaskContext(eventChannel, resourceCache)
-
-		// Fetch the queue (channel) of tasks that should be executed.
-		klog.V(4).Infoln("applier building task queue...")
-		// Build list of apply validation filters.
-		applyFilters := []filter.ValidationFilter{
-			filter.InventoryPolicyApplyFilter{
-				Client:    a.client,
-				Mapper:    a.mapper,
-				Inv:       invInfo,
-				InvPolicy: options.InventoryPolicy,
-			},
-			filter.DependencyFilter{
-				TaskContext:       taskContext,
-				ActuationStrategy: actuation.ActuationStrategyApply,
-				DryRunStrategy:    options.DryRunStrategy,
-			},
-		}
-		// Build list of prune validation filters.
-		pruneFilters := []filter.ValidationFilter{
-			filter.PreventRemoveFilter{},
-			filter.InventoryPolicyPruneFilter{
-				Inv:       invInfo,
-				InvPolicy: options.InventoryPolicy,
-			},
-			filter.LocalNamespacesFilter{
-				LocalNamespaces: localNamespaces(invInfo, object.UnstructuredSetToObjMetadataSet(objects)),
-			},
-			filter.DependencyFilter{
-				TaskContext:       taskContext,
-				ActuationStrategy: actuation.ActuationStrategyDelete,
-				DryRunStrategy:    options.DryRunStrategy,
-			},
-		}
-		// Build list of apply mutators.
-		applyMutators := []mutator.Interface{
-			&mutator.ApplyTimeMutator{
-				Client:        a.client,
-				Mapper:        a.mapper,
-				ResourceCache: resourceCache,
-			},
-		}
-		taskBuilder := &solver.TaskQueueBuilder{
-			Pruner:        a.pruner,
-			DynamicClient: a.client,
-			OpenAPIGetter: a.openAPIGetter,
-			InfoHelper:    a.infoHelper,
-			Mapper:        a.mapper,
-			InvClient:     a.invClient,
-			Collector:     vCollector,
-			ApplyFilters:  applyFilters,
-			ApplyMutators: applyMutators,
-			PruneFilters:  pruneFilters,
-		}
-		opts := solver.Options{
-			ServerSideOptions:      options.ServerSideOptions,
-			ReconcileTimeout:       options.ReconcileTimeout,
-			Destroy:                false,
-			Prune:                  !options.NoPrune,
-			DryRunStrategy:         options.DryRunStrategy,
-			PrunePropagationPolicy: options.PrunePropagationPolicy,
-			PruneTimeout:           options.PruneTimeout,
-			InventoryPolicy:        options.InventoryPolicy,
-		}
-
-		// Build the ordered set of tasks to execute.
-		taskQueue := taskBuilder.
-			WithApplyObjects(applyObjs).
-			WithPruneObjects(pruneObjs).
-			WithInventory(invInfo).
-			Build(taskContext, opts)
 
-		klog.V(4).Infof("validation errors: %d", len(vCollector.Errors))
-		klog.V(4).Infof("invalid objects: %d", len(vCollector.InvalidIds))
+		logger.V(4).Info("validated objects", "errors", len(p.collector.Errors), "invalid", len(p.collector.InvalidIds))
 
 		// Handle validation errors
 		switch options.ValidationPolicy {
 		case validation.ExitEarly:
-			err = vCollector.ToError()
+			err = p.collector.ToError()
 			if err != nil {
 				handleError(eventChannel, err)
 				return
 			}
 		case validation.SkipInvalid:
-			for _, err := range vCollector.Errors {
+			for _, err := range p.collector.Errors {
 				handleValidationError(eventChannel, err)
 			}
 		default:
@@ -219,8 +263,8 @@ func (a *Applier) Run(ctx context.Context, invInfo inventory.Info, objects objec
 		}
 
 		// Register invalid objects to be retained in the inventory, if present.
-		for _, id := range vCollector.InvalidIds {
-			taskContext.AddInvalidObject(id)
+		for _, id := range p.collector.InvalidIds {
+			p.taskContext.AddInvalidObject(id)
 		}
 
 		// Send event to inform the caller about the resources that
@@ -228,21 +272,28 @@ func (a *Applier) Run(ctx context.Context, invInfo inventory.Info, objects objec
 		eventChannel <- event.Event{
 			Type: event.InitType,
 			InitEvent: event.InitEvent{
-				ActionGroups: taskQueue.ToActionGroups(),
+				ActionGroups: p.taskQueue.ToActionGroups(),
 			},
 		}
 		// Create a new TaskStatusRunner to execute the taskQueue.
-		klog.V(4).Infoln("applier building TaskStatusRunner...")
-		allIds := object.UnstructuredSetToObjMetadataSet(append(applyObjs, pruneObjs...))
+		logger.V(4).Info("applier building TaskStatusRunner")
+		applyIds := object.UnstructuredSetToObjMetadataSet(p.applyObjs)
+		if !options.WaitForStatuslessObjects {
+			// Objects without status are marked Current by the ApplyTask.
+			applyIds = applyIds.Diff(object.StatuslessObjects(applyIds))
+		}
+		allIds := applyIds.Union(object.UnstructuredSetToObjMetadataSet(p.pruneObjs)).
+			Union(object.UnstructuredSetToObjMetadataSet(p.deleteObjs))
 		statusWatcher := a.statusWatcher
 		// Disable watcher for dry runs
-		if opts.DryRunStrategy.ClientOrServerDryRun() {
+		if options.DryRunStrategy.ClientOrServerDryRun() {
 			statusWatcher = watcher.BlindStatusWatcher{}
 		}
 		runner := taskrunner.NewTaskStatusRunner(allIds, statusWatcher)
-		klog.V(4).Infoln("applier running TaskStatusRunner...")
-		err = runner.Run(ctx, taskContext, taskQueue.ToChannel(), taskrunner.Options{
-			EmitStatusEvents: options.EmitStatusEvents,
+		logger.V(4).Info("applier running TaskStatusRunner")
+		err = runner.Run(ctx, p.taskContext, p.taskQueue.ToChannel(), taskrunner.Options{
+			EmitStatusEvents:  options.EmitStatusEvents,
+			CancelGracePeriod: options.CancelGracePeriod,
 		})
 		if err != nil {
 			handleError(eventChannel, err)
@@ -252,6 +303,257 @@ func (a *Applier) Run(ctx context.Context, invInfo inventory.Info, objects objec
 	return eventChannel
 }
 
+// Plan returns the graph of the tasks that Run would run for the objects, to
+// show how the objects are split into apply, prune, and wait tasks. Nothing is
+// applied or pruned. With the ExitEarly ValidationPolicy, validation errors
+// are returned. Otherwise, invalid objects are left out of the tasks.
+func (a *Applier) Plan(ctx context.Context, invInfo inventory.Info, objects object.UnstructuredSet, options ApplierOptions) (*solver.TaskGraph, error) {
+	logger := runLogger(ctx, a.logger, invInfo)
+	logger.V(4).Info("apply plan starting", "objects", len(objects))
+	setDefaults(&options)
+	if options.ServerSideOptions.FieldManager == "" {
+		options.ServerSideOptions.FieldManager = a.fieldManager
+	}
+	// No events are sent while building the task queue.
+	p, err := a.plan(ctx, logger, invInfo, objects, options, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	switch options.ValidationPolicy {
+	case validation.ExitEarly:
+		if err := p.collector.ToError(); err != nil {
+			return nil, err
+		}
+	case validation.SkipInvalid:
+	default:
+		return nil, fmt.Errorf("invalid ValidationPolicy: %q", options.ValidationPolicy)
+	}
+	return solver.NewTaskGraph(p.taskQueue, p.taskContext.Graph()), nil
+}
+
+// applyPlan is the queue of tasks of a run, with the state needed to run it.
+type applyPlan struct {
+	taskContext *taskrunner.TaskContext
+	taskQueue   *solver.TaskQueue
+	collector   *validation.Collector
+	applyObjs   object.UnstructuredSet
+	pruneObjs   object.UnstructuredSet
+	deleteObjs  object.UnstructuredSet
+}
+
+// plan validates the objects, decides which objects to apply and which to
+// prune, and builds the queue of tasks of the run. The tasks send their events
+// on the eventChannel. Nothing is changed in the cluster until the tasks run.
+// If pruneOnly is true, the objects are only used to decide which objects to
+// prune, and are not applied.
+func (a *Applier) plan(ctx context.Context, logger logr.Logger, invInfo inventory.Info, objects object.UnstructuredSet,
+	options ApplierOptions, eventChannel chan event.Event, pruneOnly bool) (*applyPlan, error) {
+	if err := options.ServerSideOptions.ValidateFieldValidation(); err != nil {
+		return nil, err
+	}
+	if err := validateInventory(a.invClient, invInfo, options.InventoryPolicy); err != nil {
+		return nil, err
+	}
+	// Validate the resources to make sure we catch those problems early
+	// before anything has been updated in the cluster.
+	vCollector := &validation.Collector{}
+	validator := &validation.Validator{
+		Collector: vCollector,
+		Mapper:    a.mapper,
+	}
+	validator.Validate(objects)
+	a.pipeline.Validate(objects, vCollector)
+
+	// Decide which objects to apply and which to prune
+	applyObjs, pruneObjs, deleteObjs, err := a.prepareObjects(logger, invInfo, objects, options)
+	if err != nil {
+		return nil, err
+	}
+	// Find the objects previously applied with `kubectl apply --prune`, to
+	// adopt and prune them.
+	var legacyObjs object.UnstructuredSet
+	if options.LegacyPruneSet != nil && options.pruneEnabled() {
+		pruneObjs, legacyObjs, err = a.legacyPruneObjects(ctx, logger, invInfo, applyObjs, pruneObjs, *options.LegacyPruneSet)
+		if err != nil {
+			return nil, err
+		}
+	}
+	logger.V(4).Info("calculated objects", "apply", len(applyObjs), "prune", len(pruneObjs), "delete", len(deleteObjs))
+
+	// Skip the objects that succeeded in the previous run, if requested
+	var succeededObjs object.UnstructuredSet
+	switch {
+	case pruneOnly:
+		// Keep the objects in the inventory without applying them.
+		succeededObjs, err = a.retainObjects(invInfo, applyObjs)
+		if err != nil {
+			return nil, err
+		}
+		applyObjs = nil
+	case options.RetryFailed:
+		applyObjs, succeededObjs, err = a.retryObjects(logger, invInfo, applyObjs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Build a TaskContext for passing info between tasks
+	resourceCache := a.newResourceCache(applyObjs, pruneObjs, deleteObjs)
+	taskContext := taskrunner.NewTaskContext(eventChannel, resourceCache)
+	taskContext.SetLogger(logger)
+	if options.RecordTiming {
+		taskContext.EnableTiming()
+	}
+
+	// Fetch the queue (channel) of tasks that should be executed.
+	logger.V(4).Info("applier building task queue")
+	// Build list of apply validation filters.
+	defaultApplyFilters := []filter.ValidationFilter{
+		filter.IgnoreApplyFilter{},
+	}
+	if options.PreValidate && options.ValidationPolicy == validation.SkipInvalid {
+		// Skip the objects that failed the validate task.
+		defaultApplyFilters = append(defaultApplyFilters, filter.InvalidObjectFilter{
+			TaskContext: taskContext,
+		})
+	}
+	selectorFilter, selected := options.selectorFilter()
+	if selected {
+		defaultApplyFilters = append(defaultApplyFilters, selectorFilter)
+	}
+	defaultApplyFilters = append(defaultApplyFilters, filter.InventoryPolicyApplyFilter{
+		Client:    a.client,
+		Mapper:    a.mapper,
+		Inv:       invInfo,
+		InvPolicy: options.InventoryPolicy,
+	})
+	if options.UpdateOnly {
+		defaultApplyFilters = append(defaultApplyFilters, filter.NoCreateApplyFilter{
+			Client: a.client,
+			Mapper: a.mapper,
+		})
+	}
+	defaultApplyFilters = append(defaultApplyFilters, filter.DependencyFilter{
+		TaskContext:       taskContext,
+		ActuationStrategy: actuation.ActuationStrategyApply,
+		DryRunStrategy:    options.DryRunStrategy,
+	})
+	applyFilters := a.pipeline.ApplyFilters(defaultApplyFilters...)
+	// Build list of prune validation filters. Namespaces of the objects to
+	// delete on apply do not prevent deleting their namespace.
+	localObjs, _ := splitDeleteObjects(objects)
+	defaultPruneFilters := []filter.ValidationFilter{
+		filter.PreventRemoveFilter{},
+	}
+	if selected {
+		defaultPruneFilters = append(defaultPruneFilters, selectorFilter)
+	}
+	defaultPruneFilters = append(defaultPruneFilters,
+		filter.InventoryPolicyPruneFilter{
+			Inv:       invInfo,
+			InvPolicy: options.InventoryPolicy,
+		},
+		filter.LocalNamespacesFilter{
+			LocalNamespaces: localNamespaces(invInfo, object.UnstructuredSetToObjMetadataSet(localObjs)),
+		},
+	)
+	if len(options.PruneAllowedGroupKinds) > 0 || len(options.PruneDeniedGroupKinds) > 0 {
+		defaultPruneFilters = append(defaultPruneFilters, filter.PruneGroupKindFilter{
+			Allowed: options.PruneAllowedGroupKinds,
+			Denied:  options.PruneDeniedGroupKinds,
+		})
+	}
+	if !options.ForcePruneCRDs {
+		defaultPruneFilters = append(defaultPruneFilters, filter.CRDInstancesFilter{
+			Client: a.client,
+			Mapper: a.mapper,
+		})
+	}
+	if !options.ForcePruneNamespaces {
+		defaultPruneFilters = append(defaultPruneFilters, filter.NamespaceForeignObjectsFilter{
+			Client:    a.client,
+			Discovery: a.discoClient,
+			Inv:       invInfo,
+		})
+	}
+	defaultPruneFilters = append(defaultPruneFilters, filter.DependencyFilter{
+		TaskContext:       taskContext,
+		ActuationStrategy: actuation.ActuationStrategyDelete,
+		DryRunStrategy:    options.DryRunStrategy,
+	})
+	pruneFilters := a.pipeline.PruneFilters(defaultPruneFilters...)
+	pruneFilters = append(pruneFilters, options.PruneFilters...)
+	// Build list of apply mutators.
+	applyMutators := a.pipeline.ApplyMutators(
+		&mutator.ApplyTimeMutator{
+			Client:        a.client,
+			Mapper:        a.mapper,
+			ResourceCache: resourceCache,
+		},
+	)
+	applyMutators = append(applyMutators, options.ApplyMutators...)
+	taskBuilder := &solver.TaskQueueBuilder{
+		Pruner:        a.pruner,
+		DynamicClient: a.client,
+		OpenAPIGetter: a.openAPIGetter,
+		InfoHelper:    a.infoHelper,
+		Mapper:        a.mapper,
+		InvClient:     a.invClient,
+		Collector:     vCollector,
+		ApplyFilters:  applyFilters,
+		ApplyMutators: applyMutators,
+		PruneFilters:  pruneFilters,
+		Logger:        logger,
+	}
+	opts := solver.Options{
+		ServerSideOptions:        options.ServerSideOptions,
+		ReconcileTimeout:         options.ReconcileTimeout,
+		Destroy:                  false,
+		Prune:                    options.pruneEnabled(),
+		DryRunStrategy:           options.DryRunStrategy,
+		PrunePropagationPolicy:   options.PrunePropagationPolicy,
+		PruneTimeout:             options.PruneTimeout,
+		InventoryPolicy:          options.InventoryPolicy,
+		WaitSummaryInterval:      options.WaitSummaryInterval,
+		WaitForStatuslessObjects: options.WaitForStatuslessObjects,
+		InventoryTombstones:      options.InventoryTombstones,
+		ApplyConcurrency:         options.ApplyConcurrency,
+		RetryPolicy:              options.RetryPolicy,
+		WaitTimeouts:             options.WaitTimeouts,
+		PreValidate:              options.PreValidate,
+		ValidationPolicy:         options.ValidationPolicy,
+		PruneFirst:               options.PruneFirst,
+	}
+
+	// Build the ordered set of tasks to execute.
+	taskQueue := taskBuilder.
+		WithApplyObjects(applyObjs).
+		WithSucceededObjects(succeededObjs).
+		WithPruneObjects(pruneObjs).
+		WithDeleteObjects(deleteObjs).
+		WithLegacyObjects(legacyObjs).
+		WithInventory(invInfo).
+		Build(taskContext, opts)
+	if pruneOnly {
+		if err := a.restoreObjectStatus(logger, invInfo, taskContext.InventoryManager(), succeededObjs); err != nil {
+			return nil, err
+		}
+	}
+
+	// Allow the pipeline to customize the task queue.
+	if err := a.pipeline.ModifyTaskQueue(taskQueue); err != nil {
+		return nil, err
+	}
+	return &applyPlan{
+		taskContext: taskContext,
+		taskQueue:   taskQueue,
+		collector:   vCollector,
+		applyObjs:   applyObjs,
+		pruneObjs:   pruneObjs,
+		deleteObjs:  deleteObjs,
+	}, nil
+}
+
 type ApplierOptions struct {
 	// Encapsulates the fields for server-side apply.
 	ServerSideOptions common.ServerSideOptions
@@ -265,8 +567,14 @@ type ApplierOptions struct {
 	// emitted on the eventChannel to the caller.
 	EmitStatusEvents bool
 
-	// NoPrune defines whether pruning of previously applied
-	// objects should happen after apply.
+	// Prune defines whether previously applied objects, that are no
+	// longer in the set of objects being applied, should be pruned
+	// (deleted) after apply. Pruning is disabled unless explicitly enabled.
+	Prune PrunePolicy
+
+	// NoPrune disables pruning, even if Prune is PruneEnabled.
+	//
+	// Deprecated: Pruning is disabled unless Prune is PruneEnabled.
 	NoPrune bool
 
 	// DryRunStrategy defines whether changes should actually be performed,
@@ -283,11 +591,210 @@ type ApplierOptions struct {
 	// wait.
 	PruneTimeout time.Duration
 
+	// PruneFirst defines whether the obsolete objects are pruned, and waited
+	// on to be deleted, before the objects are applied, instead of after.
+	// This is needed to replace an object with another that conflicts with
+	// it, like a Service that takes the port of a renamed Service. The
+	// inventory is only updated at the end of the run, as usual.
+	PruneFirst bool
+
+	// WaitTimeouts overrides the ReconcileTimeout or PruneTimeout of
+	// individual wait tasks, by task name (ex: "wait-1"). The task names are
+	// listed by Plan. Individual objects may also override the timeout with
+	// the cli-utils.sigs.k8s.io/reconcile-timeout annotation.
+	WaitTimeouts map[string]time.Duration
+
+	// PruneAllowedGroupKinds restricts pruning to objects of these
+	// GroupKinds. Objects of other GroupKinds are skipped. If empty, objects
+	// of any GroupKind may be pruned.
+	PruneAllowedGroupKinds []schema.GroupKind
+
+	// PruneDeniedGroupKinds prevents pruning objects of these GroupKinds.
+	// Objects of these GroupKinds are skipped, even if they are also in
+	// PruneAllowedGroupKinds.
+	PruneDeniedGroupKinds []schema.GroupKind
+
+	// ForcePruneCRDs allows pruning CustomResourceDefinitions that still
+	// have custom resources in the cluster. By default, these CRDs are
+	// skipped, because deleting a CRD also deletes all of its custom
+	// resources, including ones managed by other inventories.
+	ForcePruneCRDs bool
+
+	// ForcePruneNamespaces allows pruning Namespaces that contain objects
+	// not owned by the inventory. By default, these Namespaces are skipped,
+	// because deleting a Namespace also deletes all the objects in it.
+	ForcePruneNamespaces bool
+
 	// InventoryPolicy defines the inventory policy of apply.
 	InventoryPolicy inventory.Policy
 
 	// ValidationPolicy defines how to handle invalid objects.
 	ValidationPolicy validation.Policy
+
+	// WaitSummaryInterval defines how often a WaitSummaryEvent should be
+	// emitted, listing the objects that are still being waited on.
+	// If this is not provided, no summary events are emitted.
+	WaitSummaryInterval time.Duration
+
+	// WaitForStatuslessObjects defines whether objects without status
+	// (e.g. ConfigMaps, Secrets, and RBAC objects) should be watched until
+	// they are Current. By default, they are reported as Current as soon as
+	// they are applied, and are not watched.
+	WaitForStatuslessObjects bool
+
+	// InventoryTombstones defines whether pruned objects are recorded as
+	// tombstones in the inventory, with the removal time and the hash of the
+	// last known spec, instead of being removed from the inventory.
+	// Requires an inventory that implements inventory.TombstoneStorage.
+	InventoryTombstones bool
+
+	// LegacyPruneSet identifies objects previously applied with
+	// `kubectl apply --prune`. If set, these objects are adopted by the
+	// inventory before apply, and the ones that are no longer being applied
+	// are pruned. This allows migrating from kubectl in a single run.
+	// Objects owned by another inventory are left unchanged. During a dry
+	// run, the objects are not annotated in the cluster, so adopting the
+	// objects being applied requires an InventoryPolicy other than
+	// PolicyMustMatch.
+	LegacyPruneSet *inventory.LegacyPruneSet
+
+	// RetryFailed defines whether only the objects that were not
+	// successfully applied and reconciled by the previous run should be
+	// applied, along with the objects that depend on them. Objects that
+	// succeeded are not applied or waited on again, but are retained in the
+	// inventory. Requires the previous run to store the object status in the
+	// inventory (see inventory.StatusPolicyAll), otherwise all objects are
+	// applied.
+	RetryFailed bool
+
+	// ApplyConcurrency defines the maximum number of objects applied at the
+	// same time. Only objects without dependencies on each other are
+	// applied concurrently. Events for each object are still sent in the
+	// same order as when applying one object at a time. If this is not
+	// provided, objects are applied one at a time.
+	ApplyConcurrency int
+
+	// RetryPolicy defines how applies and prunes that fail with a transient
+	// API error (conflict, throttling, or server error) are retried. A
+	// RetryEvent is sent before each retry. If this is not provided,
+	// failures are not retried.
+	RetryPolicy common.RetryPolicy
+
+	// RecordTiming defines whether the apply, prune, and wait events include
+	// the start and end time of each object, to help find slow resources.
+	// Wait events measure the time from when the object was applied or
+	// pruned until it reached the desired status.
+	RecordTiming bool
+
+	// CancelGracePeriod enables graceful cancellation, if greater than zero.
+	// When the context is cancelled, no more tasks are started and the
+	// running task is given up to CancelGracePeriod to finish. After that,
+	// the running task skips the objects it has not applied or pruned yet.
+	// The objects of the tasks that were not started are skipped, so every
+	// object still gets an event, and the run ends with an ErrorEvent with a
+	// taskrunner.CancelledError. By default, the run stops as soon as the
+	// running task allows it, without events for the remaining objects.
+	CancelGracePeriod time.Duration
+
+	// Verified are the results of the verification of the manifests the
+	// objects were read from, like the Results of the
+	// manifestreader.VerifiedManifestReader. A VerificationEvent is sent for
+	// each of them before anything is planned or applied, so that audit logs
+	// record what was verified.
+	Verified []verify.Result
+
+	// PreValidate adds a validation phase before anything is changed in
+	// the cluster. Every object to apply is checked against the OpenAPI
+	// schema of the cluster and applied with a server-side dry-run. If any
+	// object is invalid, a ValidationEvent is sent for it. With the
+	// ExitEarly ValidationPolicy, the run then ends with an ErrorEvent
+	// before the inventory or any object is updated. With SkipInvalid, the
+	// invalid objects are skipped, and kept in the inventory if present.
+	// Objects in namespaces or of types created by the same run, and
+	// objects with apply-time mutations, are only validated when applied.
+	PreValidate bool
+
+	// UpdateOnly skips the objects that do not exist in the cluster, instead
+	// of creating them. Existing objects are updated as usual. The skipped
+	// objects get an ApplyEvent with the ApplySkipped status and a
+	// filter.CreatePreventedError, for which apierrors.IsNotFound is true.
+	// Objects with a generated name are always skipped.
+	UpdateOnly bool
+
+	// Selector restricts the run to the objects with matching labels.
+	// The other objects are neither applied nor pruned: they get an
+	// ApplyEvent or PruneEvent with the skipped status and a
+	// filter.ObjectNotSelectedError, and are retained in the inventory.
+	// Objects that depend on skipped objects are skipped as well, so the
+	// selection should include the dependencies of the selected objects,
+	// like their namespace. If nil, objects are not selected by label.
+	Selector labels.Selector
+
+	// IncludeGroupKinds restricts the run to the objects of these
+	// GroupKinds. Like with Selector, the other objects are skipped. If
+	// empty, objects of any GroupKind not in ExcludeGroupKinds are included.
+	IncludeGroupKinds []schema.GroupKind
+
+	// ExcludeGroupKinds skips the objects of these GroupKinds, like with
+	// Selector, even if they are also in IncludeGroupKinds.
+	ExcludeGroupKinds []schema.GroupKind
+
+	// PruneFilters are additional filters run on each object before it is
+	// pruned, after the default filters and the filters of the Pipeline.
+	// An object is skipped if any filter returns an error, and the error
+	// is the reason in its PruneEvent. A filter.FatalError fails the prune
+	// of the object instead. Objects deleted because of the on-apply delete
+	// annotation are filtered too.
+	PruneFilters []filter.ValidationFilter
+
+	// ApplyMutators are additional mutators run on each object right before
+	// it is applied, after the default mutators and the mutators of the
+	// Pipeline, so they see the values set by apply-time mutations. If a
+	// mutator returns an error, the apply of the object fails. Mutators
+	// must not change the identity of the object. The PreValidate phase
+	// validates the objects before they are mutated.
+	ApplyMutators []mutator.Interface
+}
+
+// selectorFilter returns the filter of the objects selected for the run, and
+// false if all objects are selected.
+func (o ApplierOptions) selectorFilter() (filter.SelectorFilter, bool) {
+	f := filter.SelectorFilter{
+		Selector: o.Selector,
+		Included: o.IncludeGroupKinds,
+		Excluded: o.ExcludeGroupKinds,
+	}
+	selected := (o.Selector != nil && !o.Selector.Empty()) ||
+		len(o.IncludeGroupKinds) > 0 || len(o.ExcludeGroupKinds) > 0
+	return f, selected
+}
+
+// pruneEnabled returns true if pruning has been explicitly enabled and not
+// disabled with the deprecated NoPrune option.
+func (o ApplierOptions) pruneEnabled() bool {
+	return o.Prune == PruneEnabled && !o.NoPrune
+}
+
+// PrunePolicy defines whether the Applier prunes previously applied objects.
+type PrunePolicy int
+
+const (
+	// PruneDisabled skips pruning. This is the default.
+	PruneDisabled PrunePolicy = iota
+	// PruneEnabled deletes previously applied objects that are no longer
+	// in the set of objects being applied.
+	PruneEnabled
+)
+
+// LegacyApplierOptions returns ApplierOptions with pruning enabled, matching
+// the behavior of the zero value ApplierOptions before pruning required
+// explicit opt-in.
+//
+// Deprecated: Use ApplierOptions with Prune set to PruneEnabled.
+func LegacyApplierOptions() ApplierOptions {
+	return ApplierOptions{
+		Prune: PruneEnabled,
+	}
 }
 
 // setDefaults set the options to the default values if they
@@ -298,6 +805,32 @@ func setDefaults(o *ApplierOptions) {
 	}
 }
 
+// sendVerificationEvents sends a VerificationEvent for each verification.
+func sendVerificationEvents(eventChannel chan event.Event, verified []verify.Result) {
+	for _, result := range verified {
+		eventChannel <- event.Event{
+			Type: event.VerificationType,
+			VerificationEvent: event.VerificationEvent{
+				Source: result.Source,
+				Digest: result.Digest,
+				Method: result.Method,
+				Signer: result.Signer,
+			},
+		}
+	}
+}
+
+// validateInventory validates the inventory, if the inventory client
+// implements inventory.Validator, so that an invalid or colliding inventory
+// ID fails the run before anything is changed in the cluster.
+func validateInventory(invClient inventory.Client, invInfo inventory.Info, policy inventory.Policy) error {
+	v, ok := invClient.(inventory.Validator)
+	if !ok {
+		return nil
+	}
+	return v.ValidateInventory(invInfo, policy)
+}
+
 func handleError(eventChannel chan event.Event, err error) {
 	eventChannel <- event.Event{
 		Type: event.ErrorType,
@@ -4,31 +4,35 @@
 package apply
 
 import (
-	"errors"
-	"fmt"
-
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/apply/cache"
 	"sigs.k8s.io/cli-utils/pkg/apply/info"
 	"sigs.k8s.io/cli-utils/pkg/apply/prune"
+	"sigs.k8s.io/cli-utils/pkg/flowcontrol"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/engine"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
 )
 
+// ApplierBuilder builds an Applier. Everything that is not provided
+// explicitly is retrieved from the factory. If no factory is provided, it is
+// built from the rest config, which allows building an Applier with only a
+// rest config:
+//
+//	applier, err := apply.NewApplierBuilder().
+//		WithRestConfig(restConfig).
+//		Build()
 type ApplierBuilder struct {
-	// factory is only used to retrieve things that have not been provided explicitly.
-	factory                      util.Factory
-	invClient                    inventory.Client
-	client                       dynamic.Interface
-	discoClient                  discovery.CachedDiscoveryInterface
-	mapper                       meta.RESTMapper
-	restConfig                   *rest.Config
-	unstructuredClientForMapping func(*meta.RESTMapping) (resource.RESTClient, error)
-	statusWatcher                watcher.StatusWatcher
+	commonBuilder
+	pipeline     *Pipeline
+	fieldManager string
 }
 
 // NewApplierBuilder returns a new ApplierBuilder.
@@ -49,69 +53,21 @@ func (b *ApplierBuilder) Build() (*Applier, error) {
 			Client:    bx.client,
 			Mapper:    bx.mapper,
 		},
-		statusWatcher: bx.statusWatcher,
-		invClient:     bx.invClient,
-		client:        bx.client,
-		openAPIGetter: bx.discoClient,
-		mapper:        bx.mapper,
-		infoHelper:    info.NewHelper(bx.mapper, bx.unstructuredClientForMapping),
+		statusWatcher:  bx.statusWatcher,
+		invClient:      bx.invClient,
+		client:         bx.client,
+		openAPIGetter:  bx.discoClient,
+		discoClient:    bx.discoClient,
+		mapper:         bx.mapper,
+		infoHelper:     info.NewHelper(bx.mapper, bx.unstructuredClientForMapping),
+		pipeline:       b.pipeline,
+		rateLimitEvent: bx.rateLimitEvent,
+		resourceCache:  bx.resourceCache,
+		fieldManager:   b.fieldManager,
+		logger:         bx.logger,
 	}, nil
 }
 
-func (b *ApplierBuilder) finalize() (*ApplierBuilder, error) {
-	bx := *b // make a copy before mutating any fields. Shallow copy is good enough.
-	var err error
-	if bx.invClient == nil {
-		return nil, errors.New("inventory client must be provided")
-	}
-	if bx.client == nil {
-		if bx.factory == nil {
-			return nil, fmt.Errorf("a factory must be provided or all other options: %v", err)
-		}
-		bx.client, err = bx.factory.DynamicClient()
-		if err != nil {
-			return nil, fmt.Errorf("error getting dynamic client: %v", err)
-		}
-	}
-	if bx.discoClient == nil {
-		if bx.factory == nil {
-			return nil, fmt.Errorf("a factory must be provided or all other options: %v", err)
-		}
-		bx.discoClient, err = bx.factory.ToDiscoveryClient()
-		if err != nil {
-			return nil, fmt.Errorf("error getting discovery client: %v", err)
-		}
-	}
-	if bx.mapper == nil {
-		if bx.factory == nil {
-			return nil, fmt.Errorf("a factory must be provided or all other options: %v", err)
-		}
-		bx.mapper, err = bx.factory.ToRESTMapper()
-		if err != nil {
-			return nil, fmt.Errorf("error getting rest mapper: %v", err)
-		}
-	}
-	if bx.restConfig == nil {
-		if bx.factory == nil {
-			return nil, fmt.Errorf("a factory must be provided or all other options: %v", err)
-		}
-		bx.restConfig, err = bx.factory.ToRESTConfig()
-		if err != nil {
-			return nil, fmt.Errorf("error getting rest config: %v", err)
-		}
-	}
-	if bx.unstructuredClientForMapping == nil {
-		if bx.factory == nil {
-			return nil, fmt.Errorf("a factory must be provided or all other options: %v", err)
-		}
-		bx.unstructuredClientForMapping = bx.factory.UnstructuredClientForMapping
-	}
-	if bx.statusWatcher == nil {
-		bx.statusWatcher = watcher.NewDefaultStatusWatcher(bx.client, bx.mapper)
-	}
-	return &bx, nil
-}
-
 func (b *ApplierBuilder) WithFactory(factory util.Factory) *ApplierBuilder {
 	b.factory = factory
 	return b
@@ -122,6 +78,14 @@ func (b *ApplierBuilder) WithInventoryClient(invClient inventory.Client) *Applie
 	return b
 }
 
+// WithInventoryClientFactory customizes the inventory backend, if the
+// inventory client is not provided explicitly. The default is a
+// ClusterClientFactory that stores the object status in the inventory.
+func (b *ApplierBuilder) WithInventoryClientFactory(invClientFactory inventory.ClientFactory) *ApplierBuilder {
+	b.invClientFactory = invClientFactory
+	return b
+}
+
 func (b *ApplierBuilder) WithDynamicClient(client dynamic.Interface) *ApplierBuilder {
 	b.client = client
 	return b
@@ -151,3 +115,67 @@ func (b *ApplierBuilder) WithStatusWatcher(statusWatcher watcher.StatusWatcher)
 	b.statusWatcher = statusWatcher
 	return b
 }
+
+// WithStatusReaders registers custom StatusReaders, used by the default
+// status watcher to compute the status of the GroupKinds they support, like
+// custom resources with bespoke status schemas. They take precedence over the
+// built-in StatusReaders. They are ignored if a status watcher is provided
+// with WithStatusWatcher.
+func (b *ApplierBuilder) WithStatusReaders(statusReaders ...engine.StatusReader) *ApplierBuilder {
+	b.statusReaders = append(b.statusReaders, statusReaders...)
+	return b
+}
+
+// WithStatusRules declares how the default status watcher computes the
+// status of the GroupKinds of the rules, like custom resources that do not
+// follow the kstatus conventions. The rules take precedence over the
+// StatusReaders. They are ignored if a status watcher is provided with
+// WithStatusWatcher.
+func (b *ApplierBuilder) WithStatusRules(rules status.Rules) *ApplierBuilder {
+	b.statusRules = append(b.statusRules, rules...)
+	return b
+}
+
+// WithPipeline customizes the stages of each run. See PipelineBuilder.
+func (b *ApplierBuilder) WithPipeline(pipeline *Pipeline) *ApplierBuilder {
+	b.pipeline = pipeline
+	return b
+}
+
+// WithFieldManager sets the field manager used for server-side apply, unless
+// ApplierOptions.ServerSideOptions.FieldManager is set for the run.
+func (b *ApplierBuilder) WithFieldManager(fieldManager string) *ApplierBuilder {
+	b.fieldManager = fieldManager
+	return b
+}
+
+// WithRateLimits configures the client-side rate limits based on whether the
+// server has API Priority and Fairness enabled. If enabled, client-side rate
+// limiting is disabled. Otherwise, the passed limits are used, unless the
+// rest config already has explicit limits. The chosen limits are reported
+// with a RateLimitEvent at the start of each run.
+//
+// The dynamic and discovery clients are built from the configured rest
+// config, unless they have been provided explicitly.
+func (b *ApplierBuilder) WithRateLimits(limits flowcontrol.RateLimits) *ApplierBuilder {
+	b.rateLimits = &limits
+	return b
+}
+
+// WithResourceCache shares the passed ResourceCache across all runs of the
+// Applier, instead of creating a new ResourceCacheMap for each run. This
+// avoids fetching the same objects again in long-lived controllers, when used
+// with an InformerResourceCache. The objects being applied or pruned are
+// removed from the cache at the start of each run.
+func (b *ApplierBuilder) WithResourceCache(resourceCache cache.ResourceCache) *ApplierBuilder {
+	b.resourceCache = resourceCache
+	return b
+}
+
+// WithLogger sets the logger used by the Applier, instead of klog. A logger
+// in the context passed to Run takes precedence. The inventory ID and a
+// unique run ID are added to the logger of each run.
+func (b *ApplierBuilder) WithLogger(logger logr.Logger) *ApplierBuilder {
+	b.logger = logger
+	return b
+}
@@ -5,16 +5,26 @@ package apply
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/kubectl/pkg/scheme"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/apply/applytest"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	"sigs.k8s.io/cli-utils/pkg/apply/mutator"
+	"sigs.k8s.io/cli-utils/pkg/apply/solver"
+	"sigs.k8s.io/cli-utils/pkg/apply/verify"
+	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
@@ -121,7 +131,6 @@ func TestApplier(t *testing.T) {
 			},
 			clusterObjs: object.UnstructuredSet{},
 			options: ApplierOptions{
-				NoPrune:         true,
 				InventoryPolicy: inventory.PolicyMustMatch,
 			},
 			expectedEvents: []testutil.ExpEvent{
@@ -228,9 +237,12 @@ func TestApplier(t *testing.T) {
 			},
 			clusterObjs: object.UnstructuredSet{},
 			options: ApplierOptions{
-				ReconcileTimeout: time.Minute,
-				InventoryPolicy:  inventory.PolicyMustMatch,
-				EmitStatusEvents: true,
+				// Watch the Secret, like objects with status
+				WaitForStatuslessObjects: true,
+				Prune:                    PruneEnabled,
+				ReconcileTimeout:         time.Minute,
+				InventoryPolicy:          inventory.PolicyMustMatch,
+				EmitStatusEvents:         true,
 			},
 			statusEvents: []pollevent.Event{
 				{
@@ -424,9 +436,12 @@ func TestApplier(t *testing.T) {
 				testutil.Unstructured(t, resources["deployment"]),
 			},
 			options: ApplierOptions{
-				ReconcileTimeout: time.Minute,
-				InventoryPolicy:  inventory.PolicyAdoptIfNoInventory,
-				EmitStatusEvents: true,
+				// Watch the Secret, like objects with status
+				WaitForStatuslessObjects: true,
+				Prune:                    PruneEnabled,
+				ReconcileTimeout:         time.Minute,
+				InventoryPolicy:          inventory.PolicyAdoptIfNoInventory,
+				EmitStatusEvents:         true,
 			},
 			statusEvents: []pollevent.Event{
 				{
@@ -606,6 +621,7 @@ func TestApplier(t *testing.T) {
 				testutil.Unstructured(t, resources["secret"], testutil.AddOwningInv(t, "test")),
 			},
 			options: ApplierOptions{
+				Prune:            PruneEnabled,
 				InventoryPolicy:  inventory.PolicyMustMatch,
 				EmitStatusEvents: true,
 			},
@@ -805,6 +821,7 @@ func TestApplier(t *testing.T) {
 				testutil.Unstructured(t, resources["deployment"], testutil.AddOwningInv(t, "unmatched")),
 			},
 			options: ApplierOptions{
+				Prune:            PruneEnabled,
 				ReconcileTimeout: time.Minute,
 				InventoryPolicy:  inventory.PolicyMustMatch,
 				EmitStatusEvents: true,
@@ -907,6 +924,396 @@ func TestApplier(t *testing.T) {
 				},
 			},
 		},
+		"update-only apply skips missing object": {
+			namespace: "default",
+			resources: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["deployment"]),
+			},
+			invInfo: inventoryInfo{
+				name:      "abc-123",
+				namespace: "default",
+				id:        "test",
+			},
+			clusterObjs: object.UnstructuredSet{},
+			options: ApplierOptions{
+				Prune:            PruneEnabled,
+				ReconcileTimeout: time.Minute,
+				InventoryPolicy:  inventory.PolicyMustMatch,
+				EmitStatusEvents: true,
+				UpdateOnly:       true,
+			},
+			statusEvents:         []pollevent.Event{},
+			expectedStatusEvents: []testutil.ExpEvent{},
+			expectedEvents: []testutil.ExpEvent{
+				{
+					EventType: event.InitType,
+					InitEvent: &testutil.ExpInitEvent{},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "inventory-add-0",
+						Action:    event.InventoryAction,
+						Type:      event.Started,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "inventory-add-0",
+						Action:    event.InventoryAction,
+						Type:      event.Finished,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "apply-0",
+						Action:    event.ApplyAction,
+						Type:      event.Started,
+					},
+				},
+				{
+					EventType: event.ApplyType,
+					ApplyEvent: &testutil.ExpApplyEvent{
+						GroupName:  "apply-0",
+						Identifier: testutil.ToIdentifier(t, resources["deployment"]),
+						Status:     event.ApplySkipped,
+						Error: &filter.CreatePreventedError{
+							Object: testutil.ToIdentifier(t, resources["deployment"]),
+						},
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "apply-0",
+						Action:    event.ApplyAction,
+						Type:      event.Finished,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "wait-0",
+						Action:    event.WaitAction,
+						Type:      event.Started,
+					},
+				},
+				{
+					EventType: event.WaitType,
+					WaitEvent: &testutil.ExpWaitEvent{
+						GroupName:  "wait-0",
+						Status:     event.ReconcileSkipped,
+						Identifier: testutil.ToIdentifier(t, resources["deployment"]),
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "wait-0",
+						Action:    event.WaitAction,
+						Type:      event.Finished,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "inventory-set-0",
+						Action:    event.InventoryAction,
+						Type:      event.Started,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "inventory-set-0",
+						Action:    event.InventoryAction,
+						Type:      event.Finished,
+					},
+				},
+			},
+		},
+		"objects outside the selection are neither applied nor pruned": {
+			namespace: "default",
+			resources: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["deployment"]),
+			},
+			invInfo: inventoryInfo{
+				name:      "abc-123",
+				namespace: "default",
+				id:        "test",
+				set: object.ObjMetadataSet{
+					testutil.ToIdentifier(t, resources["secret"]),
+				},
+			},
+			clusterObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["secret"], testutil.AddOwningInv(t, "test")),
+			},
+			options: ApplierOptions{
+				Prune:            PruneEnabled,
+				ReconcileTimeout: time.Minute,
+				InventoryPolicy:  inventory.PolicyMustMatch,
+				EmitStatusEvents: true,
+				Selector:         labels.SelectorFromSet(labels.Set{"app": "foo"}),
+			},
+			statusEvents:         []pollevent.Event{},
+			expectedStatusEvents: []testutil.ExpEvent{},
+			expectedEvents: []testutil.ExpEvent{
+				{
+					EventType: event.InitType,
+					InitEvent: &testutil.ExpInitEvent{},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "inventory-add-0",
+						Action:    event.InventoryAction,
+						Type:      event.Started,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "inventory-add-0",
+						Action:    event.InventoryAction,
+						Type:      event.Finished,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "apply-0",
+						Action:    event.ApplyAction,
+						Type:      event.Started,
+					},
+				},
+				{
+					EventType: event.ApplyType,
+					ApplyEvent: &testutil.ExpApplyEvent{
+						GroupName:  "apply-0",
+						Identifier: testutil.ToIdentifier(t, resources["deployment"]),
+						Status:     event.ApplySkipped,
+						Error: &filter.ObjectNotSelectedError{
+							GroupKind: testutil.ToIdentifier(t, resources["deployment"]).GroupKind,
+							Selector:  "app=foo",
+							Reason:    "selector",
+						},
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "apply-0",
+						Action:    event.ApplyAction,
+						Type:      event.Finished,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "wait-0",
+						Action:    event.WaitAction,
+						Type:      event.Started,
+					},
+				},
+				{
+					EventType: event.WaitType,
+					WaitEvent: &testutil.ExpWaitEvent{
+						GroupName:  "wait-0",
+						Status:     event.ReconcileSkipped,
+						Identifier: testutil.ToIdentifier(t, resources["deployment"]),
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "wait-0",
+						Action:    event.WaitAction,
+						Type:      event.Finished,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "prune-0",
+						Action:    event.PruneAction,
+						Type:      event.Started,
+					},
+				},
+				{
+					EventType: event.PruneType,
+					PruneEvent: &testutil.ExpPruneEvent{
+						GroupName:  "prune-0",
+						Status:     event.PruneSkipped,
+						Identifier: testutil.ToIdentifier(t, resources["secret"]),
+						Error: &filter.ObjectNotSelectedError{
+							GroupKind: testutil.ToIdentifier(t, resources["secret"]).GroupKind,
+							Selector:  "app=foo",
+							Reason:    "selector",
+						},
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "prune-0",
+						Action:    event.PruneAction,
+						Type:      event.Finished,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "wait-1",
+						Action:    event.WaitAction,
+						Type:      event.Started,
+					},
+				},
+				{
+					EventType: event.WaitType,
+					WaitEvent: &testutil.ExpWaitEvent{
+						GroupName:  "wait-1",
+						Status:     event.ReconcileSkipped,
+						Identifier: testutil.ToIdentifier(t, resources["secret"]),
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "wait-1",
+						Action:    event.WaitAction,
+						Type:      event.Finished,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "inventory-set-0",
+						Action:    event.InventoryAction,
+						Type:      event.Started,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "inventory-set-0",
+						Action:    event.InventoryAction,
+						Type:      event.Finished,
+					},
+				},
+			},
+		},
+		"prune filters in options skip objects": {
+			namespace: "default",
+			resources: object.UnstructuredSet{},
+			invInfo: inventoryInfo{
+				name:      "abc-123",
+				namespace: "default",
+				id:        "test",
+				set: object.ObjMetadataSet{
+					testutil.ToIdentifier(t, resources["secret"]),
+				},
+			},
+			clusterObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["secret"], testutil.AddOwningInv(t, "test")),
+			},
+			options: ApplierOptions{
+				Prune:            PruneEnabled,
+				InventoryPolicy:  inventory.PolicyMustMatch,
+				EmitStatusEvents: true,
+				PruneFilters: []filter.ValidationFilter{
+					protectedPruneFilter{names: []string{"secret"}},
+				},
+			},
+			expectedEvents: []testutil.ExpEvent{
+				{
+					EventType: event.InitType,
+					InitEvent: &testutil.ExpInitEvent{},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "inventory-add-0",
+						Action:    event.InventoryAction,
+						Type:      event.Started,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "inventory-add-0",
+						Action:    event.InventoryAction,
+						Type:      event.Finished,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "prune-0",
+						Action:    event.PruneAction,
+						Type:      event.Started,
+					},
+				},
+				{
+					EventType: event.PruneType,
+					PruneEvent: &testutil.ExpPruneEvent{
+						GroupName:  "prune-0",
+						Status:     event.PruneSkipped,
+						Identifier: testutil.ToIdentifier(t, resources["secret"]),
+						Error:      testutil.EqualErrorString(`object "secret" is protected`),
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "prune-0",
+						Action:    event.PruneAction,
+						Type:      event.Finished,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "wait-0",
+						Action:    event.WaitAction,
+						Type:      event.Started,
+					},
+				},
+				{
+					EventType: event.WaitType,
+					WaitEvent: &testutil.ExpWaitEvent{
+						GroupName:  "wait-0",
+						Status:     event.ReconcileSkipped,
+						Identifier: testutil.ToIdentifier(t, resources["secret"]),
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "wait-0",
+						Action:    event.WaitAction,
+						Type:      event.Finished,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "inventory-set-0",
+						Action:    event.InventoryAction,
+						Type:      event.Started,
+					},
+				},
+				{
+					EventType: event.ActionGroupType,
+					ActionGroupEvent: &testutil.ExpActionGroupEvent{
+						GroupName: "inventory-set-0",
+						Action:    event.InventoryAction,
+						Type:      event.Finished,
+					},
+				},
+			},
+		},
 		"resources belonging to a different inventory should not be pruned": {
 			namespace: "default",
 			resources: object.UnstructuredSet{},
@@ -924,6 +1331,7 @@ func TestApplier(t *testing.T) {
 				testutil.Unstructured(t, resources["deployment"], testutil.AddOwningInv(t, "unmatched")),
 			},
 			options: ApplierOptions{
+				Prune:            PruneEnabled,
 				InventoryPolicy:  inventory.PolicyMustMatch,
 				EmitStatusEvents: true,
 			},
@@ -1036,6 +1444,7 @@ func TestApplier(t *testing.T) {
 				testutil.Unstructured(t, resources["deployment"], testutil.AddOwningInv(t, "test")),
 			},
 			options: ApplierOptions{
+				Prune:            PruneEnabled,
 				InventoryPolicy:  inventory.PolicyMustMatch,
 				EmitStatusEvents: true,
 			},
@@ -1185,10 +1594,13 @@ func TestApplier(t *testing.T) {
 			},
 			clusterObjs: object.UnstructuredSet{},
 			options: ApplierOptions{
-				ReconcileTimeout: time.Minute,
-				InventoryPolicy:  inventory.PolicyAdoptIfNoInventory,
-				EmitStatusEvents: true,
-				ValidationPolicy: validation.SkipInvalid,
+				// Watch the Secret, like objects with status
+				WaitForStatuslessObjects: true,
+				Prune:                    PruneEnabled,
+				ReconcileTimeout:         time.Minute,
+				InventoryPolicy:          inventory.PolicyAdoptIfNoInventory,
+				EmitStatusEvents:         true,
+				ValidationPolicy:         validation.SkipInvalid,
 			},
 			statusEvents: []pollevent.Event{
 				{
@@ -1365,6 +1777,7 @@ func TestApplier(t *testing.T) {
 			},
 			clusterObjs: object.UnstructuredSet{},
 			options: ApplierOptions{
+				Prune:            PruneEnabled,
 				ReconcileTimeout: time.Minute,
 				InventoryPolicy:  inventory.PolicyAdoptIfNoInventory,
 				EmitStatusEvents: true,
@@ -1545,7 +1958,6 @@ func TestApplierCancel(t *testing.T) {
 			options: ApplierOptions{
 				// EmitStatusEvents required to test event output
 				EmitStatusEvents: true,
-				NoPrune:          true,
 				InventoryPolicy:  inventory.PolicyMustMatch,
 				// ReconcileTimeout required to enable WaitTasks
 				ReconcileTimeout: 1 * time.Minute,
@@ -1703,7 +2115,6 @@ func TestApplierCancel(t *testing.T) {
 			options: ApplierOptions{
 				// EmitStatusEvents required to test event output
 				EmitStatusEvents: true,
-				NoPrune:          true,
 				InventoryPolicy:  inventory.PolicyMustMatch,
 				// ReconcileTimeout required to enable WaitTasks
 				ReconcileTimeout: 1 * time.Minute,
@@ -1943,7 +2354,7 @@ func TestApplierCancel(t *testing.T) {
 
 func TestReadAndPrepareObjectsNilInv(t *testing.T) {
 	applier := Applier{}
-	_, _, err := applier.prepareObjects(nil, object.UnstructuredSet{}, ApplierOptions{})
+	_, _, _, err := applier.prepareObjects(logr.Discard(), nil, object.UnstructuredSet{}, ApplierOptions{})
 	assert.Error(t, err)
 }
 
@@ -1954,6 +2365,10 @@ func TestReadAndPrepareObjects(t *testing.T) {
 	obj1 := testutil.Unstructured(t, resources["obj1"])
 	obj2 := testutil.Unstructured(t, resources["obj2"])
 	clusterScopedObj := testutil.Unstructured(t, resources["clusterScopedObj"])
+	obj2Delete := testutil.Unstructured(t, resources["obj2"])
+	obj2Delete.SetAnnotations(map[string]string{
+		common.OnApplyAnnotation: common.OnApplyDelete,
+	})
 
 	testCases := map[string]struct {
 		// objects in the cluster
@@ -1966,6 +2381,8 @@ func TestReadAndPrepareObjects(t *testing.T) {
 		applyObjs object.UnstructuredSet
 		// expected objects to prune
 		pruneObjs object.UnstructuredSet
+		// expected objects to delete on apply
+		deleteObjs object.UnstructuredSet
 		// expected error
 		isError bool
 	}{
@@ -2038,6 +2455,29 @@ func TestReadAndPrepareObjects(t *testing.T) {
 			applyObjs: object.UnstructuredSet{obj1, obj2, clusterScopedObj},
 			pruneObjs: object.UnstructuredSet{},
 		},
+		"delete on apply in inventory, apply others, delete one": {
+			clusterObjs: object.UnstructuredSet{obj2},
+			invInfo: inventoryInfo{
+				name:      inventory.Name(),
+				namespace: inventory.Namespace(),
+				id:        inventory.ID(),
+				set: object.ObjMetadataSet{
+					object.UnstructuredToObjMetadata(obj2),
+				},
+			},
+			resources:  object.UnstructuredSet{obj1, obj2Delete},
+			applyObjs:  object.UnstructuredSet{obj1},
+			deleteObjs: object.UnstructuredSet{obj2},
+		},
+		"delete on apply not in cluster, apply others, delete none": {
+			invInfo: inventoryInfo{
+				name:      inventory.Name(),
+				namespace: inventory.Namespace(),
+				id:        inventory.ID(),
+			},
+			resources: object.UnstructuredSet{obj1, obj2Delete},
+			applyObjs: object.UnstructuredSet{obj1},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -2050,7 +2490,7 @@ func TestReadAndPrepareObjects(t *testing.T) {
 				watcher.BlindStatusWatcher{},
 			)
 
-			applyObjs, pruneObjs, err := applier.prepareObjects(tc.invInfo.toWrapped(), tc.resources, ApplierOptions{})
+			applyObjs, pruneObjs, deleteObjs, err := applier.prepareObjects(logr.Discard(), tc.invInfo.toWrapped(), tc.resources, ApplierOptions{})
 			if tc.isError {
 				assert.Error(t, err)
 				return
@@ -2064,6 +2504,413 @@ func TestReadAndPrepareObjects(t *testing.T) {
 			testutil.AssertEqual(t, pruneObjs, tc.pruneObjs,
 				"Actual pruned objects (%d) do not match expected pruned objects (%d)",
 				len(pruneObjs), len(tc.pruneObjs))
+
+			testutil.AssertEqual(t, deleteObjs, tc.deleteObjs,
+				"Actual deleted objects (%d) do not match expected deleted objects (%d)",
+				len(deleteObjs), len(tc.deleteObjs))
+		})
+	}
+}
+
+func TestApplierPlan(t *testing.T) {
+	inventoryObj := testutil.Unstructured(t, resources["inventory"])
+	inv := inventory.WrapInventoryInfoObj(inventoryObj)
+	invInfo := inventoryInfo{
+		name:      inv.Name(),
+		namespace: inv.Namespace(),
+		id:        inv.ID(),
+		set: object.ObjMetadataSet{
+			testutil.ToIdentifier(t, resources["obj2"]),
+		},
+	}
+	obj1 := testutil.Unstructured(t, resources["obj1"])
+	obj2 := testutil.Unstructured(t, resources["obj2"])
+	obj1Ref := inventory.ObjectReferenceFromObjMetadata(object.UnstructuredToObjMetadata(obj1))
+	obj2Ref := inventory.ObjectReferenceFromObjMetadata(object.UnstructuredToObjMetadata(obj2))
+
+	applier := newTestApplier(t,
+		invInfo,
+		object.UnstructuredSet{obj1},
+		object.UnstructuredSet{obj2},
+		// no events needed for planning
+		watcher.BlindStatusWatcher{},
+	)
+
+	tg, err := applier.Plan(context.Background(), invInfo.toWrapped(), object.UnstructuredSet{obj1}, ApplierOptions{
+		Prune: PruneEnabled,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []solver.TaskNode{
+		{Name: "inventory-add-0", Action: "Inventory", Objects: []actuation.ObjectReference{obj1Ref}},
+		{Name: "apply-0", Action: "Apply", Objects: []actuation.ObjectReference{obj1Ref}},
+		{Name: "wait-0", Action: "Wait", Objects: []actuation.ObjectReference{obj1Ref}},
+		{Name: "prune-0", Action: "Prune", Objects: []actuation.ObjectReference{obj2Ref}},
+		{Name: "wait-1", Action: "Wait", Objects: []actuation.ObjectReference{obj2Ref}},
+		{Name: "inventory-set-0", Action: "Inventory", Objects: []actuation.ObjectReference{}},
+	}, tg.Tasks)
+	assert.Empty(t, tg.Dependencies)
+}
+
+func TestApplierOptionsPruneEnabled(t *testing.T) {
+	testCases := map[string]struct {
+		options  ApplierOptions
+		expected bool
+	}{
+		"zero value options disable pruning": {
+			options:  ApplierOptions{},
+			expected: false,
+		},
+		"PruneDisabled disables pruning": {
+			options:  ApplierOptions{Prune: PruneDisabled},
+			expected: false,
+		},
+		"PruneEnabled enables pruning": {
+			options:  ApplierOptions{Prune: PruneEnabled},
+			expected: true,
+		},
+		"NoPrune overrides PruneEnabled": {
+			options:  ApplierOptions{Prune: PruneEnabled, NoPrune: true},
+			expected: false,
+		},
+		"legacy options enable pruning": {
+			options:  LegacyApplierOptions(),
+			expected: true,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.options.pruneEnabled())
+		})
+	}
+}
+
+// protectedPruneFilter skips pruning the objects with the names.
+type protectedPruneFilter struct {
+	names []string
+}
+
+func (f protectedPruneFilter) Name() string {
+	return "ProtectedPruneFilter"
+}
+
+func (f protectedPruneFilter) Filter(obj *unstructured.Unstructured) error {
+	for _, name := range f.names {
+		if obj.GetName() == name {
+			return fmt.Errorf("object %q is protected", name)
+		}
+	}
+	return nil
+}
+
+func TestApplierVerification(t *testing.T) {
+	inventoryObj := testutil.Unstructured(t, resources["inventory"])
+	inv := inventory.WrapInventoryInfoObj(inventoryObj)
+	invInfo := inventoryInfo{
+		name:      inv.Name(),
+		namespace: inv.Namespace(),
+		id:        inv.ID(),
+	}
+	obj1 := testutil.Unstructured(t, resources["obj1"])
+
+	applier := newTestApplier(t,
+		invInfo,
+		object.UnstructuredSet{obj1},
+		object.UnstructuredSet{},
+		watcher.BlindStatusWatcher{},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventChannel := applier.Run(ctx, invInfo.toWrapped(), object.UnstructuredSet{obj1}, ApplierOptions{
+		Verified: []verify.Result{
+			{
+				Source: "manifests.yaml",
+				Digest: "sha256:def",
+				Method: verify.MethodCosign,
+				Signer: "sha256:abc",
+			},
+		},
+	})
+	// The verification is reported before anything is planned or applied.
+	assert.Equal(t, event.Event{
+		Type: event.VerificationType,
+		VerificationEvent: event.VerificationEvent{
+			Source: "manifests.yaml",
+			Digest: "sha256:def",
+			Method: verify.MethodCosign,
+			Signer: "sha256:abc",
+		},
+	}, <-eventChannel)
+	cancel()
+	for range eventChannel {
+	}
+}
+
+func TestApplierInvalidFieldValidation(t *testing.T) {
+	inventoryObj := testutil.Unstructured(t, resources["inventory"])
+	inv := inventory.WrapInventoryInfoObj(inventoryObj)
+	invInfo := inventoryInfo{
+		name:      inv.Name(),
+		namespace: inv.Namespace(),
+		id:        inv.ID(),
+	}
+	obj1 := testutil.Unstructured(t, resources["obj1"])
+
+	applier := newTestApplier(t,
+		invInfo,
+		object.UnstructuredSet{obj1},
+		object.UnstructuredSet{},
+		watcher.BlindStatusWatcher{},
+	)
+
+	_, err := applier.Plan(context.Background(), invInfo.toWrapped(), object.UnstructuredSet{obj1}, ApplierOptions{
+		ServerSideOptions: common.ServerSideOptions{
+			FieldValidation: "Loose",
+		},
+	})
+	assert.EqualError(t, err, `invalid FieldValidation: "Loose"`)
+}
+
+func TestApplierPrune(t *testing.T) {
+	inventoryObj := testutil.Unstructured(t, resources["inventory"])
+	inv := inventory.WrapInventoryInfoObj(inventoryObj)
+	invInfo := inventoryInfo{
+		name:      inv.Name(),
+		namespace: inv.Namespace(),
+		id:        inv.ID(),
+		set: object.ObjMetadataSet{
+			testutil.ToIdentifier(t, resources["obj1"]),
+			testutil.ToIdentifier(t, resources["secret"]),
+		},
+	}
+	obj1 := testutil.Unstructured(t, resources["obj1"])
+	secret := testutil.Unstructured(t, resources["secret"], testutil.AddOwningInv(t, inv.ID()))
+	deployment := testutil.Unstructured(t, resources["deployment"])
+
+	applier := newTestApplier(t,
+		invInfo,
+		object.UnstructuredSet{obj1, deployment},
+		object.UnstructuredSet{obj1, secret},
+		watcher.BlindStatusWatcher{},
+	)
+
+	// Dry-run, to skip waiting for the pruned object to be deleted.
+	eventChannel := applier.Prune(context.Background(), invInfo.toWrapped(), object.UnstructuredSet{obj1, deployment}, ApplierOptions{
+		DryRunStrategy: common.DryRunClient,
+		NoPrune:        true,
+	})
+	var groups []event.ActionGroup
+	var pruned object.ObjMetadataSet
+	for e := range eventChannel {
+		switch e.Type {
+		case event.InitType:
+			groups = e.InitEvent.ActionGroups
+		case event.ApplyType:
+			t.Errorf("unexpected apply event: %v", e)
+		case event.PruneType:
+			assert.Equal(t, event.PruneSuccessful, e.PruneEvent.Status)
+			pruned = append(pruned, e.PruneEvent.Identifier)
+		case event.ErrorType:
+			t.Errorf("unexpected error: %v", e.ErrorEvent.Err)
+		}
+	}
+	var names []string
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+	assert.Equal(t, []string{"inventory-add-0", "prune-0", "inventory-set-0"}, names)
+	assert.Equal(t, object.ObjMetadataSet{object.UnstructuredToObjMetadata(secret)}, pruned)
+}
+
+// recordingMutator adds an annotation to the objects and records their ids.
+type recordingMutator struct {
+	mu      sync.Mutex
+	mutated object.ObjMetadataSet
+}
+
+func (m *recordingMutator) Name() string {
+	return "RecordingMutator"
+}
+
+func (m *recordingMutator) Mutate(_ context.Context, obj *unstructured.Unstructured) (bool, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mutated = append(m.mutated, object.UnstructuredToObjMetadata(obj))
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations["example.com/mutated"] = "true"
+	obj.SetAnnotations(annotations)
+	return true, "added annotation", nil
+}
+
+func TestApplierApplyMutators(t *testing.T) {
+	inventoryObj := testutil.Unstructured(t, resources["inventory"])
+	inv := inventory.WrapInventoryInfoObj(inventoryObj)
+	invInfo := inventoryInfo{
+		name:      inv.Name(),
+		namespace: inv.Namespace(),
+		id:        inv.ID(),
+	}
+	deployment := testutil.Unstructured(t, resources["deployment"])
+
+	applier := newTestApplier(t,
+		invInfo,
+		object.UnstructuredSet{deployment},
+		object.UnstructuredSet{},
+		watcher.BlindStatusWatcher{},
+	)
+
+	recorder := &recordingMutator{}
+	eventChannel := applier.Run(context.Background(), invInfo.toWrapped(), object.UnstructuredSet{deployment}, ApplierOptions{
+		DryRunStrategy: common.DryRunClient,
+		ApplyMutators:  []mutator.Interface{recorder},
+	})
+	var applied object.ObjMetadataSet
+	for e := range eventChannel {
+		switch e.Type {
+		case event.ApplyType:
+			assert.Equal(t, event.ApplySuccessful, e.ApplyEvent.Status)
+			applied = append(applied, e.ApplyEvent.Identifier)
+		case event.ErrorType:
+			t.Errorf("unexpected error: %v", e.ErrorEvent.Err)
+		}
+	}
+	id := object.UnstructuredToObjMetadata(deployment)
+	assert.Equal(t, object.ObjMetadataSet{id}, applied)
+	assert.Equal(t, object.ObjMetadataSet{id}, recorder.mutated)
+}
+
+func TestApplierWithTestDoubles(t *testing.T) {
+	inventoryObj := testutil.Unstructured(t, resources["inventory"])
+	inv := inventory.WrapInventoryInfoObj(inventoryObj)
+	invInfo := inventoryInfo{
+		name:      inv.Name(),
+		namespace: inv.Namespace(),
+		id:        inv.ID(),
+	}
+	deployment := testutil.Unstructured(t, resources["deployment"])
+	deploymentID := object.UnstructuredToObjMetadata(deployment)
+	secret := testutil.Unstructured(t, resources["secret"], testutil.AddOwningInv(t, inv.ID()))
+	secretID := object.UnstructuredToObjMetadata(secret)
+
+	tf := newTestFactory(t, invInfo, object.UnstructuredSet{deployment}, object.UnstructuredSet{secret})
+	defer tf.Cleanup()
+
+	invClient := applytest.NewInventoryClient()
+	invClient.SetObjects(inv, object.ObjMetadataSet{secretID})
+	statusWatcher := applytest.NewStatusWatcher()
+	statusWatcher.Script(deploymentID, status.InProgressStatus, status.CurrentStatus)
+
+	applier, err := NewApplierBuilder().
+		WithFactory(tf).
+		WithInventoryClient(invClient).
+		WithStatusWatcher(statusWatcher).
+		Build()
+	require.NoError(t, err)
+	applier.infoHelper = &fakeInfoHelper{factory: tf}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var events []event.Event
+	for e := range applier.Run(ctx, inv, object.UnstructuredSet{deployment}, ApplierOptions{
+		Prune:            PruneEnabled,
+		ReconcileTimeout: time.Minute,
+		PruneTimeout:     time.Minute,
+		InventoryPolicy:  inventory.PolicyMustMatch,
+	}) {
+		statusWatcher.Send(e)
+		events = append(events, e)
+	}
+
+	testutil.ExpectEventGroups(t, events,
+		[]testutil.ExpEvent{
+			{
+				EventType: event.ApplyType,
+				ApplyEvent: &testutil.ExpApplyEvent{
+					Identifier: deploymentID,
+					Status:     event.ApplySuccessful,
+				},
+			},
+		},
+		[]testutil.ExpEvent{
+			{
+				EventType: event.WaitType,
+				WaitEvent: &testutil.ExpWaitEvent{
+					Identifier: deploymentID,
+					Status:     event.ReconcileSuccessful,
+				},
+			},
+		},
+		[]testutil.ExpEvent{
+			{
+				EventType: event.PruneType,
+				PruneEvent: &testutil.ExpPruneEvent{
+					Identifier: secretID,
+					Status:     event.PruneSuccessful,
+				},
+			},
+			{
+				EventType: event.WaitType,
+				WaitEvent: &testutil.ExpWaitEvent{
+					Identifier: secretID,
+					Status:     event.ReconcileSuccessful,
+				},
+			},
+		},
+	)
+	for _, e := range events {
+		if e.Type == event.ErrorType {
+			t.Errorf("unexpected error: %v", e.ErrorEvent.Err)
+		}
+	}
+	assert.Equal(t, object.ObjMetadataSet{deploymentID}, invClient.Objects(inv))
+}
+
+// validatingInventoryClient is an inventory client that implements
+// inventory.Validator.
+type validatingInventoryClient struct {
+	*inventory.FakeClient
+	err error
+}
+
+func (c *validatingInventoryClient) ValidateInventory(inventory.Info, inventory.Policy) error {
+	return c.err
+}
+
+func TestValidateInventory(t *testing.T) {
+	inv := inventory.WrapInventoryInfoObj(testutil.Unstructured(t, resources["inventory"]))
+	collisionErr := &inventory.InventoryIDCollisionError{ID: inv.ID()}
+
+	tests := map[string]struct {
+		invClient   inventory.Client
+		expectedErr error
+	}{
+		"client without validation": {
+			invClient: inventory.NewFakeClient(object.ObjMetadataSet{}),
+		},
+		"valid inventory": {
+			invClient: &validatingInventoryClient{FakeClient: inventory.NewFakeClient(object.ObjMetadataSet{})},
+		},
+		"invalid inventory": {
+			invClient: &validatingInventoryClient{
+				FakeClient: inventory.NewFakeClient(object.ObjMetadataSet{}),
+				err:        collisionErr,
+			},
+			expectedErr: collisionErr,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateInventory(tc.invClient, inv, inventory.PolicyMustMatch)
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
 		})
 	}
 }
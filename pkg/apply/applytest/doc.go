@@ -0,0 +1,29 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package applytest provides test doubles for code that embeds the Applier
+// or Destroyer, so that it can be unit tested without a live cluster:
+//
+//   - InventoryClient is an in-memory inventory.Client.
+//   - StatusWatcher is a scriptable watcher.StatusWatcher, which reports the
+//     applied objects as Current and the deleted objects as NotFound, unless
+//     scripted otherwise.
+//
+// The events of a run can be checked with testutil.ExpectEvents and
+// testutil.ExpectEventGroups.
+//
+//	invClient := applytest.NewInventoryClient()
+//	statusWatcher := applytest.NewStatusWatcher()
+//	applier, err := apply.NewApplierBuilder().
+//	  WithFactory(factory).
+//	  WithInventoryClient(invClient).
+//	  WithStatusWatcher(statusWatcher).
+//	  Build()
+//	...
+//	var events []event.Event
+//	for e := range applier.Run(ctx, inv, objs, apply.ApplierOptions{}) {
+//	  statusWatcher.Send(e)
+//	  events = append(events, e)
+//	}
+//	testutil.ExpectEvents(t, events, ...)
+package applytest
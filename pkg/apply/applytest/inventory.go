@@ -0,0 +1,187 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package applytest
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// InventoryClient is an in-memory inventory.Client. It stores the objects
+// and the object status of each inventory, like the ClusterClient does in the
+// inventory object, so that consecutive runs prune and retry as they would
+// with a cluster. Inventories are identified by their namespace and name
+// with the NameStrategy, and by their ID with the LabelStrategy. Dry runs do
+// not change the stored inventories. It is safe for concurrent use.
+type InventoryClient struct {
+	mu          sync.Mutex
+	inventories map[string]*storedInventory
+	err         error
+}
+
+type storedInventory struct {
+	objs   object.ObjMetadataSet
+	status []actuation.ObjectStatus
+}
+
+var (
+	_ inventory.Client       = &InventoryClient{}
+	_ inventory.StatusClient = &InventoryClient{}
+)
+
+// NewInventoryClient returns an InventoryClient without any inventory.
+func NewInventoryClient() *InventoryClient {
+	return &InventoryClient{
+		inventories: make(map[string]*storedInventory),
+	}
+}
+
+// inventoryKey returns the key of the inventory in the client.
+func inventoryKey(inv inventory.Info) string {
+	if inv.Strategy() == inventory.LabelStrategy {
+		return inv.ID()
+	}
+	return inv.Namespace() + "/" + inv.Name()
+}
+
+// SetObjects replaces the objects stored in the inventory, creating it if
+// needed, as if they were applied by a previous run.
+func (c *InventoryClient) SetObjects(inv inventory.Info, objs object.ObjMetadataSet) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stored, found := c.inventories[inventoryKey(inv)]
+	if !found {
+		stored = &storedInventory{}
+		c.inventories[inventoryKey(inv)] = stored
+	}
+	stored.objs = append(object.ObjMetadataSet{}, objs...)
+}
+
+// Objects returns the objects stored in the inventory.
+func (c *InventoryClient) Objects(inv inventory.Info) object.ObjMetadataSet {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stored, found := c.inventories[inventoryKey(inv)]
+	if !found {
+		return nil
+	}
+	return append(object.ObjMetadataSet{}, stored.objs...)
+}
+
+// Exists returns true if the inventory is stored.
+func (c *InventoryClient) Exists(inv inventory.Info) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, found := c.inventories[inventoryKey(inv)]
+	return found
+}
+
+// SetError makes all the following calls fail with the error, until it is
+// cleared with a nil error.
+func (c *InventoryClient) SetError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+// GetClusterObjs returns the objects stored in the inventory.
+func (c *InventoryClient) GetClusterObjs(inv inventory.Info) (object.ObjMetadataSet, error) {
+	if err := c.getError(); err != nil {
+		return nil, err
+	}
+	return c.Objects(inv), nil
+}
+
+// GetClusterObjStatus returns the object status stored in the inventory.
+func (c *InventoryClient) GetClusterObjStatus(inv inventory.Info) ([]actuation.ObjectStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	stored, found := c.inventories[inventoryKey(inv)]
+	if !found {
+		return nil, nil
+	}
+	return append([]actuation.ObjectStatus{}, stored.status...), nil
+}
+
+// Merge stores the union of the objects with the stored objects, and returns
+// the stored objects that are not in the objects.
+func (c *InventoryClient) Merge(inv inventory.Info, objs object.ObjMetadataSet, dryRun common.DryRunStrategy) (object.ObjMetadataSet, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	stored, found := c.inventories[inventoryKey(inv)]
+	if !found {
+		stored = &storedInventory{}
+	}
+	pruneIds := stored.objs.Diff(objs)
+	if !dryRun.ClientOrServerDryRun() {
+		stored.objs = stored.objs.Union(objs)
+		c.inventories[inventoryKey(inv)] = stored
+	}
+	return pruneIds, nil
+}
+
+// Replace replaces the stored objects and object status.
+func (c *InventoryClient) Replace(inv inventory.Info, objs object.ObjMetadataSet, status []actuation.ObjectStatus,
+	dryRun common.DryRunStrategy) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	if dryRun.ClientOrServerDryRun() {
+		return nil
+	}
+	c.inventories[inventoryKey(inv)] = &storedInventory{
+		objs:   append(object.ObjMetadataSet{}, objs...),
+		status: append([]actuation.ObjectStatus{}, status...),
+	}
+	return nil
+}
+
+// DeleteInventoryObj removes the inventory.
+func (c *InventoryClient) DeleteInventoryObj(inv inventory.Info, dryRun common.DryRunStrategy) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	if !dryRun.ClientOrServerDryRun() {
+		delete(c.inventories, inventoryKey(inv))
+	}
+	return nil
+}
+
+// ApplyInventoryNamespace does nothing, since namespaces are not stored.
+func (c *InventoryClient) ApplyInventoryNamespace(*unstructured.Unstructured, common.DryRunStrategy) error {
+	return c.getError()
+}
+
+// GetClusterInventoryInfo returns nil, since inventory objects are not
+// stored.
+func (c *InventoryClient) GetClusterInventoryInfo(inventory.Info) (*unstructured.Unstructured, error) {
+	return nil, c.getError()
+}
+
+// GetClusterInventoryObjs returns no object, since inventory objects are not
+// stored.
+func (c *InventoryClient) GetClusterInventoryObjs(inventory.Info) (object.UnstructuredSet, error) {
+	return object.UnstructuredSet{}, c.getError()
+}
+
+func (c *InventoryClient) getError() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
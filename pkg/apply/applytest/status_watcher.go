@@ -0,0 +1,184 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package applytest
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// StatusWatcher is a scriptable watcher.StatusWatcher. It reacts to the
+// events of a run, sent to it with Send: once an object is applied, its
+// scripted statuses are sent in order, or Current if it has no script. Once
+// an object is pruned or deleted, its scripted statuses are sent, or
+// NotFound. Statuses can also be sent at any time with SetStatus. It
+// implements event.Sink, so it can be subscribed to an event.Multiplexer.
+//
+// Status updates are queued, so Send never blocks the consumer of the events.
+type StatusWatcher struct {
+	mu      sync.Mutex
+	scripts map[object.ObjMetadata][]status.Status
+	watches map[*statusWatch]bool
+}
+
+var (
+	_ watcher.StatusWatcher = &StatusWatcher{}
+	_ event.Sink            = &StatusWatcher{}
+)
+
+// NewStatusWatcher returns a StatusWatcher without scripts.
+func NewStatusWatcher() *StatusWatcher {
+	return &StatusWatcher{
+		scripts: make(map[object.ObjMetadata][]status.Status),
+		watches: make(map[*statusWatch]bool),
+	}
+}
+
+// Script sets the statuses sent, in order, each time the object is applied,
+// pruned, or deleted. For example, InProgress then Current, or InProgress
+// only, to make the object time out. Without statuses, nothing is sent.
+func (w *StatusWatcher) Script(id object.ObjMetadata, statuses ...status.Status) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.scripts[id] = append([]status.Status{}, statuses...)
+}
+
+// SetStatus sends a status update for the object to the active watches.
+func (w *StatusWatcher) SetStatus(id object.ObjMetadata, s status.Status) {
+	w.send(id, nil, s)
+}
+
+// SetResourceStatus sends a status update for the object to the active
+// watches, with the object. Objects are reconciled only if their generation
+// is at least the generation they were applied with.
+func (w *StatusWatcher) SetResourceStatus(obj *unstructured.Unstructured, s status.Status) {
+	w.send(object.UnstructuredToObjMetadata(obj), obj, s)
+}
+
+// Send sends the scripted statuses of the object of a successful apply,
+// prune, or delete event. Other events are ignored.
+func (w *StatusWatcher) Send(e event.Event) {
+	switch {
+	case e.Type == event.ApplyType && e.ApplyEvent.Status == event.ApplySuccessful:
+		w.sendScript(e.ApplyEvent.Identifier, e.ApplyEvent.Resource, status.CurrentStatus)
+	case e.Type == event.PruneType && e.PruneEvent.Status == event.PruneSuccessful:
+		w.sendScript(e.PruneEvent.Identifier, nil, status.NotFoundStatus)
+	case e.Type == event.DeleteType && e.DeleteEvent.Status == event.DeleteSuccessful:
+		w.sendScript(e.DeleteEvent.Identifier, nil, status.NotFoundStatus)
+	}
+}
+
+// sendScript sends the scripted statuses of the object, or the default
+// status if the object has no script.
+func (w *StatusWatcher) sendScript(id object.ObjMetadata, obj *unstructured.Unstructured, defaultStatus status.Status) {
+	w.mu.Lock()
+	statuses, found := w.scripts[id]
+	w.mu.Unlock()
+	if !found {
+		statuses = []status.Status{defaultStatus}
+	}
+	for _, s := range statuses {
+		w.send(id, obj, s)
+	}
+}
+
+func (w *StatusWatcher) send(id object.ObjMetadata, obj *unstructured.Unstructured, s status.Status) {
+	e := pollevent.Event{
+		Type: pollevent.ResourceUpdateEvent,
+		Resource: &pollevent.ResourceStatus{
+			Identifier: id,
+			Status:     s,
+			Resource:   obj,
+		},
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for sw := range w.watches {
+		if sw.ids.Contains(id) {
+			sw.enqueue(e)
+		}
+	}
+}
+
+// Watch sends a SyncEvent, then the status updates of the objects, until
+// the context is cancelled.
+func (w *StatusWatcher) Watch(ctx context.Context, ids object.ObjMetadataSet, _ watcher.Options) <-chan pollevent.Event {
+	sw := &statusWatch{
+		ids:    ids,
+		notify: make(chan struct{}, 1),
+	}
+	w.mu.Lock()
+	w.watches[sw] = true
+	w.mu.Unlock()
+
+	eventCh := make(chan pollevent.Event)
+	go func() {
+		defer close(eventCh)
+		defer func() {
+			w.mu.Lock()
+			delete(w.watches, sw)
+			w.mu.Unlock()
+		}()
+		if !sw.sendEvent(ctx, eventCh, pollevent.Event{Type: pollevent.SyncEvent}) {
+			return
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sw.notify:
+				for _, e := range sw.dequeue() {
+					if !sw.sendEvent(ctx, eventCh, e) {
+						return
+					}
+				}
+			}
+		}
+	}()
+	return eventCh
+}
+
+// statusWatch is the queue of status updates of one call to Watch.
+type statusWatch struct {
+	ids    object.ObjMetadataSet
+	mu     sync.Mutex
+	queue  []pollevent.Event
+	notify chan struct{}
+}
+
+func (sw *statusWatch) enqueue(e pollevent.Event) {
+	sw.mu.Lock()
+	sw.queue = append(sw.queue, e)
+	sw.mu.Unlock()
+	select {
+	case sw.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (sw *statusWatch) dequeue() []pollevent.Event {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	events := sw.queue
+	sw.queue = nil
+	return events
+}
+
+// sendEvent returns false if the context was cancelled before the event was
+// sent.
+func (sw *statusWatch) sendEvent(ctx context.Context, eventCh chan<- pollevent.Event, e pollevent.Event) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case eventCh <- e:
+		return true
+	}
+}
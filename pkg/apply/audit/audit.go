@@ -0,0 +1,254 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit records what the Applier and Destroyer changed, for a durable
+// trail of the changes made to a cluster.
+//
+// The Recorder is an event.Sink that builds the Record of a run from its
+// events: the inventory ID, the actor and field manager, and the operation
+// and content digest of every object. Once the run is done, Write stores the
+// Record in a Sink, like a file, a ConfigMap, or a webhook:
+//
+//	recorder := audit.NewRecorder(audit.NewFileSink("audit.jsonl"), invInfo.ID(), audit.Options{
+//		Actor:        "ci",
+//		FieldManager: options.ServerSideOptions.FieldManager,
+//	})
+//	mux := event.NewMultiplexer(recorder)
+//	mux.Run(applier.Run(ctx, invInfo, objs, options))
+//	if err := recorder.Write(ctx); err != nil {
+//		return err
+//	}
+//
+// To record the full diff of every object, compute the diffs with
+// Applier.Diff before the run, and pass them in the Options. The diffs and
+// errors are redacted by the Redactor of the Options, which removes the
+// values of Secrets by default.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/print/redact"
+)
+
+// Record is the audit record of a run.
+type Record struct {
+	// InventoryID is the ID of the inventory of the run.
+	InventoryID string `json:"inventoryID"`
+	// Actor is who started the run.
+	Actor string `json:"actor,omitempty"`
+	// FieldManager is the field manager of the applied objects.
+	FieldManager string `json:"fieldManager,omitempty"`
+	// StartTime is when the first event of the run was received, and
+	// EndTime is when the record was written.
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	// Objects are the objects that were applied, pruned, or deleted, or that
+	// failed or were skipped, in the order of their events.
+	Objects []ObjectRecord `json:"objects"`
+	// Error is the fatal error that ended the run, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// ObjectRecord is the audit record of an object.
+type ObjectRecord struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Action is Apply, Prune, or Delete.
+	Action string `json:"action"`
+	// Status is Successful, Skipped, or Failed.
+	Status string `json:"status"`
+	// Operation is the change made to the object, for successful actions:
+	// Created, Configured, Unchanged, or ServersideApplied for applies,
+	// Pruned or Orphaned for prunes, and Deleted for deletes.
+	Operation string `json:"operation,omitempty"`
+	// Digest is the SHA-256 digest of the content of the object, without the
+	// metadata set by the server and the status. For pruned and deleted
+	// objects, it is the digest of the last version of the object.
+	Digest string `json:"digest,omitempty"`
+	// Diff is the unified diff of the object, if the diffs were passed in
+	// the Options.
+	Diff string `json:"diff,omitempty"`
+	// Error is the reason the object failed or was skipped.
+	Error string `json:"error,omitempty"`
+}
+
+// Options are the options of a Recorder.
+type Options struct {
+	// Actor is who started the run, like a user or a CI job.
+	Actor string
+	// FieldManager is the field manager of the applied objects.
+	FieldManager string
+	// Diffs, if set, are the diffs of the objects, computed with
+	// Applier.Diff before the run. The unified diff of each object is added
+	// to its record.
+	Diffs []apply.ObjectDiff
+	// Redactor removes sensitive data from the diffs and errors before they
+	// are recorded. Defaults to redact.SecretData, so that the values of
+	// Secrets are not recorded.
+	Redactor redact.Redactor
+}
+
+// Recorder builds the audit Record of a run from its events, and writes it
+// to a Sink. It implements event.Sink.
+type Recorder struct {
+	sink     Sink
+	redactor redact.Redactor
+	// diffs are the redacted unified diffs of the changed objects.
+	diffs  map[object.ObjMetadata]string
+	mu     sync.Mutex
+	record Record
+	now    func() time.Time
+}
+
+var _ event.Sink = &Recorder{}
+
+// NewRecorder returns a Recorder for a run of the inventory, that writes to
+// the sink.
+func NewRecorder(sink Sink, inventoryID string, options Options) *Recorder {
+	redactor := options.Redactor
+	if redactor == nil {
+		redactor = redact.SecretData{}
+	}
+	diffs := make(map[object.ObjMetadata]string)
+	for _, d := range options.Diffs {
+		if _, found := diffs[d.Identifier]; found || d.Action == apply.DiffUnchanged {
+			continue
+		}
+		// A diff that can't be formatted is left out of the record.
+		if diff, err := d.Redact(redactor).UnifiedDiff(); err == nil {
+			diffs[d.Identifier] = redactor.RedactMessage(diff)
+		}
+	}
+	return &Recorder{
+		sink:     sink,
+		redactor: redactor,
+		diffs:    diffs,
+		record: Record{
+			InventoryID:  inventoryID,
+			Actor:        options.Actor,
+			FieldManager: options.FieldManager,
+			Objects:      []ObjectRecord{},
+		},
+		now: time.Now,
+	}
+}
+
+// Send adds the object of an apply, prune, or delete event to the record.
+func (r *Recorder) Send(e event.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.record.StartTime.IsZero() {
+		r.record.StartTime = r.now()
+	}
+	switch e.Type {
+	case event.ErrorType:
+		r.record.Error = r.redactor.RedactMessage(e.ErrorEvent.Err.Error())
+	case event.ApplyType:
+		ae := e.ApplyEvent
+		if ae.Status == event.ApplyPending {
+			return
+		}
+		rec := r.objectRecord(ae.Identifier, event.ApplyAction, ae.Status.String(), ae.Resource, ae.Error)
+		if ae.Status == event.ApplySuccessful {
+			rec.Operation = ae.Operation.String()
+		}
+		r.record.Objects = append(r.record.Objects, rec)
+	case event.PruneType:
+		pe := e.PruneEvent
+		if pe.Status == event.PrunePending {
+			return
+		}
+		rec := r.objectRecord(pe.Identifier, event.PruneAction, pe.Status.String(), pe.Object, pe.Error)
+		switch {
+		case pe.Status == event.PruneSuccessful:
+			rec.Operation = "Pruned"
+		case pe.Operation == event.PruneOrphaned:
+			rec.Operation = pe.Operation.String()
+		}
+		r.record.Objects = append(r.record.Objects, rec)
+	case event.DeleteType:
+		de := e.DeleteEvent
+		if de.Status == event.DeletePending {
+			return
+		}
+		rec := r.objectRecord(de.Identifier, event.DeleteAction, de.Status.String(), de.Object, de.Error)
+		if de.Status == event.DeleteSuccessful {
+			rec.Operation = "Deleted"
+		}
+		r.record.Objects = append(r.record.Objects, rec)
+	}
+}
+
+func (r *Recorder) objectRecord(id object.ObjMetadata, action event.ResourceAction, status string,
+	obj *unstructured.Unstructured, err error) ObjectRecord {
+	rec := ObjectRecord{
+		Group:     id.GroupKind.Group,
+		Kind:      id.GroupKind.Kind,
+		Namespace: id.Namespace,
+		Name:      id.Name,
+		Action:    action.String(),
+		Status:    status,
+		Digest:    Digest(obj),
+		Diff:      r.diffs[id],
+	}
+	if err != nil {
+		rec.Error = r.redactor.RedactMessage(err.Error())
+	}
+	return rec
+}
+
+// Record returns a copy of the record built so far.
+func (r *Recorder) Record() Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	record := r.record
+	record.Objects = append([]ObjectRecord{}, r.record.Objects...)
+	return record
+}
+
+// Write sets the end time of the record and writes it to the sink. It is
+// called once the event channel of the run is closed.
+func (r *Recorder) Write(ctx context.Context) error {
+	r.mu.Lock()
+	r.record.EndTime = r.now()
+	if r.record.StartTime.IsZero() {
+		r.record.StartTime = r.record.EndTime
+	}
+	r.mu.Unlock()
+	return r.sink.Write(ctx, r.Record())
+}
+
+// Digest returns the SHA-256 digest of the content of the object, without
+// the metadata set by the server and the status, or an empty string for a
+// nil object.
+func Digest(obj *unstructured.Unstructured) string {
+	if obj == nil {
+		return ""
+	}
+	obj = obj.DeepCopy()
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+	obj.SetGeneration(0)
+	obj.SetUID("")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(obj.Object, "status")
+	// Map keys are sorted by encoding/json, so the digest is stable.
+	b, err := json.Marshal(obj.Object)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
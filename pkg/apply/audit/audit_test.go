@@ -0,0 +1,298 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/print/redact"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+var deploymentManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: foo
+  namespace: default
+spec:
+  replicas: 1
+`
+
+var secretManifest = `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+  namespace: default
+type: Opaque
+`
+
+type fakeSink struct {
+	records []Record
+}
+
+func (s *fakeSink) Write(_ context.Context, record Record) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func TestRecorder(t *testing.T) {
+	deployment := testutil.Unstructured(t, deploymentManifest)
+	deploymentID := object.UnstructuredToObjMetadata(deployment)
+	secret := testutil.Unstructured(t, secretManifest)
+	secretID := object.UnstructuredToObjMetadata(secret)
+
+	liveDeployment := deployment.DeepCopy()
+	require.NoError(t, unstructured.SetNestedField(liveDeployment.Object, int64(3), "spec", "replicas"))
+
+	sink := &fakeSink{}
+	recorder := NewRecorder(sink, "test-inv", Options{
+		Actor:        "ci",
+		FieldManager: "kubectl",
+		Diffs: []apply.ObjectDiff{
+			{Identifier: deploymentID, Action: apply.DiffUpdate, Live: liveDeployment, Merged: deployment},
+		},
+	})
+	start := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	now := start
+	recorder.now = func() time.Time {
+		defer func() { now = now.Add(time.Second) }()
+		return now
+	}
+
+	for _, e := range []event.Event{
+		{Type: event.InitType},
+		{
+			Type: event.ApplyType,
+			ApplyEvent: event.ApplyEvent{
+				Identifier: deploymentID,
+				Status:     event.ApplyPending,
+			},
+		},
+		{
+			Type: event.ApplyType,
+			ApplyEvent: event.ApplyEvent{
+				Identifier: deploymentID,
+				Status:     event.ApplySuccessful,
+				Operation:  event.ApplyConfigured,
+				Resource:   deployment,
+			},
+		},
+		{
+			Type: event.PruneType,
+			PruneEvent: event.PruneEvent{
+				Identifier: secretID,
+				Status:     event.PruneFailed,
+				Object:     secret,
+				Error:      fmt.Errorf("forbidden"),
+			},
+		},
+	} {
+		recorder.Send(e)
+	}
+	require.NoError(t, recorder.Write(context.Background()))
+
+	require.Len(t, sink.records, 1)
+	record := sink.records[0]
+	assert.Equal(t, "test-inv", record.InventoryID)
+	assert.Equal(t, "ci", record.Actor)
+	assert.Equal(t, "kubectl", record.FieldManager)
+	assert.Equal(t, start, record.StartTime)
+	assert.Equal(t, start.Add(time.Second), record.EndTime)
+	require.Len(t, record.Objects, 2)
+
+	assert.Equal(t, ObjectRecord{
+		Group:     "apps",
+		Kind:      "Deployment",
+		Namespace: "default",
+		Name:      "foo",
+		Action:    "Apply",
+		Status:    "Successful",
+		Operation: "Configured",
+		Digest:    Digest(deployment),
+	}, withoutDiff(record.Objects[0]))
+	assert.Contains(t, record.Objects[0].Diff, "-  replicas: 3\n+  replicas: 1\n")
+
+	assert.Equal(t, ObjectRecord{
+		Kind:      "Secret",
+		Namespace: "default",
+		Name:      "secret",
+		Action:    "Prune",
+		Status:    "Failed",
+		Digest:    Digest(secret),
+		Error:     "forbidden",
+	}, record.Objects[1])
+}
+
+func TestRecorder_Redact(t *testing.T) {
+	secret := testutil.Unstructured(t, secretManifest)
+	require.NoError(t, unstructured.SetNestedField(secret.Object, "bmV3", "data", "password"))
+	secretID := object.UnstructuredToObjMetadata(secret)
+	liveSecret := secret.DeepCopy()
+	require.NoError(t, unstructured.SetNestedField(liveSecret.Object, "b2xk", "data", "password"))
+	diffs := []apply.ObjectDiff{
+		{Identifier: secretID, Action: apply.DiffUpdate, Live: liveSecret, Merged: secret},
+	}
+
+	testCases := map[string]struct {
+		redactor      redact.Redactor
+		expectedError string
+	}{
+		"secret data is redacted by default": {
+			expectedError: "password bmV3 rejected",
+		},
+		"custom redactor": {
+			redactor: redact.Chain{
+				redact.SecretData{},
+				redact.Messages{Patterns: []*regexp.Regexp{regexp.MustCompile("bmV3")}},
+			},
+			expectedError: "password REDACTED rejected",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			sink := &fakeSink{}
+			recorder := NewRecorder(sink, "test-inv", Options{
+				Diffs:    diffs,
+				Redactor: tc.redactor,
+			})
+			recorder.Send(event.Event{
+				Type: event.ApplyType,
+				ApplyEvent: event.ApplyEvent{
+					Identifier: secretID,
+					Status:     event.ApplyFailed,
+					Resource:   secret,
+					Error:      fmt.Errorf("password bmV3 rejected"),
+				},
+			})
+			require.NoError(t, recorder.Write(context.Background()))
+
+			require.Len(t, sink.records, 1)
+			require.Len(t, sink.records[0].Objects, 1)
+			rec := sink.records[0].Objects[0]
+			// Both sides of the diff are redacted, so the change is hidden.
+			assert.NotContains(t, rec.Diff, "b2xk")
+			assert.NotContains(t, rec.Diff, "bmV3")
+			assert.Equal(t, tc.expectedError, rec.Error)
+		})
+	}
+}
+
+func withoutDiff(rec ObjectRecord) ObjectRecord {
+	rec.Diff = ""
+	return rec
+}
+
+func TestDigest(t *testing.T) {
+	deployment := testutil.Unstructured(t, deploymentManifest)
+	digest := Digest(deployment)
+	assert.True(t, strings.HasPrefix(digest, "sha256:"))
+
+	// The metadata set by the server and the status are ignored.
+	live := deployment.DeepCopy()
+	live.SetResourceVersion("42")
+	live.SetUID("uid")
+	live.SetGeneration(3)
+	live.SetCreationTimestamp(metav1.Now())
+	require.NoError(t, unstructured.SetNestedField(live.Object, int64(1), "status", "replicas"))
+	assert.Equal(t, digest, Digest(live))
+
+	changed := deployment.DeepCopy()
+	require.NoError(t, unstructured.SetNestedField(changed.Object, int64(2), "spec", "replicas"))
+	assert.NotEqual(t, digest, Digest(changed))
+
+	assert.Empty(t, Digest(nil))
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink := NewFileSink(path)
+	require.NoError(t, sink.Write(context.Background(), Record{InventoryID: "first", Objects: []ObjectRecord{}}))
+	require.NoError(t, sink.Write(context.Background(), Record{InventoryID: "second", Objects: []ObjectRecord{}}))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	require.Len(t, lines, 2)
+	var record Record
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	assert.Equal(t, "second", record.InventoryID)
+}
+
+func TestConfigMapSink(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	sink := NewConfigMapSink(client, "audit", "records")
+	sink.MaxRecords = 2
+
+	start := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, sink.Write(context.Background(), Record{
+			InventoryID: fmt.Sprintf("run-%d", i),
+			StartTime:   start.Add(time.Duration(i) * time.Minute),
+			Objects:     []ObjectRecord{},
+		}))
+	}
+
+	cm, err := client.Resource(configMapGVR).Namespace("audit").Get(context.Background(), "records", metav1.GetOptions{})
+	require.NoError(t, err)
+	data, _, err := unstructured.NestedStringMap(cm.Object, "data")
+	require.NoError(t, err)
+	// The oldest record was removed.
+	assert.Len(t, data, 2)
+	assert.NotContains(t, data, "20220301T100000.000000000Z")
+	var record Record
+	require.NoError(t, json.Unmarshal([]byte(data["20220301T100200.000000000Z"]), &record))
+	assert.Equal(t, "run-2", record.InventoryID)
+}
+
+func TestWebhookSink(t *testing.T) {
+	var received Record
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if received.InventoryID == "rejected" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("not allowed"))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL)
+	sink.Header = http.Header{"Authorization": []string{"Bearer token"}}
+	require.NoError(t, sink.Write(context.Background(), Record{InventoryID: "test-inv", Objects: []ObjectRecord{}}))
+	assert.Equal(t, "test-inv", received.InventoryID)
+	assert.Equal(t, "Bearer token", auth)
+
+	err := sink.Write(context.Background(), Record{InventoryID: "rejected", Objects: []ObjectRecord{}})
+	assert.EqualError(t, err, "audit webhook returned 403 Forbidden: not allowed")
+}
@@ -0,0 +1,207 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+)
+
+// Sink stores audit records.
+type Sink interface {
+	Write(ctx context.Context, record Record) error
+}
+
+// FileSink appends each record to a file, as a line of JSON.
+type FileSink struct {
+	// Path is the path of the file. It is created if it does not exist.
+	Path string
+
+	mu sync.Mutex
+}
+
+var _ Sink = &FileSink{}
+
+// NewFileSink returns a FileSink for the path.
+func NewFileSink(path string) *FileSink {
+	return &FileSink{Path: path}
+}
+
+// Write appends the record to the file.
+func (s *FileSink) Write(_ context.Context, record Record) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// DefaultMaxRecords is the number of records kept by a ConfigMapSink, if
+// MaxRecords is not set.
+const DefaultMaxRecords = 20
+
+var configMapGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+// ConfigMapSink stores the records in a ConfigMap, one record per key. The
+// keys are the start times of the runs, so the oldest records are removed
+// first when there are more than MaxRecords. The ConfigMap is created if it
+// does not exist. Since a ConfigMap is limited to 1MiB, records with diffs
+// should be stored with a small MaxRecords, or in another sink.
+type ConfigMapSink struct {
+	Client    dynamic.Interface
+	Namespace string
+	Name      string
+	// MaxRecords is the number of records to keep. If zero,
+	// DefaultMaxRecords is used.
+	MaxRecords int
+}
+
+var _ Sink = &ConfigMapSink{}
+
+// NewConfigMapSink returns a ConfigMapSink for the ConfigMap.
+func NewConfigMapSink(client dynamic.Interface, namespace, name string) *ConfigMapSink {
+	return &ConfigMapSink{
+		Client:    client,
+		Namespace: namespace,
+		Name:      name,
+	}
+}
+
+// Write adds the record to the ConfigMap, and removes the oldest records.
+func (s *ConfigMapSink) Write(ctx context.Context, record Record) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	key := record.StartTime.UTC().Format("20060102T150405.000000000Z")
+	client := s.Client.Resource(configMapGVR).Namespace(s.Namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := client.Get(ctx, s.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			cm = &unstructured.Unstructured{}
+			cm.SetAPIVersion("v1")
+			cm.SetKind("ConfigMap")
+			cm.SetNamespace(s.Namespace)
+			cm.SetName(s.Name)
+			s.setData(cm, map[string]string{key: string(b)})
+			_, err = client.Create(ctx, cm, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		data, _, err := unstructured.NestedStringMap(cm.Object, "data")
+		if err != nil {
+			return err
+		}
+		if data == nil {
+			data = make(map[string]string)
+		}
+		data[key] = string(b)
+		s.setData(cm, data)
+		_, err = client.Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// setData sets the data of the ConfigMap, without the oldest records.
+func (s *ConfigMapSink) setData(cm *unstructured.Unstructured, data map[string]string) {
+	maxRecords := s.MaxRecords
+	if maxRecords <= 0 {
+		maxRecords = DefaultMaxRecords
+	}
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for len(keys) > maxRecords {
+		delete(data, keys[0])
+		keys = keys[1:]
+	}
+	obj := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		obj[k] = v
+	}
+	cm.Object["data"] = obj
+}
+
+// DefaultWebhookTimeout is the timeout of each webhook request, if the
+// WebhookSink has no Client.
+const DefaultWebhookTimeout = 10 * time.Second
+
+// WebhookSink sends each record as a JSON POST to a URL.
+type WebhookSink struct {
+	// URL is the webhook endpoint.
+	URL string
+	// Client is the HTTP client. If nil, a client with
+	// DefaultWebhookTimeout is used.
+	Client *http.Client
+	// Header is added to each request, for example for authentication.
+	Header http.Header
+}
+
+var _ Sink = &WebhookSink{}
+
+// NewWebhookSink returns a WebhookSink for the URL.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{URL: url}
+}
+
+// Write sends the record to the webhook. Any response status other than 2xx
+// is an error.
+func (s *WebhookSink) Write(ctx context.Context, record Record) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range s.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultWebhookTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// Include the start of the body, which usually explains the error.
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("audit webhook returned %s: %s", resp.Status, msg)
+	}
+	return nil
+}
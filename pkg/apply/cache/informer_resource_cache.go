@@ -0,0 +1,235 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// DefaultInformerSyncTimeout is the default maximum time to wait for the
+// informer of a resource type to sync, the first time an object of that type
+// is retrieved.
+const DefaultInformerSyncTimeout = 30 * time.Second
+
+// InformerResourceCache stores ResourceStatus objects backed by shared
+// informers, so that objects retrieved by filters, mutators, and tasks do not
+// need to be fetched from the cluster again. An informer is started for each
+// resource type the first time an object of that type is retrieved.
+//
+// Objects stored with Load or Put take precedence over the informers, until
+// they expire after the TTL or are removed. This allows the latest status
+// known to the caller to be used, even if the informer has not observed it
+// yet.
+//
+// InformerResourceCache is thread-safe and may be reused across Applier runs.
+// Stop must be called to stop the informers when the cache is no longer
+// needed.
+type InformerResourceCache struct {
+	// SyncTimeout is the maximum time to wait for the informer of a resource
+	// type to sync. If this is not provided, the default is
+	// DefaultInformerSyncTimeout.
+	SyncTimeout time.Duration
+
+	mapper  meta.RESTMapper
+	factory dynamicinformer.DynamicSharedInformerFactory
+	ttl     time.Duration
+	now     func() time.Time
+
+	mu       sync.RWMutex
+	entries  map[object.ObjMetadata]informerCacheEntry
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// informerCacheEntry is a ResourceStatus stored with Load or Put, which
+// takes precedence over the informers until it expires.
+type informerCacheEntry struct {
+	value   ResourceStatus
+	expires time.Time
+}
+
+var _ ResourceCache = &InformerResourceCache{}
+
+// NewInformerResourceCache returns a new InformerResourceCache. Objects
+// stored with Load or Put expire after the TTL. If the TTL is zero or
+// negative, they never expire, and only Remove and Clear invalidate them.
+func NewInformerResourceCache(client dynamic.Interface, mapper meta.RESTMapper, ttl time.Duration) *InformerResourceCache {
+	return &InformerResourceCache{
+		mapper:  mapper,
+		factory: dynamicinformer.NewDynamicSharedInformerFactory(client, 0),
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[object.ObjMetadata]informerCacheEntry),
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// Load resources into the cache, generating the ID from the resource itself.
+// Existing resources with the same ID will be replaced.
+func (rc *InformerResourceCache) Load(values ...ResourceStatus) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for _, value := range values {
+		id := object.UnstructuredToObjMetadata(value.Resource)
+		rc.entries[id] = rc.newEntry(value)
+	}
+}
+
+// Put the resource into the cache using the supplied ID, replacing any
+// existing resource with the same ID.
+func (rc *InformerResourceCache) Put(id object.ObjMetadata, value ResourceStatus) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.entries[id] = rc.newEntry(value)
+}
+
+func (rc *InformerResourceCache) newEntry(value ResourceStatus) informerCacheEntry {
+	entry := informerCacheEntry{value: value}
+	if rc.ttl > 0 {
+		entry.expires = rc.now().Add(rc.ttl)
+	}
+	return entry
+}
+
+// Get retrieves the resource associated with the ID. Resources stored with
+// Load or Put are returned until they expire. Otherwise, the resource is
+// retrieved from the informer for its type, which is started if necessary.
+// Returns NotFound status if the informer does not have the resource, and
+// Unknown status if the informer could not be synced.
+func (rc *InformerResourceCache) Get(id object.ObjMetadata) ResourceStatus {
+	if value, found := rc.getEntry(id); found {
+		klog.V(6).Infof("resource cache hit: %s", id)
+		return value
+	}
+	klog.V(6).Infof("resource cache miss: %s", id)
+
+	obj, err := rc.getFromInformer(id)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ResourceStatus{
+				Status:        status.NotFoundStatus,
+				StatusMessage: "Resource not found",
+			}
+		}
+		klog.V(3).Infof("resource cache lookup failed: %s: %v", id, err)
+		return ResourceStatus{
+			Status:        status.UnknownStatus,
+			StatusMessage: err.Error(),
+		}
+	}
+	result, err := status.Compute(obj)
+	if err != nil {
+		return ResourceStatus{
+			Resource:      obj,
+			Status:        status.UnknownStatus,
+			StatusMessage: fmt.Sprintf("failed to compute status: %v", err),
+		}
+	}
+	return ResourceStatus{
+		Resource:      obj,
+		Status:        result.Status,
+		StatusMessage: result.Message,
+	}
+}
+
+// getEntry returns the resource stored with Load or Put, if not expired.
+func (rc *InformerResourceCache) getEntry(id object.ObjMetadata) (ResourceStatus, bool) {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+
+	entry, found := rc.entries[id]
+	if !found {
+		return ResourceStatus{}, false
+	}
+	if !entry.expires.IsZero() && !rc.now().Before(entry.expires) {
+		return ResourceStatus{}, false
+	}
+	return entry.value, true
+}
+
+// getFromInformer returns a copy of the object from the informer for its
+// type, starting and syncing the informer if necessary.
+func (rc *InformerResourceCache) getFromInformer(id object.ObjMetadata) (*unstructured.Unstructured, error) {
+	mapping, err := rc.mapper.RESTMapping(id.GroupKind)
+	if err != nil {
+		return nil, err
+	}
+	informer := rc.factory.ForResource(mapping.Resource)
+	// Start only starts informers that have not been started yet.
+	rc.factory.Start(rc.stopCh)
+	if !rc.waitForSync(informer.Informer()) {
+		return nil, fmt.Errorf("timed out waiting for %s informer to sync", mapping.Resource)
+	}
+	var obj runtime.Object
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		obj, err = informer.Lister().ByNamespace(id.Namespace).Get(id.Name)
+	} else {
+		obj, err = informer.Lister().Get(id.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type from informer: %T", obj)
+	}
+	// Objects in the informer store must not be modified.
+	return u.DeepCopy(), nil
+}
+
+// waitForSync waits for the informer to sync, up to the SyncTimeout.
+// Returns false if the informer did not sync in time.
+func (rc *InformerResourceCache) waitForSync(informer toolscache.SharedIndexInformer) bool {
+	if informer.HasSynced() {
+		return true
+	}
+	timeout := rc.SyncTimeout
+	if timeout <= 0 {
+		timeout = DefaultInformerSyncTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return toolscache.WaitForCacheSync(ctx.Done(), informer.HasSynced)
+}
+
+// Remove the resource associated with the ID from the cache, so that it is
+// retrieved from the informer the next time.
+func (rc *InformerResourceCache) Remove(id object.ObjMetadata) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	delete(rc.entries, id)
+}
+
+// Clear the resources stored with Load or Put, so that they are retrieved
+// from the informers the next time. The informers keep running.
+func (rc *InformerResourceCache) Clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.entries = make(map[object.ObjMetadata]informerCacheEntry)
+}
+
+// Stop stops the informers. The cache must not be used afterwards.
+func (rc *InformerResourceCache) Stop() {
+	rc.stopOnce.Do(func() {
+		close(rc.stopCh)
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+var configMapGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+func newConfigMap(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "default",
+			},
+		},
+	}
+}
+
+func newTestInformerResourceCache(ttl time.Duration, objs ...runtime.Object) *InformerResourceCache {
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{configMapGVR: "ConfigMapList"}, objs...)
+	mapper := testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme,
+		scheme.Scheme.PrioritizedVersionsAllGroups()...)
+	rc := NewInformerResourceCache(client, mapper, ttl)
+	rc.SyncTimeout = 10 * time.Second
+	return rc
+}
+
+func TestInformerResourceCache_Get(t *testing.T) {
+	cm := newConfigMap("cm")
+	rc := newTestInformerResourceCache(0, cm)
+	defer rc.Stop()
+
+	result := rc.Get(object.UnstructuredToObjMetadata(cm))
+	assert.Equal(t, status.CurrentStatus, result.Status)
+	if assert.NotNil(t, result.Resource) {
+		assert.Equal(t, "cm", result.Resource.GetName())
+	}
+
+	result = rc.Get(object.UnstructuredToObjMetadata(newConfigMap("missing")))
+	assert.Equal(t, status.NotFoundStatus, result.Status)
+	assert.Nil(t, result.Resource)
+
+	// Types unknown to the mapper can't be retrieved.
+	result = rc.Get(object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "example.com", Kind: "Unknown"},
+		Namespace: "default",
+		Name:      "unknown",
+	})
+	assert.Equal(t, status.UnknownStatus, result.Status)
+}
+
+func TestInformerResourceCache_PutExpires(t *testing.T) {
+	cm := newConfigMap("cm")
+	id := object.UnstructuredToObjMetadata(cm)
+	rc := newTestInformerResourceCache(time.Minute, cm)
+	defer rc.Stop()
+
+	now := time.Now()
+	rc.now = func() time.Time { return now }
+
+	rc.Put(id, ResourceStatus{
+		Resource: cm,
+		Status:   status.InProgressStatus,
+	})
+	assert.Equal(t, status.InProgressStatus, rc.Get(id).Status)
+
+	// After the TTL, the informer is used.
+	now = now.Add(time.Minute)
+	assert.Equal(t, status.CurrentStatus, rc.Get(id).Status)
+}
+
+func TestInformerResourceCache_Remove(t *testing.T) {
+	cm := newConfigMap("cm")
+	id := object.UnstructuredToObjMetadata(cm)
+	rc := newTestInformerResourceCache(0, cm)
+	defer rc.Stop()
+
+	rc.Load(ResourceStatus{
+		Resource: cm,
+		Status:   status.InProgressStatus,
+	})
+	assert.Equal(t, status.InProgressStatus, rc.Get(id).Status)
+
+	rc.Remove(id)
+	assert.Equal(t, status.CurrentStatus, rc.Get(id).Status)
+
+	rc.Put(id, ResourceStatus{Status: status.FailedStatus})
+	assert.Equal(t, status.FailedStatus, rc.Get(id).Status)
+
+	rc.Clear()
+	assert.Equal(t, status.CurrentStatus, rc.Get(id).Status)
+}
@@ -0,0 +1,188 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/apply/cache"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/flowcontrol"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/engine"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/statusreaders"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
+)
+
+// rateLimitTimeout is how long to wait for the server when checking whether
+// API Priority and Fairness is enabled.
+const rateLimitTimeout = 5 * time.Second
+
+// defaultInventoryClientFactory is used to build the inventory client, if
+// neither the client nor a factory for it have been provided.
+var defaultInventoryClientFactory = inventory.ClusterClientFactory{
+	StatusPolicy: inventory.StatusPolicyAll,
+}
+
+// commonBuilder contains the options shared by the ApplierBuilder and the
+// DestroyerBuilder.
+type commonBuilder struct {
+	// factory is only used to retrieve things that have not been provided explicitly.
+	factory                      util.Factory
+	invClient                    inventory.Client
+	invClientFactory             inventory.ClientFactory
+	client                       dynamic.Interface
+	discoClient                  discovery.CachedDiscoveryInterface
+	mapper                       meta.RESTMapper
+	restConfig                   *rest.Config
+	unstructuredClientForMapping func(*meta.RESTMapping) (resource.RESTClient, error)
+	statusWatcher                watcher.StatusWatcher
+	statusReaders                []engine.StatusReader
+	statusRules                  status.Rules
+	rateLimits                   *flowcontrol.RateLimits
+	resourceCache                cache.ResourceCache
+	logger                       logr.Logger
+	// rateLimitEvent is populated by finalize, if rateLimits is provided.
+	rateLimitEvent *event.RateLimitEvent
+}
+
+// finalize returns a copy of the builder, with everything that has not been
+// provided explicitly retrieved from the factory. If only a rest config has
+// been provided, the factory is built from it.
+func (cb *commonBuilder) finalize() (*commonBuilder, error) {
+	cx := *cb // make a copy before mutating any fields. Shallow copy is good enough.
+	var err error
+	if cx.factory == nil && cx.restConfig != nil {
+		cx.factory = util.NewFactory(newRESTConfigGetter(cx.restConfig))
+	}
+	if cx.rateLimits != nil {
+		if err := cx.configureRateLimits(); err != nil {
+			return nil, err
+		}
+	}
+	if cx.client == nil {
+		if cx.factory == nil {
+			return nil, fmt.Errorf("a factory must be provided or all other options: %v", err)
+		}
+		cx.client, err = cx.factory.DynamicClient()
+		if err != nil {
+			return nil, fmt.Errorf("error getting dynamic client: %v", err)
+		}
+	}
+	if cx.discoClient == nil {
+		if cx.factory == nil {
+			return nil, fmt.Errorf("a factory must be provided or all other options: %v", err)
+		}
+		cx.discoClient, err = cx.factory.ToDiscoveryClient()
+		if err != nil {
+			return nil, fmt.Errorf("error getting discovery client: %v", err)
+		}
+	}
+	if cx.mapper == nil {
+		if cx.factory == nil {
+			return nil, fmt.Errorf("a factory must be provided or all other options: %v", err)
+		}
+		cx.mapper, err = cx.factory.ToRESTMapper()
+		if err != nil {
+			return nil, fmt.Errorf("error getting rest mapper: %v", err)
+		}
+	}
+	if cx.restConfig == nil {
+		if cx.factory == nil {
+			return nil, fmt.Errorf("a factory must be provided or all other options: %v", err)
+		}
+		cx.restConfig, err = cx.factory.ToRESTConfig()
+		if err != nil {
+			return nil, fmt.Errorf("error getting rest config: %v", err)
+		}
+	}
+	if cx.unstructuredClientForMapping == nil {
+		if cx.factory == nil {
+			return nil, fmt.Errorf("a factory must be provided or all other options: %v", err)
+		}
+		cx.unstructuredClientForMapping = cx.factory.UnstructuredClientForMapping
+	}
+	if cx.invClient == nil {
+		if cx.factory == nil {
+			return nil, errors.New("inventory client must be provided")
+		}
+		invClientFactory := cx.invClientFactory
+		if invClientFactory == nil {
+			invClientFactory = defaultInventoryClientFactory
+		}
+		cx.invClient, err = invClientFactory.NewClient(cx.factory)
+		if err != nil {
+			return nil, fmt.Errorf("error getting inventory client: %v", err)
+		}
+	}
+	if cx.statusWatcher == nil {
+		statusWatcher := watcher.NewDefaultStatusWatcher(cx.client, cx.mapper)
+		statusReaders := cx.statusReaders
+		if len(cx.statusRules) > 0 {
+			statusReaders = append([]engine.StatusReader{
+				statusreaders.NewRulesStatusReader(cx.mapper, cx.statusRules),
+			}, statusReaders...)
+		}
+		if len(statusReaders) > 0 {
+			statusWatcher.StatusReader = statusreaders.NewDefaultStatusReader(cx.mapper, statusReaders...)
+		}
+		cx.statusWatcher = statusWatcher
+	}
+	return &cx, nil
+}
+
+// configureRateLimits configures the client-side rate limits on a copy of
+// the rest config, and builds the dynamic and discovery clients that have not
+// been provided explicitly from it.
+func (cb *commonBuilder) configureRateLimits() error {
+	var err error
+	if cb.restConfig == nil {
+		if cb.factory == nil {
+			return fmt.Errorf("a factory must be provided or all other options: %v", err)
+		}
+		cb.restConfig, err = cb.factory.ToRESTConfig()
+		if err != nil {
+			return fmt.Errorf("error getting rest config: %v", err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), rateLimitTimeout)
+	defer cancel()
+	restConfig := rest.CopyConfig(cb.restConfig)
+	limits, enabled, err := flowcontrol.ConfigureRateLimits(ctx, restConfig, *cb.rateLimits)
+	if err != nil {
+		return err
+	}
+	cb.restConfig = restConfig
+	cb.rateLimitEvent = &event.RateLimitEvent{
+		PriorityAndFairnessEnabled: enabled,
+		QPS:                        limits.QPS,
+		Burst:                      limits.Burst,
+	}
+	if cb.client == nil {
+		cb.client, err = dynamic.NewForConfig(restConfig)
+		if err != nil {
+			return fmt.Errorf("error getting dynamic client: %v", err)
+		}
+	}
+	if cb.discoClient == nil {
+		discoClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+		if err != nil {
+			return fmt.Errorf("error getting discovery client: %v", err)
+		}
+		cb.discoClient = memory.NewMemCacheClient(discoClient)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/cli-utils/pkg/apply/cache"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/statusreaders"
+	fakesr "sigs.k8s.io/cli-utils/pkg/kstatus/polling/statusreaders/fake"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
+)
+
+func TestApplierBuilder_RestConfigDefaults(t *testing.T) {
+	restConfig := &rest.Config{Host: "https://example.com"}
+	resourceCache := cache.NewResourceCacheMap()
+
+	applier, err := NewApplierBuilder().
+		WithRestConfig(restConfig).
+		WithFieldManager("my-controller").
+		WithResourceCache(resourceCache).
+		Build()
+	require.NoError(t, err)
+
+	assert.NotNil(t, applier.client)
+	assert.NotNil(t, applier.discoClient)
+	assert.NotNil(t, applier.mapper)
+	assert.NotNil(t, applier.infoHelper)
+	assert.NotNil(t, applier.statusWatcher)
+	assert.IsType(t, &inventory.ClusterClient{}, applier.invClient)
+	assert.Equal(t, "my-controller", applier.fieldManager)
+	assert.Equal(t, resourceCache, applier.resourceCache)
+	assert.Nil(t, applier.rateLimitEvent)
+}
+
+func TestDestroyerBuilder_RestConfigDefaults(t *testing.T) {
+	restConfig := &rest.Config{Host: "https://example.com"}
+	invClient := inventory.NewFakeClient(nil)
+
+	destroyer, err := NewDestroyerBuilder().
+		WithRestConfig(restConfig).
+		WithInventoryClient(invClient).
+		Build()
+	require.NoError(t, err)
+
+	assert.NotNil(t, destroyer.client)
+	assert.NotNil(t, destroyer.mapper)
+	assert.NotNil(t, destroyer.infoHelper)
+	assert.NotNil(t, destroyer.statusWatcher)
+	assert.Equal(t, invClient, destroyer.invClient)
+	assert.Equal(t, invClient, destroyer.pruner.InvClient)
+}
+
+func TestApplierBuilder_StatusReaders(t *testing.T) {
+	restConfig := &rest.Config{Host: "https://example.com"}
+	customStatusReader := &fakesr.StatusReader{}
+
+	applier, err := NewApplierBuilder().
+		WithRestConfig(restConfig).
+		WithStatusReaders(customStatusReader).
+		Build()
+	require.NoError(t, err)
+
+	require.IsType(t, &watcher.DefaultStatusWatcher{}, applier.statusWatcher)
+	statusReader := applier.statusWatcher.(*watcher.DefaultStatusWatcher).StatusReader
+	require.IsType(t, &statusreaders.DelegatingStatusReader{}, statusReader)
+	// Custom status readers take precedence over the built-in ones.
+	assert.Same(t, customStatusReader, statusReader.(*statusreaders.DelegatingStatusReader).StatusReaders[0])
+}
+
+func TestApplierBuilder_StatusRules(t *testing.T) {
+	restConfig := &rest.Config{Host: "https://example.com"}
+	customStatusReader := &fakesr.StatusReader{}
+
+	applier, err := NewApplierBuilder().
+		WithRestConfig(restConfig).
+		WithStatusReaders(customStatusReader).
+		WithStatusRules(status.Rules{
+			{Group: "example.com", Kind: "Database", Current: "status.phase == 'Ready'"},
+		}).
+		Build()
+	require.NoError(t, err)
+
+	require.IsType(t, &watcher.DefaultStatusWatcher{}, applier.statusWatcher)
+	statusReader := applier.statusWatcher.(*watcher.DefaultStatusWatcher).StatusReader
+	require.IsType(t, &statusreaders.DelegatingStatusReader{}, statusReader)
+	// Status rules take precedence over the custom status readers.
+	statusReaders := statusReader.(*statusreaders.DelegatingStatusReader).StatusReaders
+	assert.True(t, statusReaders[0].Supports(schema.GroupKind{Group: "example.com", Kind: "Database"}))
+	assert.False(t, statusReaders[0].Supports(schema.GroupKind{Kind: "ConfigMap"}))
+	assert.Same(t, customStatusReader, statusReaders[1])
+}
+
+func TestBuilder_MissingConfig(t *testing.T) {
+	_, err := NewApplierBuilder().Build()
+	assert.Error(t, err)
+
+	_, err = NewDestroyerBuilder().Build()
+	assert.Error(t, err)
+}
+
+func TestRESTConfigGetter(t *testing.T) {
+	restConfig := &rest.Config{Host: "https://example.com"}
+	getter := newRESTConfigGetter(restConfig)
+
+	// Callers may modify the returned config.
+	cfg, err := getter.ToRESTConfig()
+	require.NoError(t, err)
+	cfg.Host = "https://modified.example.com"
+	assert.Equal(t, "https://example.com", restConfig.Host)
+
+	disco1, err := getter.ToDiscoveryClient()
+	require.NoError(t, err)
+	disco2, err := getter.ToDiscoveryClient()
+	require.NoError(t, err)
+	assert.Same(t, disco1, disco2)
+
+	ns, _, err := getter.ToRawKubeConfigLoader().Namespace()
+	require.NoError(t, err)
+	assert.Equal(t, "default", ns)
+}
@@ -8,8 +8,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/klog/v2"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
@@ -26,32 +29,14 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
 )
 
-// NewDestroyer returns a new destroyer. It will set up the ApplyOptions and
-// PruneOptions which are responsible for capturing any command line flags.
-// It currently requires IOStreams, but this is a legacy from when
-// the ApplyOptions were responsible for printing progress. This is now
-// handled by a separate printer with the KubectlPrinterAdapter bridging
-// between the two.
+// NewDestroyer returns a new destroyer, with everything that is not
+// provided retrieved from the factory. Use NewDestroyerBuilder for more
+// options.
 func NewDestroyer(factory cmdutil.Factory, invClient inventory.Client) (*Destroyer, error) {
-	pruner, err := prune.NewPruner(factory, invClient)
-	if err != nil {
-		return nil, fmt.Errorf("error setting up PruneOptions: %w", err)
-	}
-	client, err := factory.DynamicClient()
-	if err != nil {
-		return nil, err
-	}
-	mapper, err := factory.ToRESTMapper()
-	if err != nil {
-		return nil, err
-	}
-	statusWatcher := watcher.NewDefaultStatusWatcher(client, mapper)
-	return &Destroyer{
-		pruner:        pruner,
-		statusWatcher: statusWatcher,
-		factory:       factory,
-		invClient:     invClient,
-	}, nil
+	return NewDestroyerBuilder().
+		WithFactory(factory).
+		WithInventoryClient(invClient).
+		Build()
 }
 
 // Destroyer performs the step of grabbing all the previous inventory objects and
@@ -59,8 +44,20 @@ func NewDestroyer(factory cmdutil.Factory, invClient inventory.Client) (*Destroy
 type Destroyer struct {
 	pruner        *prune.Pruner
 	statusWatcher watcher.StatusWatcher
-	factory       cmdutil.Factory
 	invClient     inventory.Client
+	client        dynamic.Interface
+	openAPIGetter discovery.OpenAPISchemaInterface
+	mapper        meta.RESTMapper
+	infoHelper    info.Helper
+	pipeline      *Pipeline
+	// rateLimitEvent, if not nil, is sent at the start of each run.
+	rateLimitEvent *event.RateLimitEvent
+	// resourceCache, if not nil, is shared by all runs. Otherwise, each run
+	// uses a new ResourceCacheMap.
+	resourceCache cache.ResourceCache
+	// logger, if set, is used instead of klog, unless the context passed to
+	// Run has a logger.
+	logger logr.Logger
 }
 
 type DestroyerOptions struct {
@@ -75,6 +72,11 @@ type DestroyerOptions struct {
 	// to be fully deleted.
 	DeleteTimeout time.Duration
 
+	// WaitTimeouts overrides the DeleteTimeout of individual wait tasks,
+	// by task name (ex: "wait-1"). Individual objects may also override the
+	// timeout with the cli-utils.sigs.k8s.io/reconcile-timeout annotation.
+	WaitTimeouts map[string]time.Duration
+
 	// DeletePropagationPolicy defines the deletion propagation policy
 	// that should be used. If this is not provided, the default is to
 	// use the Background policy.
@@ -86,6 +88,38 @@ type DestroyerOptions struct {
 
 	// ValidationPolicy defines how to handle invalid objects.
 	ValidationPolicy validation.Policy
+
+	// WaitSummaryInterval defines how often a WaitSummaryEvent should be
+	// emitted, listing the objects that are still being waited on.
+	// If this is not provided, no summary events are emitted.
+	WaitSummaryInterval time.Duration
+
+	// RetainInventory defines whether the inventory object is kept after the
+	// objects in it are deleted, for auditing, with only the objects that
+	// were not deleted (e.g. skipped or failed deletes) remaining in it. By
+	// default, the inventory object is deleted.
+	RetainInventory bool
+
+	// RemoveFinalizersAfter defines how long deleted objects may be blocked
+	// from deletion by finalizers, after their deletion timestamp, before
+	// their finalizers are removed. Removing finalizers skips any cleanup the
+	// finalizers were waiting for, so this must be explicitly enabled.
+	// If this is not provided, finalizers are never removed.
+	RemoveFinalizersAfter time.Duration
+
+	// RetryPolicy defines how deletes that fail with a transient API error
+	// (conflict, throttling, or server error) are retried. A RetryEvent is
+	// sent before each retry. If this is not provided, failures are not
+	// retried.
+	RetryPolicy common.RetryPolicy
+
+	// RecordTiming defines whether the delete and wait events include the
+	// start and end time of each object, to help find slow resources.
+	RecordTiming bool
+
+	// CancelGracePeriod enables graceful cancellation, if greater than zero.
+	// See ApplierOptions.CancelGracePeriod.
+	CancelGracePeriod time.Duration
 }
 
 func setDestroyerDefaults(o *DestroyerOptions) {
@@ -94,14 +128,40 @@ func setDestroyerDefaults(o *DestroyerOptions) {
 	}
 }
 
+// newResourceCache returns the ResourceCache for a run. If a shared cache was
+// provided, the objects to delete are invalidated, so that their status from
+// previous runs is not used.
+func (d *Destroyer) newResourceCache(deleteObjs object.UnstructuredSet) cache.ResourceCache {
+	if d.resourceCache == nil {
+		return cache.NewResourceCacheMap()
+	}
+	for _, obj := range deleteObjs {
+		d.resourceCache.Remove(object.UnstructuredToObjMetadata(obj))
+	}
+	return d.resourceCache
+}
+
 // Run performs the destroy step. Passes the inventory object. This
 // happens asynchronously on progress and any errors are reported
 // back on the event channel.
 func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options DestroyerOptions) <-chan event.Event {
+	logger := runLogger(ctx, d.logger, invInfo)
+	ctx = logr.NewContext(ctx, logger)
+	logger.V(4).Info("destroy run starting")
 	eventChannel := make(chan event.Event)
 	setDestroyerDefaults(&options)
 	go func() {
 		defer close(eventChannel)
+		if d.rateLimitEvent != nil {
+			eventChannel <- event.Event{
+				Type:           event.RateLimitType,
+				RateLimitEvent: *d.rateLimitEvent,
+			}
+		}
+		if err := validateInventory(d.invClient, invInfo, options.InventoryPolicy); err != nil {
+			handleError(eventChannel, err)
+			return
+		}
 		// Retrieve the objects to be deleted from the cluster. Second parameter is empty
 		// because no local objects returns all inventory objects for deletion.
 		emptyLocalObjs := object.UnstructuredSet{}
@@ -112,32 +172,25 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 			handleError(eventChannel, err)
 			return
 		}
-		mapper, err := d.factory.ToRESTMapper()
-		if err != nil {
-			handleError(eventChannel, err)
-			return
-		}
-
 		// Validate the resources to make sure we catch those problems early
 		// before anything has been updated in the cluster.
 		vCollector := &validation.Collector{}
 		validator := &validation.Validator{
 			Collector: vCollector,
-			Mapper:    mapper,
+			Mapper:    d.mapper,
 		}
 		validator.Validate(deleteObjs)
 
 		// Build a TaskContext for passing info between tasks
-		resourceCache := cache.NewResourceCacheMap()
+		resourceCache := d.newResourceCache(deleteObjs)
 		taskContext := taskrunner.NewTaskContext(eventChannel, resourceCache)
-
-		klog.V(4).Infoln("destroyer building task queue...")
-		dynamicClient, err := d.factory.DynamicClient()
-		if err != nil {
-			handleError(eventChannel, err)
-			return
+		taskContext.SetLogger(logger)
+		if options.RecordTiming {
+			taskContext.EnableTiming()
 		}
-		deleteFilters := []filter.ValidationFilter{
+
+		logger.V(4).Info("destroyer building task queue")
+		deleteFilters := d.pipeline.PruneFilters(
 			filter.PreventRemoveFilter{},
 			filter.InventoryPolicyPruneFilter{
 				Inv:       invInfo,
@@ -148,24 +201,30 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 				ActuationStrategy: actuation.ActuationStrategyDelete,
 				DryRunStrategy:    options.DryRunStrategy,
 			},
-		}
+		)
 		taskBuilder := &solver.TaskQueueBuilder{
 			Pruner:        d.pruner,
-			DynamicClient: dynamicClient,
-			OpenAPIGetter: d.factory.OpenAPIGetter(),
-			InfoHelper:    info.NewHelper(mapper, d.factory.UnstructuredClientForMapping),
-			Mapper:        mapper,
+			DynamicClient: d.client,
+			OpenAPIGetter: d.openAPIGetter,
+			InfoHelper:    d.infoHelper,
+			Mapper:        d.mapper,
 			InvClient:     d.invClient,
 			Collector:     vCollector,
 			PruneFilters:  deleteFilters,
+			Logger:        logger,
 		}
 		opts := solver.Options{
 			Destroy:                true,
+			RetainInventory:        options.RetainInventory,
 			Prune:                  true,
 			DryRunStrategy:         options.DryRunStrategy,
 			PrunePropagationPolicy: options.DeletePropagationPolicy,
 			PruneTimeout:           options.DeleteTimeout,
 			InventoryPolicy:        options.InventoryPolicy,
+			WaitSummaryInterval:    options.WaitSummaryInterval,
+			RemoveFinalizersAfter:  options.RemoveFinalizersAfter,
+			RetryPolicy:            options.RetryPolicy,
+			WaitTimeouts:           options.WaitTimeouts,
 		}
 
 		// Build the ordered set of tasks to execute.
@@ -174,8 +233,7 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 			WithInventory(invInfo).
 			Build(taskContext, opts)
 
-		klog.V(4).Infof("validation errors: %d", len(vCollector.Errors))
-		klog.V(4).Infof("invalid objects: %d", len(vCollector.InvalidIds))
+		logger.V(4).Info("validated objects", "errors", len(vCollector.Errors), "invalid", len(vCollector.InvalidIds))
 
 		// Handle validation errors
 		switch options.ValidationPolicy {
@@ -208,7 +266,7 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 			},
 		}
 		// Create a new TaskStatusRunner to execute the taskQueue.
-		klog.V(4).Infoln("destroyer building TaskStatusRunner...")
+		logger.V(4).Info("destroyer building TaskStatusRunner")
 		deleteIds := object.UnstructuredSetToObjMetadataSet(deleteObjs)
 		statusWatcher := d.statusWatcher
 		// Disable watcher for dry runs
@@ -216,9 +274,10 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 			statusWatcher = watcher.BlindStatusWatcher{}
 		}
 		runner := taskrunner.NewTaskStatusRunner(deleteIds, statusWatcher)
-		klog.V(4).Infoln("destroyer running TaskStatusRunner...")
+		logger.V(4).Info("destroyer running TaskStatusRunner")
 		err = runner.Run(ctx, taskContext, taskQueue.ToChannel(), taskrunner.Options{
-			EmitStatusEvents: options.EmitStatusEvents,
+			EmitStatusEvents:  options.EmitStatusEvents,
+			CancelGracePeriod: options.CancelGracePeriod,
 		})
 		if err != nil {
 			handleError(eventChannel, err)
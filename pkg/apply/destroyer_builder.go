@@ -0,0 +1,154 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/apply/cache"
+	"sigs.k8s.io/cli-utils/pkg/apply/info"
+	"sigs.k8s.io/cli-utils/pkg/apply/prune"
+	"sigs.k8s.io/cli-utils/pkg/flowcontrol"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/engine"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
+)
+
+// DestroyerBuilder builds a Destroyer. Everything that is not provided
+// explicitly is retrieved from the factory. If no factory is provided, it is
+// built from the rest config.
+type DestroyerBuilder struct {
+	commonBuilder
+	pipeline *Pipeline
+}
+
+// NewDestroyerBuilder returns a new DestroyerBuilder.
+func NewDestroyerBuilder() *DestroyerBuilder {
+	return &DestroyerBuilder{
+		// Defaults, if any, go here.
+	}
+}
+
+func (b *DestroyerBuilder) Build() (*Destroyer, error) {
+	bx, err := b.finalize()
+	if err != nil {
+		return nil, err
+	}
+	return &Destroyer{
+		pruner: &prune.Pruner{
+			InvClient: bx.invClient,
+			Client:    bx.client,
+			Mapper:    bx.mapper,
+		},
+		statusWatcher:  bx.statusWatcher,
+		invClient:      bx.invClient,
+		client:         bx.client,
+		openAPIGetter:  bx.discoClient,
+		mapper:         bx.mapper,
+		infoHelper:     info.NewHelper(bx.mapper, bx.unstructuredClientForMapping),
+		pipeline:       b.pipeline,
+		rateLimitEvent: bx.rateLimitEvent,
+		resourceCache:  bx.resourceCache,
+		logger:         bx.logger,
+	}, nil
+}
+
+func (b *DestroyerBuilder) WithFactory(factory util.Factory) *DestroyerBuilder {
+	b.factory = factory
+	return b
+}
+
+func (b *DestroyerBuilder) WithInventoryClient(invClient inventory.Client) *DestroyerBuilder {
+	b.invClient = invClient
+	return b
+}
+
+// WithInventoryClientFactory customizes the inventory backend, if the
+// inventory client is not provided explicitly. The default is a
+// ClusterClientFactory that stores the object status in the inventory.
+func (b *DestroyerBuilder) WithInventoryClientFactory(invClientFactory inventory.ClientFactory) *DestroyerBuilder {
+	b.invClientFactory = invClientFactory
+	return b
+}
+
+func (b *DestroyerBuilder) WithDynamicClient(client dynamic.Interface) *DestroyerBuilder {
+	b.client = client
+	return b
+}
+
+func (b *DestroyerBuilder) WithDiscoveryClient(discoClient discovery.CachedDiscoveryInterface) *DestroyerBuilder {
+	b.discoClient = discoClient
+	return b
+}
+
+func (b *DestroyerBuilder) WithRestMapper(mapper meta.RESTMapper) *DestroyerBuilder {
+	b.mapper = mapper
+	return b
+}
+
+func (b *DestroyerBuilder) WithRestConfig(restConfig *rest.Config) *DestroyerBuilder {
+	b.restConfig = restConfig
+	return b
+}
+
+func (b *DestroyerBuilder) WithUnstructuredClientForMapping(unstructuredClientForMapping func(*meta.RESTMapping) (resource.RESTClient, error)) *DestroyerBuilder {
+	b.unstructuredClientForMapping = unstructuredClientForMapping
+	return b
+}
+
+func (b *DestroyerBuilder) WithStatusWatcher(statusWatcher watcher.StatusWatcher) *DestroyerBuilder {
+	b.statusWatcher = statusWatcher
+	return b
+}
+
+// WithStatusReaders registers custom StatusReaders, used by the default
+// status watcher. See ApplierBuilder.WithStatusReaders.
+func (b *DestroyerBuilder) WithStatusReaders(statusReaders ...engine.StatusReader) *DestroyerBuilder {
+	b.statusReaders = append(b.statusReaders, statusReaders...)
+	return b
+}
+
+// WithStatusRules declares how the default status watcher computes the
+// status of the GroupKinds of the rules. See ApplierBuilder.WithStatusRules.
+func (b *DestroyerBuilder) WithStatusRules(rules status.Rules) *DestroyerBuilder {
+	b.statusRules = append(b.statusRules, rules...)
+	return b
+}
+
+// WithRateLimits configures the client-side rate limits based on whether the
+// server has API Priority and Fairness enabled. See
+// ApplierBuilder.WithRateLimits.
+func (b *DestroyerBuilder) WithRateLimits(limits flowcontrol.RateLimits) *DestroyerBuilder {
+	b.rateLimits = &limits
+	return b
+}
+
+// WithResourceCache shares the passed ResourceCache across all runs of the
+// Destroyer. The objects being deleted are removed from the cache at the
+// start of each run.
+func (b *DestroyerBuilder) WithResourceCache(resourceCache cache.ResourceCache) *DestroyerBuilder {
+	b.resourceCache = resourceCache
+	return b
+}
+
+// WithLogger sets the logger used by the Destroyer, instead of klog. A logger
+// in the context passed to Run takes precedence. The inventory ID and a
+// unique run ID are added to the logger of each run.
+func (b *DestroyerBuilder) WithLogger(logger logr.Logger) *DestroyerBuilder {
+	b.logger = logger
+	return b
+}
+
+// WithPipeline customizes the prune filters of each run. The other stages
+// of the Pipeline only apply to the Applier. See PipelineBuilder.
+func (b *DestroyerBuilder) WithPipeline(pipeline *Pipeline) *DestroyerBuilder {
+	b.pipeline = pipeline
+	return b
+}
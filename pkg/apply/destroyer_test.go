@@ -5,11 +5,14 @@ package apply
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
@@ -393,3 +396,50 @@ func TestDestroyerCancel(t *testing.T) {
 		})
 	}
 }
+
+type denyFilter struct{}
+
+func (denyFilter) Name() string {
+	return "DenyFilter"
+}
+
+func (denyFilter) Filter(*unstructured.Unstructured) error {
+	return errors.New("denied")
+}
+
+func TestDestroyerPipeline(t *testing.T) {
+	invInfo := inventoryInfo{
+		name:      "abc-123",
+		namespace: "test",
+		id:        "test",
+		set: object.ObjMetadataSet{
+			testutil.ToIdentifier(t, resources["deployment"]),
+		},
+	}
+	statusWatcher := newFakeWatcher(nil)
+	statusWatcher.Start()
+	destroyer := newTestDestroyer(t,
+		invInfo,
+		object.UnstructuredSet{
+			testutil.Unstructured(t, resources["deployment"], testutil.AddOwningInv(t, "test")),
+			inventory.InvInfoToConfigMap(invInfo.toWrapped()),
+		},
+		statusWatcher,
+	)
+	destroyer.pipeline = NewPipelineBuilder().WithPruneFilter(denyFilter{}).Build()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var deleteEvents []event.DeleteEvent
+	for e := range destroyer.Run(ctx, invInfo.toWrapped(), DestroyerOptions{}) {
+		require.NotEqual(t, event.ErrorType, e.Type, "unexpected error: %v", e.ErrorEvent.Err)
+		if e.Type == event.DeleteType {
+			deleteEvents = append(deleteEvents, e.DeleteEvent)
+		}
+	}
+	require.Len(t, deleteEvents, 1)
+	assert.Equal(t, event.DeleteSkipped, deleteEvents[0].Status)
+	assert.EqualError(t, deleteEvents[0].Error, "denied")
+	assert.NoError(t, ctx.Err())
+}
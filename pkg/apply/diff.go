@@ -0,0 +1,195 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/multierror"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/print/redact"
+	"sigs.k8s.io/yaml"
+)
+
+//go:generate stringer -type=DiffAction -linecomment
+
+// DiffAction is the change an apply would make to an object.
+type DiffAction int
+
+const (
+	DiffUnchanged DiffAction = iota // Unchanged
+	DiffCreate                      // Create
+	DiffUpdate                      // Update
+	DiffPrune                       // Prune
+)
+
+// ObjectDiff is the difference between the live version of an object and
+// the version an apply would leave in the cluster.
+type ObjectDiff struct {
+	Identifier object.ObjMetadata
+	Action     DiffAction
+	// Live is the object in the cluster. It is nil for created objects.
+	Live *unstructured.Unstructured
+	// Merged is the object returned by the server-side dry-run apply. It is
+	// nil for pruned objects.
+	Merged *unstructured.Unstructured
+}
+
+// Redact returns a copy of the diff with both objects redacted, so that the
+// values of Secrets are not printed. The objects of the diff are unchanged.
+func (d ObjectDiff) Redact(r redact.Redactor) ObjectDiff {
+	d.Live = redact.Object(r, d.Live)
+	d.Merged = redact.Object(r, d.Merged)
+	return d
+}
+
+// UnifiedDiff returns the unified diff of the YAML of the live and merged
+// objects, without the fields set by the server on every write.
+func (d ObjectDiff) UnifiedDiff() (string, error) {
+	live, err := diffYAML(d.Live)
+	if err != nil {
+		return "", err
+	}
+	merged, err := diffYAML(d.Merged)
+	if err != nil {
+		return "", err
+	}
+	name := d.Identifier.String()
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        diffLines(live),
+		B:        diffLines(merged),
+		FromFile: "live/" + name,
+		ToFile:   "merged/" + name,
+		Context:  3,
+	})
+}
+
+// HasChanges returns true if an apply would change any of the objects.
+func HasChanges(diffs []ObjectDiff) bool {
+	for _, d := range diffs {
+		if d.Action != DiffUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff returns the changes that Run would make to the objects, without
+// making them: the objects are applied and pruned with the server dry-run
+// strategy, and the results are compared with the live objects. The
+// filters, mutators, and inventory policy of the run apply, so skipped
+// objects are not in the diff. The inventory object is not in the diff.
+// The diffs are in the order of the events of the dry run. If an object
+// failed to be applied or pruned, the error is returned.
+func (a *Applier) Diff(ctx context.Context, invInfo inventory.Info, objects object.UnstructuredSet, options ApplierOptions) ([]ObjectDiff, error) {
+	options.DryRunStrategy = common.DryRunServer
+	var diffs []ObjectDiff
+	var errs []error
+	for e := range a.Run(ctx, invInfo, objects, options) {
+		switch e.Type {
+		case event.ErrorType:
+			errs = append(errs, e.ErrorEvent.Err)
+		case event.ApplyType:
+			switch e.ApplyEvent.Status {
+			case event.ApplyFailed:
+				errs = append(errs, e.ApplyEvent.Error)
+			case event.ApplySuccessful:
+				d, err := a.applyDiff(ctx, e.ApplyEvent)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				diffs = append(diffs, d)
+			}
+		case event.PruneType:
+			switch e.PruneEvent.Status {
+			case event.PruneFailed:
+				errs = append(errs, e.PruneEvent.Error)
+			case event.PruneSuccessful:
+				diffs = append(diffs, ObjectDiff{
+					Identifier: e.PruneEvent.Identifier,
+					Action:     DiffPrune,
+					Live:       e.PruneEvent.Object,
+				})
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return diffs, multierror.Wrap(errs...)
+	}
+	return diffs, nil
+}
+
+// applyDiff returns the diff of an object applied with the server dry-run
+// strategy, by getting the live object from the cluster.
+func (a *Applier) applyDiff(ctx context.Context, e event.ApplyEvent) (ObjectDiff, error) {
+	d := ObjectDiff{
+		Identifier: e.Identifier,
+		Merged:     e.Resource,
+	}
+	mapping, err := a.mapper.RESTMapping(e.Identifier.GroupKind)
+	if err != nil {
+		return d, err
+	}
+	live, err := a.client.Resource(mapping.Resource).Namespace(e.Identifier.Namespace).
+		Get(ctx, e.Identifier.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		d.Action = DiffCreate
+		return d, nil
+	case err != nil:
+		return d, fmt.Errorf("failed to get live object %s: %w", e.Identifier, err)
+	}
+	d.Live = live
+	if d.Merged == nil || equality.Semantic.DeepEqual(diffObject(live), diffObject(d.Merged)) {
+		d.Action = DiffUnchanged
+	} else {
+		d.Action = DiffUpdate
+	}
+	return d, nil
+}
+
+// diffObject returns a copy of the object without the fields set by the
+// server on every write, which are not changes to the object.
+func diffObject(obj *unstructured.Unstructured) map[string]interface{} {
+	if obj == nil {
+		return nil
+	}
+	obj = obj.DeepCopy()
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+	obj.SetGeneration(0)
+	return obj.Object
+}
+
+// diffYAML returns the YAML of the object for the unified diff, or an empty
+// string for a nil object.
+func diffYAML(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+	b, err := yaml.Marshal(diffObject(obj))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// diffLines splits the YAML into lines, each with its newline.
+func diffLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return difflib.SplitLines(strings.TrimSuffix(s, "\n"))
+}
@@ -0,0 +1,199 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	openapi_v2 "github.com/google/gnostic/openapiv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/apply/applytest"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/print/redact"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestApplierDiff(t *testing.T) {
+	inv := inventory.WrapInventoryInfoObj(testutil.Unstructured(t, resources["inventory"]))
+	invInfo := inventoryInfo{
+		name:      inv.Name(),
+		namespace: inv.Namespace(),
+		id:        inv.ID(),
+	}
+	deployment := testutil.Unstructured(t, resources["deployment"], testutil.AddOwningInv(t, inv.ID()))
+	deploymentID := object.UnstructuredToObjMetadata(deployment)
+	secret := testutil.Unstructured(t, resources["secret"], testutil.AddOwningInv(t, inv.ID()))
+	secretID := object.UnstructuredToObjMetadata(secret)
+
+	testCases := map[string]struct {
+		invObjs     object.ObjMetadataSet
+		objs        object.UnstructuredSet
+		clusterObjs object.UnstructuredSet
+		expActions  map[object.ObjMetadata]DiffAction
+		expChanges  bool
+	}{
+		"create and prune": {
+			invObjs:     object.ObjMetadataSet{secretID},
+			objs:        object.UnstructuredSet{deployment},
+			clusterObjs: object.UnstructuredSet{secret},
+			expActions: map[object.ObjMetadata]DiffAction{
+				deploymentID: DiffCreate,
+				secretID:     DiffPrune,
+			},
+			expChanges: true,
+		},
+		"unchanged": {
+			invObjs:     object.ObjMetadataSet{deploymentID},
+			objs:        object.UnstructuredSet{deployment},
+			clusterObjs: object.UnstructuredSet{deployment},
+			expActions: map[object.ObjMetadata]DiffAction{
+				deploymentID: DiffUnchanged,
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tf := newTestFactory(t, invInfo, tc.objs, tc.clusterObjs)
+			defer tf.Cleanup()
+
+			invClient := applytest.NewInventoryClient()
+			invClient.SetObjects(inv, tc.invObjs)
+			applier, err := NewApplierBuilder().
+				WithFactory(tf).
+				WithInventoryClient(invClient).
+				WithStatusWatcher(applytest.NewStatusWatcher()).
+				Build()
+			require.NoError(t, err)
+			applier.infoHelper = &fakeInfoHelper{factory: tf}
+			applier.openAPIGetter = dryRunOpenAPI{
+				deployment.GroupVersionKind(),
+				secret.GroupVersionKind(),
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			diffs, err := applier.Diff(ctx, inv, tc.objs, ApplierOptions{
+				Prune:           PruneEnabled,
+				InventoryPolicy: inventory.PolicyMustMatch,
+			})
+			require.NoError(t, err)
+
+			actions := make(map[object.ObjMetadata]DiffAction)
+			for _, d := range diffs {
+				actions[d.Identifier] = d.Action
+			}
+			assert.Equal(t, tc.expActions, actions)
+			assert.Equal(t, tc.expChanges, HasChanges(diffs))
+			// Nothing was stored by the dry run.
+			testutil.AssertEqual(t, tc.invObjs, invClient.Objects(inv))
+		})
+	}
+}
+
+// dryRunOpenAPI is an OpenAPI schema getter for kinds that support the
+// server-side dry-run.
+type dryRunOpenAPI []schema.GroupVersionKind
+
+func (o dryRunOpenAPI) OpenAPISchema() (*openapi_v2.Document, error) {
+	doc := &openapi_v2.Document{Paths: &openapi_v2.Paths{}}
+	for _, gvk := range o {
+		doc.Paths.Path = append(doc.Paths.Path, &openapi_v2.NamedPathItem{
+			Name: gvk.String(),
+			Value: &openapi_v2.PathItem{
+				Patch: &openapi_v2.Operation{
+					VendorExtension: []*openapi_v2.NamedAny{{
+						Name: "x-kubernetes-group-version-kind",
+						Value: &openapi_v2.Any{
+							Yaml: fmt.Sprintf("group: %q\nkind: %s\nversion: %s\n", gvk.Group, gvk.Kind, gvk.Version),
+						},
+					}},
+					Parameters: []*openapi_v2.ParametersItem{{
+						Oneof: &openapi_v2.ParametersItem_Parameter{
+							Parameter: &openapi_v2.Parameter{
+								Oneof: &openapi_v2.Parameter_NonBodyParameter{
+									NonBodyParameter: &openapi_v2.NonBodyParameter{
+										Oneof: &openapi_v2.NonBodyParameter_QueryParameterSubSchema{
+											QueryParameterSubSchema: &openapi_v2.QueryParameterSubSchema{Name: "dryRun"},
+										},
+									},
+								},
+							},
+						},
+					}},
+				},
+			},
+		})
+	}
+	return doc, nil
+}
+
+func TestObjectDiffUnifiedDiff(t *testing.T) {
+	live := testutil.Unstructured(t, resources["deployment"])
+	live.SetResourceVersion("1")
+	merged := live.DeepCopy()
+	merged.SetResourceVersion("2")
+	merged.SetGeneration(2)
+	require.NoError(t, unstructured.SetNestedField(merged.Object, int64(3), "spec", "replicas"))
+	id := object.UnstructuredToObjMetadata(live)
+
+	unified, err := ObjectDiff{Identifier: id, Action: DiffUpdate, Live: live, Merged: merged}.UnifiedDiff()
+	require.NoError(t, err)
+	assert.Equal(t, `--- live/default_foo_apps_Deployment
++++ merged/default_foo_apps_Deployment
+@@ -5,4 +5,4 @@
+   namespace: default
+   uid: dep-uid
+ spec:
+-  replicas: 1
++  replicas: 3
+`, unified)
+
+	unified, err = ObjectDiff{Identifier: id, Action: DiffPrune, Live: live}.UnifiedDiff()
+	require.NoError(t, err)
+	assert.Contains(t, unified, "-kind: Deployment\n")
+}
+
+func TestObjectDiffRedact(t *testing.T) {
+	live := testutil.Unstructured(t, `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: foo
+  namespace: default
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: '{"data":{"password":"b2xk"}}'
+data:
+  password: b2xk
+`)
+	merged := live.DeepCopy()
+	merged.SetAnnotations(map[string]string{
+		"kubectl.kubernetes.io/last-applied-configuration": `{"data":{"password":"bmV3"}}`,
+	})
+	require.NoError(t, unstructured.SetNestedField(merged.Object, "bmV3", "data", "password"))
+	d := ObjectDiff{
+		Identifier: object.UnstructuredToObjMetadata(live),
+		Action:     DiffUpdate,
+		Live:       live,
+		Merged:     merged,
+	}
+
+	unified, err := d.Redact(redact.SecretData{}).UnifiedDiff()
+	require.NoError(t, err)
+	assert.NotContains(t, unified, "b2xk")
+	assert.NotContains(t, unified, "bmV3")
+	assert.NotContains(t, unified, "last-applied-configuration")
+
+	// The objects of the diff are unchanged.
+	password, _, err := unstructured.NestedString(d.Merged.Object, "data", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "bmV3", password)
+}
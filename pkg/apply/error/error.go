@@ -1,7 +1,31 @@
 // Copyright 2019 The Kubernetes Authors.
 // SPDX-License-Identifier: Apache-2.0
+
+// Package error defines the typed errors of apply, prune, and wait failures.
+// They are sent in the events of the objects, and wrap the underlying error,
+// so that callers can use errors.As to decide how to handle a failure,
+// instead of matching the error message:
+//
+//   - UnknownTypeError: the type of an object to apply is not registered
+//   - ApplyRunError: an object failed to be applied
+//   - PruneFailedError: an object failed to be pruned or deleted
+//   - ReconcileTimeoutError: an object did not reconcile before the timeout
+//   - PolicyPreventedError: the inventory policy prevented an apply or prune
+//   - ValidationError: an object is invalid
+//
+// The errors from the API server, like conflicts or throttling, are wrapped,
+// so that the apierrors.IsXxx functions can also be used on these errors.
 package error
 
+import (
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/validation"
+)
+
 type UnknownTypeError struct {
 	err error
 }
@@ -10,6 +34,10 @@ func (e *UnknownTypeError) Error() string {
 	return e.err.Error()
 }
 
+func (e *UnknownTypeError) Unwrap() error {
+	return e.err
+}
+
 func NewUnknownTypeError(err error) *UnknownTypeError {
 	return &UnknownTypeError{err: err}
 }
@@ -22,6 +50,10 @@ func (e *ApplyRunError) Error() string {
 	return e.err.Error()
 }
 
+func (e *ApplyRunError) Unwrap() error {
+	return e.err
+}
+
 func NewApplyRunError(err error) *ApplyRunError {
 	return &ApplyRunError{err: err}
 }
@@ -34,6 +66,68 @@ func (e *InitializeApplyOptionError) Error() string {
 	return e.err.Error()
 }
 
+func (e *InitializeApplyOptionError) Unwrap() error {
+	return e.err
+}
+
 func NewInitializeApplyOptionError(err error) *InitializeApplyOptionError {
 	return &InitializeApplyOptionError{err: err}
 }
+
+// PruneFailedError is the error of an object that failed to be pruned or
+// deleted. Err is the error returned by the API server.
+type PruneFailedError struct {
+	Object object.ObjMetadata
+	Err    error
+}
+
+func (e *PruneFailedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PruneFailedError) Unwrap() error {
+	return e.Err
+}
+
+func NewPruneFailedError(id object.ObjMetadata, err error) *PruneFailedError {
+	return &PruneFailedError{Object: id, Err: err}
+}
+
+// ReconcileTimeoutError is the error of an object that did not reach the
+// desired status before the timeout of the wait task.
+type ReconcileTimeoutError struct {
+	Object  object.ObjMetadata
+	Timeout time.Duration
+}
+
+func (e *ReconcileTimeoutError) Error() string {
+	if e.Timeout == 0 {
+		return "reconcile timeout"
+	}
+	return fmt.Sprintf("reconcile timeout after %s", e.Timeout)
+}
+
+// Is returns true if the specified error is equal to this error.
+// Use errors.Is(error) to recursively check if an error wraps this error.
+func (e *ReconcileTimeoutError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*ReconcileTimeoutError)
+	if !ok {
+		return false
+	}
+	return e.Object == tErr.Object &&
+		e.Timeout == tErr.Timeout
+}
+
+func NewReconcileTimeoutError(id object.ObjMetadata, timeout time.Duration) *ReconcileTimeoutError {
+	return &ReconcileTimeoutError{Object: id, Timeout: timeout}
+}
+
+// PolicyPreventedError is the error of an object that the inventory policy
+// prevented from being applied or pruned.
+type PolicyPreventedError = inventory.PolicyPreventedActuationError
+
+// ValidationError is the error of one or more invalid objects.
+type ValidationError = validation.Error
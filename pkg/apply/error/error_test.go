@@ -0,0 +1,76 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package error
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/multierror"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/validation"
+)
+
+var testID = object.ObjMetadata{
+	GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+	Namespace: "default",
+	Name:      "foo",
+}
+
+func TestErrorsAs(t *testing.T) {
+	conflict := apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"},
+		"foo", fmt.Errorf("the object has been modified"))
+
+	t.Run("apply error wraps the API error", func(t *testing.T) {
+		err := fmt.Errorf("task failed: %w", NewApplyRunError(conflict))
+		var applyErr *ApplyRunError
+		assert.True(t, errors.As(err, &applyErr))
+		assert.True(t, apierrors.IsConflict(err))
+	})
+
+	t.Run("prune error wraps the API error", func(t *testing.T) {
+		var err error = NewPruneFailedError(testID, conflict)
+		var pruneErr *PruneFailedError
+		if assert.True(t, errors.As(err, &pruneErr)) {
+			assert.Equal(t, testID, pruneErr.Object)
+		}
+		assert.True(t, apierrors.IsConflict(err))
+		assert.Equal(t, conflict.Error(), err.Error())
+	})
+
+	t.Run("reconcile timeout", func(t *testing.T) {
+		var err error = NewReconcileTimeoutError(testID, time.Minute)
+		assert.EqualError(t, err, "reconcile timeout after 1m0s")
+		assert.ErrorIs(t, err, &ReconcileTimeoutError{Object: testID, Timeout: time.Minute})
+		assert.NotErrorIs(t, err, &ReconcileTimeoutError{Object: testID})
+	})
+
+	t.Run("causes of a multi-error", func(t *testing.T) {
+		err := multierror.New(
+			validation.NewError(fmt.Errorf("invalid spec"), testID),
+			&inventory.PolicyPreventedActuationError{
+				Strategy: actuation.ActuationStrategyApply,
+				Policy:   inventory.PolicyMustMatch,
+				Status:   inventory.NoMatch,
+			},
+		)
+		var validationErr *ValidationError
+		if assert.True(t, errors.As(err, &validationErr)) {
+			assert.Equal(t, object.ObjMetadataSet{testID}, validationErr.Identifiers())
+		}
+		var policyErr *PolicyPreventedError
+		if assert.True(t, errors.As(err, &policyErr)) {
+			assert.Equal(t, inventory.NoMatch, policyErr.Status)
+		}
+		var pruneErr *PruneFailedError
+		assert.False(t, errors.As(err, &pruneErr))
+	})
+}
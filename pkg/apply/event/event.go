@@ -6,9 +6,12 @@ package event
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	"sigs.k8s.io/cli-utils/pkg/object"
 )
 
@@ -26,6 +29,10 @@ const (
 	DeleteType
 	WaitType
 	ValidationType
+	WaitSummaryType
+	RetryType
+	RateLimitType
+	VerificationType
 )
 
 // Event is the type of the objects that will be returned through
@@ -69,6 +76,22 @@ type Event struct {
 
 	// ValidationEvent contains information about validation errors.
 	ValidationEvent ValidationEvent
+
+	// WaitSummaryEvent contains a periodic summary of the objects that a
+	// WaitTask is still waiting for.
+	WaitSummaryEvent WaitSummaryEvent
+
+	// RetryEvent contains information about an apply or delete request that
+	// failed with a transient error and is being retried.
+	RetryEvent RetryEvent
+
+	// RateLimitEvent contains the client-side rate limits chosen for the
+	// run.
+	RateLimitEvent RateLimitEvent
+
+	// VerificationEvent contains the result of the verification of the
+	// signature of the manifests the objects were read from.
+	VerificationEvent VerificationEvent
 }
 
 // String returns a string suitable for logging
@@ -93,6 +116,14 @@ func (e Event) String() string {
 		sb.WriteString(e.WaitEvent.String())
 	case ValidationType:
 		sb.WriteString(e.ValidationEvent.String())
+	case WaitSummaryType:
+		sb.WriteString(e.WaitSummaryEvent.String())
+	case RetryType:
+		sb.WriteString(e.RetryEvent.String())
+	case RateLimitType:
+		sb.WriteString(e.RateLimitEvent.String())
+	case VerificationType:
+		sb.WriteString(e.VerificationEvent.String())
 	}
 	return sb.String()
 }
@@ -115,6 +146,7 @@ const (
 	DeleteAction                          // Delete
 	WaitAction                            // Wait
 	InventoryAction                       // Inventory
+	ValidateAction                        // Validate
 )
 
 type ActionGroupList []ActionGroup
@@ -171,14 +203,100 @@ type WaitEvent struct {
 	GroupName  string
 	Identifier object.ObjMetadata
 	Status     WaitEventStatus
+	// Error is only set for timeouts, to an error.ReconcileTimeoutError.
+	Error error
+	// Timing is from when the object was applied or deleted until it was
+	// reconciled. It is not set for pending objects.
+	Timing Timing
 }
 
 // String returns a string suitable for logging
 func (we WaitEvent) String() string {
+	if we.Error != nil {
+		return fmt.Sprintf("WaitEvent{ GroupName: %q, Status: %q, Identifier: %q, Error: %q }",
+			we.GroupName, we.Status, we.Identifier, we.Error)
+	}
 	return fmt.Sprintf("WaitEvent{ GroupName: %q, Status: %q, Identifier: %q }",
 		we.GroupName, we.Status, we.Identifier)
 }
 
+// WaitSummaryEvent is sent periodically while a WaitTask is waiting, listing
+// the objects that have not yet reconciled, grouped by kind and status. It
+// also counts all the objects of the WaitTask per status, so that progress can
+// be shown without tracking every StatusEvent.
+type WaitSummaryEvent struct {
+	GroupName string
+	// Elapsed is how long the WaitTask has been waiting.
+	Elapsed time.Duration
+	// Total is the number of objects in the WaitTask.
+	Total int
+	// Counts is the number of objects of the WaitTask per status, including
+	// the reconciled objects. Statuses without objects are omitted.
+	Counts map[status.Status]int
+	// Pending is the list of groups of objects that are still pending,
+	// sorted by kind and status.
+	Pending []WaitSummaryGroup
+	// Terminating is the list of pending objects that are being deleted,
+	// but are blocked by finalizers, sorted by identifier.
+	Terminating []TerminatingObject
+}
+
+// String returns a string suitable for logging
+func (wse WaitSummaryEvent) String() string {
+	return fmt.Sprintf("WaitSummaryEvent{ GroupName: %q, Elapsed: %q, Total: %d, Counts: %v, Pending: %s, Terminating: %s }",
+		wse.GroupName, wse.Elapsed, wse.Total, wse.Counts, wse.Pending, wse.Terminating)
+}
+
+// VerificationEvent is sent for each verification of ApplierOptions.Verified,
+// before anything is planned or applied.
+type VerificationEvent struct {
+	// Source is the name of the verified file or bundle.
+	Source string
+	// Digest is the SHA-256 digest of the verified content.
+	Digest string
+	// Method is the signature method, like "cosign" or "gpg".
+	Method string
+	// Signer identifies the key that made the signature.
+	Signer string
+}
+
+// String returns a string suitable for logging
+func (ve VerificationEvent) String() string {
+	return fmt.Sprintf("VerificationEvent{ Source: %q, Digest: %q, Method: %q, Signer: %q }",
+		ve.Source, ve.Digest, ve.Method, ve.Signer)
+}
+
+// TerminatingObject is a pending object that is being deleted, but is blocked
+// by finalizers.
+type TerminatingObject struct {
+	Identifier object.ObjMetadata
+	// DeletionTimestamp is when the object is deleted, once the finalizers
+	// have been removed.
+	DeletionTimestamp time.Time
+	// Finalizers are the remaining finalizers of the object.
+	Finalizers []string
+}
+
+// String returns a string suitable for logging
+func (to TerminatingObject) String() string {
+	return fmt.Sprintf("TerminatingObject{ Identifier: %q, DeletionTimestamp: %q, Finalizers: %q }",
+		to.Identifier, to.DeletionTimestamp.Format(time.RFC3339), to.Finalizers)
+}
+
+// WaitSummaryGroup is a set of pending objects with the same kind and status.
+type WaitSummaryGroup struct {
+	GroupKind schema.GroupKind
+	Status    status.Status
+	// Identifiers of the objects in this group.
+	Identifiers object.ObjMetadataSet
+}
+
+// String returns a string suitable for logging
+func (wsg WaitSummaryGroup) String() string {
+	return fmt.Sprintf("WaitSummaryGroup{ GroupKind: %q, Status: %q, Identifiers: %s }",
+		wsg.GroupKind, wsg.Status, wsg.Identifiers)
+}
+
 //go:generate stringer -type=ActionGroupEventStatus
 type ActionGroupEventStatus int
 
@@ -209,12 +327,45 @@ const (
 	ApplyFailed                             // Failed
 )
 
+// ApplyEventOperation describes what a successful apply did to the object,
+// mirroring the kubectl apply output verbs.
+//go:generate stringer -type=ApplyEventOperation -linecomment
+type ApplyEventOperation int
+
+const (
+	ApplyUnspecified       ApplyEventOperation = iota // Unspecified
+	ApplyCreated                                      // Created
+	ApplyConfigured                                   // Configured
+	ApplyUnchanged                                    // Unchanged
+	ApplyServersideApplied                            // ServersideApplied
+)
+
 type ApplyEvent struct {
 	GroupName  string
 	Identifier object.ObjMetadata
 	Status     ApplyEventStatus
-	Resource   *unstructured.Unstructured
-	Error      error
+	// Operation is only set for successful applies.
+	Operation ApplyEventOperation
+	Resource  *unstructured.Unstructured
+	Error     error
+	// Timing is from when the apply started until it completed.
+	Timing Timing
+	// Warnings are the warnings returned by the server for successful
+	// applies, like the unknown fields dropped by the server with the
+	// Warn field validation.
+	Warnings []string
+	// Conflicts are the fields owned by other field managers that failed a
+	// server-side apply. They are only set for failed applies.
+	Conflicts []FieldConflict
+}
+
+// FieldConflict is a field of an object owned by another field manager,
+// which conflicts with the value of the field in a server-side apply.
+type FieldConflict struct {
+	// Field is the path of the field, like .spec.replicas.
+	Field string
+	// Manager is the name of the field manager that owns the field.
+	Manager string
 }
 
 // String returns a string suitable for logging
@@ -223,6 +374,10 @@ func (ae ApplyEvent) String() string {
 		return fmt.Sprintf("ApplyEvent{ GroupName: %q, Status: %q, Identifier: %q, Error: %q }",
 			ae.GroupName, ae.Status, ae.Identifier, ae.Error)
 	}
+	if ae.Operation != ApplyUnspecified {
+		return fmt.Sprintf("ApplyEvent{ GroupName: %q, Status: %q, Operation: %q, Identifier: %q }",
+			ae.GroupName, ae.Status, ae.Operation, ae.Identifier)
+	}
 	return fmt.Sprintf("ApplyEvent{ GroupName: %q, Status: %q, Identifier: %q }",
 		ae.GroupName, ae.Status, ae.Identifier)
 }
@@ -262,16 +417,34 @@ const (
 	PruneFailed                             // Failed
 )
 
+// PruneEventOperation describes what the pruner did instead of deleting the
+// object, if anything.
+//go:generate stringer -type=PruneEventOperation -linecomment
+type PruneEventOperation int
+
+const (
+	PruneUnspecified PruneEventOperation = iota // Unspecified
+	PruneOrphaned                               // Orphaned
+)
+
 type PruneEvent struct {
 	GroupName  string
 	Identifier object.ObjMetadata
 	Status     PruneEventStatus
-	Object     *unstructured.Unstructured
-	Error      error
+	// Operation is only set for skipped prunes.
+	Operation PruneEventOperation
+	Object    *unstructured.Unstructured
+	Error     error
+	// Timing is from when the prune started until it completed.
+	Timing Timing
 }
 
 // String returns a string suitable for logging
 func (pe PruneEvent) String() string {
+	if pe.Operation != PruneUnspecified && pe.Error != nil {
+		return fmt.Sprintf("PruneEvent{ GroupName: %q, Status: %q, Operation: %q, Identifier: %q, Error: %q }",
+			pe.GroupName, pe.Status, pe.Operation, pe.Identifier, pe.Error)
+	}
 	if pe.Error != nil {
 		return fmt.Sprintf("PruneEvent{ GroupName: %q, Status: %q, Identifier: %q, Error: %q }",
 			pe.GroupName, pe.Status, pe.Identifier, pe.Error)
@@ -296,6 +469,8 @@ type DeleteEvent struct {
 	Status     DeleteEventStatus
 	Object     *unstructured.Unstructured
 	Error      error
+	// Timing is from when the delete started until it completed.
+	Timing Timing
 }
 
 // String returns a string suitable for logging
@@ -322,3 +497,86 @@ func (ve ValidationEvent) String() string {
 	return fmt.Sprintf("ValidationEvent{ Identifiers: %+v }",
 		ve.Identifiers)
 }
+
+// RetryEvent is sent when an apply or delete request fails with a transient
+// error and is going to be retried. The final outcome is reported by the
+// ApplyEvent, PruneEvent, or DeleteEvent for the object.
+type RetryEvent struct {
+	GroupName  string
+	Identifier object.ObjMetadata
+	// Action is the action being retried: ApplyAction, PruneAction, or
+	// DeleteAction.
+	Action ResourceAction
+	// Retry is the number of the upcoming retry, starting at 1.
+	Retry int
+	// MaxRetries is the maximum number of retries.
+	MaxRetries int
+	// Backoff is how long to wait before retrying.
+	Backoff time.Duration
+	// Error is the error from the failed attempt.
+	Error error
+}
+
+// String returns a string suitable for logging
+func (re RetryEvent) String() string {
+	return fmt.Sprintf("RetryEvent{ GroupName: %q, Action: %q, Identifier: %q, Retry: %d/%d, Backoff: %q, Error: %q }",
+		re.GroupName, re.Action, re.Identifier, re.Retry, re.MaxRetries, re.Backoff, re.Error)
+}
+
+// RateLimitEvent is sent at the start of a run when the client-side rate
+// limits were configured based on whether the server has API Priority and
+// Fairness enabled.
+type RateLimitEvent struct {
+	// PriorityAndFairnessEnabled is true if the server throttles requests
+	// itself, in which case client-side rate limiting is disabled.
+	PriorityAndFairnessEnabled bool
+	// QPS is the maximum queries per second. Negative if client-side rate
+	// limiting is disabled.
+	QPS float32
+	// Burst is the maximum burst of queries.
+	Burst int
+}
+
+// String returns a string suitable for logging
+func (re RateLimitEvent) String() string {
+	return fmt.Sprintf("RateLimitEvent{ PriorityAndFairnessEnabled: %t, QPS: %v, Burst: %d }",
+		re.PriorityAndFairnessEnabled, re.QPS, re.Burst)
+}
+
+// Timing records when the processing of an object started and ended. It is
+// only set when timing is enabled, with ApplierOptions.RecordTiming or
+// DestroyerOptions.RecordTiming.
+type Timing struct {
+	Start time.Time
+	End   time.Time
+}
+
+// IsZero returns true if the timing was not recorded.
+func (t Timing) IsZero() bool {
+	return t.Start.IsZero() || t.End.IsZero()
+}
+
+// Duration returns the time between Start and End, or zero if the timing
+// was not recorded.
+func (t Timing) Duration() time.Duration {
+	if t.IsZero() {
+		return 0
+	}
+	return t.End.Sub(t.Start)
+}
+
+// WithTiming returns a copy of the event with the timing set, if it is an
+// apply, prune, delete, or wait event. Other events are returned unchanged.
+func (e Event) WithTiming(t Timing) Event {
+	switch e.Type {
+	case ApplyType:
+		e.ApplyEvent.Timing = t
+	case PruneType:
+		e.PruneEvent.Timing = t
+	case DeleteType:
+		e.DeleteEvent.Timing = t
+	case WaitType:
+		e.WaitEvent.Timing = t
+	}
+	return e
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package event
+
+import "sync"
+
+// Sink receives events, for example to print them, record metrics, or write
+// an audit log.
+type Sink interface {
+	// Send handles a single event. Events are sent in the order they were
+	// received from the Applier or Destroyer.
+	Send(Event)
+}
+
+// SinkFunc is a function that implements Sink.
+type SinkFunc func(Event)
+
+// Send calls the function with the event.
+func (f SinkFunc) Send(e Event) {
+	f(e)
+}
+
+// Multiplexer sends every event from an event channel to all the subscribed
+// sinks, so that multiple consumers each receive the full stream of events,
+// without draining each other.
+//
+// Sinks are called one at a time, in the order they were subscribed, so a
+// slow sink slows down all the others. Sinks must not block indefinitely.
+type Multiplexer struct {
+	mu       sync.Mutex
+	sinks    []Sink
+	channels []chan Event
+	done     bool
+}
+
+// NewMultiplexer returns a Multiplexer with the passed sinks subscribed.
+func NewMultiplexer(sinks ...Sink) *Multiplexer {
+	return &Multiplexer{
+		sinks: sinks,
+	}
+}
+
+// Subscribe adds a sink. Sinks subscribed while Run is in progress only
+// receive the remaining events.
+func (m *Multiplexer) Subscribe(sink Sink) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sinks = append(m.sinks, sink)
+}
+
+// SubscribeChannel returns a channel that receives every event, for
+// consumers that read from a channel, like the printers. The channel is
+// closed when Run completes. The channel must be read until it is closed,
+// otherwise the other sinks are blocked.
+func (m *Multiplexer) SubscribeChannel() <-chan Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ch := make(chan Event)
+	if m.done {
+		close(ch)
+		return ch
+	}
+	m.channels = append(m.channels, ch)
+	m.sinks = append(m.sinks, SinkFunc(func(e Event) {
+		ch <- e
+	}))
+	return ch
+}
+
+// Run sends every event from the channel to the subscribed sinks, until the
+// channel is closed. Then the subscribed channels are closed. Run must only
+// be called once.
+func (m *Multiplexer) Run(ch <-chan Event) {
+	for e := range ch {
+		for _, sink := range m.subscribed() {
+			sink.Send(e)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.done = true
+	for _, ch := range m.channels {
+		close(ch)
+	}
+	m.channels = nil
+}
+
+// subscribed returns a snapshot of the subscribed sinks.
+func (m *Multiplexer) subscribed() []Sink {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sinks := make([]Sink, len(m.sinks))
+	copy(sinks, m.sinks)
+	return sinks
+}
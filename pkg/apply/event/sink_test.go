@@ -0,0 +1,56 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package event
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiplexer(t *testing.T) {
+	events := []Event{
+		{Type: InitType},
+		{Type: ApplyType},
+		{Type: ActionGroupType},
+	}
+
+	var funcEvents []Event
+	m := NewMultiplexer(SinkFunc(func(e Event) {
+		funcEvents = append(funcEvents, e)
+	}))
+
+	// Two channel subscribers, like two printers, each receive every event.
+	var wg sync.WaitGroup
+	channelEvents := make([][]Event, 2)
+	for i := range channelEvents {
+		i := i
+		ch := m.SubscribeChannel()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for e := range ch {
+				channelEvents[i] = append(channelEvents[i], e)
+			}
+		}()
+	}
+
+	eventChannel := make(chan Event, len(events))
+	for _, e := range events {
+		eventChannel <- e
+	}
+	close(eventChannel)
+
+	m.Run(eventChannel)
+	wg.Wait()
+
+	assert.Equal(t, events, funcEvents)
+	assert.Equal(t, events, channelEvents[0])
+	assert.Equal(t, events, channelEvents[1])
+
+	// Channels subscribed after Run completed are closed.
+	_, ok := <-m.SubscribeChannel()
+	assert.False(t, ok)
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// CRDInstancesFilter implements ValidationFilter interface to determine
+// if a CustomResourceDefinition should not be pruned (deleted) because
+// instances of the custom resource still exist in the cluster. Deleting a
+// CRD deletes all its custom resources, including ones that are not managed
+// by the same inventory.
+type CRDInstancesFilter struct {
+	Client dynamic.Interface
+	Mapper meta.RESTMapper
+}
+
+// Name returns a filter identifier for logging.
+func (cif CRDInstancesFilter) Name() string {
+	return "CRDInstancesFilter"
+}
+
+// Filter returns a CRDInstancesExistError if the object prune/delete should
+// be skipped.
+func (cif CRDInstancesFilter) Filter(obj *unstructured.Unstructured) error {
+	if !object.IsCRD(obj) {
+		return nil
+	}
+	gk, found := object.GetCRDGroupKind(obj)
+	if !found {
+		return nil
+	}
+	count, err := cif.countInstances(gk)
+	if err != nil {
+		return NewFatalError(fmt.Errorf("failed to list custom resources: %w", err))
+	}
+	if count > 0 {
+		return &CRDInstancesExistError{
+			GroupKind: gk,
+			Count:     count,
+		}
+	}
+	return nil
+}
+
+// countInstances returns the number of custom resources of the GroupKind in
+// all namespaces. Returns zero if the resource type is not served.
+func (cif CRDInstancesFilter) countInstances(gk schema.GroupKind) (int64, error) {
+	mapping, err := cif.Mapper.RESTMapping(gk)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	// Only the first page is needed if the server counts the remaining
+	// items. Otherwise, the remaining pages are counted.
+	opts := metav1.ListOptions{Limit: 1}
+	var count int64
+	for {
+		list, err := cif.Client.Resource(mapping.Resource).List(context.TODO(), opts)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return 0, nil
+			}
+			return 0, err
+		}
+		count += int64(len(list.Items))
+		if remaining := list.GetRemainingItemCount(); remaining != nil {
+			return count + *remaining, nil
+		}
+		if list.GetContinue() == "" {
+			return count, nil
+		}
+		opts = metav1.ListOptions{Limit: countPageSize, Continue: list.GetContinue()}
+	}
+}
+
+// countPageSize is the page size used to count custom resources, if the
+// server does not count the remaining items.
+const countPageSize = 500
+
+type CRDInstancesExistError struct {
+	GroupKind schema.GroupKind
+	Count     int64
+}
+
+func (e *CRDInstancesExistError) Error() string {
+	return fmt.Sprintf("custom resource definition still has instances: %d %s", e.Count, e.GroupKind)
+}
+
+func (e *CRDInstancesExistError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*CRDInstancesExistError)
+	if !ok {
+		return false
+	}
+	return e.GroupKind == tErr.GroupKind &&
+		e.Count == tErr.Count
+}
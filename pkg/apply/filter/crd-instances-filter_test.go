@@ -0,0 +1,170 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+var crdObj = &unstructured.Unstructured{
+	Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata": map[string]interface{}{
+			"name": "anvils.acme.com",
+		},
+		"spec": map[string]interface{}{
+			"group": "acme.com",
+			"names": map[string]interface{}{
+				"kind":   "Anvil",
+				"plural": "anvils",
+			},
+		},
+	},
+}
+
+func anvil(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "acme.com/v1",
+			"kind":       "Anvil",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "test-namespace",
+			},
+		},
+	}
+}
+
+func anvilList(continueToken string, remaining *int64, items ...*unstructured.Unstructured) *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetAPIVersion("acme.com/v1")
+	list.SetKind("AnvilList")
+	list.SetContinue(continueToken)
+	list.SetRemainingItemCount(remaining)
+	for _, item := range items {
+		list.Items = append(list.Items, *item)
+	}
+	return list
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+// pagedClient returns the pages from the list calls, in order. The fake
+// dynamic client does not keep the continue token and the remaining item
+// count of lists.
+type pagedClient struct {
+	dynamic.Interface
+	pages []*unstructured.UnstructuredList
+}
+
+func (c *pagedClient) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &pagedResource{NamespaceableResourceInterface: c.Interface.Resource(gvr), client: c}
+}
+
+type pagedResource struct {
+	dynamic.NamespaceableResourceInterface
+	client *pagedClient
+}
+
+func (r *pagedResource) List(context.Context, metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	page := r.client.pages[0]
+	r.client.pages = r.client.pages[1:]
+	return page, nil
+}
+
+func TestCRDInstancesFilter(t *testing.T) {
+	anvilGVK := schema.GroupVersionKind{Group: "acme.com", Version: "v1", Kind: "Anvil"}
+	anvilGVR := schema.GroupVersionResource{Group: "acme.com", Version: "v1", Resource: "anvils"}
+
+	tests := map[string]struct {
+		obj         *unstructured.Unstructured
+		clusterObjs []runtime.Object
+		mapped      bool
+		// pages, if set, are returned by the list calls, in order.
+		pages         []*unstructured.UnstructuredList
+		expectedError error
+	}{
+		"not a CRD, not filtered": {
+			obj:    defaultObj,
+			mapped: true,
+		},
+		"CRD without instances, not filtered": {
+			obj:    crdObj,
+			mapped: true,
+		},
+		"CRD not served, not filtered": {
+			obj:    crdObj,
+			mapped: false,
+		},
+		"CRD with instances, filtered and error": {
+			obj: crdObj,
+			clusterObjs: []runtime.Object{
+				anvil("anvil-1"),
+				anvil("anvil-2"),
+			},
+			mapped: true,
+			expectedError: &CRDInstancesExistError{
+				GroupKind: anvilGVK.GroupKind(),
+				Count:     2,
+			},
+		},
+		"CRD with instances counted by the server": {
+			obj:    crdObj,
+			mapped: true,
+			pages: []*unstructured.UnstructuredList{
+				anvilList("next", int64Ptr(4), anvil("anvil-1")),
+			},
+			expectedError: &CRDInstancesExistError{
+				GroupKind: anvilGVK.GroupKind(),
+				Count:     5,
+			},
+		},
+		"CRD with instances not counted by the server": {
+			obj:    crdObj,
+			mapped: true,
+			pages: []*unstructured.UnstructuredList{
+				anvilList("next", nil, anvil("anvil-1")),
+				anvilList("", nil, anvil("anvil-2"), anvil("anvil-3")),
+			},
+			expectedError: &CRDInstancesExistError{
+				GroupKind: anvilGVK.GroupKind(),
+				Count:     3,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{anvilGVK.GroupVersion()})
+			if tc.mapped {
+				mapper.Add(anvilGVK, meta.RESTScopeNamespace)
+			}
+			var client dynamic.Interface = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{anvilGVR: "AnvilList"},
+				tc.clusterObjs...)
+			if len(tc.pages) > 0 {
+				client = &pagedClient{Interface: client, pages: tc.pages}
+			}
+			filter := CRDInstancesFilter{
+				Client: client,
+				Mapper: mapper,
+			}
+			err := filter.Filter(tc.obj.DeepCopy())
+			testutil.AssertEqual(t, tc.expectedError, err)
+		})
+	}
+}
@@ -4,6 +4,8 @@
 package filter
 
 import (
+	"context"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -18,3 +20,20 @@ type ValidationFilter interface {
 	// should be skipped for this object.
 	Filter(obj *unstructured.Unstructured) error
 }
+
+// ContextValidationFilter is implemented by ValidationFilters that make
+// requests, so that the requests use the context of the run.
+type ContextValidationFilter interface {
+	ValidationFilter
+	// FilterWithContext is called instead of Filter.
+	FilterWithContext(ctx context.Context, obj *unstructured.Unstructured) error
+}
+
+// Evaluate runs the filter, passing it the context if it is a
+// ContextValidationFilter.
+func Evaluate(ctx context.Context, f ValidationFilter, obj *unstructured.Unstructured) error {
+	if cf, ok := f.(ContextValidationFilter); ok {
+		return cf.FilterWithContext(ctx, obj)
+	}
+	return f.Filter(obj)
+}
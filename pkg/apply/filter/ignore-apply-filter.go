@@ -0,0 +1,60 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/common"
+)
+
+// IgnoreApplyFilter implements ValidationFilter interface to determine
+// if an object should not be applied because of an "ignore" annotation.
+// Objects skipped by this filter remain in the inventory, so they are not
+// pruned.
+type IgnoreApplyFilter struct{}
+
+const IgnoreApplyFilterName = "IgnoreApplyFilter"
+
+// Name returns the preferred name for the filter. Usually
+// used for logging.
+func (iaf IgnoreApplyFilter) Name() string {
+	return IgnoreApplyFilterName
+}
+
+// Filter returns a AnnotationPreventedApplyError if the object apply
+// should be skipped.
+func (iaf IgnoreApplyFilter) Filter(obj *unstructured.Unstructured) error {
+	for annotation, value := range obj.GetAnnotations() {
+		if common.NoApply(annotation, value) {
+			return &AnnotationPreventedApplyError{
+				Annotation: annotation,
+				Value:      value,
+			}
+		}
+	}
+	return nil
+}
+
+type AnnotationPreventedApplyError struct {
+	Annotation string
+	Value      string
+}
+
+func (e *AnnotationPreventedApplyError) Error() string {
+	return fmt.Sprintf("annotation prevents apply (%q: %q)", e.Annotation, e.Value)
+}
+
+func (e *AnnotationPreventedApplyError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*AnnotationPreventedApplyError)
+	if !ok {
+		return false
+	}
+	return e.Annotation == tErr.Annotation &&
+		e.Value == tErr.Value
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"testing"
+
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestIgnoreApplyAnnotation(t *testing.T) {
+	tests := map[string]struct {
+		annotations   map[string]string
+		expectedError error
+	}{
+		"Nil map returns nil": {
+			annotations: nil,
+		},
+		"Empty map returns nil": {
+			annotations: map[string]string{},
+		},
+		"Wrong annotation key/value returns nil": {
+			annotations: map[string]string{
+				"foo": "bar",
+			},
+		},
+		"Annotation key with false value returns nil": {
+			annotations: map[string]string{
+				common.IgnoreAnnotation: "false",
+			},
+		},
+		"Annotation key and true value returns error": {
+			annotations: map[string]string{
+				common.IgnoreAnnotation: common.IgnoreTrue,
+			},
+			expectedError: &AnnotationPreventedApplyError{
+				Annotation: common.IgnoreAnnotation,
+				Value:      common.IgnoreTrue,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			filter := IgnoreApplyFilter{}
+			obj := defaultObj.DeepCopy()
+			obj.SetAnnotations(tc.annotations)
+			err := filter.Filter(obj)
+			testutil.AssertEqual(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// InvalidObjectFilter implements ValidationFilter interface to determine
+// if an object should not be applied because it was found invalid during
+// the run, like by the ValidateTask with the SkipInvalid ValidationPolicy.
+// Objects skipped by this filter remain in the inventory, so they are not
+// pruned.
+type InvalidObjectFilter struct {
+	TaskContext *taskrunner.TaskContext
+}
+
+const InvalidObjectFilterName = "InvalidObjectFilter"
+
+// Name returns the preferred name for the filter. Usually
+// used for logging.
+func (iof InvalidObjectFilter) Name() string {
+	return InvalidObjectFilterName
+}
+
+// Filter returns an InvalidObjectError if the object is invalid.
+func (iof InvalidObjectFilter) Filter(obj *unstructured.Unstructured) error {
+	id := object.UnstructuredToObjMetadata(obj)
+	if iof.TaskContext.IsInvalidObject(id) {
+		return &InvalidObjectError{Object: id}
+	}
+	return nil
+}
+
+type InvalidObjectError struct {
+	Object object.ObjMetadata
+}
+
+func (e *InvalidObjectError) Error() string {
+	return "object failed validation"
+}
+
+func (e *InvalidObjectError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*InvalidObjectError)
+	if !ok {
+		return false
+	}
+	return e.Object == tErr.Object
+}
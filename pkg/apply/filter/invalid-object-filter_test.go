@@ -0,0 +1,39 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"testing"
+
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestInvalidObjectFilter(t *testing.T) {
+	tests := map[string]struct {
+		invalid       bool
+		expectedError error
+	}{
+		"Valid object returns nil": {},
+		"Invalid object returns error": {
+			invalid: true,
+			expectedError: &InvalidObjectError{
+				Object: object.UnstructuredToObjMetadata(defaultObj),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			taskContext := taskrunner.NewTaskContext(nil, nil)
+			if tc.invalid {
+				taskContext.AddInvalidObject(object.UnstructuredToObjMetadata(defaultObj))
+			}
+			filter := InvalidObjectFilter{TaskContext: taskContext}
+			err := filter.Filter(defaultObj.DeepCopy())
+			testutil.AssertEqual(t, tc.expectedError, err)
+		})
+	}
+}
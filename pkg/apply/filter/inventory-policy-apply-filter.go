@@ -38,6 +38,12 @@ func (ipaf InventoryPolicyApplyFilter) Filter(obj *unstructured.Unstructured) er
 	if ipaf.InvPolicy == inventory.PolicyAdoptAll {
 		return nil
 	}
+	// Objects with a generated name do not exist yet, so the policy is
+	// checked against the object that will be created.
+	if object.HasGeneratedName(obj) {
+		_, err := inventory.CanApply(ipaf.Inv, obj, ipaf.InvPolicy)
+		return err
+	}
 	// Object must be retrieved from the cluster to get the inventory id.
 	clusterObj, err := ipaf.getObject(object.UnstructuredToObjMetadata(obj))
 	if err != nil {
@@ -31,6 +31,7 @@ func TestInventoryPolicyApplyFilter(t *testing.T) {
 	tests := map[string]struct {
 		inventoryID    string
 		objInventoryID string
+		generated      bool
 		policy         inventory.Policy
 		expectedError  error
 	}{
@@ -84,6 +85,23 @@ func TestInventoryPolicyApplyFilter(t *testing.T) {
 				Status:   inventory.NoMatch,
 			},
 		},
+		"generated name and ids match, not filtered": {
+			inventoryID:    "foo",
+			objInventoryID: "foo",
+			generated:      true,
+			policy:         inventory.PolicyMustMatch,
+		},
+		"generated name and ids do not match and policy must match, filtered and error": {
+			inventoryID:    "foo",
+			objInventoryID: "bar",
+			generated:      true,
+			policy:         inventory.PolicyMustMatch,
+			expectedError: &inventory.PolicyPreventedActuationError{
+				Strategy: actuation.ActuationStrategyApply,
+				Policy:   inventory.PolicyMustMatch,
+				Status:   inventory.NoMatch,
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -93,6 +111,10 @@ func TestInventoryPolicyApplyFilter(t *testing.T) {
 				"config.k8s.io/owning-inventory": tc.objInventoryID,
 			}
 			obj.SetAnnotations(objIDAnnotation)
+			if tc.generated {
+				obj.SetGenerateName(obj.GetName() + "-")
+				obj.SetName("")
+			}
 			invIDLabel := map[string]string{
 				common.InventoryLabel: tc.inventoryID,
 			}
@@ -0,0 +1,170 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// NamespaceForeignObjectsFilter implements ValidationFilter interface to
+// determine if a Namespace should not be pruned (deleted) because it
+// contains objects that are not owned by the inventory. Deleting a Namespace
+// deletes all the objects in it, including ones managed by other inventories
+// or not managed by any inventory.
+type NamespaceForeignObjectsFilter struct {
+	Client    dynamic.Interface
+	Discovery discovery.ServerResourcesInterface
+	Inv       inventory.Info
+}
+
+// Name returns a filter identifier for logging.
+func (nff NamespaceForeignObjectsFilter) Name() string {
+	return "NamespaceForeignObjectsFilter"
+}
+
+// Filter returns a NamespaceForeignObjectsError if the object prune/delete
+// should be skipped.
+func (nff NamespaceForeignObjectsFilter) Filter(obj *unstructured.Unstructured) error {
+	if !object.IsNamespace(obj) {
+		return nil
+	}
+	foreignObjs, err := nff.foreignObjects(obj.GetName())
+	if err != nil {
+		return NewFatalError(fmt.Errorf("failed to list namespace objects: %w", err))
+	}
+	if len(foreignObjs) > 0 {
+		return &NamespaceForeignObjectsError{
+			Namespace: obj.GetName(),
+			Objects:   foreignObjs,
+		}
+	}
+	return nil
+}
+
+// foreignObjects returns the objects in the namespace that are not owned by
+// the inventory.
+func (nff NamespaceForeignObjectsFilter) foreignObjects(namespace string) (object.ObjMetadataSet, error) {
+	resourceLists, err := nff.Discovery.ServerPreferredNamespacedResources()
+	if err != nil {
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, err
+		}
+		// Unavailable API groups can't have objects to list.
+		klog.V(4).Infof("partial discovery failure: %v", err)
+	}
+	resourceLists = discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"list"}}, resourceLists)
+
+	var foreignObjs object.ObjMetadataSet
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range resourceList.APIResources {
+			if isIgnoredResource(gv.Group, resource.Name) {
+				continue
+			}
+			gvr := gv.WithResource(resource.Name)
+			list, err := nff.Client.Resource(gvr).Namespace(namespace).List(context.TODO(), metav1.ListOptions{})
+			if err != nil {
+				if apierrors.IsNotFound(err) || apierrors.IsMethodNotSupported(err) {
+					continue
+				}
+				return nil, err
+			}
+			for i := range list.Items {
+				item := &list.Items[i]
+				if nff.isOwned(item) {
+					continue
+				}
+				foreignObjs = append(foreignObjs, object.UnstructuredToObjMetadata(item))
+			}
+		}
+	}
+	return foreignObjs, nil
+}
+
+// isOwned returns true if the object is owned by the inventory, or is
+// otherwise expected to be deleted along with the namespace.
+func (nff NamespaceForeignObjectsFilter) isOwned(obj *unstructured.Unstructured) bool {
+	// Objects applied with this inventory.
+	if inventory.IDMatch(nff.Inv, obj) == inventory.Match {
+		return true
+	}
+	// The inventory object itself.
+	if id := nff.Inv.ID(); id != "" && obj.GetLabels()[common.InventoryLabel] == id {
+		return true
+	}
+	// Dependents are garbage collected with their owners.
+	if len(obj.GetOwnerReferences()) > 0 {
+		return true
+	}
+	return isDefaultNamespaceObject(obj)
+}
+
+// isIgnoredResource returns true for resources that are not user managed.
+func isIgnoredResource(group, resource string) bool {
+	return resource == "events" && (group == "" || group == "events.k8s.io")
+}
+
+// isDefaultNamespaceObject returns true for objects that are created in every
+// namespace by the Kubernetes control plane.
+func isDefaultNamespaceObject(obj *unstructured.Unstructured) bool {
+	gk := obj.GroupVersionKind().GroupKind()
+	switch {
+	case gk == schema.GroupKind{Kind: "ServiceAccount"} && obj.GetName() == "default":
+		return true
+	case gk == schema.GroupKind{Kind: "ConfigMap"} && obj.GetName() == "kube-root-ca.crt":
+		return true
+	case gk == schema.GroupKind{Kind: "Secret"}:
+		secretType, _, _ := unstructured.NestedString(obj.Object, "type")
+		return secretType == "kubernetes.io/service-account-token"
+	}
+	return false
+}
+
+type NamespaceForeignObjectsError struct {
+	Namespace string
+	Objects   object.ObjMetadataSet
+}
+
+func (e *NamespaceForeignObjectsError) Error() string {
+	// Only list a few objects, to keep the message readable.
+	const maxListed = 3
+	var ids []string
+	for i, id := range e.Objects {
+		if i == maxListed {
+			ids = append(ids, "...")
+			break
+		}
+		ids = append(ids, id.String())
+	}
+	return fmt.Sprintf("namespace contains %d object(s) not owned by the inventory: %s",
+		len(e.Objects), strings.Join(ids, ", "))
+}
+
+func (e *NamespaceForeignObjectsError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*NamespaceForeignObjectsError)
+	if !ok {
+		return false
+	}
+	return e.Namespace == tErr.Namespace &&
+		e.Objects.Equal(tErr.Objects)
+}
@@ -0,0 +1,148 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+// fakeNamespacedDiscovery returns the configured resources, because
+// FakeDiscovery does not implement ServerPreferredNamespacedResources.
+type fakeNamespacedDiscovery struct {
+	*fakediscovery.FakeDiscovery
+}
+
+func (f fakeNamespacedDiscovery) ServerPreferredNamespacedResources() ([]*metav1.APIResourceList, error) {
+	return f.Resources, nil
+}
+
+var namespaceObj = &unstructured.Unstructured{
+	Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Namespace",
+		"metadata": map[string]interface{}{
+			"name": "test-namespace",
+		},
+	},
+}
+
+func namespacedObj(kind, name string, annotations map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "test-namespace",
+			},
+		},
+	}
+	obj.SetAnnotations(annotations)
+	return obj
+}
+
+func TestNamespaceForeignObjectsFilter(t *testing.T) {
+	owned := map[string]string{inventory.OwningInventoryKey: "inv-id"}
+	otherOwner := map[string]string{inventory.OwningInventoryKey: "other-inv-id"}
+
+	ownerRefPod := namespacedObj("Pod", "owned-by-rs", nil)
+	ownerRefPod.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: "apps/v1",
+		Kind:       "ReplicaSet",
+		Name:       "rs",
+		UID:        "uid",
+	}})
+	invObj := namespacedObj("ConfigMap", "inventory", nil)
+	invObj.SetLabels(map[string]string{common.InventoryLabel: "inv-id"})
+
+	tests := map[string]struct {
+		obj           *unstructured.Unstructured
+		clusterObjs   []runtime.Object
+		expectedError error
+	}{
+		"not a namespace, not filtered": {
+			obj: defaultObj,
+			clusterObjs: []runtime.Object{
+				namespacedObj("Pod", "foreign", nil),
+			},
+		},
+		"empty namespace, not filtered": {
+			obj: namespaceObj,
+		},
+		"only owned and default objects, not filtered": {
+			obj: namespaceObj,
+			clusterObjs: []runtime.Object{
+				namespacedObj("Pod", "owned", owned),
+				ownerRefPod,
+				invObj,
+				namespacedObj("ServiceAccount", "default", nil),
+				namespacedObj("ConfigMap", "kube-root-ca.crt", nil),
+			},
+		},
+		"foreign objects, filtered and error": {
+			obj: namespaceObj,
+			clusterObjs: []runtime.Object{
+				namespacedObj("Pod", "owned", owned),
+				namespacedObj("Pod", "unmanaged", nil),
+				namespacedObj("ConfigMap", "other-inventory", otherOwner),
+			},
+			expectedError: &NamespaceForeignObjectsError{
+				Namespace: "test-namespace",
+				Objects: object.ObjMetadataSet{
+					object.UnstructuredToObjMetadata(namespacedObj("Pod", "unmanaged", nil)),
+					object.UnstructuredToObjMetadata(namespacedObj("ConfigMap", "other-inventory", nil)),
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			invInfo := inventoryObj.DeepCopy()
+			invInfo.SetLabels(map[string]string{common.InventoryLabel: "inv-id"})
+			filter := NamespaceForeignObjectsFilter{
+				Client: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+					map[schema.GroupVersionResource]string{
+						{Version: "v1", Resource: "pods"}:            "PodList",
+						{Version: "v1", Resource: "configmaps"}:      "ConfigMapList",
+						{Version: "v1", Resource: "serviceaccounts"}: "ServiceAccountList",
+						{Version: "v1", Resource: "events"}:          "EventList",
+					},
+					tc.clusterObjs...),
+				Discovery: fakeNamespacedDiscovery{
+					FakeDiscovery: &fakediscovery.FakeDiscovery{
+						Fake: &clienttesting.Fake{
+							Resources: []*metav1.APIResourceList{
+								{
+									GroupVersion: "v1",
+									APIResources: []metav1.APIResource{
+										{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: []string{"list"}},
+										{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: []string{"list"}},
+										{Name: "serviceaccounts", Kind: "ServiceAccount", Namespaced: true, Verbs: []string{"list"}},
+										{Name: "events", Kind: "Event", Namespaced: true, Verbs: []string{"list"}},
+									},
+								},
+							},
+						},
+					},
+				},
+				Inv: inventory.WrapInventoryInfoObj(invInfo),
+			}
+			err := filter.Filter(tc.obj.DeepCopy())
+			testutil.AssertEqual(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// NoCreateApplyFilter implements ValidationFilter interface to determine
+// if an object should not be applied because it does not exist in the
+// cluster yet. It is used to update existing objects without creating the
+// missing ones.
+type NoCreateApplyFilter struct {
+	Client dynamic.Interface
+	Mapper meta.RESTMapper
+}
+
+const NoCreateApplyFilterName = "NoCreateApplyFilter"
+
+// Name returns the preferred name for the filter. Usually
+// used for logging.
+func (ncaf NoCreateApplyFilter) Name() string {
+	return NoCreateApplyFilterName
+}
+
+// Filter returns a CreatePreventedError if the object does not exist in the
+// cluster. Objects with a generated name never exist, so they are always
+// filtered.
+func (ncaf NoCreateApplyFilter) Filter(obj *unstructured.Unstructured) error {
+	id := object.UnstructuredToObjMetadata(obj)
+	if object.HasGeneratedName(obj) {
+		return &CreatePreventedError{Object: id}
+	}
+	mapping, err := ncaf.Mapper.RESTMapping(id.GroupKind)
+	if err != nil {
+		return NewFatalError(fmt.Errorf("failed to get current object from cluster: %w", err))
+	}
+	_, err = ncaf.Client.Resource(mapping.Resource).Namespace(id.Namespace).
+		Get(context.TODO(), id.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return &CreatePreventedError{Object: id, Err: err}
+		}
+		return NewFatalError(fmt.Errorf("failed to get current object from cluster: %w", err))
+	}
+	return nil
+}
+
+// CreatePreventedError is the reason an object was skipped by the
+// NoCreateApplyFilter. Err is the NotFound error of the object, so that
+// apierrors.IsNotFound is true for it. It is nil for objects with a generated
+// name, which are not looked up.
+type CreatePreventedError struct {
+	Object object.ObjMetadata
+	Err    error
+}
+
+func (e *CreatePreventedError) Error() string {
+	if e.Err == nil {
+		return "create prevented: object has a generated name"
+	}
+	return fmt.Sprintf("create prevented: %v", e.Err)
+}
+
+func (e *CreatePreventedError) Unwrap() error {
+	return e.Err
+}
+
+func (e *CreatePreventedError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*CreatePreventedError)
+	if !ok {
+		return false
+	}
+	return e.Object == tErr.Object
+}
@@ -0,0 +1,65 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestNoCreateApplyFilter(t *testing.T) {
+	generatedObj := defaultObj.DeepCopy()
+	generatedObj.SetName("")
+	generatedObj.SetGenerateName("pod-")
+
+	tests := map[string]struct {
+		obj           *unstructured.Unstructured
+		clusterObjs   []runtime.Object
+		expectedError error
+		notFound      bool
+	}{
+		"object exists, not filtered": {
+			obj:         defaultObj,
+			clusterObjs: []runtime.Object{defaultObj},
+		},
+		"object missing, filtered and error": {
+			obj: defaultObj,
+			expectedError: &CreatePreventedError{
+				Object: object.UnstructuredToObjMetadata(defaultObj),
+			},
+			notFound: true,
+		},
+		"object with generated name, filtered and error": {
+			obj: generatedObj,
+			expectedError: &CreatePreventedError{
+				Object: object.UnstructuredToObjMetadata(generatedObj),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			filter := NoCreateApplyFilter{
+				Client: dynamicfake.NewSimpleDynamicClient(scheme.Scheme, tc.clusterObjs...),
+				Mapper: testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme,
+					scheme.Scheme.PrioritizedVersionsAllGroups()...),
+			}
+			err := filter.Filter(tc.obj)
+			if tc.expectedError == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.notFound, apierrors.IsNotFound(err))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// PruneGroupKindFilter implements ValidationFilter interface to determine
+// if an object should not be pruned (deleted) because its GroupKind is not
+// in the allowlist or is in the denylist.
+type PruneGroupKindFilter struct {
+	// Allowed is the list of GroupKinds that may be pruned. If empty, all
+	// GroupKinds not in Denied may be pruned.
+	Allowed []schema.GroupKind
+	// Denied is the list of GroupKinds that must never be pruned.
+	Denied []schema.GroupKind
+}
+
+const PruneGroupKindFilterName = "PruneGroupKindFilter"
+
+// Name returns a filter identifier for logging.
+func (pgf PruneGroupKindFilter) Name() string {
+	return PruneGroupKindFilterName
+}
+
+// Filter returns a GroupKindPreventedPruneError if the object prune/delete
+// should be skipped.
+func (pgf PruneGroupKindFilter) Filter(obj *unstructured.Unstructured) error {
+	gk := obj.GroupVersionKind().GroupKind()
+	if containsGroupKind(pgf.Denied, gk) {
+		return &GroupKindPreventedPruneError{
+			GroupKind: gk,
+			Reason:    "denylist",
+		}
+	}
+	if len(pgf.Allowed) > 0 && !containsGroupKind(pgf.Allowed, gk) {
+		return &GroupKindPreventedPruneError{
+			GroupKind: gk,
+			Reason:    "allowlist",
+		}
+	}
+	return nil
+}
+
+func containsGroupKind(gks []schema.GroupKind, gk schema.GroupKind) bool {
+	for _, g := range gks {
+		if g == gk {
+			return true
+		}
+	}
+	return false
+}
+
+type GroupKindPreventedPruneError struct {
+	GroupKind schema.GroupKind
+	// Reason is "allowlist" if the GroupKind is not in the allowlist, or
+	// "denylist" if it is in the denylist.
+	Reason string
+}
+
+func (e *GroupKindPreventedPruneError) Error() string {
+	if e.Reason == "allowlist" {
+		return fmt.Sprintf("prune allowlist does not include %q", e.GroupKind)
+	}
+	return fmt.Sprintf("prune denylist includes %q", e.GroupKind)
+}
+
+func (e *GroupKindPreventedPruneError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*GroupKindPreventedPruneError)
+	if !ok {
+		return false
+	}
+	return e.GroupKind == tErr.GroupKind &&
+		e.Reason == tErr.Reason
+}
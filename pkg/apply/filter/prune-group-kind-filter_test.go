@@ -0,0 +1,64 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestPruneGroupKindFilter(t *testing.T) {
+	podGK := schema.GroupKind{Kind: "Pod"}
+	deploymentGK := schema.GroupKind{Group: "apps", Kind: "Deployment"}
+	pvcGK := schema.GroupKind{Kind: "PersistentVolumeClaim"}
+
+	tests := map[string]struct {
+		allowed       []schema.GroupKind
+		denied        []schema.GroupKind
+		expectedError error
+	}{
+		"no lists, not filtered": {},
+		"in allowlist, not filtered": {
+			allowed: []schema.GroupKind{deploymentGK, podGK},
+		},
+		"not in allowlist, filtered": {
+			allowed: []schema.GroupKind{deploymentGK},
+			expectedError: &GroupKindPreventedPruneError{
+				GroupKind: podGK,
+				Reason:    "allowlist",
+			},
+		},
+		"not in denylist, not filtered": {
+			denied: []schema.GroupKind{pvcGK},
+		},
+		"in denylist, filtered": {
+			denied: []schema.GroupKind{pvcGK, podGK},
+			expectedError: &GroupKindPreventedPruneError{
+				GroupKind: podGK,
+				Reason:    "denylist",
+			},
+		},
+		"in both lists, denylist wins": {
+			allowed: []schema.GroupKind{podGK},
+			denied:  []schema.GroupKind{podGK},
+			expectedError: &GroupKindPreventedPruneError{
+				GroupKind: podGK,
+				Reason:    "denylist",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			filter := PruneGroupKindFilter{
+				Allowed: tc.allowed,
+				Denied:  tc.denied,
+			}
+			err := filter.Filter(defaultObj.DeepCopy())
+			testutil.AssertEqual(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// SelectorFilter implements ValidationFilter interface to determine if an
+// object should be skipped because it is not selected for the run, either by
+// its labels or by its GroupKind. It is used for both apply and prune, so
+// that the objects outside of the selection are neither applied nor pruned.
+type SelectorFilter struct {
+	// Selector is the label selector of the objects. If nil, objects are
+	// not selected by label.
+	Selector labels.Selector
+	// Included is the list of GroupKinds of the objects. If empty, all
+	// GroupKinds not in Excluded are selected.
+	Included []schema.GroupKind
+	// Excluded is the list of GroupKinds that are never selected.
+	Excluded []schema.GroupKind
+}
+
+const SelectorFilterName = "SelectorFilter"
+
+// Name returns a filter identifier for logging.
+func (sf SelectorFilter) Name() string {
+	return SelectorFilterName
+}
+
+// Filter returns an ObjectNotSelectedError if the object is not selected.
+func (sf SelectorFilter) Filter(obj *unstructured.Unstructured) error {
+	gk := obj.GroupVersionKind().GroupKind()
+	if containsGroupKind(sf.Excluded, gk) {
+		return &ObjectNotSelectedError{
+			GroupKind: gk,
+			Reason:    "exclude",
+		}
+	}
+	if len(sf.Included) > 0 && !containsGroupKind(sf.Included, gk) {
+		return &ObjectNotSelectedError{
+			GroupKind: gk,
+			Reason:    "include",
+		}
+	}
+	if sf.Selector != nil && !sf.Selector.Matches(labels.Set(obj.GetLabels())) {
+		return &ObjectNotSelectedError{
+			GroupKind: gk,
+			Selector:  sf.Selector.String(),
+			Reason:    "selector",
+		}
+	}
+	return nil
+}
+
+type ObjectNotSelectedError struct {
+	GroupKind schema.GroupKind
+	// Selector is the label selector that the object does not match, if
+	// Reason is "selector".
+	Selector string
+	// Reason is "selector" if the labels do not match the selector,
+	// "include" if the GroupKind is not in the included list, or "exclude"
+	// if it is in the excluded list.
+	Reason string
+}
+
+func (e *ObjectNotSelectedError) Error() string {
+	switch e.Reason {
+	case "selector":
+		return fmt.Sprintf("object labels do not match selector %q", e.Selector)
+	case "include":
+		return fmt.Sprintf("included kinds do not include %q", e.GroupKind)
+	default:
+		return fmt.Sprintf("excluded kinds include %q", e.GroupKind)
+	}
+}
+
+func (e *ObjectNotSelectedError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*ObjectNotSelectedError)
+	if !ok {
+		return false
+	}
+	return e.GroupKind == tErr.GroupKind &&
+		e.Selector == tErr.Selector &&
+		e.Reason == tErr.Reason
+}
@@ -0,0 +1,105 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestSelectorFilter(t *testing.T) {
+	podGK := schema.GroupKind{Kind: "Pod"}
+	crdGK := schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+
+	tests := map[string]struct {
+		labels        map[string]string
+		selector      string
+		included      []schema.GroupKind
+		excluded      []schema.GroupKind
+		expectedError error
+	}{
+		"no selection, not filtered": {},
+		"matching labels, not filtered": {
+			labels:   map[string]string{"app": "foo"},
+			selector: "app=foo",
+		},
+		"other labels, filtered": {
+			labels:   map[string]string{"app": "bar"},
+			selector: "app=foo",
+			expectedError: &ObjectNotSelectedError{
+				GroupKind: podGK,
+				Selector:  "app=foo",
+				Reason:    "selector",
+			},
+		},
+		"no labels, filtered": {
+			selector: "app",
+			expectedError: &ObjectNotSelectedError{
+				GroupKind: podGK,
+				Selector:  "app",
+				Reason:    "selector",
+			},
+		},
+		"included kind, not filtered": {
+			included: []schema.GroupKind{crdGK, podGK},
+		},
+		"not included kind, filtered": {
+			included: []schema.GroupKind{crdGK},
+			expectedError: &ObjectNotSelectedError{
+				GroupKind: podGK,
+				Reason:    "include",
+			},
+		},
+		"excluded kind, filtered": {
+			excluded: []schema.GroupKind{podGK},
+			expectedError: &ObjectNotSelectedError{
+				GroupKind: podGK,
+				Reason:    "exclude",
+			},
+		},
+		"included and excluded kind, exclude wins": {
+			included: []schema.GroupKind{podGK},
+			excluded: []schema.GroupKind{podGK},
+			expectedError: &ObjectNotSelectedError{
+				GroupKind: podGK,
+				Reason:    "exclude",
+			},
+		},
+		"included kind with other labels, filtered": {
+			labels:   map[string]string{"app": "bar"},
+			selector: "app=foo",
+			included: []schema.GroupKind{podGK},
+			expectedError: &ObjectNotSelectedError{
+				GroupKind: podGK,
+				Selector:  "app=foo",
+				Reason:    "selector",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var selector labels.Selector
+			if tc.selector != "" {
+				var err error
+				selector, err = labels.Parse(tc.selector)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			filter := SelectorFilter{
+				Selector: selector,
+				Included: tc.included,
+				Excluded: tc.excluded,
+			}
+			obj := defaultObj.DeepCopy()
+			obj.SetLabels(tc.labels)
+			err := filter.Filter(obj)
+			testutil.AssertEqual(t, tc.expectedError, err)
+		})
+	}
+}
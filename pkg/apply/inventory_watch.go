@@ -0,0 +1,188 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/aggregator"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// StatusCondition returns true when the latest statuses of the watched
+// objects satisfy the condition. Objects without a status update yet have the
+// Unknown status.
+type StatusCondition func(statuses map[object.ObjMetadata]*pollevent.ResourceStatus) bool
+
+// AllStatus returns a StatusCondition that is true when all the objects
+// have the desired status.
+func AllStatus(desired status.Status) StatusCondition {
+	return func(statuses map[object.ObjMetadata]*pollevent.ResourceStatus) bool {
+		rss := make([]*pollevent.ResourceStatus, 0, len(statuses))
+		for _, rs := range statuses {
+			rss = append(rss, rs)
+		}
+		return aggregator.AggregateStatus(rss, desired) == desired
+	}
+}
+
+// AllKnown returns a StatusCondition that is true when none of the objects
+// has the Unknown status.
+func AllKnown() StatusCondition {
+	return func(statuses map[object.ObjMetadata]*pollevent.ResourceStatus) bool {
+		for _, rs := range statuses {
+			if rs.Status == status.UnknownStatus {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// InventoryWatchOptions are the options of WatchInventory.
+type InventoryWatchOptions struct {
+	// Until is the condition that stops the watch once it is true. If nil,
+	// the objects are watched until the context is cancelled or the timeout
+	// expires.
+	Until StatusCondition
+
+	// Timeout is how long to watch the objects. Zero means no timeout.
+	Timeout time.Duration
+
+	// WatcherOptions are passed to the StatusWatcher.
+	WatcherOptions watcher.Options
+}
+
+// InventoryWatch is the status watch of the objects of an inventory,
+// started by WatchInventory.
+type InventoryWatch struct {
+	// Objects are the objects of the inventory.
+	Objects object.ObjMetadataSet
+
+	// Events receives the status events of the objects. It is closed when
+	// the watch stops.
+	Events <-chan pollevent.Event
+
+	mu  sync.Mutex
+	err error
+}
+
+// Err returns why the watch stopped, once Events is closed: nil if the
+// condition became true, or if there is no condition and the timeout
+// expired, an InventoryWatchTimeoutError if the timeout expired before the
+// condition became true, or the error of the context if it was cancelled.
+func (w *InventoryWatch) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+func (w *InventoryWatch) setErr(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.err = err
+}
+
+// InventoryWatchTimeoutError is the error of an InventoryWatch whose timeout
+// expired before its condition became true.
+type InventoryWatchTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *InventoryWatchTimeoutError) Error() string {
+	return fmt.Sprintf("timeout after %s waiting for the inventory objects", e.Timeout)
+}
+
+// WatchInventory reads the objects of the inventory from the cluster, and
+// watches their status with the statusWatcher until the condition of the
+// options is true, the timeout expires, or the context is cancelled. It
+// allows checking on a previous apply without running it again. If the
+// inventory has no object, the returned watch has no event.
+func WatchInventory(ctx context.Context, invClient inventory.Client, invInfo inventory.Info,
+	statusWatcher watcher.StatusWatcher, options InventoryWatchOptions) (*InventoryWatch, error) {
+	ids, err := invClient.GetClusterObjs(invInfo)
+	if err != nil {
+		return nil, err
+	}
+	eventCh := make(chan pollevent.Event)
+	w := &InventoryWatch{
+		Objects: ids,
+		Events:  eventCh,
+	}
+	if len(ids) == 0 {
+		close(eventCh)
+		return w, nil
+	}
+
+	var watchCtx context.Context
+	var cancel context.CancelFunc
+	if options.Timeout != 0 {
+		watchCtx, cancel = context.WithTimeout(ctx, options.Timeout)
+	} else {
+		watchCtx, cancel = context.WithCancel(ctx)
+	}
+	statuses := make(map[object.ObjMetadata]*pollevent.ResourceStatus, len(ids))
+	for _, id := range ids {
+		statuses[id] = &pollevent.ResourceStatus{
+			Identifier: id,
+			Status:     status.UnknownStatus,
+		}
+	}
+	statusCh := statusWatcher.Watch(watchCtx, ids, options.WatcherOptions)
+	go func() {
+		defer close(eventCh)
+		defer func() {
+			// Stop watching and drain the watcher's channel, so it is not
+			// blocked sending events that will never be received.
+			cancel()
+			for range statusCh {
+			}
+		}()
+		for {
+			select {
+			case <-watchCtx.Done():
+				w.setErr(watchErr(ctx, watchCtx, options, statuses))
+				return
+			case e, ok := <-statusCh:
+				if !ok {
+					w.setErr(watchErr(ctx, watchCtx, options, statuses))
+					return
+				}
+				if e.Type == pollevent.ResourceUpdateEvent && statuses[e.Resource.Identifier] != nil {
+					statuses[e.Resource.Identifier] = e.Resource
+				}
+				select {
+				case eventCh <- e:
+				case <-watchCtx.Done():
+					w.setErr(watchErr(ctx, watchCtx, options, statuses))
+					return
+				}
+				if options.Until != nil && options.Until(statuses) {
+					return
+				}
+			}
+		}
+	}()
+	return w, nil
+}
+
+// watchErr returns the error of a watch that stopped before its condition
+// became true.
+func watchErr(ctx, watchCtx context.Context, options InventoryWatchOptions,
+	statuses map[object.ObjMetadata]*pollevent.ResourceStatus) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if watchCtx.Err() != context.DeadlineExceeded || options.Until == nil || options.Until(statuses) {
+		return nil
+	}
+	return &InventoryWatchTimeoutError{Timeout: options.Timeout}
+}
@@ -0,0 +1,152 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/cli-utils/pkg/apply/applytest"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestWatchInventory(t *testing.T) {
+	inv := inventory.WrapInventoryInfoObj(testutil.Unstructured(t, resources["inventory"]))
+	deploymentID := testutil.ToIdentifier(t, resources["deployment"])
+	secretID := testutil.ToIdentifier(t, resources["secret"])
+
+	testCases := map[string]struct {
+		objs        object.ObjMetadataSet
+		options     InventoryWatchOptions
+		statuses    []*pollevent.ResourceStatus
+		cancel      bool
+		expStatuses []status.Status
+		expErr      error
+	}{
+		"empty inventory": {
+			options: InventoryWatchOptions{
+				Until: AllStatus(status.CurrentStatus),
+			},
+		},
+		"until all current": {
+			objs: object.ObjMetadataSet{deploymentID, secretID},
+			options: InventoryWatchOptions{
+				Until:   AllStatus(status.CurrentStatus),
+				Timeout: 10 * time.Second,
+			},
+			statuses: []*pollevent.ResourceStatus{
+				{Identifier: deploymentID, Status: status.InProgressStatus},
+				{Identifier: secretID, Status: status.CurrentStatus},
+				{Identifier: deploymentID, Status: status.CurrentStatus},
+			},
+			expStatuses: []status.Status{
+				status.InProgressStatus,
+				status.CurrentStatus,
+				status.CurrentStatus,
+			},
+		},
+		"until all known": {
+			objs: object.ObjMetadataSet{deploymentID, secretID},
+			options: InventoryWatchOptions{
+				Until:   AllKnown(),
+				Timeout: 10 * time.Second,
+			},
+			statuses: []*pollevent.ResourceStatus{
+				{Identifier: deploymentID, Status: status.InProgressStatus},
+				{Identifier: secretID, Status: status.FailedStatus},
+			},
+			expStatuses: []status.Status{
+				status.InProgressStatus,
+				status.FailedStatus,
+			},
+		},
+		"timeout before all current": {
+			objs: object.ObjMetadataSet{deploymentID, secretID},
+			options: InventoryWatchOptions{
+				Until:   AllStatus(status.CurrentStatus),
+				Timeout: 100 * time.Millisecond,
+			},
+			statuses: []*pollevent.ResourceStatus{
+				{Identifier: deploymentID, Status: status.InProgressStatus},
+				{Identifier: secretID, Status: status.CurrentStatus},
+			},
+			expStatuses: []status.Status{
+				status.InProgressStatus,
+				status.CurrentStatus,
+			},
+			expErr: &InventoryWatchTimeoutError{Timeout: 100 * time.Millisecond},
+		},
+		"timeout without condition": {
+			objs: object.ObjMetadataSet{deploymentID},
+			options: InventoryWatchOptions{
+				Timeout: 100 * time.Millisecond,
+			},
+			statuses: []*pollevent.ResourceStatus{
+				{Identifier: deploymentID, Status: status.InProgressStatus},
+			},
+			expStatuses: []status.Status{
+				status.InProgressStatus,
+			},
+		},
+		"cancelled": {
+			objs: object.ObjMetadataSet{deploymentID},
+			options: InventoryWatchOptions{
+				Until: AllStatus(status.CurrentStatus),
+			},
+			cancel: true,
+			expErr: context.Canceled,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			invClient := applytest.NewInventoryClient()
+			invClient.SetObjects(inv, tc.objs)
+			statusWatcher := applytest.NewStatusWatcher()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			w, err := WatchInventory(ctx, invClient, inv, statusWatcher, tc.options)
+			require.NoError(t, err)
+			testutil.AssertEqual(t, tc.objs, w.Objects)
+
+			for _, rs := range tc.statuses {
+				statusWatcher.SetStatus(rs.Identifier, rs.Status)
+			}
+			if tc.cancel {
+				cancel()
+			}
+
+			var statuses []status.Status
+			for e := range w.Events {
+				if e.Type == pollevent.ResourceUpdateEvent {
+					statuses = append(statuses, e.Resource.Status)
+				}
+			}
+			assert.Equal(t, tc.expStatuses, statuses)
+			if tc.expErr == nil {
+				assert.NoError(t, w.Err())
+				return
+			}
+			assert.Equal(t, tc.expErr, w.Err())
+		})
+	}
+
+	t.Run("inventory error", func(t *testing.T) {
+		invClient := applytest.NewInventoryClient()
+		invClient.SetError(errors.New("unavailable"))
+		_, err := WatchInventory(context.Background(), invClient, inv,
+			applytest.NewStatusWatcher(), InventoryWatchOptions{})
+		assert.EqualError(t, err, "unavailable")
+	})
+}
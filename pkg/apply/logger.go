@@ -0,0 +1,34 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+)
+
+// runLogger returns the logger for a single run of the Applier or Destroyer,
+// with the inventory ID and a unique run ID as values, so that the logs of
+// concurrent runs can be told apart.
+//
+// The logger from the context takes precedence over the configured logger.
+// If neither is set, klog is used.
+func runLogger(ctx context.Context, configured logr.Logger, invInfo inventory.Info) logr.Logger {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		logger = configured
+		if logger.GetSink() == nil {
+			logger = klog.Background()
+		}
+	}
+	values := []interface{}{"runID", uuid.New().String()}
+	if invInfo != nil {
+		values = append(values, "inventory", invInfo.ID())
+	}
+	return logger.WithValues(values...)
+}
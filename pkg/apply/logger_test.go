@@ -0,0 +1,66 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunLogger(t *testing.T) {
+	invInfo := inventoryInfo{
+		name:      "inv",
+		namespace: "default",
+		id:        "inv-id",
+	}.toWrapped()
+
+	newLogger := func(lines *[]string) logr.Logger {
+		return funcr.New(func(_, args string) {
+			*lines = append(*lines, args)
+		}, funcr.Options{})
+	}
+
+	var configuredLines, contextLines []string
+	configured := newLogger(&configuredLines)
+
+	// The configured logger is used, if the context has no logger.
+	runLogger(context.Background(), configured, invInfo).Info("configured")
+	require.Len(t, configuredLines, 1)
+	assert.Contains(t, configuredLines[0], `"msg"="configured"`)
+	assert.Contains(t, configuredLines[0], `"inventory"="inv-id"`)
+	assert.Contains(t, configuredLines[0], `"runID"=`)
+
+	// The logger from the context takes precedence.
+	ctx := logr.NewContext(context.Background(), newLogger(&contextLines))
+	runLogger(ctx, configured, invInfo).Info("context")
+	assert.Len(t, configuredLines, 1)
+	require.Len(t, contextLines, 1)
+	assert.Contains(t, contextLines[0], `"msg"="context"`)
+	assert.Contains(t, contextLines[0], `"inventory"="inv-id"`)
+
+	// Every run has a different run ID.
+	var lines []string
+	logger := newLogger(&lines)
+	runLogger(context.Background(), logger, nil).Info("first")
+	runLogger(context.Background(), logger, nil).Info("second")
+	require.Len(t, lines, 2)
+	assert.NotContains(t, lines[0], `"inventory"`)
+	assert.NotEqual(t, runID(lines[0]), runID(lines[1]))
+
+	// Without any logger, klog is used.
+	assert.NotNil(t, runLogger(context.Background(), logr.Logger{}, invInfo).GetSink())
+}
+
+// runID returns the run ID from a line logged by funcr.
+func runID(line string) string {
+	const key = `"runID"="`
+	i := strings.Index(line, key) + len(key)
+	return line[i : i+36]
+}
@@ -0,0 +1,224 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics records Prometheus metrics from the events of the Applier
+// and Destroyer.
+//
+// The Recorder is an event.Sink, so it can be subscribed to an
+// event.Multiplexer along with a printer, and registered with an existing
+// Prometheus registry, like the controller-runtime metrics registry:
+//
+//	recorder := metrics.NewRecorder()
+//	if err := recorder.Register(ctrlmetrics.Registry); err != nil {
+//		return err
+//	}
+//	mux := event.NewMultiplexer(recorder)
+//	mux.Run(applier.Run(ctx, invInfo, objs, options))
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+const (
+	// Namespace is the namespace of all the metrics.
+	Namespace = "cli_utils"
+)
+
+// Recorder records metrics from events. It implements event.Sink.
+//
+// Wait durations are measured from when the object was applied or deleted
+// until it was reconciled. If the events include timing (see
+// ApplierOptions.RecordTiming), the timing is used. Otherwise, the time the
+// events were received is used.
+//
+// Each run starts with an InitEvent. Task starts and actuation times left over
+// from the previous run, like objects applied during a dry-run, which are never
+// waited on, are dropped when the next run starts. Runs that send their events
+// to the same Recorder must not overlap.
+type Recorder struct {
+	objects        *prometheus.CounterVec
+	taskDuration   *prometheus.HistogramVec
+	reconcileWait  *prometheus.HistogramVec
+	apiErrors      *prometheus.CounterVec
+	mu             sync.Mutex
+	run            int
+	groupStarts    map[groupKey]time.Time
+	actuationTimes map[object.ObjMetadata]time.Time
+	now            func() time.Time
+}
+
+// groupKey identifies a task of a run.
+type groupKey struct {
+	run   int
+	group string
+}
+
+// NewRecorder returns a Recorder with unregistered metrics.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		objects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "objects_total",
+			Help:      "Number of objects applied, pruned, or deleted, by action, GroupKind, and status.",
+		}, []string{"action", "group_kind", "status"}),
+		taskDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "task_duration_seconds",
+			Help:      "Duration of the apply, prune, delete, wait, and inventory tasks, by action.",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 14),
+		}, []string{"action"}),
+		reconcileWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "reconcile_wait_duration_seconds",
+			Help:      "Duration from when objects were applied or deleted until they were reconciled, by GroupKind and status.",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 14),
+		}, []string{"group_kind", "status"}),
+		apiErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "api_errors_total",
+			Help:      "Number of API errors when applying, pruning, or deleting objects, by action and reason, including retried errors.",
+		}, []string{"action", "reason"}),
+		groupStarts:    make(map[groupKey]time.Time),
+		actuationTimes: make(map[object.ObjMetadata]time.Time),
+		now:            time.Now,
+	}
+}
+
+// Collectors returns the metrics, to register them with a registry.
+func (r *Recorder) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		r.objects,
+		r.taskDuration,
+		r.reconcileWait,
+		r.apiErrors,
+	}
+}
+
+// Register registers the metrics with the registry.
+func (r *Recorder) Register(registerer prometheus.Registerer) error {
+	for _, c := range r.Collectors() {
+		if err := registerer.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Send records the metrics for a single event.
+func (r *Recorder) Send(e event.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch e.Type {
+	case event.InitType:
+		r.startRun()
+	case event.ActionGroupType:
+		r.recordActionGroup(e.ActionGroupEvent)
+	case event.ApplyType:
+		ae := e.ApplyEvent
+		r.recordObject(event.ApplyAction, ae.Identifier, ae.Status.String(), ae.Error)
+		if ae.Status == event.ApplySuccessful {
+			r.recordActuation(ae.Identifier, ae.Timing)
+		} else {
+			delete(r.actuationTimes, ae.Identifier)
+		}
+	case event.PruneType:
+		pe := e.PruneEvent
+		r.recordObject(event.PruneAction, pe.Identifier, pe.Status.String(), pe.Error)
+		if pe.Status == event.PruneSuccessful {
+			r.recordActuation(pe.Identifier, pe.Timing)
+		} else {
+			delete(r.actuationTimes, pe.Identifier)
+		}
+	case event.DeleteType:
+		de := e.DeleteEvent
+		r.recordObject(event.DeleteAction, de.Identifier, de.Status.String(), de.Error)
+		if de.Status == event.DeleteSuccessful {
+			r.recordActuation(de.Identifier, de.Timing)
+		} else {
+			delete(r.actuationTimes, de.Identifier)
+		}
+	case event.WaitType:
+		r.recordWait(e.WaitEvent)
+	case event.RetryType:
+		r.recordAPIError(e.RetryEvent.Action, e.RetryEvent.Error)
+	}
+}
+
+// startRun drops the task starts and actuation times of the previous run.
+func (r *Recorder) startRun() {
+	r.run++
+	r.groupStarts = make(map[groupKey]time.Time)
+	r.actuationTimes = make(map[object.ObjMetadata]time.Time)
+}
+
+func (r *Recorder) recordActionGroup(e event.ActionGroupEvent) {
+	key := groupKey{run: r.run, group: e.GroupName}
+	switch e.Status {
+	case event.Started:
+		r.groupStarts[key] = r.now()
+	case event.Finished:
+		start, found := r.groupStarts[key]
+		if !found {
+			return
+		}
+		delete(r.groupStarts, key)
+		r.taskDuration.WithLabelValues(e.Action.String()).
+			Observe(r.now().Sub(start).Seconds())
+	}
+}
+
+func (r *Recorder) recordObject(action event.ResourceAction, id object.ObjMetadata, status string, err error) {
+	r.objects.WithLabelValues(action.String(), id.GroupKind.String(), status).Inc()
+	r.recordAPIError(action, err)
+}
+
+// recordAPIError counts the error, if it is an API error.
+func (r *Recorder) recordAPIError(action event.ResourceAction, err error) {
+	if err == nil {
+		return
+	}
+	reason := apierrors.ReasonForError(err)
+	if reason == metav1.StatusReasonUnknown {
+		return
+	}
+	r.apiErrors.WithLabelValues(action.String(), string(reason)).Inc()
+}
+
+// recordActuation records when the object was applied or deleted, to measure
+// how long the object takes to reconcile.
+func (r *Recorder) recordActuation(id object.ObjMetadata, t event.Timing) {
+	if !t.IsZero() {
+		r.actuationTimes[id] = t.End
+		return
+	}
+	r.actuationTimes[id] = r.now()
+}
+
+func (r *Recorder) recordWait(e event.WaitEvent) {
+	if e.Status == event.ReconcilePending {
+		return
+	}
+	gk := e.Identifier.GroupKind.String()
+	status := e.Status.String()
+	if !e.Timing.IsZero() {
+		delete(r.actuationTimes, e.Identifier)
+		r.reconcileWait.WithLabelValues(gk, status).Observe(e.Timing.Duration().Seconds())
+		return
+	}
+	start, found := r.actuationTimes[e.Identifier]
+	if !found {
+		// Skipped objects were not applied or deleted.
+		return
+	}
+	delete(r.actuationTimes, e.Identifier)
+	r.reconcileWait.WithLabelValues(gk, status).Observe(r.now().Sub(start).Seconds())
+}
@@ -0,0 +1,193 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestRecorder(t *testing.T) {
+	deployment := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Namespace: "default",
+		Name:      "dep",
+	}
+	configMap := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+		Namespace: "default",
+		Name:      "cm",
+	}
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "cm", errors.New("changed"))
+
+	now := time.Unix(1000, 0)
+	recorder := NewRecorder()
+	recorder.now = func() time.Time { return now }
+	registry := prometheus.NewRegistry()
+	require.NoError(t, recorder.Register(registry))
+
+	events := []struct {
+		event   event.Event
+		advance time.Duration
+	}{
+		{
+			event: event.Event{
+				Type: event.ActionGroupType,
+				ActionGroupEvent: event.ActionGroupEvent{
+					GroupName: "apply-0", Action: event.ApplyAction, Status: event.Started,
+				},
+			},
+		},
+		{
+			event: event.Event{
+				Type: event.RetryType,
+				RetryEvent: event.RetryEvent{
+					Identifier: configMap, Action: event.ApplyAction, Error: conflict,
+				},
+			},
+		},
+		{
+			event: event.Event{
+				Type: event.ApplyType,
+				ApplyEvent: event.ApplyEvent{
+					Identifier: configMap, Status: event.ApplyFailed, Error: conflict,
+				},
+			},
+		},
+		{
+			event: event.Event{
+				Type: event.ApplyType,
+				ApplyEvent: event.ApplyEvent{
+					Identifier: deployment, Status: event.ApplySuccessful,
+				},
+			},
+			advance: 2 * time.Second,
+		},
+		{
+			event: event.Event{
+				Type: event.ActionGroupType,
+				ActionGroupEvent: event.ActionGroupEvent{
+					GroupName: "apply-0", Action: event.ApplyAction, Status: event.Finished,
+				},
+			},
+			advance: 5 * time.Second,
+		},
+		{
+			event: event.Event{
+				Type: event.WaitType,
+				WaitEvent: event.WaitEvent{
+					Identifier: deployment, Status: event.ReconcileSuccessful,
+				},
+			},
+		},
+	}
+	for _, e := range events {
+		recorder.Send(e.event)
+		now = now.Add(e.advance)
+	}
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(
+		recorder.objects.WithLabelValues("Apply", "Deployment.apps", "Successful")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(
+		recorder.objects.WithLabelValues("Apply", "ConfigMap", "Failed")))
+	// The retried and the final error are both counted.
+	assert.Equal(t, float64(2), testutil.ToFloat64(
+		recorder.apiErrors.WithLabelValues("Apply", "Conflict")))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	sums := map[string]float64{}
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			if h := m.GetHistogram(); h != nil {
+				sums[family.GetName()] += h.GetSampleSum()
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{
+		"cli_utils_task_duration_seconds":           2,
+		"cli_utils_reconcile_wait_duration_seconds": 7,
+	}, sums)
+}
+
+func TestRecorder_WaitTiming(t *testing.T) {
+	id := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Namespace: "default",
+		Name:      "dep",
+	}
+	recorder := NewRecorder()
+	registry := prometheus.NewRegistry()
+	require.NoError(t, recorder.Register(registry))
+
+	start := time.Unix(1000, 0)
+	recorder.Send(event.Event{
+		Type: event.WaitType,
+		WaitEvent: event.WaitEvent{
+			Identifier: id,
+			Status:     event.ReconcileTimeout,
+			Timing:     event.Timing{Start: start, End: start.Add(30 * time.Second)},
+		},
+	})
+
+	assert.Equal(t, 1, testutil.CollectAndCount(recorder.reconcileWait))
+	// Registering twice fails, because the metrics already exist.
+	assert.Error(t, recorder.Register(registry))
+}
+
+func TestRecorder_Runs(t *testing.T) {
+	deployment := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Namespace: "default",
+		Name:      "dep",
+	}
+	configMap := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+		Namespace: "default",
+		Name:      "cm",
+	}
+	recorder := NewRecorder()
+
+	applied := func(id object.ObjMetadata, status event.ApplyEventStatus) event.Event {
+		return event.Event{
+			Type:       event.ApplyType,
+			ApplyEvent: event.ApplyEvent{Identifier: id, Status: status},
+		}
+	}
+	group := func(status event.ActionGroupEventStatus) event.Event {
+		return event.Event{
+			Type: event.ActionGroupType,
+			ActionGroupEvent: event.ActionGroupEvent{
+				GroupName: "apply-0", Action: event.ApplyAction, Status: status,
+			},
+		}
+	}
+
+	recorder.Send(event.Event{Type: event.InitType})
+	recorder.Send(group(event.Started))
+	recorder.Send(applied(deployment, event.ApplySuccessful))
+	recorder.Send(applied(configMap, event.ApplySuccessful))
+	// A failed apply drops the actuation time of the object.
+	recorder.Send(applied(configMap, event.ApplyFailed))
+	assert.Len(t, recorder.actuationTimes, 1)
+	assert.Len(t, recorder.groupStarts, 1)
+
+	// The next run drops what the previous run left over, and its tasks do
+	// not finish the tasks of the previous run.
+	recorder.Send(event.Event{Type: event.InitType})
+	assert.Empty(t, recorder.actuationTimes)
+	assert.Empty(t, recorder.groupStarts)
+	recorder.Send(group(event.Finished))
+	assert.Equal(t, 0, testutil.CollectAndCount(recorder.taskDuration))
+}
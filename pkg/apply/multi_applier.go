@@ -0,0 +1,199 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/multierror"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	printcommon "sigs.k8s.io/cli-utils/pkg/print/common"
+	"sigs.k8s.io/cli-utils/pkg/print/stats"
+)
+
+// ClusterEvent is an event from one of the clusters of a MultiApplier.
+// When the apply to a cluster completes, a final ClusterEvent is sent for the
+// cluster with the Result populated, instead of the Event.
+type ClusterEvent struct {
+	// Cluster is the name of the cluster.
+	Cluster string
+	// Event is the event from the Applier of the cluster.
+	Event event.Event
+	// Result is only populated on the final event for the cluster.
+	Result *ClusterResult
+}
+
+// ClusterResult summarizes the apply to a single cluster.
+type ClusterResult struct {
+	// Cluster is the name of the cluster.
+	Cluster string
+	// Stats summarizes the events from the apply.
+	Stats stats.Stats
+	// Err is the error that caused the apply to fail, if any. This is either
+	// a fatal apply error, or a printcommon.ResultError if any objects failed
+	// to apply, prune, or reconcile.
+	Err error
+}
+
+// multiCluster is a target cluster of a MultiApplier.
+type multiCluster struct {
+	name    string
+	runner  applyRunner
+	invInfo inventory.Info
+}
+
+// MultiApplier applies the same objects to multiple clusters, with a
+// separate inventory in each cluster. The events from all the clusters are
+// multiplexed on a single channel, tagged with the name of the cluster.
+type MultiApplier struct {
+	// Concurrency is the maximum number of clusters applied to at the same
+	// time. If this is not provided, all the clusters are applied to at the
+	// same time.
+	Concurrency int
+
+	clusters []multiCluster
+}
+
+// NewMultiApplier returns a MultiApplier without clusters.
+func NewMultiApplier() *MultiApplier {
+	return &MultiApplier{}
+}
+
+// AddCluster adds a cluster with the passed Applier. If invInfo is nil, the
+// inventory passed to Run is used, which creates an inventory object with the
+// same name in every cluster. Cluster names must be unique.
+func (m *MultiApplier) AddCluster(name string, applier *Applier, invInfo inventory.Info) error {
+	return m.addCluster(name, applier, invInfo)
+}
+
+// AddClusterConfig adds a cluster with an Applier built from the rest config,
+// with the defaults of the ApplierBuilder. Cluster names must be unique.
+func (m *MultiApplier) AddClusterConfig(name string, restConfig *rest.Config) error {
+	applier, err := NewApplierBuilder().
+		WithRestConfig(restConfig).
+		Build()
+	if err != nil {
+		return fmt.Errorf("error building applier for cluster %q: %w", name, err)
+	}
+	return m.addCluster(name, applier, nil)
+}
+
+func (m *MultiApplier) addCluster(name string, runner applyRunner, invInfo inventory.Info) error {
+	if name == "" {
+		return fmt.Errorf("cluster name must not be empty")
+	}
+	for _, cluster := range m.clusters {
+		if cluster.name == name {
+			return fmt.Errorf("duplicate cluster name: %q", name)
+		}
+	}
+	m.clusters = append(m.clusters, multiCluster{
+		name:    name,
+		runner:  runner,
+		invInfo: invInfo,
+	})
+	return nil
+}
+
+// Run applies the objects to all the clusters, and returns a channel with the
+// events from all of them. Each cluster gets its own copy of the objects.
+// The channel is closed when the applies to all the clusters have completed.
+func (m *MultiApplier) Run(ctx context.Context, invInfo inventory.Info, objects object.UnstructuredSet, options ApplierOptions) <-chan ClusterEvent {
+	eventChannel := make(chan ClusterEvent)
+	concurrency := m.Concurrency
+	if concurrency <= 0 || concurrency > len(m.clusters) {
+		concurrency = len(m.clusters)
+	}
+	go func() {
+		defer close(eventChannel)
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, concurrency)
+		for _, cluster := range m.clusters {
+			cluster := cluster
+			sem <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				result := m.runCluster(ctx, cluster, invInfo, objects, options, eventChannel)
+				eventChannel <- ClusterEvent{
+					Cluster: cluster.name,
+					Result:  &result,
+				}
+			}()
+		}
+		wg.Wait()
+	}()
+	return eventChannel
+}
+
+// runCluster applies a copy of the objects to a single cluster, forwarding
+// the events.
+func (m *MultiApplier) runCluster(ctx context.Context, cluster multiCluster, invInfo inventory.Info, objects object.UnstructuredSet,
+	options ApplierOptions, eventChannel chan<- ClusterEvent) ClusterResult {
+	if cluster.invInfo != nil {
+		invInfo = cluster.invInfo
+	}
+	// The Applier modifies the objects, so every cluster needs a copy.
+	objs := make(object.UnstructuredSet, len(objects))
+	for i, obj := range objects {
+		objs[i] = obj.DeepCopy()
+	}
+
+	// Only a logger from the context is replaced, so that the logger of the
+	// Applier is used otherwise.
+	logger, err := logr.FromContext(ctx)
+	if err == nil {
+		logger = logger.WithValues("cluster", cluster.name)
+		ctx = logr.NewContext(ctx, logger)
+	} else {
+		logger = klog.Background().WithValues("cluster", cluster.name)
+	}
+	logger.V(4).Info("multi-cluster apply starting")
+	result := ClusterResult{Cluster: cluster.name}
+	var fatalErr error
+	for e := range cluster.runner.Run(ctx, invInfo, objs, options) {
+		result.Stats.Handle(e)
+		if e.Type == event.ErrorType {
+			fatalErr = e.ErrorEvent.Err
+		}
+		eventChannel <- ClusterEvent{
+			Cluster: cluster.name,
+			Event:   e,
+		}
+	}
+	if fatalErr != nil {
+		result.Err = fatalErr
+	} else {
+		result.Err = printcommon.ResultErrorFromStats(result.Stats)
+	}
+	logger.V(4).Info("multi-cluster apply finished", "error", result.Err)
+	return result
+}
+
+// ClusterResultsError returns an error that combines the errors of all the
+// clusters that failed, prefixed with the cluster name, or nil if all the
+// clusters succeeded.
+func ClusterResultsError(results []ClusterResult) error {
+	sorted := make([]ClusterResult, len(results))
+	copy(sorted, results)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cluster < sorted[j].Cluster
+	})
+	var errs []error
+	for _, result := range sorted {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("cluster %q: %w", result.Cluster, result.Err))
+		}
+	}
+	return multierror.Wrap(errs...)
+}
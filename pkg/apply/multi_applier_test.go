@@ -0,0 +1,115 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	printcommon "sigs.k8s.io/cli-utils/pkg/print/common"
+	"sigs.k8s.io/cli-utils/pkg/print/stats"
+)
+
+// recordingApplyRunner sends the events and records the arguments of Run.
+type recordingApplyRunner struct {
+	fakeApplyRunner
+	invInfo inventory.Info
+	objects object.UnstructuredSet
+}
+
+func (r *recordingApplyRunner) Run(ctx context.Context, invInfo inventory.Info, objects object.UnstructuredSet, options ApplierOptions) <-chan event.Event {
+	r.invInfo = invInfo
+	r.objects = objects
+	return r.fakeApplyRunner.Run(ctx, invInfo, objects, options)
+}
+
+func TestMultiApplier(t *testing.T) {
+	successEvent := event.Event{
+		Type: event.ApplyType,
+		ApplyEvent: event.ApplyEvent{
+			Status:    event.ApplySuccessful,
+			Operation: event.ApplyConfigured,
+		},
+	}
+	failedEvent := event.Event{
+		Type: event.ApplyType,
+		ApplyEvent: event.ApplyEvent{
+			Status: event.ApplyFailed,
+			Error:  errors.New("apply failed"),
+		},
+	}
+	fatalErr := errors.New("fatal")
+	fatalEvent := event.Event{
+		Type: event.ErrorType,
+		ErrorEvent: event.ErrorEvent{
+			Err: fatalErr,
+		},
+	}
+
+	runners := map[string]*recordingApplyRunner{
+		"east":  {fakeApplyRunner: fakeApplyRunner{events: []event.Event{successEvent}}},
+		"west":  {fakeApplyRunner: fakeApplyRunner{events: []event.Event{successEvent, failedEvent}}},
+		"south": {fakeApplyRunner: fakeApplyRunner{events: []event.Event{fatalEvent}}},
+	}
+	southInv := inventoryInfo{
+		name:      "south-inv",
+		namespace: "default",
+		id:        "south",
+	}.toWrapped()
+
+	ma := NewMultiApplier()
+	ma.Concurrency = 2
+	require.NoError(t, ma.addCluster("east", runners["east"], nil))
+	require.NoError(t, ma.addCluster("west", runners["west"], nil))
+	require.NoError(t, ma.addCluster("south", runners["south"], southInv))
+	assert.Error(t, ma.addCluster("east", runners["east"], nil))
+
+	invInfo := inventoryInfo{
+		name:      "inv",
+		namespace: "default",
+		id:        "shared",
+	}.toWrapped()
+	objs := object.UnstructuredSet{
+		{Object: map[string]interface{}{"kind": "ConfigMap"}},
+	}
+
+	events := map[string]int{}
+	results := map[string]ClusterResult{}
+	for e := range ma.Run(context.Background(), invInfo, objs, ApplierOptions{}) {
+		if e.Result != nil {
+			assert.Equal(t, e.Cluster, e.Result.Cluster)
+			results[e.Cluster] = *e.Result
+			continue
+		}
+		events[e.Cluster]++
+	}
+
+	assert.Equal(t, map[string]int{"east": 1, "west": 2, "south": 1}, events)
+	assert.NoError(t, results["east"].Err)
+	assert.Equal(t, &printcommon.ResultError{
+		Stats: stats.Stats{
+			ApplyStats: stats.ApplyStats{Successful: 1, Configured: 1, Failed: 1},
+		},
+	}, results["west"].Err)
+	assert.Equal(t, fatalErr, results["south"].Err)
+
+	// Every cluster gets its own copy of the objects.
+	assert.Equal(t, invInfo, runners["east"].invInfo)
+	assert.Equal(t, southInv, runners["south"].invInfo)
+	assert.Equal(t, objs, runners["east"].objects)
+	assert.NotSame(t, objs[0], runners["east"].objects[0])
+	assert.NotSame(t, runners["east"].objects[0], runners["west"].objects[0])
+
+	err := ClusterResultsError([]ClusterResult{results["west"], results["east"], results["south"]})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `cluster "south": fatal`)
+	assert.Contains(t, err.Error(), `cluster "west": 1 resources failed`)
+	assert.NotContains(t, err.Error(), "east")
+}
@@ -75,6 +75,10 @@ spec:
             name: old
             port:
               number: 80
+status:
+  loadBalancer:
+    ingress:
+    - ip: 10.0.0.1
 `
 
 var pod2y = `
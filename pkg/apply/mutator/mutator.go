@@ -20,3 +20,18 @@ type Interface interface {
 	// If an error happens during mutation, it is returned.
 	Mutate(ctx context.Context, obj *unstructured.Unstructured) (bool, string, error)
 }
+
+// SkipError is returned by a mutator to skip the apply of the object, like
+// the error of a filter, instead of failing it. Err is the reason in the
+// skipped event.
+type SkipError struct {
+	Err error
+}
+
+func (e *SkipError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *SkipError) Unwrap() error {
+	return e.Err
+}
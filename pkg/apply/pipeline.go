@@ -0,0 +1,254 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	"sigs.k8s.io/cli-utils/pkg/apply/mutator"
+	"sigs.k8s.io/cli-utils/pkg/apply/policyhook"
+	"sigs.k8s.io/cli-utils/pkg/apply/solver"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/validation"
+)
+
+// ObjectValidator validates the set of objects before the task queue is
+// built. Validation errors should be reported to the Collector, wrapped with
+// validation.NewError to identify the invalid objects.
+type ObjectValidator interface {
+	// Name returns a validator name (usually for logging).
+	Name() string
+	// Validate validates the objects and collects any errors.
+	Validate(objs object.UnstructuredSet, collector *validation.Collector)
+}
+
+// TaskQueueHook is called after the solver has built the task queue and
+// before any tasks are executed. Hooks may insert, replace, or remove tasks,
+// relative to other tasks or at a solver.InsertionPoint, like between
+// applying and pruning. Returning an error aborts the run.
+type TaskQueueHook func(taskQueue *solver.TaskQueue) error
+
+// Pipeline customizes the stages of a run: validation, filtering, mutation,
+// and the task queue produced by the solver. The zero value uses the default
+// stages only.
+type Pipeline struct {
+	validators     []ObjectValidator
+	applyFilters   stageList
+	pruneFilters   stageList
+	applyMutators  stageList
+	taskQueueHooks []TaskQueueHook
+}
+
+// ApplyFilters returns the default apply filters, with any replacements and
+// removals applied, followed by any additional apply filters.
+func (p *Pipeline) ApplyFilters(defaults ...filter.ValidationFilter) []filter.ValidationFilter {
+	if p == nil {
+		return defaults
+	}
+	stages := make([]namedStage, len(defaults))
+	for i, f := range defaults {
+		stages[i] = f
+	}
+	return toFilters(p.applyFilters.merge(stages))
+}
+
+// PruneFilters returns the default prune filters, with any replacements and
+// removals applied, followed by any additional prune filters.
+func (p *Pipeline) PruneFilters(defaults ...filter.ValidationFilter) []filter.ValidationFilter {
+	if p == nil {
+		return defaults
+	}
+	stages := make([]namedStage, len(defaults))
+	for i, f := range defaults {
+		stages[i] = f
+	}
+	return toFilters(p.pruneFilters.merge(stages))
+}
+
+// ApplyMutators returns the default apply mutators, with any replacements
+// and removals applied, followed by any additional apply mutators.
+func (p *Pipeline) ApplyMutators(defaults ...mutator.Interface) []mutator.Interface {
+	if p == nil {
+		return defaults
+	}
+	stages := make([]namedStage, len(defaults))
+	for i, m := range defaults {
+		stages[i] = m
+	}
+	merged := p.applyMutators.merge(stages)
+	mutators := make([]mutator.Interface, len(merged))
+	for i, stage := range merged {
+		mutators[i] = stage.(mutator.Interface)
+	}
+	return mutators
+}
+
+// Validate runs the additional validators against the objects.
+func (p *Pipeline) Validate(objs object.UnstructuredSet, collector *validation.Collector) {
+	if p == nil {
+		return
+	}
+	for _, v := range p.validators {
+		v.Validate(objs, collector)
+	}
+}
+
+// ModifyTaskQueue runs the task queue hooks, in order, stopping at the
+// first error.
+func (p *Pipeline) ModifyTaskQueue(taskQueue *solver.TaskQueue) error {
+	if p == nil {
+		return nil
+	}
+	for _, hook := range p.taskQueueHooks {
+		if err := hook(taskQueue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// namedStage is implemented by filters and mutators.
+type namedStage interface {
+	Name() string
+}
+
+// stageList tracks additions, replacements, and removals of named stages
+// relative to a list of defaults that is only known at run time.
+type stageList struct {
+	additions    []namedStage
+	replacements map[string]namedStage
+	removals     map[string]bool
+}
+
+func (l *stageList) add(stage namedStage) {
+	l.additions = append(l.additions, stage)
+}
+
+func (l *stageList) replace(name string, stage namedStage) {
+	if l.replacements == nil {
+		l.replacements = make(map[string]namedStage)
+	}
+	l.replacements[name] = stage
+}
+
+func (l *stageList) remove(name string) {
+	if l.removals == nil {
+		l.removals = make(map[string]bool)
+	}
+	l.removals[name] = true
+}
+
+func (l *stageList) merge(defaults []namedStage) []namedStage {
+	merged := make([]namedStage, 0, len(defaults)+len(l.additions))
+	for _, stage := range defaults {
+		name := stage.Name()
+		if l.removals[name] {
+			continue
+		}
+		if replacement, found := l.replacements[name]; found {
+			merged = append(merged, replacement)
+			continue
+		}
+		merged = append(merged, stage)
+	}
+	return append(merged, l.additions...)
+}
+
+func toFilters(stages []namedStage) []filter.ValidationFilter {
+	filters := make([]filter.ValidationFilter, len(stages))
+	for i, stage := range stages {
+		filters[i] = stage.(filter.ValidationFilter)
+	}
+	return filters
+}
+
+// PipelineBuilder assembles a Pipeline.
+type PipelineBuilder struct {
+	pipeline Pipeline
+}
+
+// NewPipelineBuilder returns a new PipelineBuilder.
+func NewPipelineBuilder() *PipelineBuilder {
+	return &PipelineBuilder{}
+}
+
+// Build returns the assembled Pipeline.
+func (b *PipelineBuilder) Build() *Pipeline {
+	p := b.pipeline
+	return &p
+}
+
+// WithValidator adds a validator, to run after the default validation.
+func (b *PipelineBuilder) WithValidator(v ObjectValidator) *PipelineBuilder {
+	b.pipeline.validators = append(b.pipeline.validators, v)
+	return b
+}
+
+// WithApplyFilter adds an apply filter, to run after the default filters.
+func (b *PipelineBuilder) WithApplyFilter(f filter.ValidationFilter) *PipelineBuilder {
+	b.pipeline.applyFilters.add(f)
+	return b
+}
+
+// ReplaceApplyFilter replaces the default apply filter with the given name.
+func (b *PipelineBuilder) ReplaceApplyFilter(name string, f filter.ValidationFilter) *PipelineBuilder {
+	b.pipeline.applyFilters.replace(name, f)
+	return b
+}
+
+// RemoveApplyFilter removes the default apply filter with the given name.
+func (b *PipelineBuilder) RemoveApplyFilter(name string) *PipelineBuilder {
+	b.pipeline.applyFilters.remove(name)
+	return b
+}
+
+// WithPruneFilter adds a prune filter, to run after the default filters.
+func (b *PipelineBuilder) WithPruneFilter(f filter.ValidationFilter) *PipelineBuilder {
+	b.pipeline.pruneFilters.add(f)
+	return b
+}
+
+// ReplacePruneFilter replaces the default prune filter with the given name.
+func (b *PipelineBuilder) ReplacePruneFilter(name string, f filter.ValidationFilter) *PipelineBuilder {
+	b.pipeline.pruneFilters.replace(name, f)
+	return b
+}
+
+// RemovePruneFilter removes the default prune filter with the given name.
+func (b *PipelineBuilder) RemovePruneFilter(name string) *PipelineBuilder {
+	b.pipeline.pruneFilters.remove(name)
+	return b
+}
+
+// WithApplyMutator adds an apply mutator, to run after the default mutators.
+func (b *PipelineBuilder) WithApplyMutator(m mutator.Interface) *PipelineBuilder {
+	b.pipeline.applyMutators.add(m)
+	return b
+}
+
+// ReplaceApplyMutator replaces the default apply mutator with the given name.
+func (b *PipelineBuilder) ReplaceApplyMutator(name string, m mutator.Interface) *PipelineBuilder {
+	b.pipeline.applyMutators.replace(name, m)
+	return b
+}
+
+// RemoveApplyMutator removes the default apply mutator with the given name.
+func (b *PipelineBuilder) RemoveApplyMutator(name string) *PipelineBuilder {
+	b.pipeline.applyMutators.remove(name)
+	return b
+}
+
+// WithPolicyHook adds the apply mutator and prune filter that ask an external
+// policy service to review every object. The apply mutator runs after the
+// default mutators, so that the policy reviews the mutated object.
+func (b *PipelineBuilder) WithPolicyHook(hook *policyhook.Hook) *PipelineBuilder {
+	return b.WithApplyMutator(hook.ApplyMutator()).
+		WithPruneFilter(hook.PruneFilter())
+}
+
+// WithTaskQueueHook adds a hook to modify the task queue built by the
+// solver. Hooks run in the order they were added.
+func (b *PipelineBuilder) WithTaskQueueHook(hook TaskQueueHook) *PipelineBuilder {
+	b.pipeline.taskQueueHooks = append(b.pipeline.taskQueueHooks, hook)
+	return b
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	"sigs.k8s.io/cli-utils/pkg/apply/solver"
+)
+
+type namedFilter string
+
+func (nf namedFilter) Name() string {
+	return string(nf)
+}
+
+func (nf namedFilter) Filter(*unstructured.Unstructured) error {
+	return nil
+}
+
+func filterNames(filters []filter.ValidationFilter) []string {
+	var names []string
+	for _, f := range filters {
+		names = append(names, f.Name())
+	}
+	return names
+}
+
+func TestPipeline_ApplyFilters(t *testing.T) {
+	defaults := []filter.ValidationFilter{namedFilter("a"), namedFilter("b"), namedFilter("c")}
+
+	testCases := map[string]struct {
+		pipeline      *Pipeline
+		expectedNames []string
+	}{
+		"nil pipeline": {
+			pipeline:      nil,
+			expectedNames: []string{"a", "b", "c"},
+		},
+		"empty pipeline": {
+			pipeline:      NewPipelineBuilder().Build(),
+			expectedNames: []string{"a", "b", "c"},
+		},
+		"add, replace, and remove": {
+			pipeline: NewPipelineBuilder().
+				WithApplyFilter(namedFilter("d")).
+				ReplaceApplyFilter("a", namedFilter("x")).
+				RemoveApplyFilter("b").
+				Build(),
+			expectedNames: []string{"x", "c", "d"},
+		},
+		"prune filters are separate": {
+			pipeline: NewPipelineBuilder().
+				WithPruneFilter(namedFilter("d")).
+				RemovePruneFilter("a").
+				Build(),
+			expectedNames: []string{"a", "b", "c"},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			filters := tc.pipeline.ApplyFilters(defaults...)
+			assert.Equal(t, tc.expectedNames, filterNames(filters))
+		})
+	}
+}
+
+func TestPipeline_ModifyTaskQueue(t *testing.T) {
+	var calls []string
+	hookErr := errors.New("hook failed")
+	pipeline := NewPipelineBuilder().
+		WithTaskQueueHook(func(*solver.TaskQueue) error {
+			calls = append(calls, "first")
+			return hookErr
+		}).
+		WithTaskQueueHook(func(*solver.TaskQueue) error {
+			calls = append(calls, "second")
+			return nil
+		}).
+		Build()
+
+	err := pipeline.ModifyTaskQueue(&solver.TaskQueue{})
+	assert.Equal(t, hookErr, err)
+	assert.Equal(t, []string{"first"}, calls)
+}
@@ -0,0 +1,69 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package policyhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout is the timeout for each policy review request, if the
+// HTTPReviewer has no Client.
+const DefaultTimeout = 10 * time.Second
+
+// HTTPReviewer sends each Request as a JSON POST to a policy service URL and
+// reads the Response from the JSON response body.
+type HTTPReviewer struct {
+	// URL is the policy service endpoint.
+	URL string
+	// Client is the HTTP client. If nil, a client with DefaultTimeout is
+	// used.
+	Client *http.Client
+}
+
+var _ Reviewer = &HTTPReviewer{}
+
+// NewHTTPReviewer returns an HTTPReviewer for the URL.
+func NewHTTPReviewer(url string) *HTTPReviewer {
+	return &HTTPReviewer{URL: url}
+}
+
+// Review sends the request to the policy service.
+func (r *HTTPReviewer) Review(ctx context.Context, req Request) (Response, error) {
+	var resp Response
+	body, err := json.Marshal(req)
+	if err != nil {
+		return resp, fmt.Errorf("failed to encode policy request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	client := r.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return resp, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		// Include the start of the body, which usually explains the error.
+		msg, _ := io.ReadAll(io.LimitReader(httpResp.Body, 1024))
+		return resp, fmt.Errorf("policy service returned %s: %s", httpResp.Status, msg)
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return resp, fmt.Errorf("failed to decode policy response: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,305 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package policyhook lets an external policy service allow, deny, or mutate
+// each object before it is applied or pruned.
+//
+// A Hook is added to the Applier or the Destroyer with
+// apply.PipelineBuilder.WithPolicyHook, which registers an apply mutator and
+// a prune filter. Objects to apply are reviewed by the mutator, after the
+// apply-time mutations, so that the policy reviews the object as it would be
+// applied. Denied objects are skipped, like objects excluded by any other
+// filter.
+package policyhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	"sigs.k8s.io/cli-utils/pkg/apply/mutator"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// Action is the planned action for an object.
+type Action string
+
+const (
+	ActionApply Action = "Apply"
+	ActionPrune Action = "Prune"
+)
+
+// Decision is the policy decision for an object.
+type Decision string
+
+const (
+	// DecisionAllow allows the planned action.
+	DecisionAllow Decision = "Allow"
+	// DecisionDeny skips the planned action.
+	DecisionDeny Decision = "Deny"
+	// DecisionMutate allows the planned action, with the changes from the
+	// reviewed object to the object in the response merged into the object.
+	// Only valid for ActionApply.
+	DecisionMutate Decision = "Mutate"
+)
+
+// Request is sent to the policy service for each object.
+type Request struct {
+	// Action is the planned action.
+	Action Action `json:"action"`
+	// Object is the object to apply, or the object to prune.
+	Object *unstructured.Unstructured `json:"object"`
+	// LiveObject is the object in the cluster, if it exists.
+	LiveObject *unstructured.Unstructured `json:"liveObject,omitempty"`
+}
+
+// Response is returned by the policy service for each object.
+type Response struct {
+	// Decision is the policy decision.
+	Decision Decision `json:"decision"`
+	// Reason is a human readable explanation of the decision.
+	Reason string `json:"reason,omitempty"`
+	// Object is the mutated object, if Decision is DecisionMutate.
+	Object *unstructured.Unstructured `json:"object,omitempty"`
+}
+
+// Reviewer sends a Request to a policy service and returns its Response.
+type Reviewer interface {
+	Review(ctx context.Context, req Request) (Response, error)
+}
+
+// Hook asks a policy service to review every object before it is applied or
+// pruned.
+type Hook struct {
+	// Reviewer is the policy service client.
+	Reviewer Reviewer
+	// Client and Mapper are used to look up the live object for apply
+	// requests. If either is nil, LiveObject is not sent.
+	Client dynamic.Interface
+	Mapper meta.RESTMapper
+}
+
+// NewHook returns a Hook that uses the reviewer for every decision and the
+// client and mapper to look up live objects.
+func NewHook(reviewer Reviewer, client dynamic.Interface, mapper meta.RESTMapper) *Hook {
+	return &Hook{
+		Reviewer: reviewer,
+		Client:   client,
+		Mapper:   mapper,
+	}
+}
+
+// ApplyMutator returns the apply mutator that reviews the objects to apply,
+// skips the denied objects, and mutates the objects the policy service
+// mutated. It must be registered after the other mutators, so that the
+// reviewed object is the object that is applied.
+func (h *Hook) ApplyMutator() *ApplyMutator {
+	return &ApplyMutator{hook: h}
+}
+
+// PruneFilter returns the prune filter that skips denied objects.
+func (h *Hook) PruneFilter() filter.ValidationFilter {
+	return pruneFilter{hook: h}
+}
+
+// review sends the request and validates the response.
+func (h *Hook) review(ctx context.Context, req Request) (Response, error) {
+	id := object.UnstructuredToObjMetadata(req.Object)
+	resp, err := h.Reviewer.Review(ctx, req)
+	if err != nil {
+		return resp, fmt.Errorf("policy review failed: %w", err)
+	}
+	klog.V(4).Infof("policy review (action: %s, object: %q): %s: %s", req.Action, id, resp.Decision, resp.Reason)
+	switch resp.Decision {
+	case DecisionAllow, DecisionDeny:
+	case DecisionMutate:
+		if req.Action != ActionApply {
+			return resp, fmt.Errorf("invalid policy decision for %s: %q", req.Action, resp.Decision)
+		}
+		if resp.Object == nil {
+			return resp, fmt.Errorf("policy mutation missing object")
+		}
+		if mutatedID := object.UnstructuredToObjMetadata(resp.Object); mutatedID != id {
+			return resp, fmt.Errorf("policy mutation changed object identity: %q", mutatedID)
+		}
+	default:
+		return resp, fmt.Errorf("invalid policy decision: %q", resp.Decision)
+	}
+	return resp, nil
+}
+
+// liveObject returns the object from the cluster, or nil if it does not
+// exist or the hook has no client.
+func (h *Hook) liveObject(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if h.Client == nil || h.Mapper == nil {
+		return nil, nil
+	}
+	gvk := obj.GroupVersionKind()
+	mapping, err := h.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			// The type may be applied by an earlier task.
+			return nil, nil
+		}
+		return nil, err
+	}
+	live, err := h.Client.Resource(mapping.Resource).Namespace(obj.GetNamespace()).
+		Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return live, nil
+}
+
+const (
+	PruneFilterName  = "PolicyHookPruneFilter"
+	ApplyMutatorName = "PolicyHookApplyMutator"
+)
+
+// pruneFilter implements ValidationFilter interface to determine if an
+// object should not be pruned (deleted) because the policy service denied
+// it.
+type pruneFilter struct {
+	hook *Hook
+}
+
+// Name returns a filter identifier for logging.
+func (f pruneFilter) Name() string {
+	return PruneFilterName
+}
+
+// Filter returns a PolicyDeniedError if the object prune/delete should be
+// skipped.
+func (f pruneFilter) Filter(obj *unstructured.Unstructured) error {
+	return f.FilterWithContext(context.Background(), obj)
+}
+
+// FilterWithContext returns a PolicyDeniedError if the object prune/delete
+// should be skipped. The request uses the context.
+func (f pruneFilter) FilterWithContext(ctx context.Context, obj *unstructured.Unstructured) error {
+	// Prune objects are read from the cluster, so obj is the live object.
+	resp, err := f.hook.review(ctx, Request{
+		Action:     ActionPrune,
+		Object:     obj,
+		LiveObject: obj,
+	})
+	if err != nil {
+		return filter.NewFatalError(err)
+	}
+	if resp.Decision == DecisionDeny {
+		return &PolicyDeniedError{Action: ActionPrune, Reason: resp.Reason}
+	}
+	return nil
+}
+
+// ApplyMutator implements mutator.Interface to review the objects to apply
+// with the policy service, and merge the mutations it returns.
+type ApplyMutator struct {
+	hook *Hook
+}
+
+// Name returns a mutator identifier for logging.
+func (m *ApplyMutator) Name() string {
+	return ApplyMutatorName
+}
+
+// Mutate reviews the object. Denied objects are skipped with a
+// mutator.SkipError. If the policy service mutated the object, the changes
+// from the reviewed object to the object in the response are merged into
+// the object.
+func (m *ApplyMutator) Mutate(ctx context.Context, obj *unstructured.Unstructured) (bool, string, error) {
+	live, err := m.hook.liveObject(ctx, obj)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get live object: %w", err)
+	}
+	// The reviewed copy is made through JSON, because mutators may set
+	// values that can not be deep copied, like int.
+	objJSON, err := json.Marshal(obj.Object)
+	if err != nil {
+		return false, "", err
+	}
+	reviewed := &unstructured.Unstructured{}
+	if err := reviewed.UnmarshalJSON(objJSON); err != nil {
+		return false, "", err
+	}
+	resp, err := m.hook.review(ctx, Request{
+		Action:     ActionApply,
+		Object:     reviewed,
+		LiveObject: live,
+	})
+	if err != nil {
+		return false, "", err
+	}
+	switch resp.Decision {
+	case DecisionDeny:
+		return false, "", &mutator.SkipError{
+			Err: &PolicyDeniedError{Action: ActionApply, Reason: resp.Reason},
+		}
+	case DecisionMutate:
+		if err := mergeMutation(obj, objJSON, resp.Object); err != nil {
+			return false, "", fmt.Errorf("failed to merge policy mutation: %w", err)
+		}
+		reason := resp.Reason
+		if reason == "" {
+			reason = "mutated by policy"
+		}
+		return true, reason, nil
+	}
+	return false, "", nil
+}
+
+// mergeMutation merges the changes from the reviewed object to the mutated
+// object into the object, as a JSON merge patch.
+func mergeMutation(obj *unstructured.Unstructured, reviewedJSON []byte, mutated *unstructured.Unstructured) error {
+	mutatedJSON, err := json.Marshal(mutated.Object)
+	if err != nil {
+		return err
+	}
+	patch, err := jsonpatch.CreateMergePatch(reviewedJSON, mutatedJSON)
+	if err != nil {
+		return err
+	}
+	merged, err := jsonpatch.MergePatch(reviewedJSON, patch)
+	if err != nil {
+		return err
+	}
+	// UnmarshalJSON keeps integers as int64.
+	result := &unstructured.Unstructured{}
+	if err := result.UnmarshalJSON(merged); err != nil {
+		return err
+	}
+	obj.Object = result.Object
+	return nil
+}
+
+type PolicyDeniedError struct {
+	Action Action
+	Reason string
+}
+
+func (e *PolicyDeniedError) Error() string {
+	return fmt.Sprintf("policy denied %s: %s", e.Action, e.Reason)
+}
+
+func (e *PolicyDeniedError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*PolicyDeniedError)
+	if !ok {
+		return false
+	}
+	return e.Action == tErr.Action &&
+		e.Reason == tErr.Reason
+}
@@ -0,0 +1,320 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package policyhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/cli-utils/pkg/apply/cache"
+	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	applymutator "sigs.k8s.io/cli-utils/pkg/apply/mutator"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/mutation"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+var deployment = &unstructured.Unstructured{
+	Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "test-deployment",
+			"namespace": "test-namespace",
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(1),
+		},
+	},
+}
+
+type fakeReviewer struct {
+	resp     Response
+	err      error
+	requests []Request
+	contexts []context.Context
+}
+
+func (f *fakeReviewer) Review(ctx context.Context, req Request) (Response, error) {
+	f.requests = append(f.requests, req)
+	f.contexts = append(f.contexts, ctx)
+	return f.resp, f.err
+}
+
+func mutatedDeployment(name string, replicas int64) *unstructured.Unstructured {
+	obj := deployment.DeepCopy()
+	obj.SetName(name)
+	obj.Object["spec"] = map[string]interface{}{"replicas": replicas}
+	return obj
+}
+
+func TestHook_Apply(t *testing.T) {
+	testCases := map[string]struct {
+		resp           Response
+		reviewErr      error
+		expectedSkip   error
+		expectedError  bool
+		expectedObj    *unstructured.Unstructured
+		expectedMutate bool
+	}{
+		"allow": {
+			resp:        Response{Decision: DecisionAllow},
+			expectedObj: deployment,
+		},
+		"deny": {
+			resp: Response{Decision: DecisionDeny, Reason: "replicas too low"},
+			expectedSkip: &PolicyDeniedError{
+				Action: ActionApply,
+				Reason: "replicas too low",
+			},
+		},
+		"mutate": {
+			resp: Response{
+				Decision: DecisionMutate,
+				Reason:   "scaled up",
+				Object:   mutatedDeployment("test-deployment", 3),
+			},
+			expectedObj:    mutatedDeployment("test-deployment", 3),
+			expectedMutate: true,
+		},
+		"mutate identity change": {
+			resp: Response{
+				Decision: DecisionMutate,
+				Object:   mutatedDeployment("other", 3),
+			},
+			expectedError: true,
+		},
+		"invalid decision": {
+			resp:          Response{Decision: "Maybe"},
+			expectedError: true,
+		},
+		"review error": {
+			reviewErr:     errors.New("connection refused"),
+			expectedError: true,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			reviewer := &fakeReviewer{resp: tc.resp, err: tc.reviewErr}
+			hook := NewHook(reviewer, nil, nil)
+			obj := deployment.DeepCopy()
+
+			mutated, reason, err := hook.ApplyMutator().Mutate(context.TODO(), obj)
+			require.Len(t, reviewer.requests, 1)
+			assert.Equal(t, ActionApply, reviewer.requests[0].Action)
+			var skipErr *applymutator.SkipError
+			switch {
+			case tc.expectedError:
+				require.Error(t, err)
+				assert.False(t, errors.As(err, &skipErr), "expected apply error, got skip: %v", err)
+				return
+			case tc.expectedSkip != nil:
+				require.True(t, errors.As(err, &skipErr), "expected skip error, got: %v", err)
+				testutil.AssertEqual(t, tc.expectedSkip, skipErr.Err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMutate, mutated)
+			if tc.expectedMutate {
+				assert.Equal(t, tc.resp.Reason, reason)
+			}
+			assert.Equal(t, tc.expectedObj, obj)
+		})
+	}
+}
+
+func TestHook_ApplyTimeMutation(t *testing.T) {
+	source := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "replicas",
+				"namespace": "test-namespace",
+			},
+			"data": map[string]interface{}{
+				"count": "5",
+			},
+		},
+	}
+	obj := deployment.DeepCopy()
+	require.NoError(t, unstructured.SetNestedField(obj.Object, "unknown",
+		"spec", "template", "metadata", "labels", "replicas"))
+	obj.SetAnnotations(map[string]string{
+		mutation.Annotation: `
+- sourceRef:
+    kind: ConfigMap
+    name: replicas
+  sourcePath: $.data.count
+  targetPath: $.spec.template.metadata.labels.replicas
+`,
+	})
+	resourceCache := cache.NewResourceCacheMap()
+	resourceCache.Put(object.UnstructuredToObjMetadata(source), cache.ResourceStatus{
+		Resource: source,
+		Status:   status.CurrentStatus,
+	})
+	applyTimeMutator := &applymutator.ApplyTimeMutator{
+		Mapper:        testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme, scheme.Scheme.PrioritizedVersionsAllGroups()...),
+		ResourceCache: resourceCache,
+	}
+
+	// The policy scales the object it reviewed.
+	reviewer := &policyFunc{review: func(req Request) Response {
+		mutated := req.Object.DeepCopy()
+		require.NoError(t, unstructured.SetNestedField(mutated.Object, int64(3), "spec", "replicas"))
+		return Response{Decision: DecisionMutate, Object: mutated}
+	}}
+	hook := NewHook(reviewer, nil, nil)
+
+	for _, m := range []applymutator.Interface{applyTimeMutator, hook.ApplyMutator()} {
+		mutated, _, err := m.Mutate(context.TODO(), obj)
+		require.NoError(t, err)
+		assert.True(t, mutated, m.Name())
+	}
+
+	// The policy reviewed the object after the apply-time mutation.
+	require.Len(t, reviewer.requests, 1)
+	reviewed, _, err := unstructured.NestedString(reviewer.requests[0].Object.Object,
+		"spec", "template", "metadata", "labels", "replicas")
+	require.NoError(t, err)
+	assert.Equal(t, "5", reviewed)
+
+	// Both the substituted field and the policy mutation are applied.
+	substituted, _, err := unstructured.NestedString(obj.Object,
+		"spec", "template", "metadata", "labels", "replicas")
+	require.NoError(t, err)
+	assert.Equal(t, "5", substituted)
+	replicas, _, err := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), replicas)
+}
+
+// policyFunc is a Reviewer that returns the response of a function.
+type policyFunc struct {
+	review   func(req Request) Response
+	requests []Request
+}
+
+func (p *policyFunc) Review(_ context.Context, req Request) (Response, error) {
+	p.requests = append(p.requests, req)
+	return p.review(req), nil
+}
+
+func TestHook_Prune(t *testing.T) {
+	testCases := map[string]struct {
+		resp          Response
+		expectedError error
+		expectedFatal bool
+	}{
+		"allow": {
+			resp: Response{Decision: DecisionAllow},
+		},
+		"deny": {
+			resp: Response{Decision: DecisionDeny, Reason: "protected"},
+			expectedError: &PolicyDeniedError{
+				Action: ActionPrune,
+				Reason: "protected",
+			},
+		},
+		"mutate not allowed": {
+			resp: Response{
+				Decision: DecisionMutate,
+				Object:   deployment,
+			},
+			expectedFatal: true,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			reviewer := &fakeReviewer{resp: tc.resp}
+			hook := NewHook(reviewer, nil, nil)
+
+			err := hook.PruneFilter().Filter(deployment.DeepCopy())
+			require.Len(t, reviewer.requests, 1)
+			assert.Equal(t, ActionPrune, reviewer.requests[0].Action)
+			assert.Equal(t, deployment, reviewer.requests[0].LiveObject)
+			if tc.expectedFatal {
+				var fatalErr *filter.FatalError
+				require.True(t, errors.As(err, &fatalErr), "expected fatal error, got: %v", err)
+				return
+			}
+			testutil.AssertEqual(t, tc.expectedError, err)
+		})
+	}
+}
+
+type ctxKey struct{}
+
+func TestHook_Context(t *testing.T) {
+	reviewer := &fakeReviewer{resp: Response{Decision: DecisionAllow}}
+	hook := NewHook(reviewer, nil, nil)
+	ctx := context.WithValue(context.Background(), ctxKey{}, "run")
+
+	_, _, err := hook.ApplyMutator().Mutate(ctx, deployment.DeepCopy())
+	require.NoError(t, err)
+	err = filter.Evaluate(ctx, hook.PruneFilter(), deployment.DeepCopy())
+	require.NoError(t, err)
+
+	require.Len(t, reviewer.contexts, 2)
+	for _, reviewCtx := range reviewer.contexts {
+		assert.Equal(t, "run", reviewCtx.Value(ctxKey{}))
+	}
+}
+
+func TestHTTPReviewer(t *testing.T) {
+	var received Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&received)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if received.Object.GetName() == "broken" {
+			http.Error(w, "internal policy error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Response{
+			Decision: DecisionMutate,
+			Reason:   "scaled up",
+			Object:   mutatedDeployment("test-deployment", 3),
+		})
+	}))
+	defer server.Close()
+
+	reviewer := NewHTTPReviewer(server.URL)
+	resp, err := reviewer.Review(context.TODO(), Request{
+		Action: ActionApply,
+		Object: deployment,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ActionApply, received.Action)
+	assert.Equal(t, deployment, received.Object)
+	assert.Nil(t, received.LiveObject)
+	assert.Equal(t, DecisionMutate, resp.Decision)
+	assert.Equal(t, "scaled up", resp.Reason)
+	assert.Equal(t, mutatedDeployment("test-deployment", 3), resp.Object)
+
+	_, err = reviewer.Review(context.TODO(), Request{
+		Action: ActionApply,
+		Object: mutatedDeployment("broken", 1),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "500")
+	assert.Contains(t, err.Error(), "internal policy error")
+}
@@ -5,6 +5,8 @@
 package prune
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/object"
@@ -15,7 +17,9 @@ import (
 type EventFactory interface {
 	CreateSuccessEvent(obj *unstructured.Unstructured) event.Event
 	CreateSkippedEvent(obj *unstructured.Unstructured, err error) event.Event
+	CreateOrphanedEvent(obj *unstructured.Unstructured, err error) event.Event
 	CreateFailedEvent(id object.ObjMetadata, err error) event.Event
+	CreateRetryEvent(id object.ObjMetadata, retry, maxRetries int, backoff time.Duration, err error) event.Event
 }
 
 // CreateEventFactory returns the correct concrete version of
@@ -63,6 +67,13 @@ func (pef PruneEventFactory) CreateSkippedEvent(obj *unstructured.Unstructured,
 	}
 }
 
+// CreateOrphanedEvent returns a skipped event with the Orphaned operation.
+func (pef PruneEventFactory) CreateOrphanedEvent(obj *unstructured.Unstructured, err error) event.Event {
+	e := pef.CreateSkippedEvent(obj, err)
+	e.PruneEvent.Operation = event.PruneOrphaned
+	return e
+}
+
 func (pef PruneEventFactory) CreateFailedEvent(id object.ObjMetadata, err error) event.Event {
 	return event.Event{
 		Type: event.PruneType,
@@ -75,6 +86,21 @@ func (pef PruneEventFactory) CreateFailedEvent(id object.ObjMetadata, err error)
 	}
 }
 
+func (pef PruneEventFactory) CreateRetryEvent(id object.ObjMetadata, retry, maxRetries int, backoff time.Duration, err error) event.Event {
+	return event.Event{
+		Type: event.RetryType,
+		RetryEvent: event.RetryEvent{
+			GroupName:  pef.groupName,
+			Identifier: id,
+			Action:     event.PruneAction,
+			Retry:      retry,
+			MaxRetries: maxRetries,
+			Backoff:    backoff,
+			Error:      err,
+		},
+	}
+}
+
 // DeleteEventFactory implements EventFactory interface as a concrete
 // representation of for delete events.
 type DeleteEventFactory struct {
@@ -106,6 +132,12 @@ func (def DeleteEventFactory) CreateSkippedEvent(obj *unstructured.Unstructured,
 	}
 }
 
+// CreateOrphanedEvent returns a skipped event. Delete events do not
+// distinguish orphaned objects from other skipped objects.
+func (def DeleteEventFactory) CreateOrphanedEvent(obj *unstructured.Unstructured, err error) event.Event {
+	return def.CreateSkippedEvent(obj, err)
+}
+
 func (def DeleteEventFactory) CreateFailedEvent(id object.ObjMetadata, err error) event.Event {
 	return event.Event{
 		Type: event.DeleteType,
@@ -117,3 +149,18 @@ func (def DeleteEventFactory) CreateFailedEvent(id object.ObjMetadata, err error
 		},
 	}
 }
+
+func (def DeleteEventFactory) CreateRetryEvent(id object.ObjMetadata, retry, maxRetries int, backoff time.Duration, err error) event.Event {
+	return event.Event{
+		Type: event.RetryType,
+		RetryEvent: event.RetryEvent{
+			GroupName:  def.groupName,
+			Identifier: id,
+			Action:     event.DeleteAction,
+			Retry:      retry,
+			MaxRetries: maxRetries,
+			Backoff:    backoff,
+			Error:      err,
+		},
+	}
+}
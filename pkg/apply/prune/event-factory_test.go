@@ -93,6 +93,25 @@ func TestEventFactory(t *testing.T) {
 			if tc.skippedErr != err {
 				t.Errorf("skipped event expected error (%s), got (%s)", tc.skippedErr, err)
 			}
+			// Validate the "orphaned" event"
+			actualEvent = eventFactory.CreateOrphanedEvent(tc.obj, tc.skippedErr)
+			if tc.expectedType != actualEvent.Type {
+				t.Errorf("orphaned event expected type (%s), got (%s)",
+					tc.expectedType, actualEvent.Type)
+			}
+			if tc.expectedType == event.PruneType {
+				if event.PruneSkipped != actualEvent.PruneEvent.Status {
+					t.Errorf("orphaned event expected status (PruneSkipped), got (%s)",
+						actualEvent.PruneEvent.Status)
+				}
+				if event.PruneOrphaned != actualEvent.PruneEvent.Operation {
+					t.Errorf("orphaned event expected operation (PruneOrphaned), got (%s)",
+						actualEvent.PruneEvent.Operation)
+				}
+			} else if event.DeleteSkipped != actualEvent.DeleteEvent.Status {
+				t.Errorf("orphaned event expected status (DeleteSkipped), got (%s)",
+					actualEvent.DeleteEvent.Status)
+			}
 			// Validate the "failed" event"
 			actualEvent = eventFactory.CreateFailedEvent(id, tc.failedErr)
 			if tc.expectedType != actualEvent.Type {
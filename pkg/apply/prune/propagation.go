@@ -0,0 +1,37 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package prune
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// ReadPropagationPolicy reads the deletion propagation policy annotation
+// from the object. Returns the defaultPolicy if the annotation is not present.
+func ReadPropagationPolicy(obj *unstructured.Unstructured, defaultPolicy metav1.DeletionPropagation) (metav1.DeletionPropagation, error) {
+	value, found := obj.GetAnnotations()[common.DeletionPropagationAnnotation]
+	if !found {
+		return defaultPolicy, nil
+	}
+	switch policy := metav1.DeletionPropagation(value); policy {
+	case metav1.DeletePropagationForeground,
+		metav1.DeletePropagationBackground,
+		metav1.DeletePropagationOrphan:
+		return policy, nil
+	default:
+		return defaultPolicy, object.InvalidAnnotationError{
+			Annotation: common.DeletionPropagationAnnotation,
+			Cause: fmt.Errorf("must be one of %s, %s, or %s: %q",
+				metav1.DeletePropagationForeground,
+				metav1.DeletePropagationBackground,
+				metav1.DeletePropagationOrphan,
+				value),
+		}
+	}
+}
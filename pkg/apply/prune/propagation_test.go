@@ -0,0 +1,59 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package prune
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestReadPropagationPolicy(t *testing.T) {
+	testCases := map[string]struct {
+		annotations    map[string]string
+		expectedPolicy metav1.DeletionPropagation
+		expectedError  bool
+	}{
+		"no annotation returns default": {
+			expectedPolicy: metav1.DeletePropagationBackground,
+		},
+		"foreground annotation": {
+			annotations: map[string]string{
+				common.DeletionPropagationAnnotation: "Foreground",
+			},
+			expectedPolicy: metav1.DeletePropagationForeground,
+		},
+		"orphan annotation": {
+			annotations: map[string]string{
+				common.DeletionPropagationAnnotation: "Orphan",
+			},
+			expectedPolicy: metav1.DeletePropagationOrphan,
+		},
+		"invalid annotation returns error": {
+			annotations: map[string]string{
+				common.DeletionPropagationAnnotation: "foreground",
+			},
+			expectedPolicy: metav1.DeletePropagationBackground,
+			expectedError:  true,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			obj := pdb.DeepCopy()
+			obj.SetAnnotations(tc.annotations)
+			policy, err := ReadPropagationPolicy(obj, metav1.DeletePropagationBackground)
+			if tc.expectedError {
+				var annotationErr object.InvalidAnnotationError
+				assert.ErrorAs(t, err, &annotationErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedPolicy, policy)
+		})
+	}
+}
@@ -14,7 +14,9 @@ package prune
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,6 +24,7 @@ import (
 	"k8s.io/client-go/dynamic"
 	"k8s.io/klog/v2"
 	"k8s.io/kubectl/pkg/cmd/util"
+	applyerror "sigs.k8s.io/cli-utils/pkg/apply/error"
 	"sigs.k8s.io/cli-utils/pkg/apply/filter"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
@@ -68,6 +71,15 @@ type Options struct {
 	// True if we are destroying, which deletes the inventory object
 	// as well (possibly) the inventory namespace.
 	Destroy bool
+
+	// True if successfully pruned objects should be recorded as tombstones
+	// in the inventory, instead of being removed from it. Ignored when
+	// destroying, because the inventory object is deleted.
+	Tombstones bool
+
+	// RetryPolicy defines how deletes that fail with a transient error are
+	// retried. A RetryEvent is sent before each retry.
+	RetryPolicy common.RetryPolicy
 }
 
 // Prune deletes the set of passed objects. A prune skip/failure is
@@ -93,21 +105,32 @@ func (p *Pruner) Prune(
 	opts Options,
 ) error {
 	eventFactory := CreateEventFactory(opts.Destroy, taskName)
+	logger := taskContext.Logger().WithValues("task", taskName)
+	ctx := klog.NewContext(taskContext.Context(), logger)
 	// Iterate through objects to prune (delete). If an object is not pruned
 	// and we need to keep it in the inventory, we must capture the prune failure.
 	for _, obj := range objs {
+		start := time.Now()
 		id := object.UnstructuredToObjMetadata(obj)
-		klog.V(5).Infof("evaluating prune filters (object: %q)", id)
+
+		// Skip the remaining objects if the run was cancelled.
+		if err := taskContext.Cancelled(); err != nil {
+			logger.V(4).Info("prune skipped", "object", id, "reason", err)
+			taskContext.SendEventWithTiming(eventFactory.CreateSkippedEvent(obj, err), start)
+			taskContext.InventoryManager().AddSkippedDelete(id)
+			continue
+		}
+		logger.V(5).Info("evaluating prune filters", "object", id)
 
 		// UID will change if the object is deleted and re-created.
 		uid := obj.GetUID()
 		if uid == "" {
 			err := object.NotFound([]interface{}{"metadata", "uid"}, "")
-			if klog.V(4).Enabled() {
+			if logger.V(4).Enabled() {
 				// only log event emitted errors if the verbosity > 4
-				klog.Errorf("prune uid lookup errored (object: %s): %v", id, err)
+				logger.Error(err, "prune uid lookup errored", "object", id)
 			}
-			taskContext.SendEvent(eventFactory.CreateFailedEvent(id, err))
+			taskContext.SendEventWithTiming(eventFactory.CreateFailedEvent(id, err), start)
 			taskContext.InventoryManager().AddFailedDelete(id)
 			continue
 		}
@@ -115,20 +138,20 @@ func (p *Pruner) Prune(
 		// Check filters to see if we're prevented from pruning/deleting object.
 		var filterErr error
 		for _, pruneFilter := range pruneFilters {
-			klog.V(6).Infof("prune filter evaluating (filter: %s, object: %s)", pruneFilter.Name(), id)
-			filterErr = pruneFilter.Filter(obj)
+			logger.V(6).Info("prune filter evaluating", "filter", pruneFilter.Name(), "object", id)
+			filterErr = filter.Evaluate(ctx, pruneFilter, obj)
 			if filterErr != nil {
 				var fatalErr *filter.FatalError
 				if errors.As(filterErr, &fatalErr) {
-					if klog.V(4).Enabled() {
+					if logger.V(4).Enabled() {
 						// only log event emitted errors if the verbosity > 4
-						klog.Errorf("prune filter errored (filter: %s, object: %s): %v", pruneFilter.Name(), id, fatalErr.Err)
+						logger.Error(fatalErr.Err, "prune filter errored", "filter", pruneFilter.Name(), "object", id)
 					}
-					taskContext.SendEvent(eventFactory.CreateFailedEvent(id, fatalErr.Err))
+					taskContext.SendEventWithTiming(eventFactory.CreateFailedEvent(id, fatalErr.Err), start)
 					taskContext.InventoryManager().AddFailedDelete(id)
 					break
 				}
-				klog.V(4).Infof("prune filtered (filter: %s, object: %s): %v", pruneFilter.Name(), id, filterErr)
+				logger.V(4).Info("prune filtered", "filter", pruneFilter.Name(), "object", id, "reason", filterErr)
 
 				// Remove the inventory annotation if deletion was prevented.
 				// This abandons the object so it won't be pruned by future applier runs.
@@ -136,13 +159,13 @@ func (p *Pruner) Prune(
 				if errors.As(filterErr, &abandonErr) {
 					if !opts.DryRunStrategy.ClientOrServerDryRun() {
 						var err error
-						obj, err = p.removeInventoryAnnotation(obj)
+						obj, err = p.removeInventoryAnnotation(logger, obj)
 						if err != nil {
-							if klog.V(4).Enabled() {
+							if logger.V(4).Enabled() {
 								// only log event emitted errors if the verbosity > 4
-								klog.Errorf("error removing annotation (object: %q, annotation: %q): %v", id, inventory.OwningInventoryKey, err)
+								logger.Error(err, "error removing annotation", "object", id, "annotation", inventory.OwningInventoryKey)
 							}
-							taskContext.SendEvent(eventFactory.CreateFailedEvent(id, err))
+							taskContext.SendEventWithTiming(eventFactory.CreateFailedEvent(id, err), start)
 							taskContext.InventoryManager().AddFailedDelete(id)
 							break
 						}
@@ -150,9 +173,12 @@ func (p *Pruner) Prune(
 						// Register for removal from the inventory.
 						taskContext.AddAbandonedObject(id)
 					}
+					taskContext.SendEventWithTiming(eventFactory.CreateOrphanedEvent(obj, filterErr), start)
+					taskContext.InventoryManager().AddSkippedDelete(id)
+					break
 				}
 
-				taskContext.SendEvent(eventFactory.CreateSkippedEvent(obj, filterErr))
+				taskContext.SendEventWithTiming(eventFactory.CreateSkippedEvent(obj, filterErr), start)
 				taskContext.InventoryManager().AddSkippedDelete(id)
 				break
 			}
@@ -161,40 +187,74 @@ func (p *Pruner) Prune(
 			continue
 		}
 
+		// Object annotation may override the default propagation policy.
+		propagationPolicy, err := ReadPropagationPolicy(obj, opts.PropagationPolicy)
+		if err != nil {
+			if logger.V(4).Enabled() {
+				// only log event emitted errors if the verbosity > 4
+				logger.Error(err, "prune propagation policy errored", "object", id)
+			}
+			taskContext.SendEventWithTiming(eventFactory.CreateFailedEvent(id, err), start)
+			taskContext.InventoryManager().AddFailedDelete(id)
+			continue
+		}
+
 		// Filters passed--actually delete object if not dry run.
 		if !opts.DryRunStrategy.ClientOrServerDryRun() {
-			klog.V(4).Infof("deleting object (object: %q)", id)
-			err := p.deleteObject(id, metav1.DeleteOptions{
-				// Only delete the resource if it hasn't already been deleted
-				// and recreated since the last GET. Otherwise error.
-				Preconditions: &metav1.Preconditions{
-					UID: &uid,
-				},
-				PropagationPolicy: &opts.PropagationPolicy,
+			logger.V(4).Info("deleting object", "object", id)
+			err = opts.RetryPolicy.Do(ctx, func() error {
+				return p.deleteObject(id, metav1.DeleteOptions{
+					// Only delete the resource if it hasn't already been deleted
+					// and recreated since the last GET. Otherwise error.
+					Preconditions: &metav1.Preconditions{
+						UID: &uid,
+					},
+					PropagationPolicy: &propagationPolicy,
+				})
+			}, func(retry int, backoff time.Duration, err error) {
+				logger.V(4).Info("delete retrying", "object", id, "retry", retry, "backoff", backoff, "reason", err)
+				taskContext.SendEvent(eventFactory.CreateRetryEvent(id, retry, opts.RetryPolicy.MaxRetries, backoff, err))
 			})
 			if err != nil {
 				if apierrors.IsNotFound(err) {
-					klog.Warningf("error deleting object (object: %q): object not found: object may have been deleted asynchronously by another client", id)
+					logger.Info("error deleting object: object not found: object may have been deleted asynchronously by another client", "object", id)
 					// treat this as successful idempotent deletion
 				} else {
-					if klog.V(4).Enabled() {
+					if logger.V(4).Enabled() {
 						// only log event emitted errors if the verbosity > 4
-						klog.Errorf("error deleting object (object: %q): %v", id, err)
+						logger.Error(err, "error deleting object", "object", id)
 					}
-					taskContext.SendEvent(eventFactory.CreateFailedEvent(id, err))
+					taskContext.SendEventWithTiming(eventFactory.CreateFailedEvent(id, applyerror.NewPruneFailedError(id, err)), start)
 					taskContext.InventoryManager().AddFailedDelete(id)
 					continue
 				}
 			}
 		}
 		taskContext.InventoryManager().AddSuccessfulDelete(id, obj.GetUID())
-		taskContext.SendEvent(eventFactory.CreateSuccessEvent(obj))
+		taskContext.SetActuationTime(id, time.Now())
+		if opts.Tombstones && !opts.Destroy {
+			p.setTombstone(obj, id, taskContext)
+		}
+		taskContext.SendEventWithTiming(eventFactory.CreateSuccessEvent(obj), start)
 	}
 	return nil
 }
 
+// setTombstone records a tombstone for the deleted object in the inventory.
+// Failure to compute the tombstone is logged but does not fail the delete,
+// because the object is gone either way.
+func (p *Pruner) setTombstone(obj *unstructured.Unstructured, id object.ObjMetadata, taskContext *taskrunner.TaskContext) {
+	tombstone, err := inventory.NewTombstone(obj, time.Now())
+	if err == nil {
+		err = taskContext.InventoryManager().SetTombstone(id, tombstone)
+	}
+	if err != nil {
+		taskContext.Logger().Info("failed to record tombstone", "object", id, "error", err)
+	}
+}
+
 // removeInventoryAnnotation removes the `config.k8s.io/owning-inventory` annotation from pruneObj.
-func (p *Pruner) removeInventoryAnnotation(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+func (p *Pruner) removeInventoryAnnotation(logger logr.Logger, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
 	// Make a copy of the input object to avoid modifying the input.
 	// This prevents race conditions when writing to the underlying map.
 	obj = obj.DeepCopy()
@@ -202,7 +262,7 @@ func (p *Pruner) removeInventoryAnnotation(obj *unstructured.Unstructured) (*uns
 	annotations := obj.GetAnnotations()
 	if annotations != nil {
 		if _, ok := annotations[inventory.OwningInventoryKey]; ok {
-			klog.V(4).Infof("removing annotation (object: %q, annotation: %q)", id, inventory.OwningInventoryKey)
+			logger.V(4).Info("removing annotation", "object", id, "annotation", inventory.OwningInventoryKey)
 			delete(annotations, inventory.OwningInventoryKey)
 			obj.SetAnnotations(annotations)
 			namespacedClient, err := p.namespacedClient(id)
@@ -231,10 +291,16 @@ func (p *Pruner) GetPruneObjs(
 		return nil, err
 	}
 	// only return objects that were in the inventory but not in the object set
-	ids = invIDs.Diff(ids)
-	objs = object.UnstructuredSet{}
+	return p.GetClusterObjs(invIDs.Diff(ids))
+}
+
+// GetClusterObjs returns the objects in the cluster with the passed ids, to
+// be deleted. Objects that are not found, or whose resource type is not
+// registered, are left out.
+func (p *Pruner) GetClusterObjs(ids object.ObjMetadataSet) (object.UnstructuredSet, error) {
+	objs := object.UnstructuredSet{}
 	for _, id := range ids {
-		pruneObj, err := p.getObject(id)
+		obj, err := p.getObject(id)
 		if err != nil {
 			if meta.IsNoMatchError(err) {
 				klog.V(4).Infof("skip pruning (object: %q): resource type not registered", id)
@@ -246,7 +312,7 @@ func (p *Pruner) GetPruneObjs(
 			}
 			return nil, err
 		}
-		objs = append(objs, pruneObj)
+		objs = append(objs, obj)
 	}
 	return objs, nil
 }
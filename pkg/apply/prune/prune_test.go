@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -404,6 +405,7 @@ func TestPrune(t *testing.T) {
 					PruneEvent: event.PruneEvent{
 						Identifier: object.UnstructuredToObjMetadata(podDeletionPrevention),
 						Status:     event.PruneSkipped,
+						Operation:  event.PruneOrphaned,
 						Object: testutil.Mutate(podDeletionPrevention.DeepCopy(),
 							testutil.DeleteOwningInv(t, testInventoryLabel)),
 						Error: testutil.EqualError(&filter.AnnotationPreventedDeletionError{
@@ -417,6 +419,7 @@ func TestPrune(t *testing.T) {
 					PruneEvent: event.PruneEvent{
 						Identifier: testutil.ToIdentifier(t, pdbDeletePreventionManifest),
 						Status:     event.PruneSkipped,
+						Operation:  event.PruneOrphaned,
 						Object: testutil.Unstructured(t, pdbDeletePreventionManifest,
 							testutil.DeleteOwningInv(t, testInventoryLabel)),
 						Error: testutil.EqualError(&filter.AnnotationPreventedDeletionError{
@@ -494,6 +497,7 @@ func TestPrune(t *testing.T) {
 					PruneEvent: event.PruneEvent{
 						Identifier: object.UnstructuredToObjMetadata(podDeletionPrevention),
 						Status:     event.PruneSkipped,
+						Operation:  event.PruneOrphaned,
 						Object: testutil.Mutate(podDeletionPrevention.DeepCopy(),
 							testutil.DeleteOwningInv(t, testInventoryLabel)),
 						Error: testutil.EqualError(&filter.AnnotationPreventedDeletionError{
@@ -909,7 +913,7 @@ func TestHandleDeletePrevention(t *testing.T) {
 			scheme.Scheme.PrioritizedVersionsAllGroups()...),
 	}
 	var err error
-	obj, err = po.removeInventoryAnnotation(obj)
+	obj, err = po.removeInventoryAnnotation(logr.Discard(), obj)
 	if err != nil {
 		t.Fatalf("unexpected error %s returned", err)
 	}
@@ -950,12 +954,23 @@ func (c *optionsCaptureNamespaceClient) Delete(_ context.Context, _ string, opti
 func TestPrune_PropagationPolicy(t *testing.T) {
 	testCases := map[string]struct {
 		propagationPolicy metav1.DeletionPropagation
+		annotations       map[string]string
+		expectedPolicy    metav1.DeletionPropagation
 	}{
 		"background propagation policy": {
 			propagationPolicy: metav1.DeletePropagationBackground,
+			expectedPolicy:    metav1.DeletePropagationBackground,
 		},
 		"foreground propagation policy": {
 			propagationPolicy: metav1.DeletePropagationForeground,
+			expectedPolicy:    metav1.DeletePropagationForeground,
+		},
+		"annotation overrides propagation policy": {
+			propagationPolicy: metav1.DeletePropagationBackground,
+			annotations: map[string]string{
+				common.DeletionPropagationAnnotation: string(metav1.DeletePropagationOrphan),
+			},
+			expectedPolicy: metav1.DeletePropagationOrphan,
 		},
 	}
 	for name, tc := range testCases {
@@ -973,12 +988,16 @@ func TestPrune_PropagationPolicy(t *testing.T) {
 			eventChannel := make(chan event.Event, 1)
 			resourceCache := cache.NewResourceCacheMap()
 			taskContext := taskrunner.NewTaskContext(eventChannel, resourceCache)
-			err := po.Prune([]*unstructured.Unstructured{pdb}, []filter.ValidationFilter{}, taskContext, "test-0", Options{
+			obj := pdb.DeepCopy()
+			if tc.annotations != nil {
+				obj.SetAnnotations(tc.annotations)
+			}
+			err := po.Prune([]*unstructured.Unstructured{obj}, []filter.ValidationFilter{}, taskContext, "test-0", Options{
 				PropagationPolicy: tc.propagationPolicy,
 			})
 			assert.NoError(t, err)
 			require.NotNil(t, captureClient.options.PropagationPolicy)
-			assert.Equal(t, tc.propagationPolicy, *captureClient.options.PropagationPolicy)
+			assert.Equal(t, tc.expectedPolicy, *captureClient.options.PropagationPolicy)
 		})
 	}
 }
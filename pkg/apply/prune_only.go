@@ -0,0 +1,78 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// Prune deletes the previously applied objects that are no longer in the
+// objects, without applying any of the objects. The objects that are still
+// in the inventory are kept in it, and prevent pruning the objects they
+// depend on. Objects that are not in the inventory yet are not added to it.
+// Pruning is always enabled, and the options that only affect apply, like
+// PreValidate, UpdateOnly, and RetryFailed, are ignored. Like Run, the
+// progress is sent on the returned event channel.
+func (a *Applier) Prune(ctx context.Context, invInfo inventory.Info, objects object.UnstructuredSet, options ApplierOptions) <-chan event.Event {
+	options.Prune = PruneEnabled
+	options.NoPrune = false
+	return a.run(ctx, invInfo, objects, options, true)
+}
+
+// retainObjects returns the objects to keep in the inventory during a
+// prune-only run: the objects that are already in the inventory.
+func (a *Applier) retainObjects(invInfo inventory.Info, objs object.UnstructuredSet) (object.UnstructuredSet, error) {
+	invIds, err := a.invClient.GetClusterObjs(invInfo)
+	if err != nil {
+		return nil, err
+	}
+	var retainedObjs object.UnstructuredSet
+	for _, obj := range objs {
+		if invIds.Contains(object.UnstructuredToObjMetadata(obj)) {
+			retainedObjs = append(retainedObjs, obj)
+		}
+	}
+	return retainedObjs, nil
+}
+
+// restoreObjectStatus replaces the status of the retained objects with their
+// status from the previous run, if the inventory stores it. The retained
+// objects are registered as successfully applied, so that they are kept in
+// the inventory, but they were not applied by this run.
+func (a *Applier) restoreObjectStatus(logger logr.Logger, invInfo inventory.Info, im *inventory.Manager,
+	retainedObjs object.UnstructuredSet) error {
+	statusClient, ok := a.invClient.(inventory.StatusClient)
+	if !ok || len(retainedObjs) == 0 {
+		return nil
+	}
+	statuses, err := statusClient.GetClusterObjStatus(invInfo)
+	if err != nil {
+		return err
+	}
+	retainedIds := object.UnstructuredSetToObjMetadataSet(retainedObjs)
+	restored := 0
+	for _, status := range statuses {
+		if !retainedIds.Contains(inventory.ObjMetadataFromObjectReference(status.ObjectReference)) {
+			continue
+		}
+		// Objects with any other status would be removed from the
+		// inventory.
+		if status.Strategy != actuation.ActuationStrategyApply {
+			continue
+		}
+		switch status.Actuation {
+		case actuation.ActuationSucceeded, actuation.ActuationFailed, actuation.ActuationSkipped:
+			im.SetObjectStatus(status)
+			restored++
+		}
+	}
+	logger.V(4).Info("restored object status", "retained", len(retainedObjs), "restored", restored)
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	printcommon "sigs.k8s.io/cli-utils/pkg/print/common"
+	"sigs.k8s.io/cli-utils/pkg/print/stats"
+)
+
+// ObjectSource returns the set of objects to apply. It is called at the start
+// of every reconcile iteration, so that changes to the source are picked up
+// without restarting the loop.
+type ObjectSource func() (object.UnstructuredSet, error)
+
+// ReconcileStatus summarizes a single iteration of a ReconcileLoop.
+type ReconcileStatus struct {
+	// Iteration is the 1-based number of the iteration.
+	Iteration int
+	// StartTime is the time the iteration started.
+	StartTime time.Time
+	// Duration is how long the iteration took.
+	Duration time.Duration
+	// Stats summarizes the events from the apply.
+	Stats stats.Stats
+	// Err is the error that caused the iteration to fail, if any. This is
+	// either an error reading the objects, a fatal apply error, or a
+	// printcommon.ResultError if any objects failed to apply, prune, or
+	// reconcile.
+	Err error
+}
+
+// applyRunner runs an apply and returns the event channel. It is implemented
+// by the Applier.
+type applyRunner interface {
+	Run(ctx context.Context, invInfo inventory.Info, objects object.UnstructuredSet, options ApplierOptions) <-chan event.Event
+}
+
+// ReconcileLoop periodically re-applies a set of objects, correcting any drift
+// from the desired state, until its context is cancelled. Each iteration
+// waits for the previous apply to complete, so iterations never overlap.
+type ReconcileLoop struct {
+	runner applyRunner
+
+	// InventoryInfo is the inventory used for every apply.
+	InventoryInfo inventory.Info
+	// Source is called at the start of every iteration to read the objects.
+	Source ObjectSource
+	// Interval is the time between the end of one iteration and the start of
+	// the next.
+	Interval time.Duration
+	// Options are the options used for every apply.
+	Options ApplierOptions
+	// EventHandler, if set, is called with every event from every apply.
+	EventHandler func(event.Event)
+
+	mu         sync.RWMutex
+	lastStatus *ReconcileStatus
+}
+
+// NewReconcileLoop returns a ReconcileLoop that uses the applier to apply
+// the objects from the source every interval.
+func NewReconcileLoop(applier *Applier, invInfo inventory.Info, source ObjectSource, interval time.Duration, options ApplierOptions) *ReconcileLoop {
+	return &ReconcileLoop{
+		runner:        applier,
+		InventoryInfo: invInfo,
+		Source:        source,
+		Interval:      interval,
+		Options:       options,
+	}
+}
+
+// Run starts the loop in a goroutine and returns a channel that receives the
+// status of each iteration. The first iteration starts immediately. The
+// channel is closed after the context is cancelled and the current
+// iteration has completed.
+func (r *ReconcileLoop) Run(ctx context.Context) <-chan ReconcileStatus {
+	statusChannel := make(chan ReconcileStatus)
+	go func() {
+		defer close(statusChannel)
+		for iteration := 1; ; iteration++ {
+			status := r.reconcile(ctx, iteration)
+			r.mu.Lock()
+			r.lastStatus = &status
+			r.mu.Unlock()
+			select {
+			case statusChannel <- status:
+			case <-ctx.Done():
+				return
+			}
+
+			timer := time.NewTimer(r.Interval)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+	}()
+	return statusChannel
+}
+
+// LastStatus returns the status of the most recently completed iteration,
+// or false if no iteration has completed yet. It is safe to call
+// concurrently with Run, for example from a health or metrics endpoint.
+func (r *ReconcileLoop) LastStatus() (ReconcileStatus, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.lastStatus == nil {
+		return ReconcileStatus{}, false
+	}
+	return *r.lastStatus, true
+}
+
+// reconcile runs a single iteration of the loop.
+func (r *ReconcileLoop) reconcile(ctx context.Context, iteration int) ReconcileStatus {
+	status := ReconcileStatus{
+		Iteration: iteration,
+		StartTime: time.Now(),
+	}
+
+	logger := klog.FromContext(ctx).WithValues("iteration", iteration)
+	logger.V(4).Info("reconcile loop iteration starting")
+	objs, err := r.Source()
+	if err != nil {
+		logger.V(4).Info("reconcile loop iteration failed to read objects", "error", err)
+		status.Err = err
+		status.Duration = time.Since(status.StartTime)
+		return status
+	}
+
+	var fatalErr error
+	for e := range r.runner.Run(ctx, r.InventoryInfo, objs, r.Options) {
+		status.Stats.Handle(e)
+		if e.Type == event.ErrorType {
+			fatalErr = e.ErrorEvent.Err
+		}
+		if r.EventHandler != nil {
+			r.EventHandler(e)
+		}
+	}
+	if fatalErr != nil {
+		status.Err = fatalErr
+	} else {
+		status.Err = printcommon.ResultErrorFromStats(status.Stats)
+	}
+	status.Duration = time.Since(status.StartTime)
+	logger.V(4).Info("reconcile loop iteration finished", "duration", status.Duration)
+	return status
+}
@@ -0,0 +1,147 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	printcommon "sigs.k8s.io/cli-utils/pkg/print/common"
+	"sigs.k8s.io/cli-utils/pkg/print/stats"
+)
+
+type fakeApplyRunner struct {
+	events []event.Event
+	runs   int
+}
+
+func (f *fakeApplyRunner) Run(_ context.Context, _ inventory.Info, _ object.UnstructuredSet, _ ApplierOptions) <-chan event.Event {
+	f.runs++
+	eventChannel := make(chan event.Event, len(f.events))
+	for _, e := range f.events {
+		eventChannel <- e
+	}
+	close(eventChannel)
+	return eventChannel
+}
+
+func TestReconcileLoop(t *testing.T) {
+	testCases := map[string]struct {
+		events        []event.Event
+		sourceErr     error
+		expectedRuns  int
+		expectedApply int
+		expectedErr   error
+	}{
+		"successful apply": {
+			events: []event.Event{
+				{
+					Type: event.ApplyType,
+					ApplyEvent: event.ApplyEvent{
+						Status:    event.ApplySuccessful,
+						Operation: event.ApplyConfigured,
+					},
+				},
+			},
+			expectedRuns:  2,
+			expectedApply: 1,
+		},
+		"failed apply": {
+			events: []event.Event{
+				{
+					Type: event.ApplyType,
+					ApplyEvent: event.ApplyEvent{
+						Status: event.ApplyFailed,
+						Error:  errors.New("apply failed"),
+					},
+				},
+			},
+			expectedRuns:  2,
+			expectedApply: 1,
+			expectedErr: &printcommon.ResultError{
+				Stats: stats.Stats{
+					ApplyStats: stats.ApplyStats{Failed: 1},
+				},
+			},
+		},
+		"fatal error": {
+			events: []event.Event{
+				{
+					Type: event.ErrorType,
+					ErrorEvent: event.ErrorEvent{
+						Err: errors.New("fatal"),
+					},
+				},
+			},
+			expectedRuns: 2,
+			expectedErr:  errors.New("fatal"),
+		},
+		"source error": {
+			sourceErr:    errors.New("read failed"),
+			expectedRuns: 0,
+			expectedErr:  errors.New("read failed"),
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			runner := &fakeApplyRunner{events: tc.events}
+			loop := &ReconcileLoop{
+				runner: runner,
+				Source: func() (object.UnstructuredSet, error) {
+					return object.UnstructuredSet{}, tc.sourceErr
+				},
+				Interval: time.Millisecond,
+			}
+			_, found := loop.LastStatus()
+			assert.False(t, found)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			statusChannel := loop.Run(ctx)
+
+			var statuses []ReconcileStatus
+			for status := range statusChannel {
+				statuses = append(statuses, status)
+				if len(statuses) == 2 {
+					break
+				}
+			}
+			cancel()
+			// Drain the channel, so the loop goroutine can exit.
+			for range statusChannel {
+			}
+			require.Len(t, statuses, 2)
+			// Another iteration may start before the loop observes the
+			// cancellation.
+			if tc.expectedRuns == 0 {
+				assert.Equal(t, 0, runner.runs)
+			} else {
+				assert.GreaterOrEqual(t, runner.runs, tc.expectedRuns)
+			}
+
+			for i, status := range statuses {
+				assert.Equal(t, i+1, status.Iteration)
+				assert.Equal(t, tc.expectedApply, status.Stats.ApplyStats.Sum())
+				if tc.expectedErr == nil {
+					assert.NoError(t, status.Err)
+				} else {
+					assert.IsType(t, tc.expectedErr, status.Err)
+					assert.Equal(t, tc.expectedErr.Error(), status.Err.Error())
+				}
+			}
+
+			last, found := loop.LastStatus()
+			assert.True(t, found)
+			assert.GreaterOrEqual(t, last.Iteration, 2)
+		})
+	}
+}
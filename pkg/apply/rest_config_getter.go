@@ -0,0 +1,83 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// restConfigGetter is a RESTClientGetter that builds clients from a
+// rest.Config, for building a Factory when no kubeconfig is available, like
+// in controllers.
+type restConfigGetter struct {
+	config *rest.Config
+
+	mu          sync.Mutex
+	discoClient discovery.CachedDiscoveryInterface
+	mapper      meta.RESTMapper
+}
+
+var _ genericclioptions.RESTClientGetter = &restConfigGetter{}
+
+func newRESTConfigGetter(config *rest.Config) *restConfigGetter {
+	return &restConfigGetter{config: config}
+}
+
+// ToRESTConfig returns a copy of the rest config, because callers may
+// modify it.
+func (g *restConfigGetter) ToRESTConfig() (*rest.Config, error) {
+	return rest.CopyConfig(g.config), nil
+}
+
+// ToDiscoveryClient returns a discovery client with an in-memory cache,
+// shared by all callers.
+func (g *restConfigGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.discoClient == nil {
+		discoClient, err := discovery.NewDiscoveryClientForConfig(rest.CopyConfig(g.config))
+		if err != nil {
+			return nil, err
+		}
+		g.discoClient = memory.NewMemCacheClient(discoClient)
+	}
+	return g.discoClient, nil
+}
+
+// ToRESTMapper returns a RESTMapper backed by the discovery client, shared
+// by all callers.
+func (g *restConfigGetter) ToRESTMapper() (meta.RESTMapper, error) {
+	discoClient, err := g.ToDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.mapper == nil {
+		mapper := restmapper.NewDeferredDiscoveryRESTMapper(discoClient)
+		g.mapper = restmapper.NewShortcutExpander(mapper, discoClient)
+	}
+	return g.mapper, nil
+}
+
+// ToRawKubeConfigLoader returns a ClientConfig that only knows the default
+// namespace, since there is no kubeconfig.
+func (g *restConfigGetter) ToRawKubeConfigLoader() clientcmd.ClientConfig {
+	return clientcmd.NewDefaultClientConfig(*clientcmdapi.NewConfig(), &clientcmd.ConfigOverrides{
+		Context: clientcmdapi.Context{
+			Namespace: "default",
+		},
+	})
+}
@@ -0,0 +1,221 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/policyhook"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+//go:generate stringer -type=ResultClass -linecomment
+
+// ResultClass classifies the outcome of a run, or of a single object.
+type ResultClass int
+
+const (
+	ResultSuccess          ResultClass = iota // Success
+	ResultSkipped                             // Skipped
+	ResultReconcileTimeout                    // ReconcileTimeout
+	ResultReconcileFailed                     // ReconcileFailed
+	ResultPolicyViolation                     // PolicyViolation
+	ResultActuationFailed                     // ActuationFailed
+	ResultCancelled                           // Cancelled
+	ResultError                               // Error
+)
+
+// runClassOrder lists the classes in order of precedence, when
+// classifying a run from the classes of its objects.
+var runClassOrder = []ResultClass{
+	ResultActuationFailed,
+	ResultPolicyViolation,
+	ResultReconcileFailed,
+	ResultReconcileTimeout,
+}
+
+// ObjectResult is the outcome of a run for a single object.
+type ObjectResult struct {
+	Identifier object.ObjMetadata
+	// Action is the action taken on the object: Apply, Prune, or Delete.
+	Action event.ResourceAction
+	// Class is the outcome for the object. It is ResultSkipped if the
+	// object was skipped for any reason other than a policy denial.
+	Class ResultClass
+	// Reconcile is the result of waiting for the object to reconcile.
+	// It is ReconcilePending if the object was not waited on.
+	Reconcile event.WaitEventStatus
+	// Error is the error of the actuation, or the reason it was skipped.
+	// For objects that were actuated but timed out, it is the
+	// ReconcileTimeoutError of the wait.
+	Error error
+	// ActuationTiming and ReconcileTiming are only set if the events
+	// include timing (see ApplierOptions.RecordTiming).
+	ActuationTiming event.Timing
+	ReconcileTiming event.Timing
+}
+
+// RunResult is the structured result of an Applier or Destroyer run,
+// built from its events. It implements event.Sink, so it can be
+// subscribed to an event.Multiplexer next to a printer. It is safe to
+// read the result while events are being sent.
+type RunResult struct {
+	mu      sync.Mutex
+	objects map[object.ObjMetadata]*ObjectResult
+	ids     []object.ObjMetadata
+	err     error
+	start   time.Time
+	end     time.Time
+}
+
+// NewRunResult returns an empty RunResult.
+func NewRunResult() *RunResult {
+	return &RunResult{
+		objects: make(map[object.ObjMetadata]*ObjectResult),
+	}
+}
+
+// Send updates the result based on an event.
+func (r *RunResult) Send(e event.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if r.start.IsZero() {
+		r.start = now
+	}
+	r.end = now
+
+	switch e.Type {
+	case event.ApplyType:
+		ae := e.ApplyEvent
+		r.actuated(ae.Identifier, event.ApplyAction, ae.Timing, ae.Error,
+			ae.Status == event.ApplyFailed, ae.Status == event.ApplySkipped)
+	case event.PruneType:
+		pe := e.PruneEvent
+		r.actuated(pe.Identifier, event.PruneAction, pe.Timing, pe.Error,
+			pe.Status == event.PruneFailed, pe.Status == event.PruneSkipped)
+	case event.DeleteType:
+		de := e.DeleteEvent
+		r.actuated(de.Identifier, event.DeleteAction, de.Timing, de.Error,
+			de.Status == event.DeleteFailed, de.Status == event.DeleteSkipped)
+	case event.WaitType:
+		we := e.WaitEvent
+		obj := r.object(we.Identifier)
+		obj.Reconcile = we.Status
+		obj.ReconcileTiming = we.Timing
+		if obj.Class != ResultSuccess {
+			break
+		}
+		switch we.Status {
+		case event.ReconcileFailed:
+			obj.Class = ResultReconcileFailed
+		case event.ReconcileTimeout:
+			obj.Class = ResultReconcileTimeout
+			obj.Error = we.Error
+		}
+	case event.ErrorType:
+		r.err = e.ErrorEvent.Err
+	}
+}
+
+// actuated records the result of applying, pruning, or deleting an object.
+func (r *RunResult) actuated(id object.ObjMetadata, action event.ResourceAction,
+	timing event.Timing, err error, failed, skipped bool) {
+	obj := r.object(id)
+	obj.Action = action
+	obj.ActuationTiming = timing
+	obj.Error = err
+	var denied *policyhook.PolicyDeniedError
+	switch {
+	case failed:
+		obj.Class = ResultActuationFailed
+	case skipped && errors.As(err, &denied):
+		obj.Class = ResultPolicyViolation
+	case skipped:
+		obj.Class = ResultSkipped
+	default:
+		obj.Class = ResultSuccess
+	}
+}
+
+// object returns the result of the object, adding it if it is new.
+func (r *RunResult) object(id object.ObjMetadata) *ObjectResult {
+	obj, found := r.objects[id]
+	if !found {
+		obj = &ObjectResult{Identifier: id}
+		r.objects[id] = obj
+		r.ids = append(r.ids, id)
+	}
+	return obj
+}
+
+// Objects returns the results of all objects, in the order they were
+// first seen in the events.
+func (r *RunResult) Objects() []ObjectResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	objs := make([]ObjectResult, len(r.ids))
+	for i, id := range r.ids {
+		objs[i] = *r.objects[id]
+	}
+	return objs
+}
+
+// Object returns the result of the object with the provided identifier.
+func (r *RunResult) Object(id object.ObjMetadata) (ObjectResult, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	obj, found := r.objects[id]
+	if !found {
+		return ObjectResult{}, false
+	}
+	return *obj, true
+}
+
+// Err returns the fatal error that ended the run, if any.
+func (r *RunResult) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.err
+}
+
+// Duration returns the time between the first and last event.
+func (r *RunResult) Duration() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.end.Sub(r.start)
+}
+
+// Class returns the classification of the run. A fatal error takes
+// precedence, followed by cancellation, then by the classes of the
+// objects: actuation failures, policy violations, reconcile failures,
+// and reconcile timeouts. Skipped objects do not fail the run.
+func (r *RunResult) Class() ResultClass {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		if errors.As(r.err, new(*taskrunner.CancelledError)) {
+			return ResultCancelled
+		}
+		return ResultError
+	}
+	for _, class := range runClassOrder {
+		for _, obj := range r.objects {
+			if obj.Class == class {
+				return class
+			}
+		}
+	}
+	return ResultSuccess
+}
@@ -0,0 +1,223 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/policyhook"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestRunResult(t *testing.T) {
+	dep := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Namespace: "default",
+		Name:      "dep",
+	}
+	cm := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+		Namespace: "default",
+		Name:      "cm",
+	}
+
+	applyEvent := func(id object.ObjMetadata, status event.ApplyEventStatus, err error) event.Event {
+		return event.Event{
+			Type: event.ApplyType,
+			ApplyEvent: event.ApplyEvent{
+				Identifier: id,
+				Status:     status,
+				Error:      err,
+			},
+		}
+	}
+	waitEvent := func(id object.ObjMetadata, status event.WaitEventStatus) event.Event {
+		return event.Event{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				Identifier: id,
+				Status:     status,
+			},
+		}
+	}
+	denied := fmt.Errorf("filtered: %w", &policyhook.PolicyDeniedError{
+		Action: policyhook.ActionApply,
+		Reason: "no privileged pods",
+	})
+
+	testCases := map[string]struct {
+		events          []event.Event
+		expectedClass   ResultClass
+		expectedObjects map[object.ObjMetadata]ResultClass
+	}{
+		"success": {
+			events: []event.Event{
+				applyEvent(dep, event.ApplySuccessful, nil),
+				applyEvent(cm, event.ApplySuccessful, nil),
+				waitEvent(dep, event.ReconcileSuccessful),
+				waitEvent(cm, event.ReconcileSuccessful),
+			},
+			expectedClass: ResultSuccess,
+			expectedObjects: map[object.ObjMetadata]ResultClass{
+				dep: ResultSuccess,
+				cm:  ResultSuccess,
+			},
+		},
+		"skipped objects do not fail the run": {
+			events: []event.Event{
+				applyEvent(dep, event.ApplySuccessful, nil),
+				applyEvent(cm, event.ApplySkipped, errors.New("dependency not ready")),
+				waitEvent(dep, event.ReconcileSuccessful),
+				waitEvent(cm, event.ReconcileSkipped),
+			},
+			expectedClass: ResultSuccess,
+			expectedObjects: map[object.ObjMetadata]ResultClass{
+				dep: ResultSuccess,
+				cm:  ResultSkipped,
+			},
+		},
+		"reconcile timeout": {
+			events: []event.Event{
+				applyEvent(dep, event.ApplySuccessful, nil),
+				applyEvent(cm, event.ApplySuccessful, nil),
+				waitEvent(dep, event.ReconcileTimeout),
+				waitEvent(cm, event.ReconcileSuccessful),
+			},
+			expectedClass: ResultReconcileTimeout,
+			expectedObjects: map[object.ObjMetadata]ResultClass{
+				dep: ResultReconcileTimeout,
+				cm:  ResultSuccess,
+			},
+		},
+		"reconcile failure takes precedence over timeout": {
+			events: []event.Event{
+				applyEvent(dep, event.ApplySuccessful, nil),
+				applyEvent(cm, event.ApplySuccessful, nil),
+				waitEvent(dep, event.ReconcileTimeout),
+				waitEvent(cm, event.ReconcileFailed),
+			},
+			expectedClass: ResultReconcileFailed,
+			expectedObjects: map[object.ObjMetadata]ResultClass{
+				dep: ResultReconcileTimeout,
+				cm:  ResultReconcileFailed,
+			},
+		},
+		"policy violation": {
+			events: []event.Event{
+				applyEvent(dep, event.ApplySkipped, denied),
+				applyEvent(cm, event.ApplySuccessful, nil),
+				waitEvent(dep, event.ReconcileSkipped),
+				waitEvent(cm, event.ReconcileTimeout),
+			},
+			expectedClass: ResultPolicyViolation,
+			expectedObjects: map[object.ObjMetadata]ResultClass{
+				dep: ResultPolicyViolation,
+				cm:  ResultReconcileTimeout,
+			},
+		},
+		"actuation failure": {
+			events: []event.Event{
+				applyEvent(dep, event.ApplyFailed, errors.New("conflict")),
+				applyEvent(cm, event.ApplySkipped, denied),
+				waitEvent(dep, event.ReconcileSkipped),
+			},
+			expectedClass: ResultActuationFailed,
+			expectedObjects: map[object.ObjMetadata]ResultClass{
+				dep: ResultActuationFailed,
+				cm:  ResultPolicyViolation,
+			},
+		},
+		"cancelled": {
+			events: []event.Event{
+				applyEvent(dep, event.ApplyFailed, errors.New("conflict")),
+				{
+					Type: event.ErrorType,
+					ErrorEvent: event.ErrorEvent{
+						Err: &taskrunner.CancelledError{Err: context.Canceled},
+					},
+				},
+			},
+			expectedClass: ResultCancelled,
+			expectedObjects: map[object.ObjMetadata]ResultClass{
+				dep: ResultActuationFailed,
+			},
+		},
+		"fatal error": {
+			events: []event.Event{
+				{
+					Type: event.ErrorType,
+					ErrorEvent: event.ErrorEvent{
+						Err: errors.New("inventory not found"),
+					},
+				},
+			},
+			expectedClass:   ResultError,
+			expectedObjects: map[object.ObjMetadata]ResultClass{},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			result := NewRunResult()
+			for _, e := range tc.events {
+				result.Send(e)
+			}
+
+			assert.Equal(t, tc.expectedClass, result.Class())
+			objs := result.Objects()
+			require.Len(t, objs, len(tc.expectedObjects))
+			for _, obj := range objs {
+				assert.Equal(t, tc.expectedObjects[obj.Identifier], obj.Class,
+					"class of %s", obj.Identifier)
+			}
+		})
+	}
+}
+
+func TestRunResult_Object(t *testing.T) {
+	id := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+		Namespace: "default",
+		Name:      "cm",
+	}
+	start := time.Now()
+	timing := event.Timing{Start: start, End: start.Add(2 * time.Second)}
+
+	result := NewRunResult()
+	result.Send(event.Event{
+		Type: event.PruneType,
+		PruneEvent: event.PruneEvent{
+			Identifier: id,
+			Status:     event.PruneSuccessful,
+			Timing:     timing,
+		},
+	})
+	result.Send(event.Event{
+		Type: event.WaitType,
+		WaitEvent: event.WaitEvent{
+			Identifier: id,
+			Status:     event.ReconcileSuccessful,
+		},
+	})
+
+	obj, found := result.Object(id)
+	require.True(t, found)
+	assert.Equal(t, event.PruneAction, obj.Action)
+	assert.Equal(t, ResultSuccess, obj.Class)
+	assert.Equal(t, event.ReconcileSuccessful, obj.Reconcile)
+	assert.Equal(t, 2*time.Second, obj.ActuationTiming.Duration())
+	assert.NoError(t, result.Err())
+
+	_, found = result.Object(object.ObjMetadata{Name: "missing"})
+	assert.False(t, found)
+}
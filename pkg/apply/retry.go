@@ -0,0 +1,69 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/graph"
+)
+
+// retryObjects splits the objects to apply into the objects that need to be
+// applied again and the objects that were successfully applied and
+// reconciled by the previous run, using the object status stored in the
+// inventory. Objects that depend on an object being applied again are also
+// applied again. If the previous run did not store the object status, all
+// the objects are applied again.
+func (a *Applier) retryObjects(logger logr.Logger, localInv inventory.Info, applyObjs object.UnstructuredSet) (object.UnstructuredSet, object.UnstructuredSet, error) {
+	statusClient, ok := a.invClient.(inventory.StatusClient)
+	if !ok {
+		return nil, nil, fmt.Errorf("inventory client does not support retrying failed objects: %T", a.invClient)
+	}
+	statuses, err := statusClient.GetClusterObjStatus(localInv)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(statuses) == 0 {
+		logger.V(4).Info("no object status found in inventory: retrying all objects")
+		return applyObjs, nil, nil
+	}
+
+	succeededIds := object.ObjMetadataSet{}
+	for _, status := range statuses {
+		if status.Strategy == actuation.ActuationStrategyApply &&
+			status.Actuation == actuation.ActuationSucceeded &&
+			status.Reconcile == actuation.ReconcileSucceeded {
+			succeededIds = append(succeededIds, inventory.ObjMetadataFromObjectReference(status.ObjectReference))
+		}
+	}
+
+	// Objects with a status other than succeeded, or without a status, are
+	// applied again, along with all of their dependents.
+	retryIds := object.UnstructuredSetToObjMetadataSet(applyObjs).Diff(succeededIds)
+	// Graph errors are ignored here, because they are reported as
+	// validation errors by the solver.
+	g, _ := graph.DependencyGraph(applyObjs)
+	for i := 0; i < len(retryIds); i++ {
+		for _, dependent := range g.Dependents(retryIds[i]) {
+			if !retryIds.Contains(dependent) {
+				retryIds = append(retryIds, dependent)
+			}
+		}
+	}
+
+	var retryObjs, succeededObjs object.UnstructuredSet
+	for _, obj := range applyObjs {
+		if retryIds.Contains(object.UnstructuredToObjMetadata(obj)) {
+			retryObjs = append(retryObjs, obj)
+		} else {
+			succeededObjs = append(succeededObjs, obj)
+		}
+	}
+	logger.V(4).Info("retrying objects", "retry", len(retryObjs), "skip", len(succeededObjs))
+	return retryObjs, succeededObjs, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestRetryObjects(t *testing.T) {
+	secret := testutil.Unstructured(t, resources["secret"])
+	deployment := testutil.Unstructured(t, resources["deployment"],
+		testutil.AddDependsOn(t, testutil.ToIdentifier(t, resources["secret"])))
+	obj1 := testutil.Unstructured(t, resources["obj1"])
+	obj2 := testutil.Unstructured(t, resources["obj2"])
+
+	objStatus := func(obj string, act actuation.ActuationStatus, rec actuation.ReconcileStatus) actuation.ObjectStatus {
+		return actuation.ObjectStatus{
+			ObjectReference: inventory.ObjectReferenceFromObjMetadata(testutil.ToIdentifier(t, resources[obj])),
+			Strategy:        actuation.ActuationStrategyApply,
+			Actuation:       act,
+			Reconcile:       rec,
+		}
+	}
+
+	testCases := map[string]struct {
+		status            []actuation.ObjectStatus
+		applyObjs         object.UnstructuredSet
+		expectedRetry     object.UnstructuredSet
+		expectedSucceeded object.UnstructuredSet
+	}{
+		"no stored status, retry all": {
+			applyObjs:     object.UnstructuredSet{obj1, obj2},
+			expectedRetry: object.UnstructuredSet{obj1, obj2},
+		},
+		"retry failed and unreconciled objects": {
+			status: []actuation.ObjectStatus{
+				objStatus("obj1", actuation.ActuationFailed, actuation.ReconcileSkipped),
+				objStatus("obj2", actuation.ActuationSucceeded, actuation.ReconcileSucceeded),
+				objStatus("secret", actuation.ActuationSucceeded, actuation.ReconcileTimeout),
+			},
+			applyObjs:         object.UnstructuredSet{obj1, obj2, secret},
+			expectedRetry:     object.UnstructuredSet{obj1, secret},
+			expectedSucceeded: object.UnstructuredSet{obj2},
+		},
+		"retry objects without status": {
+			status: []actuation.ObjectStatus{
+				objStatus("obj2", actuation.ActuationSucceeded, actuation.ReconcileSucceeded),
+			},
+			applyObjs:         object.UnstructuredSet{obj1, obj2},
+			expectedRetry:     object.UnstructuredSet{obj1},
+			expectedSucceeded: object.UnstructuredSet{obj2},
+		},
+		"retry dependents of failed objects": {
+			status: []actuation.ObjectStatus{
+				objStatus("secret", actuation.ActuationFailed, actuation.ReconcileSkipped),
+				objStatus("deployment", actuation.ActuationSucceeded, actuation.ReconcileSucceeded),
+				objStatus("obj1", actuation.ActuationSucceeded, actuation.ReconcileSucceeded),
+			},
+			applyObjs:         object.UnstructuredSet{deployment, secret, obj1},
+			expectedRetry:     object.UnstructuredSet{deployment, secret},
+			expectedSucceeded: object.UnstructuredSet{obj1},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			applier := &Applier{
+				invClient: &inventory.FakeClient{Status: tc.status},
+			}
+			retryObjs, succeededObjs, err := applier.retryObjects(logr.Discard(), nil, tc.applyObjs)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRetry, retryObjs)
+			assert.Equal(t, tc.expectedSucceeded, succeededObjs)
+		})
+	}
+}
@@ -18,11 +18,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/filter"
 	"sigs.k8s.io/cli-utils/pkg/apply/info"
@@ -50,19 +52,73 @@ type TaskQueueBuilder struct {
 	ApplyFilters  []filter.ValidationFilter
 	ApplyMutators []mutator.Interface
 	PruneFilters  []filter.ValidationFilter
+	// Logger is used to log the tasks being added. If not set, klog is used.
+	Logger logr.Logger
 
 	// The accumulated tasks and counter variables to name tasks.
 	applyCounter int
 	pruneCounter int
 	waitCounter  int
 
-	invInfo   inventory.Info
-	applyObjs object.UnstructuredSet
-	pruneObjs object.UnstructuredSet
+	invInfo       inventory.Info
+	applyObjs     object.UnstructuredSet
+	pruneObjs     object.UnstructuredSet
+	deleteObjs    object.UnstructuredSet
+	succeededObjs object.UnstructuredSet
+	legacyObjs    object.UnstructuredSet
+}
+
+// logger returns the Logger, or the klog logger if it is not set.
+func (t *TaskQueueBuilder) logger() logr.Logger {
+	if t.Logger.GetSink() == nil {
+		return klog.Background()
+	}
+	return t.Logger
+}
+
+// InsertionPoint identifies a point in the task queue where custom tasks can
+// be inserted with TaskQueue.InsertAt. With Options.PruneFirst, the prune tasks and
+// the AfterPrune tasks are before the BeforeApply tasks, and the AfterApply
+// tasks are last, before the inventory is updated.
+type InsertionPoint int
+
+const (
+	// BeforeApply is after the inventory is created or updated with the
+	// objects to apply, before the first apply task.
+	BeforeApply InsertionPoint = iota
+	// AfterApply is after the last apply and wait tasks, before the first
+	// prune task.
+	AfterApply
+	// AfterPrune is after the last prune (or delete) and wait tasks, before
+	// the inventory is updated (or deleted).
+	AfterPrune
+)
+
+// String returns the name of the InsertionPoint.
+func (p InsertionPoint) String() string {
+	switch p {
+	case BeforeApply:
+		return "BeforeApply"
+	case AfterApply:
+		return "AfterApply"
+	case AfterPrune:
+		return "AfterPrune"
+	default:
+		return fmt.Sprintf("InsertionPoint(%d)", int(p))
+	}
 }
 
 type TaskQueue struct {
 	tasks []taskrunner.Task
+	// points are the positions of the insertion points, in queue order.
+	points []insertionPoint
+}
+
+// insertionPoint is the position of an InsertionPoint in the task queue:
+// tasks inserted at the point go before the task at the index.
+type insertionPoint struct {
+	point InsertionPoint
+	index int
 }
 
 func (tq *TaskQueue) ToChannel() chan taskrunner.Task {
@@ -73,6 +129,118 @@ func (tq *TaskQueue) ToChannel() chan taskrunner.Task {
 	return taskQueue
 }
 
+// Tasks returns a copy of the ordered list of tasks in the queue.
+func (tq *TaskQueue) Tasks() []taskrunner.Task {
+	tasks := make([]taskrunner.Task, len(tq.tasks))
+	copy(tasks, tq.tasks)
+	return tasks
+}
+
+// Index returns the position of the task with the specified name,
+// or -1 if no such task is in the queue.
+func (tq *TaskQueue) Index(name string) int {
+	for i, t := range tq.tasks {
+		if t.Name() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Append adds the tasks to the end of the queue.
+func (tq *TaskQueue) Append(tasks ...taskrunner.Task) {
+	tq.tasks = append(tq.tasks, tasks...)
+}
+
+// InsertBefore inserts the tasks immediately before the task with the
+// specified name. Returns an error if the named task is not in the queue.
+func (tq *TaskQueue) InsertBefore(name string, tasks ...taskrunner.Task) error {
+	i := tq.Index(name)
+	if i < 0 {
+		return fmt.Errorf("task not found: %q", name)
+	}
+	tq.insert(i, tasks...)
+	return nil
+}
+
+// InsertAfter inserts the tasks immediately after the task with the
+// specified name. Returns an error if the named task is not in the queue.
+func (tq *TaskQueue) InsertAfter(name string, tasks ...taskrunner.Task) error {
+	i := tq.Index(name)
+	if i < 0 {
+		return fmt.Errorf("task not found: %q", name)
+	}
+	tq.insert(i+1, tasks...)
+	return nil
+}
+
+// Replace replaces the task with the specified name. Returns an error if
+// the named task is not in the queue.
+func (tq *TaskQueue) Replace(name string, task taskrunner.Task) error {
+	i := tq.Index(name)
+	if i < 0 {
+		return fmt.Errorf("task not found: %q", name)
+	}
+	tq.tasks[i] = task
+	return nil
+}
+
+// Remove removes the task with the specified name. Returns an error if
+// the named task is not in the queue.
+func (tq *TaskQueue) Remove(name string) error {
+	i := tq.Index(name)
+	if i < 0 {
+		return fmt.Errorf("task not found: %q", name)
+	}
+	tq.tasks = append(tq.tasks[:i], tq.tasks[i+1:]...)
+	for j := range tq.points {
+		if tq.points[j].index > i {
+			tq.points[j].index--
+		}
+	}
+	return nil
+}
+
+// InsertAt inserts the tasks at the InsertionPoint, after any tasks already
+// inserted there. The insertion points are set by the TaskQueueBuilder, even
+// if there are no objects to apply or prune, so that tasks can be inserted at
+// fixed points. Returns an error if the queue has no such insertion point.
+//
+// Custom tasks must send a TaskResult on the TaskChannel of the TaskContext
+// when they are done, and their names must be unique in the task queue.
+func (tq *TaskQueue) InsertAt(point InsertionPoint, tasks ...taskrunner.Task) error {
+	for j := range tq.points {
+		if tq.points[j].point != point {
+			continue
+		}
+		tq.insertTasks(tq.points[j].index, tasks...)
+		// Move this point and the points after it, including the points at
+		// the same index, after the inserted tasks.
+		for k := j; k < len(tq.points); k++ {
+			tq.points[k].index += len(tasks)
+		}
+		return nil
+	}
+	return fmt.Errorf("insertion point not found: %s", point)
+}
+
+func (tq *TaskQueue) insert(i int, tasks ...taskrunner.Task) {
+	tq.insertTasks(i, tasks...)
+	for j := range tq.points {
+		if tq.points[j].index > i {
+			tq.points[j].index += len(tasks)
+		}
+	}
+}
+
+func (tq *TaskQueue) insertTasks(i int, tasks ...taskrunner.Task) {
+	merged := make([]taskrunner.Task, 0, len(tq.tasks)+len(tasks))
+	merged = append(merged, tq.tasks[:i]...)
+	merged = append(merged, tasks...)
+	merged = append(merged, tq.tasks[i:]...)
+	tq.tasks = merged
+}
+
 func (tq *TaskQueue) ToActionGroups() []event.ActionGroup {
 	var ags []event.ActionGroup
 
@@ -92,12 +260,47 @@ type Options struct {
 	// True if we are destroying, which deletes the inventory object
 	// as well (possibly) the inventory namespace.
 	Destroy bool
+	// True if the inventory object should be kept when destroying. Objects
+	// that were not deleted are kept in the inventory.
+	RetainInventory bool
 	// True if we're deleting prune objects
 	Prune                  bool
 	DryRunStrategy         common.DryRunStrategy
 	PrunePropagationPolicy metav1.DeletionPropagation
 	PruneTimeout           time.Duration
 	InventoryPolicy        inventory.Policy
+	// WaitSummaryInterval defines how often wait tasks send a summary of
+	// the objects that are still pending. Zero disables summaries.
+	WaitSummaryInterval time.Duration
+	// WaitForStatuslessObjects disables marking applied objects without
+	// status as Current, so that they are watched like any other object.
+	WaitForStatuslessObjects bool
+	// InventoryTombstones records pruned objects as tombstones in the
+	// inventory, instead of removing them from it.
+	InventoryTombstones bool
+	// RemoveFinalizersAfter defines how long pruned objects may be blocked
+	// from deletion by finalizers before their finalizers are removed.
+	// Zero disables finalizer removal.
+	RemoveFinalizersAfter time.Duration
+	// ApplyConcurrency defines how many objects in the same apply task are
+	// applied at the same time. Zero or one applies objects one at a time.
+	ApplyConcurrency int
+	// RetryPolicy defines how applies and deletes that fail with a
+	// transient error are retried.
+	RetryPolicy common.RetryPolicy
+	// WaitTimeouts overrides the ReconcileTimeout or PruneTimeout of
+	// individual wait tasks, by task name (ex: "wait-1").
+	WaitTimeouts map[string]time.Duration
+	// PreValidate adds a task that validates all the objects to apply with
+	// a server-side dry-run, before the inventory or any object is updated.
+	PreValidate bool
+	// ValidationPolicy defines how the validate task handles invalid
+	// objects.
+	ValidationPolicy validation.Policy
+	// PruneFirst moves the prune tasks, and their wait tasks, before the
+	// apply tasks, so that obsolete objects are deleted before the new ones
+	// are applied.
+	PruneFirst bool
 }
 
 // WithInventory sets the inventory info and returns the builder for chaining.
@@ -106,6 +309,15 @@ func (t *TaskQueueBuilder) WithInventory(inv inventory.Info) *TaskQueueBuilder {
 	return t
 }
 
+// WithSucceededObjects sets the objects that were successfully applied and
+// reconciled by a previous run, and are not being applied again. They are
+// included in the dependency graph and kept in the inventory, but no tasks
+// are added for them. Returns the builder for chaining.
+func (t *TaskQueueBuilder) WithSucceededObjects(succeededObjs object.UnstructuredSet) *TaskQueueBuilder {
+	t.succeededObjs = succeededObjs
+	return t
+}
+
 // WithApplyObjects sets the apply objects and returns the builder for chaining.
 func (t *TaskQueueBuilder) WithApplyObjects(applyObjs object.UnstructuredSet) *TaskQueueBuilder {
 	t.applyObjs = applyObjs
@@ -118,6 +330,23 @@ func (t *TaskQueueBuilder) WithPruneObjects(pruneObjs object.UnstructuredSet) *T
 	return t
 }
 
+// WithDeleteObjects sets the objects annotated to be deleted on apply, and
+// returns the builder for chaining. They are deleted with the prune objects,
+// even if pruning is disabled.
+func (t *TaskQueueBuilder) WithDeleteObjects(deleteObjs object.UnstructuredSet) *TaskQueueBuilder {
+	t.deleteObjs = deleteObjs
+	return t
+}
+
+// WithLegacyObjects sets the objects previously applied with
+// `kubectl apply --prune` to adopt into the inventory, and returns the
+// builder for chaining. They are only adopted when pruning, and not during
+// a dry run.
+func (t *TaskQueueBuilder) WithLegacyObjects(legacyObjs object.UnstructuredSet) *TaskQueueBuilder {
+	t.legacyObjs = legacyObjs
+	return t
+}
+
 // Build returns the queue of tasks that have been created
 func (t *TaskQueueBuilder) Build(taskContext *taskrunner.TaskContext, o Options) *TaskQueue {
 	var tasks []taskrunner.Task
@@ -130,17 +359,34 @@ func (t *TaskQueueBuilder) Build(taskContext *taskrunner.TaskContext, o Options)
 	// Filter objects that failed earlier validation
 	applyObjs := t.Collector.FilterInvalidObjects(t.applyObjs)
 	pruneObjs := t.Collector.FilterInvalidObjects(t.pruneObjs)
+	deleteObjs := t.Collector.FilterInvalidObjects(t.deleteObjs)
+	succeededObjs := t.Collector.FilterInvalidObjects(t.succeededObjs)
 
-	// Merge applyObjs & pruneObjs and graph them together.
+	// Merge applyObjs, succeededObjs, pruneObjs & deleteObjs and graph them
+	// together.
 	// This detects implicit and explicit dependencies.
 	// Invalid dependency annotations will be treated as validation errors.
-	allObjs := make(object.UnstructuredSet, 0, len(applyObjs)+len(pruneObjs))
-	allObjs = append(allObjs, applyObjs...)
-	allObjs = append(allObjs, pruneObjs...)
+	allApplyObjs := make(object.UnstructuredSet, 0, len(applyObjs)+len(succeededObjs))
+	allApplyObjs = append(allApplyObjs, applyObjs...)
+	allApplyObjs = append(allApplyObjs, succeededObjs...)
+	allDeleteObjs := make(object.UnstructuredSet, 0, len(pruneObjs)+len(deleteObjs))
+	allDeleteObjs = append(allDeleteObjs, pruneObjs...)
+	allDeleteObjs = append(allDeleteObjs, deleteObjs...)
+	allObjs := make(object.UnstructuredSet, 0, len(allApplyObjs)+len(allDeleteObjs))
+	allObjs = append(allObjs, allApplyObjs...)
+	allObjs = append(allObjs, allDeleteObjs...)
 	g, err := graph.DependencyGraph(allObjs)
 	if err != nil {
 		t.Collector.Collect(err)
 	}
+	// Apply waves are added separately for apply and prune objects, to
+	// avoid apply objects depending on prune objects, or vice versa.
+	if err := graph.AddWaveEdges(g, allApplyObjs); err != nil {
+		t.Collector.Collect(err)
+	}
+	if err := graph.AddWaveEdges(g, allDeleteObjs); err != nil {
+		t.Collector.Collect(err)
+	}
 	// Store graph for use by DependencyFilter
 	taskContext.SetGraph(g)
 	// Sort objects into phases (apply order).
@@ -150,70 +396,106 @@ func (t *TaskQueueBuilder) Build(taskContext *taskrunner.TaskContext, o Options)
 		t.Collector.Collect(err)
 	}
 
-	// Filter objects with cycles or invalid dependency annotations
+	// Read the reconcile timeouts of the objects to wait for.
+	// Invalid timeout annotations will be treated as validation errors.
+	objTimeouts := t.objectTimeouts(applyObjs, allDeleteObjs)
+	// Invalid ready-when annotations will be treated as validation errors.
+	t.validateReadyWhen(applyObjs)
+
+	// Filter objects with cycles or invalid dependency, timeout, or
+	// ready-when annotations
 	applyObjs = t.Collector.FilterInvalidObjects(applyObjs)
 	pruneObjs = t.Collector.FilterInvalidObjects(pruneObjs)
+	deleteObjs = t.Collector.FilterInvalidObjects(deleteObjs)
+
+	// Objects to delete on apply are deleted with the prune objects, and
+	// waited on to be NotFound, even if pruning is disabled.
+	if o.Prune {
+		merged := make(object.UnstructuredSet, 0, len(pruneObjs)+len(deleteObjs))
+		merged = append(merged, pruneObjs...)
+		deleteObjs = append(merged, deleteObjs...)
+	}
+
+	if o.PreValidate && !o.Destroy && len(applyObjs) > 0 {
+		// ValidateTask fails the run before anything is changed in the
+		// cluster, if any object is invalid.
+		t.logger().V(2).Info("adding validate task", "objects", len(applyObjs))
+		tasks = append(tasks, &task.ValidateTask{
+			TaskName:          "validate-0",
+			DynamicClient:     t.DynamicClient,
+			OpenAPIGetter:     t.OpenAPIGetter,
+			InfoHelper:        t.InfoHelper,
+			Mapper:            t.Mapper,
+			Objects:           applyObjs,
+			ServerSideOptions: o.ServerSideOptions,
+			ValidationPolicy:  o.ValidationPolicy,
+		})
+	}
 
 	if !o.Destroy {
 		// InvAddTask creates the inventory and adds any objects being applied
-		klog.V(2).Infof("adding inventory add task (%d objects)", len(applyObjs))
+		t.logger().V(2).Info("adding inventory add task", "objects", len(applyObjs))
 		tasks = append(tasks, &task.InvAddTask{
-			TaskName:  "inventory-add-0",
+			TaskName:        "inventory-add-0",
+			InvClient:       t.InvClient,
+			InvInfo:         t.invInfo,
+			Objects:         applyObjs,
+			DryRun:          o.DryRunStrategy,
+			InventoryPolicy: o.InventoryPolicy,
+		})
+	}
+
+	if !o.Destroy && o.Prune && !o.DryRunStrategy.ClientOrServerDryRun() && len(t.legacyObjs) > 0 {
+		// LegacyAdoptTask adopts the objects previously applied with
+		// `kubectl apply --prune`, before they are applied or pruned.
+		t.logger().V(2).Info("adding legacy adopt task", "objects", len(t.legacyObjs))
+		tasks = append(tasks, &task.LegacyAdoptTask{
+			TaskName:  "inventory-legacy-0",
+			Client:    t.DynamicClient,
+			Mapper:    t.Mapper,
 			InvClient: t.InvClient,
 			InvInfo:   t.invInfo,
-			Objects:   applyObjs,
+			Objects:   t.legacyObjs,
 			DryRun:    o.DryRunStrategy,
 		})
 	}
 
-	if len(applyObjs) > 0 {
-		// Register actuation plan in the inventory
-		for _, id := range object.UnstructuredSetToObjMetadataSet(applyObjs) {
-			taskContext.InventoryManager().AddPendingApply(id)
-		}
-
-		// Filter idSetList down to just apply objects
-		applySets := graph.HydrateSetList(idSetList, applyObjs)
-
-		for _, applySet := range applySets {
-			tasks = append(tasks,
-				t.newApplyTask(applySet, t.ApplyFilters, t.ApplyMutators, o))
-			// dry-run skips wait tasks
-			if !o.DryRunStrategy.ClientOrServerDryRun() {
-				applyIds := object.UnstructuredSetToObjMetadataSet(applySet)
-				tasks = append(tasks,
-					t.newWaitTask(applyIds, taskrunner.AllCurrent, o.ReconcileTimeout))
-			}
-		}
+	// Register the previous status of the succeeded objects, so they are
+	// kept in the inventory and satisfy the dependencies of applied objects.
+	for _, id := range object.UnstructuredSetToObjMetadataSet(succeededObjs) {
+		taskContext.InventoryManager().SetObjectStatus(actuation.ObjectStatus{
+			ObjectReference: inventory.ObjectReferenceFromObjMetadata(id),
+			Strategy:        actuation.ActuationStrategyApply,
+			Actuation:       actuation.ActuationSucceeded,
+			Reconcile:       actuation.ReconcileSucceeded,
+		})
 	}
 
-	if o.Prune && len(pruneObjs) > 0 {
-		// Register actuation plan in the inventory
-		for _, id := range object.UnstructuredSetToObjMetadataSet(pruneObjs) {
-			taskContext.InventoryManager().AddPendingDelete(id)
-		}
-
-		// Filter idSetList down to just prune objects
-		pruneSets := graph.HydrateSetList(idSetList, pruneObjs)
-
-		// Reverse apply order to get prune order
-		graph.ReverseSetList(pruneSets)
-
-		for _, pruneSet := range pruneSets {
-			tasks = append(tasks,
-				t.newPruneTask(pruneSet, t.PruneFilters, o))
-			// dry-run skips wait tasks
-			if !o.DryRunStrategy.ClientOrServerDryRun() {
-				pruneIds := object.UnstructuredSetToObjMetadataSet(pruneSet)
-				tasks = append(tasks,
-					t.newWaitTask(pruneIds, taskrunner.AllNotFound, o.PruneTimeout))
-			}
-		}
+	// The prune phase is before the apply phase with PruneFirst, so that
+	// obsolete objects are deleted before the objects that replace them
+	// are applied. The task names are numbered in the order of the tasks.
+	// The insertion points are set between the phases, for the custom tasks
+	// inserted by the TaskQueueHooks.
+	var points []insertionPoint
+	mark := func(point InsertionPoint) {
+		points = append(points, insertionPoint{point: point, index: len(tasks)})
+	}
+	if o.PruneFirst {
+		tasks = append(tasks, t.pruneTasks(taskContext, deleteObjs, g, objTimeouts, o)...)
+		mark(AfterPrune)
+		mark(BeforeApply)
+		tasks = append(tasks, t.applyTasks(taskContext, applyObjs, idSetList, objTimeouts, o)...)
+		mark(AfterApply)
+	} else {
+		mark(BeforeApply)
+		tasks = append(tasks, t.applyTasks(taskContext, applyObjs, idSetList, objTimeouts, o)...)
+		mark(AfterApply)
+		tasks = append(tasks, t.pruneTasks(taskContext, deleteObjs, g, objTimeouts, o)...)
+		mark(AfterPrune)
 	}
 
-	// TODO: add InvSetTask when Destroy=true to retain undeleted objects
-	if !o.Destroy {
-		klog.V(2).Infoln("adding inventory set task")
+	if !o.Destroy || o.RetainInventory {
+		t.logger().V(2).Info("adding inventory set task")
 		prevInvIds, _ := t.InvClient.GetClusterObjs(t.invInfo)
 		tasks = append(tasks, &task.InvSetTask{
 			TaskName:      "inventory-set-0",
@@ -223,7 +505,7 @@ func (t *TaskQueueBuilder) Build(taskContext *taskrunner.TaskContext, o Options)
 			DryRun:        o.DryRunStrategy,
 		})
 	} else {
-		klog.V(2).Infoln("adding delete inventory task")
+		t.logger().V(2).Info("adding delete inventory task")
 		tasks = append(tasks, &task.DeleteInvTask{
 			TaskName:  "delete-inventory-0",
 			InvClient: t.InvClient,
@@ -232,7 +514,106 @@ func (t *TaskQueueBuilder) Build(taskContext *taskrunner.TaskContext, o Options)
 		})
 	}
 
-	return &TaskQueue{tasks: tasks}
+	return &TaskQueue{tasks: tasks, points: points}
+}
+
+// applyTasks returns the apply tasks of the objects, in dependency order,
+// each followed by a wait task, and registers the objects as pending apply
+// in the inventory.
+func (t *TaskQueueBuilder) applyTasks(taskContext *taskrunner.TaskContext, applyObjs object.UnstructuredSet,
+	idSetList []object.ObjMetadataSet, objTimeouts map[object.ObjMetadata]time.Duration, o Options) []taskrunner.Task {
+	if len(applyObjs) == 0 {
+		return nil
+	}
+	// Register actuation plan in the inventory
+	for _, id := range object.UnstructuredSetToObjMetadataSet(applyObjs) {
+		taskContext.InventoryManager().AddPendingApply(id)
+	}
+
+	// Filter idSetList down to just apply objects
+	applySets := graph.HydrateSetList(idSetList, applyObjs)
+
+	var tasks []taskrunner.Task
+	for _, applySet := range applySets {
+		tasks = append(tasks,
+			t.newApplyTask(applySet, t.ApplyFilters, t.ApplyMutators, o))
+		// dry-run skips wait tasks
+		if !o.DryRunStrategy.ClientOrServerDryRun() {
+			applyIds := object.UnstructuredSetToObjMetadataSet(applySet)
+			tasks = append(tasks,
+				t.newWaitTask(applyIds, taskrunner.AllCurrent, o.ReconcileTimeout, objTimeouts, o))
+		}
+	}
+	return tasks
+}
+
+// pruneTasks returns the prune tasks of the objects, in reverse dependency
+// order, each followed by a wait task, and registers the objects as pending
+// delete in the inventory.
+func (t *TaskQueueBuilder) pruneTasks(taskContext *taskrunner.TaskContext, deleteObjs object.UnstructuredSet,
+	g *graph.Graph, objTimeouts map[object.ObjMetadata]time.Duration, o Options) []taskrunner.Task {
+	if len(deleteObjs) == 0 {
+		return nil
+	}
+	// Register actuation plan in the inventory
+	for _, id := range object.UnstructuredSetToObjMetadataSet(deleteObjs) {
+		taskContext.InventoryManager().AddPendingDelete(id)
+	}
+
+	// Sort the same graph in reverse dependency order, so objects are
+	// pruned before the objects they depend on.
+	// Cycles were already collected as validation errors.
+	pruneIdSetList, _ := g.ReverseSort()
+
+	// Filter pruneIdSetList down to just prune objects
+	pruneSets := graph.HydrateReverseSetList(pruneIdSetList, deleteObjs)
+
+	var tasks []taskrunner.Task
+	for _, pruneSet := range pruneSets {
+		tasks = append(tasks,
+			t.newPruneTask(pruneSet, t.PruneFilters, o))
+		// dry-run skips wait tasks
+		if !o.DryRunStrategy.ClientOrServerDryRun() {
+			pruneIds := object.UnstructuredSetToObjMetadataSet(pruneSet)
+			waitTask := t.newWaitTask(pruneIds, taskrunner.AllNotFound, o.PruneTimeout, objTimeouts, o)
+			if o.RemoveFinalizersAfter > 0 {
+				waitTask.RemoveFinalizersAfter = o.RemoveFinalizersAfter
+				waitTask.DynamicClient = t.DynamicClient
+			}
+			tasks = append(tasks, waitTask)
+		}
+	}
+	return tasks
+}
+
+// objectTimeouts returns the timeouts from the reconcile-timeout annotations
+// of the objects. Invalid annotations are collected as validation errors.
+func (t *TaskQueueBuilder) objectTimeouts(objSets ...object.UnstructuredSet) map[object.ObjMetadata]time.Duration {
+	timeouts := make(map[object.ObjMetadata]time.Duration)
+	for _, objs := range objSets {
+		for _, obj := range objs {
+			id := object.UnstructuredToObjMetadata(obj)
+			timeout, found, err := ReadReconcileTimeout(obj)
+			if err != nil {
+				t.Collector.Collect(validation.NewError(err, id))
+				continue
+			}
+			if found {
+				timeouts[id] = timeout
+			}
+		}
+	}
+	return timeouts
+}
+
+// validateReadyWhen collects the invalid ready-when annotations of the
+// objects as validation errors.
+func (t *TaskQueueBuilder) validateReadyWhen(objs object.UnstructuredSet) {
+	for _, obj := range objs {
+		if _, _, err := taskrunner.ReadReadyWhen(obj); err != nil {
+			t.Collector.Collect(validation.NewError(err, object.UnstructuredToObjMetadata(obj)))
+		}
+	}
 }
 
 // AppendApplyTask appends a task to the task queue to apply the passed objects
@@ -240,7 +621,7 @@ func (t *TaskQueueBuilder) Build(taskContext *taskrunner.TaskContext, o Options)
 func (t *TaskQueueBuilder) newApplyTask(applyObjs object.UnstructuredSet,
 	applyFilters []filter.ValidationFilter, applyMutators []mutator.Interface, o Options) taskrunner.Task {
 	applyObjs = t.Collector.FilterInvalidObjects(applyObjs)
-	klog.V(2).Infof("adding apply task (%d objects)", len(applyObjs))
+	t.logger().V(2).Info("adding apply task", "objects", len(applyObjs))
 	task := &task.ApplyTask{
 		TaskName:          fmt.Sprintf("apply-%d", t.applyCounter),
 		Objects:           applyObjs,
@@ -252,6 +633,10 @@ func (t *TaskQueueBuilder) newApplyTask(applyObjs object.UnstructuredSet,
 		OpenAPIGetter:     t.OpenAPIGetter,
 		InfoHelper:        t.InfoHelper,
 		Mapper:            t.Mapper,
+		// Objects without status are Current as soon as they are applied.
+		StatuslessReconciled: !o.WaitForStatuslessObjects,
+		Concurrency:          o.ApplyConcurrency,
+		RetryPolicy:          o.RetryPolicy,
 	}
 	t.applyCounter++
 	return task
@@ -260,16 +645,29 @@ func (t *TaskQueueBuilder) newApplyTask(applyObjs object.UnstructuredSet,
 // AppendWaitTask appends a task to wait on the passed objects to the task queue.
 // Returns a pointer to the Builder to chain function calls.
 func (t *TaskQueueBuilder) newWaitTask(waitIds object.ObjMetadataSet, condition taskrunner.Condition,
-	waitTimeout time.Duration) taskrunner.Task {
+	waitTimeout time.Duration, objTimeouts map[object.ObjMetadata]time.Duration, o Options) *taskrunner.WaitTask {
 	waitIds = t.Collector.FilterInvalidIds(waitIds)
-	klog.V(2).Infoln("adding wait task")
+	t.logger().V(2).Info("adding wait task")
+	name := fmt.Sprintf("wait-%d", t.waitCounter)
+	if timeout, found := o.WaitTimeouts[name]; found {
+		waitTimeout = timeout
+	}
 	task := taskrunner.NewWaitTask(
-		fmt.Sprintf("wait-%d", t.waitCounter),
+		name,
 		waitIds,
 		condition,
 		waitTimeout,
 		t.Mapper,
 	)
+	task.SummaryInterval = o.WaitSummaryInterval
+	for _, id := range waitIds {
+		if timeout, found := objTimeouts[id]; found {
+			if task.ObjectTimeouts == nil {
+				task.ObjectTimeouts = make(map[object.ObjMetadata]time.Duration)
+			}
+			task.ObjectTimeouts[id] = timeout
+		}
+	}
 	t.waitCounter++
 	return task
 }
@@ -279,7 +677,7 @@ func (t *TaskQueueBuilder) newWaitTask(waitIds object.ObjMetadataSet, condition
 func (t *TaskQueueBuilder) newPruneTask(pruneObjs object.UnstructuredSet,
 	pruneFilters []filter.ValidationFilter, o Options) taskrunner.Task {
 	pruneObjs = t.Collector.FilterInvalidObjects(pruneObjs)
-	klog.V(2).Infof("adding prune task (%d objects)", len(pruneObjs))
+	t.logger().V(2).Info("adding prune task", "objects", len(pruneObjs))
 	task := &task.PruneTask{
 		TaskName:          fmt.Sprintf("prune-%d", t.pruneCounter),
 		Objects:           pruneObjs,
@@ -288,6 +686,8 @@ func (t *TaskQueueBuilder) newPruneTask(pruneObjs object.UnstructuredSet,
 		PropagationPolicy: o.PrunePropagationPolicy,
 		DryRunStrategy:    o.DryRunStrategy,
 		Destroy:           o.Destroy,
+		Tombstones:        o.InventoryTombstones,
+		RetryPolicy:       o.RetryPolicy,
 	}
 	t.pruneCounter++
 	return task
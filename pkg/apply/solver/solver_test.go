@@ -4,14 +4,17 @@
 package solver
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/prune"
 	"sigs.k8s.io/cli-utils/pkg/apply/task"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
@@ -135,6 +138,7 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 
 	testCases := map[string]struct {
 		applyObjs      []*unstructured.Unstructured
+		succeededObjs  []*unstructured.Unstructured
 		options        Options
 		expectedTasks  []taskrunner.Task
 		expectedError  error
@@ -171,7 +175,8 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 					},
 				},
 				&task.ApplyTask{
-					TaskName: "apply-0",
+					TaskName:             "apply-0",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["deployment"]),
 					},
@@ -219,7 +224,8 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 					},
 				},
 				&task.ApplyTask{
-					TaskName: "apply-0",
+					TaskName:             "apply-0",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["deployment"]),
 						testutil.Unstructured(t, resources["secret"]),
@@ -282,7 +288,8 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 					},
 				},
 				&task.ApplyTask{
-					TaskName: "apply-0",
+					TaskName:             "apply-0",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["secret"]),
 						testutil.Unstructured(t, resources["deployment"]),
@@ -349,7 +356,8 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 					DryRun: common.DryRunClient,
 				},
 				&task.ApplyTask{
-					TaskName: "apply-0",
+					TaskName:             "apply-0",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["deployment"]),
 						testutil.Unstructured(t, resources["secret"]),
@@ -408,7 +416,8 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 					DryRun: common.DryRunServer,
 				},
 				&task.ApplyTask{
-					TaskName: "apply-0",
+					TaskName:             "apply-0",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["pod"]),
 						testutil.Unstructured(t, resources["default-pod"]),
@@ -463,7 +472,8 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 					},
 				},
 				&task.ApplyTask{
-					TaskName: "apply-0",
+					TaskName:             "apply-0",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["crd"]),
 					},
@@ -477,7 +487,8 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 					Condition: taskrunner.AllCurrent,
 				},
 				&task.ApplyTask{
-					TaskName: "apply-1",
+					TaskName:             "apply-1",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["crontab1"]),
 						testutil.Unstructured(t, resources["crontab2"]),
@@ -553,14 +564,16 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 					DryRun: common.DryRunClient,
 				},
 				&task.ApplyTask{
-					TaskName: "apply-0",
+					TaskName:             "apply-0",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["crd"]),
 					},
 					DryRunStrategy: common.DryRunClient,
 				},
 				&task.ApplyTask{
-					TaskName: "apply-1",
+					TaskName:             "apply-1",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["crontab1"]),
 						testutil.Unstructured(t, resources["crontab2"]),
@@ -624,7 +637,8 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 					},
 				},
 				&task.ApplyTask{
-					TaskName: "apply-0",
+					TaskName:             "apply-0",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["namespace"]),
 					},
@@ -638,7 +652,8 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 					Condition: taskrunner.AllCurrent,
 				},
 				&task.ApplyTask{
-					TaskName: "apply-1",
+					TaskName:             "apply-1",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["secret"]),
 						testutil.Unstructured(t, resources["pod"]),
@@ -709,7 +724,8 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 					},
 				},
 				&task.ApplyTask{
-					TaskName: "apply-0",
+					TaskName:             "apply-0",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["secret"]),
 					},
@@ -723,7 +739,8 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 					Condition: taskrunner.AllCurrent,
 				},
 				&task.ApplyTask{
-					TaskName: "apply-1",
+					TaskName:             "apply-1",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["deployment"],
 							testutil.AddDependsOn(t, testutil.ToIdentifier(t, resources["secret"]))),
@@ -766,6 +783,69 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 				},
 			},
 		},
+		"succeeded dependency is not applied again": {
+			applyObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["deployment"],
+					testutil.AddDependsOn(t, testutil.ToIdentifier(t, resources["secret"]))),
+			},
+			succeededObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			expectedTasks: []taskrunner.Task{
+				&task.InvAddTask{
+					TaskName:  "inventory-add-0",
+					InvClient: &inventory.FakeClient{},
+					InvInfo:   invInfo,
+					Objects: object.UnstructuredSet{
+						testutil.Unstructured(t, resources["deployment"],
+							testutil.AddDependsOn(t, testutil.ToIdentifier(t, resources["secret"]))),
+					},
+				},
+				&task.ApplyTask{
+					TaskName:             "apply-0",
+					StatuslessReconciled: true,
+					Objects: []*unstructured.Unstructured{
+						testutil.Unstructured(t, resources["deployment"],
+							testutil.AddDependsOn(t, testutil.ToIdentifier(t, resources["secret"]))),
+					},
+					DryRunStrategy: common.DryRunNone,
+				},
+				&taskrunner.WaitTask{
+					TaskName: "wait-0",
+					Ids: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, resources["deployment"]),
+					},
+					Condition: taskrunner.AllCurrent,
+				},
+				&task.InvSetTask{
+					TaskName:  "inventory-set-0",
+					InvClient: &inventory.FakeClient{},
+					InvInfo:   invInfo,
+					PrevInventory: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, resources["deployment"]),
+						testutil.ToIdentifier(t, resources["secret"]),
+					},
+				},
+			},
+			expectedStatus: []actuation.ObjectStatus{
+				{
+					ObjectReference: inventory.ObjectReferenceFromObjMetadata(
+						testutil.ToIdentifier(t, resources["secret"]),
+					),
+					Strategy:  actuation.ActuationStrategyApply,
+					Actuation: actuation.ActuationSucceeded,
+					Reconcile: actuation.ReconcileSucceeded,
+				},
+				{
+					ObjectReference: inventory.ObjectReferenceFromObjMetadata(
+						testutil.ToIdentifier(t, resources["deployment"]),
+					),
+					Strategy:  actuation.ActuationStrategyApply,
+					Actuation: actuation.ActuationPending,
+					Reconcile: actuation.ReconcilePending,
+				},
+			},
+		},
 		"cyclic dependency returns error": {
 			applyObjs: []*unstructured.Unstructured{
 				testutil.Unstructured(t, resources["deployment"],
@@ -806,7 +886,8 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 				}
 			}
 
-			applyIds := object.UnstructuredSetToObjMetadataSet(tc.applyObjs)
+			applyIds := object.UnstructuredSetToObjMetadataSet(tc.applyObjs).
+				Union(object.UnstructuredSetToObjMetadataSet(tc.succeededObjs))
 			fakeInvClient := inventory.NewFakeClient(applyIds)
 			vCollector := &validation.Collector{}
 			tqb := TaskQueueBuilder{
@@ -818,6 +899,7 @@ func TestTaskQueueBuilder_ApplyBuild(t *testing.T) {
 			taskContext := taskrunner.NewTaskContext(nil, nil)
 			tq := tqb.WithInventory(invInfo).
 				WithApplyObjects(tc.applyObjs).
+				WithSucceededObjects(tc.succeededObjs).
 				Build(taskContext, tc.options)
 			err := vCollector.ToError()
 			if tc.expectedError != nil {
@@ -915,6 +997,46 @@ func TestTaskQueueBuilder_PruneBuild(t *testing.T) {
 				},
 			},
 		},
+		"destroy, retain inventory, inventory set task": {
+			pruneObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["default-pod"]),
+			},
+			options: Options{Prune: true, Destroy: true, RetainInventory: true},
+			expectedTasks: []taskrunner.Task{
+				&task.PruneTask{
+					TaskName: "prune-0",
+					Objects: []*unstructured.Unstructured{
+						testutil.Unstructured(t, resources["default-pod"]),
+					},
+					Destroy: true,
+				},
+				&taskrunner.WaitTask{
+					TaskName: "wait-0",
+					Ids: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, resources["default-pod"]),
+					},
+					Condition: taskrunner.AllNotFound,
+				},
+				&task.InvSetTask{
+					TaskName:  "inventory-set-0",
+					InvClient: &inventory.FakeClient{},
+					InvInfo:   invInfo,
+					PrevInventory: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, resources["default-pod"]),
+					},
+				},
+			},
+			expectedStatus: []actuation.ObjectStatus{
+				{
+					ObjectReference: inventory.ObjectReferenceFromObjMetadata(
+						testutil.ToIdentifier(t, resources["default-pod"]),
+					),
+					Strategy:  actuation.ActuationStrategyDelete,
+					Actuation: actuation.ActuationPending,
+					Reconcile: actuation.ReconcilePending,
+				},
+			},
+		},
 		"multiple resources, one prune task, one wait task": {
 			pruneObjs: []*unstructured.Unstructured{
 				testutil.Unstructured(t, resources["default-pod"]),
@@ -1230,6 +1352,87 @@ func TestTaskQueueBuilder_PruneBuild(t *testing.T) {
 				},
 			},
 		},
+		"independent resources pruned before CRD": {
+			pruneObjs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["crontab1"]),
+				testutil.Unstructured(t, resources["crd"]),
+				testutil.Unstructured(t, resources["default-pod"]),
+			},
+			options: Options{Prune: true},
+			// Objects without dependents are pruned first, not with the CRD.
+			expectedTasks: []taskrunner.Task{
+				&task.InvAddTask{
+					TaskName:  "inventory-add-0",
+					InvClient: &inventory.FakeClient{},
+					InvInfo:   invInfo,
+					Objects:   object.UnstructuredSet{},
+				},
+				&task.PruneTask{
+					TaskName: "prune-0",
+					Objects: []*unstructured.Unstructured{
+						testutil.Unstructured(t, resources["crontab1"]),
+						testutil.Unstructured(t, resources["default-pod"]),
+					},
+				},
+				&taskrunner.WaitTask{
+					TaskName: "wait-0",
+					Ids: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, resources["crontab1"]),
+						testutil.ToIdentifier(t, resources["default-pod"]),
+					},
+					Condition: taskrunner.AllNotFound,
+				},
+				&task.PruneTask{
+					TaskName: "prune-1",
+					Objects: []*unstructured.Unstructured{
+						testutil.Unstructured(t, resources["crd"]),
+					},
+				},
+				&taskrunner.WaitTask{
+					TaskName: "wait-1",
+					Ids: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, resources["crd"]),
+					},
+					Condition: taskrunner.AllNotFound,
+				},
+				&task.InvSetTask{
+					TaskName:  "inventory-set-0",
+					InvClient: &inventory.FakeClient{},
+					InvInfo:   invInfo,
+					PrevInventory: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, resources["crontab1"]),
+						testutil.ToIdentifier(t, resources["crd"]),
+						testutil.ToIdentifier(t, resources["default-pod"]),
+					},
+				},
+			},
+			expectedStatus: []actuation.ObjectStatus{
+				{
+					ObjectReference: inventory.ObjectReferenceFromObjMetadata(
+						testutil.ToIdentifier(t, resources["crontab1"]),
+					),
+					Strategy:  actuation.ActuationStrategyDelete,
+					Actuation: actuation.ActuationPending,
+					Reconcile: actuation.ReconcilePending,
+				},
+				{
+					ObjectReference: inventory.ObjectReferenceFromObjMetadata(
+						testutil.ToIdentifier(t, resources["crd"]),
+					),
+					Strategy:  actuation.ActuationStrategyDelete,
+					Actuation: actuation.ActuationPending,
+					Reconcile: actuation.ReconcilePending,
+				},
+				{
+					ObjectReference: inventory.ObjectReferenceFromObjMetadata(
+						testutil.ToIdentifier(t, resources["default-pod"]),
+					),
+					Strategy:  actuation.ActuationStrategyDelete,
+					Actuation: actuation.ActuationPending,
+					Reconcile: actuation.ReconcilePending,
+				},
+			},
+		},
 		"no wait with CRDs if it is a dryrun": {
 			pruneObjs: []*unstructured.Unstructured{
 				testutil.Unstructured(t, resources["crontab1"]),
@@ -1549,7 +1752,8 @@ func TestTaskQueueBuilder_ApplyPruneBuild(t *testing.T) {
 					},
 				},
 				&task.ApplyTask{
-					TaskName: "apply-0",
+					TaskName:             "apply-0",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["deployment"]),
 					},
@@ -1623,7 +1827,8 @@ func TestTaskQueueBuilder_ApplyPruneBuild(t *testing.T) {
 					},
 				},
 				&task.ApplyTask{
-					TaskName: "apply-0",
+					TaskName:             "apply-0",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["deployment"]),
 					},
@@ -1683,7 +1888,8 @@ func TestTaskQueueBuilder_ApplyPruneBuild(t *testing.T) {
 					},
 				},
 				&task.ApplyTask{
-					TaskName: "apply-0",
+					TaskName:             "apply-0",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["deployment"],
 							testutil.AddDependsOn(t, testutil.ToIdentifier(t, resources["secret"]))),
@@ -1763,7 +1969,8 @@ func TestTaskQueueBuilder_ApplyPruneBuild(t *testing.T) {
 					},
 				},
 				&task.ApplyTask{
-					TaskName: "apply-0",
+					TaskName:             "apply-0",
+					StatuslessReconciled: true,
 					Objects: []*unstructured.Unstructured{
 						testutil.Unstructured(t, resources["deployment"]),
 					},
@@ -1902,3 +2109,715 @@ func inventoryInfoComparer() cmp.Option {
 			x.Strategy() == y.Strategy()
 	})
 }
+
+func TestTaskQueue_Modify(t *testing.T) {
+	newTask := func(name string) taskrunner.Task {
+		return taskrunner.NewWaitTask(name, nil, taskrunner.AllCurrent, 0, nil)
+	}
+	taskNames := func(tq *TaskQueue) []string {
+		var names []string
+		for _, t := range tq.Tasks() {
+			names = append(names, t.Name())
+		}
+		return names
+	}
+
+	testCases := map[string]struct {
+		modify        func(*TaskQueue) error
+		expectedNames []string
+		expectedError string
+	}{
+		"append": {
+			modify: func(tq *TaskQueue) error {
+				tq.Append(newTask("c"))
+				return nil
+			},
+			expectedNames: []string{"a", "b", "c"},
+		},
+		"insert before": {
+			modify: func(tq *TaskQueue) error {
+				return tq.InsertBefore("b", newTask("x"), newTask("y"))
+			},
+			expectedNames: []string{"a", "x", "y", "b"},
+		},
+		"insert after": {
+			modify: func(tq *TaskQueue) error {
+				return tq.InsertAfter("b", newTask("x"))
+			},
+			expectedNames: []string{"a", "b", "x"},
+		},
+		"replace": {
+			modify: func(tq *TaskQueue) error {
+				return tq.Replace("a", newTask("x"))
+			},
+			expectedNames: []string{"x", "b"},
+		},
+		"remove": {
+			modify: func(tq *TaskQueue) error {
+				return tq.Remove("a")
+			},
+			expectedNames: []string{"b"},
+		},
+		"missing task": {
+			modify: func(tq *TaskQueue) error {
+				return tq.InsertAfter("z", newTask("x"))
+			},
+			expectedNames: []string{"a", "b"},
+			expectedError: `task not found: "z"`,
+		},
+		"insert at point": {
+			modify: func(tq *TaskQueue) error {
+				return tq.InsertAt(AfterApply, newTask("x"), newTask("y"))
+			},
+			expectedNames: []string{"a", "x", "y", "b"},
+		},
+		"insert at point twice": {
+			modify: func(tq *TaskQueue) error {
+				if err := tq.InsertAt(AfterApply, newTask("x")); err != nil {
+					return err
+				}
+				return tq.InsertAt(AfterApply, newTask("y"))
+			},
+			expectedNames: []string{"a", "x", "y", "b"},
+		},
+		"insert at point after removal": {
+			modify: func(tq *TaskQueue) error {
+				if err := tq.Remove("a"); err != nil {
+					return err
+				}
+				return tq.InsertAt(AfterApply, newTask("x"))
+			},
+			expectedNames: []string{"x", "b"},
+		},
+		"insert at point after insert before": {
+			modify: func(tq *TaskQueue) error {
+				if err := tq.InsertBefore("a", newTask("x")); err != nil {
+					return err
+				}
+				return tq.InsertAt(AfterApply, newTask("y"))
+			},
+			expectedNames: []string{"x", "a", "y", "b"},
+		},
+		"missing point": {
+			modify: func(tq *TaskQueue) error {
+				return tq.InsertAt(AfterPrune, newTask("x"))
+			},
+			expectedNames: []string{"a", "b"},
+			expectedError: "insertion point not found: AfterPrune",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tq := &TaskQueue{
+				tasks:  []taskrunner.Task{newTask("a"), newTask("b")},
+				points: []insertionPoint{{point: AfterApply, index: 1}},
+			}
+			err := tc.modify(tq)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedNames, taskNames(tq))
+		})
+	}
+}
+
+func TestTaskQueueBuilder_InsertionPoints(t *testing.T) {
+	invInfo := inventory.WrapInventoryInfoObj(newInvObject(
+		"abc-123", "default", "test"))
+
+	names := map[InsertionPoint]string{
+		BeforeApply: "before-apply",
+		AfterApply:  "after-apply",
+		AfterPrune:  "after-prune",
+	}
+
+	testCases := map[string]struct {
+		inventoryIDs  object.ObjMetadataSet
+		applyObjs     object.UnstructuredSet
+		pruneObjs     object.UnstructuredSet
+		options       Options
+		expectedNames []string
+	}{
+		"no objects": {
+			options: Options{Prune: true},
+			expectedNames: []string{
+				"inventory-add-0",
+				"before-apply",
+				"after-apply",
+				"after-prune",
+				"inventory-set-0",
+			},
+		},
+		"apply and prune": {
+			inventoryIDs: object.ObjMetadataSet{
+				testutil.ToIdentifier(t, resources["secret"]),
+			},
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["deployment"]),
+			},
+			pruneObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			options: Options{Prune: true},
+			expectedNames: []string{
+				"inventory-add-0",
+				"before-apply",
+				"apply-0",
+				"wait-0",
+				"after-apply",
+				"prune-0",
+				"wait-1",
+				"after-prune",
+				"inventory-set-0",
+			},
+		},
+		"prune first": {
+			inventoryIDs: object.ObjMetadataSet{
+				testutil.ToIdentifier(t, resources["secret"]),
+			},
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["deployment"]),
+			},
+			pruneObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			options: Options{Prune: true, PruneFirst: true},
+			expectedNames: []string{
+				"inventory-add-0",
+				"prune-0",
+				"wait-0",
+				"after-prune",
+				"before-apply",
+				"apply-0",
+				"wait-1",
+				"after-apply",
+				"inventory-set-0",
+			},
+		},
+		"destroy": {
+			inventoryIDs: object.ObjMetadataSet{
+				testutil.ToIdentifier(t, resources["secret"]),
+			},
+			pruneObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			options: Options{Prune: true, Destroy: true},
+			expectedNames: []string{
+				"before-apply",
+				"after-apply",
+				"prune-0",
+				"wait-0",
+				"after-prune",
+				"delete-inventory-0",
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tqb := TaskQueueBuilder{
+				Pruner:    pruner,
+				Mapper:    testutil.NewFakeRESTMapper(),
+				InvClient: inventory.NewFakeClient(tc.inventoryIDs),
+				Collector: &validation.Collector{},
+			}
+			taskContext := taskrunner.NewTaskContext(nil, nil)
+			tq := tqb.WithInventory(invInfo).
+				WithApplyObjects(tc.applyObjs).
+				WithPruneObjects(tc.pruneObjs).
+				Build(taskContext, tc.options)
+			for _, point := range []InsertionPoint{BeforeApply, AfterApply, AfterPrune} {
+				err := tq.InsertAt(point,
+					taskrunner.NewWaitTask(names[point], nil, taskrunner.AllCurrent, 0, nil))
+				require.NoError(t, err)
+			}
+
+			var names []string
+			for _, t := range tq.Tasks() {
+				names = append(names, t.Name())
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestTaskQueueBuilder_WaitTimeouts(t *testing.T) {
+	invInfo := inventory.WrapInventoryInfoObj(newInvObject(
+		"abc-123", "default", "test"))
+
+	withTimeout := func(manifest, timeout string) *unstructured.Unstructured {
+		obj := testutil.Unstructured(t, manifest)
+		obj.SetAnnotations(map[string]string{
+			common.ReconcileTimeoutAnnotation: timeout,
+		})
+		return obj
+	}
+	deploymentID := testutil.ToIdentifier(t, resources["deployment"])
+	secretID := testutil.ToIdentifier(t, resources["secret"])
+
+	testCases := map[string]struct {
+		applyObjs              object.UnstructuredSet
+		pruneObjs              object.UnstructuredSet
+		options                Options
+		expectedTimeouts       map[string]time.Duration
+		expectedObjectTimeouts map[string]map[object.ObjMetadata]time.Duration
+		expectedError          error
+	}{
+		"default timeouts": {
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["deployment"]),
+			},
+			pruneObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			options: Options{
+				Prune:            true,
+				ReconcileTimeout: time.Minute,
+				PruneTimeout:     2 * time.Minute,
+			},
+			expectedTimeouts: map[string]time.Duration{
+				"wait-0": time.Minute,
+				"wait-1": 2 * time.Minute,
+			},
+			expectedObjectTimeouts: map[string]map[object.ObjMetadata]time.Duration{},
+		},
+		"wait task timeouts": {
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["deployment"]),
+			},
+			pruneObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			options: Options{
+				Prune:            true,
+				ReconcileTimeout: time.Minute,
+				PruneTimeout:     2 * time.Minute,
+				WaitTimeouts: map[string]time.Duration{
+					"wait-1": 5 * time.Minute,
+				},
+			},
+			expectedTimeouts: map[string]time.Duration{
+				"wait-0": time.Minute,
+				"wait-1": 5 * time.Minute,
+			},
+			expectedObjectTimeouts: map[string]map[object.ObjMetadata]time.Duration{},
+		},
+		"object timeouts": {
+			applyObjs: object.UnstructuredSet{
+				withTimeout(resources["deployment"], "10m"),
+			},
+			pruneObjs: object.UnstructuredSet{
+				withTimeout(resources["secret"], "30s"),
+			},
+			options: Options{
+				Prune:            true,
+				ReconcileTimeout: time.Minute,
+				PruneTimeout:     time.Minute,
+			},
+			expectedTimeouts: map[string]time.Duration{
+				"wait-0": time.Minute,
+				"wait-1": time.Minute,
+			},
+			expectedObjectTimeouts: map[string]map[object.ObjMetadata]time.Duration{
+				"wait-0": {deploymentID: 10 * time.Minute},
+				"wait-1": {secretID: 30 * time.Second},
+			},
+		},
+		"invalid object timeout": {
+			applyObjs: object.UnstructuredSet{
+				withTimeout(resources["deployment"], "forever"),
+			},
+			options: Options{
+				ReconcileTimeout: time.Minute,
+			},
+			expectedError: validation.NewError(
+				object.InvalidAnnotationError{
+					Annotation: common.ReconcileTimeoutAnnotation,
+					Cause:      errors.New(`time: invalid duration "forever"`),
+				},
+				deploymentID,
+			),
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			inventoryIDs := object.UnstructuredSetToObjMetadataSet(tc.pruneObjs)
+			vCollector := &validation.Collector{}
+			tqb := TaskQueueBuilder{
+				Pruner:    pruner,
+				Mapper:    testutil.NewFakeRESTMapper(),
+				InvClient: inventory.NewFakeClient(inventoryIDs),
+				Collector: vCollector,
+			}
+			taskContext := taskrunner.NewTaskContext(nil, nil)
+			tq := tqb.WithInventory(invInfo).
+				WithApplyObjects(tc.applyObjs).
+				WithPruneObjects(tc.pruneObjs).
+				Build(taskContext, tc.options)
+			err := vCollector.ToError()
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				return
+			}
+			assert.NoError(t, err)
+
+			timeouts := make(map[string]time.Duration)
+			objectTimeouts := make(map[string]map[object.ObjMetadata]time.Duration)
+			for _, tsk := range tq.Tasks() {
+				waitTask, ok := tsk.(*taskrunner.WaitTask)
+				if !ok {
+					continue
+				}
+				timeouts[waitTask.Name()] = waitTask.Timeout
+				if waitTask.ObjectTimeouts != nil {
+					objectTimeouts[waitTask.Name()] = waitTask.ObjectTimeouts
+				}
+			}
+			assert.Equal(t, tc.expectedTimeouts, timeouts)
+			assert.Equal(t, tc.expectedObjectTimeouts, objectTimeouts)
+		})
+	}
+}
+
+func TestTaskQueueBuilder_ReadyWhenValidation(t *testing.T) {
+	invInfo := inventory.WrapInventoryInfoObj(newInvObject(
+		"abc-123", "default", "test"))
+
+	deployment := testutil.Unstructured(t, resources["deployment"])
+	deployment.SetAnnotations(map[string]string{
+		common.ReadyWhenAnnotation: "status.readyReplicas = spec.replicas",
+	})
+	pod := testutil.Unstructured(t, resources["pod"])
+	pod.SetAnnotations(map[string]string{
+		common.ReadyWhenAnnotation: "status.phase == 'Running'",
+	})
+
+	vCollector := &validation.Collector{}
+	tqb := TaskQueueBuilder{
+		Pruner:    pruner,
+		Mapper:    testutil.NewFakeRESTMapper(),
+		InvClient: inventory.NewFakeClient(nil),
+		Collector: vCollector,
+	}
+	taskContext := taskrunner.NewTaskContext(nil, nil)
+	tq := tqb.WithInventory(invInfo).
+		WithApplyObjects(object.UnstructuredSet{deployment, pod}).
+		Build(taskContext, Options{})
+
+	deploymentID := object.UnstructuredToObjMetadata(deployment)
+	podID := object.UnstructuredToObjMetadata(pod)
+	assert.Equal(t, object.ObjMetadataSet{deploymentID}, vCollector.InvalidIds)
+	var annotationErr object.InvalidAnnotationError
+	assert.ErrorAs(t, vCollector.ToError(), &annotationErr)
+	assert.Equal(t, common.ReadyWhenAnnotation, annotationErr.Annotation)
+
+	// The invalid object is not applied or waited on.
+	for _, tsk := range tq.Tasks() {
+		switch tsk.Action() {
+		case event.ApplyAction, event.WaitAction:
+			assert.Equal(t, object.ObjMetadataSet{podID}, tsk.Identifiers())
+		}
+	}
+}
+
+func TestTaskQueueBuilder_DeleteObjects(t *testing.T) {
+	invInfo := inventory.WrapInventoryInfoObj(newInvObject(
+		"abc-123", "default", "test"))
+
+	podID := testutil.ToIdentifier(t, resources["pod"])
+	deploymentID := testutil.ToIdentifier(t, resources["deployment"])
+	secretID := testutil.ToIdentifier(t, resources["secret"])
+
+	testCases := map[string]struct {
+		pruneObjs      object.UnstructuredSet
+		deleteObjs     object.UnstructuredSet
+		options        Options
+		expectedDelete object.ObjMetadataSet
+	}{
+		"delete objects without prune": {
+			pruneObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			deleteObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["deployment"]),
+			},
+			options:        Options{},
+			expectedDelete: object.ObjMetadataSet{deploymentID},
+		},
+		"delete objects with prune": {
+			pruneObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			deleteObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["deployment"]),
+			},
+			options:        Options{Prune: true},
+			expectedDelete: object.ObjMetadataSet{deploymentID, secretID},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			vCollector := &validation.Collector{}
+			tqb := TaskQueueBuilder{
+				Pruner:    pruner,
+				Mapper:    testutil.NewFakeRESTMapper(),
+				InvClient: inventory.NewFakeClient(object.UnstructuredSetToObjMetadataSet(tc.pruneObjs)),
+				Collector: vCollector,
+			}
+			taskContext := taskrunner.NewTaskContext(nil, nil)
+			tq := tqb.WithInventory(invInfo).
+				WithApplyObjects(object.UnstructuredSet{
+					testutil.Unstructured(t, resources["pod"]),
+				}).
+				WithPruneObjects(tc.pruneObjs).
+				WithDeleteObjects(tc.deleteObjs).
+				Build(taskContext, tc.options)
+			assert.NoError(t, vCollector.ToError())
+
+			var deleteIds, waitIds object.ObjMetadataSet
+			for _, tsk := range tq.Tasks() {
+				switch tsk.Action() {
+				case event.ApplyAction:
+					assert.Equal(t, object.ObjMetadataSet{podID}, tsk.Identifiers())
+				case event.PruneAction:
+					deleteIds = deleteIds.Union(tsk.Identifiers())
+				case event.WaitAction:
+					if tsk.(*taskrunner.WaitTask).Condition == taskrunner.AllNotFound {
+						waitIds = waitIds.Union(tsk.Identifiers())
+					}
+				}
+			}
+			testutil.AssertEqual(t, tc.expectedDelete, deleteIds)
+			testutil.AssertEqual(t, tc.expectedDelete, waitIds)
+		})
+	}
+}
+
+func TestTaskQueueBuilder_PruneFirst(t *testing.T) {
+	invInfo := inventory.WrapInventoryInfoObj(newInvObject(
+		"abc-123", "default", "test"))
+
+	testCases := map[string]struct {
+		applyObjs     object.UnstructuredSet
+		pruneObjs     object.UnstructuredSet
+		options       Options
+		expectedNames []string
+	}{
+		"prune before apply": {
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["deployment"]),
+			},
+			pruneObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			options: Options{Prune: true, PruneFirst: true},
+			expectedNames: []string{
+				"inventory-add-0",
+				"prune-0",
+				"wait-0",
+				"apply-0",
+				"wait-1",
+				"inventory-set-0",
+			},
+		},
+		"dependent prune objects in reverse order": {
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["deployment"]),
+			},
+			pruneObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["pod"],
+					testutil.AddDependsOn(t, testutil.ToIdentifier(t, resources["secret"]))),
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			options: Options{Prune: true, PruneFirst: true},
+			expectedNames: []string{
+				"inventory-add-0",
+				"prune-0",
+				"wait-0",
+				"prune-1",
+				"wait-1",
+				"apply-0",
+				"wait-2",
+				"inventory-set-0",
+			},
+		},
+		"dry-run skips wait tasks": {
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["deployment"]),
+			},
+			pruneObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			options: Options{Prune: true, PruneFirst: true, DryRunStrategy: common.DryRunClient},
+			expectedNames: []string{
+				"inventory-add-0",
+				"prune-0",
+				"apply-0",
+				"inventory-set-0",
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			vCollector := &validation.Collector{}
+			tqb := TaskQueueBuilder{
+				Pruner:    pruner,
+				Mapper:    testutil.NewFakeRESTMapper(),
+				InvClient: inventory.NewFakeClient(object.UnstructuredSetToObjMetadataSet(tc.pruneObjs)),
+				Collector: vCollector,
+			}
+			taskContext := taskrunner.NewTaskContext(nil, nil)
+			tq := tqb.WithInventory(invInfo).
+				WithApplyObjects(tc.applyObjs).
+				WithPruneObjects(tc.pruneObjs).
+				Build(taskContext, tc.options)
+			assert.NoError(t, vCollector.ToError())
+
+			var names []string
+			for _, tsk := range tq.Tasks() {
+				names = append(names, tsk.Name())
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestTaskQueueBuilder_PreValidate(t *testing.T) {
+	invInfo := inventory.WrapInventoryInfoObj(newInvObject(
+		"abc-123", "default", "test"))
+
+	testCases := map[string]struct {
+		applyObjs     object.UnstructuredSet
+		pruneObjs     object.UnstructuredSet
+		options       Options
+		expectedNames []string
+	}{
+		"apply": {
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["deployment"]),
+			},
+			options: Options{PreValidate: true},
+			expectedNames: []string{
+				"validate-0",
+				"inventory-add-0",
+				"apply-0",
+				"wait-0",
+				"inventory-set-0",
+			},
+		},
+		"no objects to apply": {
+			options: Options{PreValidate: true},
+			expectedNames: []string{
+				"inventory-add-0",
+				"inventory-set-0",
+			},
+		},
+		"destroy": {
+			pruneObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			options: Options{PreValidate: true, Prune: true, Destroy: true},
+			expectedNames: []string{
+				"prune-0",
+				"wait-0",
+				"delete-inventory-0",
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tqb := TaskQueueBuilder{
+				Pruner:    pruner,
+				Mapper:    testutil.NewFakeRESTMapper(),
+				InvClient: inventory.NewFakeClient(nil),
+				Collector: &validation.Collector{},
+			}
+			taskContext := taskrunner.NewTaskContext(nil, nil)
+			tq := tqb.WithInventory(invInfo).
+				WithApplyObjects(tc.applyObjs).
+				WithPruneObjects(tc.pruneObjs).
+				Build(taskContext, tc.options)
+
+			var names []string
+			for _, tsk := range tq.Tasks() {
+				names = append(names, tsk.Name())
+				if vt, ok := tsk.(*task.ValidateTask); ok {
+					assert.Equal(t, event.ValidateAction, vt.Action())
+					assert.Equal(t, object.UnstructuredSetToObjMetadataSet(tc.applyObjs), vt.Identifiers())
+				}
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestTaskQueueBuilder_LegacyObjects(t *testing.T) {
+	invInfo := inventory.WrapInventoryInfoObj(newInvObject(
+		"abc-123", "default", "test"))
+	legacyObjs := object.UnstructuredSet{
+		testutil.Unstructured(t, resources["secret"]),
+	}
+
+	testCases := map[string]struct {
+		options       Options
+		expectedNames []string
+	}{
+		"prune": {
+			options: Options{Prune: true},
+			expectedNames: []string{
+				"inventory-add-0",
+				"inventory-legacy-0",
+				"prune-0",
+				"wait-0",
+				"inventory-set-0",
+			},
+		},
+		"prune disabled": {
+			options: Options{Prune: false},
+			expectedNames: []string{
+				"inventory-add-0",
+				"inventory-set-0",
+			},
+		},
+		"dry run": {
+			options: Options{Prune: true, DryRunStrategy: common.DryRunClient},
+			expectedNames: []string{
+				"inventory-add-0",
+				"prune-0",
+				"inventory-set-0",
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tqb := TaskQueueBuilder{
+				Pruner:    pruner,
+				Mapper:    testutil.NewFakeRESTMapper(),
+				InvClient: inventory.NewFakeClient(nil),
+				Collector: &validation.Collector{},
+			}
+			taskContext := taskrunner.NewTaskContext(nil, nil)
+			tq := tqb.WithInventory(invInfo).
+				WithPruneObjects(legacyObjs).
+				WithLegacyObjects(legacyObjs).
+				Build(taskContext, tc.options)
+
+			var names []string
+			for _, tsk := range tq.Tasks() {
+				names = append(names, tsk.Name())
+				if lt, ok := tsk.(*task.LegacyAdoptTask); ok {
+					assert.Equal(t, legacyObjs, lt.Objects)
+				}
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
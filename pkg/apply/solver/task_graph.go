@@ -0,0 +1,167 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package solver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/graph"
+)
+
+// TaskGraph describes the tasks of a TaskQueue, in order, with the objects
+// of each task and the dependencies between the objects. The dependencies
+// explain why objects were split into separate apply, prune, and wait tasks.
+type TaskGraph struct {
+	Tasks        []TaskNode       `json:"tasks"`
+	Dependencies []TaskDependency `json:"dependencies"`
+}
+
+// TaskNode describes a single task.
+type TaskNode struct {
+	Name    string                      `json:"name"`
+	Action  string                      `json:"action"`
+	Objects []actuation.ObjectReference `json:"objects"`
+}
+
+// TaskDependency describes an object that depends on another object. The
+// tasks are the apply, prune, or delete tasks of the objects. The task of an
+// object that is not actuated, like an object that succeeded in a previous
+// run, is empty.
+type TaskDependency struct {
+	Object         actuation.ObjectReference `json:"object"`
+	Task           string                    `json:"task,omitempty"`
+	Dependency     actuation.ObjectReference `json:"dependency"`
+	DependencyTask string                    `json:"dependencyTask,omitempty"`
+}
+
+// NewTaskGraph returns the TaskGraph of the tasks in the queue, with the
+// dependencies from the object dependency graph built by the
+// TaskQueueBuilder (see TaskContext.Graph).
+func NewTaskGraph(tq *TaskQueue, g *graph.Graph) *TaskGraph {
+	tg := &TaskGraph{
+		Tasks:        []TaskNode{},
+		Dependencies: []TaskDependency{},
+	}
+	// actuationTasks are the names of the tasks that actuate each object.
+	actuationTasks := make(map[object.ObjMetadata]string)
+	var ids object.ObjMetadataSet
+	for _, t := range tq.tasks {
+		node := TaskNode{
+			Name:    t.Name(),
+			Action:  t.Action().String(),
+			Objects: []actuation.ObjectReference{},
+		}
+		for _, id := range t.Identifiers() {
+			node.Objects = append(node.Objects, inventory.ObjectReferenceFromObjMetadata(id))
+			if isActuation(t.Action()) {
+				actuationTasks[id] = t.Name()
+				ids = append(ids, id)
+			}
+		}
+		tg.Tasks = append(tg.Tasks, node)
+	}
+	if g == nil {
+		return tg
+	}
+	var edges []graph.Edge
+	for _, id := range ids {
+		for _, dep := range g.Dependencies(id) {
+			edges = append(edges, graph.Edge{From: id, To: dep})
+		}
+	}
+	sort.Sort(graph.SortableEdges(edges))
+	for _, edge := range edges {
+		tg.Dependencies = append(tg.Dependencies, TaskDependency{
+			Object:         inventory.ObjectReferenceFromObjMetadata(edge.From),
+			Task:           actuationTasks[edge.From],
+			Dependency:     inventory.ObjectReferenceFromObjMetadata(edge.To),
+			DependencyTask: actuationTasks[edge.To],
+		})
+	}
+	return tg
+}
+
+// isActuation returns true if the tasks of the action change objects.
+func isActuation(action event.ResourceAction) bool {
+	switch action {
+	case event.ApplyAction, event.PruneAction, event.DeleteAction:
+		return true
+	default:
+		return false
+	}
+}
+
+// WriteJSON writes the TaskGraph in indented JSON format.
+func (tg *TaskGraph) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(tg)
+}
+
+// WriteDOT writes the TaskGraph in the DOT format of Graphviz. Each task is
+// a cluster of its objects, labeled with its position in the queue.
+// Dependencies are edges from the object to its dependency, between the
+// objects in the apply, prune, and delete tasks.
+func (tg *TaskGraph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph tasks {\n")
+	b.WriteString("\tnode [shape=box];\n")
+	// nodeIDs are the IDs of the object nodes used by the dependency edges.
+	nodeIDs := make(map[actuation.ObjectReference]string)
+	for i, t := range tg.Tasks {
+		label := fmt.Sprintf("%d. %s (%s)", i+1, t.Name, t.Action)
+		if len(t.Objects) == 0 || t.Action == event.InventoryAction.String() {
+			fmt.Fprintf(&b, "\t%q [label=%q, shape=ellipse];\n", t.Name, label)
+			continue
+		}
+		fmt.Fprintf(&b, "\tsubgraph %q {\n", fmt.Sprintf("cluster_%d", i))
+		fmt.Fprintf(&b, "\t\tlabel=%q;\n", label)
+		for _, ref := range t.Objects {
+			id := t.Name + "/" + objectLabel(ref)
+			if _, found := nodeIDs[ref]; !found && t.Action != event.WaitAction.String() &&
+				t.Action != event.ValidateAction.String() {
+				nodeIDs[ref] = id
+			}
+			fmt.Fprintf(&b, "\t\t%q [label=%q];\n", id, objectLabel(ref))
+		}
+		b.WriteString("\t}\n")
+	}
+	for _, dep := range tg.Dependencies {
+		from, found := nodeIDs[dep.Object]
+		if !found {
+			continue
+		}
+		to, found := nodeIDs[dep.Dependency]
+		if !found {
+			// The dependency is not actuated, so it has no node.
+			to = objectLabel(dep.Dependency)
+			fmt.Fprintf(&b, "\t%q [style=dashed];\n", to)
+		}
+		fmt.Fprintf(&b, "\t%q -> %q;\n", from, to)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// objectLabel returns the object reference formatted like
+// "Kind.group namespace/name".
+func objectLabel(ref actuation.ObjectReference) string {
+	kind := ref.Kind
+	if ref.Group != "" {
+		kind += "." + ref.Group
+	}
+	if ref.Namespace == "" {
+		return fmt.Sprintf("%s %s", kind, ref.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", kind, ref.Namespace, ref.Name)
+}
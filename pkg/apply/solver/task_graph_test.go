@@ -0,0 +1,106 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package solver
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/apply/task"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/graph"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestTaskGraph(t *testing.T) {
+	namespace := testutil.Unstructured(t, resources["namespace"])
+	deployment := testutil.Unstructured(t, resources["deployment"])
+	namespaceID := object.UnstructuredToObjMetadata(namespace)
+	deploymentID := object.UnstructuredToObjMetadata(deployment)
+	// The secret succeeded in a previous run, so it has no task.
+	secretID := testutil.ToIdentifier(t, resources["secret"])
+
+	tq := &TaskQueue{}
+	tq.Append(
+		&task.InvAddTask{
+			TaskName: "inventory-add-0",
+			Objects:  object.UnstructuredSet{namespace, deployment},
+		},
+		&task.ApplyTask{
+			TaskName: "apply-0",
+			Objects:  object.UnstructuredSet{namespace},
+		},
+		taskrunner.NewWaitTask("wait-0", object.ObjMetadataSet{namespaceID},
+			taskrunner.AllCurrent, 1*time.Minute, testutil.NewFakeRESTMapper()),
+		&task.ApplyTask{
+			TaskName: "apply-1",
+			Objects:  object.UnstructuredSet{deployment},
+		},
+		&task.InvSetTask{
+			TaskName: "inventory-set-0",
+		},
+	)
+	g := graph.New()
+	g.AddVertex(namespaceID)
+	g.AddVertex(deploymentID)
+	g.AddVertex(secretID)
+	g.AddEdge(deploymentID, namespaceID)
+	g.AddEdge(deploymentID, secretID)
+
+	namespaceRef := actuation.ObjectReference{Kind: "Namespace", Name: "test-namespace"}
+	deploymentRef := actuation.ObjectReference{Group: "apps", Kind: "Deployment", Namespace: "test-namespace", Name: "foo"}
+	secretRef := actuation.ObjectReference{Kind: "Secret", Namespace: "test-namespace", Name: "secret"}
+
+	tg := NewTaskGraph(tq, g)
+	assert.Equal(t, &TaskGraph{
+		Tasks: []TaskNode{
+			{Name: "inventory-add-0", Action: "Inventory", Objects: []actuation.ObjectReference{namespaceRef, deploymentRef}},
+			{Name: "apply-0", Action: "Apply", Objects: []actuation.ObjectReference{namespaceRef}},
+			{Name: "wait-0", Action: "Wait", Objects: []actuation.ObjectReference{namespaceRef}},
+			{Name: "apply-1", Action: "Apply", Objects: []actuation.ObjectReference{deploymentRef}},
+			{Name: "inventory-set-0", Action: "Inventory", Objects: []actuation.ObjectReference{}},
+		},
+		Dependencies: []TaskDependency{
+			{Object: deploymentRef, Task: "apply-1", Dependency: namespaceRef, DependencyTask: "apply-0"},
+			{Object: deploymentRef, Task: "apply-1", Dependency: secretRef},
+		},
+	}, tg)
+
+	var dot bytes.Buffer
+	require.NoError(t, tg.WriteDOT(&dot))
+	assert.Equal(t, `digraph tasks {
+	node [shape=box];
+	"inventory-add-0" [label="1. inventory-add-0 (Inventory)", shape=ellipse];
+	subgraph "cluster_1" {
+		label="2. apply-0 (Apply)";
+		"apply-0/Namespace test-namespace" [label="Namespace test-namespace"];
+	}
+	subgraph "cluster_2" {
+		label="3. wait-0 (Wait)";
+		"wait-0/Namespace test-namespace" [label="Namespace test-namespace"];
+	}
+	subgraph "cluster_3" {
+		label="4. apply-1 (Apply)";
+		"apply-1/Deployment.apps test-namespace/foo" [label="Deployment.apps test-namespace/foo"];
+	}
+	"inventory-set-0" [label="5. inventory-set-0 (Inventory)", shape=ellipse];
+	"apply-1/Deployment.apps test-namespace/foo" -> "apply-0/Namespace test-namespace";
+	"Secret test-namespace/secret" [style=dashed];
+	"apply-1/Deployment.apps test-namespace/foo" -> "Secret test-namespace/secret";
+}
+`, dot.String())
+
+	var out bytes.Buffer
+	require.NoError(t, tg.WriteJSON(&out))
+	var decoded TaskGraph
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, tg, &decoded)
+	assert.Contains(t, out.String(), `"dependencyTask": "apply-0"`)
+}
@@ -0,0 +1,39 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package solver
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// ReadReconcileTimeout reads the reconcile-timeout annotation and parses the
+// timeout. Returns false if the annotation is not present.
+func ReadReconcileTimeout(obj *unstructured.Unstructured) (time.Duration, bool, error) {
+	if obj == nil {
+		return 0, false, nil
+	}
+	value, found := obj.GetAnnotations()[common.ReconcileTimeoutAnnotation]
+	if !found {
+		return 0, false, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, false, object.InvalidAnnotationError{
+			Annotation: common.ReconcileTimeoutAnnotation,
+			Cause:      err,
+		}
+	}
+	if timeout < 0 {
+		return 0, false, object.InvalidAnnotationError{
+			Annotation: common.ReconcileTimeoutAnnotation,
+			Cause:      fmt.Errorf("must not be negative: %q", value),
+		}
+	}
+	return timeout, true, nil
+}
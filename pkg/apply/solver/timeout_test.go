@@ -0,0 +1,66 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package solver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestReadReconcileTimeout(t *testing.T) {
+	testCases := map[string]struct {
+		annotations     map[string]string
+		expectedTimeout time.Duration
+		expectedFound   bool
+		expectedError   bool
+	}{
+		"no annotation": {},
+		"minutes": {
+			annotations: map[string]string{
+				common.ReconcileTimeoutAnnotation: "10m",
+			},
+			expectedTimeout: 10 * time.Minute,
+			expectedFound:   true,
+		},
+		"zero disables the timeout": {
+			annotations: map[string]string{
+				common.ReconcileTimeoutAnnotation: "0s",
+			},
+			expectedFound: true,
+		},
+		"invalid duration returns error": {
+			annotations: map[string]string{
+				common.ReconcileTimeoutAnnotation: "10",
+			},
+			expectedError: true,
+		},
+		"negative duration returns error": {
+			annotations: map[string]string{
+				common.ReconcileTimeoutAnnotation: "-1m",
+			},
+			expectedError: true,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			obj := testutil.Unstructured(t, resources["deployment"])
+			obj.SetAnnotations(tc.annotations)
+			timeout, found, err := ReadReconcileTimeout(obj)
+			if tc.expectedError {
+				var annotationErr object.InvalidAnnotationError
+				assert.ErrorAs(t, err, &annotationErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedTimeout, timeout)
+			assert.Equal(t, tc.expectedFound, found)
+		})
+	}
+}
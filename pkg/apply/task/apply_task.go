@@ -8,18 +8,27 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 	"k8s.io/kubectl/pkg/cmd/apply"
 	cmddelete "k8s.io/kubectl/pkg/cmd/delete"
+	"sigs.k8s.io/cli-utils/pkg/apply/cache"
 	applyerror "sigs.k8s.io/cli-utils/pkg/apply/error"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/filter"
@@ -27,6 +36,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/apply/mutator"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	"sigs.k8s.io/cli-utils/pkg/object"
 )
 
@@ -57,6 +67,17 @@ type ApplyTask struct {
 	Mutators          []mutator.Interface
 	DryRunStrategy    common.DryRunStrategy
 	ServerSideOptions common.ServerSideOptions
+	// StatuslessReconciled marks objects without status as Current in the
+	// ResourceCache after a successful apply, so that they do not need to
+	// be watched while waiting for reconciliation.
+	StatuslessReconciled bool
+	// Concurrency is the maximum number of objects applied at the same
+	// time. Objects in the same task have no dependencies on each other.
+	// Defaults to 1, applying objects one at a time.
+	Concurrency int
+	// RetryPolicy defines how applies that fail with a transient error are
+	// retried. A RetryEvent is sent before each retry.
+	RetryPolicy common.RetryPolicy
 }
 
 // applyOptionsFactoryFunc is a factory function for creating a new
@@ -83,102 +104,407 @@ func (a *ApplyTask) Identifiers() object.ObjMetadataSet {
 // after the Run function has completed. This information is then added
 // to the taskContext. The generation is increased every time
 // the desired state of a resource is changed.
+//
+// Objects are filtered and mutated sequentially, then applied by up to
+// Concurrency workers. Events and results are reported in object order.
 func (a *ApplyTask) Start(taskContext *taskrunner.TaskContext) {
 	go func() {
-		// TODO: pipe Context through TaskContext
-		ctx := context.TODO()
+		logger := taskContext.Logger().WithValues("task", a.Name())
+		ctx := klog.NewContext(taskContext.Context(), logger)
 		objects := a.Objects
-		klog.V(2).Infof("apply task starting (name: %q, objects: %d)",
-			a.Name(), len(objects))
-		for _, obj := range objects {
-			// Set the client and mapping fields on the provided
-			// info so they can be applied to the cluster.
-			info, err := a.InfoHelper.BuildInfo(obj)
-			// BuildInfo strips path annotations.
-			// Use modified object for filters, mutations, and events.
-			obj = info.Object.(*unstructured.Unstructured)
-			id := object.UnstructuredToObjMetadata(obj)
-			if err != nil {
-				err = applyerror.NewUnknownTypeError(err)
-				if klog.V(4).Enabled() {
-					// only log event emitted errors if the verbosity > 4
-					klog.Errorf("apply task errored (object: %s): unable to convert obj to info: %v", id, err)
+		logger.V(2).Info("apply task starting", "objects", len(objects), "concurrency", a.concurrency())
+		results := make([]*applyResult, len(objects))
+		mapperReset := false
+		for i, obj := range objects {
+			results[i] = a.prepareObject(ctx, obj, &mapperReset)
+		}
+		a.applyObjects(ctx, taskContext, results)
+		for _, result := range results {
+			<-result.done
+			for _, e := range result.events {
+				if taskContext.TimingEnabled() {
+					e = e.WithTiming(event.Timing{Start: result.start, End: result.end})
 				}
-				taskContext.SendEvent(a.createApplyFailedEvent(id, err))
-				taskContext.InventoryManager().AddFailedApply(id)
-				continue
+				taskContext.SendEvent(e)
 			}
+			a.recordResult(taskContext, result)
+		}
+		logger.V(2).Info("apply task completing")
+		a.sendTaskResult(taskContext)
+	}()
+}
 
-			// Check filters to see if we're prevented from applying.
-			var filterErr error
-			for _, applyFilter := range a.Filters {
-				klog.V(6).Infof("apply filter evaluating (filter: %s, object: %s)", applyFilter.Name(), id)
-				filterErr = applyFilter.Filter(obj)
-				if filterErr != nil {
-					var fatalErr *filter.FatalError
-					if errors.As(filterErr, &fatalErr) {
-						if klog.V(4).Enabled() {
-							// only log event emitted errors if the verbosity > 4
-							klog.Errorf("apply filter errored (filter: %s, object: %s): %v", applyFilter.Name(), id, fatalErr.Err)
-						}
-						taskContext.SendEvent(a.createApplyFailedEvent(id, err))
-						taskContext.InventoryManager().AddFailedApply(id)
-						break
-					}
-					klog.V(4).Infof("apply filtered (filter: %s, object: %s): %v", applyFilter.Name(), id, filterErr)
-					taskContext.SendEvent(a.createApplySkippedEvent(id, obj, filterErr))
-					taskContext.InventoryManager().AddSkippedApply(id)
-					break
-				}
-			}
-			if filterErr != nil {
-				continue
-			}
+// applyResult tracks the outcome of applying a single object.
+type applyResult struct {
+	id   object.ObjMetadata
+	obj  *unstructured.Unstructured
+	info *resource.Info
+	// createdID is the id of the object created for an object with a
+	// generated name.
+	createdID object.ObjMetadata
+	// events are the events to send for the object, in order.
+	events []event.Event
+	// skipped is true if the object was skipped by a filter.
+	skipped bool
+	// failed is true if the object could not be applied.
+	failed bool
+	// done is closed when the object has been processed.
+	done chan struct{}
+	// start and end are when the processing of the object started and
+	// ended. For applied objects, start is when the apply started.
+	start time.Time
+	end   time.Time
+}
+
+// finish marks the result as processed.
+func (r *applyResult) finish() *applyResult {
+	r.end = time.Now()
+	close(r.done)
+	return r
+}
 
-			// Execute mutators, if any apply
-			err = a.mutate(ctx, obj)
-			if err != nil {
-				if klog.V(4).Enabled() {
+// concurrency returns the number of objects to apply concurrently.
+func (a *ApplyTask) concurrency() int {
+	if a.Concurrency < 1 {
+		return 1
+	}
+	return a.Concurrency
+}
+
+// prepareObject builds the info for the object, and runs the filters and
+// mutators. If the object should not be applied, the returned result is
+// already done.
+func (a *ApplyTask) prepareObject(ctx context.Context, obj *unstructured.Unstructured, mapperReset *bool) *applyResult {
+	logger := klog.FromContext(ctx)
+	start := time.Now()
+	// Set the client and mapping fields on the provided
+	// info so they can be applied to the cluster.
+	info, err := a.InfoHelper.BuildInfo(obj)
+	if err != nil && meta.IsNoMatchError(err) && !*mapperReset {
+		// The type may have been registered by a CRD that became
+		// established after the RESTMapper was last reset. Reset it
+		// (at most once per task) and try again.
+		logger.V(3).Info("resetting RESTMapper", "reason", err)
+		meta.MaybeResetRESTMapper(a.Mapper)
+		*mapperReset = true
+		info, err = a.InfoHelper.BuildInfo(obj)
+	}
+	// BuildInfo strips path annotations.
+	// Use modified object for filters, mutations, and events.
+	obj = info.Object.(*unstructured.Unstructured)
+	id := object.UnstructuredToObjMetadata(obj)
+	result := &applyResult{
+		id:    id,
+		obj:   obj,
+		info:  info,
+		done:  make(chan struct{}),
+		start: start,
+	}
+	if err != nil {
+		err = applyerror.NewUnknownTypeError(err)
+		if logger.V(4).Enabled() {
+			// only log event emitted errors if the verbosity > 4
+			logger.Error(err, "apply task errored: unable to convert obj to info", "object", id)
+		}
+		result.events = append(result.events, a.createApplyFailedEvent(id, err))
+		result.failed = true
+		return result.finish()
+	}
+
+	// Check filters to see if we're prevented from applying.
+	for _, applyFilter := range a.Filters {
+		logger.V(6).Info("apply filter evaluating", "filter", applyFilter.Name(), "object", id)
+		filterErr := filter.Evaluate(ctx, applyFilter, obj)
+		if filterErr != nil {
+			var fatalErr *filter.FatalError
+			if errors.As(filterErr, &fatalErr) {
+				if logger.V(4).Enabled() {
 					// only log event emitted errors if the verbosity > 4
-					klog.Errorf("apply mutation errored (object: %s): %v", id, err)
+					logger.Error(fatalErr.Err, "apply filter errored", "filter", applyFilter.Name(), "object", id)
 				}
-				taskContext.SendEvent(a.createApplyFailedEvent(id, err))
-				taskContext.InventoryManager().AddFailedApply(id)
-				continue
+				result.events = append(result.events, a.createApplyFailedEvent(id, fatalErr.Err))
+				result.failed = true
+				return result.finish()
 			}
+			logger.V(4).Info("apply filtered", "filter", applyFilter.Name(), "object", id, "reason", filterErr)
+			result.events = append(result.events, a.createApplySkippedEvent(id, obj, filterErr))
+			result.skipped = true
+			return result.finish()
+		}
+	}
 
-			// Create a new instance of the applyOptions interface and use it
-			// to apply the objects.
-			ao := applyOptionsFactoryFunc(a.Name(), taskContext.EventChannel(),
-				a.ServerSideOptions, a.DryRunStrategy, a.DynamicClient, a.OpenAPIGetter)
-			ao.SetObjects([]*resource.Info{info})
-			klog.V(5).Infof("applying object: %v", id)
-			err = ao.Run()
-			if err != nil && a.ServerSideOptions.ServerSideApply && isAPIService(obj) && isStreamError(err) {
-				// Server-side Apply doesn't work with APIService before k8s 1.21
-				// https://github.com/kubernetes/kubernetes/issues/89264
-				// Thus APIService is handled specially using client-side apply.
-				err = a.clientSideApply(info, taskContext.EventChannel())
+	// Execute mutators, if any apply
+	err = a.mutate(ctx, obj)
+	var skipErr *mutator.SkipError
+	if errors.As(err, &skipErr) {
+		logger.V(4).Info("apply skipped by mutator", "object", id, "reason", skipErr.Err)
+		result.events = append(result.events, a.createApplySkippedEvent(id, obj, skipErr.Err))
+		result.skipped = true
+		return result.finish()
+	}
+	if err != nil {
+		if logger.V(4).Enabled() {
+			// only log event emitted errors if the verbosity > 4
+			logger.Error(err, "apply mutation errored", "object", id)
+		}
+		result.events = append(result.events, a.createApplyFailedEvent(id, err))
+		result.failed = true
+		return result.finish()
+	}
+	return result
+}
+
+// applyObjects applies the pending objects using a pool of workers.
+// Returns immediately. Each result is done once its object is applied.
+// If the task is cancelled, the objects not yet applied are skipped.
+func (a *ApplyTask) applyObjects(ctx context.Context, taskContext *taskrunner.TaskContext, results []*applyResult) {
+	queue := make(chan *applyResult)
+	for i := 0; i < a.concurrency(); i++ {
+		go func() {
+			for result := range queue {
+				a.applyObject(ctx, result)
+				result.finish()
 			}
-			if err != nil {
-				err = applyerror.NewApplyRunError(err)
-				if klog.V(4).Enabled() {
-					// only log event emitted errors if the verbosity > 4
-					klog.Errorf("apply errored (object: %s): %v", id, err)
-				}
-				taskContext.SendEvent(a.createApplyFailedEvent(id, err))
-				taskContext.InventoryManager().AddFailedApply(id)
-			} else if info.Object != nil {
-				acc, err := meta.Accessor(info.Object)
-				if err == nil {
-					uid := acc.GetUID()
-					gen := acc.GetGeneration()
-					taskContext.InventoryManager().AddSuccessfulApply(id, uid, gen)
-				}
+		}()
+	}
+	go func() {
+		defer close(queue)
+		for _, result := range results {
+			if result.failed || result.skipped {
+				continue
 			}
+			if err := taskContext.Cancelled(); err != nil {
+				result.start = time.Now()
+				result.events = append(result.events, a.createApplySkippedEvent(result.id, result.obj, err))
+				result.skipped = true
+				result.finish()
+				continue
+			}
+			queue <- result
+		}
+	}()
+}
+
+// applyObject applies the object to the cluster, collecting the events
+// for the object in the result.
+func (a *ApplyTask) applyObject(ctx context.Context, result *applyResult) {
+	logger := klog.FromContext(ctx)
+	result.start = time.Now()
+	eventChannel := make(chan event.Event)
+	collected := make(chan struct{})
+	go func() {
+		defer close(collected)
+		for e := range eventChannel {
+			result.events = append(result.events, e)
 		}
-		a.sendTaskResult(taskContext)
 	}()
+
+	// In Warn mode, the server reports the unknown and duplicate fields as
+	// warnings, which are added to the apply event of the object.
+	var warnings *warningRecorder
+	if a.ServerSideOptions.FieldValidation == metav1.FieldValidationWarn && result.info.Client != nil {
+		warnings = &warningRecorder{}
+		result.info.Client = resource.NewClientWithOptions(result.info.Client, func(r *rest.Request) {
+			r.WarningHandler(warnings)
+		})
+	}
+
+	fellBack := false
+	var forced []event.FieldConflict
+	// Creates of objects with a generated name are not idempotent, so they
+	// are only retried if the object was not created.
+	retry := a.RetryPolicy.Do
+	if object.HasGeneratedName(result.obj) {
+		retry = a.RetryPolicy.DoCreate
+	}
+	err := retry(ctx, func() error {
+		warnings.reset()
+		fellBack = false
+		forced = nil
+		// Objects with a generated name can not be applied, only created.
+		if object.HasGeneratedName(result.obj) {
+			return a.createObject(ctx, result, eventChannel)
+		}
+		// Create a new instance of the applyOptions interface and use it
+		// to apply the objects.
+		ao := applyOptionsFactoryFunc(a.Name(), eventChannel,
+			a.ServerSideOptions, a.DryRunStrategy, a.DynamicClient, a.OpenAPIGetter)
+		ao.SetObjects([]*resource.Info{result.info})
+		logger.V(5).Info("applying object", "object", result.id)
+		err := ao.Run()
+		if err != nil && a.ServerSideOptions.ServerSideApply && isAPIService(result.obj) && isStreamError(err) {
+			// Server-side Apply doesn't work with APIService before k8s 1.21
+			// https://github.com/kubernetes/kubernetes/issues/89264
+			// Thus APIService is handled specially using client-side apply.
+			err = a.clientSideApply(result.info, eventChannel)
+		}
+		if err != nil && !a.ServerSideOptions.ServerSideApply && isAnnotationTooLongError(err) {
+			// The last-applied-configuration annotation of a large object
+			// exceeds the size limit of the annotations. Server-side apply
+			// does not need the annotation, so the object is applied with
+			// it instead of failing.
+			logger.V(4).Info("annotations too long, falling back to server-side apply", "object", result.id)
+			fellBack = true
+			err = a.serverSideApply(result.info, eventChannel)
+		}
+		if err != nil && a.ServerSideOptions.ServerSideApply && !a.ServerSideOptions.ForceConflicts {
+			if conflicts := fieldConflicts(err); a.canForceConflicts(conflicts) {
+				logger.V(4).Info("forcing conflicts with allowed field managers", "object", result.id, "conflicts", conflicts)
+				forced = conflicts
+				err = a.forceConflicts(result.info, eventChannel)
+			}
+		}
+		return err
+	}, func(retry int, backoff time.Duration, err error) {
+		logger.V(4).Info("apply retrying", "object", result.id, "retry", retry, "backoff", backoff, "reason", err)
+		eventChannel <- a.createApplyRetryEvent(result.id, retry, backoff, err)
+	})
+	close(eventChannel)
+	<-collected
+
+	// The recorded warnings are copied, so that the warnings of the
+	// fallbacks can be added.
+	applyWarnings := append([]string{}, warnings.list()...)
+	if fellBack {
+		applyWarnings = append(applyWarnings, serverSideFallbackWarning)
+	}
+	for _, c := range forced {
+		applyWarnings = append(applyWarnings,
+			fmt.Sprintf("forced conflict with field manager %q on field %s", c.Manager, c.Field))
+	}
+	if err == nil && len(applyWarnings) > 0 {
+		addApplyWarnings(result.events, applyWarnings)
+	}
+	if err != nil {
+		err = applyerror.NewApplyRunError(err)
+		if logger.V(4).Enabled() {
+			// only log event emitted errors if the verbosity > 4
+			logger.Error(err, "apply errored", "object", result.id)
+		}
+		result.events = append(result.events, a.createApplyFailedEvent(result.id, err))
+		result.failed = true
+	}
+}
+
+// addApplyWarnings adds the warnings to the last successful apply event.
+func addApplyWarnings(events []event.Event, warnings []string) {
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Type == event.ApplyType && events[i].ApplyEvent.Status == event.ApplySuccessful {
+			events[i].ApplyEvent.Warnings = warnings
+			return
+		}
+	}
+}
+
+// warningRecorder records the warnings sent by the server with the
+// responses to the requests of one object. A nil warningRecorder records
+// nothing.
+type warningRecorder struct {
+	mu       sync.Mutex
+	warnings []string
+}
+
+// HandleWarningHeader implements rest.WarningHandler.
+func (w *warningRecorder) HandleWarningHeader(code int, _ string, message string) {
+	if code != 299 || message == "" {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.warnings = append(w.warnings, message)
+}
+
+// reset removes the recorded warnings, before a retry.
+func (w *warningRecorder) reset() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.warnings = nil
+}
+
+// list returns the recorded warnings.
+func (w *warningRecorder) list() []string {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.warnings
+}
+
+// createObject creates an object with a generated name, and records the id
+// of the created object in the result. With the client dry-run strategy,
+// nothing is created.
+func (a *ApplyTask) createObject(ctx context.Context, result *applyResult, eventChannel chan<- event.Event) error {
+	created := result.obj
+	if !a.DryRunStrategy.ClientDryRun() {
+		opts := metav1.CreateOptions{
+			FieldManager:    a.ServerSideOptions.FieldManager,
+			FieldValidation: a.ServerSideOptions.FieldValidation,
+		}
+		if a.DryRunStrategy.ServerDryRun() {
+			opts.DryRun = []string{metav1.DryRunAll}
+		}
+		gvk := result.obj.GroupVersionKind()
+		mapping, err := a.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return err
+		}
+		klog.FromContext(ctx).V(5).Info("creating object", "object", result.id)
+		created, err = a.DynamicClient.Resource(mapping.Resource).
+			Namespace(result.id.Namespace).Create(ctx, result.obj, opts)
+		if err != nil {
+			return err
+		}
+		result.info.Object = created
+		result.createdID = object.UnstructuredToObjMetadata(created)
+	}
+	id := result.id
+	if result.createdID != object.NilObjMetadata {
+		id = result.createdID
+	}
+	eventChannel <- event.Event{
+		Type: event.ApplyType,
+		ApplyEvent: event.ApplyEvent{
+			GroupName:  a.Name(),
+			Identifier: id,
+			Status:     event.ApplySuccessful,
+			Operation:  event.ApplyCreated,
+			Resource:   created,
+		},
+	}
+	return nil
+}
+
+// recordResult registers the outcome of applying the object in the
+// inventory. Objects with a generated name are registered with the id of
+// the created object.
+func (a *ApplyTask) recordResult(taskContext *taskrunner.TaskContext, result *applyResult) {
+	id := result.id
+	if result.createdID != object.NilObjMetadata {
+		taskContext.AddGeneratedObject(id, result.createdID)
+		id = result.createdID
+	}
+	switch {
+	case result.skipped:
+		taskContext.InventoryManager().AddSkippedApply(id)
+	case result.failed:
+		taskContext.InventoryManager().AddFailedApply(id)
+	case result.info.Object != nil:
+		acc, err := meta.Accessor(result.info.Object)
+		if err == nil {
+			uid := acc.GetUID()
+			gen := acc.GetGeneration()
+			taskContext.InventoryManager().AddSuccessfulApply(id, uid, gen)
+		}
+		taskContext.SetActuationTime(id, result.end)
+		if a.StatuslessReconciled && object.IsStatusless(id.GroupKind) &&
+			!a.DryRunStrategy.ClientOrServerDryRun() {
+			a.markStatuslessCurrent(taskContext, id, result.info.Object)
+		}
+	}
 }
 
 func newApplyOptions(taskName string, eventChannel chan<- event.Event, serverSideOptions common.ServerSideOptions,
@@ -208,6 +534,8 @@ func newApplyOptions(taskName string, eventChannel chan<- event.Event, serverSid
 		ForceConflicts:  serverSideOptions.ForceConflicts,
 		FieldManager:    serverSideOptions.FieldManager,
 		DryRunStrategy:  strategy.Strategy(),
+		// Passed to the server as the fieldValidation query parameter.
+		ValidationDirective: serverSideOptions.FieldValidation,
 		ToPrinter: (&KubectlPrinterAdapter{
 			ch:        eventChannel,
 			groupName: taskName,
@@ -217,12 +545,27 @@ func newApplyOptions(taskName string, eventChannel chan<- event.Event, serverSid
 	}
 }
 
+// markStatuslessCurrent updates the ResourceCache to mark an applied object
+// without status as Current.
+func (a *ApplyTask) markStatuslessCurrent(taskContext *taskrunner.TaskContext, id object.ObjMetadata, applied runtime.Object) {
+	obj, ok := applied.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	taskContext.Logger().V(5).Info("marking object without status as current", "object", id)
+	taskContext.ResourceCache().Put(id, cache.ResourceStatus{
+		Resource:      obj,
+		Status:        status.CurrentStatus,
+		StatusMessage: "Resource has no status",
+	})
+}
+
 func (a *ApplyTask) sendTaskResult(taskContext *taskrunner.TaskContext) {
-	klog.V(2).Infof("apply task completing (name: %q)", a.Name())
 	taskContext.TaskChannel() <- taskrunner.TaskResult{}
 }
 
-// Cancel is not supported by the ApplyTask.
+// Cancel is handled by skipping the objects that are not applied yet, once
+// the TaskContext is cancelled. Objects being applied are not interrupted.
 func (a *ApplyTask) Cancel(_ *taskrunner.TaskContext) {}
 
 // StatusUpdate is not supported by the ApplyTask.
@@ -230,15 +573,16 @@ func (a *ApplyTask) StatusUpdate(_ *taskrunner.TaskContext, _ object.ObjMetadata
 
 // mutate loops through the mutator list and executes them on the object.
 func (a *ApplyTask) mutate(ctx context.Context, obj *unstructured.Unstructured) error {
+	logger := klog.FromContext(ctx)
 	id := object.UnstructuredToObjMetadata(obj)
 	for _, mutator := range a.Mutators {
-		klog.V(6).Infof("apply mutator %s: %s", mutator.Name(), id)
+		logger.V(6).Info("apply mutator", "mutator", mutator.Name(), "object", id)
 		mutated, reason, err := mutator.Mutate(ctx, obj)
 		if err != nil {
 			return fmt.Errorf("failed to mutate %q with %q: %w", id, mutator.Name(), err)
 		}
 		if mutated {
-			klog.V(4).Infof("resource mutated (mutator: %q, resource: %q, reason: %q)", mutator.Name(), id, reason)
+			logger.V(4).Info("resource mutated", "mutator", mutator.Name(), "object", id, "reason", reason)
 		}
 	}
 	return nil
@@ -252,6 +596,22 @@ func (a *ApplyTask) createApplyFailedEvent(id object.ObjMetadata, err error) eve
 			Identifier: id,
 			Status:     event.ApplyFailed,
 			Error:      err,
+			Conflicts:  fieldConflicts(err),
+		},
+	}
+}
+
+func (a *ApplyTask) createApplyRetryEvent(id object.ObjMetadata, retry int, backoff time.Duration, err error) event.Event {
+	return event.Event{
+		Type: event.RetryType,
+		RetryEvent: event.RetryEvent{
+			GroupName:  a.Name(),
+			Identifier: id,
+			Action:     event.ApplyAction,
+			Retry:      retry,
+			MaxRetries: a.RetryPolicy.MaxRetries,
+			Backoff:    backoff,
+			Error:      err,
 		},
 	}
 }
@@ -280,8 +640,118 @@ func isStreamError(err error) bool {
 	return strings.Contains(err.Error(), "stream error: stream ID ")
 }
 
+// serverSideFallbackWarning is the warning added to the apply event of an
+// object applied with server-side apply because its annotations were too long
+// for client-side apply.
+const serverSideFallbackWarning = "the last-applied-configuration annotation exceeds the size limit of the annotations: " +
+	"the object was applied with server-side apply"
+
+// isAnnotationTooLongError checks if the error is the validation error of
+// annotations that exceed the total size limit, usually because of the
+// last-applied-configuration annotation set by client-side apply.
+func isAnnotationTooLongError(err error) bool {
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) || !apierrors.IsInvalid(err) {
+		return false
+	}
+	details := statusErr.Status().Details
+	if details == nil {
+		return false
+	}
+	for _, cause := range details.Causes {
+		if cause.Type == metav1.CauseType(field.ErrorTypeTooLong) && cause.Field == "metadata.annotations" {
+			return true
+		}
+	}
+	return false
+}
+
+// serverSideApply applies the object with server-side apply, forcing
+// conflicts like client-side apply overwrites the fields of other managers.
+func (a *ApplyTask) serverSideApply(info *resource.Info, eventChannel chan<- event.Event) error {
+	fieldManager := a.ServerSideOptions.FieldManager
+	if fieldManager == "" {
+		fieldManager = common.DefaultFieldManager
+	}
+	ao := applyOptionsFactoryFunc(a.Name(), eventChannel, common.ServerSideOptions{
+		ServerSideApply: true,
+		ForceConflicts:  true,
+		FieldManager:    fieldManager,
+		FieldValidation: a.ServerSideOptions.FieldValidation,
+	}, a.DryRunStrategy, a.DynamicClient, a.OpenAPIGetter)
+	ao.SetObjects([]*resource.Info{info})
+	return ao.Run()
+}
+
+// fieldConflicts returns the fields owned by other field managers that
+// failed a server-side apply, or nil if the error is not a conflict.
+func fieldConflicts(err error) []event.FieldConflict {
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) || !apierrors.IsConflict(err) {
+		return nil
+	}
+	details := statusErr.Status().Details
+	if details == nil {
+		return nil
+	}
+	var conflicts []event.FieldConflict
+	for _, cause := range details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		conflicts = append(conflicts, event.FieldConflict{
+			Field:   cause.Field,
+			Manager: conflictManager(cause.Message),
+		})
+	}
+	return conflicts
+}
+
+// conflictManager returns the name of the field manager in the message of a
+// conflict, like `conflict with "kubectl" using apps/v1`.
+func conflictManager(message string) string {
+	quoted, err := strconv.QuotedPrefix(strings.TrimPrefix(message, "conflict with "))
+	if err != nil {
+		return ""
+	}
+	manager, err := strconv.Unquote(quoted)
+	if err != nil {
+		return ""
+	}
+	return manager
+}
+
+// canForceConflicts returns true if all the conflicts are with the field
+// managers of ForceConflictsWith.
+func (a *ApplyTask) canForceConflicts(conflicts []event.FieldConflict) bool {
+	if len(conflicts) == 0 {
+		return false
+	}
+	allowed := sets.NewString(a.ServerSideOptions.ForceConflictsWith...)
+	for _, c := range conflicts {
+		if !allowed.Has(c.Manager) {
+			return false
+		}
+	}
+	return true
+}
+
+// forceConflicts applies the object with server-side apply, forcing the
+// conflicts.
+func (a *ApplyTask) forceConflicts(info *resource.Info, eventChannel chan<- event.Event) error {
+	serverSideOptions := a.ServerSideOptions
+	serverSideOptions.ForceConflicts = true
+	ao := applyOptionsFactoryFunc(a.Name(), eventChannel, serverSideOptions,
+		a.DryRunStrategy, a.DynamicClient, a.OpenAPIGetter)
+	ao.SetObjects([]*resource.Info{info})
+	return ao.Run()
+}
+
 func (a *ApplyTask) clientSideApply(info *resource.Info, eventChannel chan<- event.Event) error {
-	ao := applyOptionsFactoryFunc(a.Name(), eventChannel, common.ServerSideOptions{ServerSideApply: false}, a.DryRunStrategy, a.DynamicClient, a.OpenAPIGetter)
+	ao := applyOptionsFactoryFunc(a.Name(), eventChannel, common.ServerSideOptions{
+		ServerSideApply: false,
+		FieldValidation: a.ServerSideOptions.FieldValidation,
+	}, a.DryRunStrategy, a.DynamicClient, a.OpenAPIGetter)
 	ao.SetObjects([]*resource.Info{info})
 	return ao.Run()
 }
@@ -4,22 +4,42 @@
 package task
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/kubectl/pkg/cmd/apply"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
+	applyerror "sigs.k8s.io/cli-utils/pkg/apply/error"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	"sigs.k8s.io/cli-utils/pkg/apply/mutator"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 )
@@ -210,6 +230,86 @@ func TestApplyTask_FetchGeneration(t *testing.T) {
 	}
 }
 
+func TestApplyTask_StatuslessReconciled(t *testing.T) {
+	applied := []resourceInfo{
+		{
+			apiVersion: "v1",
+			kind:       "ConfigMap",
+			name:       "foo",
+			namespace:  "default",
+			uid:        types.UID("cm-uid"),
+		},
+		{
+			group:      "apps",
+			apiVersion: "apps/v1",
+			kind:       "Deployment",
+			name:       "bar",
+			namespace:  "default",
+			uid:        types.UID("deploy-uid"),
+			generation: int64(1),
+		},
+	}
+	configMapID := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+		Name:      "foo",
+		Namespace: "default",
+	}
+	deploymentID := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Name:      "bar",
+		Namespace: "default",
+	}
+
+	testCases := map[string]struct {
+		statuslessReconciled bool
+		dryRunStrategy       common.DryRunStrategy
+		expectedStatus       status.Status
+	}{
+		"statusless objects marked current": {
+			statuslessReconciled: true,
+			expectedStatus:       status.CurrentStatus,
+		},
+		"statusless objects not marked current when disabled": {
+			statuslessReconciled: false,
+			expectedStatus:       status.UnknownStatus,
+		},
+		"statusless objects not marked current during dry-run": {
+			statuslessReconciled: true,
+			dryRunStrategy:       common.DryRunClient,
+			expectedStatus:       status.UnknownStatus,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			eventChannel := make(chan event.Event)
+			defer close(eventChannel)
+			resourceCache := cache.NewResourceCacheMap()
+			taskContext := taskrunner.NewTaskContext(eventChannel, resourceCache)
+
+			oldAO := applyOptionsFactoryFunc
+			applyOptionsFactoryFunc = func(string, chan<- event.Event, common.ServerSideOptions, common.DryRunStrategy,
+				dynamic.Interface, discovery.OpenAPISchemaInterface) applyOptions {
+				return &fakeApplyOptions{}
+			}
+			defer func() { applyOptionsFactoryFunc = oldAO }()
+
+			applyTask := &ApplyTask{
+				Objects:              toUnstructureds(applied),
+				InfoHelper:           &fakeInfoHelper{},
+				DryRunStrategy:       tc.dryRunStrategy,
+				StatuslessReconciled: tc.statuslessReconciled,
+			}
+			applyTask.Start(taskContext)
+			<-taskContext.TaskChannel()
+
+			assert.Equal(t, tc.expectedStatus, resourceCache.Get(configMapID).Status)
+			// Objects with status are always left to the StatusWatcher.
+			assert.Equal(t, status.UnknownStatus, resourceCache.Get(deploymentID).Status)
+		})
+	}
+}
+
 func TestApplyTask_DryRun(t *testing.T) {
 	testCases := map[string]struct {
 		objs            []*unstructured.Unstructured
@@ -577,3 +677,993 @@ func (f *fakeInfoHelper) BuildInfos(objs []*unstructured.Unstructured) ([]*resou
 func (f *fakeInfoHelper) BuildInfo(obj *unstructured.Unstructured) (*resource.Info, error) {
 	return object.UnstructuredToInfo(obj)
 }
+
+// resettableRESTMapper records when the RESTMapper is reset.
+type resettableRESTMapper struct {
+	meta.RESTMapper
+	resets int
+}
+
+func (m *resettableRESTMapper) Reset() {
+	m.resets++
+}
+
+// staleInfoHelper returns a NoKindMatchError until the mapper is reset.
+type staleInfoHelper struct {
+	fakeInfoHelper
+	mapper *resettableRESTMapper
+}
+
+func (f *staleInfoHelper) BuildInfo(obj *unstructured.Unstructured) (*resource.Info, error) {
+	info, err := object.UnstructuredToInfo(obj)
+	if err != nil {
+		return nil, err
+	}
+	if f.mapper.resets == 0 {
+		gvk := obj.GroupVersionKind()
+		return info, &meta.NoKindMatchError{
+			GroupKind:        gvk.GroupKind(),
+			SearchedVersions: []string{gvk.Version},
+		}
+	}
+	return info, nil
+}
+
+func TestApplyTask_ResetsStaleRESTMapper(t *testing.T) {
+	eventChannel := make(chan event.Event)
+	defer close(eventChannel)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := taskrunner.NewTaskContext(eventChannel, resourceCache)
+
+	objs := toUnstructureds([]resourceInfo{
+		{
+			group:      "custom.io",
+			apiVersion: "custom.io/v1",
+			kind:       "Custom",
+			name:       "foo",
+			namespace:  "default",
+			uid:        types.UID("uid-1"),
+		},
+		{
+			group:      "custom.io",
+			apiVersion: "custom.io/v1",
+			kind:       "Custom",
+			name:       "bar",
+			namespace:  "default",
+			uid:        types.UID("uid-2"),
+		},
+	})
+
+	oldAO := applyOptionsFactoryFunc
+	applyOptionsFactoryFunc = func(string, chan<- event.Event, common.ServerSideOptions, common.DryRunStrategy,
+		dynamic.Interface, discovery.OpenAPISchemaInterface) applyOptions {
+		return &fakeApplyOptions{}
+	}
+	defer func() { applyOptionsFactoryFunc = oldAO }()
+
+	mapper := &resettableRESTMapper{
+		RESTMapper: testutil.NewFakeRESTMapper(),
+	}
+	applyTask := &ApplyTask{
+		Objects:    objs,
+		Mapper:     mapper,
+		InfoHelper: &staleInfoHelper{mapper: mapper},
+	}
+
+	applyTask.Start(taskContext)
+	<-taskContext.TaskChannel()
+
+	assert.Equal(t, 1, mapper.resets)
+	expectedIDs := object.UnstructuredSetToObjMetadataSet(objs)
+	actual := taskContext.InventoryManager().SuccessfulApplies()
+	if !actual.Equal(expectedIDs) {
+		t.Errorf("expected (%s) inventory resources, got (%s)", expectedIDs, actual)
+	}
+}
+
+// concurrentApplyOptions waits until all the objects are being applied,
+// then sends an event for its object, with the first objects finishing last.
+type concurrentApplyOptions struct {
+	ch      chan<- event.Event
+	started *sync.WaitGroup
+	delay   map[string]time.Duration
+	objects []*resource.Info
+}
+
+func (f *concurrentApplyOptions) Run() error {
+	f.started.Done()
+	allStarted := make(chan struct{})
+	go func() {
+		f.started.Wait()
+		close(allStarted)
+	}()
+	select {
+	case <-allStarted:
+	case <-time.After(5 * time.Second):
+		return fmt.Errorf("timed out waiting for concurrent applies")
+	}
+	for _, info := range f.objects {
+		time.Sleep(f.delay[info.Name])
+		id, err := object.RuntimeToObjMeta(info.Object)
+		if err != nil {
+			return err
+		}
+		f.ch <- event.Event{
+			Type: event.ApplyType,
+			ApplyEvent: event.ApplyEvent{
+				Identifier: id,
+				Status:     event.ApplySuccessful,
+			},
+		}
+	}
+	return nil
+}
+
+func (f *concurrentApplyOptions) SetObjects(objects []*resource.Info) {
+	f.objects = objects
+}
+
+func TestApplyTask_Concurrency(t *testing.T) {
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := taskrunner.NewTaskContext(eventChannel, resourceCache)
+
+	var applied []resourceInfo
+	delay := map[string]time.Duration{}
+	for i := 0; i < 4; i++ {
+		name := fmt.Sprintf("obj-%d", i)
+		applied = append(applied, resourceInfo{
+			group:      "apps",
+			apiVersion: "apps/v1",
+			kind:       "Deployment",
+			name:       name,
+			namespace:  "default",
+			uid:        types.UID(name),
+		})
+		delay[name] = time.Duration(4-i) * 10 * time.Millisecond
+	}
+	objs := toUnstructureds(applied)
+
+	var started sync.WaitGroup
+	started.Add(len(objs))
+	oldAO := applyOptionsFactoryFunc
+	applyOptionsFactoryFunc = func(_ string, ch chan<- event.Event, _ common.ServerSideOptions, _ common.DryRunStrategy,
+		_ dynamic.Interface, _ discovery.OpenAPISchemaInterface) applyOptions {
+		return &concurrentApplyOptions{ch: ch, started: &started, delay: delay}
+	}
+	defer func() { applyOptionsFactoryFunc = oldAO }()
+
+	applyTask := &ApplyTask{
+		Objects:     objs,
+		Mapper:      testutil.NewFakeRESTMapper(),
+		InfoHelper:  &fakeInfoHelper{},
+		Concurrency: len(objs),
+	}
+
+	var events []event.Event
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for e := range eventChannel {
+			events = append(events, e)
+		}
+	}()
+
+	applyTask.Start(taskContext)
+	<-taskContext.TaskChannel()
+	close(eventChannel)
+	wg.Wait()
+
+	// Events are sent in object order, even though the objects finished
+	// applying in reverse order.
+	expectedIDs := object.UnstructuredSetToObjMetadataSet(objs)
+	var actualIDs object.ObjMetadataSet
+	for _, e := range events {
+		assert.Equal(t, event.ApplySuccessful, e.ApplyEvent.Status)
+		actualIDs = append(actualIDs, e.ApplyEvent.Identifier)
+	}
+	assert.Equal(t, []object.ObjMetadata(expectedIDs), []object.ObjMetadata(actualIDs))
+	assert.True(t, taskContext.InventoryManager().SuccessfulApplies().Equal(expectedIDs))
+}
+
+// errorFilter fails every object with its error.
+type errorFilter struct {
+	err error
+}
+
+func (f errorFilter) Name() string {
+	return "ErrorFilter"
+}
+
+func (f errorFilter) Filter(*unstructured.Unstructured) error {
+	return f.err
+}
+
+// errorMutator fails every object with its error.
+type errorMutator struct {
+	err error
+}
+
+func (m errorMutator) Name() string {
+	return "ErrorMutator"
+}
+
+func (m errorMutator) Mutate(context.Context, *unstructured.Unstructured) (bool, string, error) {
+	return false, "", m.err
+}
+
+func TestApplyTask_FilterAndMutatorErrors(t *testing.T) {
+	denied := errors.New("denied by policy")
+	testCases := map[string]struct {
+		filters        []filter.ValidationFilter
+		mutators       []mutator.Interface
+		expectedStatus event.ApplyEventStatus
+		expectedErr    error
+	}{
+		"fatal filter error": {
+			filters:        []filter.ValidationFilter{errorFilter{err: filter.NewFatalError(denied)}},
+			expectedStatus: event.ApplyFailed,
+			expectedErr:    denied,
+		},
+		"filter error": {
+			filters:        []filter.ValidationFilter{errorFilter{err: denied}},
+			expectedStatus: event.ApplySkipped,
+			expectedErr:    denied,
+		},
+		"mutator skip error": {
+			mutators:       []mutator.Interface{errorMutator{err: &mutator.SkipError{Err: denied}}},
+			expectedStatus: event.ApplySkipped,
+			expectedErr:    denied,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			eventChannel := make(chan event.Event)
+			taskContext := taskrunner.NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+
+			oldAO := applyOptionsFactoryFunc
+			applyOptionsFactoryFunc = func(string, chan<- event.Event, common.ServerSideOptions, common.DryRunStrategy,
+				dynamic.Interface, discovery.OpenAPISchemaInterface) applyOptions {
+				t.Error("the object must not be applied")
+				return &fakeApplyOptions{}
+			}
+			defer func() { applyOptionsFactoryFunc = oldAO }()
+
+			applyTask := &ApplyTask{
+				Objects: toUnstructureds([]resourceInfo{{
+					group:      "apps",
+					apiVersion: "apps/v1",
+					kind:       "Deployment",
+					name:       "foo",
+					namespace:  "default",
+				}}),
+				Filters:    tc.filters,
+				Mutators:   tc.mutators,
+				Mapper:     testutil.NewFakeRESTMapper(),
+				InfoHelper: &fakeInfoHelper{},
+			}
+
+			var events []event.Event
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for e := range eventChannel {
+					events = append(events, e)
+				}
+			}()
+
+			applyTask.Start(taskContext)
+			<-taskContext.TaskChannel()
+			close(eventChannel)
+			wg.Wait()
+
+			require.Len(t, events, 1)
+			assert.Equal(t, tc.expectedStatus, events[0].ApplyEvent.Status)
+			assert.ErrorIs(t, events[0].ApplyEvent.Error, tc.expectedErr)
+		})
+	}
+}
+
+// flakyApplyOptions fails with the next error in errs, until none remain.
+type flakyApplyOptions struct {
+	errs *[]error
+}
+
+func (f *flakyApplyOptions) Run() error {
+	if len(*f.errs) == 0 {
+		return nil
+	}
+	err := (*f.errs)[0]
+	*f.errs = (*f.errs)[1:]
+	return err
+}
+
+func (f *flakyApplyOptions) SetObjects([]*resource.Info) {}
+
+func TestApplyTask_Retry(t *testing.T) {
+	gr := schema.GroupResource{Group: "apps", Resource: "deployments"}
+	conflict := apierrors.NewConflict(gr, "foo", fmt.Errorf("object has been modified"))
+	forbidden := apierrors.NewForbidden(gr, "foo", fmt.Errorf("denied"))
+
+	testCases := map[string]struct {
+		maxRetries      int
+		errs            []error
+		expectedRetries []int
+		expectedFailed  bool
+	}{
+		"succeeds after retrying conflicts": {
+			maxRetries:      3,
+			errs:            []error{conflict, conflict},
+			expectedRetries: []int{1, 2},
+		},
+		"fails after retries exhausted": {
+			maxRetries:      1,
+			errs:            []error{conflict, conflict},
+			expectedRetries: []int{1},
+			expectedFailed:  true,
+		},
+		"does not retry errors that are not transient": {
+			maxRetries:     3,
+			errs:           []error{forbidden},
+			expectedFailed: true,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			eventChannel := make(chan event.Event)
+			taskContext := taskrunner.NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+
+			objs := toUnstructureds([]resourceInfo{
+				{
+					group:      "apps",
+					apiVersion: "apps/v1",
+					kind:       "Deployment",
+					name:       "foo",
+					namespace:  "default",
+					uid:        types.UID("my-uid"),
+				},
+			})
+			id := object.UnstructuredToObjMetadata(objs[0])
+
+			errs := tc.errs
+			oldAO := applyOptionsFactoryFunc
+			applyOptionsFactoryFunc = func(string, chan<- event.Event, common.ServerSideOptions, common.DryRunStrategy,
+				dynamic.Interface, discovery.OpenAPISchemaInterface) applyOptions {
+				return &flakyApplyOptions{errs: &errs}
+			}
+			defer func() { applyOptionsFactoryFunc = oldAO }()
+
+			applyTask := &ApplyTask{
+				TaskName:   "apply-0",
+				Objects:    objs,
+				Mapper:     testutil.NewFakeRESTMapper(),
+				InfoHelper: &fakeInfoHelper{},
+				RetryPolicy: common.RetryPolicy{
+					MaxRetries:     tc.maxRetries,
+					InitialBackoff: time.Millisecond,
+				},
+			}
+
+			var events []event.Event
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for e := range eventChannel {
+					events = append(events, e)
+				}
+			}()
+
+			applyTask.Start(taskContext)
+			<-taskContext.TaskChannel()
+			close(eventChannel)
+			wg.Wait()
+
+			var retries []int
+			for _, e := range events {
+				if e.Type == event.RetryType {
+					assert.Equal(t, id, e.RetryEvent.Identifier)
+					assert.Equal(t, event.ApplyAction, e.RetryEvent.Action)
+					assert.Equal(t, tc.maxRetries, e.RetryEvent.MaxRetries)
+					retries = append(retries, e.RetryEvent.Retry)
+				}
+			}
+			assert.Equal(t, tc.expectedRetries, retries)
+			assert.Equal(t, tc.expectedFailed, taskContext.InventoryManager().IsFailedApply(id))
+			if tc.expectedFailed {
+				lastEvent := events[len(events)-1]
+				assert.Equal(t, event.ApplyType, lastEvent.Type)
+				assert.Equal(t, event.ApplyFailed, lastEvent.ApplyEvent.Status)
+			}
+		})
+	}
+}
+
+func TestApplyTask_GeneratedName(t *testing.T) {
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := taskrunner.NewTaskContext(eventChannel, resourceCache)
+
+	obj := testutil.Unstructured(t, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  generateName: foo-
+  namespace: default
+`)
+	id := object.UnstructuredToObjMetadata(obj)
+	createdID := object.ObjMetadata{
+		GroupKind: id.GroupKind,
+		Namespace: "default",
+		Name:      "foo-x7k2p",
+	}
+
+	client := fake.NewSimpleDynamicClient(scheme.Scheme)
+	client.PrependReactor("create", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		created := action.(clienttesting.CreateAction).GetObject().(*unstructured.Unstructured).DeepCopy()
+		created.SetName(created.GetGenerateName() + "x7k2p")
+		created.SetUID("created-uid")
+		return true, created, nil
+	})
+
+	oldAO := applyOptionsFactoryFunc
+	applyOptionsFactoryFunc = func(string, chan<- event.Event, common.ServerSideOptions, common.DryRunStrategy,
+		dynamic.Interface, discovery.OpenAPISchemaInterface) applyOptions {
+		t.Error("objects with a generated name must not be applied")
+		return &fakeApplyOptions{}
+	}
+	defer func() { applyOptionsFactoryFunc = oldAO }()
+
+	applyTask := &ApplyTask{
+		Objects:       object.UnstructuredSet{obj},
+		DynamicClient: client,
+		Mapper:        testutil.NewFakeRESTMapper(obj.GroupVersionKind()),
+		InfoHelper:    &fakeInfoHelper{},
+	}
+
+	var events []event.Event
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for e := range eventChannel {
+			events = append(events, e)
+		}
+	}()
+
+	applyTask.Start(taskContext)
+	<-taskContext.TaskChannel()
+	close(eventChannel)
+	wg.Wait()
+
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, event.ApplySuccessful, events[0].ApplyEvent.Status)
+		assert.Equal(t, event.ApplyCreated, events[0].ApplyEvent.Operation)
+		assert.Equal(t, createdID, events[0].ApplyEvent.Identifier)
+	}
+	generated, found := taskContext.GeneratedObject(id)
+	assert.True(t, found)
+	assert.Equal(t, createdID, generated)
+	uid, _ := taskContext.InventoryManager().AppliedResourceUID(createdID)
+	assert.Equal(t, types.UID("created-uid"), uid)
+	assert.True(t, taskContext.InventoryManager().SuccessfulApplies().Equal(object.ObjMetadataSet{createdID}))
+}
+
+func TestApplyTask_GeneratedNameNotRetried(t *testing.T) {
+	eventChannel := make(chan event.Event)
+	taskContext := taskrunner.NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+
+	obj := testutil.Unstructured(t, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  generateName: foo-
+  namespace: default
+`)
+
+	// The server creates the object, but the response times out.
+	var created []string
+	client := fake.NewSimpleDynamicClient(scheme.Scheme)
+	client.PrependReactor("create", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		name := fmt.Sprintf("%s%d", obj.GetGenerateName(), len(created))
+		created = append(created, name)
+		return true, nil, apierrors.NewServerTimeout(schema.GroupResource{Group: "apps", Resource: "deployments"}, "create", 0)
+	})
+
+	applyTask := &ApplyTask{
+		Objects:       object.UnstructuredSet{obj},
+		DynamicClient: client,
+		Mapper:        testutil.NewFakeRESTMapper(obj.GroupVersionKind()),
+		InfoHelper:    &fakeInfoHelper{},
+		RetryPolicy: common.RetryPolicy{
+			MaxRetries:     3,
+			InitialBackoff: time.Millisecond,
+		},
+	}
+
+	var events []event.Event
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for e := range eventChannel {
+			events = append(events, e)
+		}
+	}()
+
+	applyTask.Start(taskContext)
+	<-taskContext.TaskChannel()
+	close(eventChannel)
+	wg.Wait()
+
+	// A retry would have created a second object.
+	assert.Equal(t, []string{"foo-0"}, created)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, event.ApplyFailed, events[0].ApplyEvent.Status)
+	}
+}
+
+// clientInfoHelper builds infos with the client.
+type clientInfoHelper struct {
+	fakeInfoHelper
+	client resource.RESTClient
+}
+
+func (f *clientInfoHelper) BuildInfo(obj *unstructured.Unstructured) (*resource.Info, error) {
+	info, err := object.UnstructuredToInfo(obj)
+	if err != nil {
+		return nil, err
+	}
+	info.Client = f.client
+	return info, nil
+}
+
+// requestApplyOptions sends a request with the client of each object, and
+// reports it as applied.
+type requestApplyOptions struct {
+	ch      chan<- event.Event
+	objects []*resource.Info
+}
+
+func (r *requestApplyOptions) Run() error {
+	for _, info := range r.objects {
+		if err := info.Client.Get().AbsPath("/").Do(context.TODO()).Error(); err != nil {
+			return err
+		}
+		r.ch <- event.Event{
+			Type: event.ApplyType,
+			ApplyEvent: event.ApplyEvent{
+				Identifier: object.UnstructuredToObjMetadata(info.Object.(*unstructured.Unstructured)),
+				Status:     event.ApplySuccessful,
+			},
+		}
+	}
+	return nil
+}
+
+func (r *requestApplyOptions) SetObjects(objects []*resource.Info) {
+	r.objects = objects
+}
+
+func TestApplyTask_FieldValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Add("Warning", `299 - "unknown field \"spec.replica\""`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := rest.UnversionedRESTClientFor(&rest.Config{
+		Host: server.URL,
+		ContentConfig: rest.ContentConfig{
+			NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		},
+	})
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		fieldValidation  string
+		expectedWarnings []string
+	}{
+		"warnings are added to the apply event in Warn mode": {
+			fieldValidation:  metav1.FieldValidationWarn,
+			expectedWarnings: []string{`unknown field "spec.replica"`},
+		},
+		"warnings are not recorded in Strict mode": {
+			fieldValidation: metav1.FieldValidationStrict,
+		},
+		"warnings are not recorded by default": {
+			fieldValidation: "",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			eventChannel := make(chan event.Event)
+			taskContext := taskrunner.NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+			objs := toUnstructureds([]resourceInfo{
+				{
+					group:      "apps",
+					apiVersion: "apps/v1",
+					kind:       "Deployment",
+					name:       "foo",
+					namespace:  "default",
+				},
+			})
+
+			var fieldValidation string
+			oldAO := applyOptionsFactoryFunc
+			applyOptionsFactoryFunc = func(_ string, ch chan<- event.Event, serverSideOptions common.ServerSideOptions,
+				_ common.DryRunStrategy, _ dynamic.Interface, _ discovery.OpenAPISchemaInterface) applyOptions {
+				fieldValidation = serverSideOptions.FieldValidation
+				return &requestApplyOptions{ch: ch}
+			}
+			defer func() { applyOptionsFactoryFunc = oldAO }()
+
+			applyTask := &ApplyTask{
+				Objects:    objs,
+				Mapper:     testutil.NewFakeRESTMapper(),
+				InfoHelper: &clientInfoHelper{client: client},
+				ServerSideOptions: common.ServerSideOptions{
+					FieldValidation: tc.fieldValidation,
+				},
+			}
+
+			var events []event.Event
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for e := range eventChannel {
+					events = append(events, e)
+				}
+			}()
+
+			applyTask.Start(taskContext)
+			<-taskContext.TaskChannel()
+			close(eventChannel)
+			wg.Wait()
+
+			assert.Equal(t, tc.fieldValidation, fieldValidation)
+			if assert.Len(t, events, 1) {
+				assert.Equal(t, event.ApplySuccessful, events[0].ApplyEvent.Status)
+				assert.Equal(t, tc.expectedWarnings, events[0].ApplyEvent.Warnings)
+			}
+		})
+	}
+}
+
+func TestNewApplyOptions_FieldValidation(t *testing.T) {
+	ao := newApplyOptions("apply-0", nil, common.ServerSideOptions{
+		FieldValidation: metav1.FieldValidationStrict,
+	}, common.DryRunNone, nil, nil)
+	assert.Equal(t, metav1.FieldValidationStrict, ao.(*apply.ApplyOptions).ValidationDirective)
+}
+
+// sizeLimitApplyOptions fails client-side applies with the validation error
+// of annotations that are too long, and succeeds server-side applies.
+type sizeLimitApplyOptions struct {
+	ch                chan<- event.Event
+	serverSideOptions common.ServerSideOptions
+	objects           []*resource.Info
+}
+
+func (s *sizeLimitApplyOptions) Run() error {
+	for _, info := range s.objects {
+		obj := info.Object.(*unstructured.Unstructured)
+		if !s.serverSideOptions.ServerSideApply {
+			return apierrors.NewInvalid(obj.GroupVersionKind().GroupKind(), obj.GetName(), field.ErrorList{
+				field.TooLong(field.NewPath("metadata", "annotations"), "", 262144),
+			})
+		}
+		s.ch <- event.Event{
+			Type: event.ApplyType,
+			ApplyEvent: event.ApplyEvent{
+				Identifier: object.UnstructuredToObjMetadata(obj),
+				Status:     event.ApplySuccessful,
+				Operation:  event.ApplyServersideApplied,
+			},
+		}
+	}
+	return nil
+}
+
+func (s *sizeLimitApplyOptions) SetObjects(objects []*resource.Info) {
+	s.objects = objects
+}
+
+func TestApplyTask_AnnotationSizeFallback(t *testing.T) {
+	testCases := map[string]struct {
+		serverSideOptions common.ServerSideOptions
+		expectedOptions   []common.ServerSideOptions
+		expectedWarnings  []string
+	}{
+		"client-side apply falls back to server-side apply": {
+			expectedOptions: []common.ServerSideOptions{
+				{},
+				{ServerSideApply: true, ForceConflicts: true, FieldManager: common.DefaultFieldManager},
+			},
+			expectedWarnings: []string{serverSideFallbackWarning},
+		},
+		"field manager is kept": {
+			serverSideOptions: common.ServerSideOptions{FieldManager: "test"},
+			expectedOptions: []common.ServerSideOptions{
+				{FieldManager: "test"},
+				{ServerSideApply: true, ForceConflicts: true, FieldManager: "test"},
+			},
+			expectedWarnings: []string{serverSideFallbackWarning},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			eventChannel := make(chan event.Event)
+			taskContext := taskrunner.NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+			objs := toUnstructureds([]resourceInfo{
+				{
+					group:      "apiextensions.k8s.io",
+					apiVersion: "apiextensions.k8s.io/v1",
+					kind:       "CustomResourceDefinition",
+					name:       "large.example.com",
+				},
+			})
+
+			var options []common.ServerSideOptions
+			oldAO := applyOptionsFactoryFunc
+			applyOptionsFactoryFunc = func(_ string, ch chan<- event.Event, serverSideOptions common.ServerSideOptions,
+				_ common.DryRunStrategy, _ dynamic.Interface, _ discovery.OpenAPISchemaInterface) applyOptions {
+				options = append(options, serverSideOptions)
+				return &sizeLimitApplyOptions{ch: ch, serverSideOptions: serverSideOptions}
+			}
+			defer func() { applyOptionsFactoryFunc = oldAO }()
+
+			applyTask := &ApplyTask{
+				Objects:           objs,
+				Mapper:            testutil.NewFakeRESTMapper(),
+				InfoHelper:        &fakeInfoHelper{},
+				ServerSideOptions: tc.serverSideOptions,
+			}
+
+			var events []event.Event
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for e := range eventChannel {
+					events = append(events, e)
+				}
+			}()
+
+			applyTask.Start(taskContext)
+			<-taskContext.TaskChannel()
+			close(eventChannel)
+			wg.Wait()
+
+			assert.Equal(t, tc.expectedOptions, options)
+			if assert.Len(t, events, 1) {
+				assert.Equal(t, event.ApplySuccessful, events[0].ApplyEvent.Status)
+				assert.Equal(t, tc.expectedWarnings, events[0].ApplyEvent.Warnings)
+			}
+		})
+	}
+}
+
+func TestIsAnnotationTooLongError(t *testing.T) {
+	gk := schema.GroupKind{Kind: "ConfigMap"}
+	testCases := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"annotations too long": {
+			err: apierrors.NewInvalid(gk, "foo", field.ErrorList{
+				field.TooLong(field.NewPath("metadata", "annotations"), "", 262144),
+			}),
+			expected: true,
+		},
+		"wrapped error": {
+			err: fmt.Errorf("apply failed: %w", apierrors.NewInvalid(gk, "foo", field.ErrorList{
+				field.TooLong(field.NewPath("metadata", "annotations"), "", 262144),
+			})),
+			expected: true,
+		},
+		"other field too long": {
+			err: apierrors.NewInvalid(gk, "foo", field.ErrorList{
+				field.TooLong(field.NewPath("data", "key"), "", 1024),
+			}),
+		},
+		"other error": {
+			err: apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "foo", fmt.Errorf("conflict")),
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isAnnotationTooLongError(tc.err))
+		})
+	}
+}
+
+func TestFieldConflicts(t *testing.T) {
+	testCases := map[string]struct {
+		err      error
+		expected []event.FieldConflict
+	}{
+		"conflicts": {
+			err: apierrors.NewApplyConflict([]metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "kube-controller-manager" using apps/v1`,
+					Field:   ".spec.replicas",
+				},
+				{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "kubectl" with subresource "scale"`,
+					Field:   ".spec.paused",
+				},
+			}, "Apply failed with 2 conflicts"),
+			expected: []event.FieldConflict{
+				{Field: ".spec.replicas", Manager: "kube-controller-manager"},
+				{Field: ".spec.paused", Manager: "kubectl"},
+			},
+		},
+		"wrapped conflicts": {
+			err: applyerror.NewApplyRunError(apierrors.NewApplyConflict([]metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "hpa"`,
+					Field:   ".spec.replicas",
+				},
+			}, "Apply failed with 1 conflict")),
+			expected: []event.FieldConflict{
+				{Field: ".spec.replicas", Manager: "hpa"},
+			},
+		},
+		"conflict without causes": {
+			err: apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "foo", fmt.Errorf("conflict")),
+		},
+		"other error": {
+			err: fmt.Errorf("apply failed"),
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, fieldConflicts(tc.err))
+		})
+	}
+}
+
+// conflictApplyOptions fails server-side applies with conflicts, unless
+// they are forced.
+type conflictApplyOptions struct {
+	ch                chan<- event.Event
+	serverSideOptions common.ServerSideOptions
+	conflicts         []metav1.StatusCause
+	objects           []*resource.Info
+}
+
+func (c *conflictApplyOptions) Run() error {
+	for _, info := range c.objects {
+		if !c.serverSideOptions.ForceConflicts {
+			return apierrors.NewApplyConflict(c.conflicts, "Apply failed with conflicts")
+		}
+		c.ch <- event.Event{
+			Type: event.ApplyType,
+			ApplyEvent: event.ApplyEvent{
+				Identifier: object.UnstructuredToObjMetadata(info.Object.(*unstructured.Unstructured)),
+				Status:     event.ApplySuccessful,
+				Operation:  event.ApplyServersideApplied,
+			},
+		}
+	}
+	return nil
+}
+
+func (c *conflictApplyOptions) SetObjects(objects []*resource.Info) {
+	c.objects = objects
+}
+
+func TestApplyTask_ForceConflictsWith(t *testing.T) {
+	hpaConflict := metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldManagerConflict,
+		Message: `conflict with "hpa" using autoscaling/v2`,
+		Field:   ".spec.replicas",
+	}
+	kubectlConflict := metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldManagerConflict,
+		Message: `conflict with "kubectl" using apps/v1`,
+		Field:   ".spec.paused",
+	}
+
+	testCases := map[string]struct {
+		forceConflictsWith []string
+		conflicts          []metav1.StatusCause
+		expectedStatus     event.ApplyEventStatus
+		expectedWarnings   []string
+		expectedConflicts  []event.FieldConflict
+	}{
+		"conflicts with allowed managers are forced": {
+			forceConflictsWith: []string{"hpa", "kubectl"},
+			conflicts:          []metav1.StatusCause{hpaConflict, kubectlConflict},
+			expectedStatus:     event.ApplySuccessful,
+			expectedWarnings: []string{
+				`forced conflict with field manager "hpa" on field .spec.replicas`,
+				`forced conflict with field manager "kubectl" on field .spec.paused`,
+			},
+		},
+		"conflicts with other managers fail the apply": {
+			forceConflictsWith: []string{"hpa"},
+			conflicts:          []metav1.StatusCause{hpaConflict, kubectlConflict},
+			expectedStatus:     event.ApplyFailed,
+			expectedConflicts: []event.FieldConflict{
+				{Field: ".spec.replicas", Manager: "hpa"},
+				{Field: ".spec.paused", Manager: "kubectl"},
+			},
+		},
+		"conflicts fail the apply by default": {
+			conflicts:      []metav1.StatusCause{hpaConflict},
+			expectedStatus: event.ApplyFailed,
+			expectedConflicts: []event.FieldConflict{
+				{Field: ".spec.replicas", Manager: "hpa"},
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			eventChannel := make(chan event.Event)
+			taskContext := taskrunner.NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+			objs := toUnstructureds([]resourceInfo{
+				{
+					group:      "apps",
+					apiVersion: "apps/v1",
+					kind:       "Deployment",
+					name:       "foo",
+					namespace:  "default",
+				},
+			})
+
+			oldAO := applyOptionsFactoryFunc
+			applyOptionsFactoryFunc = func(_ string, ch chan<- event.Event, serverSideOptions common.ServerSideOptions,
+				_ common.DryRunStrategy, _ dynamic.Interface, _ discovery.OpenAPISchemaInterface) applyOptions {
+				return &conflictApplyOptions{ch: ch, serverSideOptions: serverSideOptions, conflicts: tc.conflicts}
+			}
+			defer func() { applyOptionsFactoryFunc = oldAO }()
+
+			applyTask := &ApplyTask{
+				Objects:    objs,
+				Mapper:     testutil.NewFakeRESTMapper(),
+				InfoHelper: &fakeInfoHelper{},
+				ServerSideOptions: common.ServerSideOptions{
+					ServerSideApply:    true,
+					FieldManager:       "test",
+					ForceConflictsWith: tc.forceConflictsWith,
+				},
+			}
+
+			var events []event.Event
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for e := range eventChannel {
+					events = append(events, e)
+				}
+			}()
+
+			applyTask.Start(taskContext)
+			<-taskContext.TaskChannel()
+			close(eventChannel)
+			wg.Wait()
+
+			if assert.Len(t, events, 1) {
+				assert.Equal(t, tc.expectedStatus, events[0].ApplyEvent.Status)
+				assert.Equal(t, tc.expectedWarnings, events[0].ApplyEvent.Warnings)
+				assert.Equal(t, tc.expectedConflicts, events[0].ApplyEvent.Conflicts)
+			}
+		})
+	}
+}
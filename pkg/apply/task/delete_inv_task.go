@@ -5,7 +5,6 @@ package task
 
 import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
@@ -37,15 +36,24 @@ func (i *DeleteInvTask) Identifiers() object.ObjMetadataSet {
 }
 
 // Start deletes the inventory object from the cluster.
+//
+// If the run was cancelled, the objects of the skipped delete tasks are still
+// in the cluster, so the inventory is kept for the next run.
 func (i *DeleteInvTask) Start(taskContext *taskrunner.TaskContext) {
 	go func() {
-		klog.V(2).Infof("delete inventory task starting (name: %q)", i.Name())
+		logger := taskContext.Logger().WithValues("task", i.Name())
+		if reason := taskContext.Cancelled(); reason != nil {
+			logger.V(2).Info("delete inventory task keeping the inventory", "reason", reason)
+			taskContext.TaskChannel() <- taskrunner.TaskResult{}
+			return
+		}
+		logger.V(2).Info("delete inventory task starting")
 		err := i.InvClient.DeleteInventoryObj(i.InvInfo, i.DryRun)
 		// Not found is not error, since this means it was already deleted.
 		if apierrors.IsNotFound(err) {
 			err = nil
 		}
-		klog.V(2).Infof("delete inventory task completing (name: %q)", i.Name())
+		logger.V(2).Info("delete inventory task completing")
 		taskContext.TaskChannel() <- taskrunner.TaskResult{Err: err}
 	}()
 }
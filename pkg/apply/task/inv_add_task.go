@@ -6,7 +6,6 @@ package task
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
@@ -27,6 +26,9 @@ type InvAddTask struct {
 	InvInfo   inventory.Info
 	Objects   object.UnstructuredSet
 	DryRun    common.DryRunStrategy
+	// InventoryPolicy decides if a cluster inventory object with another
+	// inventory id can be adopted, if the client is an inventory.PolicyMerger.
+	InventoryPolicy inventory.Policy
 }
 
 func (i *InvAddTask) Name() string {
@@ -45,22 +47,35 @@ func (i *InvAddTask) Identifiers() object.ObjMetadataSet {
 // into the current inventory.
 func (i *InvAddTask) Start(taskContext *taskrunner.TaskContext) {
 	go func() {
-		klog.V(2).Infof("inventory add task starting (name: %q)", i.Name())
+		logger := taskContext.Logger().WithValues("task", i.Name())
+		logger.V(2).Info("inventory add task starting")
 		if err := inventory.ValidateNoInventory(i.Objects); err != nil {
 			i.sendTaskResult(taskContext, err)
 			return
 		}
 		// Ensures the namespace exists before applying the inventory object into it.
 		if invNamespace := inventoryNamespaceInSet(i.InvInfo, i.Objects); invNamespace != nil {
-			klog.V(4).Infof("applying inventory namespace %s", invNamespace.GetName())
+			logger.V(4).Info("applying inventory namespace", "namespace", invNamespace.GetName())
 			if err := i.InvClient.ApplyInventoryNamespace(invNamespace, i.DryRun); err != nil {
 				i.sendTaskResult(taskContext, err)
 				return
 			}
 		}
-		klog.V(4).Infof("merging %d local objects into inventory", len(i.Objects))
-		currentObjs := object.UnstructuredSetToObjMetadataSet(i.Objects)
-		_, err := i.InvClient.Merge(i.InvInfo, currentObjs, i.DryRun)
+		logger.V(4).Info("merging local objects into inventory", "count", len(i.Objects))
+		// Objects with a generated name are added by the InvSetTask, once
+		// their name is known.
+		var currentObjs object.ObjMetadataSet
+		for _, id := range object.UnstructuredSetToObjMetadataSet(i.Objects) {
+			if !id.IsGenerated() {
+				currentObjs = append(currentObjs, id)
+			}
+		}
+		var err error
+		if pm, ok := i.InvClient.(inventory.PolicyMerger); ok {
+			_, err = pm.MergeWithPolicy(i.InvInfo, currentObjs, i.DryRun, i.InventoryPolicy)
+		} else {
+			_, err = i.InvClient.Merge(i.InvInfo, currentObjs, i.DryRun)
+		}
 		i.sendTaskResult(taskContext, err)
 	}()
 }
@@ -91,7 +106,7 @@ func inventoryNamespaceInSet(inv inventory.Info, objs object.UnstructuredSet) *u
 }
 
 func (i *InvAddTask) sendTaskResult(taskContext *taskrunner.TaskContext, err error) {
-	klog.V(2).Infof("inventory add task completing (name: %q)", i.Name())
+	taskContext.Logger().V(2).Info("inventory add task completing", "task", i.Name())
 	taskContext.TaskChannel() <- taskrunner.TaskResult{
 		Err: err,
 	}
@@ -4,7 +4,6 @@
 package task
 
 import (
-	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
@@ -54,9 +53,13 @@ func (i *InvSetTask) Identifiers() object.ObjMetadataSet {
 // Removed objects:
 // - Deleted resources (successful)
 // - Abandoned resources (successful)
+//
+// Deleted resources (successful) with a tombstone are stored as tombstones,
+// if the inventory supports them.
 func (i *InvSetTask) Start(taskContext *taskrunner.TaskContext) {
 	go func() {
-		klog.V(2).Infof("inventory set task starting (name: %q)", i.Name())
+		logger := taskContext.Logger().WithValues("task", i.Name())
+		logger.V(2).Info("inventory set task starting")
 		invObjs := object.ObjMetadataSet{}
 
 		// TODO: Just use InventoryManager.Store()
@@ -64,7 +67,7 @@ func (i *InvSetTask) Start(taskContext *taskrunner.TaskContext) {
 
 		// If an object applied successfully, keep or add it to the inventory.
 		appliedObjs := im.SuccessfulApplies()
-		klog.V(4).Infof("set inventory %d successful applies", len(appliedObjs))
+		logger.V(4).Info("set inventory successful applies", "count", len(appliedObjs))
 		invObjs = invObjs.Union(appliedObjs)
 
 		// If an object failed to apply and was previously stored in the inventory,
@@ -73,7 +76,7 @@ func (i *InvSetTask) Start(taskContext *taskrunner.TaskContext) {
 		// because even tho they were added by InvAddTask, the PrevInventory
 		// represents the inventory before the pipeline has run.
 		applyFailures := i.PrevInventory.Intersection(im.FailedApplies())
-		klog.V(4).Infof("keep in inventory %d failed applies", len(applyFailures))
+		logger.V(4).Info("keep in inventory failed applies", "count", len(applyFailures))
 		invObjs = invObjs.Union(applyFailures)
 
 		// If an object skipped apply and was previously stored in the inventory,
@@ -82,7 +85,7 @@ func (i *InvSetTask) Start(taskContext *taskrunner.TaskContext) {
 		// because the apply filters all currently depend on cluster state,
 		// but we're doing the intersection anyway just to be sure.
 		applySkips := i.PrevInventory.Intersection(im.SkippedApplies())
-		klog.V(4).Infof("keep in inventory %d skipped applies", len(applySkips))
+		logger.V(4).Info("keep in inventory skipped applies", "count", len(applySkips))
 		invObjs = invObjs.Union(applySkips)
 
 		// If an object failed to delete and was previously stored in the inventory,
@@ -91,7 +94,7 @@ func (i *InvSetTask) Start(taskContext *taskrunner.TaskContext) {
 		// because the set of resources to prune comes from the inventory,
 		// but we're doing the intersection anyway just to be sure.
 		pruneFailures := i.PrevInventory.Intersection(im.FailedDeletes())
-		klog.V(4).Infof("set inventory %d failed prunes", len(pruneFailures))
+		logger.V(4).Info("set inventory failed prunes", "count", len(pruneFailures))
 		invObjs = invObjs.Union(pruneFailures)
 
 		// If an object skipped delete and was previously stored in the inventory,
@@ -100,27 +103,27 @@ func (i *InvSetTask) Start(taskContext *taskrunner.TaskContext) {
 		// because the set of resources to prune comes from the inventory,
 		// but we're doing the intersection anyway just to be sure.
 		pruneSkips := i.PrevInventory.Intersection(im.SkippedDeletes())
-		klog.V(4).Infof("keep in inventory %d skipped prunes", len(pruneSkips))
+		logger.V(4).Info("keep in inventory skipped prunes", "count", len(pruneSkips))
 		invObjs = invObjs.Union(pruneSkips)
 
 		// If an object is abandoned, then remove it from the inventory.
 		abandonedObjects := taskContext.AbandonedObjects()
-		klog.V(4).Infof("remove from inventory %d abandoned objects", len(abandonedObjects))
+		logger.V(4).Info("remove from inventory abandoned objects", "count", len(abandonedObjects))
 		invObjs = invObjs.Diff(abandonedObjects)
 
 		// If an object is invalid and was previously stored in the inventory,
 		// then keep it in the inventory so it can be applied/pruned next time.
 		invalidObjects := i.PrevInventory.Intersection(taskContext.InvalidObjects())
-		klog.V(4).Infof("keep in inventory %d invalid objects", len(invalidObjects))
+		logger.V(4).Info("keep in inventory invalid objects", "count", len(invalidObjects))
 		invObjs = invObjs.Union(invalidObjects)
 
-		klog.V(4).Infof("get the apply status for %d objects", len(invObjs))
+		logger.V(4).Info("get the apply status for objects", "count", len(invObjs))
 		objStatus := taskContext.InventoryManager().Inventory().Status.Objects
 
-		klog.V(4).Infof("set inventory %d total objects", len(invObjs))
+		logger.V(4).Info("set inventory total objects", "count", len(invObjs))
 		err := i.InvClient.Replace(i.InvInfo, invObjs, objStatus, i.DryRun)
 
-		klog.V(2).Infof("inventory set task completing (name: %q)", i.Name())
+		logger.V(2).Info("inventory set task completing")
 		taskContext.TaskChannel() <- taskrunner.TaskResult{Err: err}
 	}()
 }
@@ -0,0 +1,65 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// LegacyAdoptTask adopts the objects previously applied with
+// `kubectl apply --prune` into the inventory, before they are applied or
+// pruned. The owning-inventory annotation is patched on the objects in the
+// cluster, and the objects are merged into the inventory, so that objects
+// which fail to be pruned are retained in the inventory.
+type LegacyAdoptTask struct {
+	TaskName  string
+	Client    dynamic.Interface
+	Mapper    meta.RESTMapper
+	InvClient inventory.Client
+	InvInfo   inventory.Info
+	Objects   object.UnstructuredSet
+	DryRun    common.DryRunStrategy
+}
+
+func (l *LegacyAdoptTask) Name() string {
+	return l.TaskName
+}
+
+func (l *LegacyAdoptTask) Action() event.ResourceAction {
+	return event.InventoryAction
+}
+
+func (l *LegacyAdoptTask) Identifiers() object.ObjMetadataSet {
+	return object.UnstructuredSetToObjMetadataSet(l.Objects)
+}
+
+// Start annotates the legacy objects and merges them into the inventory.
+func (l *LegacyAdoptTask) Start(taskContext *taskrunner.TaskContext) {
+	go func() {
+		logger := taskContext.Logger().WithValues("task", l.Name())
+		logger.V(2).Info("legacy adopt task starting", "objects", len(l.Objects))
+		converted, err := inventory.ConvertLegacyPruneObjects(taskContext.Context(), l.Client, l.Mapper,
+			l.InvInfo, l.Objects, l.DryRun)
+		if err == nil && len(converted) > 0 && !l.DryRun.ClientOrServerDryRun() {
+			logger.V(4).Info("merging legacy objects into inventory", "count", len(converted))
+			_, err = l.InvClient.Merge(l.InvInfo, object.UnstructuredSetToObjMetadataSet(converted), l.DryRun)
+		}
+		logger.V(2).Info("legacy adopt task completing")
+		taskContext.TaskChannel() <- taskrunner.TaskResult{
+			Err: err,
+		}
+	}()
+}
+
+// Cancel is not supported by the LegacyAdoptTask.
+func (l *LegacyAdoptTask) Cancel(_ *taskrunner.TaskContext) {}
+
+// StatusUpdate is not supported by the LegacyAdoptTask.
+func (l *LegacyAdoptTask) StatusUpdate(_ *taskrunner.TaskContext, _ object.ObjMetadata) {}
@@ -0,0 +1,78 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/cli-utils/pkg/apply/cache"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestLegacyAdoptTask(t *testing.T) {
+	podGVR := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	legacyPod := obj1.DeepCopy()
+	legacyPod.SetAnnotations(map[string]string{corev1.LastAppliedConfigAnnotation: "{}"})
+	id1 := object.UnstructuredToObjMetadata(legacyPod)
+
+	tests := map[string]struct {
+		dryRun          common.DryRunStrategy
+		expectedObjs    object.ObjMetadataSet
+		expectedAdopted bool
+	}{
+		"adopts legacy objects": {
+			dryRun:          common.DryRunNone,
+			expectedObjs:    object.ObjMetadataSet{id1},
+			expectedAdopted: true,
+		},
+		"dry run changes nothing": {
+			dryRun:       common.DryRunClient,
+			expectedObjs: object.ObjMetadataSet{},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), legacyPod.DeepCopy())
+			invClient := inventory.NewFakeClient(object.ObjMetadataSet{})
+			taskContext := taskrunner.NewTaskContext(make(chan event.Event), cache.NewResourceCacheMap())
+
+			task := LegacyAdoptTask{
+				TaskName:  taskName,
+				Client:    client,
+				Mapper:    testutil.NewFakeRESTMapper(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}),
+				InvClient: invClient,
+				InvInfo:   localInv,
+				Objects:   object.UnstructuredSet{legacyPod.DeepCopy()},
+				DryRun:    tc.dryRun,
+			}
+			assert.Equal(t, object.ObjMetadataSet{id1}, task.Identifiers())
+			task.Start(taskContext)
+			result := <-taskContext.TaskChannel()
+			require.NoError(t, result.Err)
+
+			actual, err := invClient.GetClusterObjs(nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedObjs, actual)
+
+			live, err := client.Resource(podGVR).Namespace(namespace).
+				Get(context.Background(), legacyPod.GetName(), metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAdopted, inventory.IDMatch(localInv, live) == inventory.Match)
+		})
+	}
+}
@@ -27,9 +27,10 @@ type KubectlPrinterAdapter struct {
 // resourcePrinterImpl implements the ResourcePrinter interface. But
 // instead of printing, it emits information on the provided channel.
 type resourcePrinterImpl struct {
-	applyStatus event.ApplyEventStatus
-	ch          chan<- event.Event
-	groupName   string
+	applyStatus    event.ApplyEventStatus
+	applyOperation event.ApplyEventOperation
+	ch             chan<- event.Event
+	groupName      string
 }
 
 // PrintObj takes the provided object and operation and emits
@@ -45,6 +46,7 @@ func (r *resourcePrinterImpl) PrintObj(obj runtime.Object, _ io.Writer) error {
 			GroupName:  r.groupName,
 			Identifier: id,
 			Status:     r.applyStatus,
+			Operation:  r.applyOperation,
 			Resource:   obj.(*unstructured.Unstructured),
 		},
 	}
@@ -57,26 +59,27 @@ type toPrinterFunc func(string) (printers.ResourcePrinter, error)
 // is the type required by the ApplyOptions.
 func (p *KubectlPrinterAdapter) toPrinterFunc() toPrinterFunc {
 	return func(operation string) (printers.ResourcePrinter, error) {
-		applyStatus, err := kubectlOperationToApplyStatus(operation)
+		applyOperation, err := kubectlOperationToApplyOperation(operation)
 		return &resourcePrinterImpl{
-			ch:          p.ch,
-			applyStatus: applyStatus,
-			groupName:   p.groupName,
+			ch:             p.ch,
+			applyStatus:    event.ApplySuccessful,
+			applyOperation: applyOperation,
+			groupName:      p.groupName,
 		}, err
 	}
 }
 
-func kubectlOperationToApplyStatus(operation string) (event.ApplyEventStatus, error) {
+func kubectlOperationToApplyOperation(operation string) (event.ApplyEventOperation, error) {
 	switch operation {
 	case "serverside-applied":
-		return event.ApplySuccessful, nil
+		return event.ApplyServersideApplied, nil
 	case "created":
-		return event.ApplySuccessful, nil
+		return event.ApplyCreated, nil
 	case "unchanged":
-		return event.ApplySuccessful, nil
+		return event.ApplyUnchanged, nil
 	case "configured":
-		return event.ApplySuccessful, nil
+		return event.ApplyConfigured, nil
 	default:
-		return event.ApplyEventStatus(0), fmt.Errorf("unknown operation %s", operation)
+		return event.ApplyUnspecified, fmt.Errorf("unknown operation %s", operation)
 	}
 }
@@ -51,5 +51,48 @@ func TestKubectlPrinterAdapter(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.Equal(t, event.ApplySuccessful, msg.ApplyEvent.Status)
+	assert.Equal(t, event.ApplyServersideApplied, msg.ApplyEvent.Operation)
 	assert.Equal(t, deployment, msg.ApplyEvent.Resource)
 }
+
+func TestKubectlOperationToApplyOperation(t *testing.T) {
+	testCases := map[string]struct {
+		operation   string
+		expected    event.ApplyEventOperation
+		expectedErr bool
+	}{
+		"created": {
+			operation: "created",
+			expected:  event.ApplyCreated,
+		},
+		"configured": {
+			operation: "configured",
+			expected:  event.ApplyConfigured,
+		},
+		"unchanged": {
+			operation: "unchanged",
+			expected:  event.ApplyUnchanged,
+		},
+		"serverside-applied": {
+			operation: "serverside-applied",
+			expected:  event.ApplyServersideApplied,
+		},
+		"unknown": {
+			operation:   "deleted",
+			expected:    event.ApplyUnspecified,
+			expectedErr: true,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			op, err := kubectlOperationToApplyOperation(tc.operation)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, op)
+		})
+	}
+}
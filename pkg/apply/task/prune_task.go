@@ -5,7 +5,6 @@ package task
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/filter"
 	"sigs.k8s.io/cli-utils/pkg/apply/prune"
@@ -28,6 +27,12 @@ type PruneTask struct {
 	// True if we are destroying, which deletes the inventory object
 	// as well (possibly) the inventory namespace.
 	Destroy bool
+	// True if pruned objects should be recorded as tombstones in the
+	// inventory.
+	Tombstones bool
+	// RetryPolicy defines how deletes that fail with a transient error are
+	// retried.
+	RetryPolicy common.RetryPolicy
 }
 
 func (p *PruneTask) Name() string {
@@ -52,8 +57,8 @@ func (p *PruneTask) Identifiers() object.ObjMetadataSet {
 // to signal to the taskrunner that the task has completed (or failed).
 func (p *PruneTask) Start(taskContext *taskrunner.TaskContext) {
 	go func() {
-		klog.V(2).Infof("prune task starting (name: %q, objects: %d)",
-			p.Name(), len(p.Objects))
+		logger := taskContext.Logger().WithValues("task", p.Name())
+		logger.V(2).Info("prune task starting", "objects", len(p.Objects))
 		// Create filter to prevent deletion of currently applied
 		// objects. Must be done here to wait for applied UIDs.
 		uidFilter := filter.CurrentUIDFilter{
@@ -69,16 +74,19 @@ func (p *PruneTask) Start(taskContext *taskrunner.TaskContext) {
 				DryRunStrategy:    p.DryRunStrategy,
 				PropagationPolicy: p.PropagationPolicy,
 				Destroy:           p.Destroy,
+				Tombstones:        p.Tombstones,
+				RetryPolicy:       p.RetryPolicy,
 			},
 		)
-		klog.V(2).Infof("prune task completing (name: %q)", p.Name())
+		logger.V(2).Info("prune task completing")
 		taskContext.TaskChannel() <- taskrunner.TaskResult{
 			Err: err,
 		}
 	}()
 }
 
-// Cancel is not supported by the PruneTask.
+// Cancel is handled by skipping the objects that are not pruned yet, once
+// the TaskContext is cancelled. Objects being pruned are not interrupted.
 func (p *PruneTask) Cancel(_ *taskrunner.TaskContext) {}
 
 // StatusUpdate is not supported by the PruneTask.
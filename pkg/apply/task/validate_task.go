@@ -0,0 +1,228 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	"k8s.io/kubectl/pkg/util/openapi"
+	schemavalidation "k8s.io/kubectl/pkg/util/openapi/validation"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/info"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/mutation"
+	"sigs.k8s.io/cli-utils/pkg/object/validation"
+)
+
+// ValidateTask validates all the objects to apply before any of them is
+// applied. Each object is checked against the OpenAPI schema of the
+// cluster, then applied with a server-side dry-run. A ValidationEvent is
+// sent for each invalid object. With the ExitEarly ValidationPolicy, the
+// task fails if any object is invalid, so that the run ends before the
+// cluster is changed. With SkipInvalid, the invalid objects are registered
+// in the TaskContext, so that they are skipped by the apply task and kept
+// in the inventory.
+//
+// Errors caused by objects that do not exist yet are ignored: objects of
+// types that are not registered yet, and objects in namespaces that are
+// created by the same run. Objects with apply-time mutations are not
+// validated, because their content is only known once they are applied.
+type ValidateTask struct {
+	TaskName string
+
+	DynamicClient     dynamic.Interface
+	OpenAPIGetter     discovery.OpenAPISchemaInterface
+	InfoHelper        info.Helper
+	Mapper            meta.RESTMapper
+	Objects           object.UnstructuredSet
+	ServerSideOptions common.ServerSideOptions
+	ValidationPolicy  validation.Policy
+}
+
+func (v *ValidateTask) Name() string {
+	return v.TaskName
+}
+
+func (v *ValidateTask) Action() event.ResourceAction {
+	return event.ValidateAction
+}
+
+func (v *ValidateTask) Identifiers() object.ObjMetadataSet {
+	return object.UnstructuredSetToObjMetadataSet(v.Objects)
+}
+
+// Start validates the objects, one at a time, and sends the TaskResult once
+// all of them are validated.
+func (v *ValidateTask) Start(taskContext *taskrunner.TaskContext) {
+	go func() {
+		logger := taskContext.Logger().WithValues("task", v.Name())
+		ctx := klog.NewContext(taskContext.Context(), logger)
+		logger.V(2).Info("validate task starting", "objects", len(v.Objects))
+		schema, err := v.schemaValidation()
+		if err != nil {
+			v.sendTaskResult(taskContext, fmt.Errorf("failed to read the OpenAPI schema: %w", err))
+			return
+		}
+		namespaces := localNamespaces(v.Objects)
+		invalid := 0
+		for _, obj := range v.Objects {
+			id := object.UnstructuredToObjMetadata(obj)
+			source := object.UnstructuredSource(obj)
+			if mutation.HasAnnotation(obj) {
+				logger.V(4).Info("skipping validation of object with apply-time mutations", "object", id)
+				continue
+			}
+			if err := v.validateObject(ctx, obj, schema, namespaces); err != nil {
+				logger.V(4).Info("object failed validation", "object", id, "reason", err)
+				taskContext.SendEvent(event.Event{
+					Type: event.ValidationType,
+					ValidationEvent: event.ValidationEvent{
+						Identifiers: object.ObjMetadataSet{id},
+						Error:       validation.NewError(err, id).WithSources(source),
+					},
+				})
+				invalid++
+				if v.ValidationPolicy == validation.SkipInvalid {
+					taskContext.AddInvalidObject(id)
+				}
+			}
+		}
+		logger.V(2).Info("validate task completing", "invalid", invalid)
+		if invalid > 0 && v.ValidationPolicy != validation.SkipInvalid {
+			v.sendTaskResult(taskContext, fmt.Errorf("%d of %d objects failed validation", invalid, len(v.Objects)))
+			return
+		}
+		v.sendTaskResult(taskContext, nil)
+	}()
+}
+
+// schemaValidation returns the validation of the objects against the OpenAPI
+// schema, or nil if the schema is not available.
+func (v *ValidateTask) schemaValidation() (*schemavalidation.SchemaValidation, error) {
+	if v.OpenAPIGetter == nil {
+		return nil, nil
+	}
+	doc, err := v.OpenAPIGetter.OpenAPISchema()
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, nil
+	}
+	resources, err := openapi.NewOpenAPIData(doc)
+	if err != nil {
+		return nil, err
+	}
+	return schemavalidation.NewSchemaValidation(resources), nil
+}
+
+// validateObject checks the object against the schema, if any, then applies
+// it with a server-side dry-run.
+func (v *ValidateTask) validateObject(ctx context.Context, obj *unstructured.Unstructured,
+	schema *schemavalidation.SchemaValidation, namespaces sets.String) error {
+	logger := klog.FromContext(ctx)
+	info, err := v.InfoHelper.BuildInfo(obj)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			// The type is registered by a CRD applied in the same run.
+			// Unknown types are reported by the earlier validation.
+			logger.V(4).Info("skipping validation of object with unknown type", "object", object.UnstructuredToObjMetadata(obj))
+			return nil
+		}
+		return err
+	}
+	// BuildInfo strips path annotations.
+	obj = info.Object.(*unstructured.Unstructured)
+	if schema != nil {
+		data, err := json.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		if err := schema.ValidateBytes(data); err != nil {
+			return err
+		}
+	}
+	err = v.dryRun(ctx, obj, info)
+	if err != nil && apierrors.IsNotFound(err) && namespaces.Has(obj.GetNamespace()) {
+		logger.V(4).Info("skipping validation of object in new namespace", "object", object.UnstructuredToObjMetadata(obj))
+		return nil
+	}
+	return err
+}
+
+// dryRun applies the object with a server-side dry-run. Objects with a
+// generated name are created with a server-side dry-run instead.
+func (v *ValidateTask) dryRun(ctx context.Context, obj *unstructured.Unstructured, info *resource.Info) error {
+	if object.HasGeneratedName(obj) {
+		gvk := obj.GroupVersionKind()
+		mapping, err := v.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return err
+		}
+		_, err = v.DynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace()).
+			Create(ctx, obj, metav1.CreateOptions{
+				DryRun:          []string{metav1.DryRunAll},
+				FieldManager:    v.ServerSideOptions.FieldManager,
+				FieldValidation: v.ServerSideOptions.FieldValidation,
+			})
+		return err
+	}
+	serverSideOptions := v.ServerSideOptions
+	if !serverSideOptions.ServerSideApply {
+		// Objects applied client-side do not conflict with other field
+		// managers, so the server-side dry-run must not either.
+		serverSideOptions.ForceConflicts = true
+	}
+	// The events of the dry-run are not sent.
+	eventChannel := make(chan event.Event)
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		for range eventChannel {
+		}
+	}()
+	ao := applyOptionsFactoryFunc(v.Name(), eventChannel, serverSideOptions,
+		common.DryRunServer, v.DynamicClient, v.OpenAPIGetter)
+	ao.SetObjects([]*resource.Info{info})
+	err := ao.Run()
+	close(eventChannel)
+	<-drained
+	return err
+}
+
+// localNamespaces returns the names of the namespaces in the objects.
+func localNamespaces(objs object.UnstructuredSet) sets.String {
+	namespaces := sets.NewString()
+	for _, obj := range objs {
+		if object.IsKindNamespace(obj) {
+			namespaces.Insert(obj.GetName())
+		}
+	}
+	return namespaces
+}
+
+func (v *ValidateTask) sendTaskResult(taskContext *taskrunner.TaskContext, err error) {
+	taskContext.TaskChannel() <- taskrunner.TaskResult{
+		Err: err,
+	}
+}
+
+// Cancel is not supported by the ValidateTask.
+func (v *ValidateTask) Cancel(_ *taskrunner.TaskContext) {}
+
+// StatusUpdate is not supported by the ValidateTask.
+func (v *ValidateTask) StatusUpdate(_ *taskrunner.TaskContext, _ object.ObjMetadata) {}
@@ -0,0 +1,391 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	clienttesting "k8s.io/client-go/testing"
+	prototesting "k8s.io/kube-openapi/pkg/util/proto/testing"
+	"sigs.k8s.io/cli-utils/pkg/apply/cache"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/info"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/validation"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+var configMapGK = schema.GroupKind{Kind: "ConfigMap"}
+
+// dryRunApplyOptions fails the apply of the objects with an error.
+type dryRunApplyOptions struct {
+	errors  map[string]error
+	objects []*resource.Info
+	applied []string
+}
+
+func (f *dryRunApplyOptions) Run() error {
+	for _, obj := range f.objects {
+		if err, found := f.errors[obj.Name]; found {
+			return err
+		}
+		f.applied = append(f.applied, obj.Name)
+	}
+	return nil
+}
+
+func (f *dryRunApplyOptions) SetObjects(objects []*resource.Info) {
+	f.objects = objects
+}
+
+func TestValidateTask(t *testing.T) {
+	testCases := map[string]struct {
+		objs              object.UnstructuredSet
+		serverSideOptions common.ServerSideOptions
+		validationPolicy  validation.Policy
+		dryRunErrors      map[string]error
+		expectedApplied   []string
+		expectedForce     bool
+		expectedInvalid   object.ObjMetadataSet
+		expectedErrors    []string
+	}{
+		"valid objects": {
+			objs: object.UnstructuredSet{
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: default
+data:
+  key: value
+`),
+			},
+			expectedApplied: []string{"foo"},
+			expectedForce:   true,
+		},
+		"server-side apply keeps the conflict option": {
+			objs: object.UnstructuredSet{
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: default
+`),
+			},
+			serverSideOptions: common.ServerSideOptions{
+				ServerSideApply: true,
+			},
+			expectedApplied: []string{"foo"},
+			expectedForce:   false,
+		},
+		"schema error": {
+			objs: object.UnstructuredSet{
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: default
+datum:
+  key: value
+`),
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: bar
+  namespace: default
+`),
+			},
+			expectedApplied: []string{"bar"},
+			expectedForce:   true,
+			expectedInvalid: object.ObjMetadataSet{
+				{GroupKind: configMapGK, Namespace: "default", Name: "foo"},
+			},
+			expectedErrors: []string{`ValidationError(ConfigMap): unknown field "datum"`},
+		},
+		"dry-run error": {
+			objs: object.UnstructuredSet{
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: default
+`),
+			},
+			dryRunErrors: map[string]error{
+				"foo": apierrors.NewInvalid(configMapGK, "foo", field.ErrorList{
+					field.Invalid(field.NewPath("metadata", "labels"), "-", "invalid label value"),
+				}),
+			},
+			expectedForce: true,
+			expectedInvalid: object.ObjMetadataSet{
+				{GroupKind: configMapGK, Namespace: "default", Name: "foo"},
+			},
+			expectedErrors: []string{`ConfigMap "foo" is invalid`},
+		},
+		"skip invalid objects": {
+			objs: object.UnstructuredSet{
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: default
+`),
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: bar
+  namespace: default
+`),
+			},
+			validationPolicy: validation.SkipInvalid,
+			dryRunErrors: map[string]error{
+				"foo": apierrors.NewInvalid(configMapGK, "foo", field.ErrorList{
+					field.Invalid(field.NewPath("metadata", "labels"), "-", "invalid label value"),
+				}),
+			},
+			expectedApplied: []string{"bar"},
+			expectedForce:   true,
+			expectedInvalid: object.ObjMetadataSet{
+				{GroupKind: configMapGK, Namespace: "default", Name: "foo"},
+			},
+			expectedErrors: []string{`ConfigMap "foo" is invalid`},
+		},
+		"object in a namespace created by the run": {
+			objs: object.UnstructuredSet{
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: new
+`),
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: new
+`),
+			},
+			dryRunErrors: map[string]error{
+				"foo": apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "new"),
+			},
+			expectedApplied: []string{"new"},
+			expectedForce:   true,
+		},
+		"object in a missing namespace": {
+			objs: object.UnstructuredSet{
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: missing
+`),
+			},
+			dryRunErrors: map[string]error{
+				"foo": apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "missing"),
+			},
+			expectedForce: true,
+			expectedInvalid: object.ObjMetadataSet{
+				{GroupKind: configMapGK, Namespace: "missing", Name: "foo"},
+			},
+			expectedErrors: []string{`namespaces "missing" not found`},
+		},
+		"object with apply-time mutations": {
+			objs: object.UnstructuredSet{
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: default
+  annotations:
+    config.kubernetes.io/apply-time-mutation: |
+      - sourceRef:
+          kind: ConfigMap
+          name: bar
+        sourcePath: $.data.key
+        targetPath: $.data.key
+data:
+  key: 1
+`),
+			},
+			dryRunErrors: map[string]error{
+				"foo": apierrors.NewBadRequest("unresolved mutation"),
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			eventChannel := make(chan event.Event)
+			taskContext := taskrunner.NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+
+			var force []bool
+			ao := &dryRunApplyOptions{errors: tc.dryRunErrors}
+			oldAO := applyOptionsFactoryFunc
+			applyOptionsFactoryFunc = func(_ string, _ chan<- event.Event, serverSideOptions common.ServerSideOptions,
+				strategy common.DryRunStrategy, _ dynamic.Interface, _ discovery.OpenAPISchemaInterface) applyOptions {
+				assert.Equal(t, common.DryRunServer, strategy)
+				force = append(force, serverSideOptions.ForceConflicts)
+				return ao
+			}
+			defer func() { applyOptionsFactoryFunc = oldAO }()
+
+			validateTask := &ValidateTask{
+				TaskName:          "validate-0",
+				OpenAPIGetter:     &prototesting.Fake{Path: "testdata/swagger.json"},
+				InfoHelper:        &fakeInfoHelper{},
+				Mapper:            testutil.NewFakeRESTMapper(),
+				Objects:           tc.objs,
+				ServerSideOptions: tc.serverSideOptions,
+				ValidationPolicy:  tc.validationPolicy,
+			}
+
+			var events []event.Event
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for e := range eventChannel {
+					events = append(events, e)
+				}
+			}()
+
+			validateTask.Start(taskContext)
+			result := <-taskContext.TaskChannel()
+			close(eventChannel)
+			wg.Wait()
+
+			assert.Equal(t, tc.expectedApplied, ao.applied)
+			for _, f := range force {
+				assert.Equal(t, tc.expectedForce, f)
+			}
+			if len(tc.expectedInvalid) == 0 {
+				assert.NoError(t, result.Err)
+				assert.Empty(t, events)
+				return
+			}
+			if tc.validationPolicy == validation.SkipInvalid {
+				// The invalid objects are skipped by the apply task.
+				assert.NoError(t, result.Err)
+				assert.Equal(t, tc.expectedInvalid, taskContext.InvalidObjects())
+			} else {
+				assert.Error(t, result.Err)
+				assert.Empty(t, taskContext.InvalidObjects())
+			}
+			require.Len(t, events, len(tc.expectedInvalid))
+			for i, e := range events {
+				assert.Equal(t, event.ValidationType, e.Type)
+				assert.Equal(t, object.ObjMetadataSet{tc.expectedInvalid[i]}, e.ValidationEvent.Identifiers)
+				assert.Contains(t, e.ValidationEvent.Error.Error(), tc.expectedErrors[i])
+			}
+		})
+	}
+}
+
+func TestValidateTask_UnknownType(t *testing.T) {
+	eventChannel := make(chan event.Event)
+	defer close(eventChannel)
+	taskContext := taskrunner.NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+
+	oldAO := applyOptionsFactoryFunc
+	applyOptionsFactoryFunc = func(string, chan<- event.Event, common.ServerSideOptions, common.DryRunStrategy,
+		dynamic.Interface, discovery.OpenAPISchemaInterface) applyOptions {
+		t.Error("objects of unknown types must not be applied")
+		return &dryRunApplyOptions{}
+	}
+	defer func() { applyOptionsFactoryFunc = oldAO }()
+
+	var infoHelper info.Helper = &staleInfoHelper{
+		mapper: &resettableRESTMapper{RESTMapper: testutil.NewFakeRESTMapper()},
+	}
+	validateTask := &ValidateTask{
+		TaskName:   "validate-0",
+		InfoHelper: infoHelper,
+		Objects: object.UnstructuredSet{
+			testutil.Unstructured(t, `
+apiVersion: custom.io/v1
+kind: Custom
+metadata:
+  name: foo
+  namespace: default
+`),
+		},
+	}
+
+	validateTask.Start(taskContext)
+	result := <-taskContext.TaskChannel()
+	assert.NoError(t, result.Err)
+}
+
+func TestValidateTask_GeneratedName(t *testing.T) {
+	eventChannel := make(chan event.Event)
+	taskContext := taskrunner.NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+
+	obj := testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  generateName: foo-
+  namespace: default
+`)
+	client := fake.NewSimpleDynamicClient(scheme.Scheme)
+	client.PrependReactor("create", "configmaps", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		created := action.(clienttesting.CreateAction).GetObject().(*unstructured.Unstructured)
+		return true, nil, apierrors.NewInvalid(configMapGK, created.GetGenerateName(), field.ErrorList{
+			field.Invalid(field.NewPath("metadata", "generateName"), created.GetGenerateName(), "invalid name"),
+		})
+	})
+
+	validateTask := &ValidateTask{
+		TaskName:      "validate-0",
+		DynamicClient: client,
+		InfoHelper:    &fakeInfoHelper{},
+		Mapper:        testutil.NewFakeRESTMapper(obj.GroupVersionKind()),
+		Objects:       object.UnstructuredSet{obj},
+	}
+
+	var events []event.Event
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for e := range eventChannel {
+			events = append(events, e)
+		}
+	}()
+
+	validateTask.Start(taskContext)
+	result := <-taskContext.TaskChannel()
+	close(eventChannel)
+	wg.Wait()
+
+	assert.EqualError(t, result.Err, "1 of 1 objects failed validation")
+	if assert.Len(t, events, 1) {
+		assert.Contains(t, events[0].ValidationEvent.Error.Error(), "invalid name")
+	}
+}
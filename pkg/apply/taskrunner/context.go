@@ -4,10 +4,16 @@
 package taskrunner
 
 import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/object/graph"
 )
@@ -22,6 +28,9 @@ func NewTaskContext(eventChannel chan event.Event, resourceCache cache.ResourceC
 		abandonedObjects: make(map[object.ObjMetadata]struct{}),
 		invalidObjects:   make(map[object.ObjMetadata]struct{}),
 		graph:            graph.New(),
+		actuationTimes:   make(map[object.ObjMetadata]time.Time),
+		generatedObjects: make(map[object.ObjMetadata]object.ObjMetadata),
+		logger:           klog.Background(),
 	}
 }
 
@@ -35,6 +44,15 @@ type TaskContext struct {
 	abandonedObjects map[object.ObjMetadata]struct{}
 	invalidObjects   map[object.ObjMetadata]struct{}
 	graph            *graph.Graph
+	timingEnabled    bool
+	actuationTimes   map[object.ObjMetadata]time.Time
+	generatedObjects map[object.ObjMetadata]object.ObjMetadata
+	generatedMu      sync.RWMutex
+	logger           logr.Logger
+	cancelMu         sync.RWMutex
+	cancelReason     error
+	ctx              context.Context
+	statusWatcher    watcher.StatusWatcher
 }
 
 func (tc *TaskContext) TaskChannel() chan TaskResult {
@@ -61,9 +79,20 @@ func (tc *TaskContext) SetGraph(g *graph.Graph) {
 	tc.graph = g
 }
 
+// Logger returns the logger for the tasks. By default, this logs to klog.
+func (tc *TaskContext) Logger() logr.Logger {
+	return tc.logger
+}
+
+// SetLogger sets the logger for the tasks, usually with values that identify
+// the run, like the inventory ID.
+func (tc *TaskContext) SetLogger(logger logr.Logger) {
+	tc.logger = logger
+}
+
 // SendEvent sends an event on the event channel
 func (tc *TaskContext) SendEvent(e event.Event) {
-	klog.V(3).Infof("Sending event: %v", e)
+	tc.logger.V(3).Info("sending event", "event", e)
 	tc.eventChannel <- e
 }
 
@@ -98,3 +127,106 @@ func (tc *TaskContext) AddInvalidObject(id object.ObjMetadata) {
 func (tc *TaskContext) InvalidObjects() object.ObjMetadataSet {
 	return object.ObjMetadataSetFromMap(tc.invalidObjects)
 }
+
+// EnableTiming enables recording the timing of each object in the apply,
+// prune, delete, and wait events.
+func (tc *TaskContext) EnableTiming() {
+	tc.timingEnabled = true
+}
+
+// TimingEnabled returns true if the timing of each object is recorded.
+func (tc *TaskContext) TimingEnabled() bool {
+	return tc.timingEnabled
+}
+
+// SendEventWithTiming sends the event with its timing set from the passed
+// start time until now, if timing is enabled.
+func (tc *TaskContext) SendEventWithTiming(e event.Event, start time.Time) {
+	if tc.timingEnabled {
+		e = e.WithTiming(event.Timing{
+			Start: start,
+			End:   time.Now(),
+		})
+	}
+	tc.SendEvent(e)
+}
+
+// SetActuationTime records when the object was successfully applied or
+// deleted, if timing is enabled. The WaitTask uses it to time the
+// reconciliation of the object.
+func (tc *TaskContext) SetActuationTime(id object.ObjMetadata, t time.Time) {
+	if tc.timingEnabled {
+		tc.actuationTimes[id] = t
+	}
+}
+
+// ActuationTime returns when the object was successfully applied or
+// deleted, if recorded.
+func (tc *TaskContext) ActuationTime(id object.ObjMetadata) (time.Time, bool) {
+	t, found := tc.actuationTimes[id]
+	return t, found
+}
+
+// AddGeneratedObject records the id of an object created with a name
+// generated by the server, for the id of its generateName.
+func (tc *TaskContext) AddGeneratedObject(id, created object.ObjMetadata) {
+	tc.generatedMu.Lock()
+	defer tc.generatedMu.Unlock()
+	tc.generatedObjects[id] = created
+}
+
+// GeneratedObject returns the id of the object created for the id of a
+// generateName, if it was created.
+func (tc *TaskContext) GeneratedObject(id object.ObjMetadata) (object.ObjMetadata, bool) {
+	tc.generatedMu.RLock()
+	defer tc.generatedMu.RUnlock()
+	created, found := tc.generatedObjects[id]
+	return created, found
+}
+
+// setContext sets the context of the run, passed to the requests of the
+// tasks.
+func (tc *TaskContext) setContext(ctx context.Context) {
+	tc.ctx = ctx
+}
+
+// setStatusWatcher sets the StatusWatcher watching the objects of the run.
+func (tc *TaskContext) setStatusWatcher(statusWatcher watcher.StatusWatcher) {
+	tc.statusWatcher = statusWatcher
+}
+
+// watchIdentifiers adds the objects to the objects watched by the
+// StatusWatcher of the run, if it only watches objects by name.
+func (tc *TaskContext) watchIdentifiers(ids object.ObjMetadataSet) error {
+	updater, ok := tc.statusWatcher.(watcher.IdentifierUpdater)
+	if !ok || len(ids) == 0 {
+		return nil
+	}
+	return updater.AddIdentifiers(ids)
+}
+
+// Context returns the context of the run, for the requests of the tasks.
+// It carries the logger of the run, and is cancelled when the run is
+// cancelled, or at the end of the grace period of a graceful cancellation.
+func (tc *TaskContext) Context() context.Context {
+	if tc.ctx == nil {
+		return klog.NewContext(context.Background(), tc.logger)
+	}
+	return tc.ctx
+}
+
+// setCancelled records that the run was cancelled gracefully, and the
+// running task must skip the objects it has not started yet.
+func (tc *TaskContext) setCancelled(reason error) {
+	tc.cancelMu.Lock()
+	defer tc.cancelMu.Unlock()
+	tc.cancelReason = reason
+}
+
+// Cancelled returns the reason the run was cancelled gracefully, or nil if
+// it was not. Tasks check it before each object, after they are cancelled.
+func (tc *TaskContext) Cancelled() error {
+	tc.cancelMu.RLock()
+	defer tc.cancelMu.RUnlock()
+	return tc.cancelReason
+}
@@ -0,0 +1,45 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package taskrunner
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/jsonpath"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// ReadReadyWhen reads the ready-when annotation and validates the
+// expression by evaluating it against the object. Some invalid expressions
+// are only detected once the values they use are found, after the object is
+// reconciled by its controller. Returns false if the annotation is not
+// present.
+func ReadReadyWhen(obj *unstructured.Unstructured) (string, bool, error) {
+	if obj == nil {
+		return "", false, nil
+	}
+	expression, found := obj.GetAnnotations()[common.ReadyWhenAnnotation]
+	if !found {
+		return "", false, nil
+	}
+	if _, err := EvalReadyWhen(obj, expression); err != nil {
+		return "", false, object.InvalidAnnotationError{
+			Annotation: common.ReadyWhenAnnotation,
+			Cause:      err,
+		}
+	}
+	return expression, true, nil
+}
+
+// EvalReadyWhen evaluates the ready-when expression against the object.
+// Returns true if the expression is true, false if it is false or uses a
+// value that is not found, or an error if the expression is invalid or not
+// a boolean.
+//
+// The expression combines JSONPath values with comparison, logical, and
+// arithmetic operators. Paths may start at the root of the object, like
+// "status.phase", or with "$." like "$.status.phase".
+func EvalReadyWhen(obj *unstructured.Unstructured, expression string) (bool, error) {
+	return jsonpath.EvalCondition(obj.Object, expression)
+}
@@ -0,0 +1,128 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package taskrunner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+var testPVCYAML = `
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: data
+  namespace: default
+status:
+  phase: Bound
+  conditions:
+  - type: Resizing
+    status: "False"
+`
+
+func TestEvalReadyWhen(t *testing.T) {
+	testCases := map[string]struct {
+		expression    string
+		expectedReady bool
+		expectedError bool
+	}{
+		"path from root": {
+			expression:    "status.phase == 'Bound'",
+			expectedReady: true,
+		},
+		"jsonpath": {
+			expression:    "$.status.phase == 'Bound'",
+			expectedReady: true,
+		},
+		"false": {
+			expression:    "status.phase == 'Pending'",
+			expectedReady: false,
+		},
+		"logical operators": {
+			expression:    "status.phase == 'Bound' && (metadata.name == 'data' || metadata.name == 'logs')",
+			expectedReady: true,
+		},
+		"field selector": {
+			expression:    "status.conditions[?(@.type == 'Resizing')].status == 'False'",
+			expectedReady: true,
+		},
+		"quoted path is not changed": {
+			expression:    "'status.phase' == 'Bound'",
+			expectedReady: false,
+		},
+		"missing value": {
+			expression:    "status.capacity.storage == '1Gi'",
+			expectedReady: false,
+		},
+		"not a boolean": {
+			expression:    "status.phase",
+			expectedError: true,
+		},
+		"invalid expression": {
+			expression:    "status.phase ==",
+			expectedError: true,
+		},
+		"empty expression": {
+			expression:    " ",
+			expectedError: true,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			obj := testutil.Unstructured(t, testPVCYAML)
+			ready, err := EvalReadyWhen(obj, tc.expression)
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedReady, ready)
+		})
+	}
+}
+
+func TestReadReadyWhen(t *testing.T) {
+	testCases := map[string]struct {
+		annotations        map[string]string
+		expectedExpression string
+		expectedFound      bool
+		expectedError      bool
+	}{
+		"no annotation": {},
+		"valid expression": {
+			annotations: map[string]string{
+				common.ReadyWhenAnnotation: "status.phase == 'Bound'",
+			},
+			expectedExpression: "status.phase == 'Bound'",
+			expectedFound:      true,
+		},
+		"invalid expression returns error": {
+			annotations: map[string]string{
+				common.ReadyWhenAnnotation: "status.phase ==",
+			},
+			expectedError: true,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			obj := testutil.Unstructured(t, testPVCYAML)
+			obj.SetAnnotations(tc.annotations)
+			expression, found, err := ReadReadyWhen(obj)
+			if tc.expectedError {
+				var annotationErr object.InvalidAnnotationError
+				assert.ErrorAs(t, err, &annotationErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedExpression, expression)
+			assert.Equal(t, tc.expectedFound, found)
+		})
+	}
+}
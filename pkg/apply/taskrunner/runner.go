@@ -5,7 +5,9 @@ package taskrunner
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
@@ -35,6 +37,14 @@ type TaskStatusRunner struct {
 // the statusPoller.
 type Options struct {
 	EmitStatusEvents bool
+	// CancelGracePeriod enables graceful cancellation, if greater than zero.
+	// When the context is cancelled, no more tasks are started and the
+	// running task is given up to CancelGracePeriod to finish, before it is
+	// cancelled. The objects of the apply, prune, delete, and wait tasks that
+	// were not started are skipped, the inventory tasks are still run, so
+	// that the inventory records what was done, and Run returns a
+	// CancelledError.
+	CancelGracePeriod time.Duration
 }
 
 // Run executes the tasks in the taskqueue, with the statusPoller running in the
@@ -56,8 +66,17 @@ func (tsr *TaskStatusRunner) Run(
 	// Give the poller its own context and run it in the background.
 	// If taskStatusRunner.Run is cancelled, baseRunner.run will exit early,
 	// causing the poller to be cancelled.
-	statusCtx, cancelFunc := context.WithCancel(context.Background())
+	// The logger of the run is still passed on.
+	logger := taskContext.Logger()
+	statusCtx, cancelFunc := context.WithCancel(klog.NewContext(context.Background(), logger))
 	statusChannel := tsr.StatusWatcher.Watch(statusCtx, tsr.Identifiers, watcher.Options{})
+	taskContext.setStatusWatcher(tsr.StatusWatcher)
+
+	// The tasks get their own context, which is not cancelled with ctx
+	// during the grace period of a graceful cancellation, so the requests
+	// of the running task can finish.
+	taskCtx, cancelTasks := context.WithCancel(klog.NewContext(context.Background(), logger))
+	taskContext.setContext(taskCtx)
 
 	// complete stops the statusPoller, drains the statusChannel, and returns
 	// the provided error.
@@ -65,10 +84,11 @@ func (tsr *TaskStatusRunner) Run(
 	// Avoid using defer, otherwise the statusPoller will hang. It needs to be
 	// drained synchronously before return, instead of asynchronously after.
 	complete := func(err error) error {
-		klog.V(7).Info("Runner cancelled status watcher")
+		cancelTasks()
+		logger.V(7).Info("runner cancelled status watcher")
 		cancelFunc()
 		for statusEvent := range statusChannel {
-			klog.V(7).Infof("Runner ignored status event: %v", statusEvent)
+			logger.V(7).Info("runner ignored status event", "event", statusEvent)
 		}
 		return err
 	}
@@ -85,6 +105,12 @@ func (tsr *TaskStatusRunner) Run(
 	abort := false
 	var abortReason error
 
+	// drain is used to signal that the run is being cancelled gracefully,
+	// and the objects of the remaining tasks must be skipped before exiting.
+	// graceCh receives when the running task must be cancelled.
+	drain := false
+	var graceCh <-chan time.Time
+
 	// We do this so we can set the doneCh to a nil channel after
 	// it has been closed. This is needed to avoid a busy loop.
 	doneCh := ctx.Done()
@@ -106,17 +132,20 @@ func (tsr *TaskStatusRunner) Run(
 				continue
 			}
 
-			if abort {
-				klog.V(7).Infof("Runner ignored status event: %v", statusEvent)
+			// While draining, the running task still needs status updates,
+			// until the grace period expires.
+			if abort && graceCh == nil {
+				logger.V(7).Info("runner ignored status event", "event", statusEvent)
 				continue
 			}
-			klog.V(7).Infof("Runner received status event: %v", statusEvent)
+			logger.V(7).Info("runner received status event", "event", statusEvent)
 
 			// An error event on the statusChannel means the StatusWatcher
 			// has encountered a problem so it can't continue. This means
 			// the statusChannel will be closed soon.
 			if statusEvent.Type == pollevent.ErrorEvent {
 				abort = true
+				graceCh = nil
 				abortReason = fmt.Errorf("polling for status failed: %v",
 					statusEvent.Error)
 				if currentTask != nil {
@@ -192,6 +221,15 @@ func (tsr *TaskStatusRunner) Run(
 						currentTask.Action(), currentTask.Name(), msg.Err))
 			}
 			if abort {
+				if drain {
+					// The grace period only applies to the task that was
+					// running when the run was cancelled.
+					graceCh = nil
+					currentTask, done = drainTasks(taskQueue, taskContext, abortReason)
+					if !done {
+						continue
+					}
+				}
 				return complete(abortReason)
 			}
 			currentTask, done = nextTask(taskQueue, taskContext)
@@ -207,17 +245,147 @@ func (tsr *TaskStatusRunner) Run(
 			doneCh = nil // Set doneCh to nil so we don't enter a busy loop.
 			abort = true
 			abortReason = ctx.Err() // always non-nil when doneCh is closed
-			klog.V(7).Infof("Runner aborting: %v", abortReason)
+			if opts.CancelGracePeriod > 0 {
+				abortReason = &CancelledError{Err: abortReason}
+				drain = true
+				if currentTask == nil {
+					// tasks not started yet - only run the inventory tasks
+					currentTask, done = drainTasks(taskQueue, taskContext, abortReason)
+					if done {
+						return complete(abortReason)
+					}
+					continue
+				}
+				logger.V(7).Info("runner draining", "reason", abortReason, "gracePeriod", opts.CancelGracePeriod)
+				graceTimer := time.NewTimer(opts.CancelGracePeriod)
+				defer graceTimer.Stop()
+				graceCh = graceTimer.C
+				continue
+			}
+			logger.V(7).Info("runner aborting", "reason", abortReason)
+			cancelTasks()
 			if currentTask != nil {
 				currentTask.Cancel(taskContext)
 			} else {
 				// tasks not started yet - abort now
 				return complete(abortReason)
 			}
+		// The graceCh receives when the grace period of a graceful
+		// cancellation expires. The running task is cancelled, so that it
+		// skips the objects it has not started yet.
+		case <-graceCh:
+			graceCh = nil
+			logger.V(7).Info("runner cancelling task after grace period", "task", currentTask.Name())
+			taskContext.setCancelled(abortReason)
+			cancelTasks()
+			currentTask.Cancel(taskContext)
 		}
 	}
 }
 
+// drainTasks removes the remaining tasks from the taskQueue until it finds an
+// inventory task, which it starts, like nextTask. The other tasks are not
+// started, and skipped events are sent for their objects. The ActionGroup
+// events are still sent, so that every action group is finished. The run is
+// marked as cancelled, so that the inventory tasks know about it. If the
+// taskQueue is empty, the second return value is true.
+func drainTasks(taskQueue chan Task, taskContext *TaskContext, reason error) (Task, bool) {
+	taskContext.setCancelled(reason)
+	for {
+		var tsk Task
+		select {
+		case tsk = <-taskQueue:
+		default:
+			return nil, true
+		}
+		if tsk.Action() == event.InventoryAction {
+			taskContext.SendEvent(event.Event{
+				Type: event.ActionGroupType,
+				ActionGroupEvent: event.ActionGroupEvent{
+					GroupName: tsk.Name(),
+					Action:    tsk.Action(),
+					Status:    event.Started,
+				},
+			})
+			tsk.Start(taskContext)
+			return tsk, false
+		}
+		taskContext.SendEvent(event.Event{
+			Type: event.ActionGroupType,
+			ActionGroupEvent: event.ActionGroupEvent{
+				GroupName: tsk.Name(),
+				Action:    tsk.Action(),
+				Status:    event.Started,
+			},
+		})
+		for _, id := range tsk.Identifiers() {
+			skipObject(taskContext, tsk, id, reason)
+		}
+		taskContext.SendEvent(event.Event{
+			Type: event.ActionGroupType,
+			ActionGroupEvent: event.ActionGroupEvent{
+				GroupName: tsk.Name(),
+				Action:    tsk.Action(),
+				Status:    event.Finished,
+			},
+		})
+	}
+}
+
+// skipObject registers that the object of the task was skipped and sends a
+// skipped event for it. Objects of inventory tasks are ignored.
+func skipObject(taskContext *TaskContext, tsk Task, id object.ObjMetadata, reason error) {
+	im := taskContext.InventoryManager()
+	switch tsk.Action() {
+	case event.ApplyAction:
+		im.AddSkippedApply(id)
+		taskContext.SendEvent(event.Event{
+			Type: event.ApplyType,
+			ApplyEvent: event.ApplyEvent{
+				GroupName:  tsk.Name(),
+				Identifier: id,
+				Status:     event.ApplySkipped,
+				Error:      reason,
+			},
+		})
+	case event.PruneAction:
+		im.AddSkippedDelete(id)
+		taskContext.SendEvent(event.Event{
+			Type: event.PruneType,
+			PruneEvent: event.PruneEvent{
+				GroupName:  tsk.Name(),
+				Identifier: id,
+				Status:     event.PruneSkipped,
+				Error:      reason,
+			},
+		})
+	case event.DeleteAction:
+		im.AddSkippedDelete(id)
+		taskContext.SendEvent(event.Event{
+			Type: event.DeleteType,
+			DeleteEvent: event.DeleteEvent{
+				GroupName:  tsk.Name(),
+				Identifier: id,
+				Status:     event.DeleteSkipped,
+				Error:      reason,
+			},
+		})
+	case event.WaitAction:
+		if err := im.SetSkippedReconcile(id); err != nil {
+			// Object never applied or deleted!
+			taskContext.Logger().Error(err, "failed to mark object as skipped reconcile", "object", id)
+		}
+		taskContext.SendEvent(event.Event{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  tsk.Name(),
+				Identifier: id,
+				Status:     event.ReconcileSkipped,
+			},
+		})
+	}
+}
+
 // nextTask fetches the latest task from the taskQueue and
 // starts it. If the taskQueue is empty, it the second
 // return value will be true.
@@ -253,3 +421,30 @@ func nextTask(taskQueue chan Task, taskContext *TaskContext) (Task, bool) {
 type TaskResult struct {
 	Err error
 }
+
+// CancelledError is returned by the TaskStatusRunner when the run was
+// cancelled gracefully. It is also the error of the skipped events for the
+// objects that were not applied, pruned, or deleted because of it.
+type CancelledError struct {
+	// Err is the error of the cancelled context.
+	Err error
+}
+
+func (e *CancelledError) Error() string {
+	return fmt.Sprintf("run cancelled: %v", e.Err)
+}
+
+func (e *CancelledError) Unwrap() error {
+	return e.Err
+}
+
+func (e *CancelledError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*CancelledError)
+	if !ok {
+		return false
+	}
+	return errors.Is(e.Err, tErr.Err)
+}
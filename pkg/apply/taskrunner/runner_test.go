@@ -13,7 +13,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
+	applyerror "sigs.k8s.io/cli-utils/pkg/apply/error"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
 	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
@@ -165,6 +168,7 @@ func TestBaseRunner(t *testing.T) {
 					GroupName:  "wait",
 					Identifier: depID,
 					Status:     event.ReconcileTimeout,
+					Error:      applyerror.NewReconcileTimeoutError(depID, 2*time.Second),
 				},
 			},
 		},
@@ -220,6 +224,7 @@ func TestBaseRunner(t *testing.T) {
 					GroupName:  "wait",
 					Identifier: depID,
 					Status:     event.ReconcileTimeout,
+					Error:      applyerror.NewReconcileTimeoutError(depID, 2*time.Second),
 				},
 			},
 		},
@@ -507,8 +512,192 @@ func TestBaseRunnerCancellation(t *testing.T) {
 	}
 }
 
+func TestBaseRunnerGracefulCancellation(t *testing.T) {
+	testCases := map[string]struct {
+		tasks               []Task
+		contextTimeout      time.Duration
+		gracePeriod         time.Duration
+		expectedEventTypes  []event.Type
+		expectedSkippedApps object.ObjMetadataSet
+	}{
+		"running task finishes within grace period": {
+			tasks: []Task{
+				&fakeApplyTask{
+					name: "apply-0",
+					resultEvent: event.Event{
+						Type: event.ApplyType,
+					},
+					duration: 2 * time.Second,
+				},
+				&fakeApplyTask{
+					name:     "apply-1",
+					ids:      object.ObjMetadataSet{cmID},
+					duration: 2 * time.Second,
+				},
+				NewWaitTask("wait-1", object.ObjMetadataSet{cmID}, AllCurrent,
+					20*time.Second, testutil.NewFakeRESTMapper()),
+			},
+			contextTimeout: 1 * time.Second,
+			gracePeriod:    10 * time.Second,
+			expectedEventTypes: []event.Type{
+				event.ActionGroupType,
+				event.ApplyType, // applied
+				event.ActionGroupType,
+				event.ActionGroupType,
+				event.ApplyType, // skipped
+				event.ActionGroupType,
+				event.ActionGroupType,
+				event.WaitType, // skipped
+				event.ActionGroupType,
+			},
+			expectedSkippedApps: object.ObjMetadataSet{cmID},
+		},
+		"grace period expires while wait task is running": {
+			tasks: []Task{
+				NewWaitTask("wait-0", object.ObjMetadataSet{depID}, AllCurrent,
+					20*time.Second, testutil.NewFakeRESTMapper()),
+				&fakeApplyTask{
+					name:     "apply-1",
+					ids:      object.ObjMetadataSet{cmID},
+					duration: 2 * time.Second,
+				},
+			},
+			contextTimeout: 1 * time.Second,
+			gracePeriod:    1 * time.Second,
+			expectedEventTypes: []event.Type{
+				event.ActionGroupType,
+				event.WaitType, // pending
+				event.WaitType, // skipped
+				event.ActionGroupType,
+				event.ActionGroupType,
+				event.ApplyType, // skipped
+				event.ActionGroupType,
+			},
+			expectedSkippedApps: object.ObjMetadataSet{cmID},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			taskQueue := make(chan Task, len(tc.tasks))
+			for _, tsk := range tc.tasks {
+				taskQueue <- tsk
+			}
+
+			ids := object.ObjMetadataSet{} // unused by fake statusWatcher
+			statusWatcher := newFakeWatcher(nil)
+			statusWatcher.Start()
+			eventChannel := make(chan event.Event)
+			resourceCache := cache.NewResourceCacheMap()
+			taskContext := NewTaskContext(eventChannel, resourceCache)
+			runner := NewTaskStatusRunner(ids, statusWatcher)
+
+			var events []event.Event
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for msg := range eventChannel {
+					events = append(events, msg)
+				}
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), tc.contextTimeout)
+			defer cancel()
+
+			opts := Options{
+				EmitStatusEvents:  true,
+				CancelGracePeriod: tc.gracePeriod,
+			}
+			err := runner.Run(ctx, taskContext, taskQueue, opts)
+			close(eventChannel)
+			<-done
+
+			expectedErr := &CancelledError{Err: context.DeadlineExceeded}
+			assert.Equal(t, expectedErr, err)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+			var eventTypes []event.Type
+			for _, e := range events {
+				eventTypes = append(eventTypes, e.Type)
+				switch e.Type {
+				case event.ApplyType:
+					if e.ApplyEvent.Status == event.ApplySkipped {
+						assert.Equal(t, expectedErr, e.ApplyEvent.Error)
+					}
+				case event.WaitType:
+					assert.NotEqual(t, event.ReconcileSuccessful, e.WaitEvent.Status)
+				}
+			}
+			assert.Equal(t, tc.expectedEventTypes, eventTypes)
+			assert.Equal(t, tc.expectedSkippedApps, taskContext.InventoryManager().SkippedApplies())
+		})
+	}
+}
+
+func TestBaseRunnerGracefulCancellationInventory(t *testing.T) {
+	invClient := inventory.NewFakeClient(object.ObjMetadataSet{})
+	taskQueue := make(chan Task, 4)
+	taskQueue <- &fakeApplyTask{
+		name:     "apply-0",
+		ids:      object.ObjMetadataSet{depID},
+		duration: 500 * time.Millisecond,
+	}
+	taskQueue <- &fakeApplyTask{
+		name: "apply-1",
+		ids:  object.ObjMetadataSet{cmID},
+	}
+	taskQueue <- NewWaitTask("wait-1", object.ObjMetadataSet{cmID}, AllCurrent,
+		20*time.Second, testutil.NewFakeRESTMapper())
+	taskQueue <- &fakeInvSetTask{
+		name:      "inventory-set-0",
+		invClient: invClient,
+	}
+
+	statusWatcher := newFakeWatcher(nil)
+	statusWatcher.Start()
+	eventChannel := make(chan event.Event)
+	taskContext := NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+	runner := NewTaskStatusRunner(object.ObjMetadataSet{}, statusWatcher)
+
+	var groups []string
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for msg := range eventChannel {
+			if msg.Type == event.ActionGroupType {
+				groups = append(groups, fmt.Sprintf("%s %s",
+					msg.ActionGroupEvent.GroupName, msg.ActionGroupEvent.Status))
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := runner.Run(ctx, taskContext, taskQueue, Options{
+		CancelGracePeriod: 10 * time.Second,
+	})
+	close(eventChannel)
+	<-done
+
+	assert.Equal(t, &CancelledError{Err: context.DeadlineExceeded}, err)
+	assert.Equal(t, []string{
+		"apply-0 Started",
+		"apply-0 Finished",
+		"apply-1 Started",
+		"apply-1 Finished",
+		"wait-1 Started",
+		"wait-1 Finished",
+		"inventory-set-0 Started",
+		"inventory-set-0 Finished",
+	}, groups)
+	// The inventory task ran after the skipped tasks, and saw the skipped
+	// objects.
+	assert.Equal(t, object.ObjMetadataSet{cmID}, invClient.Objs)
+}
+
 type fakeApplyTask struct {
 	name        string
+	ids         object.ObjMetadataSet
 	resultEvent event.Event
 	duration    time.Duration
 	err         error
@@ -523,7 +712,7 @@ func (f *fakeApplyTask) Action() event.ResourceAction {
 }
 
 func (f *fakeApplyTask) Identifiers() object.ObjMetadataSet {
-	return object.ObjMetadataSet{}
+	return f.ids
 }
 
 func (f *fakeApplyTask) Start(taskContext *TaskContext) {
@@ -540,6 +729,36 @@ func (f *fakeApplyTask) Cancel(_ *TaskContext) {}
 
 func (f *fakeApplyTask) StatusUpdate(_ *TaskContext, _ object.ObjMetadata) {}
 
+// fakeInvSetTask replaces the inventory with the skipped applies.
+type fakeInvSetTask struct {
+	name      string
+	invClient inventory.Client
+}
+
+func (f *fakeInvSetTask) Name() string {
+	return f.name
+}
+
+func (f *fakeInvSetTask) Action() event.ResourceAction {
+	return event.InventoryAction
+}
+
+func (f *fakeInvSetTask) Identifiers() object.ObjMetadataSet {
+	return object.ObjMetadataSet{}
+}
+
+func (f *fakeInvSetTask) Start(taskContext *TaskContext) {
+	go func() {
+		err := f.invClient.Replace(nil, taskContext.InventoryManager().SkippedApplies(),
+			nil, common.DryRunNone)
+		taskContext.TaskChannel() <- TaskResult{Err: err}
+	}()
+}
+
+func (f *fakeInvSetTask) Cancel(_ *TaskContext) {}
+
+func (f *fakeInvSetTask) StatusUpdate(_ *TaskContext, _ object.ObjMetadata) {}
+
 type fakeWatcher struct {
 	start  chan struct{}
 	events []pollevent.Event
@@ -6,20 +6,30 @@ package taskrunner
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/klog/v2"
+	applyerror "sigs.k8s.io/cli-utils/pkg/apply/error"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	"sigs.k8s.io/cli-utils/pkg/object"
 )
 
 var (
 	crdGK = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+
+	// finalizerCheckInterval is how often the WaitTask checks for objects
+	// with finalizers to remove.
+	finalizerCheckInterval = 5 * time.Second
 )
 
 // Task is the interface that must be implemented by
@@ -64,6 +74,24 @@ type WaitTask struct {
 	Timeout time.Duration
 	// Mapper is the RESTMapper to update after CRDs have been reconciled
 	Mapper meta.RESTMapper
+	// SummaryInterval defines how often to send a WaitSummaryEvent listing
+	// the objects that are still pending, and counting the objects per
+	// status. Zero disables summary events.
+	SummaryInterval time.Duration
+	// RemoveFinalizersAfter defines how long an object may be blocked from
+	// deletion by finalizers, after its deletion timestamp, before its
+	// finalizers are removed. Only used with the AllNotFound condition.
+	// Zero disables finalizer removal.
+	RemoveFinalizersAfter time.Duration
+	// DynamicClient is used to remove finalizers.
+	DynamicClient dynamic.Interface
+	// ObjectTimeouts overrides the Timeout of individual objects. An object
+	// that times out gets a ReconcileTimeout event, while the task keeps
+	// waiting for the other objects. Zero disables the timeout of an object.
+	ObjectTimeouts map[object.ObjMetadata]time.Duration
+	// finalizersRemoved is the set of resources that have had their
+	// finalizers removed.
+	finalizersRemoved object.ObjMetadataSet
 	// cancelFunc is a function that will cancel the timeout timer
 	// on the task.
 	cancelFunc context.CancelFunc
@@ -72,6 +100,8 @@ type WaitTask struct {
 	// failed is the set of resources that we are waiting for, but is considered
 	// failed, i.e. unlikely to successfully reconcile.
 	failed object.ObjMetadataSet
+	// timedOut is the set of resources that timed out before the task.
+	timedOut object.ObjMetadataSet
 	// mu protects the pending ObjMetadataSet
 	mu sync.RWMutex
 }
@@ -91,21 +121,42 @@ func (w *WaitTask) Identifiers() object.ObjMetadataSet {
 // Start kicks off the task. For the wait task, this just means
 // setting up the timeout timer.
 func (w *WaitTask) Start(taskContext *TaskContext) {
-	klog.V(2).Infof("wait task starting (name: %q, objects: %d)",
-		w.Name(), len(w.Ids))
+	logger := taskContext.Logger().WithValues("task", w.Name())
+	logger.V(2).Info("wait task starting", "objects", len(w.Ids))
 
 	// TODO: inherit context from task runner, passed through the TaskContext
-	ctx := context.Background()
+	ctx := klog.NewContext(context.Background(), logger)
 
 	// use a context wrapper to handle complete/cancel/timeout
-	if w.Timeout > 0 {
-		ctx, w.cancelFunc = context.WithTimeout(ctx, w.Timeout)
+	start := time.Now()
+	taskTimeout := w.taskTimeout()
+	if taskTimeout > 0 {
+		ctx, w.cancelFunc = context.WithTimeout(ctx, taskTimeout)
 	} else {
 		ctx, w.cancelFunc = context.WithCancel(ctx)
 	}
 
+	w.resolveGeneratedIds(taskContext)
 	w.startInner(taskContext)
 
+	// Goroutines to periodically summarize the pending objects, remove the
+	// finalizers of objects blocked from deletion, and time out objects with
+	// a shorter timeout than the task.
+	var periodic sync.WaitGroup
+	periodic.Add(3)
+	go func() {
+		defer periodic.Done()
+		w.sendSummaryEvents(ctx, taskContext)
+	}()
+	go func() {
+		defer periodic.Done()
+		w.removeFinalizers(ctx, taskContext)
+	}()
+	go func() {
+		defer periodic.Done()
+		w.timeoutObjects(ctx, taskContext, start, taskTimeout)
+	}()
+
 	// A goroutine to handle ending the WaitTask.
 	go func() {
 		// Block until complete/cancel/timeout
@@ -113,11 +164,19 @@ func (w *WaitTask) Start(taskContext *TaskContext) {
 		// Err is always non-nil when Done channel is closed.
 		err := ctx.Err()
 
-		klog.V(2).Infof("wait task completing (name: %q,): %v", w.TaskName, err)
+		// Wait for the periodic goroutines to exit, so that no summary
+		// events are sent after the timeout events.
+		periodic.Wait()
+
+		logger.V(2).Info("wait task completing", "reason", err)
 
 		switch err {
 		case context.Canceled:
 			// happy path - cancelled or completed (not considered an error)
+			if taskContext.Cancelled() != nil {
+				// run cancelled gracefully - skip the pending objects
+				w.sendSkippedEvents(taskContext)
+			}
 		case context.DeadlineExceeded:
 			// timed out
 			w.sendTimeoutEvents(taskContext)
@@ -132,14 +191,57 @@ func (w *WaitTask) Start(taskContext *TaskContext) {
 }
 
 func (w *WaitTask) sendEvent(taskContext *TaskContext, id object.ObjMetadata, status event.WaitEventStatus) {
-	taskContext.SendEvent(event.Event{
+	e := event.Event{
 		Type: event.WaitType,
 		WaitEvent: event.WaitEvent{
 			GroupName:  w.Name(),
 			Identifier: id,
 			Status:     status,
 		},
-	})
+	}
+	if status == event.ReconcileTimeout {
+		e.WaitEvent.Error = applyerror.NewReconcileTimeoutError(id, w.objectTimeout(id))
+	}
+	// Time the reconciliation from when the object was applied or deleted.
+	if status != event.ReconcilePending {
+		if start, found := taskContext.ActuationTime(id); found {
+			taskContext.SendEventWithTiming(e, start)
+			return
+		}
+	}
+	taskContext.SendEvent(e)
+}
+
+// resolveGeneratedIds replaces the ids of objects with a generated name by
+// the ids of the objects created for them, so that the created objects are
+// waited for. Ids of objects that were not created are kept, and skipped.
+// The created objects are added to the objects watched by the StatusWatcher,
+// since their names were not known when the watch started.
+func (w *WaitTask) resolveGeneratedIds(taskContext *TaskContext) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ids := make(object.ObjMetadataSet, len(w.Ids))
+	var createdIds object.ObjMetadataSet
+	for i, id := range w.Ids {
+		ids[i] = id
+		if !id.IsGenerated() {
+			continue
+		}
+		created, found := taskContext.GeneratedObject(id)
+		if !found {
+			continue
+		}
+		ids[i] = created
+		createdIds = append(createdIds, created)
+		if timeout, found := w.ObjectTimeouts[id]; found {
+			w.ObjectTimeouts[created] = timeout
+		}
+	}
+	w.Ids = ids
+	if err := taskContext.watchIdentifiers(createdIds); err != nil {
+		taskContext.Logger().Error(err, "failed to watch objects created with a generated name", "task", w.TaskName)
+	}
 }
 
 // startInner sends initial pending, skipped, an reconciled events.
@@ -149,7 +251,7 @@ func (w *WaitTask) startInner(taskContext *TaskContext) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	klog.V(3).Infof("wait task progress: %d/%d", 0, len(w.Ids))
+	taskContext.Logger().V(3).Info("wait task progress", "task", w.TaskName, "reconciled", 0, "total", len(w.Ids))
 
 	pending := object.ObjMetadataSet{}
 	for _, id := range w.Ids {
@@ -158,7 +260,7 @@ func (w *WaitTask) startInner(taskContext *TaskContext) {
 			err := taskContext.InventoryManager().SetSkippedReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
-				klog.Errorf("Failed to mark object as skipped reconcile: %v", err)
+				taskContext.Logger().Error(err, "failed to mark object as skipped reconcile", "object", id)
 			}
 			w.sendEvent(taskContext, id, event.ReconcileSkipped)
 		case w.changedUID(taskContext, id):
@@ -168,14 +270,14 @@ func (w *WaitTask) startInner(taskContext *TaskContext) {
 			err := taskContext.InventoryManager().SetSuccessfulReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
-				klog.Errorf("Failed to mark object as successful reconcile: %v", err)
+				taskContext.Logger().Error(err, "failed to mark object as successful reconcile", "object", id)
 			}
 			w.sendEvent(taskContext, id, event.ReconcileSuccessful)
 		default:
 			err := taskContext.InventoryManager().SetPendingReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
-				klog.Errorf("Failed to mark object as pending reconcile: %v", err)
+				taskContext.Logger().Error(err, "failed to mark object as pending reconcile", "object", id)
 			}
 			pending = append(pending, id)
 			w.sendEvent(taskContext, id, event.ReconcilePending)
@@ -183,11 +285,282 @@ func (w *WaitTask) startInner(taskContext *TaskContext) {
 	}
 	w.pending = pending
 
-	klog.V(3).Infof("wait task progress: %d/%d", len(w.Ids)-len(w.pending), len(w.Ids))
+	taskContext.Logger().V(3).Info("wait task progress", "task", w.TaskName, "reconciled", len(w.Ids)-len(w.pending), "total", len(w.Ids))
 
 	if len(pending) == 0 {
 		// all reconciled - clear pending and exit
-		klog.V(3).Infof("all objects reconciled or skipped (name: %q)", w.TaskName)
+		taskContext.Logger().V(3).Info("all objects reconciled or skipped", "task", w.TaskName)
+		w.cancelFunc()
+	}
+}
+
+// sendSummaryEvents sends a WaitSummaryEvent every SummaryInterval, until the
+// context is done.
+func (w *WaitTask) sendSummaryEvents(ctx context.Context, taskContext *TaskContext) {
+	if w.SummaryInterval <= 0 {
+		return
+	}
+	start := time.Now()
+	ticker := time.NewTicker(w.SummaryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.sendSummaryEvent(taskContext, time.Since(start))
+		}
+	}
+}
+
+// sendSummaryEvent sends a WaitSummaryEvent listing the pending objects,
+// grouped by kind and status, and counting all the objects of the task per
+// status, as last seen in the resource cache. No event is sent if nothing is
+// pending.
+// The pending set is read locked during execution of sendSummaryEvent.
+func (w *WaitTask) sendSummaryEvent(taskContext *TaskContext, elapsed time.Duration) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if len(w.pending) == 0 {
+		return
+	}
+
+	type groupKey struct {
+		GroupKind schema.GroupKind
+		Status    status.Status
+	}
+	groups := make(map[groupKey]*event.WaitSummaryGroup)
+	var keys []groupKey
+	for _, id := range w.pending {
+		key := groupKey{
+			GroupKind: id.GroupKind,
+			Status:    taskContext.ResourceCache().Get(id).Status,
+		}
+		group, found := groups[key]
+		if !found {
+			group = &event.WaitSummaryGroup{
+				GroupKind: key.GroupKind,
+				Status:    key.Status,
+			}
+			groups[key] = group
+			keys = append(keys, key)
+		}
+		group.Identifiers = append(group.Identifiers, id)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].GroupKind.String() != keys[j].GroupKind.String() {
+			return keys[i].GroupKind.String() < keys[j].GroupKind.String()
+		}
+		return keys[i].Status < keys[j].Status
+	})
+
+	pending := make([]event.WaitSummaryGroup, len(keys))
+	for i, key := range keys {
+		group := groups[key]
+		sort.Slice(group.Identifiers, func(i, j int) bool {
+			return group.Identifiers[i].String() < group.Identifiers[j].String()
+		})
+		pending[i] = *group
+	}
+
+	var terminating []event.TerminatingObject
+	for _, id := range w.pending {
+		obj := taskContext.ResourceCache().Get(id).Resource
+		if !blockedByFinalizers(obj) {
+			continue
+		}
+		terminating = append(terminating, event.TerminatingObject{
+			Identifier:        id,
+			DeletionTimestamp: obj.GetDeletionTimestamp().Time,
+			Finalizers:        obj.GetFinalizers(),
+		})
+	}
+	sort.Slice(terminating, func(i, j int) bool {
+		return terminating[i].Identifier.String() < terminating[j].Identifier.String()
+	})
+
+	counts := make(map[status.Status]int)
+	for _, id := range w.Ids {
+		counts[taskContext.ResourceCache().Get(id).Status]++
+	}
+
+	taskContext.Logger().V(3).Info("wait task summary", "task", w.TaskName, "elapsed", elapsed, "pending", len(w.pending))
+
+	taskContext.SendEvent(event.Event{
+		Type: event.WaitSummaryType,
+		WaitSummaryEvent: event.WaitSummaryEvent{
+			GroupName:   w.Name(),
+			Elapsed:     elapsed,
+			Total:       len(w.Ids),
+			Counts:      counts,
+			Pending:     pending,
+			Terminating: terminating,
+		},
+	})
+}
+
+// blockedByFinalizers returns true if the object is being deleted, but still
+// has finalizers.
+func blockedByFinalizers(obj *unstructured.Unstructured) bool {
+	return obj != nil && obj.GetDeletionTimestamp() != nil && len(obj.GetFinalizers()) > 0
+}
+
+// removeFinalizers removes the finalizers of pending objects that have been
+// blocked from deletion for longer than RemoveFinalizersAfter, checking
+// every finalizerCheckInterval, until the context is done.
+func (w *WaitTask) removeFinalizers(ctx context.Context, taskContext *TaskContext) {
+	if w.RemoveFinalizersAfter <= 0 || w.Condition != AllNotFound || w.DynamicClient == nil {
+		return
+	}
+	ticker := time.NewTicker(finalizerCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, obj := range w.stuckObjects(taskContext) {
+				w.removeObjectFinalizers(ctx, obj)
+			}
+		}
+	}
+}
+
+// stuckObjects returns the pending objects that have been blocked from
+// deletion by finalizers for longer than RemoveFinalizersAfter.
+// The pending set is read locked during execution of stuckObjects.
+func (w *WaitTask) stuckObjects(taskContext *TaskContext) []*unstructured.Unstructured {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var stuck []*unstructured.Unstructured
+	for _, id := range w.pending {
+		if w.finalizersRemoved.Contains(id) {
+			continue
+		}
+		obj := taskContext.ResourceCache().Get(id).Resource
+		if !blockedByFinalizers(obj) {
+			continue
+		}
+		if time.Since(obj.GetDeletionTimestamp().Time) < w.RemoveFinalizersAfter {
+			continue
+		}
+		stuck = append(stuck, obj)
+	}
+	return stuck
+}
+
+// removeObjectFinalizers removes all the finalizers from the object.
+// Errors are logged, and removal is retried on the next check.
+func (w *WaitTask) removeObjectFinalizers(ctx context.Context, obj *unstructured.Unstructured) {
+	logger := klog.FromContext(ctx)
+	id := object.UnstructuredToObjMetadata(obj)
+	gvk := obj.GroupVersionKind()
+	mapping, err := w.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		logger.Error(err, "failed to remove finalizers", "object", id)
+		return
+	}
+	logger.Info("removing finalizers", "object", id, "finalizers", obj.GetFinalizers())
+	patch := []byte(`{"metadata":{"finalizers":null}}`)
+	_, err = w.DynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace()).
+		Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		logger.Error(err, "failed to remove finalizers", "object", id)
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.finalizersRemoved = append(w.finalizersRemoved, id)
+}
+
+// objectTimeout returns the timeout of the object, from ObjectTimeouts or
+// Timeout. Zero means no timeout.
+func (w *WaitTask) objectTimeout(id object.ObjMetadata) time.Duration {
+	if timeout, found := w.ObjectTimeouts[id]; found {
+		return timeout
+	}
+	return w.Timeout
+}
+
+// taskTimeout returns the longest timeout of the objects, which is when the
+// task times out. Zero means no timeout, if any object has no timeout.
+func (w *WaitTask) taskTimeout() time.Duration {
+	if len(w.ObjectTimeouts) == 0 {
+		return w.Timeout
+	}
+	var taskTimeout time.Duration
+	for _, id := range w.Ids {
+		timeout := w.objectTimeout(id)
+		if timeout <= 0 {
+			return 0
+		}
+		if timeout > taskTimeout {
+			taskTimeout = timeout
+		}
+	}
+	return taskTimeout
+}
+
+// timeoutObjects times out the pending objects with a shorter timeout than
+// the task, when their timeout expires, until the context is done. Objects
+// with the task timeout are timed out when the task times out.
+func (w *WaitTask) timeoutObjects(ctx context.Context, taskContext *TaskContext, start time.Time, taskTimeout time.Duration) {
+	groups := make(map[time.Duration]object.ObjMetadataSet)
+	var timeouts []time.Duration
+	for _, id := range w.Ids {
+		timeout := w.objectTimeout(id)
+		if timeout <= 0 || (taskTimeout > 0 && timeout >= taskTimeout) {
+			continue
+		}
+		if _, found := groups[timeout]; !found {
+			timeouts = append(timeouts, timeout)
+		}
+		groups[timeout] = append(groups[timeout], id)
+	}
+	sort.Slice(timeouts, func(i, j int) bool {
+		return timeouts[i] < timeouts[j]
+	})
+	for _, timeout := range timeouts {
+		timer := time.NewTimer(time.Until(start.Add(timeout)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			w.sendObjectTimeoutEvents(taskContext, groups[timeout])
+		}
+	}
+}
+
+// sendObjectTimeoutEvents sends a timeout event for each of the objects that
+// is still pending, and stops waiting for them. If no objects are pending
+// afterwards, cancelFunc is called.
+// The pending set is write locked during execution of sendObjectTimeoutEvents.
+func (w *WaitTask) sendObjectTimeoutEvents(taskContext *TaskContext, ids object.ObjMetadataSet) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, id := range ids {
+		if !w.pending.Contains(id) {
+			continue
+		}
+		taskContext.Logger().V(3).Info("object timed out", "task", w.TaskName, "object", id, "timeout", w.objectTimeout(id))
+		err := taskContext.InventoryManager().SetTimeoutReconcile(id)
+		if err != nil {
+			// Object never applied or deleted!
+			taskContext.Logger().Error(err, "failed to mark object as timeout reconcile", "object", id)
+		}
+		w.pending = w.pending.Remove(id)
+		w.timedOut = append(w.timedOut, id)
+		w.sendEvent(taskContext, id, event.ReconcileTimeout)
+	}
+
+	if len(w.pending) == 0 {
+		// all reconciled or timed out, so exit
+		taskContext.Logger().V(3).Info("all objects reconciled, skipped, or timed out", "task", w.TaskName)
 		w.cancelFunc()
 	}
 }
@@ -202,18 +575,76 @@ func (w *WaitTask) sendTimeoutEvents(taskContext *TaskContext) {
 		err := taskContext.InventoryManager().SetTimeoutReconcile(id)
 		if err != nil {
 			// Object never applied or deleted!
-			klog.Errorf("Failed to mark object as pending reconcile: %v", err)
+			taskContext.Logger().Error(err, "failed to mark object as pending reconcile", "object", id)
 		}
 		w.sendEvent(taskContext, id, event.ReconcileTimeout)
 	}
 }
 
+// sendSkippedEvents sends a ReconcileSkipped event for each pending object,
+// after the run was cancelled gracefully.
+func (w *WaitTask) sendSkippedEvents(taskContext *TaskContext) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, id := range w.pending {
+		err := taskContext.InventoryManager().SetSkippedReconcile(id)
+		if err != nil {
+			// Object never applied or deleted!
+			taskContext.Logger().Error(err, "failed to mark object as skipped reconcile", "object", id)
+		}
+		w.sendEvent(taskContext, id, event.ReconcileSkipped)
+	}
+	w.pending = object.ObjMetadataSet{}
+}
+
 // reconciledByID checks whether the condition set in the task is currently met
 // for the specified object given the status of resource in the cache.
+// Applied objects with a ready-when annotation are reconciled when the
+// expression is true, instead of when they are Current.
 func (w *WaitTask) reconciledByID(taskContext *TaskContext, id object.ObjMetadata) bool {
+	if ready, found := w.readyWhen(taskContext, id); found {
+		return ready
+	}
 	return conditionMet(taskContext, object.ObjMetadataSet{id}, w.Condition)
 }
 
+// readyWhen evaluates the ready-when annotation of the object in the cache.
+// Returns false if the task is not waiting for applied objects, or the
+// object does not have the annotation.
+func (w *WaitTask) readyWhen(taskContext *TaskContext, id object.ObjMetadata) (bool, bool) {
+	expression, found := w.readyWhenExpression(taskContext, id)
+	if !found {
+		return false, false
+	}
+	cached := taskContext.ResourceCache().Get(id)
+	applyGen, _ := taskContext.InventoryManager().AppliedGeneration(id) // generation at apply time
+	if cached.Resource.GetGeneration() < applyGen {
+		// cache too old
+		return false, true
+	}
+	ready, err := EvalReadyWhen(cached.Resource, expression)
+	if err != nil {
+		taskContext.Logger().Error(err, "failed to evaluate ready-when annotation", "object", id)
+		return false, true
+	}
+	return ready, true
+}
+
+// readyWhenExpression returns the ready-when annotation of the object in the
+// cache, if the task is waiting for applied objects.
+func (w *WaitTask) readyWhenExpression(taskContext *TaskContext, id object.ObjMetadata) (string, bool) {
+	if w.Condition != AllCurrent {
+		return "", false
+	}
+	cached := taskContext.ResourceCache().Get(id)
+	if cached.Resource == nil {
+		return "", false
+	}
+	expression, found := cached.Resource.GetAnnotations()[common.ReadyWhenAnnotation]
+	return expression, found
+}
+
 // skipped returns true if the object failed or was skipped by a preceding
 // apply/delete/prune task.
 func (w *WaitTask) skipped(taskContext *TaskContext, id object.ObjMetadata) bool {
@@ -229,9 +660,15 @@ func (w *WaitTask) skipped(taskContext *TaskContext, id object.ObjMetadata) bool
 	return false
 }
 
-// failedByID returns true if the resource is failed.
+// failedByID returns true if the resource is failed. Objects with a
+// ready-when annotation are failed if the expression is invalid, because
+// their status is not used.
 func (w *WaitTask) failedByID(taskContext *TaskContext, id object.ObjMetadata) bool {
 	cached := taskContext.ResourceCache().Get(id)
+	if expression, found := w.readyWhenExpression(taskContext, id); found {
+		_, err := EvalReadyWhen(cached.Resource, expression)
+		return err != nil
+	}
 	return cached.Status == status.FailedStatus
 }
 
@@ -243,13 +680,13 @@ func (w *WaitTask) changedUID(taskContext *TaskContext, id object.ObjMetadata) b
 	// Get the uid from the ApplyTask/PruneTask
 	taskObj, found := taskContext.InventoryManager().ObjectStatus(id)
 	if !found {
-		klog.Errorf("Unknown object UID from InventoryManager: %v", id)
+		taskContext.Logger().Error(nil, "unknown object UID from InventoryManager", "object", id)
 		return false
 	}
 	oldUID = taskObj.UID
 	if oldUID == "" {
 		// All objects should have been given a UID by the apiserver
-		klog.Errorf("Empty object UID from InventoryManager: %v", id)
+		taskContext.Logger().Error(nil, "empty object UID from InventoryManager", "object", id)
 		return false
 	}
 
@@ -264,14 +701,14 @@ func (w *WaitTask) changedUID(taskContext *TaskContext, id object.ObjMetadata) b
 			// K8s DELETE API doesn't always return an object.
 		default:
 			// For all other statuses, nil Resource is probably a bug.
-			klog.Errorf("Unknown object UID from ResourceCache (status: %v): %v", pollerObj.Status, id)
+			taskContext.Logger().Error(nil, "unknown object UID from ResourceCache", "object", id, "status", pollerObj.Status)
 		}
 		return false
 	}
 	newUID = pollerObj.Resource.GetUID()
 	if newUID == "" {
 		// All objects should have been given a UID by the apiserver
-		klog.Errorf("Empty object UID from ResourceCache (status: %v): %v", pollerObj.Status, id)
+		taskContext.Logger().Error(nil, "empty object UID from ResourceCache", "object", id, "status", pollerObj.Status)
 		return false
 	}
 
@@ -284,21 +721,21 @@ func (w *WaitTask) handleChangedUID(taskContext *TaskContext, id object.ObjMetad
 	case AllNotFound:
 		// Object recreated by another actor after deletion.
 		// Treat as success.
-		klog.Infof("UID change detected: deleted object have been recreated: marking reconcile successful: %v", id)
+		taskContext.Logger().Info("UID change detected: deleted object have been recreated: marking reconcile successful", "object", id)
 		err := taskContext.InventoryManager().SetSuccessfulReconcile(id)
 		if err != nil {
 			// Object never applied or deleted!
-			klog.Errorf("Failed to mark object as successful reconcile: %v", err)
+			taskContext.Logger().Error(err, "failed to mark object as successful reconcile", "object", id)
 		}
 		w.sendEvent(taskContext, id, event.ReconcileSuccessful)
 	case AllCurrent:
 		// Object deleted and recreated by another actor after apply.
 		// Treat as failure (unverifiable).
-		klog.Infof("UID change detected: applied object has been deleted and recreated: marking reconcile failed: %v", id)
+		taskContext.Logger().Info("UID change detected: applied object has been deleted and recreated: marking reconcile failed", "object", id)
 		err := taskContext.InventoryManager().SetFailedReconcile(id)
 		if err != nil {
 			// Object never applied or deleted!
-			klog.Errorf("Failed to mark object as failed reconcile: %v", err)
+			taskContext.Logger().Error(err, "failed to mark object as failed reconcile", "object", id)
 		}
 		w.sendEvent(taskContext, id, event.ReconcileFailed)
 	default:
@@ -318,9 +755,9 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	if klog.V(5).Enabled() {
+	if logger := taskContext.Logger().V(5); logger.Enabled() {
 		status := taskContext.ResourceCache().Get(id).Status
-		klog.Infof("status update (object: %q, status: %q)", id, status)
+		logger.Info("status update", "object", id, "status", status)
 	}
 
 	switch {
@@ -335,7 +772,7 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 			err := taskContext.InventoryManager().SetSuccessfulReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
-				klog.Errorf("Failed to mark object as successful reconcile: %v", err)
+				taskContext.Logger().Error(err, "failed to mark object as successful reconcile", "object", id)
 			}
 			w.pending = w.pending.Remove(id)
 			w.sendEvent(taskContext, id, event.ReconcileSuccessful)
@@ -344,7 +781,7 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 			err := taskContext.InventoryManager().SetFailedReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
-				klog.Errorf("Failed to mark object as failed reconcile: %v", err)
+				taskContext.Logger().Error(err, "failed to mark object as failed reconcile", "object", id)
 			}
 			w.pending = w.pending.Remove(id)
 			w.failed = append(w.failed, id)
@@ -357,6 +794,9 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 	case w.skipped(taskContext, id):
 		// skipped - ignore
 		return
+	case w.timedOut.Contains(id):
+		// timed out - ignore
+		return
 	case w.failed.Contains(id):
 		// If a failed resource becomes current before other
 		// resources have completed/timed out, we consider it
@@ -366,7 +806,7 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 			err := taskContext.InventoryManager().SetSuccessfulReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
-				klog.Errorf("Failed to mark object as successful reconcile: %v", err)
+				taskContext.Logger().Error(err, "failed to mark object as successful reconcile", "object", id)
 			}
 			w.failed = w.failed.Remove(id)
 			w.sendEvent(taskContext, id, event.ReconcileSuccessful)
@@ -376,7 +816,7 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 			err := taskContext.InventoryManager().SetPendingReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
-				klog.Errorf("Failed to mark object as pending reconcile: %v", err)
+				taskContext.Logger().Error(err, "failed to mark object as pending reconcile", "object", id)
 			}
 			w.failed = w.failed.Remove(id)
 			w.pending = append(w.pending, id)
@@ -390,7 +830,7 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 			err := taskContext.InventoryManager().SetPendingReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
-				klog.Errorf("Failed to mark object as pending reconcile: %v", err)
+				taskContext.Logger().Error(err, "failed to mark object as pending reconcile", "object", id)
 			}
 			w.pending = append(w.pending, id)
 			w.sendEvent(taskContext, id, event.ReconcilePending)
@@ -398,13 +838,13 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 		// else - still reconciled
 	}
 
-	klog.V(3).Infof("wait task progress: %d/%d", len(w.Ids)-len(w.pending), len(w.Ids))
+	taskContext.Logger().V(3).Info("wait task progress", "task", w.TaskName, "reconciled", len(w.Ids)-len(w.pending), "total", len(w.Ids))
 
 	// If we no longer have any pending resources, the WaitTask
 	// can be completed.
 	if len(w.pending) == 0 {
 		// all reconciled, so exit
-		klog.V(3).Infof("all objects reconciled or skipped (name: %q)", w.TaskName)
+		taskContext.Logger().V(3).Info("all objects reconciled or skipped", "task", w.TaskName)
 		w.cancelFunc()
 	}
 }
@@ -426,6 +866,6 @@ func (w *WaitTask) updateRESTMapper(taskContext *TaskContext) {
 		return
 	}
 
-	klog.V(3).Infof("Resetting RESTMapper")
+	taskContext.Logger().V(3).Info("resetting RESTMapper")
 	meta.MaybeResetRESTMapper(w.Mapper)
 }
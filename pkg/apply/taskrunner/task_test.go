@@ -4,15 +4,25 @@
 package taskrunner
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
+	applyerror "sigs.k8s.io/cli-utils/pkg/apply/error"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 )
@@ -381,6 +391,7 @@ loop:
 				GroupName:  taskName,
 				Identifier: testDeployment2ID,
 				Status:     event.ReconcileTimeout,
+				Error:      applyerror.NewReconcileTimeoutError(testDeployment2ID, waitTimeout),
 			},
 		},
 	}
@@ -425,6 +436,300 @@ loop:
 	testutil.AssertEqual(t, &expectedInventory, taskContext.InventoryManager().Inventory())
 }
 
+func TestWaitTask_ObjectTimeout(t *testing.T) {
+	testDeployment1ID := testutil.ToIdentifier(t, testDeployment1YAML)
+	testDeployment1 := testutil.Unstructured(t, testDeployment1YAML)
+	testDeployment2ID := testutil.ToIdentifier(t, testDeployment2YAML)
+	testDeployment2 := testutil.Unstructured(t, testDeployment2YAML)
+	ids := object.ObjMetadataSet{
+		testDeployment1ID,
+		testDeployment2ID,
+	}
+	taskName := "wait-0"
+	// deployment1 times out, while the task waits for deployment2 without
+	// a timeout.
+	task := NewWaitTask(taskName, ids, AllCurrent, 0, testutil.NewFakeRESTMapper())
+	task.ObjectTimeouts = map[object.ObjMetadata]time.Duration{
+		testDeployment1ID: 1 * time.Second,
+	}
+
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := NewTaskContext(eventChannel, resourceCache)
+	defer close(eventChannel)
+
+	taskContext.InventoryManager().AddSuccessfulApply(testDeployment1ID,
+		testDeployment1.GetUID(), testDeployment1.GetGeneration())
+	taskContext.InventoryManager().AddSuccessfulApply(testDeployment2ID,
+		testDeployment2.GetUID(), testDeployment2.GetGeneration())
+
+	// run task async, to let the test collect events
+	go task.Start(taskContext)
+
+	// wait for task result
+	timer := time.NewTimer(5 * time.Second)
+	receivedEvents := []event.Event{}
+loop:
+	for {
+		select {
+		case e := <-taskContext.EventChannel():
+			receivedEvents = append(receivedEvents, e)
+			if e.WaitEvent.Status != event.ReconcileTimeout {
+				continue
+			}
+			go func() {
+				// deployment1 becoming Current after the timeout is ignored
+				resourceCache.Put(testDeployment1ID, cache.ResourceStatus{
+					Resource: testDeployment1,
+					Status:   status.CurrentStatus,
+				})
+				task.StatusUpdate(taskContext, testDeployment1ID)
+				// deployment2 becoming Current completes the task
+				resourceCache.Put(testDeployment2ID, cache.ResourceStatus{
+					Resource: testDeployment2,
+					Status:   status.CurrentStatus,
+				})
+				task.StatusUpdate(taskContext, testDeployment2ID)
+			}()
+		case res := <-taskContext.TaskChannel():
+			timer.Stop()
+			assert.NoError(t, res.Err)
+			break loop
+		case <-timer.C:
+			t.Fatalf("timed out waiting for TaskResult")
+		}
+	}
+
+	expectedEvents := []event.Event{
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment1ID,
+				Status:     event.ReconcilePending,
+			},
+		},
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment2ID,
+				Status:     event.ReconcilePending,
+			},
+		},
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment1ID,
+				Status:     event.ReconcileTimeout,
+				Error:      applyerror.NewReconcileTimeoutError(testDeployment1ID, 1*time.Second),
+			},
+		},
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment2ID,
+				Status:     event.ReconcileSuccessful,
+			},
+		},
+	}
+	testutil.AssertEqual(t, expectedEvents, receivedEvents,
+		"Actual events (%d) do not match expected events (%d)",
+		len(receivedEvents), len(expectedEvents))
+
+	expectedInventory := actuation.Inventory{
+		Status: actuation.InventoryStatus{
+			Objects: []actuation.ObjectStatus{
+				{
+					ObjectReference: inventory.ObjectReferenceFromObjMetadata(testDeployment1ID),
+					Strategy:        actuation.ActuationStrategyApply,
+					Actuation:       actuation.ActuationSucceeded,
+					Reconcile:       actuation.ReconcileTimeout,
+					UID:             testDeployment1.GetUID(),
+					Generation:      testDeployment1.GetGeneration(),
+				},
+				{
+					ObjectReference: inventory.ObjectReferenceFromObjMetadata(testDeployment2ID),
+					Strategy:        actuation.ActuationStrategyApply,
+					Actuation:       actuation.ActuationSucceeded,
+					Reconcile:       actuation.ReconcileSucceeded,
+					UID:             testDeployment2.GetUID(),
+					Generation:      testDeployment2.GetGeneration(),
+				},
+			},
+		},
+	}
+	testutil.AssertEqual(t, &expectedInventory, taskContext.InventoryManager().Inventory())
+}
+
+func TestWaitTask_TaskTimeout(t *testing.T) {
+	id1 := testutil.ToIdentifier(t, testDeployment1YAML)
+	id2 := testutil.ToIdentifier(t, testDeployment2YAML)
+
+	testCases := map[string]struct {
+		timeout         time.Duration
+		objectTimeouts  map[object.ObjMetadata]time.Duration
+		expectedTimeout time.Duration
+	}{
+		"task timeout": {
+			timeout:         time.Minute,
+			expectedTimeout: time.Minute,
+		},
+		"longest object timeout": {
+			timeout: time.Minute,
+			objectTimeouts: map[object.ObjMetadata]time.Duration{
+				id1: 5 * time.Minute,
+			},
+			expectedTimeout: 5 * time.Minute,
+		},
+		"shorter object timeout": {
+			timeout: time.Minute,
+			objectTimeouts: map[object.ObjMetadata]time.Duration{
+				id1: 30 * time.Second,
+			},
+			expectedTimeout: time.Minute,
+		},
+		"object without timeout": {
+			timeout: time.Minute,
+			objectTimeouts: map[object.ObjMetadata]time.Duration{
+				id1: 0,
+			},
+			expectedTimeout: 0,
+		},
+		"task without timeout": {
+			objectTimeouts: map[object.ObjMetadata]time.Duration{
+				id1: time.Minute,
+			},
+			expectedTimeout: 0,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			task := NewWaitTask("wait-0", object.ObjMetadataSet{id1, id2}, AllCurrent,
+				tc.timeout, testutil.NewFakeRESTMapper())
+			task.ObjectTimeouts = tc.objectTimeouts
+			assert.Equal(t, tc.expectedTimeout, task.taskTimeout())
+		})
+	}
+}
+
+func TestWaitTask_ReadyWhen(t *testing.T) {
+	testDeployment1ID := testutil.ToIdentifier(t, testDeployment1YAML)
+	testDeployment1 := testutil.Unstructured(t, testDeployment1YAML)
+	testDeployment1.SetAnnotations(map[string]string{
+		common.ReadyWhenAnnotation: "status.phase == 'Bound'",
+	})
+	// The expression is invalid, but only fails once status.phase is found.
+	testDeployment2ID := testutil.ToIdentifier(t, testDeployment2YAML)
+	testDeployment2 := testutil.Unstructured(t, testDeployment2YAML)
+	testDeployment2.SetAnnotations(map[string]string{
+		common.ReadyWhenAnnotation: "status.phase ==",
+	})
+	ids := object.ObjMetadataSet{testDeployment1ID, testDeployment2ID}
+	taskName := "wait-0"
+	task := NewWaitTask(taskName, ids, AllCurrent, 5*time.Second, testutil.NewFakeRESTMapper())
+
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := NewTaskContext(eventChannel, resourceCache)
+	defer close(eventChannel)
+
+	taskContext.InventoryManager().AddSuccessfulApply(testDeployment1ID,
+		testDeployment1.GetUID(), testDeployment1.GetGeneration())
+	taskContext.InventoryManager().AddSuccessfulApply(testDeployment2ID,
+		testDeployment2.GetUID(), testDeployment2.GetGeneration())
+
+	// run task async, to let the test collect events
+	go func() {
+		task.Start(taskContext)
+		// Current and Failed are ignored, because the expression is false
+		resourceCache.Put(testDeployment1ID, cache.ResourceStatus{
+			Resource: testDeployment1,
+			Status:   status.CurrentStatus,
+		})
+		task.StatusUpdate(taskContext, testDeployment1ID)
+		resourceCache.Put(testDeployment1ID, cache.ResourceStatus{
+			Resource: testDeployment1,
+			Status:   status.FailedStatus,
+		})
+		task.StatusUpdate(taskContext, testDeployment1ID)
+		// InProgress is ignored, because the expression is true
+		bound := testDeployment1.DeepCopy()
+		assert.NoError(t, unstructured.SetNestedField(bound.Object, "Bound", "status", "phase"))
+		resourceCache.Put(testDeployment1ID, cache.ResourceStatus{
+			Resource: bound,
+			Status:   status.InProgressStatus,
+		})
+		task.StatusUpdate(taskContext, testDeployment1ID)
+		// The invalid expression fails
+		bound = testDeployment2.DeepCopy()
+		assert.NoError(t, unstructured.SetNestedField(bound.Object, "Bound", "status", "phase"))
+		resourceCache.Put(testDeployment2ID, cache.ResourceStatus{
+			Resource: bound,
+			Status:   status.CurrentStatus,
+		})
+		task.StatusUpdate(taskContext, testDeployment2ID)
+	}()
+
+	// wait for task result
+	timer := time.NewTimer(5 * time.Second)
+	receivedEvents := []event.Event{}
+loop:
+	for {
+		select {
+		case e := <-taskContext.EventChannel():
+			receivedEvents = append(receivedEvents, e)
+		case res := <-taskContext.TaskChannel():
+			timer.Stop()
+			assert.NoError(t, res.Err)
+			break loop
+		case <-timer.C:
+			t.Fatalf("timed out waiting for TaskResult")
+		}
+	}
+
+	expectedEvents := []event.Event{
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment1ID,
+				Status:     event.ReconcilePending,
+			},
+		},
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment2ID,
+				Status:     event.ReconcilePending,
+			},
+		},
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment1ID,
+				Status:     event.ReconcileSuccessful,
+			},
+		},
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  taskName,
+				Identifier: testDeployment2ID,
+				Status:     event.ReconcileFailed,
+			},
+		},
+	}
+	testutil.AssertEqual(t, expectedEvents, receivedEvents,
+		"Actual events (%d) do not match expected events (%d)",
+		len(receivedEvents), len(expectedEvents))
+}
+
 func TestWaitTask_StartAndComplete(t *testing.T) {
 	testDeploymentID := testutil.ToIdentifier(t, testDeployment1YAML)
 	testDeployment := testutil.Unstructured(t, testDeployment1YAML)
@@ -1076,6 +1381,7 @@ func TestWaitTask_Failed(t *testing.T) {
 						GroupName:  taskName,
 						Identifier: testDeployment1ID,
 						Status:     event.ReconcileTimeout,
+						Error:      applyerror.NewReconcileTimeoutError(testDeployment1ID, 2*time.Second),
 					},
 				},
 			},
@@ -1403,3 +1709,331 @@ func TestWaitTask_UIDChanged(t *testing.T) {
 		})
 	}
 }
+
+func TestWaitTask_SummaryEvent(t *testing.T) {
+	testDeployment1ID := testutil.ToIdentifier(t, testDeployment1YAML)
+	testDeployment2ID := testutil.ToIdentifier(t, testDeployment2YAML)
+	testDeployment3ID := testutil.ToIdentifier(t, testDeployment3YAML)
+	ids := object.ObjMetadataSet{
+		testDeployment3ID,
+		testDeployment2ID,
+		testDeployment1ID,
+	}
+	taskName := "wait-1"
+	task := NewWaitTask(taskName, ids, AllCurrent,
+		time.Second, testutil.NewFakeRESTMapper())
+	task.pending = ids
+
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := NewTaskContext(eventChannel, resourceCache)
+	defer close(eventChannel)
+
+	resourceCache.Put(testDeployment1ID, cache.ResourceStatus{
+		Status: status.InProgressStatus,
+	})
+	resourceCache.Put(testDeployment2ID, cache.ResourceStatus{
+		Status: status.FailedStatus,
+	})
+	resourceCache.Put(testDeployment3ID, cache.ResourceStatus{
+		Status: status.InProgressStatus,
+	})
+
+	go task.sendSummaryEvent(taskContext, 30*time.Second)
+
+	var received event.Event
+	select {
+	case received = <-taskContext.EventChannel():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for WaitSummaryEvent")
+	}
+
+	expected := event.Event{
+		Type: event.WaitSummaryType,
+		WaitSummaryEvent: event.WaitSummaryEvent{
+			GroupName: taskName,
+			Elapsed:   30 * time.Second,
+			Total:     3,
+			Counts: map[status.Status]int{
+				status.InProgressStatus: 2,
+				status.FailedStatus:     1,
+			},
+			Pending: []event.WaitSummaryGroup{
+				{
+					GroupKind:   testDeployment2ID.GroupKind,
+					Status:      status.FailedStatus,
+					Identifiers: object.ObjMetadataSet{testDeployment2ID},
+				},
+				{
+					GroupKind:   testDeployment3ID.GroupKind,
+					Status:      status.InProgressStatus,
+					Identifiers: object.ObjMetadataSet{testDeployment3ID},
+				},
+				{
+					GroupKind:   testDeployment1ID.GroupKind,
+					Status:      status.InProgressStatus,
+					Identifiers: object.ObjMetadataSet{testDeployment1ID},
+				},
+			},
+		},
+	}
+	testutil.AssertEqual(t, expected, received)
+}
+
+func TestWaitTask_SummaryEventCounts(t *testing.T) {
+	testDeployment1ID := testutil.ToIdentifier(t, testDeployment1YAML)
+	testDeployment2ID := testutil.ToIdentifier(t, testDeployment2YAML)
+	testDeployment3ID := testutil.ToIdentifier(t, testDeployment3YAML)
+	testDeployment4ID := testutil.ToIdentifier(t, testDeployment4YAML)
+	ids := object.ObjMetadataSet{
+		testDeployment1ID,
+		testDeployment2ID,
+		testDeployment3ID,
+		testDeployment4ID,
+	}
+	taskName := "wait-1"
+	task := NewWaitTask(taskName, ids, AllCurrent,
+		time.Second, testutil.NewFakeRESTMapper())
+	// Only one object is pending, but all the objects are counted.
+	task.pending = object.ObjMetadataSet{testDeployment2ID}
+
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := NewTaskContext(eventChannel, resourceCache)
+	defer close(eventChannel)
+
+	resourceCache.Put(testDeployment1ID, cache.ResourceStatus{
+		Status: status.CurrentStatus,
+	})
+	resourceCache.Put(testDeployment2ID, cache.ResourceStatus{
+		Status: status.InProgressStatus,
+	})
+	resourceCache.Put(testDeployment3ID, cache.ResourceStatus{
+		Status: status.CurrentStatus,
+	})
+	// testDeployment4 is not in the cache, so its status is Unknown.
+
+	go task.sendSummaryEvent(taskContext, 30*time.Second)
+
+	var received event.Event
+	select {
+	case received = <-taskContext.EventChannel():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for WaitSummaryEvent")
+	}
+
+	expected := event.Event{
+		Type: event.WaitSummaryType,
+		WaitSummaryEvent: event.WaitSummaryEvent{
+			GroupName: taskName,
+			Elapsed:   30 * time.Second,
+			Total:     4,
+			Counts: map[status.Status]int{
+				status.CurrentStatus:    2,
+				status.InProgressStatus: 1,
+				status.UnknownStatus:    1,
+			},
+			Pending: []event.WaitSummaryGroup{
+				{
+					GroupKind:   testDeployment2ID.GroupKind,
+					Status:      status.InProgressStatus,
+					Identifiers: object.ObjMetadataSet{testDeployment2ID},
+				},
+			},
+		},
+	}
+	testutil.AssertEqual(t, expected, received)
+}
+
+func TestWaitTask_SummaryEventTerminating(t *testing.T) {
+	deletedAt := metav1.NewTime(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	terminatingDeployment := testutil.Unstructured(t, testDeployment1YAML)
+	terminatingDeployment.SetDeletionTimestamp(&deletedAt)
+	terminatingDeployment.SetFinalizers([]string{"example.com/cleanup"})
+	testDeployment1ID := testutil.ToIdentifier(t, testDeployment1YAML)
+	testDeployment2ID := testutil.ToIdentifier(t, testDeployment2YAML)
+	ids := object.ObjMetadataSet{
+		testDeployment1ID,
+		testDeployment2ID,
+	}
+	taskName := "wait-1"
+	task := NewWaitTask(taskName, ids, AllNotFound,
+		time.Second, testutil.NewFakeRESTMapper())
+	task.pending = ids
+
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := NewTaskContext(eventChannel, resourceCache)
+	defer close(eventChannel)
+
+	resourceCache.Put(testDeployment1ID, cache.ResourceStatus{
+		Resource: terminatingDeployment,
+		Status:   status.TerminatingStatus,
+	})
+	resourceCache.Put(testDeployment2ID, cache.ResourceStatus{
+		Resource: testutil.Unstructured(t, testDeployment2YAML),
+		Status:   status.CurrentStatus,
+	})
+
+	go task.sendSummaryEvent(taskContext, 30*time.Second)
+
+	var received event.Event
+	select {
+	case received = <-taskContext.EventChannel():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for WaitSummaryEvent")
+	}
+
+	expected := event.Event{
+		Type: event.WaitSummaryType,
+		WaitSummaryEvent: event.WaitSummaryEvent{
+			GroupName: taskName,
+			Elapsed:   30 * time.Second,
+			Total:     2,
+			Counts: map[status.Status]int{
+				status.TerminatingStatus: 1,
+				status.CurrentStatus:     1,
+			},
+			Pending: []event.WaitSummaryGroup{
+				{
+					GroupKind:   testDeployment2ID.GroupKind,
+					Status:      status.CurrentStatus,
+					Identifiers: object.ObjMetadataSet{testDeployment2ID},
+				},
+				{
+					GroupKind:   testDeployment1ID.GroupKind,
+					Status:      status.TerminatingStatus,
+					Identifiers: object.ObjMetadataSet{testDeployment1ID},
+				},
+			},
+			Terminating: []event.TerminatingObject{
+				{
+					Identifier:        testDeployment1ID,
+					DeletionTimestamp: deletedAt.Time,
+					Finalizers:        []string{"example.com/cleanup"},
+				},
+			},
+		},
+	}
+	testutil.AssertEqual(t, expected, received)
+}
+
+func TestWaitTask_RemoveFinalizers(t *testing.T) {
+	testDeployment1ID := testutil.ToIdentifier(t, testDeployment1YAML)
+
+	testCases := map[string]struct {
+		deletedAgo       time.Duration
+		finalizers       []string
+		expectedRemoved  object.ObjMetadataSet
+		expectFinalizers bool
+	}{
+		"blocked longer than the grace period": {
+			deletedAgo:      2 * time.Minute,
+			finalizers:      []string{"example.com/cleanup"},
+			expectedRemoved: object.ObjMetadataSet{testDeployment1ID},
+		},
+		"blocked shorter than the grace period": {
+			deletedAgo:       30 * time.Second,
+			finalizers:       []string{"example.com/cleanup"},
+			expectFinalizers: true,
+		},
+		"no finalizers": {
+			deletedAgo: 2 * time.Minute,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			deletedAt := metav1.NewTime(time.Now().Add(-tc.deletedAgo))
+			deployment := testutil.Unstructured(t, testDeployment1YAML)
+			deployment.SetDeletionTimestamp(&deletedAt)
+			deployment.SetFinalizers(tc.finalizers)
+
+			client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), deployment.DeepCopy())
+			mapper := testutil.NewFakeRESTMapper(deployment.GroupVersionKind())
+			task := NewWaitTask("wait-1", object.ObjMetadataSet{testDeployment1ID}, AllNotFound,
+				time.Second, mapper)
+			task.RemoveFinalizersAfter = time.Minute
+			task.DynamicClient = client
+			task.pending = object.ObjMetadataSet{testDeployment1ID}
+
+			resourceCache := cache.NewResourceCacheMap()
+			taskContext := NewTaskContext(make(chan event.Event), resourceCache)
+			resourceCache.Put(testDeployment1ID, cache.ResourceStatus{
+				Resource: deployment,
+				Status:   status.TerminatingStatus,
+			})
+
+			for _, obj := range task.stuckObjects(taskContext) {
+				task.removeObjectFinalizers(context.Background(), obj)
+			}
+			testutil.AssertEqual(t, tc.expectedRemoved, task.finalizersRemoved)
+
+			gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+			live, err := client.Resource(gvr).Namespace(deployment.GetNamespace()).
+				Get(context.Background(), deployment.GetName(), metav1.GetOptions{})
+			require.NoError(t, err)
+			if tc.expectFinalizers {
+				assert.Equal(t, tc.finalizers, live.GetFinalizers())
+			} else {
+				assert.Empty(t, live.GetFinalizers())
+			}
+
+			// Objects are only patched once.
+			assert.Empty(t, task.stuckObjects(taskContext))
+		})
+	}
+}
+
+func TestWaitTask_GeneratedName(t *testing.T) {
+	createdID := testutil.ToIdentifier(t, testDeployment1YAML)
+	created := testutil.Unstructured(t, testDeployment1YAML)
+	created.SetUID("a")
+	created.SetGeneration(1)
+	id := object.ObjMetadata{
+		GroupKind:    createdID.GroupKind,
+		Namespace:    createdID.Namespace,
+		GenerateName: "foo-",
+	}
+	task := NewWaitTask("wait-generated", object.ObjMetadataSet{id}, AllCurrent,
+		time.Second, testutil.NewFakeRESTMapper())
+
+	eventChannel := make(chan event.Event, 10)
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := NewTaskContext(eventChannel, resourceCache)
+	defer close(eventChannel)
+
+	statusWatcher := &fakeIdentifierWatcher{}
+	taskContext.setStatusWatcher(statusWatcher)
+
+	// The object was created with a generated name, and is already Current.
+	taskContext.AddGeneratedObject(id, createdID)
+	taskContext.InventoryManager().AddSuccessfulApply(createdID, created.GetUID(), created.GetGeneration())
+	resourceCache.Put(createdID, cache.ResourceStatus{
+		Resource: created,
+		Status:   status.CurrentStatus,
+	})
+
+	task.Start(taskContext)
+	<-taskContext.TaskChannel()
+
+	assert.Equal(t, object.ObjMetadataSet{createdID}, task.Identifiers())
+	// The created object is watched, since its name was not known when the
+	// watch started.
+	assert.Equal(t, object.ObjMetadataSet{createdID}, statusWatcher.added)
+	e := <-eventChannel
+	assert.Equal(t, event.WaitType, e.Type)
+	assert.Equal(t, createdID, e.WaitEvent.Identifier)
+	assert.Equal(t, event.ReconcileSuccessful, e.WaitEvent.Status)
+}
+
+// fakeIdentifierWatcher records the objects added to the watched objects.
+type fakeIdentifierWatcher struct {
+	watcher.BlindStatusWatcher
+	added object.ObjMetadataSet
+}
+
+func (w *fakeIdentifierWatcher) AddIdentifiers(ids object.ObjMetadataSet) error {
+	w.added = append(w.added, ids...)
+	return nil
+}
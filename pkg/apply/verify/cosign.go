@@ -0,0 +1,90 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+// CosignVerifier verifies a signature created with
+// `cosign sign-blob --key <key> <file>`, with the matching public key. Only
+// key-based signatures are supported: keyless signatures need the sigstore
+// transparency log and certificate authority.
+type CosignVerifier struct {
+	// SignaturePath is the file with the signature, as written by
+	// `cosign sign-blob`, base64 encoded.
+	SignaturePath string
+	// PublicKey is the PEM encoded public key, like the cosign.pub file
+	// written by `cosign generate-key-pair`. ECDSA, Ed25519, and RSA keys
+	// are supported.
+	PublicKey []byte
+}
+
+var _ Verifier = &CosignVerifier{}
+
+// Verify checks the signature of the content.
+func (v *CosignVerifier) Verify(_ context.Context, source string, content []byte) (Result, error) {
+	result := newResult(source, MethodCosign, content)
+	signer, err := v.verify(content)
+	if err != nil {
+		return result, &Error{
+			Source: source,
+			Method: MethodCosign,
+			Err:    err,
+		}
+	}
+	result.Signer = signer
+	return result, nil
+}
+
+// verify returns the digest of the public key if the signature is valid.
+func (v *CosignVerifier) verify(content []byte) (string, error) {
+	block, _ := pem.Decode(v.PublicKey)
+	if block == nil {
+		return "", fmt.Errorf("invalid public key: no PEM data found")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: %w", err)
+	}
+	encoded, err := ioutil.ReadFile(v.SignaturePath)
+	if err != nil {
+		return "", err
+	}
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encoded)))
+	if err != nil {
+		return "", fmt.Errorf("invalid signature: %w", err)
+	}
+
+	digest := sha256.Sum256(content)
+	var valid bool
+	switch key := pub.(type) {
+	case *ecdsa.PublicKey:
+		valid = ecdsa.VerifyASN1(key, digest[:], sig)
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, content, sig)
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+	default:
+		return "", fmt.Errorf("unsupported public key type %T", pub)
+	}
+	if !valid {
+		return "", errors.New("invalid signature")
+	}
+	keyDigest := sha256.Sum256(block.Bytes)
+	return "sha256:" + hex.EncodeToString(keyDigest[:]), nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var manifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`
+
+func TestCosignVerifier_Verify(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	digest := sha256.Sum256([]byte(manifest))
+	ecdsaSig, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, digest[:])
+	require.NoError(t, err)
+	edSig := ed25519.Sign(edKey, []byte(manifest))
+
+	testCases := map[string]struct {
+		content   string
+		signature string
+		publicKey crypto.PublicKey
+
+		expectedErr string
+	}{
+		"valid ECDSA signature": {
+			content:   manifest,
+			signature: base64.StdEncoding.EncodeToString(ecdsaSig) + "\n",
+			publicKey: &ecdsaKey.PublicKey,
+		},
+		"valid Ed25519 signature": {
+			content:   manifest,
+			signature: base64.StdEncoding.EncodeToString(edSig),
+			publicKey: edPub,
+		},
+		"modified content": {
+			content:     manifest + "data: {}\n",
+			signature:   base64.StdEncoding.EncodeToString(ecdsaSig),
+			publicKey:   &ecdsaKey.PublicKey,
+			expectedErr: "invalid signature",
+		},
+		"other key": {
+			content:     manifest,
+			signature:   base64.StdEncoding.EncodeToString(ecdsaSig),
+			publicKey:   &otherKey.PublicKey,
+			expectedErr: "invalid signature",
+		},
+		"signature not base64 encoded": {
+			content:     manifest,
+			signature:   string(ecdsaSig),
+			publicKey:   &ecdsaKey.PublicKey,
+			expectedErr: "invalid signature: illegal base64 data",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			dir := t.TempDir()
+			der, err := x509.MarshalPKIXPublicKey(tc.publicKey)
+			require.NoError(t, err)
+
+			verifier := &CosignVerifier{
+				SignaturePath: writeFile(t, dir, "manifest.yaml.sig", tc.signature),
+				PublicKey:     pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+			}
+			result, err := verifier.Verify(context.Background(), "manifest.yaml", []byte(tc.content))
+			assert.Equal(t, "manifest.yaml", result.Source)
+			assert.Equal(t, MethodCosign, result.Method)
+			contentDigest := sha256.Sum256([]byte(tc.content))
+			assert.Equal(t, "sha256:"+hex.EncodeToString(contentDigest[:]), result.Digest)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				var verifyErr *Error
+				assert.ErrorAs(t, err, &verifyErr)
+				assert.Empty(t, result.Signer)
+				return
+			}
+			require.NoError(t, err)
+			keyDigest := sha256.Sum256(der)
+			assert.Equal(t, "sha256:"+hex.EncodeToString(keyDigest[:]), result.Signer)
+		})
+	}
+}
+
+func TestCosignVerifier_InvalidPublicKey(t *testing.T) {
+	dir := t.TempDir()
+	verifier := &CosignVerifier{
+		SignaturePath: writeFile(t, dir, "manifest.yaml.sig", ""),
+		PublicKey:     []byte("not a key"),
+	}
+	_, err := verifier.Verify(context.Background(), "manifest.yaml", []byte(manifest))
+	assert.EqualError(t, err, `failed to verify cosign signature of "manifest.yaml": invalid public key: no PEM data found`)
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	p := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(p, []byte(content), 0600))
+	return p
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// DefaultGPGCommand is the gpg binary used to verify signatures, if the
+// GPGVerifier does not specify one.
+const DefaultGPGCommand = "gpg"
+
+// GPGVerifier verifies a detached GPG signature by running `gpg --verify`.
+// The content is passed to gpg on stdin.
+type GPGVerifier struct {
+	// SignaturePath is the detached signature, binary or ASCII armored.
+	SignaturePath string
+	// Keyring is the keyring with the trusted public keys. If empty, the
+	// default keyring of gpg is used.
+	Keyring string
+	// GPGCommand is the gpg binary. If empty, DefaultGPGCommand is used.
+	GPGCommand string
+}
+
+var _ Verifier = &GPGVerifier{}
+
+// Verify checks the signature of the content.
+func (v *GPGVerifier) Verify(ctx context.Context, source string, content []byte) (Result, error) {
+	result := newResult(source, MethodGPG, content)
+	signer, err := v.verify(ctx, content)
+	if err != nil {
+		return result, &Error{
+			Source: source,
+			Method: MethodGPG,
+			Err:    err,
+		}
+	}
+	result.Signer = signer
+	return result, nil
+}
+
+// verify returns the fingerprint of the signing key if the signature is
+// valid.
+func (v *GPGVerifier) verify(ctx context.Context, content []byte) (string, error) {
+	gpg := v.GPGCommand
+	if gpg == "" {
+		gpg = DefaultGPGCommand
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, gpg, v.args()...) // nolint:gosec
+	cmd.Stdin = bytes.NewReader(content)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%v: %s", err, msg)
+		}
+		return "", err
+	}
+	return validSignature(stdout.String())
+}
+
+// args returns the arguments of `gpg --verify`, with the status written to
+// stdout, and the signed content read from stdin.
+func (v *GPGVerifier) args() []string {
+	args := []string{"--batch", "--status-fd", "1"}
+	if v.Keyring != "" {
+		args = append(args, "--no-default-keyring", "--keyring", v.Keyring)
+	}
+	return append(args, "--verify", v.SignaturePath, "-")
+}
+
+// validSignature returns the fingerprint of the key from the VALIDSIG line
+// of the gpg status output.
+func validSignature(status string) (string, error) {
+	scanner := bufio.NewScanner(strings.NewReader(status))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 3 && fields[0] == "[GNUPG:]" && fields[1] == "VALIDSIG" {
+			return fields[2], nil
+		}
+	}
+	return "", errors.New("no valid signature found")
+}
@@ -0,0 +1,122 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package verify
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGPG reports a valid signature of the content on stdin.
+var fakeGPG = `#!/bin/sh
+grep -q "name: cm" || exit 1
+echo "[GNUPG:] NEWSIG"
+echo "[GNUPG:] GOODSIG 0123456789ABCDEF Release Signing Key"
+echo "[GNUPG:] VALIDSIG 0123456789ABCDEF0123456789ABCDEF01234567 2022-01-01 1640995200 0 4 0 1 10 00 0123456789ABCDEF0123456789ABCDEF01234567"
+`
+
+var badGPG = `#!/bin/sh
+echo "[GNUPG:] BADSIG 0123456789ABCDEF Release Signing Key"
+echo "gpg: BAD signature from \"Release Signing Key\"" >&2
+exit 1
+`
+
+// noValidSigGPG exits successfully without reporting a valid signature.
+var noValidSigGPG = `#!/bin/sh
+echo "[GNUPG:] NEWSIG"
+`
+
+func TestGPGVerifier_Verify(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake gpg command is a shell script")
+	}
+	dir := t.TempDir()
+
+	testCases := map[string]struct {
+		gpg     string
+		keyring string
+
+		expectedSigner string
+		expectedErr    string
+	}{
+		"valid signature": {
+			gpg:            fakeGPG,
+			expectedSigner: "0123456789ABCDEF0123456789ABCDEF01234567",
+		},
+		"valid signature with keyring": {
+			gpg:            fakeGPG,
+			keyring:        "trusted.gpg",
+			expectedSigner: "0123456789ABCDEF0123456789ABCDEF01234567",
+		},
+		"bad signature": {
+			gpg: badGPG,
+			expectedErr: `failed to verify gpg signature of "manifest.yaml": exit status 1: ` +
+				`gpg: BAD signature from "Release Signing Key"`,
+		},
+		"no valid signature": {
+			gpg:         noValidSigGPG,
+			expectedErr: `failed to verify gpg signature of "manifest.yaml": no valid signature found`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			gpg := filepath.Join(dir, "gpg")
+			require.NoError(t, ioutil.WriteFile(gpg, []byte(tc.gpg), 0700))
+
+			verifier := &GPGVerifier{
+				SignaturePath: "manifest.yaml.asc",
+				Keyring:       tc.keyring,
+				GPGCommand:    gpg,
+			}
+			result, err := verifier.Verify(context.Background(), "manifest.yaml", []byte(manifest))
+			assert.Equal(t, "manifest.yaml", result.Source)
+			assert.Equal(t, MethodGPG, result.Method)
+			digest := sha256.Sum256([]byte(manifest))
+			assert.Equal(t, "sha256:"+hex.EncodeToString(digest[:]), result.Digest)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSigner, result.Signer)
+		})
+	}
+}
+
+func TestGPGVerifier_Args(t *testing.T) {
+	testCases := map[string]struct {
+		keyring      string
+		expectedArgs []string
+	}{
+		"default keyring": {
+			expectedArgs: []string{"--batch", "--status-fd", "1",
+				"--verify", "manifest.yaml.asc", "-"},
+		},
+		"keyring": {
+			keyring: "trusted.gpg",
+			expectedArgs: []string{"--batch", "--status-fd", "1",
+				"--no-default-keyring", "--keyring", "trusted.gpg",
+				"--verify", "manifest.yaml.asc", "-"},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			verifier := &GPGVerifier{
+				SignaturePath: "manifest.yaml.asc",
+				Keyring:       tc.keyring,
+			}
+			assert.Equal(t, tc.expectedArgs, verifier.args())
+		})
+	}
+}
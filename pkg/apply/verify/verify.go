@@ -0,0 +1,78 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package verify checks the provenance of manifests with detached
+// signatures, before they are applied.
+//
+// Verifiers check the content the objects are read from, so that the
+// verified content can not change before it is read. They are run by the
+// manifestreader.VerifiedManifestReader, which fails if any verification
+// fails. The Results can be passed to the Applier with
+// apply.ApplierOptions.Verified, which sends a VerificationEvent for each of
+// them.
+package verify
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	// MethodCosign is the Method of signatures created with
+	// `cosign sign-blob`.
+	MethodCosign = "cosign"
+	// MethodGPG is the Method of detached GPG signatures.
+	MethodGPG = "gpg"
+)
+
+// Verifier checks the signature of the content of a manifest file or
+// bundle.
+type Verifier interface {
+	// Verify returns the Result of the verification of the content read
+	// from the source. The Source, Method, and Digest of the Result are set
+	// even if the verification fails.
+	Verify(ctx context.Context, source string, content []byte) (Result, error)
+}
+
+// Result describes a successful verification.
+type Result struct {
+	// Source is the name of the verified file or bundle.
+	Source string
+	// Digest is the SHA-256 digest of the verified content, in the
+	// "sha256:<hex>" format.
+	Digest string
+	// Method is the signature method, like MethodCosign or MethodGPG.
+	Method string
+	// Signer identifies the key that made the signature: the fingerprint
+	// of a GPG key, or the SHA-256 digest of a cosign public key.
+	Signer string
+}
+
+// Error is returned by a Verifier when the signature of the source could not
+// be verified.
+type Error struct {
+	Source string
+	Method string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("failed to verify %s signature of %q: %v", e.Method, e.Source, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// newResult returns the Result of the verification of the content with the
+// method, without a Signer.
+func newResult(source, method string, content []byte) Result {
+	digest := sha256.Sum256(content)
+	return Result{
+		Source: source,
+		Digest: "sha256:" + hex.EncodeToString(digest[:]),
+		Method: method,
+	}
+}
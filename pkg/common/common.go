@@ -6,6 +6,7 @@ package common
 import (
 	"fmt"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/rand"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 )
@@ -29,6 +30,28 @@ const (
 	OnRemoveAnnotation = "cli-utils.sigs.k8s.io/on-remove"
 	// Resource lifecycle annotation value to prevent deletion.
 	OnRemoveKeep = "keep"
+	// Resource lifecycle annotation key to skip applying an object,
+	// while keeping it in the inventory.
+	IgnoreAnnotation = "cli-utils.sigs.k8s.io/ignore"
+	// Resource lifecycle annotation value to skip applying an object.
+	IgnoreTrue = "true"
+	// Resource lifecycle annotation key for "on-apply" operations.
+	OnApplyAnnotation = "cli-utils.sigs.k8s.io/on-apply"
+	// Resource lifecycle annotation value to delete an object, instead of
+	// applying it, and wait until it is NotFound.
+	OnApplyDelete = "delete"
+	// Resource lifecycle annotation key to override the deletion
+	// propagation policy (Foreground, Background, or Orphan) used when
+	// pruning or destroying an object.
+	DeletionPropagationAnnotation = "cli-utils.sigs.k8s.io/deletion-propagation-policy"
+	// Resource lifecycle annotation key to override how long to wait for an
+	// object to reconcile after it is applied, or to be deleted after it is
+	// pruned or destroyed. The value is a duration, like "10m".
+	ReconcileTimeoutAnnotation = "cli-utils.sigs.k8s.io/reconcile-timeout"
+	// Resource lifecycle annotation key to override when an applied object
+	// is reconciled. The value is an expression of JSONPath values, like
+	// "status.phase == 'Bound'", that is reconciled when it is true.
+	ReadyWhenAnnotation = "cli-utils.sigs.k8s.io/ready-when"
 	// Maximum random number, non-inclusive, eight digits.
 	maxRandInt = 100000000
 	// DefaultFieldManager is default owner of applied fields in
@@ -63,6 +86,18 @@ func NoDeletion(key, value string) bool {
 	return false
 }
 
+// NoApply checks the passed in annotation key and value and returns
+// true if that matches with the ignore annotation.
+func NoApply(key, value string) bool {
+	return key == IgnoreAnnotation && value == IgnoreTrue
+}
+
+// DeleteOnApply checks the passed in annotation key and value and returns
+// true if that matches with the delete on apply annotation.
+func DeleteOnApply(key, value string) bool {
+	return key == OnApplyAnnotation && value == OnApplyDelete
+}
+
 var Strategies = []DryRunStrategy{DryRunClient, DryRunServer}
 
 //go:generate stringer -type=DryRunStrategy
@@ -125,6 +160,32 @@ type ServerSideOptions struct {
 	// ForceConflicts overwrites the fields when applying if the field manager differs.
 	ForceConflicts bool
 
+	// ForceConflictsWith are the field managers whose fields may be
+	// overwritten. A server-side apply that fails only with conflicts with
+	// these managers is retried with ForceConflicts. Conflicts with any
+	// other manager fail the apply.
+	ForceConflictsWith []string
+
 	// FieldManager identifies the client "owner" of the applied fields (e.g. kubectl)
 	FieldManager string
+
+	// FieldValidation instructs the server how to handle unknown or
+	// duplicate fields in the applied objects, with both client-side and
+	// server-side apply: metav1.FieldValidationIgnore drops them,
+	// metav1.FieldValidationWarn drops them and returns a warning, which is
+	// added to the ApplyEvent, and metav1.FieldValidationStrict fails the
+	// apply. If empty, the server default is used. Servers without
+	// server-side field validation ignore this option.
+	FieldValidation string
+}
+
+// ValidateFieldValidation returns an error if the FieldValidation is not one
+// of the values supported by the server.
+func (o ServerSideOptions) ValidateFieldValidation() error {
+	switch o.FieldValidation {
+	case "", metav1.FieldValidationIgnore, metav1.FieldValidationWarn, metav1.FieldValidationStrict:
+		return nil
+	default:
+		return fmt.Errorf("invalid FieldValidation: %q", o.FieldValidation)
+	}
 }
@@ -0,0 +1,166 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultRetryInitialBackoff is the default wait before the first retry.
+	DefaultRetryInitialBackoff = 500 * time.Millisecond
+	// DefaultRetryMaxBackoff is the default maximum wait between retries.
+	DefaultRetryMaxBackoff = 30 * time.Second
+)
+
+// RetryPolicy defines how requests that fail with a transient API error are
+// retried. Conflicts (409), throttling (429), server errors (5xx) and
+// timeouts are retried. Server-side apply field manager conflicts and failed
+// UID or resourceVersion preconditions are not retried, because they do not
+// resolve themselves.
+//
+// The zero value disables retries.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of times a request is retried.
+	MaxRetries int
+	// InitialBackoff is how long to wait before the first retry. The wait
+	// doubles after every retry. If this is not provided, the default is
+	// DefaultRetryInitialBackoff.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum wait between retries. If this is not
+	// provided, the default is DefaultRetryMaxBackoff.
+	MaxBackoff time.Duration
+}
+
+// Backoff returns how long to wait before the passed retry (starting at 1),
+// after the passed error. If the server suggested a delay, it is used
+// instead, as long as it is not longer than MaxBackoff.
+func (p RetryPolicy) Backoff(retry int, err error) time.Duration {
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryMaxBackoff
+	}
+	if seconds, ok := apierrors.SuggestsClientDelay(err); ok && seconds > 0 {
+		delay := time.Duration(seconds) * time.Second
+		if delay > maxBackoff {
+			return maxBackoff
+		}
+		return delay
+	}
+	backoff := p.InitialBackoff
+	if backoff <= 0 {
+		backoff = DefaultRetryInitialBackoff
+	}
+	for i := 1; i < retry && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+// Do calls fn until it succeeds, fails with an error that is not retriable,
+// MaxRetries is reached, or the context is done. onRetry, if not nil, is
+// called before waiting to retry. Returns the last error from fn.
+func (p RetryPolicy) Do(ctx context.Context, fn func() error, onRetry func(retry int, backoff time.Duration, err error)) error {
+	return p.do(ctx, IsRetriableError, fn, onRetry)
+}
+
+// DoCreate is like Do, for requests that create an object with a generated
+// name. They are not idempotent: after a timeout or a server error, the
+// object may have been created, and a retry would create another one. Only
+// the errors of IsRetriableCreateError are retried.
+func (p RetryPolicy) DoCreate(ctx context.Context, fn func() error, onRetry func(retry int, backoff time.Duration, err error)) error {
+	return p.do(ctx, IsRetriableCreateError, fn, onRetry)
+}
+
+func (p RetryPolicy) do(ctx context.Context, retriable func(error) bool, fn func() error,
+	onRetry func(retry int, backoff time.Duration, err error)) error {
+	err := fn()
+	for retry := 1; retry <= p.MaxRetries && err != nil && retriable(err); retry++ {
+		backoff := p.Backoff(retry, err)
+		if onRetry != nil {
+			onRetry(retry, backoff, err)
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		err = fn()
+	}
+	return err
+}
+
+// IsRetriableError returns true if the error is a transient API error, which
+// may succeed if the request is retried.
+func IsRetriableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch {
+	case apierrors.IsConflict(err):
+		return !isFieldManagerConflict(err) && !isPreconditionFailure(err)
+	case apierrors.IsTooManyRequests(err),
+		apierrors.IsServerTimeout(err),
+		apierrors.IsTimeout(err),
+		apierrors.IsInternalError(err),
+		apierrors.IsServiceUnavailable(err),
+		apierrors.IsUnexpectedServerError(err):
+		return true
+	}
+	var statusErr apierrors.APIStatus
+	if errors.As(err, &statusErr) {
+		code := statusErr.Status().Code
+		return code >= 500 && code != 501
+	}
+	return false
+}
+
+// IsRetriableCreateError returns true if the error guarantees that a create
+// request did not create the object, and may succeed if it is retried. Only
+// throttling errors qualify, because they are returned before the request is
+// processed.
+func IsRetriableCreateError(err error) bool {
+	return apierrors.IsTooManyRequests(err)
+}
+
+// isFieldManagerConflict returns true if the error is a server-side apply
+// conflict with another field manager.
+func isFieldManagerConflict(err error) bool {
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	details := statusErr.Status().Details
+	if details == nil {
+		return false
+	}
+	for _, cause := range details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict {
+			return true
+		}
+	}
+	return false
+}
+
+// isPreconditionFailure returns true if the error is a conflict caused by a
+// UID or resourceVersion precondition that does not match the object. The
+// preconditions of a retry are the same, so it fails again.
+func isPreconditionFailure(err error) bool {
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return strings.Contains(statusErr.Status().Message, "Precondition failed")
+}
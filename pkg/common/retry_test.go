@@ -0,0 +1,229 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var deploymentsGR = schema.GroupResource{Group: "apps", Resource: "deployments"}
+
+func TestIsRetriableError(t *testing.T) {
+	fieldManagerConflict := apierrors.NewApplyConflict([]metav1.StatusCause{
+		{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kubectl"`,
+			Field:   ".spec.replicas",
+		},
+	}, "Apply failed with 1 conflict")
+
+	testCases := map[string]struct {
+		err       error
+		retriable bool
+	}{
+		"nil": {
+			err:       nil,
+			retriable: false,
+		},
+		"not an API error": {
+			err:       fmt.Errorf("connection refused"),
+			retriable: false,
+		},
+		"not found": {
+			err:       apierrors.NewNotFound(deploymentsGR, "foo"),
+			retriable: false,
+		},
+		"forbidden": {
+			err:       apierrors.NewForbidden(deploymentsGR, "foo", fmt.Errorf("denied")),
+			retriable: false,
+		},
+		"conflict": {
+			err:       apierrors.NewConflict(deploymentsGR, "foo", fmt.Errorf("object has been modified")),
+			retriable: true,
+		},
+		"uid precondition failed": {
+			err: apierrors.NewConflict(deploymentsGR, "foo",
+				fmt.Errorf("Precondition failed: UID in precondition: 123, UID in object meta: 456")),
+			retriable: false,
+		},
+		"resource version precondition failed": {
+			err: apierrors.NewConflict(deploymentsGR, "foo",
+				fmt.Errorf("Precondition failed: ResourceVersion in precondition: 1, ResourceVersion in object meta: 2")),
+			retriable: false,
+		},
+		"field manager conflict": {
+			err:       fieldManagerConflict,
+			retriable: false,
+		},
+		"too many requests": {
+			err:       apierrors.NewTooManyRequests("slow down", 1),
+			retriable: true,
+		},
+		"internal error": {
+			err:       apierrors.NewInternalError(fmt.Errorf("etcd unavailable")),
+			retriable: true,
+		},
+		"service unavailable": {
+			err:       apierrors.NewServiceUnavailable("unavailable"),
+			retriable: true,
+		},
+		"server timeout": {
+			err:       apierrors.NewServerTimeout(deploymentsGR, "patch", 1),
+			retriable: true,
+		},
+		"wrapped conflict": {
+			err:       fmt.Errorf("apply failed: %w", apierrors.NewConflict(deploymentsGR, "foo", fmt.Errorf("modified"))),
+			retriable: true,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.retriable, IsRetriableError(tc.err))
+		})
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+	}
+	err := fmt.Errorf("failed")
+	assert.Equal(t, time.Second, policy.Backoff(1, err))
+	assert.Equal(t, 2*time.Second, policy.Backoff(2, err))
+	assert.Equal(t, 4*time.Second, policy.Backoff(3, err))
+	assert.Equal(t, 5*time.Second, policy.Backoff(4, err))
+
+	// The delay suggested by the server is used, up to the maximum.
+	assert.Equal(t, 3*time.Second, policy.Backoff(1, apierrors.NewTooManyRequests("slow down", 3)))
+	assert.Equal(t, 5*time.Second, policy.Backoff(1, apierrors.NewTooManyRequests("slow down", 60)))
+
+	// Defaults
+	assert.Equal(t, DefaultRetryInitialBackoff, RetryPolicy{}.Backoff(1, err))
+	assert.Equal(t, DefaultRetryMaxBackoff, RetryPolicy{}.Backoff(100, err))
+}
+
+func TestRetryPolicy_Do(t *testing.T) {
+	conflict := apierrors.NewConflict(deploymentsGR, "foo", fmt.Errorf("modified"))
+	notFound := apierrors.NewNotFound(deploymentsGR, "foo")
+
+	testCases := map[string]struct {
+		maxRetries      int
+		errs            []error
+		expectedErr     error
+		expectedCalls   int
+		expectedRetries []int
+	}{
+		"success": {
+			maxRetries:    3,
+			errs:          []error{nil},
+			expectedCalls: 1,
+		},
+		"retries disabled": {
+			maxRetries:    0,
+			errs:          []error{conflict},
+			expectedErr:   conflict,
+			expectedCalls: 1,
+		},
+		"success after retries": {
+			maxRetries:      3,
+			errs:            []error{conflict, conflict, nil},
+			expectedCalls:   3,
+			expectedRetries: []int{1, 2},
+		},
+		"retries exhausted": {
+			maxRetries:      2,
+			errs:            []error{conflict, conflict, conflict, nil},
+			expectedErr:     conflict,
+			expectedCalls:   3,
+			expectedRetries: []int{1, 2},
+		},
+		"error not retriable": {
+			maxRetries:      3,
+			errs:            []error{conflict, notFound, nil},
+			expectedErr:     notFound,
+			expectedCalls:   2,
+			expectedRetries: []int{1},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			policy := RetryPolicy{
+				MaxRetries:     tc.maxRetries,
+				InitialBackoff: time.Millisecond,
+			}
+			calls := 0
+			var retries []int
+			err := policy.Do(context.Background(), func() error {
+				err := tc.errs[calls]
+				calls++
+				return err
+			}, func(retry int, backoff time.Duration, err error) {
+				retries = append(retries, retry)
+				assert.Error(t, err)
+			})
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedCalls, calls)
+			assert.Equal(t, tc.expectedRetries, retries)
+		})
+	}
+}
+
+func TestRetryPolicy_DoCancelled(t *testing.T) {
+	conflict := apierrors.NewConflict(deploymentsGR, "foo", fmt.Errorf("modified"))
+	policy := RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: time.Hour,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := policy.Do(ctx, func() error {
+		calls++
+		return conflict
+	}, func(int, time.Duration, error) {
+		// Cancel while waiting to retry.
+		cancel()
+	})
+	assert.Equal(t, conflict, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryPolicy_DoCreate(t *testing.T) {
+	throttled := apierrors.NewTooManyRequests("slow down", 0)
+	timeout := apierrors.NewServerTimeout(deploymentsGR, "create", 0)
+	policy := RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: time.Millisecond,
+	}
+
+	// Throttled creates are retried.
+	errs := []error{throttled, nil}
+	calls := 0
+	err := policy.DoCreate(context.Background(), func() error {
+		err := errs[calls]
+		calls++
+		return err
+	}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	// Creates that may have created the object are not.
+	calls = 0
+	err = policy.DoCreate(context.Background(), func() error {
+		calls++
+		return timeout
+	}, nil)
+	assert.Equal(t, timeout, err)
+	assert.Equal(t, 1, calls)
+}
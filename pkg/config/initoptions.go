@@ -16,6 +16,7 @@ import (
 	"k8s.io/klog/v2"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/inventory/configmap"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/kio/filters"
@@ -78,8 +79,11 @@ func (i *InitOptions) Complete(args []string) error {
 		}
 		i.InventoryID = inventoryID
 	}
+	if err := inventory.ValidateID(i.InventoryID); err != nil {
+		return err
+	}
 	if !validateInventoryID(i.InventoryID) {
-		return fmt.Errorf("invalid group name: %s", i.InventoryID)
+		return fmt.Errorf("invalid inventory id %q: must be at least 3 characters", i.InventoryID)
 	}
 	// Output the calculated namespace used for inventory object.
 	fmt.Fprintf(i.ioStreams.Out, "namespace: %s is used for inventory object\n", i.Namespace)
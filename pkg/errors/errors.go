@@ -5,6 +5,7 @@ package errors
 
 import (
 	"bytes"
+	stderrors "errors"
 	"fmt"
 	"io"
 	"os"
@@ -21,6 +22,22 @@ const (
 	DefaultErrorExitCode = 1
 )
 
+// Exit codes of runs that did not succeed, by the class of their
+// apply.RunResult. Runs that ended with a fatal error exit with
+// DefaultErrorExitCode. The commands return errors that implement ExitCoder
+// to select them.
+const (
+	ReconcileTimeoutExitCode = 2
+	PolicyViolationExitCode  = 3
+	ActuationFailedExitCode  = 4
+	ReconcileFailedExitCode  = 5
+	CancelledExitCode        = 130
+)
+
+// ChangesFoundExitCode is the exit code of the diff command when applying
+// the objects would change the cluster.
+const ChangesFoundExitCode = 6
+
 var errorMsgForType map[reflect.Type]string
 var statusCodeForType map[reflect.Type]int
 
@@ -128,3 +145,39 @@ func findErrExitCode(err error) int {
 	}
 	return DefaultErrorExitCode
 }
+
+// ChangesFoundError is returned by the diff command when applying the
+// objects would change the cluster. Changes is the number of objects that
+// would be created, updated, or pruned.
+type ChangesFoundError struct {
+	Changes int
+}
+
+func (e *ChangesFoundError) Error() string {
+	return fmt.Sprintf("%d object(s) would be changed", e.Changes)
+}
+
+// ExitCode returns ChangesFoundExitCode.
+func (e *ChangesFoundError) ExitCode() int {
+	return ChangesFoundExitCode
+}
+
+// ExitCoder is implemented by errors that define the exit code of the
+// command that returned them.
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// ExitCode returns the exit code for the error returned by a command:
+// zero if there is no error, the exit code of the first ExitCoder in the
+// chain of the error, or the exit code defined for the type of the error.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var coder ExitCoder
+	if stderrors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+	return findErrExitCode(err)
+}
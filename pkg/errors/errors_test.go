@@ -69,3 +69,50 @@ type sliceError []string
 func (s sliceError) Error() string {
 	return "this is a test"
 }
+
+// exitCodeError is an error with its own exit code.
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("exit code %d", e.code)
+}
+
+func (e *exitCodeError) ExitCode() int {
+	return e.code
+}
+
+func TestExitCode(t *testing.T) {
+	testCases := map[string]struct {
+		err              error
+		expectedExitCode int
+	}{
+		"no error": {
+			err:              nil,
+			expectedExitCode: 0,
+		},
+		"unknown error": {
+			err:              fmt.Errorf("this is a test"),
+			expectedExitCode: DefaultErrorExitCode,
+		},
+		"exit coder": {
+			err:              &exitCodeError{code: ReconcileTimeoutExitCode},
+			expectedExitCode: 2,
+		},
+		"wrapped exit coder": {
+			err:              fmt.Errorf("apply: %w", &exitCodeError{code: CancelledExitCode}),
+			expectedExitCode: 130,
+		},
+		"changes found": {
+			err:              &ChangesFoundError{Changes: 2},
+			expectedExitCode: 6,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expectedExitCode, ExitCode(tc.err))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package flowcontrol
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/rest"
+)
+
+// DefaultRateLimits are the client-side rate limits used when the server
+// does not have PriorityAndFairness enabled. They are higher than the
+// client-go defaults (5 QPS, burst of 10), which are too low to apply large
+// numbers of objects in a reasonable time.
+var DefaultRateLimits = RateLimits{
+	QPS:   30,
+	Burst: 60,
+}
+
+// RateLimits are the client-side rate limits of a rest.Config.
+type RateLimits struct {
+	// QPS is the maximum queries per second. Negative disables client-side
+	// rate limiting.
+	QPS float32
+	// Burst is the maximum burst of queries.
+	Burst int
+}
+
+// Disabled returns true if client-side rate limiting is disabled.
+func (rl RateLimits) Disabled() bool {
+	return rl.QPS < 0
+}
+
+// String returns a string suitable for logging
+func (rl RateLimits) String() string {
+	if rl.Disabled() {
+		return "RateLimits{ Disabled }"
+	}
+	return fmt.Sprintf("RateLimits{ QPS: %v, Burst: %d }", rl.QPS, rl.Burst)
+}
+
+// ConfigureRateLimits configures the client-side rate limits of the passed
+// config, based on whether the server has PriorityAndFairness enabled.
+// If enabled, client-side rate limiting is disabled, because the server
+// throttles requests itself. Otherwise, the passed limits are used, unless
+// the config already has explicit limits. Returns the chosen limits and
+// whether PriorityAndFairness is enabled.
+func ConfigureRateLimits(ctx context.Context, config *rest.Config, limits RateLimits) (RateLimits, bool, error) {
+	enabled, err := IsEnabled(ctx, config)
+	if err != nil {
+		return RateLimits{}, false, fmt.Errorf("checking server-side throttling enablement: %w", err)
+	}
+	if enabled {
+		config.QPS = -1
+		config.Burst = -1
+	} else if config.QPS == 0 && config.Burst == 0 && config.RateLimiter == nil {
+		config.QPS = limits.QPS
+		config.Burst = limits.Burst
+	}
+	return RateLimits{QPS: config.QPS, Burst: config.Burst}, enabled, nil
+}
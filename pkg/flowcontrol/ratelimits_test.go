@@ -0,0 +1,76 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package flowcontrol
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	flowcontrolapi "k8s.io/api/flowcontrol/v1beta2"
+	"k8s.io/client-go/rest"
+)
+
+func TestConfigureRateLimits(t *testing.T) {
+	limits := RateLimits{QPS: 30, Burst: 60}
+
+	testCases := map[string]struct {
+		apfEnabled      bool
+		config          rest.Config
+		expectedLimits  RateLimits
+		expectedEnabled bool
+	}{
+		"enabled": {
+			apfEnabled:      true,
+			expectedLimits:  RateLimits{QPS: -1, Burst: -1},
+			expectedEnabled: true,
+		},
+		"enabled with explicit limits": {
+			apfEnabled:      true,
+			config:          rest.Config{QPS: 5, Burst: 10},
+			expectedLimits:  RateLimits{QPS: -1, Burst: -1},
+			expectedEnabled: true,
+		},
+		"disabled": {
+			apfEnabled:     false,
+			expectedLimits: limits,
+		},
+		"disabled with explicit limits": {
+			apfEnabled:     false,
+			config:         rest.Config{QPS: 5, Burst: 10},
+			expectedLimits: RateLimits{QPS: 5, Burst: 10},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, "/livez/ping", req.URL.Path)
+				if tc.apfEnabled {
+					w.Header().Add(flowcontrolapi.ResponseHeaderMatchedFlowSchemaUID, "unused-uuid")
+				}
+				w.WriteHeader(http.StatusOK)
+			})
+
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			cfg := tc.config
+			cfg.Host = server.URL
+
+			chosen, enabled, err := ConfigureRateLimits(ctx, &cfg, limits)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedEnabled, enabled)
+			assert.Equal(t, tc.expectedLimits, chosen)
+			assert.Equal(t, tc.expectedLimits.QPS, cfg.QPS)
+			assert.Equal(t, tc.expectedLimits.Burst, cfg.Burst)
+		})
+	}
+}
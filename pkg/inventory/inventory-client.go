@@ -6,6 +6,7 @@ package inventory
 import (
 	"context"
 	"fmt"
+	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -46,6 +47,15 @@ type Client interface {
 	GetClusterInventoryObjs(inv Info) (object.UnstructuredSet, error)
 }
 
+// PolicyMerger is implemented by the inventory clients that check the
+// inventory policy before Merge adopts a cluster inventory object with
+// another inventory id.
+type PolicyMerger interface {
+	MergeWithPolicy(inv Info, objs object.ObjMetadataSet, dryRun common.DryRunStrategy, policy Policy) (object.ObjMetadataSet, error)
+}
+
+var _ PolicyMerger = &ClusterClient{}
+
 // ClusterClient is a concrete implementation of the
 // Client interface.
 type ClusterClient struct {
@@ -55,6 +65,10 @@ type ClusterClient struct {
 	InventoryFactoryFunc  StorageFactoryFunc
 	invToUnstructuredFunc ToUnstructuredFunc
 	statusPolicy          StatusPolicy
+	// TombstoneTTL is how long tombstones are kept in the inventory after
+	// the object is removed. Zero keeps tombstones until the object is
+	// applied again.
+	TombstoneTTL time.Duration
 }
 
 var _ Client = &ClusterClient{}
@@ -95,8 +109,18 @@ func NewClient(factory cmdutil.Factory,
 // objects and the currently applied objects. This is the set of objects
 // to prune. Creates the initial cluster inventory object storing the passed
 // objects if an inventory object does not exist. Returns an error if one
-// occurred.
+// occurred. A cluster inventory object with another inventory id is only
+// adopted as allowed by PolicyMustMatch; use MergeWithPolicy for the policy
+// of the run.
 func (cic *ClusterClient) Merge(localInv Info, objs object.ObjMetadataSet, dryRun common.DryRunStrategy) (object.ObjMetadataSet, error) {
+	return cic.MergeWithPolicy(localInv, objs, dryRun, PolicyMustMatch)
+}
+
+// MergeWithPolicy is Merge, but the cluster inventory object is only adopted
+// if it has another inventory id and the policy allows it. Otherwise, an
+// InventoryIDMismatchError is returned and the inventory is not changed.
+func (cic *ClusterClient) MergeWithPolicy(localInv Info, objs object.ObjMetadataSet, dryRun common.DryRunStrategy,
+	policy Policy) (object.ObjMetadataSet, error) {
 	pruneIds := object.ObjMetadataSet{}
 	invObj := cic.invToUnstructuredFunc(localInv)
 	clusterInv, err := cic.GetClusterInventoryInfo(localInv)
@@ -126,6 +150,23 @@ func (cic *ClusterClient) Merge(localInv Info, objs object.ObjMetadataSet, dryRu
 		return nil, err
 	}
 
+	// Adopt the existing cluster inventory, if it has a different inventory
+	// id and the policy allows it.
+	adopted := false
+	if id := localInv.ID(); id != "" && InventoryIDMatch(localInv, clusterInv) != Match {
+		if _, err := CanUpdateInventory(localInv, clusterInv, policy); err != nil {
+			return pruneIds, err
+		}
+		klog.V(4).Infof("adopting inventory object: %s/%s", clusterInv.GetNamespace(), clusterInv.GetName())
+		labels := clusterInv.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[common.InventoryLabel] = id
+		clusterInv.SetLabels(labels)
+		adopted = true
+	}
+
 	// Update existing cluster inventory with merged union of objects
 	clusterObjs, err := cic.GetClusterObjs(localInv)
 	if err != nil {
@@ -147,7 +188,7 @@ func (cic *ClusterClient) Merge(localInv Info, objs object.ObjMetadataSet, dryRu
 	// Update not required when all objects in inventory are the same and
 	// status does not need to be updated. If status is stored, always update the
 	// inventory to store the latest status.
-	if objs.Equal(clusterObjs) && cic.statusPolicy == StatusPolicyNone {
+	if !adopted && objs.Equal(clusterObjs) && cic.statusPolicy == StatusPolicyNone {
 		return pruneIds, nil
 	}
 
@@ -172,6 +213,12 @@ func (cic *ClusterClient) Replace(localInv Info, objs object.ObjMetadataSet, sta
 	if err != nil {
 		return fmt.Errorf("failed to read inventory from cluster: %w", err)
 	}
+	// Nothing to replace, e.g. when retaining an inventory that was never
+	// created.
+	if clusterInv == nil {
+		klog.V(4).Infoln("inventory object not found: not replaced")
+		return nil
+	}
 
 	clusterObjs, err := cic.GetClusterObjs(localInv)
 	if err != nil {
@@ -185,8 +232,9 @@ func (cic *ClusterClient) Replace(localInv Info, objs object.ObjMetadataSet, sta
 
 	// Update not required when all objects in inventory are the same and
 	// status does not need to be updated. If status is stored, always update the
-	// inventory to store the latest status.
-	if objs.Equal(clusterObjs) && cic.statusPolicy == StatusPolicyNone {
+	// inventory to store the latest status. New tombstones are always stored.
+	if objs.Equal(clusterObjs) && cic.statusPolicy == StatusPolicyNone &&
+		len(TombstonesFromStatus(status)) == 0 {
 		return nil
 	}
 
@@ -201,8 +249,12 @@ func (cic *ClusterClient) Replace(localInv Info, objs object.ObjMetadataSet, sta
 }
 
 // replaceInventory stores the passed objects into the passed inventory object.
+// If the inventory supports tombstones, the tombstones in the status are
+// merged with the existing tombstones.
 func (cic *ClusterClient) replaceInventory(inv *unstructured.Unstructured, objs object.ObjMetadataSet,
 	status []actuation.ObjectStatus) (*unstructured.Unstructured, Storage, error) {
+	// Tombstones are stored regardless of the status policy.
+	tombstones := TombstonesFromStatus(status)
 	if cic.statusPolicy == StatusPolicyNone {
 		status = nil
 	}
@@ -210,6 +262,17 @@ func (cic *ClusterClient) replaceInventory(inv *unstructured.Unstructured, objs
 	if err := wrappedInv.Store(objs, status); err != nil {
 		return nil, nil, err
 	}
+	if tombstoneInv, ok := wrappedInv.(TombstoneStorage); ok {
+		existing, err := tombstoneInv.LoadTombstones()
+		if err != nil {
+			return nil, nil, err
+		}
+		merged := mergeTombstones(existing, tombstones, objs, cic.TombstoneTTL, time.Now())
+		klog.V(4).Infof("storing %d inventory tombstones", len(merged))
+		if err := tombstoneInv.StoreTombstones(merged); err != nil {
+			return nil, nil, err
+		}
+	}
 	clusterInv, err := wrappedInv.GetObject()
 	if err != nil {
 		return nil, nil, err
@@ -595,3 +595,67 @@ func storeObjsInInventory(info Info, objs object.ObjMetadataSet, status []actuat
 	inv, _ := wrapped.GetObject()
 	return inv
 }
+
+// nameStrategyInv is a ConfigMap inventory with the Name strategy.
+type nameStrategyInv struct {
+	*ConfigMap
+}
+
+func (i nameStrategyInv) Strategy() Strategy {
+	return NameStrategy
+}
+
+func TestMergeWithPolicy(t *testing.T) {
+	otherInv := copyInventoryInfo()
+	otherInv.SetLabels(map[string]string{common.InventoryLabel: "other-label"})
+
+	tests := map[string]struct {
+		policy      Policy
+		expectedErr error
+	}{
+		"must match does not adopt": {
+			policy: PolicyMustMatch,
+			expectedErr: &InventoryIDMismatchError{
+				ID:        testInventoryLabel,
+				ClusterID: "other-label",
+				Policy:    PolicyMustMatch,
+				Status:    NoMatch,
+			},
+		},
+		"adopt if no inventory does not adopt": {
+			policy: PolicyAdoptIfNoInventory,
+			expectedErr: &InventoryIDMismatchError{
+				ID:        testInventoryLabel,
+				ClusterID: "other-label",
+				Policy:    PolicyAdoptIfNoInventory,
+				Status:    NoMatch,
+			},
+		},
+		"adopt all adopts": {
+			policy: PolicyAdoptAll,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace(testNamespace)
+			defer tf.Cleanup()
+
+			tf.FakeDynamicClient.PrependReactor("get", "configmaps", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				return true, otherInv.DeepCopy(), nil
+			})
+			invClient, err := NewClient(tf, WrapInventoryObj, func(inv Info) *unstructured.Unstructured {
+				return inv.(nameStrategyInv).inv
+			}, StatusPolicyAll)
+			require.NoError(t, err)
+
+			inv := nameStrategyInv{ConfigMap: &ConfigMap{inv: copyInventoryInfo()}}
+			_, err = invClient.MergeWithPolicy(inv, object.ObjMetadataSet{}, common.DryRunClient, tc.policy)
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
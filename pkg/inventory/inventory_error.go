@@ -7,8 +7,10 @@ package inventory
 
 import (
 	"fmt"
+	"strings"
 
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/object"
 )
 
@@ -87,3 +89,93 @@ func (e *PolicyPreventedActuationError) Is(err error) bool {
 		e.Policy == tErr.Policy &&
 		e.Status == tErr.Status
 }
+
+// InventoryIDMismatchError is returned when the inventory-id label of the
+// inventory object in the cluster does not match the id of the current
+// inventory, and the inventory policy does not allow adoption.
+type InventoryIDMismatchError struct {
+	ID        string
+	ClusterID string
+	Policy    Policy
+	Status    IDMatchStatus
+}
+
+func (e *InventoryIDMismatchError) Error() string {
+	return fmt.Sprintf("inventory-id of inventory object in cluster doesn't match provided id %q (cluster id: %q, status: %s, policy: %s)",
+		e.ID, e.ClusterID, e.Status, e.Policy)
+}
+
+// Is returns true if the specified error is equal to this error.
+// Use errors.Is(error) to recursively check if an error wraps this error.
+func (e *InventoryIDMismatchError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*InventoryIDMismatchError)
+	if !ok {
+		return false
+	}
+	return e.ID == tErr.ID &&
+		e.ClusterID == tErr.ClusterID &&
+		e.Policy == tErr.Policy &&
+		e.Status == tErr.Status
+}
+
+// InvalidInventoryIDError is returned when the inventory ID is not a valid
+// value of the inventory-id label.
+type InvalidInventoryIDError struct {
+	ID      string
+	Reasons []string
+}
+
+func (e *InvalidInventoryIDError) Error() string {
+	return fmt.Sprintf("invalid inventory id %q: %s. The inventory id is stored in the %s label: "+
+		"set a valid id in the inventory object template, or run \"init\" with a valid --inventory-id",
+		e.ID, strings.Join(e.Reasons, "; "), common.InventoryLabel)
+}
+
+// Is returns true if the specified error is equal to this error.
+// Use errors.Is(error) to recursively check if an error wraps this error.
+func (e *InvalidInventoryIDError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*InvalidInventoryIDError)
+	if !ok {
+		return false
+	}
+	return e.ID == tErr.ID
+}
+
+// InventoryIDCollisionError is returned when more than one inventory object
+// in the cluster has the same inventory ID. The objects of the inventories
+// can not be told apart, so one inventory could prune or adopt the objects
+// of the other.
+type InventoryIDCollisionError struct {
+	ID      string
+	Objects object.ObjMetadataSet
+}
+
+func (e *InventoryIDCollisionError) Error() string {
+	names := make([]string, len(e.Objects))
+	for i, id := range e.Objects {
+		names[i] = fmt.Sprintf("%s/%s", id.Namespace, id.Name)
+	}
+	return fmt.Sprintf("inventory id %q is used by %d inventory objects (%s). Each package must have a unique "+
+		"inventory id: delete the inventory objects that are no longer used, or run \"init\" with a new "+
+		"--inventory-id for all but one of the packages",
+		e.ID, len(e.Objects), strings.Join(names, ", "))
+}
+
+// Is returns true if the specified error is equal to this error.
+// Use errors.Is(error) to recursively check if an error wraps this error.
+func (e *InventoryIDCollisionError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*InventoryIDCollisionError)
+	if !ok {
+		return false
+	}
+	return e.ID == tErr.ID && e.Objects.Equal(tErr.Objects)
+}
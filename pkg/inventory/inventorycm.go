@@ -53,10 +53,14 @@ type ConfigMap struct {
 	inv       *unstructured.Unstructured
 	objMetas  object.ObjMetadataSet
 	objStatus []actuation.ObjectStatus
+	// tombstones is nil until StoreTombstones is called, in which case
+	// the tombstones already in the wrapped ConfigMap are preserved.
+	tombstones Tombstones
 }
 
 var _ Info = &ConfigMap{}
 var _ Storage = &ConfigMap{}
+var _ TombstoneStorage = &ConfigMap{}
 
 func (icm *ConfigMap) Name() string {
 	return icm.inv.GetName()
@@ -81,6 +85,7 @@ func (icm *ConfigMap) UnstructuredInventory() *unstructured.Unstructured {
 
 // Load is an Inventory interface function returning the set of
 // object metadata from the wrapped ConfigMap, or an error.
+// Tombstones are not included.
 func (icm *ConfigMap) Load() (object.ObjMetadataSet, error) {
 	objs := object.ObjMetadataSet{}
 	objMap, exists, err := unstructured.NestedStringMap(icm.inv.Object, "data")
@@ -89,17 +94,52 @@ func (icm *ConfigMap) Load() (object.ObjMetadataSet, error) {
 		return objs, err
 	}
 	if exists {
-		for objStr := range objMap {
+		for objStr, value := range objMap {
 			obj, err := object.ParseObjMetadata(objStr)
 			if err != nil {
 				return objs, err
 			}
+			_, isTombstone, err := parseTombstone(value)
+			if err != nil {
+				return objs, err
+			}
+			if isTombstone {
+				continue
+			}
 			objs = append(objs, obj)
 		}
 	}
 	return objs, nil
 }
 
+// LoadTombstones is a TombstoneStorage interface function returning the
+// tombstones from the wrapped ConfigMap, or an error.
+func (icm *ConfigMap) LoadTombstones() (Tombstones, error) {
+	tombstones := Tombstones{}
+	objMap, exists, err := unstructured.NestedStringMap(icm.inv.Object, "data")
+	if err != nil {
+		err := fmt.Errorf("error retrieving tombstones from inventory object")
+		return tombstones, err
+	}
+	if exists {
+		for objStr, value := range objMap {
+			tombstone, isTombstone, err := parseTombstone(value)
+			if err != nil {
+				return tombstones, err
+			}
+			if !isTombstone {
+				continue
+			}
+			obj, err := object.ParseObjMetadata(objStr)
+			if err != nil {
+				return tombstones, err
+			}
+			tombstones[obj] = tombstone
+		}
+	}
+	return tombstones, nil
+}
+
 // Store is an Inventory interface function implemented to store
 // the object metadata in the wrapped ConfigMap. Actual storing
 // happens in "GetObject".
@@ -109,11 +149,33 @@ func (icm *ConfigMap) Store(objMetas object.ObjMetadataSet, status []actuation.O
 	return nil
 }
 
+// StoreTombstones is a TombstoneStorage interface function implemented to
+// store the tombstones in the wrapped ConfigMap. Actual storing happens in
+// "GetObject".
+func (icm *ConfigMap) StoreTombstones(tombstones Tombstones) error {
+	icm.tombstones = tombstones
+	return nil
+}
+
 // GetObject returns the wrapped object (ConfigMap) as a resource.Info
 // or an error if one occurs.
 func (icm *ConfigMap) GetObject() (*unstructured.Unstructured, error) {
 	// Create the objMap of all the resources, and compute the hash.
 	objMap := buildObjMap(icm.objMetas, icm.objStatus)
+	tombstones := icm.tombstones
+	if tombstones == nil {
+		var err error
+		tombstones, err = icm.LoadTombstones()
+		if err != nil {
+			return nil, err
+		}
+	}
+	for id, tombstone := range tombstones {
+		// Tombstones never replace live objects.
+		if _, found := objMap[id.String()]; !found {
+			objMap[id.String()] = tombstoneString(tombstone)
+		}
+	}
 	// Create the inventory object by copying the template.
 	invCopy := icm.inv.DeepCopy()
 	// Adds the inventory map to the ConfigMap "data" section.
@@ -0,0 +1,161 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// DefaultLegacyPruneGroupKinds are the GroupKinds pruned by
+// `kubectl apply --prune` when no allowlist is specified.
+var DefaultLegacyPruneGroupKinds = []schema.GroupKind{
+	{Kind: "ConfigMap"},
+	{Kind: "Endpoints"},
+	{Kind: "Namespace"},
+	{Kind: "PersistentVolumeClaim"},
+	{Kind: "PersistentVolume"},
+	{Kind: "Pod"},
+	{Kind: "ReplicationController"},
+	{Kind: "Secret"},
+	{Kind: "Service"},
+	{Group: "batch", Kind: "Job"},
+	{Group: "batch", Kind: "CronJob"},
+	{Group: "networking.k8s.io", Kind: "Ingress"},
+	{Group: "apps", Kind: "DaemonSet"},
+	{Group: "apps", Kind: "Deployment"},
+	{Group: "apps", Kind: "ReplicaSet"},
+	{Group: "apps", Kind: "StatefulSet"},
+}
+
+// LegacyPruneSet identifies the set of objects previously applied with
+// `kubectl apply --prune`, which are the objects matching the label
+// selector that have the last-applied-configuration annotation.
+type LegacyPruneSet struct {
+	// Selector is the label selector passed to `kubectl apply --prune -l`.
+	Selector labels.Selector
+	// Namespace restricts namespaced objects to a single namespace.
+	// If empty, objects in all namespaces are included.
+	Namespace string
+	// GroupKinds are the GroupKinds to look up, equivalent to the
+	// `--prune-allowlist` of kubectl. If empty, DefaultLegacyPruneGroupKinds
+	// are used.
+	GroupKinds []schema.GroupKind
+}
+
+// IsLegacyApplied returns true if the object was applied by `kubectl apply`.
+func IsLegacyApplied(obj *unstructured.Unstructured) bool {
+	_, found := obj.GetAnnotations()[corev1.LastAppliedConfigAnnotation]
+	return found
+}
+
+// FindLegacyPruneObjects returns the objects in the cluster that belong to
+// the legacy prune set. GroupKinds not served by the cluster are skipped.
+func FindLegacyPruneObjects(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper,
+	set LegacyPruneSet) (object.UnstructuredSet, error) {
+	if set.Selector == nil {
+		return nil, fmt.Errorf("legacy prune set requires a label selector")
+	}
+	groupKinds := set.GroupKinds
+	if len(groupKinds) == 0 {
+		groupKinds = DefaultLegacyPruneGroupKinds
+	}
+	var objs object.UnstructuredSet
+	for _, gk := range groupKinds {
+		mapping, err := mapper.RESTMapping(gk)
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				klog.V(4).Infof("legacy prune set: skipping unknown kind: %s", gk)
+				continue
+			}
+			return nil, err
+		}
+		var resource dynamic.ResourceInterface = client.Resource(mapping.Resource)
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace && set.Namespace != "" {
+			resource = client.Resource(mapping.Resource).Namespace(set.Namespace)
+		}
+		list, err := resource.List(ctx, metav1.ListOptions{
+			LabelSelector: set.Selector.String(),
+		})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s: %w", gk, err)
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if !IsLegacyApplied(obj) || obj.GetDeletionTimestamp() != nil {
+				continue
+			}
+			objs = append(objs, obj)
+		}
+	}
+	return objs, nil
+}
+
+// ConvertLegacyPruneObjects adds the owning-inventory annotation of the
+// passed inventory to the legacy objects, so they can be applied and pruned
+// with the inventory. Objects owned by another inventory are skipped.
+// Unless this is a dry run, the annotation is also patched on the objects in
+// the cluster. Returns the converted objects.
+func ConvertLegacyPruneObjects(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper,
+	inv Info, objs object.UnstructuredSet, dryRun common.DryRunStrategy) (object.UnstructuredSet, error) {
+	var converted object.UnstructuredSet
+	for _, obj := range objs {
+		id := object.UnstructuredToObjMetadata(obj)
+		switch IDMatch(inv, obj) {
+		case NoMatch:
+			klog.V(4).Infof("legacy prune set: skipping object owned by another inventory: %s", id)
+			continue
+		case Match:
+			converted = append(converted, obj)
+			continue
+		}
+		if !dryRun.ClientOrServerDryRun() {
+			if err := patchInventoryIDAnnotation(ctx, client, mapper, obj, inv); err != nil {
+				return converted, fmt.Errorf("failed to convert legacy object %s: %w", id, err)
+			}
+		}
+		klog.V(4).Infof("legacy prune set: converted object: %s", id)
+		AddInventoryIDAnnotation(obj, inv)
+		converted = append(converted, obj)
+	}
+	return converted, nil
+}
+
+func patchInventoryIDAnnotation(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper,
+	obj *unstructured.Unstructured, inv Info) error {
+	mapping, err := mapper.RESTMapping(obj.GroupVersionKind().GroupKind(), obj.GroupVersionKind().Version)
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				OwningInventoryKey: inv.ID(),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.Resource(mapping.Resource).Namespace(obj.GetNamespace()).
+		Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
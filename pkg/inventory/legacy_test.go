@@ -0,0 +1,129 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func legacyConfigMap(name string, objLabels map[string]string, annotations map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": testNamespace,
+			},
+		},
+	}
+	obj.SetLabels(objLabels)
+	obj.SetAnnotations(annotations)
+	return obj
+}
+
+var configMapGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+func TestFindAndConvertLegacyPruneObjects(t *testing.T) {
+	appLabels := map[string]string{"app": "legacy"}
+	lastApplied := map[string]string{corev1.LastAppliedConfigAnnotation: "{}"}
+
+	legacy := legacyConfigMap("legacy", appLabels, lastApplied)
+	otherLabel := legacyConfigMap("other-label", map[string]string{"app": "other"}, lastApplied)
+	notApplied := legacyConfigMap("not-applied", appLabels, nil)
+	owned := legacyConfigMap("owned", appLabels, map[string]string{
+		corev1.LastAppliedConfigAnnotation: "{}",
+		OwningInventoryKey:                 localInv.ID(),
+	})
+	foreign := legacyConfigMap("foreign", appLabels, map[string]string{
+		corev1.LastAppliedConfigAnnotation: "{}",
+		OwningInventoryKey:                 "other-inventory",
+	})
+
+	testCases := map[string]struct {
+		dryRun            common.DryRunStrategy
+		expectedConverted []string
+		expectedPatched   []string
+	}{
+		"converts legacy objects": {
+			dryRun:            common.DryRunNone,
+			expectedConverted: []string{"legacy", "owned"},
+			expectedPatched:   []string{"legacy", "owned"},
+		},
+		"dry run does not patch objects": {
+			dryRun:            common.DryRunClient,
+			expectedConverted: []string{"legacy", "owned"},
+			expectedPatched:   []string{"owned"},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					configMapGVR: "ConfigMapList",
+				},
+				legacy.DeepCopy(), otherLabel.DeepCopy(), notApplied.DeepCopy(), owned.DeepCopy(), foreign.DeepCopy())
+			mapper := testutil.NewFakeRESTMapper(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})
+			ctx := context.Background()
+
+			found, err := FindLegacyPruneObjects(ctx, client, mapper, LegacyPruneSet{
+				Selector:  labels.SelectorFromSet(appLabels),
+				Namespace: testNamespace,
+				// Deployments are not served by the cluster and are skipped.
+				GroupKinds: []schema.GroupKind{{Kind: "ConfigMap"}, {Group: "apps", Kind: "Deployment"}},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"foreign", "legacy", "owned"}, objNames(found))
+
+			converted, err := ConvertLegacyPruneObjects(ctx, client, mapper, localInv, found, tc.dryRun)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConverted, objNames(converted))
+			for _, obj := range converted {
+				assert.Equal(t, Match, IDMatch(localInv, obj))
+			}
+
+			list, err := client.Resource(configMapGVR).Namespace(testNamespace).List(ctx, metav1.ListOptions{})
+			require.NoError(t, err)
+			var patched []string
+			for i := range list.Items {
+				if IDMatch(localInv, &list.Items[i]) == Match {
+					patched = append(patched, list.Items[i].GetName())
+				}
+			}
+			sort.Strings(patched)
+			assert.Equal(t, tc.expectedPatched, patched)
+		})
+	}
+}
+
+func TestFindLegacyPruneObjects_RequiresSelector(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	mapper := testutil.NewFakeRESTMapper()
+	_, err := FindLegacyPruneObjects(context.Background(), client, mapper, LegacyPruneSet{})
+	assert.EqualError(t, err, "legacy prune set requires a label selector")
+}
+
+func objNames(objs []*unstructured.Unstructured) []string {
+	var names []string
+	for _, obj := range objs {
+		names = append(names, obj.GetName())
+	}
+	sort.Strings(names)
+	return names
+}
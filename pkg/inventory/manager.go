@@ -165,6 +165,21 @@ func (tc *Manager) AddSuccessfulDelete(id object.ObjMetadata, uid types.UID) {
 	})
 }
 
+// SetTombstone records a tombstone for the resource identified by the
+// provided id, which must already have a successful delete status.
+// Tombstones are stored in the inventory in place of the object reference.
+func (tc *Manager) SetTombstone(id object.ObjMetadata, tombstone actuation.Tombstone) error {
+	objStatus, found := tc.ObjectStatus(id)
+	if !found {
+		return fmt.Errorf("object not in inventory: %q", id)
+	}
+	if !tc.IsSuccessfulDelete(id) {
+		return fmt.Errorf("object not successfully deleted: %q", id)
+	}
+	objStatus.Tombstone = &tombstone
+	return nil
+}
+
 // SuccessfulDeletes returns all the objects (as ObjMetadata) that
 // were successfully deleted.
 func (tc *Manager) SuccessfulDeletes() object.ObjMetadataSet {
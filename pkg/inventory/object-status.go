@@ -0,0 +1,163 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// StatusStorage is implemented by Storage that persists the status of each
+// object alongside the object metadata (see StatusPolicyAll).
+type StatusStorage interface {
+	// LoadStatus retrieves the stored object status from the inventory
+	// object. Objects without a stored status are not included.
+	LoadStatus() ([]actuation.ObjectStatus, error)
+}
+
+// StatusClient is implemented by Clients that can retrieve the object status
+// stored by a previous run.
+type StatusClient interface {
+	// GetClusterObjStatus returns the object status stored in the cluster
+	// inventory object, or an error if one occurred.
+	GetClusterObjStatus(inv Info) ([]actuation.ObjectStatus, error)
+}
+
+var (
+	_ StatusStorage = &ConfigMap{}
+	_ StatusClient  = &ClusterClient{}
+	_ StatusClient  = &FakeClient{}
+)
+
+// LoadStatus is a StatusStorage interface function returning the object
+// status from the wrapped ConfigMap, or an error. Tombstones are not
+// included.
+func (icm *ConfigMap) LoadStatus() ([]actuation.ObjectStatus, error) {
+	var statuses []actuation.ObjectStatus
+	objMap, exists, err := unstructured.NestedStringMap(icm.inv.Object, "data")
+	if err != nil {
+		err := fmt.Errorf("error retrieving object status from inventory object")
+		return statuses, err
+	}
+	if !exists {
+		return statuses, nil
+	}
+	for objStr, value := range objMap {
+		if value == "" {
+			continue
+		}
+		_, isTombstone, err := parseTombstone(value)
+		if err != nil {
+			return statuses, err
+		}
+		if isTombstone {
+			continue
+		}
+		id, err := object.ParseObjMetadata(objStr)
+		if err != nil {
+			return statuses, err
+		}
+		status, err := parseObjectStatus(id, value)
+		if err != nil {
+			return statuses, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// GetClusterObjStatus returns the object status stored in the cluster
+// inventory object. Returns an error if the inventory does not store the
+// object status.
+func (cic *ClusterClient) GetClusterObjStatus(localInv Info) ([]actuation.ObjectStatus, error) {
+	clusterInv, err := cic.GetClusterInventoryInfo(localInv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inventory from cluster: %w", err)
+	}
+	// First time; no inventory obj yet.
+	if clusterInv == nil {
+		return nil, nil
+	}
+	wrapped := cic.InventoryFactoryFunc(clusterInv)
+	statusStorage, ok := wrapped.(StatusStorage)
+	if !ok {
+		return nil, fmt.Errorf("inventory does not store object status: %T", wrapped)
+	}
+	return statusStorage.LoadStatus()
+}
+
+// GetClusterObjStatus returns the currently stored object status.
+func (fic *FakeClient) GetClusterObjStatus(Info) ([]actuation.ObjectStatus, error) {
+	if fic.Err != nil {
+		return nil, fic.Err
+	}
+	return fic.Status, nil
+}
+
+// parseObjectStatus parses the object status stored by stringFrom.
+func parseObjectStatus(id object.ObjMetadata, value string) (actuation.ObjectStatus, error) {
+	tmp := map[string]string{}
+	if err := json.Unmarshal([]byte(value), &tmp); err != nil {
+		return actuation.ObjectStatus{}, fmt.Errorf("invalid object status for %s: %w", id, err)
+	}
+	status := actuation.ObjectStatus{
+		ObjectReference: ObjectReferenceFromObjMetadata(id),
+	}
+	var found bool
+	if status.Strategy, found = parseActuationStrategy(tmp["strategy"]); !found {
+		return status, fmt.Errorf("invalid actuation strategy for %s: %q", id, tmp["strategy"])
+	}
+	if status.Actuation, found = parseActuationStatus(tmp["actuation"]); !found {
+		return status, fmt.Errorf("invalid actuation status for %s: %q", id, tmp["actuation"])
+	}
+	if status.Reconcile, found = parseReconcileStatus(tmp["reconcile"]); !found {
+		return status, fmt.Errorf("invalid reconcile status for %s: %q", id, tmp["reconcile"])
+	}
+	return status, nil
+}
+
+func parseActuationStrategy(s string) (actuation.ActuationStrategy, bool) {
+	for _, v := range []actuation.ActuationStrategy{
+		actuation.ActuationStrategyApply,
+		actuation.ActuationStrategyDelete,
+	} {
+		if v.String() == s {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+func parseActuationStatus(s string) (actuation.ActuationStatus, bool) {
+	for _, v := range []actuation.ActuationStatus{
+		actuation.ActuationPending,
+		actuation.ActuationSucceeded,
+		actuation.ActuationSkipped,
+		actuation.ActuationFailed,
+	} {
+		if v.String() == s {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+func parseReconcileStatus(s string) (actuation.ReconcileStatus, bool) {
+	for _, v := range []actuation.ReconcileStatus{
+		actuation.ReconcilePending,
+		actuation.ReconcileSucceeded,
+		actuation.ReconcileSkipped,
+		actuation.ReconcileFailed,
+		actuation.ReconcileTimeout,
+	} {
+		if v.String() == s {
+			return v, true
+		}
+	}
+	return 0, false
+}
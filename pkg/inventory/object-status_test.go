@@ -0,0 +1,88 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestConfigMapLoadStatus(t *testing.T) {
+	pod1ID := object.UnstructuredToObjMetadata(pod1)
+	pod2ID := object.UnstructuredToObjMetadata(pod2)
+	pod3ID := object.UnstructuredToObjMetadata(pod3)
+	pod1Status := actuation.ObjectStatus{
+		ObjectReference: ObjectReferenceFromObjMetadata(pod1ID),
+		Strategy:        actuation.ActuationStrategyApply,
+		Actuation:       actuation.ActuationSucceeded,
+		Reconcile:       actuation.ReconcileSucceeded,
+	}
+	pod2Status := actuation.ObjectStatus{
+		ObjectReference: ObjectReferenceFromObjMetadata(pod2ID),
+		Strategy:        actuation.ActuationStrategyApply,
+		Actuation:       actuation.ActuationFailed,
+		Reconcile:       actuation.ReconcileSkipped,
+	}
+
+	cm := WrapInventoryObj(inventoryObj.DeepCopy()).(*ConfigMap)
+	require.NoError(t, cm.Store(object.ObjMetadataSet{pod1ID, pod2ID}, []actuation.ObjectStatus{pod1Status, pod2Status}))
+	require.NoError(t, cm.StoreTombstones(Tombstones{
+		pod3ID: {RemovedAt: metav1.Now(), SpecHash: "abc123"},
+	}))
+	invObj, err := cm.GetObject()
+	require.NoError(t, err)
+
+	// Tombstones are not loaded as object status.
+	statuses, err := WrapInventoryObj(invObj).(*ConfigMap).LoadStatus()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []actuation.ObjectStatus{pod1Status, pod2Status}, statuses)
+
+	// Objects stored without status are not loaded.
+	require.NoError(t, cm.Store(object.ObjMetadataSet{pod1ID, pod2ID}, nil))
+	invObj, err = cm.GetObject()
+	require.NoError(t, err)
+	statuses, err = WrapInventoryObj(invObj).(*ConfigMap).LoadStatus()
+	require.NoError(t, err)
+	assert.Empty(t, statuses)
+}
+
+func TestConfigMapLoadStatus_Invalid(t *testing.T) {
+	pod1ID := object.UnstructuredToObjMetadata(pod1)
+	testCases := map[string]struct {
+		value         string
+		expectedError string
+	}{
+		"invalid json": {
+			value:         `{`,
+			expectedError: "invalid object status for " + pod1ID.String(),
+		},
+		"unknown actuation status": {
+			value:         `{"actuation":"Unknown","reconcile":"Pending","strategy":"Apply"}`,
+			expectedError: `invalid actuation status for ` + pod1ID.String() + `: "Unknown"`,
+		},
+		"missing strategy": {
+			value:         `{"actuation":"Pending","reconcile":"Pending"}`,
+			expectedError: `invalid actuation strategy for ` + pod1ID.String() + `: ""`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			invObj := inventoryObj.DeepCopy()
+			err := unstructured.SetNestedStringMap(invObj.Object, map[string]string{
+				pod1ID.String(): tc.value,
+			}, "data")
+			require.NoError(t, err)
+			_, err = WrapInventoryObj(invObj).(*ConfigMap).LoadStatus()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedError)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/common"
 )
 
 // Policy defines if an inventory object can take over
@@ -139,6 +140,47 @@ func CanPrune(inv Info, obj *unstructured.Unstructured, policy Policy) (bool, er
 	}
 }
 
+// InventoryIDMatch compares the id from the current inventory info with the
+// inventory-id label of an inventory object from the cluster.
+func InventoryIDMatch(inv Info, invObj *unstructured.Unstructured) IDMatchStatus {
+	value, found := invObj.GetLabels()[common.InventoryLabel]
+	if !found || value == "" {
+		return Empty
+	}
+	if value == inv.ID() {
+		return Match
+	}
+	return NoMatch
+}
+
+// CanUpdateInventory returns true if the inventory object from the cluster
+// can be updated to store the objects of the current inventory. This applies
+// the same ownership semantics as CanApply to the inventory object itself,
+// so that a name collision does not merge two unrelated inventories.
+func CanUpdateInventory(inv Info, invObj *unstructured.Unstructured, policy Policy) (bool, error) {
+	matchStatus := InventoryIDMatch(inv, invObj)
+	switch matchStatus {
+	case Empty:
+		if policy != PolicyMustMatch {
+			return true, nil
+		}
+	case Match:
+		return true, nil
+	case NoMatch:
+		if policy == PolicyAdoptAll {
+			return true, nil
+		}
+	default:
+		return false, fmt.Errorf("invalid inventory id match status: %v", matchStatus)
+	}
+	return false, &InventoryIDMismatchError{
+		ID:        inv.ID(),
+		ClusterID: invObj.GetLabels()[common.InventoryLabel],
+		Policy:    policy,
+		Status:    matchStatus,
+	}
+}
+
 func AddInventoryIDAnnotation(obj *unstructured.Unstructured, inv Info) {
 	annotations := obj.GetAnnotations()
 	if annotations == nil {
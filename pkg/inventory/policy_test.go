@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 )
 
@@ -278,3 +279,87 @@ func TestCanPrune(t *testing.T) {
 		})
 	}
 }
+
+func testInventoryObjectWithLabel(id string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "inventory",
+				"namespace": "ns",
+			},
+		},
+	}
+	if id != "" {
+		obj.SetLabels(map[string]string{
+			common.InventoryLabel: id,
+		})
+	}
+	return obj
+}
+
+func TestCanUpdateInventory(t *testing.T) {
+	testcases := []struct {
+		name          string
+		obj           *unstructured.Unstructured
+		inv           Info
+		policy        Policy
+		canUpdate     bool
+		expectedError error
+	}{
+		{
+			name:      "matched with InventoryPolicyMustMatch",
+			obj:       testInventoryObjectWithLabel("matched"),
+			inv:       &fakeInventoryInfo{id: "matched"},
+			policy:    PolicyMustMatch,
+			canUpdate: true,
+		},
+		{
+			name:      "empty with InventoryPolicyMustMatch",
+			obj:       testInventoryObjectWithLabel(""),
+			inv:       &fakeInventoryInfo{id: "random-id"},
+			policy:    PolicyMustMatch,
+			canUpdate: false,
+			expectedError: &InventoryIDMismatchError{
+				ID:     "random-id",
+				Policy: PolicyMustMatch,
+				Status: Empty,
+			},
+		},
+		{
+			name:      "empty with AdoptIfNoInventory",
+			obj:       testInventoryObjectWithLabel(""),
+			inv:       &fakeInventoryInfo{id: "random-id"},
+			policy:    PolicyAdoptIfNoInventory,
+			canUpdate: true,
+		},
+		{
+			name:      "unmatched with AdoptIfNoInventory",
+			obj:       testInventoryObjectWithLabel("unmatched"),
+			inv:       &fakeInventoryInfo{id: "random-id"},
+			policy:    PolicyAdoptIfNoInventory,
+			canUpdate: false,
+			expectedError: &InventoryIDMismatchError{
+				ID:        "random-id",
+				ClusterID: "unmatched",
+				Policy:    PolicyAdoptIfNoInventory,
+				Status:    NoMatch,
+			},
+		},
+		{
+			name:      "unmatched with AdoptAll",
+			obj:       testInventoryObjectWithLabel("unmatched"),
+			inv:       &fakeInventoryInfo{id: "random-id"},
+			policy:    PolicyAdoptAll,
+			canUpdate: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := CanUpdateInventory(tc.inv, tc.obj, tc.policy)
+			assert.Equal(t, tc.canUpdate, ok)
+			testutil.AssertEqual(t, tc.expectedError, err)
+		})
+	}
+}
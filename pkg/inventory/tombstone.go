@@ -0,0 +1,143 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// Tombstones maps the objects that were removed from the set of applied
+// objects to their tombstones.
+type Tombstones map[object.ObjMetadata]actuation.Tombstone
+
+// TombstoneStorage is implemented by Storage that can persist tombstones
+// alongside the object metadata. Tombstones are not returned by Load, so
+// the objects they reference are not pruned again.
+type TombstoneStorage interface {
+	// LoadTombstones retrieves the tombstones from the inventory object.
+	LoadTombstones() (Tombstones, error)
+	// StoreTombstones replaces the tombstones in the inventory object.
+	// Tombstones for objects that are also stored with Store are ignored.
+	StoreTombstones(Tombstones) error
+}
+
+// NewTombstone returns a Tombstone for an object removed at the passed
+// time, with the hash of the last known object spec.
+func NewTombstone(obj *unstructured.Unstructured, removedAt time.Time) (actuation.Tombstone, error) {
+	specHash, err := SpecHash(obj)
+	if err != nil {
+		return actuation.Tombstone{}, err
+	}
+	return actuation.Tombstone{
+		RemovedAt: metav1.NewTime(removedAt),
+		SpecHash:  specHash,
+	}, nil
+}
+
+// SpecHash returns a hash of the spec of the object. If the object has no
+// spec field (e.g. ConfigMap), the hash is computed from the object without
+// metadata and status.
+func SpecHash(obj *unstructured.Unstructured) (string, error) {
+	content, found := obj.Object["spec"]
+	if !found {
+		withoutMeta := make(map[string]interface{}, len(obj.Object))
+		for k, v := range obj.Object {
+			if k != "metadata" && k != "status" {
+				withoutMeta[k] = v
+			}
+		}
+		content = withoutMeta
+	}
+	// Map keys are sorted by json.Marshal, so the hash is deterministic.
+	data, err := json.Marshal(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash object spec: %w", err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// TombstonesFromStatus returns the tombstones set in the object statuses.
+func TombstonesFromStatus(status []actuation.ObjectStatus) Tombstones {
+	tombstones := Tombstones{}
+	for _, objStatus := range status {
+		if objStatus.Tombstone != nil {
+			id := ObjMetadataFromObjectReference(objStatus.ObjectReference)
+			tombstones[id] = *objStatus.Tombstone
+		}
+	}
+	return tombstones
+}
+
+// mergeTombstones returns the existing tombstones, minus any that have
+// expired or reference live objects, plus the new tombstones.
+// A ttl of zero keeps tombstones forever.
+func mergeTombstones(existing, added Tombstones, liveObjs object.ObjMetadataSet, ttl time.Duration, now time.Time) Tombstones {
+	merged := Tombstones{}
+	for id, tombstone := range existing {
+		if ttl > 0 && now.Sub(tombstone.RemovedAt.Time) > ttl {
+			continue
+		}
+		merged[id] = tombstone
+	}
+	for id, tombstone := range added {
+		merged[id] = tombstone
+	}
+	for _, id := range liveObjs {
+		delete(merged, id)
+	}
+	return merged
+}
+
+const (
+	tombstoneKey = "tombstone"
+	removedAtKey = "removedAt"
+	specHashKey  = "specHash"
+)
+
+// tombstoneString returns the string stored in the inventory ConfigMap
+// data for a tombstone.
+func tombstoneString(tombstone actuation.Tombstone) string {
+	tmp := map[string]string{
+		tombstoneKey: "true",
+		removedAtKey: tombstone.RemovedAt.UTC().Format(time.RFC3339),
+		specHashKey:  tombstone.SpecHash,
+	}
+	data, err := json.Marshal(tmp)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// parseTombstone parses the string stored in the inventory ConfigMap data.
+// Returns false if the string is not a tombstone.
+func parseTombstone(value string) (actuation.Tombstone, bool, error) {
+	if value == "" {
+		return actuation.Tombstone{}, false, nil
+	}
+	tmp := map[string]string{}
+	if err := json.Unmarshal([]byte(value), &tmp); err != nil {
+		// Not JSON, so not a tombstone.
+		return actuation.Tombstone{}, false, nil
+	}
+	if tmp[tombstoneKey] != "true" {
+		return actuation.Tombstone{}, false, nil
+	}
+	removedAt, err := time.Parse(time.RFC3339, tmp[removedAtKey])
+	if err != nil {
+		return actuation.Tombstone{}, true, fmt.Errorf("invalid tombstone removal time: %w", err)
+	}
+	return actuation.Tombstone{
+		RemovedAt: metav1.NewTime(removedAt),
+		SpecHash:  tmp[specHashKey],
+	}, true, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestSpecHash(t *testing.T) {
+	hash1, err := SpecHash(pod1)
+	require.NoError(t, err)
+
+	// Metadata and status changes do not change the hash.
+	modified := pod1.DeepCopy()
+	modified.SetLabels(map[string]string{"foo": "bar"})
+	modified.Object["status"] = map[string]interface{}{"phase": "Running"}
+	hash2, err := SpecHash(modified)
+	require.NoError(t, err)
+	assert.Equal(t, hash1, hash2)
+
+	// Spec changes do.
+	modified.Object["spec"] = map[string]interface{}{"hostname": "test"}
+	hash3, err := SpecHash(modified)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash1, hash3)
+
+	// Fields other than spec are ignored if spec exists.
+	withData := modified.DeepCopy()
+	withData.Object["data"] = map[string]interface{}{"key": "value"}
+	hash4, err := SpecHash(withData)
+	require.NoError(t, err)
+	assert.Equal(t, hash3, hash4)
+}
+
+func TestMergeTombstones(t *testing.T) {
+	pod1ID := object.UnstructuredToObjMetadata(pod1)
+	pod2ID := object.UnstructuredToObjMetadata(pod2)
+	pod3ID := object.UnstructuredToObjMetadata(pod3)
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	old := actuation.Tombstone{RemovedAt: metav1.NewTime(now.Add(-2 * time.Hour)), SpecHash: "old"}
+	recent := actuation.Tombstone{RemovedAt: metav1.NewTime(now.Add(-time.Minute)), SpecHash: "recent"}
+	added := actuation.Tombstone{RemovedAt: metav1.NewTime(now), SpecHash: "added"}
+
+	testCases := map[string]struct {
+		existing Tombstones
+		added    Tombstones
+		liveObjs object.ObjMetadataSet
+		ttl      time.Duration
+		expected Tombstones
+	}{
+		"no tombstones": {
+			expected: Tombstones{},
+		},
+		"add to existing": {
+			existing: Tombstones{pod1ID: old},
+			added:    Tombstones{pod2ID: added},
+			expected: Tombstones{pod1ID: old, pod2ID: added},
+		},
+		"added replaces existing": {
+			existing: Tombstones{pod1ID: old},
+			added:    Tombstones{pod1ID: added},
+			expected: Tombstones{pod1ID: added},
+		},
+		"expired tombstones dropped": {
+			existing: Tombstones{pod1ID: old, pod2ID: recent},
+			added:    Tombstones{pod3ID: added},
+			ttl:      time.Hour,
+			expected: Tombstones{pod2ID: recent, pod3ID: added},
+		},
+		"re-applied objects dropped": {
+			existing: Tombstones{pod1ID: old, pod2ID: recent},
+			liveObjs: object.ObjMetadataSet{pod1ID},
+			expected: Tombstones{pod2ID: recent},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			actual := mergeTombstones(tc.existing, tc.added, tc.liveObjs, tc.ttl, now)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestConfigMapTombstones(t *testing.T) {
+	pod1ID := object.UnstructuredToObjMetadata(pod1)
+	pod2ID := object.UnstructuredToObjMetadata(pod2)
+	removedAt := metav1.NewTime(time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC))
+	tombstone := actuation.Tombstone{RemovedAt: removedAt, SpecHash: "abc123"}
+
+	cm := WrapInventoryObj(inventoryObj.DeepCopy()).(*ConfigMap)
+	require.NoError(t, cm.Store(object.ObjMetadataSet{pod1ID}, nil))
+	require.NoError(t, cm.StoreTombstones(Tombstones{pod2ID: tombstone}))
+	invObj, err := cm.GetObject()
+	require.NoError(t, err)
+
+	data, _, err := unstructured.NestedStringMap(invObj.Object, "data")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		pod1ID.String(): "",
+		pod2ID.String(): `{"removedAt":"2022-01-01T12:00:00Z","specHash":"abc123","tombstone":"true"}`,
+	}, data)
+
+	// Tombstones are not loaded as objects.
+	loaded := WrapInventoryObj(invObj).(*ConfigMap)
+	objs, err := loaded.Load()
+	require.NoError(t, err)
+	assert.Equal(t, object.ObjMetadataSet{pod1ID}, objs)
+	tombstones, err := loaded.LoadTombstones()
+	require.NoError(t, err)
+	assert.Equal(t, Tombstones{pod2ID: tombstone}, tombstones)
+
+	// Existing tombstones are preserved if StoreTombstones is not called.
+	require.NoError(t, loaded.Store(object.ObjMetadataSet{}, nil))
+	invObj, err = loaded.GetObject()
+	require.NoError(t, err)
+	tombstones, err = WrapInventoryObj(invObj).(*ConfigMap).LoadTombstones()
+	require.NoError(t, err)
+	assert.Equal(t, Tombstones{pod2ID: tombstone}, tombstones)
+
+	// Tombstones for stored objects are ignored.
+	require.NoError(t, loaded.Store(object.ObjMetadataSet{pod2ID}, nil))
+	invObj, err = loaded.GetObject()
+	require.NoError(t, err)
+	objs, err = WrapInventoryObj(invObj).Load()
+	require.NoError(t, err)
+	assert.Equal(t, object.ObjMetadataSet{pod2ID}, objs)
+}
+
+func TestManagerSetTombstone(t *testing.T) {
+	manager := NewManager()
+	id := object.UnstructuredToObjMetadata(pod1)
+	tombstone := actuation.Tombstone{SpecHash: "abc123"}
+
+	err := manager.SetTombstone(id, tombstone)
+	require.Error(t, err)
+
+	manager.AddSuccessfulApply(id, "uid1", 1)
+	err = manager.SetTombstone(id, tombstone)
+	require.Error(t, err)
+
+	manager.AddSuccessfulDelete(id, "uid1")
+	err = manager.SetTombstone(id, tombstone)
+	require.NoError(t, err)
+	assert.Equal(t, Tombstones{id: tombstone},
+		TombstonesFromStatus(manager.Inventory().Status.Objects))
+}
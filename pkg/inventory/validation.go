@@ -0,0 +1,142 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// Validator is implemented by the inventory clients that can check an
+// inventory before it is used, so that a run fails before anything is
+// changed in the cluster, instead of adopting or pruning the objects of
+// another inventory.
+type Validator interface {
+	// ValidateInventory returns an error if the inventory can not be used
+	// with the policy.
+	ValidateInventory(inv Info, policy Policy) error
+}
+
+var _ Validator = &ClusterClient{}
+
+// ValidateID returns an InvalidInventoryIDError if the inventory ID is not a
+// valid value of the inventory-id label.
+func ValidateID(id string) error {
+	if id == "" {
+		return &InvalidInventoryIDError{ID: id, Reasons: []string{"must not be empty"}}
+	}
+	if reasons := validation.IsValidLabelValue(id); len(reasons) > 0 {
+		return &InvalidInventoryIDError{ID: id, Reasons: reasons}
+	}
+	return nil
+}
+
+// ValidateInventory checks the inventory before it is used:
+//
+//   - the inventory ID must be a valid label value
+//   - the inventory object in the cluster with the name of the inventory
+//     must have the same inventory ID, unless the policy allows adopting it
+//   - no other inventory object in the cluster may have the same ID
+//
+// Inventories with the Name strategy and no ID are not validated. If the
+// inventory objects can not be listed in all namespaces, only the namespace
+// of the inventory is checked for other inventory objects.
+func (cic *ClusterClient) ValidateInventory(inv Info, policy Policy) error {
+	if inv == nil {
+		return fmt.Errorf("inventoryInfo must be specified")
+	}
+	if inv.Strategy() == NameStrategy && inv.ID() == "" {
+		return nil
+	}
+	if err := ValidateID(inv.ID()); err != nil {
+		return err
+	}
+	liveInvs, err := cic.getClusterInventoryObjsByName(inv)
+	if err != nil {
+		return err
+	}
+	if len(liveInvs) == 1 {
+		if _, err := CanUpdateInventory(inv, liveInvs[0], policy); err != nil {
+			return err
+		}
+	}
+	idInvs, err := cic.listInventoryObjsByID(inv)
+	if err != nil {
+		return err
+	}
+	return inventoryIDCollision(inv, idInvs)
+}
+
+// listInventoryObjsByID returns the inventory objects with the ID of the
+// inventory, in all namespaces, or in the namespace of the inventory if
+// listing all namespaces is forbidden.
+func (cic *ClusterClient) listInventoryObjsByID(inv Info) (object.UnstructuredSet, error) {
+	localInv := cic.invToUnstructuredFunc(inv)
+	if localInv == nil {
+		return nil, fmt.Errorf("retrieving cluster inventory object with nil local inventory")
+	}
+	mapping, err := cic.getMapping(localInv)
+	if err != nil {
+		return nil, err
+	}
+	opts := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", common.InventoryLabel, inv.ID()),
+	}
+	uList, err := cic.dc.Resource(mapping.Resource).Namespace(metav1.NamespaceAll).List(context.TODO(), opts)
+	if apierrors.IsForbidden(err) && mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		klog.V(4).Infof("listing inventory objects in all namespaces is forbidden, listing namespace %q", inv.Namespace())
+		uList, err = cic.dc.Resource(mapping.Resource).Namespace(inv.Namespace()).List(context.TODO(), opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inventory objects with inventory id %q: %w", inv.ID(), err)
+	}
+	var invs object.UnstructuredSet
+	for i := range uList.Items {
+		invs = append(invs, &uList.Items[i])
+	}
+	return invs, nil
+}
+
+// inventoryIDCollision returns an InventoryIDCollisionError if inventory
+// objects other than the inventory have its ID. With the Label strategy, any
+// one inventory object in the namespace of the inventory is the inventory.
+func inventoryIDCollision(inv Info, idInvs object.UnstructuredSet) error {
+	owned := 0
+	for _, obj := range idInvs {
+		if isInventoryObj(inv, obj) {
+			owned++
+		}
+	}
+	others := len(idInvs) - owned
+	if others == 0 && owned <= 1 {
+		return nil
+	}
+	ids := object.UnstructuredSetToObjMetadataSet(idInvs)
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+	return &InventoryIDCollisionError{
+		ID:      inv.ID(),
+		Objects: ids,
+	}
+}
+
+// isInventoryObj returns true if the object from the cluster is the
+// inventory object of the inventory.
+func isInventoryObj(inv Info, obj *unstructured.Unstructured) bool {
+	if obj.GetNamespace() != inv.Namespace() {
+		return false
+	}
+	return inv.Strategy() == LabelStrategy || obj.GetName() == inv.Name()
+}
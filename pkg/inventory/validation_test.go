@@ -0,0 +1,192 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clienttesting "k8s.io/client-go/testing"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestValidateID(t *testing.T) {
+	tests := map[string]struct {
+		id      string
+		isError bool
+	}{
+		"valid id": {
+			id: "test-app-label",
+		},
+		"valid uuid": {
+			id: "a6bd7da6-6c9a-4e9a-b0d5-6f4c1e7d0a2b",
+		},
+		"empty id": {
+			id:      "",
+			isError: true,
+		},
+		"invalid characters": {
+			id:      "test/app",
+			isError: true,
+		},
+		"too long": {
+			id:      "a123456789b123456789c123456789d123456789e123456789f123456789g1234",
+			isError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateID(tc.id)
+			if !tc.isError {
+				assert.NoError(t, err)
+				return
+			}
+			var idErr *InvalidInventoryIDError
+			require.ErrorAs(t, err, &idErr)
+			assert.Equal(t, tc.id, idErr.ID)
+			assert.NotEmpty(t, idErr.Reasons)
+		})
+	}
+}
+
+func inventoryObjWith(namespace, name, id string) *unstructured.Unstructured {
+	u := inventoryObj.DeepCopy()
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	u.SetLabels(map[string]string{common.InventoryLabel: id})
+	return u
+}
+
+func TestValidateInventory(t *testing.T) {
+	tests := map[string]struct {
+		inv         Info
+		policy      Policy
+		liveInv     *unstructured.Unstructured
+		idInvs      []*unstructured.Unstructured
+		listForbid  bool
+		expectedErr error
+	}{
+		"no inventory in the cluster": {
+			inv:    localInv,
+			policy: PolicyMustMatch,
+		},
+		"inventory in the cluster": {
+			inv:     localInv,
+			policy:  PolicyMustMatch,
+			liveInv: inventoryObj,
+			idInvs:  []*unstructured.Unstructured{inventoryObj},
+		},
+		"invalid inventory id": {
+			inv:    WrapInventoryInfoObj(inventoryObjWith(testNamespace, inventoryObjName, "test/app")),
+			policy: PolicyMustMatch,
+			expectedErr: &InvalidInventoryIDError{
+				ID: "test/app",
+			},
+		},
+		"live inventory with another id": {
+			inv:     localInv,
+			policy:  PolicyMustMatch,
+			liveInv: inventoryObjWith(testNamespace, inventoryObjName, "other-label"),
+			expectedErr: &InventoryIDMismatchError{
+				ID:        testInventoryLabel,
+				ClusterID: "other-label",
+				Policy:    PolicyMustMatch,
+				Status:    NoMatch,
+			},
+		},
+		"live inventory with another id adopted": {
+			inv:     localInv,
+			policy:  PolicyAdoptAll,
+			liveInv: inventoryObjWith(testNamespace, inventoryObjName, "other-label"),
+		},
+		"inventory id used in another namespace": {
+			inv:     localInv,
+			policy:  PolicyMustMatch,
+			liveInv: inventoryObj,
+			idInvs: []*unstructured.Unstructured{
+				inventoryObj,
+				inventoryObjWith("other-namespace", inventoryObjName, testInventoryLabel),
+			},
+			expectedErr: &InventoryIDCollisionError{
+				ID: testInventoryLabel,
+				Objects: object.ObjMetadataSet{
+					object.UnstructuredToObjMetadata(inventoryObjWith("other-namespace", inventoryObjName, testInventoryLabel)),
+					object.UnstructuredToObjMetadata(inventoryObj),
+				},
+			},
+		},
+		"inventory id used twice in the namespace": {
+			inv:    localInv,
+			policy: PolicyMustMatch,
+			idInvs: []*unstructured.Unstructured{
+				inventoryObjWith(testNamespace, "inventory-a", testInventoryLabel),
+				inventoryObjWith(testNamespace, "inventory-b", testInventoryLabel),
+			},
+			expectedErr: &InventoryIDCollisionError{
+				ID: testInventoryLabel,
+				Objects: object.ObjMetadataSet{
+					object.UnstructuredToObjMetadata(inventoryObjWith(testNamespace, "inventory-a", testInventoryLabel)),
+					object.UnstructuredToObjMetadata(inventoryObjWith(testNamespace, "inventory-b", testInventoryLabel)),
+				},
+			},
+		},
+		"listing all namespaces forbidden": {
+			inv:        localInv,
+			policy:     PolicyMustMatch,
+			liveInv:    inventoryObj,
+			idInvs:     []*unstructured.Unstructured{inventoryObj},
+			listForbid: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace(testNamespace)
+			defer tf.Cleanup()
+
+			tf.FakeDynamicClient.PrependReactor("get", "configmaps", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				if tc.liveInv == nil {
+					return true, nil, apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, inventoryObjName)
+				}
+				return true, tc.liveInv, nil
+			})
+			var listNamespaces []string
+			tf.FakeDynamicClient.PrependReactor("list", "configmaps", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				ns := action.GetNamespace()
+				listNamespaces = append(listNamespaces, ns)
+				if tc.listForbid && ns == "" {
+					return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, "", nil)
+				}
+				list := &unstructured.UnstructuredList{}
+				for _, u := range tc.idInvs {
+					if ns == "" || u.GetNamespace() == ns {
+						list.Items = append(list.Items, *u)
+					}
+				}
+				return true, list, nil
+			})
+
+			invClient, err := NewClient(tf, WrapInventoryObj, InvInfoToConfigMap, StatusPolicyAll)
+			require.NoError(t, err)
+
+			err = invClient.ValidateInventory(tc.inv, tc.policy)
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+			if tc.listForbid {
+				assert.Equal(t, []string{"", testNamespace}, listNamespaces)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ package jsonpath
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
+	"unicode"
 
 	// Using gopkg.in/yaml.v3 instead of sigs.k8s.io/yaml on purpose.
 	// yaml.v3 correctly parses ints:
@@ -66,6 +68,117 @@ func Get(obj map[string]interface{}, expression string) ([]interface{}, error) {
 	return result, nil
 }
 
+// Eval evaluates an expression that combines JSONPath values with
+// comparison, logical, and arithmetic operators, like
+// `$.status.phase == 'Bound'`, using the input map as the root.
+// Returns the value of the expression (nil if a value was not found), or an
+// error.
+func Eval(obj map[string]interface{}, expression string) (interface{}, error) {
+	// format input object as json for input into jsonpath library
+	jsonBytes, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input to json: %w", err)
+	}
+
+	klog.V(7).Info("jsonpath.Eval input as json:\n%s", jsonBytes)
+
+	// parse json into an ajson node
+	root, err := ajson.Unmarshal(jsonBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal input json: %w", err)
+	}
+
+	// evaluate the expression
+	node, err := ajson.Eval(root, expression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate jsonpath expression (%s): %w", expression, err)
+	}
+
+	// format node value as json
+	jsonBytes, err = ajson.Marshal(node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal jsonpath result to json: %w", err)
+	}
+
+	klog.V(7).Info("jsonpath.Eval output as json:\n%s", jsonBytes)
+
+	// parse json back into a Go primitive
+	var value interface{}
+	err = yaml.Unmarshal(jsonBytes, &value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal jsonpath result: %w", err)
+	}
+	return value, nil
+}
+
+// EvalCondition evaluates a boolean expression of JSONPath values, like
+// `status.phase == 'Bound'`, using the input map as the root.
+// Returns true if the expression is true, false if it is false or uses a
+// value that is not found, or an error if the expression is invalid or not
+// a boolean.
+//
+// Paths may start at the root of the input map, like "status.phase", or
+// with "$." like "$.status.phase".
+func EvalCondition(obj map[string]interface{}, expression string) (bool, error) {
+	if strings.TrimSpace(expression) == "" {
+		return false, fmt.Errorf("empty expression")
+	}
+	value, err := Eval(obj, rootPaths(expression))
+	if err != nil {
+		return false, err
+	}
+	switch typedValue := value.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return typedValue, nil
+	default:
+		return false, fmt.Errorf("expression must be a boolean, but found %T: %s", value, expression)
+	}
+}
+
+// rootPaths returns the expression with "$." prepended to the paths that do
+// not start with "$" or "@", so that they start at the root of the object.
+// Quoted strings, literals, and function names are not changed.
+func rootPaths(expression string) string {
+	var b strings.Builder
+	runes := []rune(expression)
+	var quote rune
+	// operand is true if the next token starts an operand.
+	operand := true
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			operand = false
+		case unicode.IsSpace(r):
+		case operand && (unicode.IsLetter(r) || r == '_'):
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			if j < len(runes) && (runes[j] == '.' || runes[j] == '[') {
+				b.WriteString("$.")
+			}
+			b.WriteString(string(runes[i:j]))
+			i = j - 1
+			operand = false
+			continue
+		case strings.ContainsRune("(=!<>&|,+-*/%", r):
+			operand = true
+		default:
+			operand = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 // Set evaluates the JSONPath expression to set a value in the input map.
 // Returns the number of matching nodes that were updated, or an error.
 // For details about the JSONPath expression language, see:
@@ -161,6 +161,113 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestEval(t *testing.T) {
+	o1 := ktestutil.YamlToUnstructured(t, o1y)
+
+	testCases := map[string]struct {
+		obj        *unstructured.Unstructured
+		expression string
+		value      interface{}
+		errMsg     string
+	}{
+		"path": {
+			obj:        o1,
+			expression: "$.metadata.name",
+			value:      "pod-name",
+		},
+		"equal": {
+			obj:        o1,
+			expression: "$.kind == 'Pod'",
+			value:      true,
+		},
+		"not equal": {
+			obj:        o1,
+			expression: "$.kind != 'Pod'",
+			value:      false,
+		},
+		"and": {
+			obj:        o1,
+			expression: "$.kind == 'Pod' && $.list[0] > 0",
+			value:      true,
+		},
+		"field selector": {
+			obj:        o1,
+			expression: `$.entries[?(@.name=="b")].value == 'y'`,
+			value:      true,
+		},
+		"missing": {
+			obj:        o1,
+			expression: "$.nope == 'Pod'",
+			value:      nil,
+		},
+		"invalid expression": {
+			obj:        o1,
+			expression: "$.kind ==",
+			errMsg:     "failed to evaluate jsonpath expression ($.kind ==): wrong request: wrong request: $.kind ==",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			testCtx := []interface{}{"expression: %s\nobject:\n%s", tc.expression, toYaml(t, tc.obj.Object)}
+			value, err := Eval(tc.obj.Object, tc.expression)
+			if tc.errMsg != "" {
+				require.EqualError(t, err, tc.errMsg, testCtx...)
+			} else {
+				require.NoError(t, err, testCtx...)
+			}
+			require.Equal(t, tc.value, value, testCtx...)
+		})
+	}
+}
+
+func TestEvalCondition(t *testing.T) {
+	o1 := ktestutil.YamlToUnstructured(t, o1y)
+
+	testCases := map[string]struct {
+		expression string
+		value      bool
+		errMsg     string
+	}{
+		"root path": {
+			expression: "$.kind == 'Pod' && metadata.name == 'pod-name'",
+			value:      true,
+		},
+		"dollar path": {
+			expression: "$.kind == 'Deployment'",
+			value:      false,
+		},
+		"quoted path": {
+			expression: "metadata.name == 'metadata.name'",
+			value:      false,
+		},
+		"missing": {
+			expression: "status.phase == 'Running'",
+			value:      false,
+		},
+		"not a boolean": {
+			expression: "metadata.name",
+			errMsg:     "expression must be a boolean, but found string: metadata.name",
+		},
+		"empty": {
+			expression: " ",
+			errMsg:     "empty expression",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			value, err := EvalCondition(o1.Object, tc.expression)
+			if tc.errMsg != "" {
+				require.EqualError(t, err, tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.value, value)
+		})
+	}
+}
+
 func TestSet(t *testing.T) {
 	testCases := map[string]struct {
 		obj   *unstructured.Unstructured
@@ -38,4 +38,16 @@
 //   for e := range eventsChan {
 //      // Handle event
 //   }
+//
+// The set of polled resources can be changed without restarting the
+// poller, with the AddIdentifiers and RemoveIdentifiers functions.
+//
+//   err := poller.AddIdentifiers(newIdentifiers)
+//   poller.RemoveIdentifiers(oldIdentifiers)
+//
+// Polling can be paused, for example during a maintenance window, without
+// stopping the poller or losing the last status of the resources.
+//
+//   poller.Pause()
+//   poller.Resume()
 package polling
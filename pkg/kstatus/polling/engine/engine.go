@@ -7,10 +7,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +37,14 @@ type PollerEngine struct {
 	StatusReaders        []StatusReader
 	DefaultStatusReader  StatusReader
 	ClusterReaderFactory ClusterReaderFactory
+
+	// mu protects runners and paused.
+	mu sync.Mutex
+	// runners are the statusPollerRunners of the running Polls, which are
+	// updated by AddIdentifiers, RemoveIdentifiers, Pause, and Resume.
+	runners map[*statusPollerRunner]struct{}
+	// paused is true between calls to Pause and Resume.
+	paused bool
 }
 
 // Poll will create a new statusPollerRunner that will poll all the resources provided and report their status
@@ -62,19 +72,101 @@ func (s *PollerEngine) Poll(ctx context.Context, identifiers object.ObjMetadataS
 
 		runner := &statusPollerRunner{
 			clusterReader:            clusterReader,
+			newClusterReader:         s.newClusterReader,
 			statusReaders:            s.StatusReaders,
 			defaultStatusReader:      s.DefaultStatusReader,
 			identifiers:              identifiers,
 			previousResourceStatuses: make(map[object.ObjMetadata]*event.ResourceStatus),
 			eventChannel:             eventChannel,
 			pollingInterval:          options.PollInterval,
+			maxBackoff:               options.MaxBackoff,
+			errorBudget:              options.ErrorBudget,
+			resourceFailures:         make(map[object.ObjMetadata]*failure),
+			pendingIdentifiers:       identifiers,
+			identifiersChanged:       make(chan struct{}, 1),
+			resumed:                  make(chan struct{}, 1),
 		}
+		s.addRunner(runner)
+		defer s.removeRunner(runner)
 		runner.Run(ctx)
 	}()
 
 	return eventChannel
 }
 
+// AddIdentifiers adds the resources to the set of resources polled by all
+// the running Polls, without restarting them. The status of the added
+// resources is reported after the next poll, which is started right away.
+func (s *PollerEngine) AddIdentifiers(identifiers object.ObjMetadataSet) error {
+	if err := s.validateIdentifiers(identifiers); err != nil {
+		return err
+	}
+	s.updateIdentifiers(func(ids object.ObjMetadataSet) object.ObjMetadataSet {
+		return ids.Union(identifiers)
+	})
+	return nil
+}
+
+// RemoveIdentifiers removes the resources from the set of resources polled by
+// all the running Polls, without restarting them. No more events are sent for
+// the removed resources.
+func (s *PollerEngine) RemoveIdentifiers(identifiers object.ObjMetadataSet) {
+	s.updateIdentifiers(func(ids object.ObjMetadataSet) object.ObjMetadataSet {
+		return ids.Diff(identifiers)
+	})
+}
+
+// Pause stops all the running Polls, and the Polls started later, from
+// making API calls, until Resume is called. The Polls keep running, with their
+// set of resources and the last status of each resource, and the resources
+// can still be added and removed while paused.
+func (s *PollerEngine) Pause() {
+	s.setPaused(true)
+}
+
+// Resume resumes the Polls stopped by Pause. They poll right away, and only
+// send events for the resources whose status changed while paused.
+func (s *PollerEngine) Resume() {
+	s.setPaused(false)
+}
+
+func (s *PollerEngine) setPaused(paused bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.paused = paused
+	for runner := range s.runners {
+		runner.setPaused(paused)
+	}
+}
+
+func (s *PollerEngine) updateIdentifiers(update func(object.ObjMetadataSet) object.ObjMetadataSet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for runner := range s.runners {
+		runner.updateIdentifiers(update)
+	}
+}
+
+func (s *PollerEngine) addRunner(runner *statusPollerRunner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.runners == nil {
+		s.runners = make(map[*statusPollerRunner]struct{})
+	}
+	s.runners[runner] = struct{}{}
+	runner.setPaused(s.paused)
+}
+
+func (s *PollerEngine) removeRunner(runner *statusPollerRunner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.runners, runner)
+}
+
+func (s *PollerEngine) newClusterReader(identifiers object.ObjMetadataSet) (ClusterReader, error) {
+	return s.ClusterReaderFactory.New(s.Reader, s.Mapper, identifiers)
+}
+
 func handleError(eventChannel chan event.Event, err error) {
 	eventChannel <- event.Event{
 		Type:  event.ErrorEvent,
@@ -112,6 +204,28 @@ type Options struct {
 	// PollInterval defines how often the PollerEngine should poll the cluster for the latest
 	// state of the resources.
 	PollInterval time.Duration
+
+	// MaxBackoff defines the maximum interval between polls of a resource
+	// whose status can't be read, or between syncs of the ClusterReader
+	// after a failed sync. The interval starts at twice the PollInterval and
+	// doubles after each consecutive failure. If zero, failing resources are
+	// polled every PollInterval.
+	MaxBackoff time.Duration
+
+	// ErrorBudget defines how many consecutive times the status of a
+	// resource can fail to be read, or the ClusterReader can fail to sync,
+	// before polling stops with an ErrorEvent. Until then, a failed sync is
+	// retried, and a resource error is only sent once, until it changes.
+	// If zero, resource errors never stop polling, and a failed sync stops
+	// polling right away.
+	ErrorBudget int
+}
+
+// failure keeps track of consecutive failures, to back off and to enforce
+// the error budget.
+type failure struct {
+	count   int
+	retryAt time.Time
 }
 
 // statusPollerRunner is responsible for polling of a set of resources. Each call to Poll will create
@@ -126,6 +240,9 @@ type statusPollerRunner struct {
 	// to make call directly to the cluster or use caching to reduce the number of calls to the cluster.
 	clusterReader ClusterReader
 
+	// newClusterReader creates a new ClusterReader when the set of identifiers changes.
+	newClusterReader func(object.ObjMetadataSet) (ClusterReader, error)
+
 	// statusReaders contains the resource specific statusReaders. These will contain logic for how to
 	// compute status for specific GroupKinds. These will use an ClusterReader to fetch
 	// status of a resource and any generated resources.
@@ -151,6 +268,92 @@ type statusPollerRunner struct {
 	// pollingInterval determines how often we should poll the cluster for
 	// the latest state of resources.
 	pollingInterval time.Duration
+
+	// maxBackoff is the maximum interval between retries of failed polls.
+	maxBackoff time.Duration
+
+	// errorBudget is the number of consecutive failures tolerated before
+	// polling stops.
+	errorBudget int
+
+	// syncFailure keeps track of failed syncs of the clusterReader.
+	syncFailure failure
+
+	// resourceFailures keeps track of the resources whose status failed to
+	// be read.
+	resourceFailures map[object.ObjMetadata]*failure
+
+	// mu protects pendingIdentifiers and paused, which are the only state of
+	// the runner accessed by other goroutines.
+	mu sync.Mutex
+	// pendingIdentifiers is the set of identifiers that should be polled,
+	// as updated by the PollerEngine. It replaces identifiers before the
+	// next poll.
+	pendingIdentifiers object.ObjMetadataSet
+	// identifiersChanged is signaled when pendingIdentifiers is updated.
+	identifiersChanged chan struct{}
+	// paused is true while polling is paused by the PollerEngine.
+	paused bool
+	// resumed is signaled when polling is resumed.
+	resumed chan struct{}
+}
+
+// setPaused pauses or resumes polling. When resumed, the runner is signaled
+// to poll right away, without blocking.
+func (r *statusPollerRunner) setPaused(paused bool) {
+	r.mu.Lock()
+	wasPaused := r.paused
+	r.paused = paused
+	r.mu.Unlock()
+	if !wasPaused || paused {
+		return
+	}
+	select {
+	case r.resumed <- struct{}{}:
+	default:
+	}
+}
+
+// isPaused returns true if polling is paused.
+func (r *statusPollerRunner) isPaused() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.paused
+}
+
+// updateIdentifiers updates the set of identifiers to poll, and signals the
+// runner to poll right away, without blocking.
+func (r *statusPollerRunner) updateIdentifiers(update func(object.ObjMetadataSet) object.ObjMetadataSet) {
+	r.mu.Lock()
+	r.pendingIdentifiers = update(r.pendingIdentifiers)
+	r.mu.Unlock()
+	select {
+	case r.identifiersChanged <- struct{}{}:
+	default:
+	}
+}
+
+// syncIdentifiers replaces the polled identifiers with the pending ones. If
+// they changed, a new ClusterReader is created for them, and the previous
+// statuses of the removed resources are forgotten.
+func (r *statusPollerRunner) syncIdentifiers() error {
+	r.mu.Lock()
+	identifiers := r.pendingIdentifiers
+	r.mu.Unlock()
+	if identifiers.Equal(r.identifiers) {
+		return nil
+	}
+	clusterReader, err := r.newClusterReader(identifiers)
+	if err != nil {
+		return fmt.Errorf("error creating new ClusterReader: %w", err)
+	}
+	for _, id := range r.identifiers.Diff(identifiers) {
+		delete(r.previousResourceStatuses, id)
+		delete(r.resourceFailures, id)
+	}
+	r.clusterReader = clusterReader
+	r.identifiers = identifiers
+	return nil
 }
 
 // Run starts the polling loop of the statusReaders.
@@ -161,10 +364,12 @@ func (r *statusPollerRunner) Run(ctx context.Context) {
 		ticker.Stop()
 	}()
 
-	err := r.syncAndPoll(ctx)
-	if err != nil {
-		r.handleSyncAndPollErr(err)
-		return
+	if !r.isPaused() {
+		err := r.syncAndPoll(ctx)
+		if err != nil {
+			r.handleSyncAndPollErr(err)
+			return
+		}
 	}
 
 	for {
@@ -172,12 +377,19 @@ func (r *statusPollerRunner) Run(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			// First sync and then compute status for all resources.
-			err := r.syncAndPoll(ctx)
-			if err != nil {
-				r.handleSyncAndPollErr(err)
-				return
-			}
+		case <-r.identifiersChanged:
+			// Poll the new set of resources right away.
+		case <-r.resumed:
+			// Poll right away, to catch up with the changes while paused.
+		}
+		if r.isPaused() {
+			continue
+		}
+		// First sync and then compute status for all resources.
+		err := r.syncAndPoll(ctx)
+		if err != nil {
+			r.handleSyncAndPollErr(err)
+			return
 		}
 	}
 }
@@ -198,14 +410,30 @@ func (r *statusPollerRunner) handleSyncAndPollErr(err error) {
 }
 
 func (r *statusPollerRunner) syncAndPoll(ctx context.Context) error {
+	// Pick up the changes to the set of resources made since the last poll.
+	if err := r.syncIdentifiers(); err != nil {
+		return err
+	}
 	// First trigger a sync of the ClusterReader. This may or may not actually
 	// result in calls to the cluster, depending on the implementation.
-	// If this call fails, there is no clean way to recover, so we just return an ErrorEvent
-	// and shut down.
+	// If this call fails more times than the error budget allows, there is
+	// no clean way to recover, so we just return an ErrorEvent and shut down.
+	if time.Now().Before(r.syncFailure.retryAt) {
+		return nil
+	}
 	err := r.clusterReader.Sync(ctx)
 	if err != nil {
-		return err
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+			r.errorBudget == 0 {
+			return err
+		}
+		if r.recordFailure(&r.syncFailure) {
+			return fmt.Errorf("error syncing cluster reader %d times: %w", r.syncFailure.count, err)
+		}
+		klog.V(3).Infof("error syncing cluster reader (retry %d/%d): %v", r.syncFailure.count, r.errorBudget, err)
+		return nil
 	}
+	r.syncFailure = failure{}
 	// Poll all resources and compute status. If the polling of resources has completed (based
 	// on information from the StatusAggregator and the value of pollUntilCancelled), we send
 	// a CompletedEvent and return.
@@ -222,12 +450,28 @@ func (r *statusPollerRunner) pollStatusForAllResources(ctx context.Context) erro
 			return ctx.Err()
 		default:
 		}
+		resourceFailure, failing := r.resourceFailures[id]
+		if failing && time.Now().Before(resourceFailure.retryAt) {
+			continue
+		}
 		gk := id.GroupKind
 		statusReader := r.statusReaderForGroupKind(gk)
 		resourceStatus, err := statusReader.ReadStatus(ctx, r.clusterReader, id)
 		if err != nil {
 			return err
 		}
+		if resourceStatus.Error == nil {
+			delete(r.resourceFailures, id)
+		} else {
+			if !failing {
+				resourceFailure = &failure{}
+				r.resourceFailures[id] = resourceFailure
+			}
+			if r.recordFailure(resourceFailure) {
+				return fmt.Errorf("error reading status of %s %d times: %w",
+					id, resourceFailure.count, resourceStatus.Error)
+			}
+		}
 		if r.isUpdatedResourceStatus(resourceStatus) {
 			r.previousResourceStatuses[id] = resourceStatus
 			r.eventChannel <- event.Event{
@@ -239,6 +483,26 @@ func (r *statusPollerRunner) pollStatusForAllResources(ctx context.Context) erro
 	return nil
 }
 
+// recordFailure counts a failure and schedules the next retry, backing off
+// exponentially. Returns true if the error budget is exhausted.
+func (r *statusPollerRunner) recordFailure(f *failure) bool {
+	f.count++
+	if r.errorBudget > 0 && f.count >= r.errorBudget {
+		return true
+	}
+	if r.maxBackoff > 0 {
+		backoff := r.pollingInterval
+		for i := 0; i < f.count && backoff < r.maxBackoff; i++ {
+			backoff *= 2
+		}
+		if backoff > r.maxBackoff {
+			backoff = r.maxBackoff
+		}
+		f.retryAt = time.Now().Add(backoff)
+	}
+	return false
+}
+
 func (r *statusPollerRunner) statusReaderForGroupKind(gk schema.GroupKind) StatusReader {
 	for _, sr := range r.statusReaders {
 		if sr.Supports(gk) {
@@ -5,7 +5,9 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -299,3 +301,293 @@ func (f *fakeStatusReader) ReadStatus(_ context.Context, _ ClusterReader, identi
 func (f *fakeStatusReader) ReadStatusForObject(_ context.Context, _ ClusterReader, _ *unstructured.Unstructured) (*event.ResourceStatus, error) {
 	return nil, nil
 }
+
+func TestStatusPollerRunnerUpdateIdentifiers(t *testing.T) {
+	deploymentID := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Name:      "foo",
+		Namespace: "default",
+	}
+	serviceID := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "", Kind: "Service"},
+		Name:      "bar",
+		Namespace: "default",
+	}
+	service2ID := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "", Kind: "Service"},
+		Name:      "baz",
+		Namespace: "default",
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var clusterReaderIds []object.ObjMetadataSet
+	engine := PollerEngine{
+		Mapper: fakemapper.NewFakeRESTMapper(
+			appsv1.SchemeGroupVersion.WithKind("Deployment"),
+			v1.SchemeGroupVersion.WithKind("Service"),
+		),
+		DefaultStatusReader: &fakeStatusReader{
+			resourceStatuses: map[schema.GroupKind][]status.Status{
+				deploymentID.GroupKind: {status.CurrentStatus},
+				serviceID.GroupKind:    {status.CurrentStatus},
+			},
+			resourceStatusCount: make(map[schema.GroupKind]int),
+		},
+		ClusterReaderFactory: ClusterReaderFactoryFunc(func(_ client.Reader, _ meta.RESTMapper, ids object.ObjMetadataSet) (ClusterReader, error) {
+			clusterReaderIds = append(clusterReaderIds, ids)
+			return fakecr.NewNoopClusterReader(), nil
+		}),
+	}
+
+	// The poll interval is long enough that all polls after the first one
+	// are triggered by the identifier updates.
+	eventChannel := engine.Poll(ctx, object.ObjMetadataSet{deploymentID}, Options{
+		PollInterval: time.Minute,
+	})
+
+	e := <-eventChannel
+	assert.Equal(t, event.ResourceUpdateEvent, e.Type)
+	assert.Equal(t, deploymentID, e.Resource.Identifier)
+
+	err := engine.AddIdentifiers(object.ObjMetadataSet{serviceID})
+	assert.NoError(t, err)
+	e = <-eventChannel
+	assert.Equal(t, event.ResourceUpdateEvent, e.Type)
+	assert.Equal(t, serviceID, e.Resource.Identifier)
+
+	engine.RemoveIdentifiers(object.ObjMetadataSet{deploymentID})
+	err = engine.AddIdentifiers(object.ObjMetadataSet{service2ID})
+	assert.NoError(t, err)
+	e = <-eventChannel
+	assert.Equal(t, event.ResourceUpdateEvent, e.Type)
+	assert.Equal(t, service2ID, e.Resource.Identifier)
+
+	// Namespaced resources need a namespace.
+	err = engine.AddIdentifiers(object.ObjMetadataSet{{
+		GroupKind: deploymentID.GroupKind,
+		Name:      "baz",
+	}})
+	assert.Error(t, err)
+
+	cancel()
+	for range eventChannel {
+	}
+	assert.Equal(t, object.ObjMetadataSet{deploymentID}, clusterReaderIds[0])
+	assert.Equal(t, object.ObjMetadataSet{deploymentID, serviceID}, clusterReaderIds[1])
+	assert.Equal(t, object.ObjMetadataSet{serviceID, service2ID}, clusterReaderIds[len(clusterReaderIds)-1])
+}
+
+// countingClusterReader is a ClusterReader that counts the calls to Sync.
+type countingClusterReader struct {
+	*fakecr.NoopClusterReader
+	syncs int32
+}
+
+func (c *countingClusterReader) Sync(_ context.Context) error {
+	atomic.AddInt32(&c.syncs, 1)
+	return nil
+}
+
+func (c *countingClusterReader) syncCount() int32 {
+	return atomic.LoadInt32(&c.syncs)
+}
+
+func TestStatusPollerRunnerPauseResume(t *testing.T) {
+	deploymentID := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Name:      "foo",
+		Namespace: "default",
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	clusterReader := &countingClusterReader{
+		NoopClusterReader: fakecr.NewNoopClusterReader(),
+	}
+	engine := PollerEngine{
+		Mapper: fakemapper.NewFakeRESTMapper(
+			appsv1.SchemeGroupVersion.WithKind("Deployment"),
+		),
+		DefaultStatusReader: &fakeStatusReader{
+			resourceStatuses: map[schema.GroupKind][]status.Status{
+				deploymentID.GroupKind: {status.CurrentStatus},
+			},
+			resourceStatusCount: make(map[schema.GroupKind]int),
+		},
+		ClusterReaderFactory: ClusterReaderFactoryFunc(func(_ client.Reader, _ meta.RESTMapper, _ object.ObjMetadataSet) (ClusterReader, error) {
+			return clusterReader, nil
+		}),
+	}
+
+	// Polls started while paused don't poll until resumed.
+	engine.Pause()
+	eventChannel := engine.Poll(ctx, object.ObjMetadataSet{deploymentID}, Options{
+		PollInterval: 10 * time.Millisecond,
+	})
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(0), clusterReader.syncCount())
+	select {
+	case e := <-eventChannel:
+		t.Fatalf("unexpected event while paused: %v", e)
+	default:
+	}
+
+	engine.Resume()
+	e := <-eventChannel
+	assert.Equal(t, event.ResourceUpdateEvent, e.Type)
+	assert.Equal(t, deploymentID, e.Resource.Identifier)
+
+	// Running polls stop polling when paused, but keep running.
+	engine.Pause()
+	time.Sleep(50 * time.Millisecond)
+	syncs := clusterReader.syncCount()
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, syncs, clusterReader.syncCount())
+
+	engine.Resume()
+	time.Sleep(50 * time.Millisecond)
+	assert.Greater(t, clusterReader.syncCount(), syncs)
+
+	cancel()
+	for range eventChannel {
+	}
+}
+
+func TestStatusPollerRunnerErrorBudget(t *testing.T) {
+	deploymentID := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Name:      "foo",
+		Namespace: "default",
+	}
+	readErr := errors.New("etcdserver: request timed out")
+	syncErr := errors.New("the server is currently unable to handle the request")
+
+	testCases := map[string]struct {
+		syncErr            error
+		readErr            error
+		options            Options
+		expectedEventTypes []event.Type
+		expectedError      string
+		expectedMaxReads   int
+	}{
+		"resource errors are sent once without error budget": {
+			readErr: readErr,
+			options: Options{
+				PollInterval: 10 * time.Millisecond,
+			},
+			expectedEventTypes: []event.Type{
+				event.ResourceUpdateEvent,
+			},
+		},
+		"resource errors stop polling when the error budget is exhausted": {
+			readErr: readErr,
+			options: Options{
+				PollInterval: 10 * time.Millisecond,
+				MaxBackoff:   40 * time.Millisecond,
+				ErrorBudget:  3,
+			},
+			expectedEventTypes: []event.Type{
+				event.ResourceUpdateEvent,
+				event.ErrorEvent,
+			},
+			expectedError:    "error reading status of default_foo_apps_Deployment 3 times: etcdserver: request timed out",
+			expectedMaxReads: 3,
+		},
+		"sync errors stop polling without error budget": {
+			syncErr: syncErr,
+			options: Options{
+				PollInterval: 10 * time.Millisecond,
+			},
+			expectedEventTypes: []event.Type{
+				event.ErrorEvent,
+			},
+			expectedError: syncErr.Error(),
+		},
+		"sync errors stop polling when the error budget is exhausted": {
+			syncErr: syncErr,
+			options: Options{
+				PollInterval: 10 * time.Millisecond,
+				MaxBackoff:   20 * time.Millisecond,
+				ErrorBudget:  2,
+			},
+			expectedEventTypes: []event.Type{
+				event.ErrorEvent,
+			},
+			expectedError: "error syncing cluster reader 2 times: " + syncErr.Error(),
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			// Without an error budget, polling is stopped by the timeout.
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			statusReader := &fakeErrorStatusReader{err: tc.readErr}
+			engine := PollerEngine{
+				Mapper: fakemapper.NewFakeRESTMapper(
+					appsv1.SchemeGroupVersion.WithKind("Deployment"),
+				),
+				DefaultStatusReader: statusReader,
+				ClusterReaderFactory: ClusterReaderFactoryFunc(func(client.Reader, meta.RESTMapper, object.ObjMetadataSet) (ClusterReader, error) {
+					return &fakecr.ClusterReader{SyncErr: tc.syncErr}, nil
+				}),
+			}
+
+			eventChannel := engine.Poll(ctx, object.ObjMetadataSet{deploymentID}, tc.options)
+
+			var eventTypes []event.Type
+			var lastErr error
+			for e := range eventChannel {
+				eventTypes = append(eventTypes, e.Type)
+				if e.Type == event.ErrorEvent {
+					lastErr = e.Error
+				}
+			}
+
+			assert.Equal(t, tc.expectedEventTypes, eventTypes)
+			if tc.expectedError != "" {
+				assert.EqualError(t, lastErr, tc.expectedError)
+				if tc.readErr != nil {
+					assert.ErrorIs(t, lastErr, tc.readErr)
+				} else {
+					assert.ErrorIs(t, lastErr, tc.syncErr)
+				}
+			}
+			if tc.expectedMaxReads > 0 {
+				assert.LessOrEqual(t, statusReader.reads, tc.expectedMaxReads)
+			}
+		})
+	}
+}
+
+type fakeErrorStatusReader struct {
+	err   error
+	reads int
+}
+
+func (f *fakeErrorStatusReader) Supports(schema.GroupKind) bool {
+	return true
+}
+
+func (f *fakeErrorStatusReader) ReadStatus(_ context.Context, _ ClusterReader, identifier object.ObjMetadata) (*event.ResourceStatus, error) {
+	f.reads++
+	if f.err != nil {
+		return &event.ResourceStatus{
+			Identifier: identifier,
+			Status:     status.UnknownStatus,
+			Error:      f.err,
+		}, nil
+	}
+	return &event.ResourceStatus{
+		Identifier: identifier,
+		Status:     status.CurrentStatus,
+	}, nil
+}
+
+func (f *fakeErrorStatusReader) ReadStatusForObject(_ context.Context, _ ClusterReader, _ *unstructured.Unstructured) (*event.ResourceStatus, error) {
+	return nil, nil
+}
@@ -75,6 +75,10 @@ type ResourceStatus struct {
 	// Message is text describing the status of the resource.
 	Message string
 
+	// GenerationLag is set if the status of the resource is stale, because
+	// its controller has not observed the latest generation yet.
+	GenerationLag *status.GenerationLag
+
 	// GeneratedResources is a slice of ResourceStatus that
 	// contains information and status for any generated resources
 	// of the current resource.
@@ -92,15 +92,53 @@ type StatusPoller struct {
 func (s *StatusPoller) Poll(ctx context.Context, identifiers object.ObjMetadataSet, options PollOptions) <-chan event.Event {
 	return s.engine.Poll(ctx, identifiers, engine.Options{
 		PollInterval: options.PollInterval,
+		MaxBackoff:   options.MaxBackoff,
+		ErrorBudget:  options.ErrorBudget,
 	})
 }
 
+// AddIdentifiers adds the resources to the set of resources polled by the
+// running Polls of the StatusPoller, without restarting them. An error is
+// returned if a namespaced resource has no namespace.
+func (s *StatusPoller) AddIdentifiers(identifiers object.ObjMetadataSet) error {
+	return s.engine.AddIdentifiers(identifiers)
+}
+
+// RemoveIdentifiers removes the resources from the set of resources polled by
+// the running Polls of the StatusPoller, without restarting them.
+func (s *StatusPoller) RemoveIdentifiers(identifiers object.ObjMetadataSet) {
+	s.engine.RemoveIdentifiers(identifiers)
+}
+
+// Pause stops the running Polls of the StatusPoller, and the Polls started
+// later, from making API calls, until Resume is called. The Polls are kept
+// running, with their set of resources and the last status of each resource.
+func (s *StatusPoller) Pause() {
+	s.engine.Pause()
+}
+
+// Resume resumes the Polls of the StatusPoller stopped by Pause. They poll
+// right away.
+func (s *StatusPoller) Resume() {
+	s.engine.Resume()
+}
+
 // PollOptions defines the levers available for tuning the behavior of the
 // StatusPoller.
 type PollOptions struct {
 	// PollInterval defines how often the PollerEngine should poll the cluster for the latest
 	// state of the resources.
 	PollInterval time.Duration
+
+	// MaxBackoff defines the maximum interval between polls of a resource
+	// whose status can't be read. If zero, failing resources are polled
+	// every PollInterval.
+	MaxBackoff time.Duration
+
+	// ErrorBudget defines how many consecutive times a resource can fail to
+	// be polled before polling stops with an ErrorEvent. If zero, polling
+	// only stops when the cluster can't be synced.
+	ErrorBudget int
 }
 
 // createStatusReaders creates an instance of all the statusreaders. This includes a set of statusreaders for
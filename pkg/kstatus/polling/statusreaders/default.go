@@ -18,21 +18,26 @@ import (
 
 // NewDefaultStatusReader returns a DelegatingStatusReader that wraps a list of
 // statusreaders to cover all built-in Kubernetes resources and other CRDs that
-// follow known status conventions.
-func NewDefaultStatusReader(mapper meta.RESTMapper) engine.StatusReader {
+// follow known status conventions. Custom statusreaders, if any, are used
+// first, for the resources they support.
+func NewDefaultStatusReader(mapper meta.RESTMapper, customStatusReaders ...engine.StatusReader) engine.StatusReader {
 	defaultStatusReader := NewGenericStatusReader(mapper, status.Compute)
 
 	replicaSetStatusReader := NewReplicaSetStatusReader(mapper, defaultStatusReader)
 	deploymentStatusReader := NewDeploymentResourceReader(mapper, replicaSetStatusReader)
 	statefulSetStatusReader := NewStatefulSetResourceReader(mapper, defaultStatusReader)
 
+	// Custom status readers take precedence over the built-in ones.
+	var statusReaders []engine.StatusReader
+	statusReaders = append(statusReaders, customStatusReaders...)
+	statusReaders = append(statusReaders,
+		deploymentStatusReader,
+		statefulSetStatusReader,
+		replicaSetStatusReader,
+		defaultStatusReader,
+	)
 	return &DelegatingStatusReader{
-		StatusReaders: []engine.StatusReader{
-			deploymentStatusReader,
-			statefulSetStatusReader,
-			replicaSetStatusReader,
-			defaultStatusReader,
-		},
+		StatusReaders: statusReaders,
 	}
 }
 
@@ -0,0 +1,93 @@
+// Copyright 2021 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package statusreaders
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakecr "sigs.k8s.io/cli-utils/pkg/kstatus/polling/clusterreader/fake"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/engine"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	fakemapper "sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+// customStatusReader computes the status of the custom GroupKind only.
+type customStatusReader struct{}
+
+func (c *customStatusReader) Supports(gk schema.GroupKind) bool {
+	return gk == customGVK.GroupKind()
+}
+
+func (c *customStatusReader) ReadStatus(_ context.Context, _ engine.ClusterReader, id object.ObjMetadata) (*event.ResourceStatus, error) {
+	return &event.ResourceStatus{
+		Identifier: id,
+		Status:     status.CurrentStatus,
+		Message:    "custom",
+	}, nil
+}
+
+func (c *customStatusReader) ReadStatusForObject(ctx context.Context, reader engine.ClusterReader, obj *unstructured.Unstructured) (*event.ResourceStatus, error) {
+	return c.ReadStatus(ctx, reader, object.UnstructuredToObjMetadata(obj))
+}
+
+func TestDefaultStatusReader_CustomStatusReaders(t *testing.T) {
+	configMapGVK := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	mapper := fakemapper.NewFakeRESTMapper(customGVK, configMapGVK)
+	statusReader := NewDefaultStatusReader(mapper, &customStatusReader{})
+
+	custom := &unstructured.Unstructured{}
+	custom.SetGroupVersionKind(customGVK)
+	custom.SetName(name)
+	custom.SetNamespace(namespace)
+
+	// The custom status reader is used for the GroupKind it supports.
+	resourceStatus, err := statusReader.ReadStatusForObject(context.Background(), fakecr.NewNoopClusterReader(), custom)
+	require.NoError(t, err)
+	assert.Equal(t, status.CurrentStatus, resourceStatus.Status)
+	assert.Equal(t, "custom", resourceStatus.Message)
+
+	configMap := &unstructured.Unstructured{}
+	configMap.SetGroupVersionKind(configMapGVK)
+	configMap.SetName(name)
+	configMap.SetNamespace(namespace)
+
+	// The built-in status readers are used for other GroupKinds.
+	resourceStatus, err = statusReader.ReadStatusForObject(context.Background(), fakecr.NewNoopClusterReader(), configMap)
+	require.NoError(t, err)
+	assert.Equal(t, status.CurrentStatus, resourceStatus.Status)
+	assert.NotEqual(t, "custom", resourceStatus.Message)
+}
+
+func TestRulesStatusReader(t *testing.T) {
+	mapper := fakemapper.NewFakeRESTMapper(customGVK)
+	statusReader := NewRulesStatusReader(mapper, status.Rules{
+		{
+			Group:   customGVK.Group,
+			Kind:    customGVK.Kind,
+			Current: "status.phase == 'Ready'",
+		},
+	})
+
+	assert.True(t, statusReader.Supports(customGVK.GroupKind()))
+	assert.False(t, statusReader.Supports(schema.GroupKind{Kind: "ConfigMap"}))
+
+	custom := &unstructured.Unstructured{}
+	custom.SetGroupVersionKind(customGVK)
+	custom.SetName(name)
+	custom.SetNamespace(namespace)
+	custom.Object["status"] = map[string]interface{}{
+		"phase": "Ready",
+	}
+
+	resourceStatus, err := statusReader.ReadStatusForObject(context.Background(), fakecr.NewNoopClusterReader(), custom)
+	require.NoError(t, err)
+	assert.Equal(t, status.CurrentStatus, resourceStatus.Status)
+}
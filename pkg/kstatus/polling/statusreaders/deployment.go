@@ -67,6 +67,7 @@ func (d *deploymentResourceReader) ReadStatusForObject(ctx context.Context, read
 		Status:             res.Status,
 		Resource:           deployment,
 		Message:            res.Message,
+		GenerationLag:      res.GenerationLag,
 		GeneratedResources: replicaSetStatuses,
 	}, nil
 }
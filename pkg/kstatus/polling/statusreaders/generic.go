@@ -57,9 +57,10 @@ func (g *genericStatusReader) ReadStatusForObject(_ context.Context, _ engine.Cl
 	}
 
 	return &event.ResourceStatus{
-		Identifier: identifier,
-		Status:     res.Status,
-		Resource:   resource,
-		Message:    res.Message,
+		Identifier:    identifier,
+		Status:        res.Status,
+		Resource:      resource,
+		Message:       res.Message,
+		GenerationLag: res.GenerationLag,
 	}, nil
 }
@@ -83,6 +83,7 @@ func (p *podControllerStatusReader) readStatus(ctx context.Context, reader engin
 		Status:             res.Status,
 		Resource:           obj,
 		Message:            res.Message,
+		GenerationLag:      res.GenerationLag,
 		GeneratedResources: podResourceStatuses,
 	}, nil
 }
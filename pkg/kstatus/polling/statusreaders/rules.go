@@ -0,0 +1,42 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package statusreaders
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/engine"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+)
+
+// NewRulesStatusReader returns a StatusReader that computes the status of
+// the resources of the GroupKinds of the rules, using the rules. It can be
+// used as a custom StatusReader, to declare the status of custom resources
+// that do not follow the kstatus conventions.
+func NewRulesStatusReader(mapper meta.RESTMapper, rules status.Rules) engine.StatusReader {
+	return &baseStatusReader{
+		mapper: mapper,
+		resourceStatusReader: &rulesStatusReader{
+			genericStatusReader: genericStatusReader{
+				mapper:     mapper,
+				statusFunc: rules.Compute,
+			},
+			rules: rules,
+		},
+	}
+}
+
+// rulesStatusReader is a resourceTypeStatusReader that only supports the
+// GroupKinds of its rules.
+type rulesStatusReader struct {
+	genericStatusReader
+
+	rules status.Rules
+}
+
+var _ resourceTypeStatusReader = &rulesStatusReader{}
+
+func (r *rulesStatusReader) Supports(gk schema.GroupKind) bool {
+	return r.rules.Supports(gk)
+}
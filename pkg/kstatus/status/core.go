@@ -4,6 +4,7 @@
 package status
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"strings"
@@ -20,21 +21,25 @@ type GetConditionsFn func(*unstructured.Unstructured) (*Result, error)
 // legacyTypes defines the mapping from GroupKind to a function that can
 // compute the status for the given resource.
 var legacyTypes = map[string]GetConditionsFn{
-	"Service":                    serviceConditions,
-	"Pod":                        podConditions,
-	"Secret":                     alwaysReady,
-	"PersistentVolumeClaim":      pvcConditions,
-	"apps/StatefulSet":           stsConditions,
-	"apps/DaemonSet":             daemonsetConditions,
-	"extensions/DaemonSet":       daemonsetConditions,
-	"apps/Deployment":            deploymentConditions,
-	"extensions/Deployment":      deploymentConditions,
-	"apps/ReplicaSet":            replicasetConditions,
-	"extensions/ReplicaSet":      replicasetConditions,
-	"policy/PodDisruptionBudget": pdbConditions,
-	"batch/CronJob":              alwaysReady,
-	"ConfigMap":                  alwaysReady,
-	"batch/Job":                  jobConditions,
+	"Service":                             serviceConditions,
+	"Namespace":                           namespaceConditions,
+	"Pod":                                 podConditions,
+	"Secret":                              alwaysReady,
+	"PersistentVolumeClaim":               pvcConditions,
+	"apps/StatefulSet":                    stsConditions,
+	"apps/DaemonSet":                      daemonsetConditions,
+	"extensions/DaemonSet":                daemonsetConditions,
+	"apps/Deployment":                     deploymentConditions,
+	"extensions/Deployment":               deploymentConditions,
+	"apps/ReplicaSet":                     replicasetConditions,
+	"extensions/ReplicaSet":               replicasetConditions,
+	"policy/PodDisruptionBudget":          pdbConditions,
+	"batch/CronJob":                       cronJobConditions,
+	"ConfigMap":                           alwaysReady,
+	"batch/Job":                           jobConditions,
+	"autoscaling/HorizontalPodAutoscaler": hpaConditions,
+	"networking.k8s.io/Ingress":           ingressConditions,
+	"extensions/Ingress":                  ingressConditions,
 	"apiextensions.k8s.io/CustomResourceDefinition": crdConditions,
 }
 
@@ -509,14 +514,24 @@ func getCrashLoopingContainers(obj map[string]interface{}) ([]string, bool, erro
 // pdbConditions computes the status for PodDisruptionBudgets. A PDB
 // is currently considered Current if the disruption controller has
 // observed the latest version of the PDB resource and has computed
-// the AllowedDisruptions. PDBs do have ObservedGeneration in the
+// the AllowedDisruptions, and there are at least as many healthy pods as
+// desired. PDBs do have ObservedGeneration in the
 // Status object, so if this function gets called we know that
 // the controller has observed the latest changes.
 // The disruption controller does not set any conditions if
 // computing the AllowedDisruptions fails (and there are many ways
 // it can fail), but there is PR against OSS Kubernetes to address
 // this: https://github.com/kubernetes/kubernetes/pull/86929
-func pdbConditions(_ *unstructured.Unstructured) (*Result, error) {
+func pdbConditions(u *unstructured.Unstructured) (*Result, error) {
+	obj := u.UnstructuredContent()
+
+	currentHealthy := GetIntField(obj, ".status.currentHealthy", 0)
+	desiredHealthy := GetIntField(obj, ".status.desiredHealthy", 0)
+	if currentHealthy < desiredHealthy {
+		message := fmt.Sprintf("Healthy: %d/%d", currentHealthy, desiredHealthy)
+		return newInProgressStatus("LessHealthy", message), nil
+	}
+
 	// All ok
 	return &Result{
 		Status:     CurrentStatus,
@@ -577,6 +592,105 @@ func jobConditions(u *unstructured.Unstructured) (*Result, error) {
 	}, nil
 }
 
+// cronJobConditions return standardized Conditions for CronJob
+//
+// A CronJob is always Current, because it may not be scheduled for a long
+// time. The message tells whether it is suspended, or when it was last
+// scheduled.
+func cronJobConditions(u *unstructured.Unstructured) (*Result, error) {
+	obj := u.UnstructuredContent()
+
+	suspend, _, err := unstructured.NestedBool(obj, "spec", "suspend")
+	if err != nil {
+		return nil, fmt.Errorf("looking up spec.suspend from resource: %w", err)
+	}
+	lastScheduleTime := GetStringField(obj, ".status.lastScheduleTime", "")
+
+	var message string
+	switch {
+	case suspend:
+		message = "CronJob is suspended"
+	case lastScheduleTime != "":
+		message = fmt.Sprintf("CronJob last scheduled at %s", lastScheduleTime)
+	default:
+		message = "CronJob has not been scheduled yet"
+	}
+	return &Result{
+		Status:     CurrentStatus,
+		Message:    message,
+		Conditions: []Condition{},
+	}, nil
+}
+
+// hpaConditionsAnnotation is the annotation used by autoscaling/v1 to store
+// the conditions of HorizontalPodAutoscalers.
+const hpaConditionsAnnotation = "autoscaling.alpha.kubernetes.io/conditions"
+
+// hpaConditions return standardized Conditions for HorizontalPodAutoscaler
+//
+// An HPA is Failed if it is not AbleToScale, because the scale target can't
+// be found or updated. It is InProgress until ScalingActive, because the
+// metrics may not be available yet, unless scaling is disabled. With
+// autoscaling/v1, the conditions are read from an annotation.
+func hpaConditions(u *unstructured.Unstructured) (*Result, error) {
+	obj := u.UnstructuredContent()
+
+	objc, err := GetObjectWithConditions(obj)
+	if err != nil {
+		return nil, err
+	}
+	conditions := objc.Status.Conditions
+	if len(conditions) == 0 {
+		if data, found := u.GetAnnotations()[hpaConditionsAnnotation]; found {
+			if err := json.Unmarshal([]byte(data), &conditions); err != nil {
+				return nil, fmt.Errorf("failed to parse %s annotation: %w", hpaConditionsAnnotation, err)
+			}
+		}
+	}
+
+	if c, found := getConditionWithStatus(conditions, "AbleToScale", corev1.ConditionFalse); found {
+		return newFailedStatus(c.Reason, c.Message), nil
+	}
+	if c, found := getConditionWithStatus(conditions, "ScalingActive", corev1.ConditionFalse); found {
+		if c.Reason == "ScalingDisabled" {
+			return &Result{
+				Status:     CurrentStatus,
+				Message:    c.Message,
+				Conditions: []Condition{},
+			}, nil
+		}
+		return newInProgressStatus(c.Reason, c.Message), nil
+	}
+	if hasConditionWithStatus(conditions, "ScalingActive", corev1.ConditionTrue) {
+		return &Result{
+			Status:     CurrentStatus,
+			Message:    "HPA is scaling",
+			Conditions: []Condition{},
+		}, nil
+	}
+	return newInProgressStatus("ScalingNotActive", "HPA conditions not available"), nil
+}
+
+// ingressConditions return standardized Conditions for Ingress
+//
+// An Ingress is InProgress until its load balancer is provisioned.
+func ingressConditions(u *unstructured.Unstructured) (*Result, error) {
+	obj := u.UnstructuredContent()
+
+	lbIngress, _, err := unstructured.NestedSlice(obj, "status", "loadBalancer", "ingress")
+	if err != nil {
+		return nil, fmt.Errorf("looking up status.loadBalancer.ingress from resource: %w", err)
+	}
+	if len(lbIngress) == 0 {
+		return newInProgressStatus("NoLoadBalancer", "Load balancer not provisioned"), nil
+	}
+	return &Result{
+		Status:     CurrentStatus,
+		Message:    "Load balancer provisioned",
+		Conditions: []Condition{},
+	}, nil
+}
+
 // serviceConditions return standardized Conditions for Service
 func serviceConditions(u *unstructured.Unstructured) (*Result, error) {
 	obj := u.UnstructuredContent()
@@ -598,6 +712,23 @@ func serviceConditions(u *unstructured.Unstructured) (*Result, error) {
 	}, nil
 }
 
+// namespaceConditions return standardized Conditions for Namespace
+func namespaceConditions(u *unstructured.Unstructured) (*Result, error) {
+	obj := u.UnstructuredContent()
+
+	phase := GetStringField(obj, ".status.phase", "unknown")
+	if phase != string(corev1.NamespaceActive) {
+		message := fmt.Sprintf("Namespace is not Active. phase: %s", phase)
+		return newInProgressStatus("NotActive", message), nil
+	}
+	// All ok
+	return &Result{
+		Status:     CurrentStatus,
+		Message:    "Namespace is Active",
+		Conditions: []Condition{},
+	}, nil
+}
+
 func crdConditions(u *unstructured.Unstructured) (*Result, error) {
 	obj := u.UnstructuredContent()
 
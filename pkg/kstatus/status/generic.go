@@ -87,11 +87,16 @@ func checkGeneration(u *unstructured.Unstructured) (*Result, error) {
 		// Resource does not have this field, so we can't do this check.
 		// TODO(mortent): Verify behavior of not set vs does not exist.
 		if observedGeneration != generation {
-			message := fmt.Sprintf("%s generation is %d, but latest observed generation is %d", u.GetKind(), generation, observedGeneration)
+			lag := &GenerationLag{
+				Generation:         generation,
+				ObservedGeneration: observedGeneration,
+			}
+			message := lag.String()
 			return &Result{
-				Status:     InProgressStatus,
-				Message:    message,
-				Conditions: []Condition{newReconcilingCondition("LatestGenerationNotObserved", message)},
+				Status:        InProgressStatus,
+				Message:       message,
+				Conditions:    []Condition{newReconcilingCondition("LatestGenerationNotObserved", message)},
+				GenerationLag: lag,
 			}, nil
 		}
 	}
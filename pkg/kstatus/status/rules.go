@@ -0,0 +1,156 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+// Rule declares how to compute the status of the resources of a GroupKind
+// that do not follow the kstatus conventions, without writing a
+// StatusReader. Each expression combines JSONPath values with comparison and
+// logical operators, like "status.phase == 'Failed'". The expressions are
+// evaluated in order: Failed, Current, then InProgress. The status of the
+// first true expression is used. If none is true, the status is computed
+// with the kstatus conventions.
+type Rule struct {
+	// Group of the resources. Empty for the core group.
+	Group string `json:"group,omitempty"`
+	// Kind of the resources.
+	Kind string `json:"kind"`
+	// Failed is the expression that is true when the resource has failed.
+	Failed string `json:"failed,omitempty"`
+	// Current is the expression that is true when the resource is current.
+	Current string `json:"current,omitempty"`
+	// InProgress is the expression that is true when the resource is in
+	// progress.
+	InProgress string `json:"inProgress,omitempty"`
+	// Message is the JSONPath of the status message, like "status.message".
+	// Optional.
+	Message string `json:"message,omitempty"`
+}
+
+// GroupKind returns the GroupKind of the resources of the rule.
+func (r Rule) GroupKind() schema.GroupKind {
+	return schema.GroupKind{Group: r.Group, Kind: r.Kind}
+}
+
+// Rules are the status rules for a set of GroupKinds.
+type Rules []Rule
+
+// ReadRules parses a YAML or JSON list of rules, like:
+//
+//   - group: example.com
+//     kind: Database
+//     current: status.phase == 'Ready'
+//     failed: status.phase == 'Error'
+//     message: status.reason
+func ReadRules(data []byte) (Rules, error) {
+	var rules Rules
+	if err := yaml.UnmarshalStrict(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse status rules: %w", err)
+	}
+	for i, rule := range rules {
+		if rule.Kind == "" {
+			return nil, fmt.Errorf("status rule %d: kind is required", i)
+		}
+		if rule.Failed == "" && rule.Current == "" && rule.InProgress == "" {
+			return nil, fmt.Errorf("status rule %d (%s): at least one expression is required", i, rule.GroupKind())
+		}
+	}
+	return rules, nil
+}
+
+// Supports returns true if there is a rule for the GroupKind.
+func (r Rules) Supports(gk schema.GroupKind) bool {
+	_, found := r.ruleFor(gk)
+	return found
+}
+
+func (r Rules) ruleFor(gk schema.GroupKind) (Rule, bool) {
+	for _, rule := range r {
+		if rule.GroupKind() == gk {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Compute finds the status of a given unstructured resource, using the rule
+// for its GroupKind, if there is one. Resources scheduled for deletion are
+// Terminating, and resources whose generation has not been observed yet are
+// InProgress, like with the kstatus conventions. Otherwise, if no rule
+// expression is true, the status is computed with the kstatus conventions.
+// Compute can be used as the StatusFunc of a generic StatusReader.
+func (r Rules) Compute(u *unstructured.Unstructured) (*Result, error) {
+	rule, found := r.ruleFor(u.GroupVersionKind().GroupKind())
+	if !found {
+		return Compute(u)
+	}
+
+	deletionTimestamp, found, err := unstructured.NestedString(u.Object, "metadata", "deletionTimestamp")
+	if err != nil {
+		return nil, fmt.Errorf("looking up metadata.deletionTimestamp from resource: %w", err)
+	}
+	if found && deletionTimestamp != "" {
+		return &Result{
+			Status:     TerminatingStatus,
+			Message:    "Resource scheduled for deletion",
+			Conditions: []Condition{},
+		}, nil
+	}
+	res, err := checkGeneration(u)
+	if res != nil || err != nil {
+		return res, err
+	}
+
+	for _, check := range []struct {
+		status     Status
+		expression string
+	}{
+		{status: FailedStatus, expression: rule.Failed},
+		{status: CurrentStatus, expression: rule.Current},
+		{status: InProgressStatus, expression: rule.InProgress},
+	} {
+		if check.expression == "" {
+			continue
+		}
+		ok, err := jsonpath.EvalCondition(u.Object, check.expression)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating %s status rule of %s: %w", check.status, rule.GroupKind(), err)
+		}
+		if ok {
+			return &Result{
+				Status:     check.status,
+				Message:    rule.message(u, check.status),
+				Conditions: []Condition{},
+			}, nil
+		}
+	}
+	return Compute(u)
+}
+
+// message returns the status message found with the Message JSONPath, or
+// a default message for the status.
+func (r Rule) message(u *unstructured.Unstructured, status Status) string {
+	if r.Message != "" {
+		path := r.Message
+		if !strings.HasPrefix(path, "$") {
+			path = "$." + path
+		}
+		values, err := jsonpath.Get(u.Object, path)
+		if err == nil && len(values) == 1 {
+			if msg, ok := values[0].(string); ok && msg != "" {
+				return msg
+			}
+		}
+	}
+	return fmt.Sprintf("Resource is %s", status)
+}
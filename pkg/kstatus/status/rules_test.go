@@ -0,0 +1,173 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var databaseRules = Rules{
+	{
+		Group:   "example.com",
+		Kind:    "Database",
+		Failed:  "status.phase == 'Error'",
+		Current: "status.phase == 'Ready'",
+		Message: "status.reason",
+	},
+}
+
+var databaseManifest = `
+apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: db
+  generation: 1
+status:
+  observedGeneration: 1
+`
+
+func TestRulesCompute(t *testing.T) {
+	testCases := map[string]struct {
+		manifest        string
+		status          map[string]interface{}
+		deleting        bool
+		expectedStatus  Status
+		expectedMessage string
+	}{
+		"current": {
+			manifest: databaseManifest,
+			status: map[string]interface{}{
+				"observedGeneration": int64(1),
+				"phase":              "Ready",
+			},
+			expectedStatus:  CurrentStatus,
+			expectedMessage: "Resource is Current",
+		},
+		"failed with message": {
+			manifest: databaseManifest,
+			status: map[string]interface{}{
+				"observedGeneration": int64(1),
+				"phase":              "Error",
+				"reason":             "disk full",
+			},
+			expectedStatus:  FailedStatus,
+			expectedMessage: "disk full",
+		},
+		"no rule is true, kstatus conventions": {
+			manifest: databaseManifest,
+			status: map[string]interface{}{
+				"observedGeneration": int64(1),
+				"phase":              "Provisioning",
+				"conditions": []interface{}{
+					map[string]interface{}{
+						"type":   "Reconciling",
+						"status": "True",
+					},
+				},
+			},
+			expectedStatus: InProgressStatus,
+		},
+		"generation not observed": {
+			manifest: databaseManifest,
+			status: map[string]interface{}{
+				"observedGeneration": int64(0),
+				"phase":              "Ready",
+			},
+			expectedStatus: InProgressStatus,
+		},
+		"terminating": {
+			manifest: databaseManifest,
+			status: map[string]interface{}{
+				"observedGeneration": int64(1),
+				"phase":              "Ready",
+			},
+			deleting:       true,
+			expectedStatus: TerminatingStatus,
+		},
+		"no rule for the kind": {
+			manifest: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`,
+			expectedStatus: CurrentStatus,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			u := y2u(t, tc.manifest)
+			if tc.status != nil {
+				u.Object["status"] = tc.status
+			}
+			if tc.deleting {
+				u.Object["metadata"].(map[string]interface{})["deletionTimestamp"] = "2022-01-01T00:00:00Z"
+			}
+			res, err := databaseRules.Compute(u)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.Status)
+			if tc.expectedMessage != "" {
+				assert.Equal(t, tc.expectedMessage, res.Message)
+			}
+		})
+	}
+}
+
+func TestReadRules(t *testing.T) {
+	testCases := map[string]struct {
+		data          string
+		expectedRules Rules
+		expectedError string
+	}{
+		"valid": {
+			data: `
+- group: example.com
+  kind: Database
+  current: status.phase == 'Ready'
+  failed: status.phase == 'Error'
+  message: status.reason
+`,
+			expectedRules: databaseRules,
+		},
+		"missing kind": {
+			data: `
+- group: example.com
+  current: status.phase == 'Ready'
+`,
+			expectedError: "status rule 0: kind is required",
+		},
+		"missing expression": {
+			data: `
+- group: example.com
+  kind: Database
+`,
+			expectedError: "status rule 0 (Database.example.com): at least one expression is required",
+		},
+		"unknown field": {
+			data: `
+- kind: Database
+  ready: status.phase == 'Ready'
+`,
+			expectedError: `failed to parse status rules: error unmarshaling JSON: while decoding JSON: json: unknown field "ready"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			rules, err := ReadRules([]byte(tc.data))
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRules, rules)
+			assert.True(t, rules.Supports(schema.GroupKind{Group: "example.com", Kind: "Database"}))
+		})
+	}
+}
@@ -69,6 +69,28 @@ type Result struct {
 	Message string
 	// Conditions list of extracted conditions from Resource
 	Conditions []Condition
+	// GenerationLag is set if the status of the resource is stale, because
+	// the controller has not observed the latest generation yet.
+	GenerationLag *GenerationLag
+}
+
+// GenerationLag describes a resource whose status.observedGeneration
+// does not match its metadata.generation, so its status is stale.
+type GenerationLag struct {
+	// Generation is the metadata.generation of the resource.
+	Generation int64
+	// ObservedGeneration is the status.observedGeneration of the resource.
+	ObservedGeneration int64
+}
+
+// String returns a message like
+// "StaleStatus: observedGeneration 3 < generation 5".
+func (g GenerationLag) String() string {
+	op := "<"
+	if g.ObservedGeneration > g.Generation {
+		op = ">"
+	}
+	return fmt.Sprintf("StaleStatus: observedGeneration %d %s generation %d", g.ObservedGeneration, op, g.Generation)
 }
 
 // Condition defines the general format for conditions on Kubernetes resources.
@@ -307,6 +307,54 @@ func TestPVCStatus(t *testing.T) {
 	}
 }
 
+var namespaceNoStatus = `
+apiVersion: v1
+kind: Namespace
+metadata:
+   name: test
+`
+var namespaceActive = `
+apiVersion: v1
+kind: Namespace
+metadata:
+   name: test
+status:
+   phase: Active
+`
+
+func TestNamespaceStatus(t *testing.T) {
+	testCases := map[string]testSpec{
+		"namespaceNoStatus": {
+			spec:           namespaceNoStatus,
+			expectedStatus: InProgressStatus,
+			expectedConditions: []Condition{{
+				Type:   ConditionReconciling,
+				Status: corev1.ConditionTrue,
+				Reason: "NotActive",
+			}},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+			},
+		},
+		"namespaceActive": {
+			spec:               namespaceActive,
+			expectedStatus:     CurrentStatus,
+			expectedConditions: []Condition{},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+				ConditionReconciling,
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			runStatusTest(t, tc)
+		})
+	}
+}
+
 var stsNoStatus = `
 apiVersion: apps/v1
 kind: StatefulSet
@@ -990,6 +1038,32 @@ status:
    observedGeneration: 1
 `
 
+var pdbLessHealthy = `
+apiVersion: policy/v1
+kind: PodDisruptionBudget
+metadata:
+   generation: 1
+   name: test
+   namespace: qual
+status:
+   observedGeneration: 1
+   currentHealthy: 1
+   desiredHealthy: 2
+`
+
+var pdbHealthy = `
+apiVersion: policy/v1
+kind: PodDisruptionBudget
+metadata:
+   generation: 1
+   name: test
+   namespace: qual
+status:
+   observedGeneration: 1
+   currentHealthy: 2
+   desiredHealthy: 2
+`
+
 func TestPDBStatus(t *testing.T) {
 	testCases := map[string]testSpec{
 		"pdbNotObserved": {
@@ -1013,6 +1087,27 @@ func TestPDBStatus(t *testing.T) {
 				ConditionReconciling,
 			},
 		},
+		"pdbLessHealthy": {
+			spec:           pdbLessHealthy,
+			expectedStatus: InProgressStatus,
+			expectedConditions: []Condition{{
+				Type:   ConditionReconciling,
+				Status: corev1.ConditionTrue,
+				Reason: "LessHealthy",
+			}},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+			},
+		},
+		"pdbHealthy": {
+			spec:               pdbHealthy,
+			expectedStatus:     CurrentStatus,
+			expectedConditions: []Condition{},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+				ConditionReconciling,
+			},
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -1023,6 +1118,18 @@ func TestPDBStatus(t *testing.T) {
 	}
 }
 
+func TestGenerationLag(t *testing.T) {
+	res, err := Compute(y2u(t, pdbNotObserved))
+	assert.NoError(t, err)
+	assert.Equal(t, InProgressStatus, res.Status)
+	assert.Equal(t, "StaleStatus: observedGeneration 1 < generation 2", res.Message)
+	assert.Equal(t, &GenerationLag{Generation: 2, ObservedGeneration: 1}, res.GenerationLag)
+
+	res, err = Compute(y2u(t, pdbObserved))
+	assert.NoError(t, err)
+	assert.Nil(t, res.GenerationLag)
+}
+
 var crdNoStatus = `
 apiVersion: something/v1
 kind: MyCR
@@ -1288,6 +1395,28 @@ metadata:
 status:
 `
 
+var cronjobSuspended = `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+   name: test
+   namespace: qual
+   generation: 1
+spec:
+   suspend: true
+`
+
+var cronjobScheduled = `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+   name: test
+   namespace: qual
+   generation: 1
+status:
+   lastScheduleTime: "2022-01-01T00:00:00Z"
+`
+
 func TestCronJobStatus(t *testing.T) {
 	testCases := map[string]testSpec{
 		"cronjobNoStatus": {
@@ -1308,6 +1437,249 @@ func TestCronJobStatus(t *testing.T) {
 				ConditionReconciling,
 			},
 		},
+		"cronjobSuspended": {
+			spec:               cronjobSuspended,
+			expectedStatus:     CurrentStatus,
+			expectedConditions: []Condition{},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+				ConditionReconciling,
+			},
+		},
+		"cronjobScheduled": {
+			spec:               cronjobScheduled,
+			expectedStatus:     CurrentStatus,
+			expectedConditions: []Condition{},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+				ConditionReconciling,
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			runStatusTest(t, tc)
+		})
+	}
+}
+
+var hpaNoConditions = `
+apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+   name: test
+   namespace: qual
+   generation: 1
+status:
+   observedGeneration: 1
+`
+
+var hpaScalingActive = `
+apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+   name: test
+   namespace: qual
+   generation: 1
+status:
+   observedGeneration: 1
+   conditions:
+   - type: AbleToScale
+     status: "True"
+     reason: ReadyForNewScale
+   - type: ScalingActive
+     status: "True"
+     reason: ValidMetricFound
+`
+
+var hpaMetricsUnavailable = `
+apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+   name: test
+   namespace: qual
+   generation: 1
+status:
+   observedGeneration: 1
+   conditions:
+   - type: AbleToScale
+     status: "True"
+     reason: SucceededGetScale
+   - type: ScalingActive
+     status: "False"
+     reason: FailedGetResourceMetric
+`
+
+var hpaScalingDisabled = `
+apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+   name: test
+   namespace: qual
+   generation: 1
+status:
+   observedGeneration: 1
+   conditions:
+   - type: AbleToScale
+     status: "True"
+     reason: SucceededGetScale
+   - type: ScalingActive
+     status: "False"
+     reason: ScalingDisabled
+`
+
+var hpaUnableToScale = `
+apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+   name: test
+   namespace: qual
+   generation: 1
+status:
+   observedGeneration: 1
+   conditions:
+   - type: AbleToScale
+     status: "False"
+     reason: FailedGetScale
+`
+
+var hpaV1ScalingActive = `
+apiVersion: autoscaling/v1
+kind: HorizontalPodAutoscaler
+metadata:
+   name: test
+   namespace: qual
+   generation: 1
+   annotations:
+     autoscaling.alpha.kubernetes.io/conditions: '[{"type":"AbleToScale","status":"True","reason":"ReadyForNewScale"},{"type":"ScalingActive","status":"True","reason":"ValidMetricFound"}]'
+status:
+   observedGeneration: 1
+`
+
+func TestHPAStatus(t *testing.T) {
+	testCases := map[string]testSpec{
+		"hpaNoConditions": {
+			spec:           hpaNoConditions,
+			expectedStatus: InProgressStatus,
+			expectedConditions: []Condition{{
+				Type:   ConditionReconciling,
+				Status: corev1.ConditionTrue,
+				Reason: "ScalingNotActive",
+			}},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+			},
+		},
+		"hpaScalingActive": {
+			spec:               hpaScalingActive,
+			expectedStatus:     CurrentStatus,
+			expectedConditions: []Condition{},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+				ConditionReconciling,
+			},
+		},
+		"hpaMetricsUnavailable": {
+			spec:           hpaMetricsUnavailable,
+			expectedStatus: InProgressStatus,
+			expectedConditions: []Condition{{
+				Type:   ConditionReconciling,
+				Status: corev1.ConditionTrue,
+				Reason: "FailedGetResourceMetric",
+			}},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+			},
+		},
+		"hpaScalingDisabled": {
+			spec:               hpaScalingDisabled,
+			expectedStatus:     CurrentStatus,
+			expectedConditions: []Condition{},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+				ConditionReconciling,
+			},
+		},
+		"hpaUnableToScale": {
+			spec:           hpaUnableToScale,
+			expectedStatus: FailedStatus,
+			expectedConditions: []Condition{{
+				Type:   ConditionStalled,
+				Status: corev1.ConditionTrue,
+				Reason: "FailedGetScale",
+			}},
+			absentConditionTypes: []ConditionType{
+				ConditionReconciling,
+			},
+		},
+		"hpaV1ScalingActive": {
+			spec:               hpaV1ScalingActive,
+			expectedStatus:     CurrentStatus,
+			expectedConditions: []Condition{},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+				ConditionReconciling,
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			runStatusTest(t, tc)
+		})
+	}
+}
+
+var ingressNoLoadBalancer = `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+   name: test
+   namespace: qual
+   generation: 1
+status:
+   loadBalancer: {}
+`
+
+var ingressLoadBalancer = `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+   name: test
+   namespace: qual
+   generation: 1
+status:
+   loadBalancer:
+     ingress:
+     - ip: 10.0.0.1
+`
+
+func TestIngressStatus(t *testing.T) {
+	testCases := map[string]testSpec{
+		"ingressNoLoadBalancer": {
+			spec:           ingressNoLoadBalancer,
+			expectedStatus: InProgressStatus,
+			expectedConditions: []Condition{{
+				Type:   ConditionReconciling,
+				Status: corev1.ConditionTrue,
+				Reason: "NoLoadBalancer",
+			}},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+			},
+		},
+		"ingressLoadBalancer": {
+			spec:               ingressLoadBalancer,
+			expectedStatus:     CurrentStatus,
+			expectedConditions: []Condition{},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+				ConditionReconciling,
+			},
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -23,8 +23,18 @@ type AllowListObjectFilter struct {
 var _ ObjectFilter = &AllowListObjectFilter{}
 
 // Filter returns true if the object should be skipped, because it is NOT in the
-// AllowList.
+// AllowList. Objects created with a generated name are also allowed by the
+// id of their generateName, which is all that is known before they are
+// created.
 func (f *AllowListObjectFilter) Filter(obj *unstructured.Unstructured) bool {
 	id := object.UnstructuredToObjMetadata(obj)
+	if f.AllowList.Contains(id) {
+		return false
+	}
+	if obj.GetGenerateName() == "" {
+		return true
+	}
+	id.Name = ""
+	id.GenerateName = obj.GetGenerateName()
 	return !f.AllowList.Contains(id)
 }
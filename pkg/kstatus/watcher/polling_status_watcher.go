@@ -0,0 +1,100 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// Poller polls the cluster for status updates to a set of objects.
+// Poller is implemented by the polling.StatusPoller.
+type Poller interface {
+	Poll(context.Context, object.ObjMetadataSet, polling.PollOptions) <-chan event.Event
+}
+
+// PollingStatusWatcher reports on status updates to a set of objects by
+// polling the cluster at a regular interval, instead of watching it.
+// PollingStatusWatcher implements the StatusWatcher interface, so it can be
+// used by the Applier and Destroyer instead of the DefaultStatusWatcher.
+//
+// Polling is slower to notice status changes than watching, but does not
+// require permission to list and watch the objects.
+type PollingStatusWatcher struct {
+	// Poller is used to poll the objects.
+	Poller Poller
+
+	// PollInterval is how often the objects are polled.
+	PollInterval time.Duration
+}
+
+var _ StatusWatcher = &PollingStatusWatcher{}
+var _ IdentifierUpdater = &PollingStatusWatcher{}
+
+// NewPollingStatusWatcher constructs a PollingStatusWatcher that polls with
+// the StatusPoller at the specified interval.
+func NewPollingStatusWatcher(poller *polling.StatusPoller, pollInterval time.Duration) *PollingStatusWatcher {
+	return &PollingStatusWatcher{
+		Poller:       poller,
+		PollInterval: pollInterval,
+	}
+}
+
+// Watch polls the cluster for changes made to the specified objects.
+// Returns an event channel on which these updates (and errors) will be reported.
+// A SyncEvent is sent first, because polling requires no synchronization.
+// The RESTScopeStrategy option is ignored.
+// Objects with a generated name can not be polled until they are created, so
+// they are skipped. The created objects can be polled with AddIdentifiers.
+func (w *PollingStatusWatcher) Watch(ctx context.Context, ids object.ObjMetadataSet, _ Options) <-chan event.Event {
+	var named object.ObjMetadataSet
+	for _, id := range ids {
+		if !id.IsGenerated() {
+			named = append(named, id)
+		}
+	}
+	eventCh := make(chan event.Event)
+	go func() {
+		defer close(eventCh)
+		pollCtx, cancel := context.WithCancel(ctx)
+		pollCh := w.Poller.Poll(pollCtx, named, polling.PollOptions{
+			PollInterval: w.PollInterval,
+		})
+		defer func() {
+			// Stop polling and drain the poller's channel, so it is not
+			// blocked sending events that will never be received.
+			cancel()
+			for range pollCh {
+			}
+		}()
+		select {
+		case eventCh <- event.Event{Type: event.SyncEvent}:
+		case <-ctx.Done():
+			return
+		}
+		for e := range pollCh {
+			select {
+			case eventCh <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return eventCh
+}
+
+// AddIdentifiers adds the objects to the objects polled by the Poller, if the
+// Poller supports it, like the StatusPoller.
+func (w *PollingStatusWatcher) AddIdentifiers(ids object.ObjMetadataSet) error {
+	updater, ok := w.Poller.(IdentifierUpdater)
+	if !ok {
+		return fmt.Errorf("poller %T can not poll more objects", w.Poller)
+	}
+	return updater.AddIdentifiers(ids)
+}
@@ -0,0 +1,104 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package watcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+type fakePoller struct {
+	events  []event.Event
+	options polling.PollOptions
+	ids     object.ObjMetadataSet
+}
+
+func (p *fakePoller) Poll(ctx context.Context, ids object.ObjMetadataSet, options polling.PollOptions) <-chan event.Event {
+	p.options = options
+	p.ids = ids
+	eventCh := make(chan event.Event)
+	go func() {
+		defer close(eventCh)
+		for _, e := range p.events {
+			select {
+			case eventCh <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+		<-ctx.Done()
+	}()
+	return eventCh
+}
+
+func (p *fakePoller) AddIdentifiers(ids object.ObjMetadataSet) error {
+	p.ids = p.ids.Union(ids)
+	return nil
+}
+
+func TestPollingStatusWatcher(t *testing.T) {
+	id := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Name:      "foo",
+		Namespace: "default",
+	}
+	pollEvents := []event.Event{
+		{
+			Type: event.ResourceUpdateEvent,
+			Resource: &event.ResourceStatus{
+				Identifier: id,
+				Status:     status.InProgressStatus,
+			},
+		},
+		{
+			Type: event.ResourceUpdateEvent,
+			Resource: &event.ResourceStatus{
+				Identifier: id,
+				Status:     status.CurrentStatus,
+			},
+		},
+	}
+	poller := &fakePoller{events: pollEvents}
+	statusWatcher := &PollingStatusWatcher{
+		Poller:       poller,
+		PollInterval: 5 * time.Second,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	generatedID := object.ObjMetadata{
+		GroupKind:    id.GroupKind,
+		Namespace:    id.Namespace,
+		GenerateName: "foo-",
+	}
+	eventCh := statusWatcher.Watch(ctx, object.ObjMetadataSet{id, generatedID}, Options{})
+
+	var receivedEvents []event.Event
+	for e := range eventCh {
+		receivedEvents = append(receivedEvents, e)
+		if len(receivedEvents) == len(pollEvents)+1 {
+			cancel()
+		}
+	}
+
+	expectedEvents := append([]event.Event{{Type: event.SyncEvent}}, pollEvents...)
+	assert.Equal(t, expectedEvents, receivedEvents)
+	assert.Equal(t, 5*time.Second, poller.options.PollInterval)
+	// The object with a generated name is only polled once it is created.
+	assert.Equal(t, object.ObjMetadataSet{id}, poller.ids)
+
+	createdID := id
+	createdID.Name = "foo-abcde"
+	require.NoError(t, statusWatcher.AddIdentifiers(object.ObjMetadataSet{createdID}))
+	assert.Equal(t, object.ObjMetadataSet{id, createdID}, poller.ids)
+}
@@ -20,6 +20,15 @@ type StatusWatcher interface {
 	Watch(context.Context, object.ObjMetadataSet, Options) <-chan event.Event
 }
 
+// IdentifierUpdater is implemented by StatusWatchers that only watch the
+// objects they were asked to watch by name. Objects created with a name
+// generated by the server can only be added to the watched objects after
+// they are created, when their names are known.
+type IdentifierUpdater interface {
+	// AddIdentifiers adds the objects to the objects being watched.
+	AddIdentifiers(object.ObjMetadataSet) error
+}
+
 // Options can be provided when creating a new StatusWatcher to customize the
 // behavior.
 type Options struct {
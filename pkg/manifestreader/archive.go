@@ -0,0 +1,220 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// MaxArchiveSize is the maximum total size, in bytes, of the manifests
+// extracted from an archive.
+const MaxArchiveSize = 64 << 20
+
+// IsArchive returns true if the path names a .tar.gz, .tgz or .zip file.
+func IsArchive(p string) bool {
+	return archiveFormat(p) != ""
+}
+
+func archiveFormat(p string) string {
+	lower := strings.ToLower(p)
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return "tar.gz"
+	case strings.HasSuffix(lower, ".zip"):
+		return "zip"
+	default:
+		return ""
+	}
+}
+
+// archiveFile is a manifest file extracted from an archive.
+type archiveFile struct {
+	name    string
+	content []byte
+}
+
+// readArchive extracts the archive in memory and returns the nodes of the
+// YAML and JSON files it contains, ordered by their path in the archive.
+func readArchive(p string) ([]*yaml.RNode, error) {
+	var files []archiveFile
+	var err error
+	switch archiveFormat(p) {
+	case "tar.gz":
+		files, err = readTarGz(p)
+	case "zip":
+		files, err = readZip(p)
+	default:
+		err = fmt.Errorf("unsupported archive format")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive %q: %w", p, err)
+	}
+	return archiveNodes(p, files)
+}
+
+// readArchiveBytes is like readArchive, for an archive named p that has
+// already been read in memory.
+func readArchiveBytes(p string, b []byte) ([]*yaml.RNode, error) {
+	var files []archiveFile
+	var err error
+	switch archiveFormat(p) {
+	case "tar.gz":
+		files, err = extractTarGz(bytes.NewReader(b))
+	case "zip":
+		var zr *zip.Reader
+		zr, err = zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		if err == nil {
+			files, err = extractZip(zr)
+		}
+	default:
+		err = fmt.Errorf("unsupported archive format")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive %q: %w", p, err)
+	}
+	return archiveNodes(p, files)
+}
+
+// archiveNodes returns the nodes of the files of the archive p, ordered by
+// their path in the archive.
+func archiveNodes(p string, files []archiveFile) ([]*yaml.RNode, error) {
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].name < files[j].name
+	})
+
+	var nodes []*yaml.RNode
+	for _, f := range files {
+		fileNodes, err := (&kio.ByteReader{
+			Reader: bytes.NewReader(f.content),
+			SetAnnotations: map[string]string{
+				kioutil.PathAnnotation:       f.name,
+				kioutil.LegacyPathAnnotation: f.name, //nolint:staticcheck
+			},
+		}).Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q in archive %q: %w", f.name, p, err)
+		}
+		nodes = append(nodes, fileNodes...)
+	}
+	return nodes, nil
+}
+
+// isManifestFile returns true if the file in the archive is a YAML or
+// JSON file, and not hidden.
+func isManifestFile(name string) bool {
+	for _, part := range strings.Split(path.Clean(name), "/") {
+		if strings.HasPrefix(part, ".") && part != "." {
+			return false
+		}
+	}
+	switch strings.ToLower(path.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+func readTarGz(p string) ([]archiveFile, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return extractTarGz(f)
+}
+
+func readZip(p string) ([]archiveFile, error) {
+	zr, err := zip.OpenReader(p)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return extractZip(&zr.Reader)
+}
+
+// extractTarGz returns the manifest files of a gzip compressed tar archive.
+func extractTarGz(r io.Reader) ([]archiveFile, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	return extractTar(gz)
+}
+
+// extractTar returns the manifest files of a tar archive.
+func extractTar(r io.Reader) ([]archiveFile, error) {
+	var files []archiveFile
+	var total int64
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg || !isManifestFile(header.Name) {
+			continue
+		}
+		content, err := readArchiveEntry(tr, &total)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, archiveFile{name: path.Clean(header.Name), content: content})
+	}
+}
+
+// extractZip returns the manifest files of a zip archive.
+func extractZip(zr *zip.Reader) ([]archiveFile, error) {
+	var files []archiveFile
+	var total int64
+	for _, zf := range zr.File {
+		if zf.FileInfo().IsDir() || !isManifestFile(zf.Name) {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := readArchiveEntry(rc, &total)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, archiveFile{name: path.Clean(zf.Name), content: content})
+	}
+	return files, nil
+}
+
+// readArchiveEntry reads an entry of the archive, and adds its size to
+// the total. It is an error if the total exceeds MaxArchiveSize.
+func readArchiveEntry(r io.Reader, total *int64) ([]byte, error) {
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, io.LimitReader(r, MaxArchiveSize-*total+1))
+	if err != nil {
+		return nil, err
+	}
+	*total += n
+	if *total > MaxArchiveSize {
+		return nil, fmt.Errorf("extracted manifests are larger than %d bytes", MaxArchiveSize)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+)
+
+var archiveManifests = map[string]string{
+	"b/dep.yaml":        depManifest,
+	"a/cm.json":         `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-json"}}`,
+	"c/cm.yml":          cmManifest,
+	"README.md":         "not a manifest",
+	".hidden/dep.yaml":  depManifest,
+	"a/.secret.yaml":    cmManifest,
+	"c/notes/todo.txt":  "not a manifest",
+	"z/nested/dep.yaml": "kind: Deployment\napiVersion: apps/v1\nmetadata:\n  name: nested\n",
+}
+
+func TestPathManifestReader_ReadArchive(t *testing.T) {
+	dir := t.TempDir()
+	archives := map[string]string{
+		"tar.gz": writeTarGz(t, filepath.Join(dir, "manifests.tar.gz"), archiveManifests),
+		"tgz":    writeTarGz(t, filepath.Join(dir, "manifests.tgz"), archiveManifests),
+		"zip":    writeZip(t, filepath.Join(dir, "manifests.zip"), archiveManifests),
+	}
+
+	for tn, archive := range archives {
+		t.Run(tn, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("test-ns")
+			defer tf.Cleanup()
+
+			mapper, err := tf.ToRESTMapper()
+			require.NoError(t, err)
+
+			assert.True(t, IsArchive(archive))
+			objs, err := (&PathManifestReader{
+				Path: archive,
+				ReaderOptions: ReaderOptions{
+					Mapper:    mapper,
+					Namespace: "default",
+				},
+			}).Read()
+			require.NoError(t, err)
+
+			var names []string
+			for _, obj := range objs {
+				names = append(names, obj.GetName())
+				assert.Equal(t, "default", obj.GetNamespace())
+			}
+			assert.Equal(t, []string{"cm-json", "dep", "cm", "nested"}, names)
+		})
+	}
+}
+
+func TestPathManifestReader_ReadInvalidArchive(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "manifests.tar.gz")
+	require.NoError(t, ioutil.WriteFile(p, []byte("not a gzip compressed archive"), 0600))
+
+	_, err := (&PathManifestReader{Path: p}).Read()
+	assert.EqualError(t, err, `failed to read archive "`+p+`": gzip: invalid header`)
+}
+
+func writeTarGz(t *testing.T, p string, files map[string]string) string {
+	f, err := os.Create(p)
+	require.NoError(t, err)
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0600,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return p
+}
+
+func writeZip(t *testing.T, p string, files map[string]string) string {
+	f, err := os.Create(p)
+	require.NoError(t, err)
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return p
+}
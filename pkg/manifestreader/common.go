@@ -4,14 +4,16 @@
 package manifestreader
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/json"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/kustomize/kyaml/kio/filters"
@@ -117,6 +119,94 @@ func SetNamespaces(mapper meta.RESTMapper, objs []*unstructured.Unstructured,
 	return nil
 }
 
+// ExpandLists returns a new slice of Unstructured where all lists, like
+// the `kind: List` output of `kubectl get -o yaml`, are replaced by their
+// items. Lists within lists are expanded recursively. Items of typed
+// lists, like a PodList, that have no apiVersion or kind get them from the
+// list.
+func ExpandLists(objs []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	var expandedObjs []*unstructured.Unstructured
+	for _, obj := range objs {
+		if !isList(obj) {
+			expandedObjs = append(expandedObjs, obj)
+			continue
+		}
+		var items []*unstructured.Unstructured
+		err := obj.EachListItem(func(item runtime.Object) error {
+			u := item.(*unstructured.Unstructured)
+			if u.GetAPIVersion() == "" {
+				u.SetAPIVersion(obj.GetAPIVersion())
+			}
+			if u.GetKind() == "" && obj.GetKind() != "List" {
+				u.SetKind(strings.TrimSuffix(obj.GetKind(), "List"))
+			}
+			copySourceAnnotations(obj, u)
+			items = append(items, u)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		items, err = ExpandLists(items)
+		if err != nil {
+			return nil, err
+		}
+		expandedObjs = append(expandedObjs, items...)
+	}
+	return expandedObjs, nil
+}
+
+// sourceAnnotations are the kyaml annotations recording the location an
+// object was read from.
+var sourceAnnotations = []string{
+	kioutil.PathAnnotation,
+	kioutil.LegacyPathAnnotation, //nolint:staticcheck
+	kioutil.IndexAnnotation,
+	kioutil.LegacyIndexAnnotation, //nolint:staticcheck
+}
+
+// copySourceAnnotations copies the location the list was read from to
+// one of its items, so validation errors can point to the list.
+func copySourceAnnotations(list, item *unstructured.Unstructured) {
+	listAnnos := list.GetAnnotations()
+	annos := item.GetAnnotations()
+	for _, a := range sourceAnnotations {
+		if v, found := listAnnos[a]; found {
+			if annos == nil {
+				annos = make(map[string]string)
+			}
+			annos[a] = v
+		}
+	}
+	item.SetAnnotations(annos)
+}
+
+// setSourcePath records the name of the source the objects were read from in
+// the kyaml path annotations, unless it is already set. The index
+// annotation is set to the position of the object in the source if missing.
+func setSourcePath(objs []*unstructured.Unstructured, path string) {
+	for i, obj := range objs {
+		annos := obj.GetAnnotations()
+		if annos == nil {
+			annos = make(map[string]string)
+		}
+		if _, found := annos[kioutil.PathAnnotation]; !found {
+			annos[kioutil.PathAnnotation] = path
+			annos[kioutil.LegacyPathAnnotation] = path //nolint:staticcheck
+		}
+		if _, found := annos[kioutil.LegacyIndexAnnotation]; !found { //nolint:staticcheck
+			annos[kioutil.LegacyIndexAnnotation] = strconv.Itoa(i) //nolint:staticcheck
+		}
+		obj.SetAnnotations(annos)
+	}
+}
+
+// isList returns true if the object is a list of resources: its kind ends
+// with "List", and it has an items array.
+func isList(obj *unstructured.Unstructured) bool {
+	return strings.HasSuffix(obj.GetKind(), "List") && obj.IsList()
+}
+
 // FilterLocalConfig returns a new slice of Unstructured where all resources
 // with the LocalConfig annotation is filtered out.
 func FilterLocalConfig(objs []*unstructured.Unstructured) []*unstructured.Unstructured {
@@ -144,7 +234,8 @@ func RemoveAnnotations(n *yaml.RNode, annotations ...kioutil.AnnotationKey) erro
 }
 
 // KyamlNodeToUnstructured take a resource represented as a kyaml RNode and
-// turns it into an Unstructured object.
+// turns it into an Unstructured object. Integers are converted to int64, like
+// in objects decoded by the apimachinery.
 func KyamlNodeToUnstructured(n *yaml.RNode) (*unstructured.Unstructured, error) {
 	b, err := n.MarshalJSON()
 	if err != nil {
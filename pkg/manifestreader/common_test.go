@@ -5,6 +5,7 @@ package manifestreader
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/kio/filters"
 	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
 	"sigs.k8s.io/kustomize/kyaml/yaml"
@@ -262,6 +264,108 @@ func TestFilterLocalConfigs(t *testing.T) {
 	}
 }
 
+func TestExpandLists(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+
+		expected    []string
+		expectedErr string
+	}{
+		"no lists": {
+			input:    depManifest + "\n---\n" + cmManifest,
+			expected: []string{"Deployment/dep", "ConfigMap/cm"},
+		},
+		"list with other objects": {
+			input: depManifest + `
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: cm-1
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: cm-2
+`,
+			expected: []string{"Deployment/dep", "ConfigMap/cm-1", "ConfigMap/cm-2"},
+		},
+		"nested lists": {
+			input: `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: List
+  items:
+  - apiVersion: v1
+    kind: ConfigMap
+    metadata:
+      name: cm-1
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: cm-2
+`,
+			expected: []string{"ConfigMap/cm-1", "ConfigMap/cm-2"},
+		},
+		"typed list": {
+			input: `
+apiVersion: v1
+kind: ConfigMapList
+items:
+- metadata:
+    name: cm-1
+`,
+			expected: []string{"ConfigMap/cm-1"},
+		},
+		"invalid item": {
+			input: `
+apiVersion: v1
+kind: List
+metadata:
+  name: bad
+items:
+- foo
+`,
+			expectedErr: `invalid List "bad": items member is not an object: map[string]interface {}`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			// Multiple documents are read one by one, so lists are not
+			// unwrapped by the kyaml reader.
+			nodes, err := (&kio.ByteReader{
+				Reader:            strings.NewReader(tc.input),
+				DisableUnwrapping: true,
+			}).Read()
+			require.NoError(t, err)
+			var objs []*unstructured.Unstructured
+			for _, n := range nodes {
+				u, err := KyamlNodeToUnstructured(n)
+				require.NoError(t, err)
+				objs = append(objs, u)
+			}
+
+			res, err := ExpandLists(objs)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var ids []string
+			for _, obj := range res {
+				ids = append(ids, obj.GetKind()+"/"+obj.GetName())
+			}
+			assert.Equal(t, tc.expected, ids)
+		})
+	}
+}
+
 func TestRemoveAnnotations(t *testing.T) {
 	testCases := map[string]struct {
 		node                *yaml.RNode
@@ -0,0 +1,189 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultHelmCommand is the helm binary used to render charts, if the
+// HelmManifestReader does not specify one.
+const DefaultHelmCommand = "helm"
+
+// HelmManifestReader implements ManifestReader interface.
+var _ ContextManifestReader = &HelmManifestReader{}
+
+// HelmManifestReader renders a Helm chart with `helm template` and returns
+// the resulting resources. The rendered manifests go through the same
+// namespace defaulting and validation as the other readers. The release
+// namespace is the namespace from the ReaderOptions.
+//
+// The chart tests and hooks are not rendered, because the Applier applies
+// every rendered object and does not run them like helm does.
+type HelmManifestReader struct {
+	// Chart is the path to a local chart, or the name of a chart in Repo.
+	Chart string
+	// Repo is the URL of the chart repository. Optional for local charts.
+	Repo string
+	// Version of the chart. If empty, the latest version is used.
+	Version string
+	// ReleaseName is the name of the release. If empty, the base name of the
+	// Chart is used, so that the objects are rendered the same on every run.
+	ReleaseName string
+	// ValuesFiles are files with values, passed to helm in order.
+	ValuesFiles []string
+	// Values override the values from the chart and the ValuesFiles.
+	Values map[string]interface{}
+	// IncludeCRDs includes the CustomResourceDefinitions of the chart.
+	IncludeCRDs bool
+	// HelmCommand is the helm binary. If empty, DefaultHelmCommand is used.
+	HelmCommand string
+
+	ReaderOptions
+}
+
+// Read renders the chart and returns the resources.
+func (h *HelmManifestReader) Read() ([]*unstructured.Unstructured, error) {
+	return h.ReadContext(context.Background())
+}
+
+// ReadContext renders the chart and returns the resources. The helm command
+// is killed when the context is done.
+func (h *HelmManifestReader) ReadContext(ctx context.Context) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	args, cleanup, err := h.templateArgs()
+	defer cleanup()
+	if err != nil {
+		return objs, err
+	}
+
+	helm := h.HelmCommand
+	if helm == "" {
+		helm = DefaultHelmCommand
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, helm, args...) // nolint:gosec
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		// Report why the command was killed, instead of the signal.
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return objs, &HelmError{
+			Chart:  h.Chart,
+			Stderr: strings.TrimSpace(stderr.String()),
+			Err:    err,
+		}
+	}
+
+	return (&StreamManifestReader{
+		ReaderName:    "helm template",
+		Reader:        &stdout,
+		ReaderOptions: h.ReaderOptions,
+	}).Read()
+}
+
+// templateArgs returns the arguments of `helm template`. The returned
+// function removes any temporary files and must always be called.
+func (h *HelmManifestReader) templateArgs() ([]string, func(), error) {
+	cleanup := func() {}
+	if h.Chart == "" {
+		return nil, cleanup, fmt.Errorf("helm chart must be specified")
+	}
+
+	releaseName, err := h.releaseName()
+	if err != nil {
+		return nil, cleanup, err
+	}
+	args := []string{"template", releaseName, h.Chart, "--skip-tests", "--no-hooks"}
+	if h.Repo != "" {
+		args = append(args, "--repo", h.Repo)
+	}
+	if h.Version != "" {
+		args = append(args, "--version", h.Version)
+	}
+	if h.Namespace != "" {
+		args = append(args, "--namespace", h.Namespace)
+	}
+	if h.IncludeCRDs {
+		args = append(args, "--include-crds")
+	}
+	for _, f := range h.ValuesFiles {
+		args = append(args, "--values", f)
+	}
+	if len(h.Values) > 0 {
+		// Values are passed in a file, to keep their types.
+		b, err := yaml.Marshal(h.Values)
+		if err != nil {
+			return nil, cleanup, fmt.Errorf("failed to encode helm values: %w", err)
+		}
+		dir, err := ioutil.TempDir("", "cli-utils-helm-")
+		if err != nil {
+			return nil, cleanup, err
+		}
+		cleanup = func() {
+			_ = os.RemoveAll(dir)
+		}
+		valuesFile := filepath.Join(dir, "values.yaml")
+		if err := ioutil.WriteFile(valuesFile, b, 0600); err != nil {
+			return nil, cleanup, err
+		}
+		args = append(args, "--values", valuesFile)
+	}
+	return args, cleanup, nil
+}
+
+// releaseName returns the ReleaseName, or the base name of the Chart.
+// A generated name would change the rendered objects on every run.
+func (h *HelmManifestReader) releaseName() (string, error) {
+	if h.ReleaseName != "" {
+		return h.ReleaseName, nil
+	}
+	if strings.Contains(h.Chart, "://") {
+		return path.Base(h.Chart), nil
+	}
+	// The absolute path has a base name, even for a chart in ".".
+	abs, err := filepath.Abs(h.Chart)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive the helm release name: %w", err)
+	}
+	return filepath.Base(abs), nil
+}
+
+// HasHelmChart returns true if the directory contains a Helm chart.
+func HasHelmChart(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, "Chart.yaml"))
+	return err == nil && !info.IsDir()
+}
+
+// HelmError is returned by the HelmManifestReader when the chart
+// could not be rendered.
+type HelmError struct {
+	Chart  string
+	Stderr string
+	Err    error
+}
+
+func (e *HelmError) Error() string {
+	if e.Stderr == "" {
+		return fmt.Sprintf("failed to render helm chart %q: %v", e.Chart, e.Err)
+	}
+	return fmt.Sprintf("failed to render helm chart %q: %v: %s", e.Chart, e.Err, e.Stderr)
+}
+
+func (e *HelmError) Unwrap() error {
+	return e.Err
+}
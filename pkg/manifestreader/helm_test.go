@@ -0,0 +1,200 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+)
+
+// fakeHelm renders a ConfigMap with the arguments it was called with,
+// and the content of the last values file.
+var fakeHelm = `#!/bin/sh
+values=""
+prev=""
+for a in "$@"; do
+  if [ "$prev" = "--values" ]; then values="$a"; fi
+  prev="$a"
+done
+cat <<EOT
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: rendered
+data:
+  args: "$*"
+EOT
+if [ -n "$values" ]; then
+  echo "  values: |"
+  sed 's/^/    /' "$values"
+fi
+`
+
+var failingHelm = `#!/bin/sh
+echo "Error: chart \"missing\" not found" >&2
+exit 1
+`
+
+// slowHelm never renders the chart, until it is killed.
+var slowHelm = `#!/bin/sh
+exec sleep 60
+`
+
+func TestHelmManifestReader_Read(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake helm command is a shell script")
+	}
+	dir := t.TempDir()
+	helm := writeScript(t, dir, "helm", fakeHelm)
+	failing := writeScript(t, dir, "failing-helm", failingHelm)
+
+	testCases := map[string]struct {
+		reader HelmManifestReader
+
+		expectedArgs      string
+		expectedValues    string
+		expectedNamespace string
+		expectedErr       string
+	}{
+		"local chart": {
+			reader: HelmManifestReader{
+				Chart:       "./charts/demo",
+				ReleaseName: "demo",
+				HelmCommand: helm,
+			},
+			expectedArgs:      "template demo ./charts/demo --skip-tests --no-hooks --namespace default",
+			expectedNamespace: "default",
+		},
+		"repo chart with values": {
+			reader: HelmManifestReader{
+				Chart:       "nginx",
+				Repo:        "https://charts.example.com",
+				Version:     "1.2.3",
+				ReleaseName: "web",
+				ValuesFiles: []string{"prod.yaml"},
+				Values: map[string]interface{}{
+					"replicas": 3,
+				},
+				IncludeCRDs: true,
+				HelmCommand: helm,
+			},
+			expectedArgs: "template web nginx --skip-tests --no-hooks --repo https://charts.example.com " +
+				"--version 1.2.3 --namespace default --include-crds --values prod.yaml --values ",
+			expectedValues:    "replicas: 3\n",
+			expectedNamespace: "default",
+		},
+		"release name from the chart": {
+			reader: HelmManifestReader{
+				Chart:       "./charts/nginx/",
+				HelmCommand: helm,
+			},
+			expectedArgs:      "template nginx ./charts/nginx/ --skip-tests --no-hooks --namespace default",
+			expectedNamespace: "default",
+		},
+		"release name from an oci chart": {
+			reader: HelmManifestReader{
+				Chart:       "oci://registry.example.com/charts/nginx",
+				HelmCommand: helm,
+			},
+			expectedArgs:      "template nginx oci://registry.example.com/charts/nginx --skip-tests --no-hooks --namespace default",
+			expectedNamespace: "default",
+		},
+		"no chart": {
+			reader: HelmManifestReader{
+				HelmCommand: helm,
+			},
+			expectedErr: "helm chart must be specified",
+		},
+		"helm fails": {
+			reader: HelmManifestReader{
+				Chart:       "missing",
+				HelmCommand: failing,
+			},
+			expectedErr: `failed to render helm chart "missing": exit status 1: Error: chart "missing" not found`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("test-ns")
+			defer tf.Cleanup()
+
+			mapper, err := tf.ToRESTMapper()
+			require.NoError(t, err)
+
+			reader := tc.reader
+			reader.ReaderOptions = ReaderOptions{
+				Mapper:    mapper,
+				Namespace: "default",
+			}
+			objs, err := reader.Read()
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, objs, 1)
+
+			obj := objs[0]
+			assert.Equal(t, "rendered", obj.GetName())
+			assert.Equal(t, tc.expectedNamespace, obj.GetNamespace())
+			data := obj.Object["data"].(map[string]interface{})
+			assert.True(t, strings.HasPrefix(data["args"].(string), tc.expectedArgs),
+				"expected args to start with %q, got %q", tc.expectedArgs, data["args"])
+			if tc.expectedValues != "" {
+				assert.Equal(t, tc.expectedValues, data["values"])
+			}
+		})
+	}
+}
+
+func TestHelmManifestReader_ReadContext(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake helm command is a shell script")
+	}
+	reader := &HelmManifestReader{
+		Chart:       "slow",
+		ReleaseName: "slow",
+		HelmCommand: writeScript(t, t.TempDir(), "slow-helm", slowHelm),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := reader.ReadContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.EqualError(t, err, `failed to render helm chart "slow": context deadline exceeded`)
+	assert.Less(t, time.Since(start), 30*time.Second)
+}
+
+func TestHasHelmChart(t *testing.T) {
+	dir := writeManifests(t, map[string]string{"Chart.yaml": "name: demo\nversion: 0.1.0\n"})
+	defer os.RemoveAll(dir)
+	assert.True(t, HasHelmChart(dir))
+
+	reader := mReader(dir, strings.NewReader(""), ReaderOptions{})
+	if assert.IsType(t, &HelmManifestReader{}, reader) {
+		releaseName, err := reader.(*HelmManifestReader).releaseName()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Base(dir), releaseName)
+	}
+
+	assert.False(t, HasHelmChart(t.TempDir()))
+}
+
+func writeScript(t *testing.T, dir, name, content string) string {
+	p := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(p, []byte(content), 0700))
+	return p
+}
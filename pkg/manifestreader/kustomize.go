@@ -0,0 +1,75 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// KustomizeManifestReader implements ManifestReader interface.
+var _ ManifestReader = &KustomizeManifestReader{}
+
+// KustomizeManifestReader builds the kustomization in the provided
+// directory, like `kustomize build`, and returns the resulting resources.
+// The kustomization is built in-process, with the default options of
+// kustomize, so plugins are disabled and files must be within the
+// directory of the kustomization.
+type KustomizeManifestReader struct {
+	Path string
+
+	// FileSystem is the file system to read the kustomization from. If
+	// nil, the kustomization is read from disk.
+	FileSystem filesys.FileSystem
+
+	ReaderOptions
+}
+
+// Read builds the kustomization and returns the resources.
+func (k *KustomizeManifestReader) Read() ([]*unstructured.Unstructured, error) {
+	fSys := k.FileSystem
+	if fSys == nil {
+		fSys = filesys.MakeFsOnDisk()
+	}
+
+	var objs []*unstructured.Unstructured
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(fSys, k.Path)
+	if err != nil {
+		return objs, fmt.Errorf("failed to build kustomization %q: %w", k.Path, err)
+	}
+
+	for _, res := range resMap.Resources() {
+		u, err := KyamlNodeToUnstructured(&res.RNode)
+		if err != nil {
+			return objs, err
+		}
+		objs = append(objs, u)
+	}
+
+	objs, err = ExpandLists(objs)
+	if err != nil {
+		return objs, err
+	}
+	objs = FilterLocalConfig(objs)
+
+	err = SetNamespaces(k.Mapper, objs, k.Namespace, k.EnforceNamespace)
+	return objs, err
+}
+
+// HasKustomization returns true if the directory contains a kustomization
+// file, with any of the names recognized by kustomize.
+func HasKustomization(dir string) bool {
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil && !info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+)
+
+var kustomization = `
+apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+namePrefix: prod-
+commonLabels:
+  app: demo
+resources:
+- dep.yaml
+- cm.yaml
+`
+
+func TestKustomizeManifestReader_Read(t *testing.T) {
+	testCases := map[string]struct {
+		manifests        map[string]string
+		namespace        string
+		enforceNamespace bool
+
+		expectedNames      []string
+		expectedNamespaces []string
+		expectedErr        string
+	}{
+		"kustomization is built": {
+			manifests: map[string]string{
+				"kustomization.yaml": kustomization,
+				"dep.yaml":           depManifest,
+				"cm.yaml":            cmManifest,
+			},
+			namespace: "default",
+
+			expectedNames:      []string{"prod-dep", "prod-cm"},
+			expectedNamespaces: []string{"default", "default"},
+		},
+		"kustomization with namespace": {
+			manifests: map[string]string{
+				"kustomization.yaml": kustomization + "namespace: foo\n",
+				"dep.yaml":           depManifest,
+				"cm.yaml":            cmManifest,
+			},
+			namespace: "default",
+
+			expectedNames:      []string{"prod-dep", "prod-cm"},
+			expectedNamespaces: []string{"foo", "foo"},
+		},
+		"missing resource": {
+			manifests: map[string]string{
+				"kustomization.yaml": kustomization,
+				"dep.yaml":           depManifest,
+			},
+			namespace: "default",
+
+			expectedErr: "failed to build kustomization",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("test-ns")
+			defer tf.Cleanup()
+
+			mapper, err := tf.ToRESTMapper()
+			require.NoError(t, err)
+
+			dir := writeManifests(t, tc.manifests)
+			defer os.RemoveAll(dir)
+
+			objs, err := (&KustomizeManifestReader{
+				Path: dir,
+				ReaderOptions: ReaderOptions{
+					Mapper:           mapper,
+					Namespace:        tc.namespace,
+					EnforceNamespace: tc.enforceNamespace,
+				},
+			}).Read()
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var names, namespaces []string
+			for _, obj := range objs {
+				names = append(names, obj.GetName())
+				namespaces = append(namespaces, obj.GetNamespace())
+				assert.Equal(t, "demo", obj.GetLabels()["app"])
+			}
+			assert.Equal(t, tc.expectedNames, names)
+			assert.Equal(t, tc.expectedNamespaces, namespaces)
+		})
+	}
+}
+
+func TestMReader_Kustomization(t *testing.T) {
+	dir := writeManifests(t, map[string]string{
+		"kustomization.yaml": kustomization,
+		"dep.yaml":           depManifest,
+		"cm.yaml":            cmManifest,
+	})
+	defer os.RemoveAll(dir)
+
+	reader := mReader(dir, strings.NewReader(""), ReaderOptions{})
+	assert.IsType(t, &KustomizeManifestReader{}, reader)
+
+	reader = mReader(t.TempDir(), strings.NewReader(""), ReaderOptions{})
+	assert.IsType(t, &PathManifestReader{}, reader)
+}
+
+func TestHasKustomization(t *testing.T) {
+	for _, name := range []string{"kustomization.yaml", "kustomization.yml", "Kustomization"} {
+		dir := writeManifests(t, map[string]string{name: kustomization})
+		assert.True(t, HasKustomization(dir), name)
+		_ = os.RemoveAll(dir)
+	}
+
+	dir := writeManifests(t, map[string]string{"dep.yaml": depManifest})
+	defer os.RemoveAll(dir)
+	assert.False(t, HasKustomization(dir))
+	assert.False(t, HasKustomization(filepath.Join(dir, "missing")))
+}
+
+func writeManifests(t *testing.T, manifests map[string]string) string {
+	dir, err := ioutil.TempDir("", "kustomize-reader-test")
+	require.NoError(t, err)
+	for filename, content := range manifests {
+		err := ioutil.WriteFile(filepath.Join(dir, filename), []byte(content), 0600)
+		require.NoError(t, err)
+	}
+	return dir
+}
@@ -5,6 +5,7 @@ package manifestreader
 
 import (
 	"io"
+	"strings"
 
 	"k8s.io/kubectl/pkg/cmd/util"
 )
@@ -28,28 +29,41 @@ func NewManifestLoader(f util.Factory) ManifestLoader {
 }
 
 func (f *manifestLoader) ManifestReader(reader io.Reader, path string) (ManifestReader, error) {
+	readerOptions, err := NewReaderOptions(f.factory)
+	if err != nil {
+		return nil, err
+	}
+	return mReader(path, reader, readerOptions), nil
+}
+
+// NewReaderOptions returns the ReaderOptions of the factory.
+func NewReaderOptions(f util.Factory) (ReaderOptions, error) {
 	// Fetch the namespace from the configloader. The source of this
 	// either the namespace flag or the context. If the namespace is provided
 	// with the flag, enforceNamespace will be true. In this case, it is
 	// an error if any of the resources in the package has a different
 	// namespace set.
-	namespace, enforceNamespace, err := f.factory.ToRawKubeConfigLoader().Namespace()
+	namespace, enforceNamespace, err := f.ToRawKubeConfigLoader().Namespace()
 	if err != nil {
-		return nil, err
+		return ReaderOptions{}, err
 	}
 
-	mapper, err := f.factory.ToRESTMapper()
+	mapper, err := f.ToRESTMapper()
 	if err != nil {
-		return nil, err
+		return ReaderOptions{}, err
 	}
 
-	readerOptions := ReaderOptions{
+	return ReaderOptions{
 		Mapper:           mapper,
 		Namespace:        namespace,
 		EnforceNamespace: enforceNamespace,
-	}
+	}, nil
+}
 
-	return mReader(path, reader, readerOptions), nil
+// IsRemote returns true if the path is an OCI reference or an HTTPS URL,
+// which the ManifestLoader fetches instead of reading from disk.
+func IsRemote(path string) bool {
+	return strings.HasPrefix(path, OCIScheme) || strings.HasPrefix(path, "https://")
 }
 
 // mReader returns the ManifestReader based in the input args
@@ -62,6 +76,30 @@ func mReader(path string, reader io.Reader, readerOptions ReaderOptions) Manifes
 			Reader:        reader,
 			ReaderOptions: readerOptions,
 		}
+	} else if strings.HasPrefix(path, OCIScheme) {
+		// Fetch OCI artifacts from the registry
+		mReader = &OCIManifestReader{
+			Reference:     path,
+			ReaderOptions: readerOptions,
+		}
+	} else if strings.HasPrefix(path, "https://") {
+		// Fetch manifest bundles from HTTPS URLs
+		mReader = &URLManifestReader{
+			URL:           path,
+			ReaderOptions: readerOptions,
+		}
+	} else if HasKustomization(path) {
+		// Build kustomize packages, like `kustomize build`
+		mReader = &KustomizeManifestReader{
+			Path:          path,
+			ReaderOptions: readerOptions,
+		}
+	} else if HasHelmChart(path) {
+		// Render Helm charts, like `helm template`, with the default values
+		mReader = &HelmManifestReader{
+			Chart:         path,
+			ReaderOptions: readerOptions,
+		}
 	} else {
 		mReader = &PathManifestReader{
 			Path:          path,
@@ -4,6 +4,8 @@
 package manifestreader
 
 import (
+	"context"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -14,6 +16,22 @@ type ManifestReader interface {
 	Read() ([]*unstructured.Unstructured, error)
 }
 
+// ContextManifestReader is a ManifestReader that fetches the manifests from
+// a remote source, and stops when the context is done.
+type ContextManifestReader interface {
+	ManifestReader
+	ReadContext(ctx context.Context) ([]*unstructured.Unstructured, error)
+}
+
+// ReadContext reads the manifests with the reader, passing the context to
+// readers that implement ContextManifestReader.
+func ReadContext(ctx context.Context, r ManifestReader) ([]*unstructured.Unstructured, error) {
+	if cr, ok := r.(ContextManifestReader); ok && ctx != nil {
+		return cr.ReadContext(ctx)
+	}
+	return r.Read()
+}
+
 // ReaderOptions defines the shared inputs for the different
 // implementations of the ManifestReader interface.
 type ReaderOptions struct {
@@ -0,0 +1,157 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// OCIScheme is the prefix of OCI artifact references passed to the
+// ManifestLoader.
+const OCIScheme = "oci://"
+
+// OCIManifestReader implements ManifestReader interface.
+var _ ContextManifestReader = &OCIManifestReader{}
+
+// OCIManifestReader fetches a manifest bundle stored as an OCI artifact in
+// a container registry, and returns the resources. Each layer of the
+// artifact is either a stream of YAML documents, or a tar, gzip compressed
+// tar, or zip archive of manifest files, which is read like the archives of
+// the PathManifestReader.
+//
+// The Reference is in the usual "registry/repository:tag" format, and can
+// pin the artifact with a digest, as in "registry/repository@sha256:<hex>".
+// The digests of the manifest and the layers are always verified.
+type OCIManifestReader struct {
+	Reference string
+	// Keychain resolves the credentials of the registry. If nil,
+	// authn.DefaultKeychain is used, which reads the docker config file
+	// and runs its credential helpers.
+	Keychain authn.Keychain
+	// Transport is the HTTP transport. If nil, remote.DefaultTransport is
+	// used.
+	Transport http.RoundTripper
+	// PlainHTTP uses HTTP instead of HTTPS to connect to the registry.
+	PlainHTTP bool
+
+	ReaderOptions
+}
+
+// Read fetches the manifests and returns them as Unstructured objects.
+func (o *OCIManifestReader) Read() ([]*unstructured.Unstructured, error) {
+	return o.ReadContext(context.Background())
+}
+
+// ReadContext fetches the manifests and returns them as Unstructured
+// objects. The requests to the registry stop when the context is done.
+func (o *OCIManifestReader) ReadContext(ctx context.Context) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	var nameOpts []name.Option
+	if o.PlainHTTP {
+		nameOpts = append(nameOpts, name.Insecure)
+	}
+	ref, err := name.ParseReference(strings.TrimPrefix(o.Reference, OCIScheme), nameOpts...)
+	if err != nil {
+		return objs, fmt.Errorf("invalid OCI reference %q: %w", o.Reference, err)
+	}
+	keychain := o.Keychain
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+	remoteOpts := []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(keychain),
+	}
+	if o.Transport != nil {
+		remoteOpts = append(remoteOpts, remote.WithTransport(o.Transport))
+	}
+
+	img, err := remote.Image(ref, remoteOpts...)
+	if err != nil {
+		return objs, fmt.Errorf("failed to fetch %s: %w", o.Reference, err)
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return objs, fmt.Errorf("failed to fetch %s: %w", o.Reference, err)
+	}
+
+	var bundle bytes.Buffer
+	for _, layer := range layers {
+		digest, err := layer.Digest()
+		if err != nil {
+			return objs, err
+		}
+		mediaType, err := layer.MediaType()
+		if err != nil {
+			return objs, err
+		}
+		// The digest of the layer is verified when it is read to the end.
+		rc, err := layer.Compressed()
+		if err != nil {
+			return objs, fmt.Errorf("failed to fetch layer %s: %w", digest, err)
+		}
+		b, err := readLimited(rc, digest.String())
+		rc.Close()
+		if err != nil {
+			return objs, err
+		}
+		content, err := layerContent(string(mediaType), b)
+		if err != nil {
+			return objs, fmt.Errorf("failed to read layer %s: %w", digest, err)
+		}
+		bundle.WriteString("\n---\n")
+		bundle.Write(content)
+	}
+
+	return (&StreamManifestReader{
+		ReaderName:    o.Reference,
+		Reader:        &bundle,
+		ReaderOptions: o.ReaderOptions,
+	}).Read()
+}
+
+// layerContent returns the YAML documents in a layer. The manifest files
+// of archive layers are joined in the order of their path.
+func layerContent(mediaType string, b []byte) ([]byte, error) {
+	var files []archiveFile
+	var err error
+	switch {
+	case strings.Contains(mediaType, "tar") && strings.Contains(mediaType, "gzip"):
+		files, err = extractTarGz(bytes.NewReader(b))
+	case strings.Contains(mediaType, "tar"):
+		files, err = extractTar(bytes.NewReader(b))
+	case strings.Contains(mediaType, "zip"):
+		var zr *zip.Reader
+		zr, err = zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		if err == nil {
+			files, err = extractZip(zr)
+		}
+	default:
+		return b, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].name < files[j].name
+	})
+	var content bytes.Buffer
+	for _, f := range files {
+		content.WriteString("\n---\n")
+		content.Write(f.content)
+	}
+	return content.Bytes(), nil
+}
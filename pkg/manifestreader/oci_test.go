@@ -0,0 +1,166 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+)
+
+func TestOCIManifestReader_Read(t *testing.T) {
+	dir := t.TempDir()
+	archive, err := ioutil.ReadFile(writeTarGz(t, filepath.Join(dir, "manifests.tar.gz"), archiveManifests))
+	require.NoError(t, err)
+	layers := []string{depManifest, string(archive)}
+	manifest := fmt.Sprintf(`{"schemaVersion":2,"mediaType":%q,`+
+		`"config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":%q,"size":2},"layers":[`+
+		`{"mediaType":"application/yaml","digest":%q,"size":%d},`+
+		`{"mediaType":"application/vnd.oci.image.layer.v1.tar+gzip","digest":%q,"size":%d}]}`,
+		types.OCIManifestSchema1, sha256Digest("{}"), sha256Digest(layers[0]), len(layers[0]),
+		sha256Digest(layers[1]), len(layers[1]))
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			user, pass, ok := r.BasicAuth()
+			if !ok || user != "user" || pass != "pass" ||
+				r.URL.Query().Get("scope") != "repository:org/app:pull" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token":"t0ken"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer t0ken" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(
+				`Bearer realm="%s/token",service="registry",scope="repository:org/app:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/":
+		case "/v2/org/app/manifests/v1", "/v2/org/app/manifests/" + sha256Digest(manifest):
+			w.Header().Set("Content-Type", string(types.OCIManifestSchema1))
+			_, _ = w.Write([]byte(manifest))
+		case "/v2/org/app/blobs/" + sha256Digest(layers[0]):
+			_, _ = w.Write([]byte(layers[0]))
+		case "/v2/org/app/blobs/" + sha256Digest(layers[1]):
+			_, _ = w.Write([]byte(layers[1]))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	registry := strings.TrimPrefix(server.URL, "http://")
+
+	auth := base64.StdEncoding.EncodeToString([]byte("user:pass"))
+	dockerConfig := writeDockerConfig(t, fmt.Sprintf(`{"auths":{%q:{"auth":%q}}}`, registry, auth))
+	noAuthConfig := writeDockerConfig(t, `{}`)
+	helperConfig := writeDockerConfig(t, fmt.Sprintf(`{"credHelpers":{%q:"fake"}}`, registry))
+	helperDir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(helperDir, "docker-credential-fake"),
+		[]byte("#!/bin/sh\necho '{\"Username\":\"user\",\"Secret\":\"pass\"}'\n"), 0700))
+	t.Setenv("PATH", helperDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	// The docker config in the home directory takes precedence.
+	t.Setenv("HOME", t.TempDir())
+
+	testCases := map[string]struct {
+		reference    string
+		dockerConfig string
+
+		expectedNames []string
+		expectedErr   string
+	}{
+		"tag": {
+			reference:     OCIScheme + registry + "/org/app:v1",
+			dockerConfig:  dockerConfig,
+			expectedNames: []string{"dep", "cm-json", "dep", "cm", "nested"},
+		},
+		"pinned digest": {
+			reference:     OCIScheme + registry + "/org/app@" + sha256Digest(manifest),
+			dockerConfig:  dockerConfig,
+			expectedNames: []string{"dep", "cm-json", "dep", "cm", "nested"},
+		},
+		"credential helper": {
+			reference:     OCIScheme + registry + "/org/app:v1",
+			dockerConfig:  helperConfig,
+			expectedNames: []string{"dep", "cm-json", "dep", "cm", "nested"},
+		},
+		"unknown tag": {
+			reference:    OCIScheme + registry + "/org/app:v2",
+			dockerConfig: dockerConfig,
+			expectedErr:  "unexpected status code 404 Not Found",
+		},
+		"no credentials": {
+			reference:    OCIScheme + registry + "/org/app:v1",
+			dockerConfig: noAuthConfig,
+			expectedErr:  "unexpected status code 401 Unauthorized",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			t.Setenv("DOCKER_CONFIG", tc.dockerConfig)
+			tf := cmdtesting.NewTestFactory().WithNamespace("test-ns")
+			defer tf.Cleanup()
+
+			mapper, err := tf.ToRESTMapper()
+			require.NoError(t, err)
+
+			objs, err := (&OCIManifestReader{
+				Reference: tc.reference,
+				PlainHTTP: true,
+				ReaderOptions: ReaderOptions{
+					Mapper:    mapper,
+					Namespace: "default",
+				},
+			}).ReadContext(context.Background())
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var names []string
+			for _, obj := range objs {
+				names = append(names, obj.GetName())
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestOCIManifestReader_ReadCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := (&OCIManifestReader{
+		Reference: OCIScheme + "registry.example.com/org/app:v1",
+		Keychain:  authn.NewMultiKeychain(),
+	}).ReadContext(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), context.Canceled.Error())
+}
+
+// writeDockerConfig writes the docker config file in a new directory, and
+// returns the directory.
+func writeDockerConfig(t *testing.T, config string) string {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(config), 0600))
+	return dir
+}
@@ -7,6 +7,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
 )
 
 // PathManifestReader implements ManifestReader interface.
@@ -14,7 +15,9 @@ var _ ManifestReader = &PathManifestReader{}
 
 // PathManifestReader reads manifests from the provided path
 // and returns them as Info objects. The returned Infos will not have
-// client or mapping set.
+// client or mapping set. If the path is a .tar.gz, .tgz or .zip archive,
+// the YAML and JSON files it contains are read in memory, ordered by
+// their path in the archive.
 type PathManifestReader struct {
 	Path string
 
@@ -24,15 +27,28 @@ type PathManifestReader struct {
 // Read reads the manifests and returns them as Info objects.
 func (p *PathManifestReader) Read() ([]*unstructured.Unstructured, error) {
 	var objs []*unstructured.Unstructured
-	nodes, err := (&kio.LocalPackageReader{
-		PackagePath: p.Path,
-	}).Read()
+	var nodes []*yaml.RNode
+	var err error
+	if IsArchive(p.Path) {
+		nodes, err = readArchive(p.Path)
+	} else {
+		nodes, err = (&kio.LocalPackageReader{
+			PackagePath: p.Path,
+		}).Read()
+	}
 	if err != nil {
 		return objs, err
 	}
 
+	return nodesToObjects(nodes, p.ReaderOptions)
+}
+
+// nodesToObjects converts the nodes read from manifest files to
+// Unstructured objects, and sets their namespace.
+func nodesToObjects(nodes []*yaml.RNode, opts ReaderOptions) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
 	for _, n := range nodes {
-		err = RemoveAnnotations(n, kioutil.IndexAnnotation)
+		err := RemoveAnnotations(n, kioutil.IndexAnnotation)
 		if err != nil {
 			return objs, err
 		}
@@ -43,8 +59,12 @@ func (p *PathManifestReader) Read() ([]*unstructured.Unstructured, error) {
 		objs = append(objs, u)
 	}
 
+	objs, err := ExpandLists(objs)
+	if err != nil {
+		return objs, err
+	}
 	objs = FilterLocalConfig(objs)
 
-	err = SetNamespaces(p.Mapper, objs, p.Namespace, p.EnforceNamespace)
+	err = SetNamespaces(opts.Mapper, objs, opts.Namespace, opts.EnforceNamespace)
 	return objs, err
 }
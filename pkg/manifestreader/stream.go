@@ -4,9 +4,14 @@
 package manifestreader
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
 )
@@ -16,7 +21,9 @@ var _ ManifestReader = &StreamManifestReader{}
 
 // StreamManifestReader reads manifest from the provided io.Reader
 // and returns them as Info objects. The returned Infos will not have
-// client or mapping set.
+// client or mapping set. The input is either a stream of YAML documents,
+// or a stream of JSON objects, like newline-delimited JSON, which is
+// detected from the first character of the input.
 type StreamManifestReader struct {
 	ReaderName string
 	Reader     io.Reader
@@ -26,9 +33,39 @@ type StreamManifestReader struct {
 
 // Read reads the manifests and returns them as Info objects.
 func (r *StreamManifestReader) Read() ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	reader := bufio.NewReader(r.Reader)
+	isJSON, err := isJSONStream(reader)
+	if err != nil {
+		return objs, err
+	}
+	if isJSON {
+		objs, err = readJSONStream(reader)
+	} else {
+		objs, err = readYAMLStream(reader)
+	}
+	if err != nil {
+		return objs, err
+	}
+	if r.ReaderName != "" {
+		setSourcePath(objs, r.ReaderName)
+	}
+
+	objs, err = ExpandLists(objs)
+	if err != nil {
+		return objs, err
+	}
+	objs = FilterLocalConfig(objs)
+
+	err = SetNamespaces(r.Mapper, objs, r.Namespace, r.EnforceNamespace)
+	return objs, err
+}
+
+// readYAMLStream reads a stream of YAML documents.
+func readYAMLStream(r io.Reader) ([]*unstructured.Unstructured, error) {
 	var objs []*unstructured.Unstructured
 	nodes, err := (&kio.ByteReader{
-		Reader: r.Reader,
+		Reader: r,
 	}).Read()
 	if err != nil {
 		return objs, err
@@ -45,9 +82,51 @@ func (r *StreamManifestReader) Read() ([]*unstructured.Unstructured, error) {
 		}
 		objs = append(objs, u)
 	}
+	return objs, nil
+}
 
-	objs = FilterLocalConfig(objs)
+// readJSONStream reads a stream of JSON objects, separated by any
+// whitespace. Integers are converted to int64, like in the objects read from
+// YAML.
+func readJSONStream(r io.Reader) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	decoder := json.NewDecoder(r)
+	for i := 0; ; i++ {
+		var raw json.RawMessage
+		err := decoder.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		var m map[string]interface{}
+		if err == nil {
+			err = utiljson.Unmarshal(raw, &m)
+		}
+		if err != nil {
+			return objs, fmt.Errorf("invalid JSON object [%d]: %w", i, err)
+		}
+		if m == nil {
+			continue
+		}
+		objs = append(objs, &unstructured.Unstructured{Object: m})
+	}
+}
 
-	err = SetNamespaces(r.Mapper, objs, r.Namespace, r.EnforceNamespace)
-	return objs, err
+// isJSONStream returns true if the first non-whitespace character of the
+// input starts a JSON object. The whitespace is consumed.
+func isJSONStream(r *bufio.Reader) (bool, error) {
+	for {
+		b, err := r.Peek(1)
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			_, _ = r.ReadByte()
+		default:
+			return b[0] == '{', nil
+		}
+	}
 }
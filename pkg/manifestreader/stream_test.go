@@ -8,7 +8,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"sigs.k8s.io/cli-utils/pkg/object"
 )
 
 func TestStreamManifestReader_Read(t *testing.T) {
@@ -20,6 +23,7 @@ func TestStreamManifestReader_Read(t *testing.T) {
 
 		infosCount int
 		namespaces []string
+		sources    []string
 	}{
 		"namespace should be set if not already present": {
 			manifests:        depManifest,
@@ -28,6 +32,7 @@ func TestStreamManifestReader_Read(t *testing.T) {
 
 			infosCount: 1,
 			namespaces: []string{"foo"},
+			sources:    []string{"testReader[0]"},
 		},
 		"multiple resources": {
 			manifests:        depManifest + "\n---\n" + cmManifest,
@@ -36,6 +41,40 @@ func TestStreamManifestReader_Read(t *testing.T) {
 
 			infosCount: 2,
 			namespaces: []string{"bar", "bar"},
+			sources:    []string{"testReader[0]", "testReader[1]"},
+		},
+		"newline-delimited JSON": {
+			manifests: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-1"}}
+{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-2","namespace":"foo"}}
+`,
+			namespace:        "bar",
+			enforceNamespace: false,
+
+			infosCount: 2,
+			namespaces: []string{"bar", "foo"},
+			sources:    []string{"testReader[0]", "testReader[1]"},
+		},
+		"indented JSON objects": {
+			manifests: `
+{
+  "apiVersion": "apps/v1",
+  "kind": "Deployment",
+  "metadata": {"name": "dep"}
+}
+{
+  "apiVersion": "v1",
+  "kind": "List",
+  "items": [
+    {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm"}}
+  ]
+}
+`,
+			namespace:        "bar",
+			enforceNamespace: true,
+
+			infosCount: 2,
+			namespaces: []string{"bar", "bar"},
+			sources:    []string{"testReader[0]", "testReader[1]"},
 		},
 	}
 
@@ -67,7 +106,65 @@ func TestStreamManifestReader_Read(t *testing.T) {
 
 			for i, obj := range objs {
 				assert.Equal(t, tc.namespaces[i], obj.GetNamespace())
+				assert.Equal(t, tc.sources[i], object.UnstructuredSource(obj))
 			}
 		})
 	}
 }
+
+func TestStreamManifestReader_ReadInvalidJSON(t *testing.T) {
+	_, err := (&StreamManifestReader{
+		ReaderName: "testReader",
+		Reader:     strings.NewReader(`{"kind":"ConfigMap"}` + "\n" + `{"kind":`),
+	}).Read()
+	assert.EqualError(t, err, "invalid JSON object [1]: unexpected EOF")
+}
+
+func TestStreamManifestReader_ReadNumbers(t *testing.T) {
+	testCases := map[string]string{
+		"yaml": `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  replicas: 3
+  progressDeadlineSeconds: 1.5
+`,
+		"newline-delimited JSON": `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"dep"},` +
+			`"spec":{"replicas":3,"progressDeadlineSeconds":1.5}}
+`,
+	}
+
+	for tn, manifests := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("test-ns")
+			defer tf.Cleanup()
+
+			mapper, err := tf.ToRESTMapper()
+			require.NoError(t, err)
+
+			objs, err := (&StreamManifestReader{
+				ReaderName: "testReader",
+				Reader:     strings.NewReader(manifests),
+				ReaderOptions: ReaderOptions{
+					Mapper:    mapper,
+					Namespace: "default",
+				},
+			}).Read()
+			require.NoError(t, err)
+			require.Len(t, objs, 1)
+
+			// Integers are int64, like in objects decoded by the
+			// apimachinery, whatever the format of the input.
+			replicas, found, err := unstructured.NestedInt64(objs[0].Object, "spec", "replicas")
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, int64(3), replicas)
+			deadline, found, err := unstructured.NestedFloat64(objs[0].Object, "spec", "progressDeadlineSeconds")
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, 1.5, deadline)
+		})
+	}
+}
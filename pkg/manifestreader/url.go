@@ -0,0 +1,164 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// MaxRemoteManifestSize is the maximum size, in bytes, of a manifest
+// bundle fetched by the URLManifestReader or the OCIManifestReader.
+const MaxRemoteManifestSize = 32 << 20
+
+// URLManifestReader implements ManifestReader interface.
+var _ ContextManifestReader = &URLManifestReader{}
+
+// URLManifestReader fetches a manifest bundle, a stream of YAML documents,
+// from an HTTPS URL and returns the resources.
+type URLManifestReader struct {
+	URL string
+	// Digest pins the content of the bundle, in the "sha256:<hex>" format.
+	// If set, the bundle is rejected if its digest does not match.
+	Digest string
+	// Header is added to the request, e.g. for authorization.
+	Header http.Header
+	// Client is the HTTP client. If nil, http.DefaultClient is used.
+	Client *http.Client
+	// AllowInsecure allows fetching from plain HTTP URLs.
+	AllowInsecure bool
+
+	ReaderOptions
+}
+
+// Read fetches the manifests and returns them as Unstructured objects.
+func (u *URLManifestReader) Read() ([]*unstructured.Unstructured, error) {
+	return u.ReadContext(context.Background())
+}
+
+// ReadContext fetches the manifests and returns them as Unstructured
+// objects. The request stops when the context is done.
+func (u *URLManifestReader) ReadContext(ctx context.Context) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	parsed, err := url.Parse(u.URL)
+	if err != nil {
+		return objs, fmt.Errorf("invalid manifest URL %q: %w", u.URL, err)
+	}
+	if parsed.Scheme != "https" && !(parsed.Scheme == "http" && u.AllowInsecure) {
+		return objs, fmt.Errorf("invalid manifest URL %q: scheme must be https", u.URL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.URL, nil)
+	if err != nil {
+		return objs, err
+	}
+	for key, values := range u.Header {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+	b, err := fetch(httpClient(u.Client), req)
+	if err != nil {
+		return objs, err
+	}
+	if u.Digest != "" {
+		if err := verifyDigest(b, u.Digest); err != nil {
+			return objs, err
+		}
+	}
+
+	return (&StreamManifestReader{
+		ReaderName:    u.URL,
+		Reader:        bytes.NewReader(b),
+		ReaderOptions: u.ReaderOptions,
+	}).Read()
+}
+
+// httpClient returns the client, or http.DefaultClient if it is nil.
+func httpClient(c *http.Client) *http.Client {
+	if c == nil {
+		return http.DefaultClient
+	}
+	return c
+}
+
+// fetch sends the request and returns the body of the response. It is an
+// error if the response is not 200 OK, or if the body is larger than
+// MaxRemoteManifestSize.
+func fetch(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", req.URL, err)
+	}
+	return readResponse(resp)
+}
+
+// readResponse returns the body of the response, and closes it. It is an
+// error if the response is not 200 OK.
+func readResponse(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &FetchError{URL: resp.Request.URL.String(), StatusCode: resp.StatusCode}
+	}
+	return readLimited(resp.Body, resp.Request.URL.String())
+}
+
+// readLimited reads at most MaxRemoteManifestSize bytes from r.
+func readLimited(r io.Reader, name string) ([]byte, error) {
+	b, err := ioutil.ReadAll(io.LimitReader(r, MaxRemoteManifestSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if len(b) > MaxRemoteManifestSize {
+		return nil, fmt.Errorf("failed to read %s: larger than %d bytes", name, MaxRemoteManifestSize)
+	}
+	return b, nil
+}
+
+// verifyDigest returns a DigestMismatchError if the sha256 digest of the
+// content does not match the expected digest.
+func verifyDigest(b []byte, expected string) error {
+	if !strings.HasPrefix(expected, "sha256:") {
+		return fmt.Errorf("unsupported digest %q: must be sha256:<hex>", expected)
+	}
+	sum := sha256.Sum256(b)
+	actual := "sha256:" + hex.EncodeToString(sum[:])
+	if actual != strings.ToLower(expected) {
+		return &DigestMismatchError{Expected: expected, Actual: actual}
+	}
+	return nil
+}
+
+// FetchError is returned when a remote manifest source responds with an
+// unexpected HTTP status.
+type FetchError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *FetchError) Error() string {
+	return fmt.Sprintf("failed to fetch %s: %d %s", e.URL, e.StatusCode,
+		http.StatusText(e.StatusCode))
+}
+
+// DigestMismatchError is returned when the content fetched from a remote
+// manifest source does not match the pinned digest.
+type DigestMismatchError struct {
+	Expected string
+	Actual   string
+}
+
+func (e *DigestMismatchError) Error() string {
+	return fmt.Sprintf("digest mismatch: expected %s, got %s", e.Expected, e.Actual)
+}
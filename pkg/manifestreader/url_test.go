@@ -0,0 +1,145 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+)
+
+func TestURLManifestReader_Read(t *testing.T) {
+	bundle := depManifest + "\n---\n" + cmManifest
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bundle.yaml":
+			_, _ = w.Write([]byte(bundle))
+		case "/private.yaml":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(cmManifest))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	testCases := map[string]struct {
+		reader URLManifestReader
+
+		expectedNames []string
+		expectedErr   string
+	}{
+		"bundle": {
+			reader: URLManifestReader{
+				URL: server.URL + "/bundle.yaml",
+			},
+			expectedNames: []string{"dep", "cm"},
+		},
+		"pinned digest": {
+			reader: URLManifestReader{
+				URL:    server.URL + "/bundle.yaml",
+				Digest: sha256Digest(bundle),
+			},
+			expectedNames: []string{"dep", "cm"},
+		},
+		"digest mismatch": {
+			reader: URLManifestReader{
+				URL:    server.URL + "/bundle.yaml",
+				Digest: sha256Digest(cmManifest),
+			},
+			expectedErr: "digest mismatch: expected " + sha256Digest(cmManifest) +
+				", got " + sha256Digest(bundle),
+		},
+		"header": {
+			reader: URLManifestReader{
+				URL:    server.URL + "/private.yaml",
+				Header: http.Header{"Authorization": []string{"Bearer secret"}},
+			},
+			expectedNames: []string{"cm"},
+		},
+		"not found": {
+			reader: URLManifestReader{
+				URL: server.URL + "/missing.yaml",
+			},
+			expectedErr: "failed to fetch " + server.URL + "/missing.yaml: 404 Not Found",
+		},
+		"plain http": {
+			reader: URLManifestReader{
+				URL: "http://example.com/bundle.yaml",
+			},
+			expectedErr: `invalid manifest URL "http://example.com/bundle.yaml": scheme must be https`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("test-ns")
+			defer tf.Cleanup()
+
+			mapper, err := tf.ToRESTMapper()
+			require.NoError(t, err)
+
+			reader := tc.reader
+			reader.Client = server.Client()
+			reader.ReaderOptions = ReaderOptions{
+				Mapper:    mapper,
+				Namespace: "default",
+			}
+			objs, err := reader.Read()
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var names []string
+			for _, obj := range objs {
+				names = append(names, obj.GetName())
+				assert.Equal(t, "default", obj.GetNamespace())
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestURLManifestReader_ReadContext(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Block until the request is cancelled.
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	reader := &URLManifestReader{
+		URL:    server.URL + "/bundle.yaml",
+		Client: server.Client(),
+	}
+	_, err := reader.ReadContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMReader_Remote(t *testing.T) {
+	reader := mReader("https://example.com/bundle.yaml", strings.NewReader(""), ReaderOptions{})
+	assert.IsType(t, &URLManifestReader{}, reader)
+
+	reader = mReader("oci://ghcr.io/org/app:v1", strings.NewReader(""), ReaderOptions{})
+	assert.IsType(t, &OCIManifestReader{}, reader)
+}
+
+func sha256Digest(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
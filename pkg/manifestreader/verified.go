@@ -0,0 +1,109 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apply/verify"
+)
+
+// VerifiedManifestReader implements ManifestReader interface.
+var _ ContextManifestReader = &VerifiedManifestReader{}
+
+// VerifiedManifestReader reads a manifest file or archive in memory, checks
+// its signatures with the Verifiers, and returns the resources parsed from
+// the verified content. Since the objects are parsed from the same bytes
+// that were verified, the file can not be changed between the verification
+// and the read.
+//
+// If the Path is "-", the manifests are read from the Reader. Directories
+// are not supported, since a detached signature is made for a single file.
+type VerifiedManifestReader struct {
+	Path   string
+	Reader io.Reader
+	// Verifiers check the signatures of the content. The read fails if any
+	// of them fails.
+	Verifiers []verify.Verifier
+	// Results are the results of the Verifiers, set by a successful read.
+	// They can be passed to the Applier with ApplierOptions.Verified.
+	Results []verify.Result
+
+	ReaderOptions
+}
+
+// Read verifies the manifests and returns them as Unstructured objects.
+func (v *VerifiedManifestReader) Read() ([]*unstructured.Unstructured, error) {
+	return v.ReadContext(context.Background())
+}
+
+// ReadContext verifies the manifests and returns them as Unstructured
+// objects. The context is passed to the Verifiers.
+func (v *VerifiedManifestReader) ReadContext(ctx context.Context) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	v.Results = nil
+	source, content, err := v.content()
+	if err != nil {
+		return objs, err
+	}
+
+	var results []verify.Result
+	for _, verifier := range v.Verifiers {
+		result, err := verifier.Verify(ctx, source, content)
+		if err != nil {
+			return objs, err
+		}
+		results = append(results, result)
+	}
+
+	if IsArchive(v.Path) {
+		nodes, err := readArchiveBytes(v.Path, content)
+		if err != nil {
+			return objs, err
+		}
+		objs, err = nodesToObjects(nodes, v.ReaderOptions)
+		if err != nil {
+			return objs, err
+		}
+	} else {
+		objs, err = (&StreamManifestReader{
+			ReaderName:    source,
+			Reader:        bytes.NewReader(content),
+			ReaderOptions: v.ReaderOptions,
+		}).Read()
+		if err != nil {
+			return objs, err
+		}
+	}
+	v.Results = results
+	return objs, nil
+}
+
+// content returns the name of the source and its content.
+func (v *VerifiedManifestReader) content() (string, []byte, error) {
+	if v.Path == "-" {
+		b, err := readLimited(v.Reader, "stdin")
+		return "stdin", b, err
+	}
+	f, err := os.Open(v.Path)
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", nil, err
+	}
+	if info.IsDir() {
+		return "", nil, fmt.Errorf("can not verify the signature of directory %q: "+
+			"a manifest file or archive is required", v.Path)
+	}
+	b, err := readLimited(f, v.Path)
+	return v.Path, b, err
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"sigs.k8s.io/cli-utils/pkg/apply/verify"
+)
+
+// fakeVerifier records the content it verifies.
+type fakeVerifier struct {
+	err     error
+	content []byte
+}
+
+func (f *fakeVerifier) Verify(_ context.Context, source string, content []byte) (verify.Result, error) {
+	f.content = content
+	result := verify.Result{
+		Source: source,
+		Method: "fake",
+		Signer: "test-key",
+	}
+	if f.err != nil {
+		return result, &verify.Error{Source: source, Method: "fake", Err: f.err}
+	}
+	return result, nil
+}
+
+func TestVerifiedManifestReader_Read(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "manifests.yaml")
+	manifest := depManifest + "\n---\n" + cmManifest
+	require.NoError(t, ioutil.WriteFile(manifestPath, []byte(manifest), 0600))
+	archivePath := writeTarGz(t, filepath.Join(dir, "manifests.tar.gz"), archiveManifests)
+	archive, err := ioutil.ReadFile(archivePath)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		path      string
+		stdin     string
+		verifyErr error
+
+		expectedContent string
+		expectedSource  string
+		expectedNames   []string
+		expectedErr     string
+	}{
+		"manifest file": {
+			path:            manifestPath,
+			expectedContent: manifest,
+			expectedSource:  manifestPath,
+			expectedNames:   []string{"dep", "cm"},
+		},
+		"stdin": {
+			path:            "-",
+			stdin:           manifest,
+			expectedContent: manifest,
+			expectedSource:  "stdin",
+			expectedNames:   []string{"dep", "cm"},
+		},
+		"archive": {
+			path:            archivePath,
+			expectedContent: string(archive),
+			expectedSource:  archivePath,
+			expectedNames:   []string{"cm-json", "dep", "cm", "nested"},
+		},
+		"directory": {
+			path: dir,
+			expectedErr: `can not verify the signature of directory "` + dir + `": ` +
+				"a manifest file or archive is required",
+		},
+		"failed verification": {
+			path:            manifestPath,
+			verifyErr:       errors.New("bad signature"),
+			expectedContent: manifest,
+			expectedErr:     `failed to verify fake signature of "` + manifestPath + `": bad signature`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("test-ns")
+			defer tf.Cleanup()
+
+			mapper, err := tf.ToRESTMapper()
+			require.NoError(t, err)
+
+			verifier := &fakeVerifier{err: tc.verifyErr}
+			reader := &VerifiedManifestReader{
+				Path:      tc.path,
+				Reader:    strings.NewReader(tc.stdin),
+				Verifiers: []verify.Verifier{verifier},
+				ReaderOptions: ReaderOptions{
+					Mapper:    mapper,
+					Namespace: "default",
+				},
+			}
+			objs, err := reader.Read()
+			assert.Equal(t, tc.expectedContent, string(verifier.content))
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				assert.Empty(t, reader.Results)
+				return
+			}
+			require.NoError(t, err)
+
+			var names []string
+			for _, obj := range objs {
+				names = append(names, obj.GetName())
+			}
+			assert.Equal(t, tc.expectedNames, names)
+			assert.Equal(t, []verify.Result{
+				{
+					Source: tc.expectedSource,
+					Method: "fake",
+					Signer: "test-key",
+				},
+			}, reader.Results)
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package multierror
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -43,6 +44,29 @@ func (mve *MultiError) Error() string {
 	return b.String()
 }
 
+// Is returns true if any of the causes is or wraps the target error.
+// Use errors.Is(error) to recursively check if an error wraps the target.
+func (mve *MultiError) Is(target error) bool {
+	for _, err := range mve.Causes {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first cause that matches the target, and if one is found,
+// sets the target to that cause and returns true.
+// Use errors.As(error, interface{}) to find the cause of a specific type.
+func (mve *MultiError) As(target interface{}) bool {
+	for _, err := range mve.Causes {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
 func formatError(err error) string {
 	lines := strings.Split(err.Error(), "\n")
 	return Prefix + strings.Join(lines, fmt.Sprintf("\n%s", Indent))
@@ -84,11 +84,37 @@ func HydrateSetList(idSetList []object.ObjMetadataSet, objs object.UnstructuredS
 	return objSetList
 }
 
+// HydrateReverseSetList is the same as HydrateSetList, but sorts the objects
+// in each set in delete order, which is the reverse of apply order. It is
+// intended for use with the output of Graph.ReverseSort.
+func HydrateReverseSetList(idSetList []object.ObjMetadataSet, objs object.UnstructuredSet) []object.UnstructuredSet {
+	objSetList := HydrateSetList(idSetList, objs)
+	for _, set := range objSetList {
+		for i, j := 0, len(set)-1; i < j; i, j = i+1, j-1 {
+			set[i], set[j] = set[j], set[i]
+		}
+	}
+	return objSetList
+}
+
 // SortObjs returns a slice of the sets of objects to apply (in order).
 // Each of the objects in an apply set is applied together. The order of
 // the returned applied sets is a topological ordering of the sets to apply.
 // Returns an single empty apply set if there are no objects to apply.
 func SortObjs(objs object.UnstructuredSet) ([]object.UnstructuredSet, error) {
+	return sortObjs(objs, false)
+}
+
+// ReverseSortObjs returns a slice of the sets of objects to delete (in
+// order). The order is a reverse topological ordering of the same dependency
+// graph used by SortObjs, so objects are deleted before the objects they
+// depend on (e.g. custom resources before their CRD, and namespaced objects
+// before their namespace).
+func ReverseSortObjs(objs object.UnstructuredSet) ([]object.UnstructuredSet, error) {
+	return sortObjs(objs, true)
+}
+
+func sortObjs(objs object.UnstructuredSet, reverse bool) ([]object.UnstructuredSet, error) {
 	var errors []error
 	if len(objs) == 0 {
 		return nil, nil
@@ -99,13 +125,24 @@ func SortObjs(objs object.UnstructuredSet) ([]object.UnstructuredSet, error) {
 		// collect and continue
 		errors = multierror.Unwrap(err)
 	}
-
-	idSetList, err := g.Sort()
-	if err != nil {
-		errors = append(errors, err)
+	if err := AddWaveEdges(g, objs); err != nil {
+		errors = append(errors, multierror.Unwrap(err)...)
 	}
 
-	objSetList := HydrateSetList(idSetList, objs)
+	var objSetList []object.UnstructuredSet
+	if reverse {
+		idSetList, err := g.ReverseSort()
+		if err != nil {
+			errors = append(errors, err)
+		}
+		objSetList = HydrateReverseSetList(idSetList, objs)
+	} else {
+		idSetList, err := g.Sort()
+		if err != nil {
+			errors = append(errors, err)
+		}
+		objSetList = HydrateSetList(idSetList, objs)
+	}
 
 	if len(errors) > 0 {
 		return objSetList, multierror.Wrap(errors...)
@@ -113,17 +150,6 @@ func SortObjs(objs object.UnstructuredSet) ([]object.UnstructuredSet, error) {
 	return objSetList, nil
 }
 
-// ReverseSortObjs is the same as SortObjs but using reverse ordering.
-func ReverseSortObjs(objs object.UnstructuredSet) ([]object.UnstructuredSet, error) {
-	// Sorted objects using normal ordering.
-	s, err := SortObjs(objs)
-	if err != nil {
-		return s, err
-	}
-	ReverseSetList(s)
-	return s, nil
-}
-
 // ReverseSetList deep reverses of a list of object lists
 func ReverseSetList(setList []object.UnstructuredSet) {
 	// Reverse the ordering of the object sets using swaps.
@@ -202,6 +228,96 @@ func addApplyTimeMutationEdges(g *Graph, objs object.UnstructuredSet, ids object
 	return nil
 }
 
+// AddWaveEdges updates the graph so that the objects in each wave, as
+// defined by the "apply-wave" annotation, depend on the objects in the
+// previous wave. Objects without the annotation are in wave 0. Edges are only
+// added if more than one wave is present. Each pair of consecutive waves is
+// ordered by a barrier vertex, so the number of edges grows with the number
+// of objects, not the number of pairs of objects.
+//
+// Dependencies already in the graph, like implicit namespace and CRD
+// dependencies, take precedence: if an object depends on an object in a
+// later wave, the conflict is returned as a validation error, and no wave
+// edges are added, since they would make a cycle.
+//
+// Waves are only meaningful between objects with the same actuation
+// strategy, so objects to apply and objects to delete should be added
+// separately.
+func AddWaveEdges(g *Graph, objs object.UnstructuredSet) error {
+	var errors []error
+	waves := make(map[int]object.ObjMetadataSet)
+	objWaves := make(map[object.ObjMetadata]int, len(objs))
+	var ids object.ObjMetadataSet
+	for _, obj := range objs {
+		id := object.UnstructuredToObjMetadata(obj)
+		if _, found := objWaves[id]; found {
+			continue
+		}
+		wave, err := ordering.ReadWaveAnnotation(obj)
+		if err != nil {
+			klog.V(3).Infof("failed to add edges from: %s: %v", id, err)
+			errors = append(errors, validation.NewError(err, id))
+			continue
+		}
+		objWaves[id] = wave
+		waves[wave] = append(waves[wave], id)
+		ids = append(ids, id)
+	}
+	if len(waves) > 1 {
+		if err := validateWaves(g, ids, objWaves); err != nil {
+			errors = append(errors, multierror.Unwrap(err)...)
+		} else {
+			waveNums := make([]int, 0, len(waves))
+			for wave := range waves {
+				waveNums = append(waveNums, wave)
+			}
+			sort.Ints(waveNums)
+			for i := 1; i < len(waveNums); i++ {
+				klog.V(3).Infof("adding barrier from wave: %d, to wave: %d", waveNums[i], waveNums[i-1])
+				g.addBarrier(waves[waveNums[i]], waves[waveNums[i-1]])
+			}
+		}
+	}
+	if len(errors) > 0 {
+		return multierror.Wrap(errors...)
+	}
+	return nil
+}
+
+// validateWaves returns a validation error for each object that depends on
+// an object in a later wave.
+func validateWaves(g *Graph, ids object.ObjMetadataSet, objWaves map[object.ObjMetadata]int) error {
+	var errors []error
+	for _, id := range ids {
+		wave := objWaves[id]
+		var objErrors []error
+		for _, dep := range g.Dependencies(id) {
+			depWave, found := objWaves[dep]
+			if !found || depWave <= wave {
+				continue
+			}
+			err := WaveConflictError{
+				Edge: Edge{
+					From: id,
+					To:   dep,
+				},
+				Wave:           wave,
+				DependencyWave: depWave,
+			}
+			objErrors = append(objErrors, err)
+			klog.V(3).Infof("failed to add wave edges: %v", err)
+		}
+		if len(objErrors) > 0 {
+			errors = append(errors,
+				validation.NewError(multierror.Wrap(objErrors...), id))
+		}
+	}
+	if len(errors) > 0 {
+		return multierror.Wrap(errors...)
+	}
+	return nil
+}
+
 // addDependsOnEdges updates the graph with edges from objects
 // with an explicit "depends-on" annotation.
 // The objs and ids must match in order and length (optimization).
@@ -5,6 +5,8 @@ package graph
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -19,6 +21,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/object/mutation"
 	mutationutil "sigs.k8s.io/cli-utils/pkg/object/mutation/testutil"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
+	"sigs.k8s.io/cli-utils/pkg/ordering"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 )
 
@@ -1437,6 +1440,170 @@ func TestAddDependsOnEdges(t *testing.T) {
 	}
 }
 
+func withWave(u *unstructured.Unstructured, wave string) *unstructured.Unstructured {
+	u.SetAnnotations(map[string]string{ordering.WaveAnnotation: wave})
+	return u
+}
+
+func TestAddWaveEdges(t *testing.T) {
+	testCases := map[string]struct {
+		objs          []*unstructured.Unstructured
+		expected      []Edge
+		expectedError error
+	}{
+		"no wave annotations adds no graph edges": {
+			objs: []*unstructured.Unstructured{
+				testutil.Unstructured(t, resources["deployment"]),
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			expected: []Edge{},
+		},
+		"same wave adds no graph edges": {
+			objs: []*unstructured.Unstructured{
+				withWave(testutil.Unstructured(t, resources["deployment"]), "1"),
+				withWave(testutil.Unstructured(t, resources["secret"]), "1"),
+			},
+			expected: []Edge{},
+		},
+		"later wave depends on default wave": {
+			objs: []*unstructured.Unstructured{
+				withWave(testutil.Unstructured(t, resources["deployment"]), "1"),
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			expected: []Edge{
+				{
+					From: testutil.ToIdentifier(t, resources["deployment"]),
+					To:   testutil.ToIdentifier(t, resources["secret"]),
+				},
+			},
+		},
+		"each wave depends only on the previous wave": {
+			objs: []*unstructured.Unstructured{
+				withWave(testutil.Unstructured(t, resources["deployment"]), "5"),
+				withWave(testutil.Unstructured(t, resources["pod"]), "2"),
+				withWave(testutil.Unstructured(t, resources["secret"]), "-1"),
+			},
+			expected: []Edge{
+				{
+					From: testutil.ToIdentifier(t, resources["deployment"]),
+					To:   testutil.ToIdentifier(t, resources["pod"]),
+				},
+				{
+					From: testutil.ToIdentifier(t, resources["pod"]),
+					To:   testutil.ToIdentifier(t, resources["secret"]),
+				},
+			},
+		},
+		"dependency in a later wave conflicts with the waves": {
+			objs: []*unstructured.Unstructured{
+				withWave(testutil.Unstructured(t, resources["namespace"]), "1"),
+				testutil.Unstructured(t, resources["pod"]),
+			},
+			// Only the implicit namespace edge, no wave edges.
+			expected: []Edge{
+				{
+					From: testutil.ToIdentifier(t, resources["pod"]),
+					To:   testutil.ToIdentifier(t, resources["namespace"]),
+				},
+			},
+			expectedError: validation.NewError(
+				WaveConflictError{
+					Edge: Edge{
+						From: testutil.ToIdentifier(t, resources["pod"]),
+						To:   testutil.ToIdentifier(t, resources["namespace"]),
+					},
+					Wave:           0,
+					DependencyWave: 1,
+				},
+				testutil.ToIdentifier(t, resources["pod"]),
+			),
+		},
+		"dependency in an earlier wave is kept": {
+			objs: []*unstructured.Unstructured{
+				withWave(testutil.Unstructured(t, resources["namespace"]), "-1"),
+				testutil.Unstructured(t, resources["pod"]),
+			},
+			expected: []Edge{
+				{
+					From: testutil.ToIdentifier(t, resources["pod"]),
+					To:   testutil.ToIdentifier(t, resources["namespace"]),
+				},
+			},
+		},
+		"invalid wave annotation": {
+			objs: []*unstructured.Unstructured{
+				withWave(testutil.Unstructured(t, resources["deployment"]), "first"),
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			expected: []Edge{},
+			expectedError: validation.NewError(
+				object.InvalidAnnotationError{
+					Annotation: ordering.WaveAnnotation,
+					Cause: &strconv.NumError{
+						Func: "Atoi",
+						Num:  "first",
+						Err:  strconv.ErrSyntax,
+					},
+				},
+				testutil.ToIdentifier(t, resources["deployment"]),
+			),
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			g := New()
+			ids := object.UnstructuredSetToObjMetadataSet(tc.objs)
+			addNamespaceEdges(g, tc.objs, ids)
+			err := AddWaveEdges(g, tc.objs)
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			// Barriers are replaced by the objects on the other side.
+			actual := []Edge{}
+			for _, id := range ids {
+				for _, dep := range g.Dependencies(id) {
+					actual = append(actual, Edge{From: id, To: dep})
+				}
+			}
+			verifyEdges(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestAddWaveEdges_Barrier(t *testing.T) {
+	var objs []*unstructured.Unstructured
+	for wave := 0; wave < 2; wave++ {
+		for i := 0; i < 10; i++ {
+			obj := withWave(testutil.Unstructured(t, resources["secret"]), strconv.Itoa(wave))
+			obj.SetName(fmt.Sprintf("secret-%d-%d", wave, i))
+			objs = append(objs, obj)
+		}
+	}
+	g := New()
+	require.NoError(t, AddWaveEdges(g, objs))
+
+	// One edge per object, through the barrier, instead of one per pair.
+	assert.Len(t, edgeMapToList(g.edges), len(objs))
+	for _, obj := range objs[10:] {
+		id := object.UnstructuredToObjMetadata(obj)
+		assert.ElementsMatch(t, object.UnstructuredSetToObjMetadataSet(objs[:10]), g.Dependencies(id))
+	}
+	for _, obj := range objs[:10] {
+		id := object.UnstructuredToObjMetadata(obj)
+		assert.ElementsMatch(t, object.UnstructuredSetToObjMetadataSet(objs[10:]), g.Dependents(id))
+	}
+
+	// The barrier is not sorted with the objects.
+	sorted, err := g.Sort()
+	require.NoError(t, err)
+	require.Len(t, sorted, 2)
+	assert.ElementsMatch(t, object.UnstructuredSetToObjMetadataSet(objs[:10]), sorted[0])
+	assert.ElementsMatch(t, object.UnstructuredSetToObjMetadataSet(objs[10:]), sorted[1])
+}
+
 func TestAddNamespaceEdges(t *testing.T) {
 	testCases := map[string]struct {
 		objs     []*unstructured.Unstructured
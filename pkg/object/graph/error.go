@@ -6,8 +6,10 @@ package graph
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	"sigs.k8s.io/cli-utils/pkg/multierror"
+	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/object/mutation"
 )
 
@@ -32,6 +34,14 @@ type CyclicDependencyError struct {
 func (cde CyclicDependencyError) Error() string {
 	var errorBuf bytes.Buffer
 	errorBuf.WriteString("cyclic dependency:")
+	if cycle := cde.Cycle(); len(cycle) > 0 {
+		refs := make([]string, len(cycle))
+		for i, id := range cycle {
+			refs[i] = mutation.ResourceReferenceFromObjMetadata(id).String()
+		}
+		errorBuf.WriteString(" ")
+		errorBuf.WriteString(strings.Join(refs, " -> "))
+	}
 	for _, edge := range cde.Edges {
 		errorBuf.WriteString(fmt.Sprintf("\n%s%s -> %s", multierror.Prefix,
 			mutation.ResourceReferenceFromObjMetadata(edge.From),
@@ -40,6 +50,54 @@ func (cde CyclicDependencyError) Error() string {
 	return errorBuf.String()
 }
 
+// Cycle returns the vertices of the first cycle found in the edges, in
+// dependency order. The first vertex is repeated at the end to close the
+// cycle. Returns nil if the edges do not contain a cycle.
+func (cde CyclicDependencyError) Cycle() []object.ObjMetadata {
+	edges := make(map[object.ObjMetadata]object.ObjMetadataSet)
+	for _, edge := range cde.Edges {
+		edges[edge.From] = append(edges[edge.From], edge.To)
+	}
+	visited := make(map[object.ObjMetadata]bool)
+	for _, edge := range cde.Edges {
+		if visited[edge.From] {
+			continue
+		}
+		if cycle := findCycle(edges, edge.From, visited, nil); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// findCycle performs a depth first search from the vertex, returning the
+// first cycle found along the current path.
+func findCycle(
+	edges map[object.ObjMetadata]object.ObjMetadataSet,
+	vertex object.ObjMetadata,
+	visited map[object.ObjMetadata]bool,
+	path []object.ObjMetadata,
+) []object.ObjMetadata {
+	for i, id := range path {
+		if id == vertex {
+			cycle := make([]object.ObjMetadata, 0, len(path)-i+1)
+			cycle = append(cycle, path[i:]...)
+			return append(cycle, vertex)
+		}
+	}
+	if visited[vertex] {
+		return nil
+	}
+	visited[vertex] = true
+	path = append(path, vertex)
+	for _, to := range edges[vertex] {
+		if cycle := findCycle(edges, to, visited, path); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
 // DuplicateDependencyError represents an invalid depends-on annotation with
 // duplicate references.
 type DuplicateDependencyError struct {
@@ -51,3 +109,17 @@ func (dde DuplicateDependencyError) Error() string {
 		mutation.ResourceReferenceFromObjMetadata(dde.Edge.From),
 		mutation.ResourceReferenceFromObjMetadata(dde.Edge.To))
 }
+
+// WaveConflictError represents a dependency on an object in a later apply
+// wave, which can not be applied before the object that depends on it.
+type WaveConflictError struct {
+	Edge           Edge
+	Wave           int
+	DependencyWave int
+}
+
+func (wce WaveConflictError) Error() string {
+	return fmt.Sprintf("dependency in a later apply wave: %s (wave %d) -> %s (wave %d)",
+		mutation.ResourceReferenceFromObjMetadata(wce.Edge.From), wce.Wave,
+		mutation.ResourceReferenceFromObjMetadata(wce.Edge.To), wce.DependencyWave)
+}
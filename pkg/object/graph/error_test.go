@@ -66,7 +66,7 @@ func TestCyclicDependencyErrorString(t *testing.T) {
 					},
 				},
 			},
-			expectedString: `cyclic dependency:
+			expectedString: `cyclic dependency: test/foo/obj1 -> test/foo/obj2 -> test/foo/obj1
 - test/foo/obj1 -> test/foo/obj2
 - test/foo/obj2 -> test/foo/obj1`,
 		},
@@ -87,7 +87,7 @@ func TestCyclicDependencyErrorString(t *testing.T) {
 					},
 				},
 			},
-			expectedString: `cyclic dependency:
+			expectedString: `cyclic dependency: test/foo/obj1 -> test/foo/obj2 -> test/foo/obj3 -> test/foo/obj1
 - test/foo/obj1 -> test/foo/obj2
 - test/foo/obj2 -> test/foo/obj3
 - test/foo/obj3 -> test/foo/obj1`,
@@ -101,6 +101,51 @@ func TestCyclicDependencyErrorString(t *testing.T) {
 	}
 }
 
+func TestCyclicDependencyErrorCycle(t *testing.T) {
+	testCases := map[string]struct {
+		err           CyclicDependencyError
+		expectedCycle []object.ObjMetadata
+	}{
+		"no edges": {
+			err:           CyclicDependencyError{},
+			expectedCycle: nil,
+		},
+		"no cycle": {
+			err: CyclicDependencyError{
+				Edges: []Edge{
+					{From: o1, To: o2},
+					{From: o2, To: o3},
+				},
+			},
+			expectedCycle: nil,
+		},
+		"dependent of a cycle is excluded": {
+			err: CyclicDependencyError{
+				Edges: []Edge{
+					{From: o1, To: o2},
+					{From: o2, To: o3},
+					{From: o3, To: o2},
+				},
+			},
+			expectedCycle: []object.ObjMetadata{o2, o3, o2},
+		},
+		"self dependency": {
+			err: CyclicDependencyError{
+				Edges: []Edge{
+					{From: o1, To: o1},
+				},
+			},
+			expectedCycle: []object.ObjMetadata{o1, o1},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expectedCycle, tc.err.Cycle())
+		})
+	}
+}
+
 func TestDuplicateDependencyErrorString(t *testing.T) {
 	testCases := map[string]struct {
 		err            DuplicateDependencyError
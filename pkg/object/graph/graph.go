@@ -8,12 +8,21 @@ package graph
 
 import (
 	"sort"
+	"strconv"
 
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
 	"sigs.k8s.io/cli-utils/pkg/ordering"
 )
 
+// barrierGroupKind is the GroupKind of barrier vertices, which order two sets
+// of vertices without being objects themselves.
+var barrierGroupKind = schema.GroupKind{
+	Group: "graph.cli-utils.sigs.k8s.io",
+	Kind:  "Barrier",
+}
+
 // Graph is contains a directed set of edges, implemented as
 // an adjacency list (map key is "from" vertex, slice are "to"
 // vertices).
@@ -22,6 +31,8 @@ type Graph struct {
 	edges map[object.ObjMetadata]object.ObjMetadataSet
 	// map "to" vertex -> list of "from" vertices
 	reverseEdges map[object.ObjMetadata]object.ObjMetadataSet
+	// number of barrier vertices added, used to name them
+	barriers int
 }
 
 // New returns a pointer to an empty Graph data structure.
@@ -80,6 +91,36 @@ func (g *Graph) AddEdge(from object.ObjMetadata, to object.ObjMetadata) {
 	}
 }
 
+// addBarrier adds a barrier vertex, with an edge from each of the dependents
+// to the barrier, and an edge from the barrier to each of the dependencies.
+// This orders the two sets with one edge per vertex, instead of one edge per
+// pair of vertices. Barriers are not returned by Sort and ReverseSort, and
+// Dependencies and Dependents return the vertices on the other side of them.
+// The dependencies must not contain duplicates.
+func (g *Graph) addBarrier(dependents, dependencies object.ObjMetadataSet) {
+	barrier := object.ObjMetadata{
+		GroupKind: barrierGroupKind,
+		Name:      strconv.Itoa(g.barriers),
+	}
+	g.barriers++
+	g.AddVertex(barrier)
+	for _, id := range dependents {
+		g.AddEdge(id, barrier)
+	}
+	// The edges from the new barrier are added directly, since none of
+	// them can exist yet.
+	for _, id := range dependencies {
+		g.AddVertex(id)
+		g.edges[barrier] = append(g.edges[barrier], id)
+		g.reverseEdges[id] = append(g.reverseEdges[id], barrier)
+	}
+}
+
+// isBarrier returns true if the vertex is a barrier, not an object.
+func isBarrier(v object.ObjMetadata) bool {
+	return v.GroupKind == barrierGroupKind
+}
+
 // edgeMapToList returns a sorted slice of directed graph edges (vertex pairs).
 func edgeMapToList(edgeMap map[object.ObjMetadata]object.ObjMetadataSet) []Edge {
 	edges := []Edge{}
@@ -126,31 +167,102 @@ func removeVertex(edges map[object.ObjMetadata]object.ObjMetadataSet, r object.O
 
 // Dependencies returns the objects that this object depends on.
 func (g *Graph) Dependencies(from object.ObjMetadata) object.ObjMetadataSet {
-	edgesFrom, exists := g.edges[from]
-	if !exists {
-		return nil
-	}
-	c := make(object.ObjMetadataSet, len(edgesFrom))
-	copy(c, edgesFrom)
-	return c
+	return adjacentObjects(g.edges, from)
 }
 
 // Dependents returns the objects that depend on this object.
 func (g *Graph) Dependents(to object.ObjMetadata) object.ObjMetadataSet {
-	edgesTo, exists := g.reverseEdges[to]
+	return adjacentObjects(g.reverseEdges, to)
+}
+
+// adjacentObjects returns the vertices adjacent to the vertex, replacing
+// barriers by the vertices adjacent to them.
+func adjacentObjects(edgeMap map[object.ObjMetadata]object.ObjMetadataSet, v object.ObjMetadata) object.ObjMetadataSet {
+	adj, exists := edgeMap[v]
 	if !exists {
 		return nil
 	}
-	c := make(object.ObjMetadataSet, len(edgesTo))
-	copy(c, edgesTo)
+	c := make(object.ObjMetadataSet, 0, len(adj))
+	for _, id := range adj {
+		if isBarrier(id) {
+			c = c.Union(adjacentObjects(edgeMap, id))
+		} else if !c.Contains(id) {
+			c = append(c, id)
+		}
+	}
 	return c
 }
 
 // Sort returns the ordered set of vertices after a topological sort.
+// Vertices are sorted before the vertices that depend on them, which is the
+// order to apply objects in.
 func (g *Graph) Sort() ([]object.ObjMetadataSet, error) {
+	sorted, remaining := sortEdges(g.edges)
+	sorted = removeBarriers(sorted)
+	if len(remaining) > 0 {
+		// Error can be ignored, so return the full set list
+		return sorted, validation.NewError(CyclicDependencyError{
+			Edges: edgeMapToList(remaining),
+		}, withoutBarriers(edgeMapKeys(remaining))...)
+	}
+	return sorted, nil
+}
+
+// ReverseSort returns the ordered set of vertices after a reverse
+// topological sort. Vertices are sorted before the vertices they depend on,
+// which is the order to delete objects in. Unlike reversing the result of
+// Sort, vertices without dependents are in the first set, so they are
+// deleted as early as possible.
+func (g *Graph) ReverseSort() ([]object.ObjMetadataSet, error) {
+	sorted, remaining := sortEdges(g.reverseEdges)
+	sorted = removeBarriers(sorted)
+	if len(remaining) > 0 {
+		// Report the cycle using the edges in their original direction.
+		edges := make(map[object.ObjMetadata]object.ObjMetadataSet, len(remaining))
+		for to, fromList := range remaining {
+			for _, from := range fromList {
+				edges[from] = append(edges[from], to)
+			}
+		}
+		// Error can be ignored, so return the full set list
+		return sorted, validation.NewError(CyclicDependencyError{
+			Edges: edgeMapToList(edges),
+		}, withoutBarriers(edgeMapKeys(remaining))...)
+	}
+	return sorted, nil
+}
+
+// removeBarriers returns the set list without barrier vertices, and without
+// the sets that only contained barriers.
+func removeBarriers(setList []object.ObjMetadataSet) []object.ObjMetadataSet {
+	result := make([]object.ObjMetadataSet, 0, len(setList))
+	for _, set := range setList {
+		if ids := withoutBarriers(set); len(ids) > 0 {
+			result = append(result, ids)
+		}
+	}
+	return result
+}
+
+// withoutBarriers returns the set without barrier vertices.
+func withoutBarriers(set object.ObjMetadataSet) object.ObjMetadataSet {
+	ids := make(object.ObjMetadataSet, 0, len(set))
+	for _, id := range set {
+		if !isBarrier(id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// sortEdges returns the ordered set of vertices after a topological sort of
+// the adjacency list, with the vertices without adjacent vertices first.
+// If the adjacency list has cycles, the edges that could not be sorted are
+// also returned.
+func sortEdges(edgeMap map[object.ObjMetadata]object.ObjMetadataSet) ([]object.ObjMetadataSet, map[object.ObjMetadata]object.ObjMetadataSet) {
 	// deep copy edge map to avoid destructive sorting
-	edges := make(map[object.ObjMetadata]object.ObjMetadataSet, len(g.edges))
-	for vertex, deps := range g.edges {
+	edges := make(map[object.ObjMetadata]object.ObjMetadataSet, len(edgeMap))
+	for vertex, deps := range edgeMap {
 		c := make(object.ObjMetadataSet, len(deps))
 		copy(c, deps)
 		edges[vertex] = c
@@ -168,10 +280,7 @@ func (g *Graph) Sort() ([]object.ObjMetadataSet, error) {
 		// No leaf vertices means cycle in the directed graph,
 		// where remaining edges define the cycle.
 		if len(leafVertices) == 0 {
-			// Error can be ignored, so return the full set list
-			return sorted, validation.NewError(CyclicDependencyError{
-				Edges: edgeMapToList(edges),
-			}, edgeMapKeys(edges)...)
+			return sorted, edges
 		}
 		// Remove all edges to leaf vertices.
 		for _, v := range leafVertices {
@@ -147,6 +147,84 @@ func TestObjectGraphSort(t *testing.T) {
 	}
 }
 
+func TestObjectGraphReverseSort(t *testing.T) {
+	testCases := map[string]struct {
+		vertices      object.ObjMetadataSet
+		edges         []Edge
+		expected      []object.ObjMetadataSet
+		expectedError error
+	}{
+		"one edge": {
+			vertices: object.ObjMetadataSet{o1, o2},
+			edges:    []Edge{e1},
+			expected: []object.ObjMetadataSet{{o1}, {o2}},
+		},
+		"four edges": {
+			vertices: object.ObjMetadataSet{o1, o2, o3, o4},
+			edges:    []Edge{e1, e2, e4, e5},
+			expected: []object.ObjMetadataSet{{o1}, {o2}, {o3}, {o4}},
+		},
+		"no edges means all in the same first set": {
+			vertices: object.ObjMetadataSet{o1, o2, o3, o4},
+			edges:    []Edge{},
+			expected: []object.ObjMetadataSet{{o4, o3, o2, o1}},
+		},
+		"vertices without dependents in first set": {
+			vertices: object.ObjMetadataSet{o1, o2, o3, o4, o5},
+			edges:    []Edge{e1, e2, e8},
+			expected: []object.ObjMetadataSet{{o4, o1}, {o5, o2}, {o3}},
+		},
+		"multi-edge cycle in graph is an error": {
+			vertices: object.ObjMetadataSet{o1, o2, o3},
+			edges:    []Edge{e1, e2, e7},
+			expected: []object.ObjMetadataSet{},
+			expectedError: validation.NewError(
+				CyclicDependencyError{
+					Edges: []Edge{
+						{
+							From: o1,
+							To:   o2,
+						},
+						{
+							From: o2,
+							To:   o3,
+						},
+						{
+							From: o3,
+							To:   o1,
+						},
+					},
+				},
+				o1, o2, o3,
+			),
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			g := New()
+			for _, vertex := range tc.vertices {
+				g.AddVertex(vertex)
+			}
+			for _, edge := range tc.edges {
+				g.AddEdge(edge.From, edge.To)
+			}
+			actual, err := g.ReverseSort()
+			if tc.expectedError != nil {
+				assert.EqualError(t, tc.expectedError, err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			testutil.AssertEqual(t, tc.expected, actual)
+
+			// verify sort is repeatable & non-destructive
+			actual, err = g.ReverseSort()
+			assert.NoError(t, err)
+			testutil.AssertEqual(t, tc.expected, actual)
+		})
+	}
+}
+
 func TestGraphDependencies(t *testing.T) {
 	testCases := map[string]struct {
 		vertices object.ObjMetadataSet
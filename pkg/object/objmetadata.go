@@ -33,6 +33,9 @@ const (
 	// Transform colons in the RBAC resource names to double
 	// underscore.
 	colonTranscoded = "__"
+	// Marks the name field as a generateName. It is not allowed as a
+	// character in resource name.
+	generateNameSuffix = "*"
 )
 
 var (
@@ -55,6 +58,11 @@ type ObjMetadata struct {
 	Namespace string
 	Name      string
 	GroupKind schema.GroupKind
+	// GenerateName is set, instead of Name, for objects that have not been
+	// created yet and get a name generated by the server from this prefix.
+	// Once created, the object is identified by its generated Name, and
+	// GenerateName is empty.
+	GenerateName string
 }
 
 // ParseObjMetadata takes a string, splits it into its four fields,
@@ -67,6 +75,11 @@ type ObjMetadata struct {
 //
 // NOTE: name field can contain double underscore (__), which represents
 // a colon. RBAC resources can have this additional character (:) in their name.
+//
+// A name field ending with an asterisk (*) is the generateName of an object
+// that has not been created yet, as in:
+//
+//   test-namespace_migrate-*_batch_Job
 func ParseObjMetadata(s string) (ObjMetadata, error) {
 	// Parse first field namespace
 	index := strings.Index(s, fieldSeparator)
@@ -104,6 +117,10 @@ func ParseObjMetadata(s string) (ObjMetadata, error) {
 			Kind:  kind,
 		},
 	}
+	if strings.HasSuffix(name, generateNameSuffix) && len(name) > len(generateNameSuffix) {
+		id.Name = ""
+		id.GenerateName = strings.TrimSuffix(name, generateNameSuffix)
+	}
 	return id, nil
 }
 
@@ -116,11 +133,21 @@ func (o *ObjMetadata) Equals(other *ObjMetadata) bool {
 	return *o == *other
 }
 
+// IsGenerated returns true if the ObjMetadata identifies an object that
+// will be created with a name generated by the server.
+func (o ObjMetadata) IsGenerated() bool {
+	return o.Name == "" && o.GenerateName != ""
+}
+
 // String create a string version of the ObjMetadata struct. For RBAC resources,
 // the "name" field transcodes ":" into double underscore for valid storing
-// as the label of a ConfigMap.
+// as the label of a ConfigMap. Objects with a generated name have their
+// generateName, followed by an asterisk, in the "name" field.
 func (o ObjMetadata) String() string {
 	name := o.Name
+	if o.IsGenerated() {
+		name = o.GenerateName + generateNameSuffix
+	}
 	if _, exists := RBACGroupKind[o.GroupKind]; exists {
 		name = strings.ReplaceAll(name, ":", colonTranscoded)
 	}
@@ -143,5 +170,8 @@ func RuntimeToObjMeta(obj runtime.Object) (ObjMetadata, error) {
 		Name:      accessor.GetName(),
 		GroupKind: obj.GetObjectKind().GroupVersionKind().GroupKind(),
 	}
+	if id.Name == "" {
+		id.GenerateName = accessor.GetGenerateName()
+	}
 	return id, nil
 }
@@ -6,6 +6,8 @@ package object
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -87,6 +89,18 @@ func TestParseObjMetadata(t *testing.T) {
 			},
 			isError: false,
 		},
+		"Generated name": {
+			invStr: "test-namespace_migrate-*_batch_Job",
+			inventory: &ObjMetadata{
+				Namespace:    "test-namespace",
+				GenerateName: "migrate-",
+				GroupKind: schema.GroupKind{
+					Group: "batch",
+					Kind:  "Job",
+				},
+			},
+			isError: false,
+		},
 		"Not enough fields -- error": {
 			invStr:    "_test-name_apps",
 			inventory: &ObjMetadata{},
@@ -120,3 +134,33 @@ func TestParseObjMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestObjMetadataString(t *testing.T) {
+	job := schema.GroupKind{Group: "batch", Kind: "Job"}
+	tests := map[string]struct {
+		id       ObjMetadata
+		expected string
+	}{
+		"name": {
+			id:       ObjMetadata{Namespace: "test-namespace", Name: "migrate", GroupKind: job},
+			expected: "test-namespace_migrate_batch_Job",
+		},
+		"generated name": {
+			id:       ObjMetadata{Namespace: "test-namespace", GenerateName: "migrate-", GroupKind: job},
+			expected: "test-namespace_migrate-*_batch_Job",
+		},
+		"other generated name": {
+			id:       ObjMetadata{Namespace: "test-namespace", GenerateName: "seed-", GroupKind: job},
+			expected: "test-namespace_seed-*_batch_Job",
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.id.String())
+			parsed, err := ParseObjMetadata(tc.id.String())
+			require.NoError(t, err)
+			assert.Equal(t, tc.id, parsed)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package object
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// statuslessGroupKinds are the built-in resource types that have no status.
+// Objects of these types are reconciled as soon as they are applied.
+var statuslessGroupKinds = map[schema.GroupKind]struct{}{
+	{Group: "", Kind: "ConfigMap"}:                                                  {},
+	{Group: "", Kind: "Secret"}:                                                     {},
+	{Group: "", Kind: "ServiceAccount"}:                                             {},
+	{Group: "", Kind: "LimitRange"}:                                                 {},
+	{Group: "rbac.authorization.k8s.io", Kind: "Role"}:                              {},
+	{Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"}:                       {},
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}:                       {},
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}:                {},
+	{Group: "scheduling.k8s.io", Kind: "PriorityClass"}:                             {},
+	{Group: "storage.k8s.io", Kind: "StorageClass"}:                                 {},
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"}: {},
+	{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"}:   {},
+}
+
+// IsStatusless returns true if the GroupKind is a built-in resource type
+// that has no status.
+func IsStatusless(gk schema.GroupKind) bool {
+	_, found := statuslessGroupKinds[gk]
+	return found
+}
+
+// StatuslessObjects returns the identifiers of the objects in the set that
+// have no status.
+func StatuslessObjects(ids ObjMetadataSet) ObjMetadataSet {
+	var statusless ObjMetadataSet
+	for _, id := range ids {
+		if IsStatusless(id.GroupKind) {
+			statusless = append(statusless, id)
+		}
+	}
+	return statusless
+}
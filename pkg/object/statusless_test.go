@@ -0,0 +1,52 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestStatuslessObjects(t *testing.T) {
+	configMap := ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "", Kind: "ConfigMap"},
+		Name:      "cm",
+		Namespace: "default",
+	}
+	clusterRole := ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"},
+		Name:      "role",
+	}
+	deployment := ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Name:      "deploy",
+		Namespace: "default",
+	}
+
+	testCases := map[string]struct {
+		ids      ObjMetadataSet
+		expected ObjMetadataSet
+	}{
+		"empty set": {
+			ids:      ObjMetadataSet{},
+			expected: nil,
+		},
+		"only objects with status": {
+			ids:      ObjMetadataSet{deployment},
+			expected: nil,
+		},
+		"mixed objects": {
+			ids:      ObjMetadataSet{configMap, deployment, clusterRole},
+			expected: ObjMetadataSet{configMap, clusterRole},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, StatuslessObjects(tc.ids))
+		})
+	}
+}
@@ -28,13 +28,24 @@ func UnstructuredSetToObjMetadataSet(objs UnstructuredSet) ObjMetadataSet {
 }
 
 // UnstructuredToObjMetadata extracts the identifying information from an
-// Unstructured object and returns it as ObjMetadata object.
+// Unstructured object and returns it as ObjMetadata object. Objects without
+// a name are identified by their generateName, if set.
 func UnstructuredToObjMetadata(obj *unstructured.Unstructured) ObjMetadata {
-	return ObjMetadata{
+	id := ObjMetadata{
 		Namespace: obj.GetNamespace(),
 		Name:      obj.GetName(),
 		GroupKind: obj.GroupVersionKind().GroupKind(),
 	}
+	if id.Name == "" {
+		id.GenerateName = obj.GetGenerateName()
+	}
+	return id
+}
+
+// HasGeneratedName returns true if the object has no name, and will be
+// created with a name generated by the server from its generateName.
+func HasGeneratedName(obj *unstructured.Unstructured) bool {
+	return obj.GetName() == "" && obj.GetGenerateName() != ""
 }
 
 // IsKindNamespace returns true if the passed Unstructured object is
@@ -202,6 +213,30 @@ func crdDefinesVersion(crd *unstructured.Unstructured, version string) (bool, er
 	return false, nil
 }
 
+// UnstructuredSource returns the location the object was read from, as
+// recorded by kyaml in the path and index annotations, like "dir/file.yaml[1]"
+// for the second document of a file. Returns an empty string if the location
+// is not known.
+func UnstructuredSource(u *unstructured.Unstructured) string {
+	annos := u.GetAnnotations()
+	path := annos[kioutil.PathAnnotation]
+	if path == "" {
+		path = annos[kioutil.LegacyPathAnnotation] //nolint:staticcheck
+	}
+	index := annos[kioutil.IndexAnnotation]
+	if index == "" {
+		index = annos[kioutil.LegacyIndexAnnotation] //nolint:staticcheck
+	}
+	switch {
+	case path == "":
+		return ""
+	case index == "":
+		return path
+	default:
+		return fmt.Sprintf("%s[%s]", path, index)
+	}
+}
+
 // StripKyamlAnnotations removes any path and index annotations from the
 // unstructured resource.
 func StripKyamlAnnotations(u *unstructured.Unstructured) {
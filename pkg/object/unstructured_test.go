@@ -117,6 +117,41 @@ func TestUnstructuredToObjMeta(t *testing.T) {
 				},
 			},
 		},
+		"test generateName translation": {
+			obj: testutil.Unstructured(t, `
+apiVersion: batch/v1
+kind: Job
+metadata:
+  generateName: migrate-
+  namespace: test-namespace
+`),
+			expected: ObjMetadata{
+				GenerateName: "migrate-",
+				Namespace:    "test-namespace",
+				GroupKind: schema.GroupKind{
+					Group: "batch",
+					Kind:  "Job",
+				},
+			},
+		},
+		"test generated name translation": {
+			obj: testutil.Unstructured(t, `
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate-x7k2p
+  generateName: migrate-
+  namespace: test-namespace
+`),
+			expected: ObjMetadata{
+				Name:      "migrate-x7k2p",
+				Namespace: "test-namespace",
+				GroupKind: schema.GroupKind{
+					Group: "batch",
+					Kind:  "Job",
+				},
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -129,6 +164,51 @@ func TestUnstructuredToObjMeta(t *testing.T) {
 	}
 }
 
+func TestUnstructuredSource(t *testing.T) {
+	tests := map[string]struct {
+		annotations map[string]string
+		expected    string
+	}{
+		"no annotations": {
+			expected: "",
+		},
+		"path and index": {
+			annotations: map[string]string{
+				"internal.config.kubernetes.io/path":  "dir/pod.yaml",
+				"internal.config.kubernetes.io/index": "1",
+			},
+			expected: "dir/pod.yaml[1]",
+		},
+		"legacy path and index": {
+			annotations: map[string]string{
+				"config.kubernetes.io/path":  "pod.yaml",
+				"config.kubernetes.io/index": "0",
+			},
+			expected: "pod.yaml[0]",
+		},
+		"path only": {
+			annotations: map[string]string{
+				"config.kubernetes.io/path": "pod.yaml",
+			},
+			expected: "pod.yaml",
+		},
+		"index only": {
+			annotations: map[string]string{
+				"config.kubernetes.io/index": "0",
+			},
+			expected: "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj := testutil.Unstructured(t, testPod)
+			obj.SetAnnotations(tc.annotations)
+			assert.Equal(t, tc.expected, UnstructuredSource(obj))
+		})
+	}
+}
+
 func TestIsKindNamespace(t *testing.T) {
 	tests := map[string]struct {
 		obj             *unstructured.Unstructured
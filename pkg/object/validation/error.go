@@ -19,8 +19,27 @@ func NewError(cause error, ids ...object.ObjMetadata) *Error {
 
 // Error wraps an error with the object or objects it applies to.
 type Error struct {
-	ids   object.ObjMetadataSet
-	cause error
+	ids     object.ObjMetadataSet
+	sources []string
+	cause   error
+}
+
+// WithSources sets the locations the invalid objects were read from, like
+// "dir/file.yaml[1]". Empty sources are ignored.
+func (ve *Error) WithSources(sources ...string) *Error {
+	ve.sources = nil
+	for _, source := range sources {
+		if source != "" {
+			ve.sources = append(ve.sources, source)
+		}
+	}
+	return ve
+}
+
+// Sources returns the locations the invalid objects were read from, if
+// known.
+func (ve *Error) Sources() []string {
+	return ve.sources
 }
 
 // Identifiers returns zero or more object IDs which are invalid.
@@ -36,6 +55,14 @@ func (ve *Error) Unwrap() error {
 
 // Error stringifies the the error.
 func (ve *Error) Error() string {
+	msg := ve.message()
+	if len(ve.sources) > 0 {
+		msg = fmt.Sprintf("%s (from %s)", msg, strings.Join(ve.sources, ", "))
+	}
+	return msg
+}
+
+func (ve *Error) message() string {
 	switch {
 	case len(ve.ids) == 0:
 		return fmt.Sprintf("validation error: %v", ve.cause.Error())
@@ -51,3 +78,13 @@ func (ve *Error) Error() string {
 		return b.String()
 	}
 }
+
+// DuplicateObjectError is the cause of the Error of an object that is
+// defined more than once.
+type DuplicateObjectError struct {
+	Count int
+}
+
+func (e DuplicateObjectError) Error() string {
+	return fmt.Sprintf("object is defined %d times", e.Count)
+}
@@ -20,8 +20,10 @@ type Validator struct {
 }
 
 // Validate validates the provided resources. A RESTMapper will be used
-// to fetch type information from the live cluster.
+// to fetch type information from the live cluster. All the errors are
+// collected, with the locations the invalid objects were read from, if known.
 func (v *Validator) Validate(objs []*unstructured.Unstructured) {
+	v.validateDuplicates(objs)
 	crds := findCRDs(objs)
 	for _, obj := range objs {
 		var objErrors []error
@@ -39,7 +41,29 @@ func (v *Validator) Validate(objs []*unstructured.Unstructured) {
 			v.Collector.Collect(NewError(
 				multierror.Wrap(objErrors...),
 				object.UnstructuredToObjMetadata(obj),
-			))
+			).WithSources(object.UnstructuredSource(obj)))
+		}
+	}
+}
+
+// validateDuplicates collects an error for each object that is defined more
+// than once, with the locations of all its definitions.
+func (v *Validator) validateDuplicates(objs []*unstructured.Unstructured) {
+	var ids object.ObjMetadataSet
+	sources := make(map[object.ObjMetadata][]string)
+	for _, obj := range objs {
+		id := object.UnstructuredToObjMetadata(obj)
+		if _, found := sources[id]; !found {
+			ids = append(ids, id)
+		}
+		sources[id] = append(sources[id], object.UnstructuredSource(obj))
+	}
+	for _, id := range ids {
+		if count := len(sources[id]); count > 1 {
+			v.Collector.Collect(NewError(
+				DuplicateObjectError{Count: count},
+				id,
+			).WithSources(sources[id]...))
 		}
 	}
 }
@@ -65,8 +89,10 @@ func (v *Validator) validateKind(u *unstructured.Unstructured) error {
 }
 
 // validateName validates the value of the name field of the resource.
+// Resources without a name must have a generateName, to be created with a
+// name generated by the server.
 func (v *Validator) validateName(u *unstructured.Unstructured) error {
-	if u.GetName() == "" {
+	if u.GetName() == "" && u.GetGenerateName() == "" {
 		return field.Required(field.NewPath("metadata", "name"), "name is required")
 	}
 	return nil
@@ -244,6 +244,110 @@ metadata:
 				},
 			),
 		},
+		"generateName instead of name": {
+			resources: []*unstructured.Unstructured{
+				testutil.Unstructured(t, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  generateName: foo-
+  namespace: default
+`,
+				),
+			},
+		},
+		"duplicate objects in different files": {
+			resources: []*unstructured.Unstructured{
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: default
+  annotations:
+    config.kubernetes.io/path: a.yaml
+    config.kubernetes.io/index: '0'
+`,
+				),
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: default
+  annotations:
+    config.kubernetes.io/path: b.yaml
+    config.kubernetes.io/index: '1'
+`,
+				),
+			},
+			expectedError: validation.NewError(
+				validation.DuplicateObjectError{Count: 2},
+				object.ObjMetadata{
+					GroupKind: schema.GroupKind{
+						Kind: "ConfigMap",
+					},
+					Name:      "foo",
+					Namespace: "default",
+				},
+			).WithSources("a.yaml[0]", "b.yaml[1]"),
+		},
+		"duplicate and invalid objects": {
+			resources: []*unstructured.Unstructured{
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: default
+`,
+				),
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: default
+`,
+				),
+				testutil.Unstructured(t, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: bar
+  annotations:
+    config.kubernetes.io/path: bar.yaml
+`,
+				),
+			},
+			expectedError: multierror.New(
+				validation.NewError(
+					validation.DuplicateObjectError{Count: 2},
+					object.ObjMetadata{
+						GroupKind: schema.GroupKind{
+							Kind: "ConfigMap",
+						},
+						Name:      "foo",
+						Namespace: "default",
+					},
+				),
+				validation.NewError(
+					&field.Error{
+						Type:     field.ErrorTypeRequired,
+						Field:    "metadata.namespace",
+						BadValue: "",
+						Detail:   "namespace is required",
+					},
+					object.ObjMetadata{
+						GroupKind: schema.GroupKind{
+							Group: "apps",
+							Kind:  "Deployment",
+						},
+						Name: "bar",
+					},
+				).WithSources("bar.yaml"),
+			),
+		},
 	}
 
 	for tn, tc := range testCases {
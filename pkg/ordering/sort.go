@@ -37,6 +37,9 @@ var _ sort.Interface = SortableUnstructureds{}
 func (a SortableUnstructureds) Len() int      { return len(a) }
 func (a SortableUnstructureds) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 func (a SortableUnstructureds) Less(i, j int) bool {
+	if wi, wj := wave(a[i]), wave(a[j]); wi != wj {
+		return wi < wj
+	}
 	first := object.UnstructuredToObjMetadata(a[i])
 	second := object.UnstructuredToObjMetadata(a[j])
 	return less(first, second)
@@ -91,6 +91,21 @@ func TestResourceOrdering(t *testing.T) {
 	assert.Equal(t, infos[3].Object.GetObjectKind().GroupVersionKind().Kind, "Deployment")
 }
 
+func TestWaveOrdering(t *testing.T) {
+	namespace := namespaceObj.DeepCopy()
+	namespace.SetAnnotations(map[string]string{WaveAnnotation: "2"})
+	deployment := deploymentObj.DeepCopy()
+	deployment.SetAnnotations(map[string]string{WaveAnnotation: "-1"})
+	configMap := configMapObj.DeepCopy()
+
+	objs := []*unstructured.Unstructured{namespace, configMap, deployment}
+	sort.Sort(SortableUnstructureds(objs))
+
+	assert.Equal(t, "testdeployment", objs[0].GetName())
+	assert.Equal(t, "the-map", objs[1].GetName())
+	assert.Equal(t, "testspace", objs[2].GetName())
+}
+
 func TestGvkLessThan(t *testing.T) {
 	gk1 := schema.GroupKind{
 		Group: "apps",
@@ -0,0 +1,63 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package ordering
+
+import (
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+const (
+	// WaveAnnotation sequences objects that have no structural dependency
+	// on each other. Objects are applied in ascending wave order, and all
+	// objects in a wave are reconciled before the next wave is applied.
+	// Objects without the annotation are in wave 0.
+	WaveAnnotation = "config.kubernetes.io/apply-wave"
+)
+
+// HasWaveAnnotation returns true if the config.kubernetes.io/apply-wave
+// annotation is present, false if not.
+func HasWaveAnnotation(u *unstructured.Unstructured) bool {
+	if u == nil {
+		return false
+	}
+	_, found := u.GetAnnotations()[WaveAnnotation]
+	return found
+}
+
+// ReadWaveAnnotation reads the apply-wave annotation and parses the wave
+// number. Returns 0 if the annotation is not present.
+func ReadWaveAnnotation(u *unstructured.Unstructured) (int, error) {
+	if u == nil {
+		return 0, nil
+	}
+	waveStr, found := u.GetAnnotations()[WaveAnnotation]
+	if !found {
+		return 0, nil
+	}
+	klog.V(5).Infof("apply-wave annotation found for %s/%s: %q",
+		u.GetNamespace(), u.GetName(), waveStr)
+
+	wave, err := strconv.Atoi(waveStr)
+	if err != nil {
+		return 0, object.InvalidAnnotationError{
+			Annotation: WaveAnnotation,
+			Cause:      err,
+		}
+	}
+	return wave, nil
+}
+
+// wave returns the apply wave of the object, treating an invalid annotation
+// as wave 0. Invalid annotations are reported during validation.
+func wave(u *unstructured.Unstructured) int {
+	w, err := ReadWaveAnnotation(u)
+	if err != nil {
+		return 0
+	}
+	return w
+}
@@ -4,6 +4,7 @@
 package list
 
 import (
+	"sigs.k8s.io/cli-utils/pkg/apply"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/object"
@@ -18,6 +19,10 @@ type Formatter interface {
 	FormatPruneEvent(pe event.PruneEvent) error
 	FormatDeleteEvent(de event.DeleteEvent) error
 	FormatWaitEvent(we event.WaitEvent) error
+	FormatWaitSummaryEvent(wse event.WaitSummaryEvent) error
+	FormatRetryEvent(re event.RetryEvent) error
+	FormatRateLimitEvent(rle event.RateLimitEvent) error
+	FormatVerificationEvent(ve event.VerificationEvent) error
 	FormatErrorEvent(ee event.ErrorEvent) error
 	FormatActionGroupEvent(
 		age event.ActionGroupEvent,
@@ -25,7 +30,11 @@ type Formatter interface {
 		s stats.Stats,
 		c Collector,
 	) error
-	FormatSummary(s stats.Stats) error
+	// FormatSummary formats the summary of the run, when the run is over.
+	// The result classifies the run like the exit code of the commands.
+	// It is also called after a fatal error, when the stats may be
+	// incomplete.
+	FormatSummary(s stats.Stats, result *apply.RunResult) error
 }
 
 type FormatterFactory func(previewStrategy common.DryRunStrategy) Formatter
@@ -58,17 +67,20 @@ func (sc *StatusCollector) LatestStatus() map[object.ObjMetadata]event.StatusEve
 func (b *BaseListPrinter) Print(ch <-chan event.Event, previewStrategy common.DryRunStrategy, printStatus bool) error {
 	var actionGroups []event.ActionGroup
 	var statsCollector stats.Stats
+	result := apply.NewRunResult()
 	statusCollector := &StatusCollector{
 		latestStatus: make(map[object.ObjMetadata]event.StatusEvent),
 	}
 	formatter := b.FormatterFactory(previewStrategy)
 	for e := range ch {
 		statsCollector.Handle(e)
+		result.Send(e)
 		switch e.Type {
 		case event.InitType:
 			actionGroups = e.InitEvent.ActionGroups
 		case event.ErrorType:
 			_ = formatter.FormatErrorEvent(e.ErrorEvent)
+			_ = formatter.FormatSummary(statsCollector, result)
 			return e.ErrorEvent.Err
 		case event.ValidationType:
 			if err := formatter.FormatValidationEvent(e.ValidationEvent); err != nil {
@@ -97,6 +109,22 @@ func (b *BaseListPrinter) Print(ch <-chan event.Event, previewStrategy common.Dr
 			if err := formatter.FormatWaitEvent(e.WaitEvent); err != nil {
 				return err
 			}
+		case event.WaitSummaryType:
+			if err := formatter.FormatWaitSummaryEvent(e.WaitSummaryEvent); err != nil {
+				return err
+			}
+		case event.RetryType:
+			if err := formatter.FormatRetryEvent(e.RetryEvent); err != nil {
+				return err
+			}
+		case event.RateLimitType:
+			if err := formatter.FormatRateLimitEvent(e.RateLimitEvent); err != nil {
+				return err
+			}
+		case event.VerificationType:
+			if err := formatter.FormatVerificationEvent(e.VerificationEvent); err != nil {
+				return err
+			}
 		case event.ActionGroupType:
 			if err := formatter.FormatActionGroupEvent(
 				e.ActionGroupEvent,
@@ -109,7 +137,7 @@ func (b *BaseListPrinter) Print(ch <-chan event.Event, previewStrategy common.Dr
 		}
 	}
 
-	if err := formatter.FormatSummary(statsCollector); err != nil {
+	if err := formatter.FormatSummary(statsCollector, result); err != nil {
 		return err
 	}
 	return printcommon.ResultErrorFromStats(statsCollector)
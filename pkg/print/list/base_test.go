@@ -4,9 +4,14 @@
 package list
 
 import (
+	"context"
+	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/cli-utils/pkg/apply"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/print/stats"
 	"sigs.k8s.io/cli-utils/pkg/printers/printer"
@@ -23,6 +28,58 @@ func TestPrint(t *testing.T) {
 	})
 }
 
+func TestPrintSummaryAfterError(t *testing.T) {
+	testCases := map[string]struct {
+		err           error
+		expectedClass apply.ResultClass
+	}{
+		"graceful cancellation prints summary": {
+			err:           &taskrunner.CancelledError{Err: context.Canceled},
+			expectedClass: apply.ResultCancelled,
+		},
+		"fatal error prints summary": {
+			err:           errors.New("task failed"),
+			expectedClass: apply.ResultError,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			formatter := newCountingFormatter()
+			printer := &BaseListPrinter{
+				FormatterFactory: func(previewStrategy common.DryRunStrategy) Formatter {
+					return formatter
+				},
+			}
+			ch := make(chan event.Event, 2)
+			ch <- event.Event{
+				Type: event.ApplyType,
+				ApplyEvent: event.ApplyEvent{
+					Status: event.ApplySkipped,
+					Error:  tc.err,
+				},
+			}
+			ch <- event.Event{
+				Type: event.ErrorType,
+				ErrorEvent: event.ErrorEvent{
+					Err: tc.err,
+				},
+			}
+			close(ch)
+
+			err := printer.Print(ch, common.DryRunNone, false)
+			assert.Equal(t, tc.err, err)
+			if assert.NotNil(t, formatter.summary) {
+				assert.Equal(t, 1, formatter.summary.ApplyStats.Skipped)
+			}
+			if assert.NotNil(t, formatter.result) {
+				assert.Equal(t, tc.expectedClass, formatter.result.Class())
+				assert.Equal(t, tc.err, formatter.result.Err())
+			}
+		})
+	}
+}
+
 func newCountingFormatter() *countingFormatter {
 	return &countingFormatter{}
 }
@@ -36,6 +93,8 @@ type countingFormatter struct {
 	waitEvents       []event.WaitEvent
 	errorEvent       event.ErrorEvent
 	actionGroupEvent []event.ActionGroupEvent
+	summary          *stats.Stats
+	result           *apply.RunResult
 }
 
 func (c *countingFormatter) FormatValidationEvent(e event.ValidationEvent) error {
@@ -68,6 +127,22 @@ func (c *countingFormatter) FormatWaitEvent(e event.WaitEvent) error {
 	return nil
 }
 
+func (c *countingFormatter) FormatWaitSummaryEvent(e event.WaitSummaryEvent) error {
+	return nil
+}
+
+func (c *countingFormatter) FormatRetryEvent(e event.RetryEvent) error {
+	return nil
+}
+
+func (c *countingFormatter) FormatRateLimitEvent(e event.RateLimitEvent) error {
+	return nil
+}
+
+func (c *countingFormatter) FormatVerificationEvent(e event.VerificationEvent) error {
+	return nil
+}
+
 func (c *countingFormatter) FormatErrorEvent(e event.ErrorEvent) error {
 	c.errorEvent = e
 	return nil
@@ -83,6 +158,8 @@ func (c *countingFormatter) FormatActionGroupEvent(
 	return nil
 }
 
-func (c *countingFormatter) FormatSummary(s stats.Stats) error {
+func (c *countingFormatter) FormatSummary(s stats.Stats, result *apply.RunResult) error {
+	c.summary = &s
+	c.result = result
 	return nil
 }
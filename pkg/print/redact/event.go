@@ -0,0 +1,88 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package redact
+
+import (
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/printers/printer"
+)
+
+// Event returns a copy of the event with the objects and error messages
+// redacted. The objects in the original event are not modified.
+func Event(r Redactor, e event.Event) event.Event {
+	switch e.Type {
+	case event.ErrorType:
+		e.ErrorEvent.Err = Error(r, e.ErrorEvent.Err)
+	case event.ValidationType:
+		e.ValidationEvent.Error = Error(r, e.ValidationEvent.Error)
+	case event.ApplyType:
+		e.ApplyEvent.Resource = Object(r, e.ApplyEvent.Resource)
+		e.ApplyEvent.Error = Error(r, e.ApplyEvent.Error)
+	case event.StatusType:
+		e.StatusEvent.Resource = Object(r, e.StatusEvent.Resource)
+		e.StatusEvent.PollResourceInfo = resourceStatus(r, e.StatusEvent.PollResourceInfo)
+		e.StatusEvent.Error = Error(r, e.StatusEvent.Error)
+	case event.PruneType:
+		e.PruneEvent.Object = Object(r, e.PruneEvent.Object)
+		e.PruneEvent.Error = Error(r, e.PruneEvent.Error)
+	case event.DeleteType:
+		e.DeleteEvent.Object = Object(r, e.DeleteEvent.Object)
+		e.DeleteEvent.Error = Error(r, e.DeleteEvent.Error)
+	case event.RetryType:
+		e.RetryEvent.Error = Error(r, e.RetryEvent.Error)
+	}
+	return e
+}
+
+func resourceStatus(r Redactor, rs *pollevent.ResourceStatus) *pollevent.ResourceStatus {
+	if rs == nil {
+		return nil
+	}
+	redacted := *rs
+	redacted.Resource = Object(r, rs.Resource)
+	redacted.Error = Error(r, rs.Error)
+	redacted.Message = r.RedactMessage(rs.Message)
+	if rs.GeneratedResources != nil {
+		redacted.GeneratedResources = make(pollevent.ResourceStatuses, len(rs.GeneratedResources))
+		for i, generated := range rs.GeneratedResources {
+			redacted.GeneratedResources[i] = resourceStatus(r, generated)
+		}
+	}
+	return &redacted
+}
+
+// Channel returns a channel that receives the redacted events from the
+// passed channel. The returned channel is closed when the passed channel is
+// closed.
+func Channel(r Redactor, ch <-chan event.Event) <-chan event.Event {
+	redactedCh := make(chan event.Event)
+	go func() {
+		defer close(redactedCh)
+		for e := range ch {
+			redactedCh <- Event(r, e)
+		}
+	}()
+	return redactedCh
+}
+
+// Printer wraps a Printer, redacting every event before it is printed.
+type Printer struct {
+	Printer  printer.Printer
+	Redactor Redactor
+}
+
+var _ printer.Printer = &Printer{}
+
+// Print prints the redacted events.
+func (p *Printer) Print(ch <-chan event.Event, previewStrategy common.DryRunStrategy, printStatus bool) error {
+	redactedCh := Channel(p.Redactor, ch)
+	err := p.Printer.Print(redactedCh, previewStrategy, printStatus)
+	// Drain the channel, in case the printer returned early, so the sender
+	// is not blocked.
+	for range redactedCh {
+	}
+	return err
+}
@@ -0,0 +1,191 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redact removes sensitive data, like Secret values, from objects,
+// events, and messages, so that output can be shipped to logging systems
+// without leaking credentials embedded in manifests.
+package redact
+
+import (
+	"errors"
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Placeholder replaces redacted values.
+const Placeholder = "REDACTED"
+
+// Redactor removes sensitive data.
+type Redactor interface {
+	// RedactObject removes sensitive data from the object, in place.
+	RedactObject(obj *unstructured.Unstructured)
+	// RedactMessage returns the message with sensitive data removed.
+	RedactMessage(msg string) string
+}
+
+// Chain is a Redactor that applies each of its Redactors in order.
+type Chain []Redactor
+
+var _ Redactor = Chain{}
+
+// RedactObject applies each Redactor to the object.
+func (c Chain) RedactObject(obj *unstructured.Unstructured) {
+	for _, r := range c {
+		r.RedactObject(obj)
+	}
+}
+
+// RedactMessage applies each Redactor to the message.
+func (c Chain) RedactMessage(msg string) string {
+	for _, r := range c {
+		msg = r.RedactMessage(msg)
+	}
+	return msg
+}
+
+// SecretData is a Redactor that replaces the values of Secret data and
+// stringData with the Placeholder, keeping the keys. The last applied
+// annotation is removed from Secrets, because it contains the values.
+type SecretData struct{}
+
+var _ Redactor = SecretData{}
+
+var secretGK = schema.GroupKind{Kind: "Secret"}
+
+// RedactObject redacts the object, if it is a Secret.
+func (SecretData) RedactObject(obj *unstructured.Unstructured) {
+	if obj == nil || obj.GroupVersionKind().GroupKind() != secretGK {
+		return
+	}
+	for _, field := range []string{"data", "stringData"} {
+		values, found, err := unstructured.NestedMap(obj.Object, field)
+		if err != nil || !found {
+			continue
+		}
+		for key := range values {
+			values[key] = Placeholder
+		}
+		_ = unstructured.SetNestedMap(obj.Object, values, field)
+	}
+	annotations := obj.GetAnnotations()
+	if _, found := annotations[corev1.LastAppliedConfigAnnotation]; found {
+		delete(annotations, corev1.LastAppliedConfigAnnotation)
+		obj.SetAnnotations(annotations)
+	}
+}
+
+// RedactMessage returns the message unchanged.
+func (SecretData) RedactMessage(msg string) string {
+	return msg
+}
+
+// Annotations is a Redactor that removes annotations with keys that match
+// any of the patterns.
+type Annotations struct {
+	Patterns []*regexp.Regexp
+}
+
+var _ Redactor = Annotations{}
+
+// RedactObject removes the matching annotations from the object.
+func (a Annotations) RedactObject(obj *unstructured.Unstructured) {
+	if obj == nil {
+		return
+	}
+	annotations := obj.GetAnnotations()
+	modified := false
+	for key := range annotations {
+		if matchAny(a.Patterns, key) {
+			delete(annotations, key)
+			modified = true
+		}
+	}
+	if modified {
+		obj.SetAnnotations(annotations)
+	}
+}
+
+// RedactMessage returns the message unchanged.
+func (a Annotations) RedactMessage(msg string) string {
+	return msg
+}
+
+// Messages is a Redactor that replaces the parts of messages that match any
+// of the patterns with the Placeholder.
+type Messages struct {
+	Patterns []*regexp.Regexp
+}
+
+var _ Redactor = Messages{}
+
+// RedactObject leaves the object unchanged.
+func (m Messages) RedactObject(_ *unstructured.Unstructured) {}
+
+// RedactMessage replaces the matching parts of the message.
+func (m Messages) RedactMessage(msg string) string {
+	for _, p := range m.Patterns {
+		msg = p.ReplaceAllString(msg, Placeholder)
+	}
+	return msg
+}
+
+func matchAny(patterns []*regexp.Regexp, s string) bool {
+	for _, p := range patterns {
+		if p.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Object returns a redacted copy of the object, leaving the original
+// unchanged. Returns nil if the object is nil.
+func Object(r Redactor, obj *unstructured.Unstructured) *unstructured.Unstructured {
+	if obj == nil {
+		return nil
+	}
+	obj = obj.DeepCopy()
+	r.RedactObject(obj)
+	return obj
+}
+
+// Objects returns redacted copies of the objects, for example before
+// diffing them or writing them to a summary.
+func Objects(r Redactor, objs []*unstructured.Unstructured) []*unstructured.Unstructured {
+	redacted := make([]*unstructured.Unstructured, len(objs))
+	for i, obj := range objs {
+		redacted[i] = Object(r, obj)
+	}
+	return redacted
+}
+
+// Error returns an error with a redacted message. errors.Is still matches
+// the original error, but the original error is not returned by Unwrap or
+// errors.As, so its message cannot leak. Returns nil if the error is nil.
+func Error(r Redactor, err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	redacted := r.RedactMessage(msg)
+	if redacted == msg {
+		return err
+	}
+	return &redactedError{msg: redacted, err: err}
+}
+
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Is(target error) bool {
+	return errors.Is(e.err, target)
+}
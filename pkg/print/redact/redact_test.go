@@ -0,0 +1,244 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package redact
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func secret() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata": map[string]interface{}{
+				"name":      "test-secret",
+				"namespace": "test-namespace",
+				"annotations": map[string]interface{}{
+					corev1.LastAppliedConfigAnnotation: `{"data":{"password":"aHVudGVyMg=="}}`,
+					"example.com/token":   "abc123",
+					"example.com/owner":   "team-a",
+				},
+			},
+			"data": map[string]interface{}{
+				"password": "aHVudGVyMg==",
+			},
+			"stringData": map[string]interface{}{
+				"username": "admin",
+			},
+		},
+	}
+}
+
+func configMap() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "test-cm",
+				"namespace": "test-namespace",
+			},
+			"data": map[string]interface{}{
+				"key": "value",
+			},
+		},
+	}
+}
+
+func TestSecretData(t *testing.T) {
+	obj := Object(SecretData{}, secret())
+	data, _, _ := unstructured.NestedStringMap(obj.Object, "data")
+	assert.Equal(t, map[string]string{"password": Placeholder}, data)
+	stringData, _, _ := unstructured.NestedStringMap(obj.Object, "stringData")
+	assert.Equal(t, map[string]string{"username": Placeholder}, stringData)
+	assert.NotContains(t, obj.GetAnnotations(), corev1.LastAppliedConfigAnnotation)
+	assert.Contains(t, obj.GetAnnotations(), "example.com/token")
+
+	// Other kinds are not redacted.
+	assert.Equal(t, configMap(), Object(SecretData{}, configMap()))
+}
+
+func TestAnnotations(t *testing.T) {
+	r := Annotations{Patterns: []*regexp.Regexp{regexp.MustCompile(`token$`)}}
+	obj := Object(r, secret())
+	assert.NotContains(t, obj.GetAnnotations(), "example.com/token")
+	assert.Contains(t, obj.GetAnnotations(), "example.com/owner")
+}
+
+func TestObject_DoesNotModifyOriginal(t *testing.T) {
+	original := secret()
+	_ = Object(SecretData{}, original)
+	assert.Equal(t, secret(), original)
+	assert.Nil(t, Object(SecretData{}, nil))
+}
+
+func TestError(t *testing.T) {
+	r := Messages{Patterns: []*regexp.Regexp{regexp.MustCompile(`password=\S+`)}}
+	cause := errors.New("apply failed: password=hunter2")
+
+	err := Error(r, cause)
+	assert.EqualError(t, err, "apply failed: REDACTED")
+	assert.True(t, errors.Is(err, cause))
+	assert.Nil(t, errors.Unwrap(err))
+	var wrapped interface{ Unwrap() error }
+	assert.False(t, errors.As(err, &wrapped))
+
+	unchanged := errors.New("apply failed")
+	assert.Equal(t, unchanged, Error(r, unchanged))
+	assert.Nil(t, Error(r, nil))
+}
+
+func TestEvent(t *testing.T) {
+	r := Chain{
+		SecretData{},
+		Messages{Patterns: []*regexp.Regexp{regexp.MustCompile(`hunter2`)}},
+	}
+	id := object.UnstructuredToObjMetadata(secret())
+
+	testCases := map[string]struct {
+		event      event.Event
+		redactedFn func(e event.Event) (*unstructured.Unstructured, error)
+	}{
+		"apply": {
+			event: event.Event{
+				Type: event.ApplyType,
+				ApplyEvent: event.ApplyEvent{
+					Identifier: id,
+					Resource:   secret(),
+					Error:      errors.New("invalid value hunter2"),
+				},
+			},
+			redactedFn: func(e event.Event) (*unstructured.Unstructured, error) {
+				return e.ApplyEvent.Resource, e.ApplyEvent.Error
+			},
+		},
+		"status": {
+			event: event.Event{
+				Type: event.StatusType,
+				StatusEvent: event.StatusEvent{
+					Identifier: id,
+					PollResourceInfo: &pollevent.ResourceStatus{
+						Identifier: id,
+						Resource:   secret(),
+					},
+					Resource: secret(),
+					Error:    errors.New("invalid value hunter2"),
+				},
+			},
+			redactedFn: func(e event.Event) (*unstructured.Unstructured, error) {
+				assert.Equal(t, e.StatusEvent.Resource, e.StatusEvent.PollResourceInfo.Resource)
+				return e.StatusEvent.Resource, e.StatusEvent.Error
+			},
+		},
+		"prune": {
+			event: event.Event{
+				Type: event.PruneType,
+				PruneEvent: event.PruneEvent{
+					Identifier: id,
+					Object:     secret(),
+					Error:      errors.New("invalid value hunter2"),
+				},
+			},
+			redactedFn: func(e event.Event) (*unstructured.Unstructured, error) {
+				return e.PruneEvent.Object, e.PruneEvent.Error
+			},
+		},
+		"delete": {
+			event: event.Event{
+				Type: event.DeleteType,
+				DeleteEvent: event.DeleteEvent{
+					Identifier: id,
+					Object:     secret(),
+					Error:      errors.New("invalid value hunter2"),
+				},
+			},
+			redactedFn: func(e event.Event) (*unstructured.Unstructured, error) {
+				return e.DeleteEvent.Object, e.DeleteEvent.Error
+			},
+		},
+		"error": {
+			event: event.Event{
+				Type: event.ErrorType,
+				ErrorEvent: event.ErrorEvent{
+					Err: errors.New("invalid value hunter2"),
+				},
+			},
+			redactedFn: func(e event.Event) (*unstructured.Unstructured, error) {
+				return nil, e.ErrorEvent.Err
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			redacted := Event(r, tc.event)
+			obj, err := tc.redactedFn(redacted)
+			if obj != nil {
+				data, _, _ := unstructured.NestedStringMap(obj.Object, "data")
+				assert.Equal(t, map[string]string{"password": Placeholder}, data)
+			}
+			assert.EqualError(t, err, "invalid value REDACTED")
+
+			// The original event is not modified.
+			obj, err = tc.redactedFn(tc.event)
+			if obj != nil {
+				assert.Equal(t, secret(), obj)
+			}
+			assert.EqualError(t, err, "invalid value hunter2")
+		})
+	}
+}
+
+type fakePrinter struct {
+	events []event.Event
+}
+
+func (f *fakePrinter) Print(ch <-chan event.Event, _ common.DryRunStrategy, _ bool) error {
+	for e := range ch {
+		f.events = append(f.events, e)
+		// Return early to verify the channel is drained.
+		if e.Type == event.ErrorType {
+			return e.ErrorEvent.Err
+		}
+	}
+	return nil
+}
+
+func TestPrinter(t *testing.T) {
+	ch := make(chan event.Event)
+	go func() {
+		defer close(ch)
+		ch <- event.Event{
+			Type:       event.ApplyType,
+			ApplyEvent: event.ApplyEvent{Resource: secret()},
+		}
+		ch <- event.Event{
+			Type:       event.ErrorType,
+			ErrorEvent: event.ErrorEvent{Err: errors.New("fatal")},
+		}
+		ch <- event.Event{
+			Type:       event.ApplyType,
+			ApplyEvent: event.ApplyEvent{Resource: secret()},
+		}
+	}()
+
+	inner := &fakePrinter{}
+	p := &Printer{Printer: inner, Redactor: SecretData{}}
+	err := p.Print(ch, common.DryRunNone, false)
+	assert.EqualError(t, err, "fatal")
+	assert.Len(t, inner.events, 2)
+	data, _, _ := unstructured.NestedStringMap(inner.events[0].ApplyEvent.Resource.Object, "data")
+	assert.Equal(t, map[string]string{"password": Placeholder}, data)
+}
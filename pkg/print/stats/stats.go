@@ -5,6 +5,9 @@ package stats
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"time"
 
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 )
@@ -17,6 +20,10 @@ type Stats struct {
 	PruneStats  PruneStats
 	DeleteStats DeleteStats
 	WaitStats   WaitStats
+
+	// Durations of the individual objects, only populated if the events
+	// include timing (see ApplierOptions.RecordTiming).
+	Durations Durations
 }
 
 // FailedActuationSum returns the number of resources that failed actuation.
@@ -34,19 +41,83 @@ func (s *Stats) Handle(e event.Event) {
 	switch e.Type {
 	case event.ApplyType:
 		s.ApplyStats.Inc(e.ApplyEvent.Status)
+		s.ApplyStats.IncOperation(e.ApplyEvent.Operation)
+		s.Durations.Apply.Add(e.ApplyEvent.Timing)
 	case event.PruneType:
 		s.PruneStats.Inc(e.PruneEvent.Status)
+		s.Durations.Prune.Add(e.PruneEvent.Timing)
 	case event.DeleteType:
 		s.DeleteStats.Inc(e.DeleteEvent.Status)
+		s.Durations.Delete.Add(e.DeleteEvent.Timing)
 	case event.WaitType:
 		s.WaitStats.Inc(e.WaitEvent.Status)
+		s.Durations.Wait.Add(e.WaitEvent.Timing)
 	}
 }
 
+// Durations captures the durations of the individual objects, by action.
+type Durations struct {
+	Apply  DurationList
+	Prune  DurationList
+	Delete DurationList
+	// Wait is the time from when objects were applied or deleted until they
+	// were reconciled, failed, or timed out.
+	Wait DurationList
+}
+
+// IsEmpty returns true if no durations were recorded.
+func (d *Durations) IsEmpty() bool {
+	return len(d.Apply) == 0 && len(d.Prune) == 0 && len(d.Delete) == 0 && len(d.Wait) == 0
+}
+
+// DurationList is a list of durations, in the order they were recorded.
+type DurationList []time.Duration
+
+// Add appends the duration of the timing. Zero timings are ignored.
+func (l *DurationList) Add(t event.Timing) {
+	if t.IsZero() {
+		return
+	}
+	*l = append(*l, t.Duration())
+}
+
+// Percentile returns the duration below which p percent of the durations
+// fall, using the nearest-rank method, or zero if the list is empty.
+// p must be between 0 and 100.
+func (l DurationList) Percentile(p float64) time.Duration {
+	if len(l) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(l))
+	copy(sorted, l)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// Max returns the longest duration, or zero if the list is empty.
+func (l DurationList) Max() time.Duration {
+	return l.Percentile(100)
+}
+
 type ApplyStats struct {
 	Successful int
 	Skipped    int
 	Failed     int
+
+	// Successful applies, by operation
+	Created           int
+	Configured        int
+	Unchanged         int
+	ServersideApplied int
 }
 
 func (a *ApplyStats) Inc(op event.ApplyEventStatus) {
@@ -62,6 +133,21 @@ func (a *ApplyStats) Inc(op event.ApplyEventStatus) {
 	}
 }
 
+// IncOperation increments the count for the operation of a successful apply.
+// Unspecified operations are ignored.
+func (a *ApplyStats) IncOperation(op event.ApplyEventOperation) {
+	switch op {
+	case event.ApplyCreated:
+		a.Created++
+	case event.ApplyConfigured:
+		a.Configured++
+	case event.ApplyUnchanged:
+		a.Unchanged++
+	case event.ApplyServersideApplied:
+		a.ServersideApplied++
+	}
+}
+
 func (a *ApplyStats) IncFailed() {
 	a.Failed++
 }
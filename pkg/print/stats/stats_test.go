@@ -0,0 +1,88 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+)
+
+func TestDurationList_Percentile(t *testing.T) {
+	testCases := map[string]struct {
+		durations DurationList
+		expected  map[float64]time.Duration
+	}{
+		"empty": {
+			durations: nil,
+			expected: map[float64]time.Duration{
+				50:  0,
+				100: 0,
+			},
+		},
+		"single": {
+			durations: DurationList{3 * time.Second},
+			expected: map[float64]time.Duration{
+				0:   3 * time.Second,
+				50:  3 * time.Second,
+				100: 3 * time.Second,
+			},
+		},
+		"unsorted": {
+			durations: DurationList{
+				5 * time.Second, 1 * time.Second, 9 * time.Second, 3 * time.Second, 7 * time.Second,
+				2 * time.Second, 10 * time.Second, 4 * time.Second, 8 * time.Second, 6 * time.Second,
+			},
+			expected: map[float64]time.Duration{
+				50:  5 * time.Second,
+				90:  9 * time.Second,
+				99:  10 * time.Second,
+				100: 10 * time.Second,
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			for p, expected := range tc.expected {
+				assert.Equal(t, expected, tc.durations.Percentile(p), "p%v", p)
+			}
+		})
+	}
+}
+
+func TestStats_HandleTiming(t *testing.T) {
+	start := time.Unix(100, 0)
+	s := Stats{}
+	s.Handle(event.Event{
+		Type: event.ApplyType,
+		ApplyEvent: event.ApplyEvent{
+			Status: event.ApplySuccessful,
+			Timing: event.Timing{Start: start, End: start.Add(2 * time.Second)},
+		},
+	})
+	// Events without timing are counted, but have no duration.
+	s.Handle(event.Event{
+		Type: event.ApplyType,
+		ApplyEvent: event.ApplyEvent{
+			Status: event.ApplySuccessful,
+		},
+	})
+	s.Handle(event.Event{
+		Type: event.WaitType,
+		WaitEvent: event.WaitEvent{
+			Status: event.ReconcileSuccessful,
+			Timing: event.Timing{Start: start, End: start.Add(time.Minute)},
+		},
+	})
+
+	assert.Equal(t, 2, s.ApplyStats.Successful)
+	assert.Equal(t, DurationList{2 * time.Second}, s.Durations.Apply)
+	assert.Equal(t, DurationList{time.Minute}, s.Durations.Wait)
+	assert.Empty(t, s.Durations.Prune)
+	assert.Empty(t, s.Durations.Delete)
+	assert.False(t, s.Durations.IsEmpty())
+}
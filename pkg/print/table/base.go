@@ -6,10 +6,12 @@ package table
 import (
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 	"unicode/utf8"
 
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/utils/integer"
 	pe "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/print/common"
@@ -43,11 +45,28 @@ type Resource interface {
 // about a set of resources into a table format.
 // The printer will print to the Out stream defined in IOStreams,
 // and will print into the format defined by the Column definitions.
+//
+// If TerminalWidth is set and returns a positive value, the columns are
+// sized to fit their content, but shrunk back towards the widths given
+// by the Column definitions when the table would otherwise be wider
+// than the terminal. It is called for every print, so the layout
+// follows the terminal as it is resized. If NoColor is true, the color
+// escape codes written by the columns are removed from the output.
 type BaseTablePrinter struct {
-	IOStreams genericclioptions.IOStreams
-	Columns   []ColumnDefinition
+	IOStreams     genericclioptions.IOStreams
+	Columns       []ColumnDefinition
+	TerminalWidth func() int
+	NoColor       bool
 }
 
+// colorCodeRegexp matches the ANSI escape codes used to set and reset
+// the color of the output.
+var colorCodeRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// measureWidth is the width given to columns when measuring the
+// width of their content.
+const measureWidth = 1024
+
 // PrintTable prints the resources defined in ResourceStates. It will
 // print subresources if they exist.
 // moveUpCount defines how many lines the printer should move up
@@ -60,9 +79,10 @@ func (t *BaseTablePrinter) PrintTable(rs ResourceStates,
 		t.eraseCurrentLine()
 	}
 
+	widths := t.columnWidths(rs.Resources())
 	linePrintCount := 0
 	for i, column := range t.Columns {
-		format := fmt.Sprintf("%%-%ds", column.Width())
+		format := fmt.Sprintf("%%-%ds", widths[i])
 		t.printOrDie(format, column.Header())
 		if i == len(t.Columns)-1 {
 			t.printOrDie("\n")
@@ -74,11 +94,11 @@ func (t *BaseTablePrinter) PrintTable(rs ResourceStates,
 
 	for _, resource := range rs.Resources() {
 		for i, column := range t.Columns {
-			written, err := column.PrintResource(t.IOStreams.Out, column.Width(), resource)
+			written, err := column.PrintResource(t.columnWriter(), widths[i], resource)
 			if err != nil {
 				panic(err)
 			}
-			remainingSpace := column.Width() - written
+			remainingSpace := widths[i] - written
 			t.printOrDie(strings.Repeat(" ", remainingSpace))
 			if i == len(t.Columns)-1 {
 				t.printOrDie("\n")
@@ -88,21 +108,134 @@ func (t *BaseTablePrinter) PrintTable(rs ResourceStates,
 			}
 		}
 
-		linePrintCount += t.printSubTable(resource.SubResources(), "")
+		linePrintCount += t.printSubTable(resource.SubResources(), "", widths)
 	}
 
 	return linePrintCount
 }
 
+// columnWidths returns the width to use for each of the columns. Unless
+// the width of the terminal is known, these are the widths given by the
+// Column definitions.
+func (t *BaseTablePrinter) columnWidths(resources []Resource) []int {
+	widths := make([]int, len(t.Columns))
+	for i, column := range t.Columns {
+		widths[i] = column.Width()
+	}
+	if t.TerminalWidth == nil {
+		return widths
+	}
+	termWidth := t.TerminalWidth()
+	if termWidth <= 0 {
+		return widths
+	}
+
+	// Grow each column to fit the header and the content of all
+	// resources, including the tree prefix of subresources.
+	for i, column := range t.Columns {
+		if l := utf8.RuneCountInString(column.Header()); l > widths[i] {
+			widths[i] = l
+		}
+		if l := t.contentWidth(column, resources, 0); l > widths[i] {
+			widths[i] = l
+		}
+	}
+
+	// Shrink the columns, starting with the last one, until the table
+	// fits in the terminal. Columns are first shrunk back to their
+	// default width, and then to the width of their header. The last
+	// character of each line is left empty to avoid the terminal
+	// wrapping the line.
+	available := termWidth - 1
+	total := tableWidth(widths)
+	for _, minWidth := range []func(ColumnDefinition) int{
+		func(c ColumnDefinition) int { return c.Width() },
+		func(c ColumnDefinition) int { return utf8.RuneCountInString(c.Header()) },
+	} {
+		for i := len(t.Columns) - 1; i >= 0 && total > available; i-- {
+			min := minWidth(t.Columns[i])
+			if widths[i] <= min {
+				continue
+			}
+			shrink := total - available
+			if widths[i]-min < shrink {
+				shrink = widths[i] - min
+			}
+			widths[i] -= shrink
+			total -= shrink
+		}
+	}
+	return widths
+}
+
+// contentWidth returns the width needed by the column to print all the
+// provided resources and their subresources without trimming them.
+func (t *BaseTablePrinter) contentWidth(column ColumnDefinition, resources []Resource, depth int) int {
+	width := 0
+	for _, resource := range resources {
+		written, err := column.PrintResource(io.Discard, measureWidth, resource)
+		if err != nil {
+			panic(err)
+		}
+		// Subresources are indented by a tree prefix of three
+		// characters, plus three more below the first level.
+		if column.Name() == "resource" && depth > 0 {
+			written += integer.IntMin(depth, 2) * 3
+		}
+		if written > width {
+			width = written
+		}
+		if l := t.contentWidth(column, resource.SubResources(), depth+1); l > width {
+			width = l
+		}
+	}
+	return width
+}
+
+// tableWidth returns the total width of a table with columns of the
+// provided widths.
+func tableWidth(widths []int) int {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	if len(widths) > 1 {
+		total += 2 * (len(widths) - 1)
+	}
+	return total
+}
+
+// columnWriter returns the writer that columns should print to.
+func (t *BaseTablePrinter) columnWriter() io.Writer {
+	if t.NoColor {
+		return &noColorWriter{w: t.IOStreams.Out}
+	}
+	return t.IOStreams.Out
+}
+
+// noColorWriter removes any color escape codes before writing
+// to the underlying writer.
+type noColorWriter struct {
+	w io.Writer
+}
+
+func (n *noColorWriter) Write(p []byte) (int, error) {
+	_, err := n.w.Write(colorCodeRegexp.ReplaceAll(p, nil))
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
 // printSubTable prints out any subresources that belong to the
 // top-level resources. This function takes care of printing the correct tree
 // structure and indentation.
 func (t *BaseTablePrinter) printSubTable(resources []Resource,
-	prefix string) int {
+	prefix string, widths []int) int {
 	linePrintCount := 0
 	for j, resource := range resources {
 		for i, column := range t.Columns {
-			availableWidth := column.Width()
+			availableWidth := widths[i]
 			if column.Name() == "resource" {
 				if j < len(resources)-1 {
 					t.printOrDie(prefix + `├─ `)
@@ -110,8 +243,11 @@ func (t *BaseTablePrinter) printSubTable(resources []Resource,
 					t.printOrDie(prefix + `└─ `)
 				}
 				availableWidth -= utf8.RuneCountInString(prefix) + 3
+				if availableWidth < 0 {
+					availableWidth = 0
+				}
 			}
-			written, err := column.PrintResource(t.IOStreams.Out,
+			written, err := column.PrintResource(t.columnWriter(),
 				availableWidth, resource)
 			if err != nil {
 				panic(err)
@@ -132,7 +268,7 @@ func (t *BaseTablePrinter) printSubTable(resources []Resource,
 		} else {
 			prefix = "   "
 		}
-		linePrintCount += t.printSubTable(resource.SubResources(), prefix)
+		linePrintCount += t.printSubTable(resource.SubResources(), prefix, widths)
 	}
 	return linePrintCount
 }
@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	pe "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	"sigs.k8s.io/cli-utils/pkg/object"
 )
 
@@ -32,6 +33,8 @@ func TestBaseTablePrinter_PrintTable(t *testing.T) {
 	testCases := map[string]struct {
 		columnDefinitions []ColumnDefinition
 		resources         []Resource
+		terminalWidth     int
+		noColor           bool
 		expectedOutput    string
 	}{
 		"no resources": {
@@ -127,6 +130,121 @@ Deployment/Foo                            end
 			expectedOutput: `
 RESOURCE                                  END
 Deployment/VeryLongNameThatShouldBeTrimm  end
+`,
+		},
+		"expand long content to terminal width": {
+			columnDefinitions: []ColumnDefinition{
+				MustColumn("resource"),
+				endColumnDef,
+			},
+			resources: []Resource{
+				&fakeResource{
+					resourceStatus: &pe.ResourceStatus{
+						Identifier: object.ObjMetadata{
+							Namespace: "default",
+							Name:      "VeryLongNameThatShouldBeTrimmed",
+							GroupKind: schema.GroupKind{
+								Group: "apps",
+								Kind:  "Deployment",
+							},
+						},
+					},
+				},
+			},
+			terminalWidth: 80,
+			expectedOutput: `
+RESOURCE                                    END
+Deployment/VeryLongNameThatShouldBeTrimmed  end
+`,
+		},
+		"shrink columns to narrow terminal": {
+			columnDefinitions: []ColumnDefinition{
+				MustColumn("resource"),
+				endColumnDef,
+			},
+			resources: []Resource{
+				&fakeResource{
+					resourceStatus: &pe.ResourceStatus{
+						Identifier: object.ObjMetadata{
+							Namespace: "default",
+							Name:      "VeryLongNameThatShouldBeTrimmed",
+							GroupKind: schema.GroupKind{
+								Group: "apps",
+								Kind:  "Deployment",
+							},
+						},
+					},
+				},
+			},
+			terminalWidth: 31,
+			expectedOutput: `
+RESOURCE                   END
+Deployment/VeryLongNameTh  end
+`,
+		},
+		"sub resources fit terminal width": {
+			columnDefinitions: []ColumnDefinition{
+				MustColumn("resource"),
+				endColumnDef,
+			},
+			resources: []Resource{
+				&fakeResource{
+					resourceStatus: &pe.ResourceStatus{
+						Identifier: object.ObjMetadata{
+							Namespace: "default",
+							Name:      "Foo",
+							GroupKind: schema.GroupKind{
+								Group: "apps",
+								Kind:  "Deployment",
+							},
+						},
+						GeneratedResources: []*pe.ResourceStatus{
+							{
+								Identifier: object.ObjMetadata{
+									Namespace: "default",
+									Name:      "VeryLongReplicaSetNameThatIsLong",
+									GroupKind: schema.GroupKind{
+										Group: "apps",
+										Kind:  "ReplicaSet",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			terminalWidth: 120,
+			expectedOutput: `
+RESOURCE                                        END
+Deployment/Foo                                  end
+└─ ReplicaSet/VeryLongReplicaSetNameThatIsLong  end
+`,
+		},
+		"strip colors": {
+			columnDefinitions: []ColumnDefinition{
+				MustColumn("resource"),
+				MustColumn("status"),
+				endColumnDef,
+			},
+			resources: []Resource{
+				&fakeResource{
+					resourceStatus: &pe.ResourceStatus{
+						Identifier: object.ObjMetadata{
+							Namespace: "default",
+							Name:      "Foo",
+							GroupKind: schema.GroupKind{
+								Group: "apps",
+								Kind:  "Deployment",
+							},
+						},
+						Status: status.CurrentStatus,
+					},
+				},
+			},
+			noColor: true,
+			expectedOutput: `
+RESOURCE                                  STATUS      END
+Deployment/Foo                            Current     end
 `,
 		},
 	}
@@ -138,6 +256,12 @@ Deployment/VeryLongNameThatShouldBeTrimm  end
 			printer := &BaseTablePrinter{
 				IOStreams: ioStreams,
 				Columns:   tc.columnDefinitions,
+				NoColor:   tc.noColor,
+			}
+			if tc.terminalWidth > 0 {
+				printer.TerminalWidth = func() int {
+					return tc.terminalWidth
+				}
 			}
 
 			resourceStates := &fakeResourceStates{
@@ -93,7 +93,8 @@ var (
 			},
 		},
 		// status defines a column that outputs the status of a resource. It
-		// will use ansii escape codes to color the output.
+		// will use ansii escape codes to color the output. Resources with a
+		// stale status are shown as Stale.
 		"status": {
 			ColumnName:   "status",
 			ColumnHeader: "STATUS",
@@ -105,6 +106,9 @@ var (
 					return 0, nil
 				}
 				s := rs.Status.String()
+				if rs.GenerationLag != nil {
+					s = "Stale"
+				}
 				if len(s) > width {
 					s = s[:width]
 				}
@@ -89,6 +89,20 @@ func TestColumnDefs(t *testing.T) {
 			columnWidth:    10,
 			expectedOutput: "\x1b[32mCurrent\x1b[0m",
 		},
+		"status stale": {
+			columnName: "status",
+			resource: &fakeResource{
+				resourceStatus: &pe.ResourceStatus{
+					Status: status.InProgressStatus,
+					GenerationLag: &status.GenerationLag{
+						Generation:         5,
+						ObservedGeneration: 3,
+					},
+				},
+			},
+			columnWidth:    10,
+			expectedOutput: "\x1b[33mStale\x1b[0m",
+		},
 		"status trimmed": {
 			columnName: "status",
 			resource: &fakeResource{
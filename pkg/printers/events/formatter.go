@@ -6,11 +6,14 @@ package events
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/apply"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
 	"sigs.k8s.io/cli-utils/pkg/print/list"
@@ -18,14 +21,29 @@ import (
 )
 
 func NewFormatter(ioStreams genericclioptions.IOStreams,
-	_ common.DryRunStrategy) list.Formatter {
+	previewStrategy common.DryRunStrategy) list.Formatter {
+	return NewFormatterWithMode(ioStreams, previewStrategy, Verbose)
+}
+
+// NewFormatterWithMode returns a formatter that only prints the events
+// selected by the provided Mode.
+func NewFormatterWithMode(ioStreams genericclioptions.IOStreams,
+	_ common.DryRunStrategy, mode Mode) list.Formatter {
 	return &formatter{
 		ioStreams: ioStreams,
+		mode:      mode,
 	}
 }
 
 type formatter struct {
 	ioStreams genericclioptions.IOStreams
+	mode      Mode
+}
+
+// verbose returns true if events that do not report a failure
+// should be printed.
+func (ef *formatter) verbose() bool {
+	return ef.mode == Verbose
 }
 
 func (ef *formatter) FormatValidationEvent(ve event.ValidationEvent) error {
@@ -61,53 +79,160 @@ func (ef *formatter) FormatValidationEvent(ve event.ValidationEvent) error {
 func (ef *formatter) FormatApplyEvent(e event.ApplyEvent) error {
 	gk := e.Identifier.GroupKind
 	name := e.Identifier.Name
-	if e.Error != nil {
+	status := strings.ToLower(e.Status.String()) + durationToString(e.Timing)
+	switch {
+	case e.Error != nil:
 		ef.print("%s apply %s: %s", resourceIDToString(gk, name),
-			strings.ToLower(e.Status.String()), e.Error.Error())
-	} else {
+			status, e.Error.Error())
+	case !ef.verbose():
+	case e.Operation != event.ApplyUnspecified:
+		ef.print("%s apply %s (%s)", resourceIDToString(gk, name),
+			status, applyOperationToString(e.Operation))
+	default:
 		ef.print("%s apply %s", resourceIDToString(gk, name),
-			strings.ToLower(e.Status.String()))
+			status)
+	}
+	// Warnings are printed in all modes, like errors.
+	for _, warning := range e.Warnings {
+		ef.print("%s apply warning: %s", resourceIDToString(gk, name), warning)
+	}
+	for _, c := range e.Conflicts {
+		ef.print("%s apply conflict: %s is owned by field manager %q", resourceIDToString(gk, name),
+			c.Field, c.Manager)
 	}
 	return nil
 }
 
 func (ef *formatter) FormatStatusEvent(se event.StatusEvent) error {
+	if !ef.verbose() {
+		return nil
+	}
 	id := se.Identifier
 	ef.printResourceStatus(id, se)
 	return nil
 }
 
 func (ef *formatter) FormatPruneEvent(e event.PruneEvent) error {
+	if e.Error == nil && !ef.verbose() {
+		return nil
+	}
 	gk := e.Identifier.GroupKind
 	name := e.Identifier.Name
+	status := strings.ToLower(e.Status.String())
+	if e.Operation != event.PruneUnspecified {
+		status = fmt.Sprintf("%s (%s)", status, strings.ToLower(e.Operation.String()))
+	}
+	status += durationToString(e.Timing)
 	if e.Error != nil {
 		ef.print("%s prune %s: %s", resourceIDToString(gk, name),
-			strings.ToLower(e.Status.String()), e.Error.Error())
+			status, e.Error.Error())
 	} else {
 		ef.print("%s prune %s", resourceIDToString(gk, name),
-			strings.ToLower(e.Status.String()))
+			status)
 	}
 	return nil
 }
 
 func (ef *formatter) FormatDeleteEvent(e event.DeleteEvent) error {
+	if e.Error == nil && !ef.verbose() {
+		return nil
+	}
 	gk := e.Identifier.GroupKind
 	name := e.Identifier.Name
+	status := strings.ToLower(e.Status.String()) + durationToString(e.Timing)
 	if e.Error != nil {
 		ef.print("%s delete %s: %s", resourceIDToString(gk, name),
-			strings.ToLower(e.Status.String()), e.Error.Error())
+			status, e.Error.Error())
 	} else {
 		ef.print("%s delete %s", resourceIDToString(gk, name),
-			strings.ToLower(e.Status.String()))
+			status)
 	}
 	return nil
 }
 
 func (ef *formatter) FormatWaitEvent(e event.WaitEvent) error {
+	failed := e.Status == event.ReconcileFailed || e.Status == event.ReconcileTimeout
+	if !failed && !ef.verbose() {
+		return nil
+	}
 	gk := e.Identifier.GroupKind
 	name := e.Identifier.Name
-	ef.print("%s reconcile %s", resourceIDToString(gk, name),
-		strings.ToLower(e.Status.String()))
+	ef.print("%s reconcile %s%s", resourceIDToString(gk, name),
+		strings.ToLower(e.Status.String()), durationToString(e.Timing))
+	return nil
+}
+
+// statusCountOrder is the order in which the status counts of a
+// WaitSummaryEvent are printed.
+var statusCountOrder = []status.Status{
+	status.CurrentStatus,
+	status.InProgressStatus,
+	status.FailedStatus,
+	status.TerminatingStatus,
+	status.NotFoundStatus,
+	status.UnknownStatus,
+}
+
+func (ef *formatter) FormatWaitSummaryEvent(e event.WaitSummaryEvent) error {
+	if !ef.verbose() {
+		return nil
+	}
+	if e.Total > 0 {
+		var counts []string
+		for _, s := range statusCountOrder {
+			if count := e.Counts[s]; count > 0 {
+				counts = append(counts, fmt.Sprintf("%d %s", count, s))
+			}
+		}
+		ef.print("%s status after %s: %s (%d total)", e.GroupName,
+			e.Elapsed.Round(time.Second), strings.Join(counts, ", "), e.Total)
+	}
+	for _, group := range e.Pending {
+		names := make([]string, len(group.Identifiers))
+		for i, id := range group.Identifiers {
+			names[i] = resourceIDToString(id.GroupKind, id.Name)
+		}
+		ef.print("still waiting on %d %s (%s) after %s: %s", len(group.Identifiers),
+			strings.ToLower(group.GroupKind.String()), group.Status,
+			e.Elapsed.Round(time.Second), strings.Join(names, ", "))
+	}
+	for _, obj := range e.Terminating {
+		ef.print("%s terminating since %s, blocked by finalizers: %s",
+			resourceIDToString(obj.Identifier.GroupKind, obj.Identifier.Name),
+			obj.DeletionTimestamp.UTC().Format(time.RFC3339), strings.Join(obj.Finalizers, ", "))
+	}
+	return nil
+}
+
+func (ef *formatter) FormatRetryEvent(e event.RetryEvent) error {
+	if !ef.verbose() {
+		return nil
+	}
+	ef.print("%s %s failed, retrying in %s (%d/%d): %s",
+		resourceIDToString(e.Identifier.GroupKind, e.Identifier.Name),
+		strings.ToLower(e.Action.String()), e.Backoff, e.Retry, e.MaxRetries, e.Error.Error())
+	return nil
+}
+
+func (ef *formatter) FormatRateLimitEvent(e event.RateLimitEvent) error {
+	if !ef.verbose() {
+		return nil
+	}
+	switch {
+	case e.PriorityAndFairnessEnabled:
+		ef.print("server-side throttling enabled, client-side rate limiting disabled")
+	case e.QPS < 0:
+		ef.print("client-side rate limiting disabled")
+	default:
+		ef.print("server-side throttling disabled, client-side rate limit: %v qps, burst %d", e.QPS, e.Burst)
+	}
+	return nil
+}
+
+func (ef *formatter) FormatVerificationEvent(e event.VerificationEvent) error {
+	if ef.verbose() {
+		ef.print("%s verified (%s signature by %s)", e.Source, e.Method, e.Signer)
+	}
 	return nil
 }
 
@@ -121,6 +246,9 @@ func (ef *formatter) FormatActionGroupEvent(
 	s stats.Stats,
 	_ list.Collector,
 ) error {
+	if !ef.verbose() {
+		return nil
+	}
 	switch age.Action {
 	case event.ApplyAction:
 		ef.print("apply phase %s", strings.ToLower(age.Status.String()))
@@ -132,17 +260,31 @@ func (ef *formatter) FormatActionGroupEvent(
 		ef.print("reconcile phase %s", strings.ToLower(age.Status.String()))
 	case event.InventoryAction:
 		ef.print("inventory update %s", strings.ToLower(age.Status.String()))
+	case event.ValidateAction:
+		ef.print("validation phase %s", strings.ToLower(age.Status.String()))
 	default:
 		return fmt.Errorf("invalid action group action: %+v", age)
 	}
 	return nil
 }
 
-func (ef *formatter) FormatSummary(s stats.Stats) error {
+func (ef *formatter) FormatSummary(s stats.Stats, result *apply.RunResult) error {
+	if ef.mode == Quiet {
+		return nil
+	}
+	// A graceful cancellation still sends events for every object, but the
+	// stats of a run that ended with any other fatal error are incomplete.
+	if result.Class() == apply.ResultError {
+		return nil
+	}
 	if s.ApplyStats != (stats.ApplyStats{}) {
 		as := s.ApplyStats
 		ef.print("apply result: %d attempted, %d successful, %d skipped, %d failed",
 			as.Sum(), as.Successful, as.Skipped, as.Failed)
+		if ef.verbose() && as.Created+as.Configured+as.Unchanged+as.ServersideApplied > 0 {
+			ef.print("apply operations: %d created, %d configured, %d unchanged, %d serverside-applied",
+				as.Created, as.Configured, as.Unchanged, as.ServersideApplied)
+		}
 	}
 	if s.PruneStats != (stats.PruneStats{}) {
 		ps := s.PruneStats
@@ -159,9 +301,40 @@ func (ef *formatter) FormatSummary(s stats.Stats) error {
 		ef.print("reconcile result: %d attempted, %d successful, %d skipped, %d failed, %d timed out",
 			ws.Sum(), ws.Successful, ws.Skipped, ws.Failed, ws.Timeout)
 	}
+	if ef.mode == Summary {
+		ef.printTotals(s)
+		return nil
+	}
+	ef.printDurations("apply", s.Durations.Apply)
+	ef.printDurations("prune", s.Durations.Prune)
+	ef.printDurations("delete", s.Durations.Delete)
+	ef.printDurations("reconcile", s.Durations.Wait)
 	return nil
 }
 
+// printTotals prints the sum of the results of all phases. Objects
+// that timed out during reconciliation are counted as failed.
+func (ef *formatter) printTotals(s stats.Stats) {
+	attempted := s.ApplyStats.Sum() + s.PruneStats.Sum() + s.DeleteStats.Sum() + s.WaitStats.Sum()
+	successful := s.ApplyStats.Successful + s.PruneStats.Successful +
+		s.DeleteStats.Successful + s.WaitStats.Successful
+	skipped := s.ApplyStats.Skipped + s.PruneStats.Skipped +
+		s.DeleteStats.Skipped + s.WaitStats.Skipped
+	failed := s.FailedActuationSum() + s.FailedReconciliationSum()
+	ef.print("total: %d attempted, %d successful, %d skipped, %d failed",
+		attempted, successful, skipped, failed)
+}
+
+// printDurations prints the percentiles of the durations, if any.
+func (ef *formatter) printDurations(action string, durations stats.DurationList) {
+	if len(durations) == 0 {
+		return
+	}
+	ef.print("%s durations: p50 %s, p90 %s, p99 %s, max %s", action,
+		roundDuration(durations.Percentile(50)), roundDuration(durations.Percentile(90)),
+		roundDuration(durations.Percentile(99)), roundDuration(durations.Max()))
+}
+
 func (ef *formatter) printResourceStatus(id object.ObjMetadata, se event.StatusEvent) {
 	ef.print("%s is %s: %s", resourceIDToString(id.GroupKind, id.Name),
 		se.PollResourceInfo.Status.String(), se.PollResourceInfo.Message)
@@ -171,6 +344,36 @@ func (ef *formatter) print(format string, a ...interface{}) {
 	_, _ = fmt.Fprintf(ef.ioStreams.Out, format+"\n", a...)
 }
 
+// applyOperationToString returns the kubectl verb for an apply operation.
+func applyOperationToString(op event.ApplyEventOperation) string {
+	switch op {
+	case event.ApplyCreated:
+		return "created"
+	case event.ApplyConfigured:
+		return "configured"
+	case event.ApplyUnchanged:
+		return "unchanged"
+	case event.ApplyServersideApplied:
+		return "serverside-applied"
+	default:
+		return strings.ToLower(op.String())
+	}
+}
+
+// durationToString returns the duration of the timing, to append to the
+// status of an event, or an empty string if the event has no timing.
+func durationToString(t event.Timing) string {
+	if t.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf(" in %s", roundDuration(t.Duration()))
+}
+
+// roundDuration rounds a duration to milliseconds, for readability.
+func roundDuration(d time.Duration) time.Duration {
+	return d.Round(time.Millisecond)
+}
+
 // resourceIDToString returns the string representation of a GroupKind and a resource name.
 func resourceIDToString(gk schema.GroupKind, name string) string {
 	return fmt.Sprintf("%s/%s", strings.ToLower(gk.String()), name)
@@ -8,12 +8,14 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/apply"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
@@ -22,6 +24,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/object/graph"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
 	"sigs.k8s.io/cli-utils/pkg/print/list"
+	"sigs.k8s.io/cli-utils/pkg/print/stats"
 )
 
 func TestFormatter_FormatApplyEvent(t *testing.T) {
@@ -55,6 +58,24 @@ func TestFormatter_FormatApplyEvent(t *testing.T) {
 			},
 			expected: "cronjob.batch/my-cron apply successful",
 		},
+		"resource created": {
+			previewStrategy: common.DryRunNone,
+			event: event.ApplyEvent{
+				Status:     event.ApplySuccessful,
+				Operation:  event.ApplyCreated,
+				Identifier: createIdentifier("apps", "Deployment", "default", "my-dep"),
+			},
+			expected: "deployment.apps/my-dep apply successful (created)",
+		},
+		"resource serverside-applied": {
+			previewStrategy: common.DryRunNone,
+			event: event.ApplyEvent{
+				Status:     event.ApplySuccessful,
+				Operation:  event.ApplyServersideApplied,
+				Identifier: createIdentifier("apps", "Deployment", "default", "my-dep"),
+			},
+			expected: "deployment.apps/my-dep apply successful (serverside-applied)",
+		},
 		"apply event with error should display the error": {
 			previewStrategy: common.DryRunServer,
 			event: event.ApplyEvent{
@@ -73,6 +94,43 @@ func TestFormatter_FormatApplyEvent(t *testing.T) {
 			},
 			expected: "deployment.apps/my-dep apply skipped: this is a test error",
 		},
+		"apply event with timing should display the duration": {
+			previewStrategy: common.DryRunNone,
+			event: event.ApplyEvent{
+				Status:     event.ApplySuccessful,
+				Operation:  event.ApplyConfigured,
+				Identifier: createIdentifier("apps", "Deployment", "default", "my-dep"),
+				Timing: event.Timing{
+					Start: time.Unix(100, 0),
+					End:   time.Unix(101, int64(250*time.Millisecond)),
+				},
+			},
+			expected: "deployment.apps/my-dep apply successful in 1.25s (configured)",
+		},
+		"apply event with warnings should display the warnings": {
+			previewStrategy: common.DryRunNone,
+			event: event.ApplyEvent{
+				Status:     event.ApplySuccessful,
+				Operation:  event.ApplyConfigured,
+				Identifier: createIdentifier("apps", "Deployment", "default", "my-dep"),
+				Warnings:   []string{`unknown field "spec.replica"`},
+			},
+			expected: "deployment.apps/my-dep apply successful (configured)\n" +
+				`deployment.apps/my-dep apply warning: unknown field "spec.replica"`,
+		},
+		"apply event with conflicts should display the conflicts": {
+			previewStrategy: common.DryRunNone,
+			event: event.ApplyEvent{
+				Status:     event.ApplyFailed,
+				Identifier: createIdentifier("apps", "Deployment", "default", "my-dep"),
+				Error:      errors.New("Apply failed with 1 conflict"),
+				Conflicts: []event.FieldConflict{
+					{Field: ".spec.replicas", Manager: "hpa-controller"},
+				},
+			},
+			expected: "deployment.apps/my-dep apply failed: Apply failed with 1 conflict\n" +
+				`deployment.apps/my-dep apply conflict: .spec.replicas is owned by field manager "hpa-controller"`,
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -158,6 +216,17 @@ func TestFormatter_FormatPruneEvent(t *testing.T) {
 			},
 			expected: "deployment.apps/my-dep prune skipped",
 		},
+		"resource orphaned": {
+			previewStrategy: common.DryRunNone,
+			event: event.PruneEvent{
+				Status:     event.PruneSkipped,
+				Operation:  event.PruneOrphaned,
+				Object:     createObject("", "ConfigMap", "default", "my-cm"),
+				Identifier: createIdentifier("", "ConfigMap", "default", "my-cm"),
+				Error:      fmt.Errorf("annotation prevents deletion"),
+			},
+			expected: "configmap/my-cm prune skipped (orphaned): annotation prevents deletion",
+		},
 		"resource with prune error": {
 			previewStrategy: common.DryRunNone,
 			event: event.PruneEvent{
@@ -268,6 +337,19 @@ func TestFormatter_FormatWaitEvent(t *testing.T) {
 			},
 			expected: "deployment.apps/my-dep reconcile successful",
 		},
+		"resource reconciled with timing": {
+			previewStrategy: common.DryRunNone,
+			event: event.WaitEvent{
+				GroupName:  "wait-1",
+				Status:     event.ReconcileSuccessful,
+				Identifier: createIdentifier("apps", "Deployment", "default", "my-dep"),
+				Timing: event.Timing{
+					Start: time.Unix(100, 0),
+					End:   time.Unix(142, 0),
+				},
+			},
+			expected: "deployment.apps/my-dep reconcile successful in 42s",
+		},
 		"resource reconciled (client-side dry-run)": {
 			previewStrategy: common.DryRunClient,
 			event: event.WaitEvent{
@@ -363,6 +445,253 @@ func TestFormatter_FormatWaitEvent(t *testing.T) {
 	}
 }
 
+func TestFormatter_FormatWaitSummaryEvent(t *testing.T) {
+	testCases := map[string]struct {
+		event    event.WaitSummaryEvent
+		expected string
+	}{
+		"nothing pending": {
+			event: event.WaitSummaryEvent{
+				GroupName: "wait-1",
+				Elapsed:   30 * time.Second,
+			},
+			expected: "",
+		},
+		"grouped by kind and status": {
+			event: event.WaitSummaryEvent{
+				GroupName: "wait-1",
+				Elapsed:   60*time.Second + 400*time.Millisecond,
+				Pending: []event.WaitSummaryGroup{
+					{
+						GroupKind: schema.GroupKind{Group: "apps", Kind: "StatefulSet"},
+						Status:    status.InProgressStatus,
+						Identifiers: object.ObjMetadataSet{
+							createIdentifier("apps", "StatefulSet", "default", "db-a"),
+							createIdentifier("apps", "StatefulSet", "default", "db-b"),
+						},
+					},
+					{
+						GroupKind: schema.GroupKind{Kind: "Pod"},
+						Status:    status.FailedStatus,
+						Identifiers: object.ObjMetadataSet{
+							createIdentifier("", "Pod", "default", "my-pod"),
+						},
+					},
+				},
+			},
+			expected: "still waiting on 2 statefulset.apps (InProgress) after 1m0s: statefulset.apps/db-a, statefulset.apps/db-b\n" +
+				"still waiting on 1 pod (Failed) after 1m0s: pod/my-pod",
+		},
+		"terminating objects": {
+			event: event.WaitSummaryEvent{
+				GroupName: "wait-1",
+				Elapsed:   30 * time.Second,
+				Pending: []event.WaitSummaryGroup{
+					{
+						GroupKind: schema.GroupKind{Kind: "Namespace"},
+						Status:    status.TerminatingStatus,
+						Identifiers: object.ObjMetadataSet{
+							createIdentifier("", "Namespace", "", "my-ns"),
+						},
+					},
+				},
+				Terminating: []event.TerminatingObject{
+					{
+						Identifier:        createIdentifier("", "Namespace", "", "my-ns"),
+						DeletionTimestamp: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+						Finalizers:        []string{"kubernetes", "example.com/cleanup"},
+					},
+				},
+			},
+			expected: "still waiting on 1 namespace (Terminating) after 30s: namespace/my-ns\n" +
+				"namespace/my-ns terminating since 2022-01-01T00:00:00Z, blocked by finalizers: kubernetes, example.com/cleanup",
+		},
+		"counts in status order": {
+			event: event.WaitSummaryEvent{
+				GroupName: "wait-1",
+				Elapsed:   60*time.Second + 400*time.Millisecond,
+				Total:     10,
+				Counts: map[status.Status]int{
+					status.FailedStatus:     2,
+					status.InProgressStatus: 5,
+					status.CurrentStatus:    3,
+				},
+			},
+			expected: "wait-1 status after 1m0s: 3 Current, 5 InProgress, 2 Failed (10 total)",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams() //nolint:dogsled
+			formatter := NewFormatter(ioStreams, common.DryRunNone)
+			err := formatter.FormatWaitSummaryEvent(tc.event)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expected, strings.TrimSpace(out.String()))
+		})
+	}
+}
+
+func TestFormatter_Mode(t *testing.T) {
+	depID := createIdentifier("apps", "Deployment", "default", "my-dep")
+	cmID := createIdentifier("", "ConfigMap", "default", "my-cm")
+	summary := stats.Stats{
+		ApplyStats: stats.ApplyStats{Successful: 1, Failed: 1, Created: 1},
+		WaitStats:  stats.WaitStats{Successful: 1},
+	}
+
+	testCases := map[string]struct {
+		mode     Mode
+		expected []string
+	}{
+		"verbose": {
+			mode: Verbose,
+			expected: []string{
+				"deployment.apps/my-dep apply successful (created)",
+				"deployment.apps/my-dep apply warning: unknown field",
+				"configmap/my-cm apply failed: conflict",
+				"deployment.apps/my-dep reconcile successful",
+				"apply phase finished",
+				"apply result: 2 attempted, 1 successful, 0 skipped, 1 failed",
+				"apply operations: 1 created, 0 configured, 0 unchanged, 0 serverside-applied",
+				"reconcile result: 1 attempted, 1 successful, 0 skipped, 0 failed, 0 timed out",
+			},
+		},
+		"quiet": {
+			mode: Quiet,
+			expected: []string{
+				"deployment.apps/my-dep apply warning: unknown field",
+				"configmap/my-cm apply failed: conflict",
+			},
+		},
+		"summary": {
+			mode: Summary,
+			expected: []string{
+				"deployment.apps/my-dep apply warning: unknown field",
+				"configmap/my-cm apply failed: conflict",
+				"apply result: 2 attempted, 1 successful, 0 skipped, 1 failed",
+				"reconcile result: 1 attempted, 1 successful, 0 skipped, 0 failed, 0 timed out",
+				"total: 3 attempted, 2 successful, 0 skipped, 1 failed",
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams() //nolint:dogsled
+			formatter := NewFormatterWithMode(ioStreams, common.DryRunNone, tc.mode)
+
+			assert.NoError(t, formatter.FormatApplyEvent(event.ApplyEvent{
+				Status:     event.ApplySuccessful,
+				Operation:  event.ApplyCreated,
+				Identifier: depID,
+				Warnings:   []string{"unknown field"},
+			}))
+			assert.NoError(t, formatter.FormatApplyEvent(event.ApplyEvent{
+				Status:     event.ApplyFailed,
+				Identifier: cmID,
+				Error:      errors.New("conflict"),
+			}))
+			assert.NoError(t, formatter.FormatWaitEvent(event.WaitEvent{
+				Status:     event.ReconcileSuccessful,
+				Identifier: depID,
+			}))
+			assert.NoError(t, formatter.FormatActionGroupEvent(event.ActionGroupEvent{
+				Action: event.ApplyAction,
+				Status: event.Finished,
+			}, nil, summary, nil))
+			assert.NoError(t, formatter.FormatSummary(summary, apply.NewRunResult()))
+
+			assert.Equal(t, tc.expected, strings.Split(strings.TrimSpace(out.String()), "\n"))
+		})
+	}
+}
+
+func TestFormatter_FormatRetryEvent(t *testing.T) {
+	testCases := map[string]struct {
+		event    event.RetryEvent
+		expected string
+	}{
+		"apply retry": {
+			event: event.RetryEvent{
+				GroupName:  "apply-1",
+				Identifier: createIdentifier("apps", "Deployment", "default", "my-dep"),
+				Action:     event.ApplyAction,
+				Retry:      1,
+				MaxRetries: 3,
+				Backoff:    500 * time.Millisecond,
+				Error:      fmt.Errorf("the server is currently unable to handle the request"),
+			},
+			expected: "deployment.apps/my-dep apply failed, retrying in 500ms (1/3): " +
+				"the server is currently unable to handle the request",
+		},
+		"delete retry": {
+			event: event.RetryEvent{
+				GroupName:  "delete-0",
+				Identifier: createIdentifier("", "ConfigMap", "default", "my-cm"),
+				Action:     event.DeleteAction,
+				Retry:      2,
+				MaxRetries: 2,
+				Backoff:    time.Second,
+				Error:      fmt.Errorf("too many requests"),
+			},
+			expected: "configmap/my-cm delete failed, retrying in 1s (2/2): too many requests",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams() //nolint:dogsled
+			formatter := NewFormatter(ioStreams, common.DryRunNone)
+			err := formatter.FormatRetryEvent(tc.event)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expected, strings.TrimSpace(out.String()))
+		})
+	}
+}
+
+func TestFormatter_FormatVerificationEvent(t *testing.T) {
+	testCases := map[string]struct {
+		mode     Mode
+		event    event.VerificationEvent
+		expected string
+	}{
+		"successful": {
+			mode: Verbose,
+			event: event.VerificationEvent{
+				Source: "manifests.yaml",
+				Digest: "sha256:def",
+				Method: "cosign",
+				Signer: "sha256:abc",
+			},
+			expected: "manifests.yaml verified (cosign signature by sha256:abc)",
+		},
+		"successful in quiet mode": {
+			mode: Quiet,
+			event: event.VerificationEvent{
+				Source: "manifests.yaml",
+				Digest: "sha256:def",
+				Method: "cosign",
+				Signer: "sha256:abc",
+			},
+			expected: "",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams() //nolint:dogsled
+			formatter := NewFormatterWithMode(ioStreams, common.DryRunNone, tc.mode)
+			err := formatter.FormatVerificationEvent(tc.event)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expected, strings.TrimSpace(out.String()))
+		})
+	}
+}
+
 func TestFormatter_FormatValidationEvent(t *testing.T) {
 	testCases := map[string]struct {
 		previewStrategy common.DryRunStrategy
@@ -486,7 +815,7 @@ func TestFormatter_FormatValidationEvent(t *testing.T) {
 					},
 				),
 			},
-			expected: `Invalid objects (deployment.apps/bar, deployment.apps/foo): cyclic dependency:
+			expected: `Invalid objects (deployment.apps/bar, deployment.apps/foo): cyclic dependency: apps/namespaces/default/Deployment/bar -> apps/namespaces/default/Deployment/foo -> apps/namespaces/default/Deployment/bar
 - apps/namespaces/default/Deployment/bar -> apps/namespaces/default/Deployment/foo
 - apps/namespaces/default/Deployment/foo -> apps/namespaces/default/Deployment/bar`,
 		},
@@ -10,10 +10,29 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/printers/printer"
 )
 
+// Mode controls which events are printed by the events printer.
+type Mode int
+
+const (
+	// Verbose prints a line for every event.
+	Verbose Mode = iota
+	// Quiet prints only the objects that failed.
+	Quiet
+	// Summary prints the objects that failed and, at the end, a line
+	// with the result of each phase and a line with the totals.
+	Summary
+)
+
 func NewPrinter(ioStreams genericclioptions.IOStreams) printer.Printer {
+	return NewPrinterWithMode(ioStreams, Verbose)
+}
+
+// NewPrinterWithMode returns an events printer that prints the events
+// selected by the provided Mode.
+func NewPrinterWithMode(ioStreams genericclioptions.IOStreams, mode Mode) printer.Printer {
 	return &list.BaseListPrinter{
 		FormatterFactory: func(previewStrategy common.DryRunStrategy) list.Formatter {
-			return NewFormatter(ioStreams, previewStrategy)
+			return NewFormatterWithMode(ioStreams, previewStrategy, mode)
 		},
 	}
 }
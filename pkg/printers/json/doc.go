@@ -6,6 +6,9 @@
 // appear as a stream of json objects, each representing a single event.
 //
 // Every event will contain the following properties:
+//  * schemaVersion: The version of the format of the events, currently 1. It
+//    is incremented when fields are removed or their meaning changes, but not
+//    when fields or events are added. See SchemaVersion.
 //  * timestamp: RFC3339-formatted timestamp describing when the event happened.
 //  * type: Describes the type of the operation which the event is related to.
 //    Type values include:
@@ -16,8 +19,12 @@
 //    * prune - PruneEvent
 //    * delete - DeleteEvent
 //    * wait - WaitEvent
+//    * waitSummary - WaitSummaryEvent
+//    * retry - RetryEvent
+//    * rateLimit - RateLimitEvent
 //    * status - StatusEvent
 //    * summary - aggregate stats collected by the printer
+//    * runSummary - totals of the whole run, always the last event
 //
 // Validation events correspond to zero or more objects. For these events, the
 // objects field includes a list of object identifiers. These generally fire
@@ -64,6 +71,13 @@
 // * timestamp (string) - ISO-8601 format
 // * type (string) - "apply", "prune", "delete", or "wait"
 // * error (string, optional) - A non-fatal error message specific to this object
+// * duration (number, optional) - Seconds spent on the object, if timing is
+//                                 recorded. For wait events, this is the time
+//                                 from apply or delete until reconciled.
+// * conflicts (array of objects, optional) - For failed server-side applies,
+//   the fields owned by other field managers
+//   * field (string) - The path of the field, like ".spec.replicas".
+//   * manager (string) - The field manager that owns the field.
 //
 // Status types are asynchronous events that correspond to status updates for
 // a specific object.
@@ -76,9 +90,72 @@
 // * status (string) - One of: "InProgress", "Failed", "Current", "Terminating",
 //                     "NotFound", or "Unknown".
 // * message (string) - Human readable description of the status.
+// * generation (number, optional) - The object's metadata.generation, if its
+//                                   status is stale.
+// * observedGeneration (number, optional) - The object's
+//                                           status.observedGeneration, if its
+//                                           status is stale.
 // * timestamp (string) - ISO-8601 format
 // * type (string) - "status"
 //
+// Wait summary events are sent periodically while waiting, listing the objects
+// that have not yet reconciled, grouped by kind and status, and counting all
+// the objects being waited on by the wait task per status.
+//
+// Wait summary events have the following fields:
+// * elapsed (number) - Seconds since the wait started.
+// * total (number) - Number of objects being waited on.
+// * counts (object) - Number of objects per status, keyed by status:
+//                     "InProgress", "Failed", "Current", "Terminating",
+//                     "NotFound", or "Unknown". Statuses without objects are
+//                     omitted.
+// * pending (array of objects) - groups of objects still being waited on
+//   * group (string, optional) - The API group of the objects.
+//   * kind (string) - The kind of the objects.
+//   * status (string) - The status of the objects.
+//   * count (number) - Number of objects in the group.
+//   * objects (array of objects) - a list of object identifiers
+// * terminating (array of objects, optional) - objects being deleted, but
+//   blocked by finalizers
+//   * group (string, optional) - The object's API group.
+//   * kind (string) - The object's kind.
+//   * name (string) - The object's name.
+//   * namespace (string, optional) - The object's namespace.
+//   * deletionTimestamp (string) - ISO-8601 format
+//   * finalizers (array of strings) - The remaining finalizers.
+// * timestamp (string) - ISO-8601 format
+// * type (string) - "waitSummary"
+//
+// Retry events are sent when an apply or delete request fails with a
+// transient error and is going to be retried. The final outcome is reported
+// by the apply, prune, or delete event for the object.
+//
+// Retry events have the following fields:
+// * action (string) - One of: "Apply", "Prune", or "Delete".
+// * group (string, optional) - The object's API group.
+// * kind (string) - The object's kind.
+// * name (string) - The object's name.
+// * namespace (string, optional) - The object's namespace.
+// * retry (number) - The number of the upcoming retry, starting at 1.
+// * maxRetries (number) - The maximum number of retries.
+// * backoff (number) - Seconds to wait before retrying.
+// * error (string) - The error from the failed attempt.
+// * timestamp (string) - ISO-8601 format
+// * type (string) - "retry"
+//
+// RateLimit events are sent at the start of a run, when the client-side rate
+// limits were chosen based on whether the server has API Priority and
+// Fairness enabled.
+//
+// RateLimit events have the following fields:
+// * priorityAndFairnessEnabled (boolean) - Whether the server throttles
+//   requests itself, in which case client-side rate limiting is disabled.
+// * qps (number) - Maximum queries per second. Negative if client-side rate
+//   limiting is disabled.
+// * burst (number) - Maximum burst of queries.
+// * timestamp (string) - ISO-8601 format
+// * type (string) - "rateLimit"
+//
 // Summary types are a meta-event sent by the printer to summarize some stats
 // that have been collected from other events. For these events, the action
 // field corresponds to the event type being summarized: Apply, Prune, Delete,
@@ -91,7 +168,33 @@
 // * skipped (number) - Number of objects for which the action was skipped.
 // * failed (number) - Number of objects for which the action failed.
 // * timeout (number, optional) - Number of objects for which the action timed out.
+// * durations (object, optional) - The p50, p90, p99, and max durations of the
+//                                  objects in seconds, if timing is recorded.
 // * timestamp (string) - ISO-8601 format
 // * type (string) - "summary"
 //
+// The run summary is a meta-event sent by the printer after all the other
+// events, with the totals of the whole run, so that the other events don't
+// need to be tallied. It is also sent after an error event that ends the
+// run, when the totals may be incomplete.
+//
+// Run summary events have the following fields:
+// * applied (number) - Number of objects successfully applied.
+// * pruned (number) - Number of objects successfully pruned.
+// * deleted (number) - Number of objects successfully deleted.
+// * failed (number) - Number of objects that failed to be applied, pruned, or
+//                     deleted, or failed to reconcile before timeout.
+// * skipped (number) - Number of objects whose apply, prune, or delete was
+//                      skipped.
+// * duration (number) - Seconds since the start of the run.
+// * result (string) - The class of the result of the run. One of: "Success",
+//                     "Skipped", "ReconcileTimeout", "ReconcileFailed",
+//                     "PolicyViolation", "ActuationFailed", "Cancelled", or
+//                     "Error".
+// * exitStatus (string) - One of: "Success" or "Failure". It is "Failure" if
+//                         the command exits with a non-zero code.
+// * error (string, optional) - Why the run failed.
+// * timestamp (string) - ISO-8601 format
+// * type (string) - "runSummary"
+//
 package json
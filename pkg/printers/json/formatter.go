@@ -9,25 +9,35 @@ import (
 	"time"
 
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/apply"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
+	printcommon "sigs.k8s.io/cli-utils/pkg/print/common"
 	"sigs.k8s.io/cli-utils/pkg/print/list"
 	"sigs.k8s.io/cli-utils/pkg/print/stats"
 )
 
+// SchemaVersion is the version of the format of the events, included in
+// every event. It is incremented when fields are removed or their meaning
+// changes, but not when fields or events are added.
+const SchemaVersion = 1
+
 func NewFormatter(ioStreams genericclioptions.IOStreams,
 	_ common.DryRunStrategy) list.Formatter {
 	return &formatter{
 		ioStreams: ioStreams,
 		now:       time.Now,
+		start:     time.Now(),
 	}
 }
 
 type formatter struct {
 	ioStreams genericclioptions.IOStreams
 	now       func() time.Time
+	// start is when the formatter was created, at the start of the run.
+	start time.Time
 }
 
 func (jf *formatter) FormatValidationEvent(ve event.ValidationEvent) error {
@@ -56,6 +66,23 @@ func (jf *formatter) FormatApplyEvent(e event.ApplyEvent) error {
 		eventInfo["error"] = e.Error.Error()
 	}
 	eventInfo["status"] = e.Status.String()
+	if e.Operation != event.ApplyUnspecified {
+		eventInfo["operation"] = e.Operation.String()
+	}
+	if len(e.Warnings) > 0 {
+		eventInfo["warnings"] = e.Warnings
+	}
+	if len(e.Conflicts) > 0 {
+		conflicts := make([]interface{}, len(e.Conflicts))
+		for i, c := range e.Conflicts {
+			conflicts[i] = map[string]interface{}{
+				"field":   c.Field,
+				"manager": c.Manager,
+			}
+		}
+		eventInfo["conflicts"] = conflicts
+	}
+	addTiming(eventInfo, e.Timing)
 	return jf.printEvent("apply", eventInfo)
 }
 
@@ -67,6 +94,10 @@ func (jf *formatter) printResourceStatus(se event.StatusEvent) error {
 	eventInfo := jf.baseResourceEvent(se.Identifier)
 	eventInfo["status"] = se.PollResourceInfo.Status.String()
 	eventInfo["message"] = se.PollResourceInfo.Message
+	if lag := se.PollResourceInfo.GenerationLag; lag != nil {
+		eventInfo["generation"] = lag.Generation
+		eventInfo["observedGeneration"] = lag.ObservedGeneration
+	}
 	return jf.printEvent("status", eventInfo)
 }
 
@@ -76,6 +107,10 @@ func (jf *formatter) FormatPruneEvent(e event.PruneEvent) error {
 		eventInfo["error"] = e.Error.Error()
 	}
 	eventInfo["status"] = e.Status.String()
+	if e.Operation != event.PruneUnspecified {
+		eventInfo["operation"] = e.Operation.String()
+	}
+	addTiming(eventInfo, e.Timing)
 	return jf.printEvent("prune", eventInfo)
 }
 
@@ -85,15 +120,84 @@ func (jf *formatter) FormatDeleteEvent(e event.DeleteEvent) error {
 		eventInfo["error"] = e.Error.Error()
 	}
 	eventInfo["status"] = e.Status.String()
+	addTiming(eventInfo, e.Timing)
 	return jf.printEvent("delete", eventInfo)
 }
 
 func (jf *formatter) FormatWaitEvent(e event.WaitEvent) error {
 	eventInfo := jf.baseResourceEvent(e.Identifier)
 	eventInfo["status"] = e.Status.String()
+	addTiming(eventInfo, e.Timing)
 	return jf.printEvent("wait", eventInfo)
 }
 
+func (jf *formatter) FormatWaitSummaryEvent(e event.WaitSummaryEvent) error {
+	pending := make([]interface{}, len(e.Pending))
+	for i, group := range e.Pending {
+		objects := make([]interface{}, len(group.Identifiers))
+		for j, id := range group.Identifiers {
+			objects[j] = jf.baseResourceEvent(id)
+		}
+		pending[i] = map[string]interface{}{
+			"group":   group.GroupKind.Group,
+			"kind":    group.GroupKind.Kind,
+			"status":  group.Status.String(),
+			"count":   len(group.Identifiers),
+			"objects": objects,
+		}
+	}
+	content := map[string]interface{}{
+		"elapsed": e.Elapsed.Seconds(),
+		"pending": pending,
+	}
+	if e.Total > 0 {
+		counts := make(map[string]interface{}, len(e.Counts))
+		for s, count := range e.Counts {
+			counts[s.String()] = count
+		}
+		content["total"] = e.Total
+		content["counts"] = counts
+	}
+	if len(e.Terminating) > 0 {
+		terminating := make([]interface{}, len(e.Terminating))
+		for i, obj := range e.Terminating {
+			objEvent := jf.baseResourceEvent(obj.Identifier)
+			objEvent["deletionTimestamp"] = obj.DeletionTimestamp.UTC().Format(time.RFC3339)
+			objEvent["finalizers"] = obj.Finalizers
+			terminating[i] = objEvent
+		}
+		content["terminating"] = terminating
+	}
+	return jf.printEvent("waitSummary", content)
+}
+
+func (jf *formatter) FormatRetryEvent(e event.RetryEvent) error {
+	eventInfo := jf.baseResourceEvent(e.Identifier)
+	eventInfo["action"] = e.Action.String()
+	eventInfo["retry"] = e.Retry
+	eventInfo["maxRetries"] = e.MaxRetries
+	eventInfo["backoff"] = e.Backoff.Seconds()
+	eventInfo["error"] = e.Error.Error()
+	return jf.printEvent("retry", eventInfo)
+}
+
+func (jf *formatter) FormatRateLimitEvent(e event.RateLimitEvent) error {
+	return jf.printEvent("rateLimit", map[string]interface{}{
+		"priorityAndFairnessEnabled": e.PriorityAndFairnessEnabled,
+		"qps":                        e.QPS,
+		"burst":                      e.Burst,
+	})
+}
+
+func (jf *formatter) FormatVerificationEvent(e event.VerificationEvent) error {
+	return jf.printEvent("verification", map[string]interface{}{
+		"source": e.Source,
+		"digest": e.Digest,
+		"method": e.Method,
+		"signer": e.Signer,
+	})
+}
+
 func (jf *formatter) FormatErrorEvent(e event.ErrorEvent) error {
 	return jf.printEvent("error", map[string]interface{}{
 		"error": e.Err.Error(),
@@ -145,7 +249,7 @@ func (jf *formatter) FormatActionGroupEvent(
 			content["failed"] = ws.Failed
 			content["timeout"] = ws.Timeout
 		}
-	case event.InventoryAction:
+	case event.InventoryAction, event.ValidateAction:
 		// no extra content
 	default:
 		return fmt.Errorf("invalid action group action: %+v", age)
@@ -154,61 +258,123 @@ func (jf *formatter) FormatActionGroupEvent(
 	return jf.printEvent("group", content)
 }
 
-func (jf *formatter) FormatSummary(s stats.Stats) error {
+func (jf *formatter) FormatSummary(s stats.Stats, result *apply.RunResult) error {
 	if s.ApplyStats != (stats.ApplyStats{}) {
 		as := s.ApplyStats
-		err := jf.printEvent("summary", map[string]interface{}{
-			"action":     event.ApplyAction.String(),
-			"count":      as.Sum(),
-			"successful": as.Successful,
-			"skipped":    as.Skipped,
-			"failed":     as.Failed,
-		})
+		content := map[string]interface{}{
+			"action":            event.ApplyAction.String(),
+			"count":             as.Sum(),
+			"successful":        as.Successful,
+			"skipped":           as.Skipped,
+			"failed":            as.Failed,
+			"created":           as.Created,
+			"configured":        as.Configured,
+			"unchanged":         as.Unchanged,
+			"serversideApplied": as.ServersideApplied,
+		}
+		addDurations(content, s.Durations.Apply)
+		err := jf.printEvent("summary", content)
 		if err != nil {
 			return err
 		}
 	}
 	if s.PruneStats != (stats.PruneStats{}) {
 		ps := s.PruneStats
-		err := jf.printEvent("summary", map[string]interface{}{
+		content := map[string]interface{}{
 			"action":     event.PruneAction.String(),
 			"count":      ps.Sum(),
 			"successful": ps.Successful,
 			"skipped":    ps.Skipped,
 			"failed":     ps.Failed,
-		})
+		}
+		addDurations(content, s.Durations.Prune)
+		err := jf.printEvent("summary", content)
 		if err != nil {
 			return err
 		}
 	}
 	if s.DeleteStats != (stats.DeleteStats{}) {
 		ds := s.DeleteStats
-		err := jf.printEvent("summary", map[string]interface{}{
+		content := map[string]interface{}{
 			"action":     event.DeleteAction.String(),
 			"count":      ds.Sum(),
 			"successful": ds.Successful,
 			"skipped":    ds.Skipped,
 			"failed":     ds.Failed,
-		})
+		}
+		addDurations(content, s.Durations.Delete)
+		err := jf.printEvent("summary", content)
 		if err != nil {
 			return err
 		}
 	}
 	if s.WaitStats != (stats.WaitStats{}) {
 		ws := s.WaitStats
-		err := jf.printEvent("summary", map[string]interface{}{
+		content := map[string]interface{}{
 			"action":     event.WaitAction.String(),
 			"count":      ws.Sum(),
 			"successful": ws.Successful,
 			"skipped":    ws.Skipped,
 			"failed":     ws.Failed,
 			"timeout":    ws.Timeout,
-		})
+		}
+		addDurations(content, s.Durations.Wait)
+		err := jf.printEvent("summary", content)
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+	return jf.printRunSummary(s, result)
+}
+
+// printRunSummary prints the final record of the run, with the totals of
+// all the actions and the result of the run, so that the events don't need
+// to be tallied. The exit status is a failure if the commands exit with a
+// non-zero code for the result class of the run.
+func (jf *formatter) printRunSummary(s stats.Stats, result *apply.RunResult) error {
+	class := result.Class()
+	content := map[string]interface{}{
+		"applied":    s.ApplyStats.Successful,
+		"pruned":     s.PruneStats.Successful,
+		"deleted":    s.DeleteStats.Successful,
+		"failed":     s.FailedActuationSum() + s.FailedReconciliationSum(),
+		"skipped":    s.ApplyStats.Skipped + s.PruneStats.Skipped + s.DeleteStats.Skipped,
+		"duration":   jf.now().Sub(jf.start).Seconds(),
+		"result":     class.String(),
+		"exitStatus": "Success",
+	}
+	if class != apply.ResultSuccess && class != apply.ResultSkipped {
+		content["exitStatus"] = "Failure"
+	}
+	if err := result.Err(); err != nil {
+		content["error"] = err.Error()
+	} else if err := printcommon.ResultErrorFromStats(s); err != nil {
+		content["error"] = err.Error()
+	}
+	return jf.printEvent("runSummary", content)
+}
+
+// addTiming adds the duration of the timing in seconds, if the event has
+// timing.
+func addTiming(eventInfo map[string]interface{}, t event.Timing) {
+	if t.IsZero() {
+		return
+	}
+	eventInfo["duration"] = t.Duration().Seconds()
+}
+
+// addDurations adds the percentiles of the durations in seconds, if there
+// are any durations.
+func addDurations(content map[string]interface{}, durations stats.DurationList) {
+	if len(durations) == 0 {
+		return
+	}
+	content["durations"] = map[string]interface{}{
+		"p50": durations.Percentile(50).Seconds(),
+		"p90": durations.Percentile(90).Seconds(),
+		"p99": durations.Percentile(99).Seconds(),
+		"max": durations.Max().Seconds(),
+	}
 }
 
 func (jf *formatter) baseResourceEvent(identifier object.ObjMetadata) map[string]interface{} {
@@ -222,6 +388,7 @@ func (jf *formatter) baseResourceEvent(identifier object.ObjMetadata) map[string
 
 func (jf *formatter) printEvent(t string, content map[string]interface{}) error {
 	m := make(map[string]interface{})
+	m["schemaVersion"] = SchemaVersion
 	m["timestamp"] = jf.now().UTC().Format(time.RFC3339)
 	m["type"] = t
 	for key, val := range content {
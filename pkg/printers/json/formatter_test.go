@@ -15,7 +15,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/apply"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/policyhook"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
@@ -51,6 +53,26 @@ func TestFormatter_FormatApplyEvent(t *testing.T) {
 				},
 			},
 		},
+		"resource configured": {
+			previewStrategy: common.DryRunNone,
+			event: event.ApplyEvent{
+				Status:     event.ApplySuccessful,
+				Operation:  event.ApplyConfigured,
+				Identifier: createIdentifier("apps", "Deployment", "default", "my-dep"),
+			},
+			expected: []map[string]interface{}{
+				{
+					"group":     "apps",
+					"kind":      "Deployment",
+					"name":      "my-dep",
+					"namespace": "default",
+					"status":    "Successful",
+					"operation": "Configured",
+					"timestamp": "",
+					"type":      "apply",
+				},
+			},
+		},
 		"resource updated with client dryrun": {
 			previewStrategy: common.DryRunClient,
 			event: event.ApplyEvent{
@@ -127,6 +149,52 @@ func TestFormatter_FormatApplyEvent(t *testing.T) {
 				},
 			},
 		},
+		"resource applied with warnings": {
+			previewStrategy: common.DryRunNone,
+			event: event.ApplyEvent{
+				Status:     event.ApplySuccessful,
+				Identifier: createIdentifier("apps", "Deployment", "default", "my-dep"),
+				Warnings:   []string{`unknown field "spec.replica"`},
+			},
+			expected: []map[string]interface{}{
+				{
+					"group":     "apps",
+					"kind":      "Deployment",
+					"name":      "my-dep",
+					"namespace": "default",
+					"status":    "Successful",
+					"timestamp": "",
+					"type":      "apply",
+					"warnings":  []interface{}{`unknown field "spec.replica"`},
+				},
+			},
+		},
+		"resource failed with conflicts": {
+			previewStrategy: common.DryRunNone,
+			event: event.ApplyEvent{
+				Status:     event.ApplyFailed,
+				Identifier: createIdentifier("apps", "Deployment", "default", "my-dep"),
+				Error:      errors.New("Apply failed with 1 conflict"),
+				Conflicts: []event.FieldConflict{
+					{Field: ".spec.replicas", Manager: "hpa-controller"},
+				},
+			},
+			expected: []map[string]interface{}{
+				{
+					"group":     "apps",
+					"kind":      "Deployment",
+					"name":      "my-dep",
+					"namespace": "default",
+					"status":    "Failed",
+					"timestamp": "",
+					"type":      "apply",
+					"error":     "Apply failed with 1 conflict",
+					"conflicts": []interface{}{
+						map[string]interface{}{"field": ".spec.replicas", "manager": "hpa-controller"},
+					},
+				},
+			},
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -189,6 +257,47 @@ func TestFormatter_FormatStatusEvent(t *testing.T) {
 				"type":      "status",
 			},
 		},
+		"resource update with stale status": {
+			previewStrategy: common.DryRunNone,
+			event: event.StatusEvent{
+				Identifier: object.ObjMetadata{
+					GroupKind: schema.GroupKind{
+						Group: "apps",
+						Kind:  "Deployment",
+					},
+					Namespace: "foo",
+					Name:      "bar",
+				},
+				PollResourceInfo: &pollevent.ResourceStatus{
+					Identifier: object.ObjMetadata{
+						GroupKind: schema.GroupKind{
+							Group: "apps",
+							Kind:  "Deployment",
+						},
+						Namespace: "foo",
+						Name:      "bar",
+					},
+					Status:  status.InProgressStatus,
+					Message: "StaleStatus: observedGeneration 3 < generation 5",
+					GenerationLag: &status.GenerationLag{
+						Generation:         5,
+						ObservedGeneration: 3,
+					},
+				},
+			},
+			expected: map[string]interface{}{
+				"group":              "apps",
+				"kind":               "Deployment",
+				"message":            "StaleStatus: observedGeneration 3 < generation 5",
+				"name":               "bar",
+				"namespace":          "foo",
+				"status":             "InProgress",
+				"generation":         5,
+				"observedGeneration": 3,
+				"timestamp":          "",
+				"type":               "status",
+			},
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -203,6 +312,151 @@ func TestFormatter_FormatStatusEvent(t *testing.T) {
 	}
 }
 
+func TestFormatter_FormatWaitSummaryEvent(t *testing.T) {
+	depID := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Namespace: "foo",
+		Name:      "bar",
+	}
+	testCases := map[string]struct {
+		event    event.WaitSummaryEvent
+		expected map[string]interface{}
+	}{
+		"pending objects": {
+			event: event.WaitSummaryEvent{
+				GroupName: "wait-0",
+				Elapsed:   30 * time.Second,
+				Pending: []event.WaitSummaryGroup{
+					{
+						GroupKind:   depID.GroupKind,
+						Status:      status.InProgressStatus,
+						Identifiers: object.ObjMetadataSet{depID},
+					},
+				},
+			},
+			expected: map[string]interface{}{
+				"elapsed": 30,
+				"pending": []interface{}{
+					map[string]interface{}{
+						"group":  "apps",
+						"kind":   "Deployment",
+						"status": "InProgress",
+						"count":  float64(1),
+						"objects": []interface{}{
+							map[string]interface{}{
+								"group":     "apps",
+								"kind":      "Deployment",
+								"name":      "bar",
+								"namespace": "foo",
+							},
+						},
+					},
+				},
+				"timestamp": "",
+				"type":      "waitSummary",
+			},
+		},
+		"terminating objects": {
+			event: event.WaitSummaryEvent{
+				GroupName: "wait-0",
+				Elapsed:   time.Minute,
+				Pending:   []event.WaitSummaryGroup{},
+				Terminating: []event.TerminatingObject{
+					{
+						Identifier:        depID,
+						DeletionTimestamp: time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC),
+						Finalizers:        []string{"example.com/cleanup"},
+					},
+				},
+			},
+			expected: map[string]interface{}{
+				"elapsed": 60,
+				"pending": []interface{}{},
+				"terminating": []interface{}{
+					map[string]interface{}{
+						"group":             "apps",
+						"kind":              "Deployment",
+						"name":              "bar",
+						"namespace":         "foo",
+						"deletionTimestamp": "2022-03-01T10:00:00Z",
+						"finalizers":        []interface{}{"example.com/cleanup"},
+					},
+				},
+				"timestamp": "",
+				"type":      "waitSummary",
+			},
+		},
+		"status counts": {
+			event: event.WaitSummaryEvent{
+				GroupName: "wait-1",
+				Elapsed:   30 * time.Second,
+				Total:     3,
+				Counts: map[status.Status]int{
+					status.CurrentStatus:    2,
+					status.InProgressStatus: 1,
+				},
+				Pending: []event.WaitSummaryGroup{},
+			},
+			expected: map[string]interface{}{
+				"elapsed": 30,
+				"total":   3,
+				"counts": map[string]interface{}{
+					"Current":    float64(2),
+					"InProgress": float64(1),
+				},
+				"pending":   []interface{}{},
+				"timestamp": "",
+				"type":      "waitSummary",
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams() //nolint:dogsled
+			formatter := NewFormatter(ioStreams, common.DryRunNone)
+			err := formatter.FormatWaitSummaryEvent(tc.event)
+			assert.NoError(t, err)
+
+			assertOutput(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestFormatter_FormatVerificationEvent(t *testing.T) {
+	testCases := map[string]struct {
+		event    event.VerificationEvent
+		expected map[string]interface{}
+	}{
+		"successful": {
+			event: event.VerificationEvent{
+				Source: "manifests.yaml",
+				Digest: "sha256:def",
+				Method: "cosign",
+				Signer: "sha256:abc",
+			},
+			expected: map[string]interface{}{
+				"source":    "manifests.yaml",
+				"digest":    "sha256:def",
+				"method":    "cosign",
+				"signer":    "sha256:abc",
+				"timestamp": "",
+				"type":      "verification",
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams() //nolint:dogsled
+			formatter := NewFormatter(ioStreams, common.DryRunNone)
+			err := formatter.FormatVerificationEvent(tc.event)
+			assert.NoError(t, err)
+			assertOutput(t, tc.expected, out.String())
+		})
+	}
+}
+
 func TestFormatter_FormatPruneEvent(t *testing.T) {
 	testCases := map[string]struct {
 		previewStrategy common.DryRunStrategy
@@ -782,7 +1036,7 @@ func TestFormatter_FormatValidationEvent(t *testing.T) {
 						"namespace": "default",
 					},
 				},
-				"error": `cyclic dependency:
+				"error": `cyclic dependency: apps/namespaces/default/Deployment/bar -> apps/namespaces/default/Deployment/foo -> apps/namespaces/default/Deployment/bar
 - apps/namespaces/default/Deployment/bar -> apps/namespaces/default/Deployment/foo
 - apps/namespaces/default/Deployment/foo -> apps/namespaces/default/Deployment/bar`,
 			},
@@ -808,11 +1062,23 @@ func TestFormatter_FormatSummary(t *testing.T) {
 	now := time.Now()
 	nowStr := now.UTC().Format(time.RFC3339)
 
+	depID := createIdentifier("apps", "Deployment", "default", "my-dep")
 	testCases := map[string]struct {
 		statsCollector stats.Stats
+		events         []event.Event
 		expected       []map[string]interface{}
 	}{
 		"apply prune wait": {
+			events: []event.Event{
+				{
+					Type: event.ApplyType,
+					ApplyEvent: event.ApplyEvent{
+						Identifier: depID,
+						Status:     event.ApplyFailed,
+						Error:      errors.New("conflict"),
+					},
+				},
+			},
 			statsCollector: stats.Stats{
 				ApplyStats: stats.ApplyStats{
 					Successful: 1,
@@ -833,13 +1099,17 @@ func TestFormatter_FormatSummary(t *testing.T) {
 			},
 			expected: []map[string]interface{}{
 				{
-					"action":     "Apply",
-					"count":      float64(6),
-					"successful": float64(1),
-					"skipped":    float64(2),
-					"failed":     float64(3),
-					"timestamp":  nowStr,
-					"type":       "summary",
+					"action":            "Apply",
+					"count":             float64(6),
+					"successful":        float64(1),
+					"skipped":           float64(2),
+					"failed":            float64(3),
+					"created":           float64(0),
+					"configured":        float64(0),
+					"unchanged":         float64(0),
+					"serversideApplied": float64(0),
+					"timestamp":         nowStr,
+					"type":              "summary",
 				},
 				{
 					"action":     "Prune",
@@ -860,6 +1130,125 @@ func TestFormatter_FormatSummary(t *testing.T) {
 					"timestamp":  nowStr,
 					"type":       "summary",
 				},
+				{
+					"applied":    float64(1),
+					"pruned":     float64(3),
+					"deleted":    float64(0),
+					"failed":     float64(6),
+					"skipped":    float64(4),
+					"duration":   float64(0),
+					"result":     "ActuationFailed",
+					"exitStatus": "Failure",
+					"error":      "4 resources failed, 2 resources failed to reconcile before timeout",
+					"timestamp":  nowStr,
+					"type":       "runSummary",
+				},
+			},
+		},
+		"successful apply": {
+			statsCollector: stats.Stats{
+				ApplyStats: stats.ApplyStats{
+					Successful: 2,
+				},
+			},
+			expected: []map[string]interface{}{
+				{
+					"action":            "Apply",
+					"count":             float64(2),
+					"successful":        float64(2),
+					"skipped":           float64(0),
+					"failed":            float64(0),
+					"created":           float64(0),
+					"configured":        float64(0),
+					"unchanged":         float64(0),
+					"serversideApplied": float64(0),
+					"timestamp":         nowStr,
+					"type":              "summary",
+				},
+				{
+					"applied":    float64(2),
+					"pruned":     float64(0),
+					"deleted":    float64(0),
+					"failed":     float64(0),
+					"skipped":    float64(0),
+					"duration":   float64(0),
+					"result":     "Success",
+					"exitStatus": "Success",
+					"timestamp":  nowStr,
+					"type":       "runSummary",
+				},
+			},
+		},
+		"policy violation": {
+			events: []event.Event{
+				{
+					Type: event.ApplyType,
+					ApplyEvent: event.ApplyEvent{
+						Identifier: depID,
+						Status:     event.ApplySkipped,
+						Error: &policyhook.PolicyDeniedError{
+							Action: policyhook.ActionApply,
+							Reason: "no privileged pods",
+						},
+					},
+				},
+			},
+			statsCollector: stats.Stats{
+				ApplyStats: stats.ApplyStats{
+					Skipped: 1,
+				},
+			},
+			expected: []map[string]interface{}{
+				{
+					"action":            "Apply",
+					"count":             float64(1),
+					"successful":        float64(0),
+					"skipped":           float64(1),
+					"failed":            float64(0),
+					"created":           float64(0),
+					"configured":        float64(0),
+					"unchanged":         float64(0),
+					"serversideApplied": float64(0),
+					"timestamp":         nowStr,
+					"type":              "summary",
+				},
+				{
+					"applied":    float64(0),
+					"pruned":     float64(0),
+					"deleted":    float64(0),
+					"failed":     float64(0),
+					"skipped":    float64(1),
+					"duration":   float64(0),
+					"result":     "PolicyViolation",
+					"exitStatus": "Failure",
+					"timestamp":  nowStr,
+					"type":       "runSummary",
+				},
+			},
+		},
+		"fatal error": {
+			events: []event.Event{
+				{
+					Type: event.ErrorType,
+					ErrorEvent: event.ErrorEvent{
+						Err: errors.New("inventory update failed"),
+					},
+				},
+			},
+			expected: []map[string]interface{}{
+				{
+					"applied":    float64(0),
+					"pruned":     float64(0),
+					"deleted":    float64(0),
+					"failed":     float64(0),
+					"skipped":    float64(0),
+					"duration":   float64(0),
+					"result":     "Error",
+					"exitStatus": "Failure",
+					"error":      "inventory update failed",
+					"timestamp":  nowStr,
+					"type":       "runSummary",
+				},
 			},
 		},
 	}
@@ -870,9 +1259,14 @@ func TestFormatter_FormatSummary(t *testing.T) {
 			jf := &formatter{
 				ioStreams: ioStreams,
 				// fake time func
-				now: func() time.Time { return now },
+				now:   func() time.Time { return now },
+				start: now,
+			}
+			result := apply.NewRunResult()
+			for _, e := range tc.events {
+				result.Send(e)
 			}
-			err := jf.FormatSummary(tc.statsCollector)
+			err := jf.FormatSummary(tc.statsCollector, result)
 			assert.NoError(t, err)
 
 			assertOutputLines(t, tc.expected, out.String())
@@ -887,6 +1281,7 @@ func assertOutputLines(t *testing.T, expectedMaps []map[string]interface{}, actu
 	for i, line := range lines {
 		err := json.Unmarshal([]byte(line), &actualMaps[i])
 		require.NoError(t, err)
+		assertSchemaVersion(t, actualMaps[i])
 	}
 	testutil.AssertEqual(t, expectedMaps, actualMaps)
 }
@@ -903,6 +1298,10 @@ func assertOutput(t *testing.T, expectedMap map[string]interface{}, actual strin
 		return false
 	}
 
+	if !assertSchemaVersion(t, m) {
+		return false
+	}
+
 	if _, found := expectedMap["timestamp"]; found {
 		if _, ok := m["timestamp"]; ok {
 			delete(expectedMap, "timestamp")
@@ -922,6 +1321,18 @@ func assertOutput(t *testing.T, expectedMap map[string]interface{}, actual strin
 	return assert.Equal(t, expectedMap, m)
 }
 
+// assertSchemaVersion asserts that the event has the current schema version,
+// and removes it from the event.
+func assertSchemaVersion(t *testing.T, m map[string]interface{}) bool {
+	version, found := m["schemaVersion"]
+	if !found {
+		t.Error("expected to find key 'schemaVersion', but didn't")
+		return false
+	}
+	delete(m, "schemaVersion")
+	return assert.Equal(t, float64(SchemaVersion), version)
+}
+
 func createIdentifier(group, kind, namespace, name string) object.ObjMetadata {
 	return object.ObjMetadata{
 		Namespace: namespace,
@@ -11,12 +11,17 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/printers/json"
 	"sigs.k8s.io/cli-utils/pkg/printers/printer"
 	"sigs.k8s.io/cli-utils/pkg/printers/table"
+	"sigs.k8s.io/cli-utils/pkg/printers/testreport"
+	"sigs.k8s.io/cli-utils/pkg/printers/yaml"
 )
 
 const (
 	EventsPrinter = "events"
 	TablePrinter  = "table"
 	JSONPrinter   = "json"
+	YAMLPrinter   = "yaml"
+	JUnitPrinter  = "junit"
+	TAPPrinter    = "tap"
 )
 
 func GetPrinter(printerType string, ioStreams genericclioptions.IOStreams) printer.Printer {
@@ -31,13 +36,19 @@ func GetPrinter(printerType string, ioStreams genericclioptions.IOStreams) print
 				return json.NewFormatter(ioStreams, previewStrategy)
 			},
 		}
+	case YAMLPrinter:
+		return yaml.NewPrinter(ioStreams)
+	case JUnitPrinter:
+		return testreport.NewPrinter(ioStreams, testreport.JUnit)
+	case TAPPrinter:
+		return testreport.NewPrinter(ioStreams, testreport.TAP)
 	default:
 		return events.NewPrinter(ioStreams)
 	}
 }
 
 func SupportedPrinters() []string {
-	return []string{EventsPrinter, TablePrinter, JSONPrinter}
+	return []string{EventsPrinter, TablePrinter, JSONPrinter, YAMLPrinter, JUnitPrinter, TAPPrinter}
 }
 
 func DefaultPrinter() string {
@@ -61,6 +61,10 @@ type resourceStateCollector struct {
 	// stats collect statistics from handled events
 	stats stats.Stats
 
+	// waitSummary is the latest summary of the running wait task, or nil
+	// if no wait task is running.
+	waitSummary *event.WaitSummaryEvent
+
 	err error
 }
 
@@ -89,6 +93,10 @@ type resourceInfo struct {
 	// a resource has been applied to the cluster.
 	ApplyStatus event.ApplyEventStatus
 
+	// Conflicts are the fields owned by other field managers that
+	// failed the server-side apply of the resource.
+	Conflicts []event.FieldConflict
+
 	// PruneStatus contains the result after
 	// a prune operation on a resource
 	PruneStatus event.PruneEventStatus
@@ -200,6 +208,11 @@ func (r *resourceStateCollector) processEvent(ev event.Event) error {
 		r.processDeleteEvent(ev.DeleteEvent)
 	case event.WaitType:
 		r.processWaitEvent(ev.WaitEvent)
+	case event.WaitSummaryType:
+		summary := ev.WaitSummaryEvent
+		r.waitSummary = &summary
+	case event.ActionGroupType:
+		r.processActionGroupEvent(ev.ActionGroupEvent)
 	case event.ErrorType:
 		return ev.ErrorEvent.Err
 	}
@@ -258,6 +271,9 @@ func (r *resourceStateCollector) processApplyEvent(e event.ApplyEvent) {
 	if e.Error != nil {
 		previous.Error = e.Error
 	}
+	if len(e.Conflicts) > 0 {
+		previous.Conflicts = e.Conflicts
+	}
 	previous.ApplyStatus = e.Status
 	r.stats.ApplyStats.Inc(e.Status)
 }
@@ -307,10 +323,21 @@ func (r *resourceStateCollector) processWaitEvent(e event.WaitEvent) {
 	r.stats.WaitStats.Inc(e.Status)
 }
 
+// processActionGroupEvent clears the wait summary when its wait task
+// finishes.
+func (r *resourceStateCollector) processActionGroupEvent(e event.ActionGroupEvent) {
+	if e.Status == event.Finished && r.waitSummary != nil && r.waitSummary.GroupName == e.GroupName {
+		r.waitSummary = nil
+	}
+}
+
 // ResourceState contains the latest state for all the resources.
 type ResourceState struct {
 	resourceInfos ResourceInfos
 
+	// waitSummary is the latest summary of the running wait task, or nil.
+	waitSummary *event.WaitSummaryEvent
+
 	err error
 }
 
@@ -341,6 +368,7 @@ func (r *resourceStateCollector) LatestState() *ResourceState {
 			resourceStatus: ri.resourceStatus,
 			ResourceAction: ri.ResourceAction,
 			ApplyStatus:    ri.ApplyStatus,
+			Conflicts:      ri.Conflicts,
 			PruneStatus:    ri.PruneStatus,
 			DeleteStatus:   ri.DeleteStatus,
 			WaitStatus:     ri.WaitStatus,
@@ -350,6 +378,7 @@ func (r *resourceStateCollector) LatestState() *ResourceState {
 
 	return &ResourceState{
 		resourceInfos: resourceInfos,
+		waitSummary:   r.waitSummary,
 		err:           r.err,
 	}
 }
@@ -6,8 +6,10 @@ package table
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
@@ -275,3 +277,36 @@ func getID(e event.StatusEvent) (object.ObjMetadata, bool) {
 	}
 	return e.Identifier, true
 }
+
+func TestResourceStateCollector_WaitSummary(t *testing.T) {
+	rsc := newResourceStateCollector([]event.ActionGroup{})
+	summary := event.WaitSummaryEvent{
+		GroupName: "wait-0",
+		Elapsed:   30 * time.Second,
+	}
+
+	require.NoError(t, rsc.processEvent(event.Event{
+		Type:             event.WaitSummaryType,
+		WaitSummaryEvent: summary,
+	}))
+	assert.Equal(t, &summary, rsc.LatestState().waitSummary)
+
+	// Another group finishing keeps the summary.
+	require.NoError(t, rsc.processEvent(event.Event{
+		Type: event.ActionGroupType,
+		ActionGroupEvent: event.ActionGroupEvent{
+			GroupName: "apply-0",
+			Status:    event.Finished,
+		},
+	}))
+	assert.Equal(t, &summary, rsc.LatestState().waitSummary)
+
+	require.NoError(t, rsc.processEvent(event.Event{
+		Type: event.ActionGroupType,
+		ActionGroupEvent: event.ActionGroupEvent{
+			GroupName: "wait-0",
+			Status:    event.Finished,
+		},
+	}))
+	assert.Nil(t, rsc.LatestState().waitSummary)
+}
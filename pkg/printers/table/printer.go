@@ -6,8 +6,12 @@ package table
 import (
 	"fmt"
 	"io"
+	"os"
+	"strings"
 	"time"
 
+	"golang.org/x/term"
+
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/common"
@@ -15,11 +19,21 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/print/table"
 )
 
+// Printer prints a live table with the state of all resources. The
+// table is redrawn in place as events are received.
 type Printer struct {
 	IOStreams genericclioptions.IOStreams
+	// Columns are the names of the columns to print, in order. If
+	// empty, DefaultColumns are printed.
+	Columns []string
 }
 
 func (t *Printer) Print(ch <-chan event.Event, _ common.DryRunStrategy, _ bool) error {
+	cols, err := lookupColumns(t.Columns)
+	if err != nil {
+		return err
+	}
+
 	// Wait for the init event that will give us the set of
 	// resources.
 	var initEvent event.InitEvent
@@ -42,14 +56,13 @@ func (t *Printer) Print(ch <-chan event.Event, _ common.DryRunStrategy, _ bool)
 
 	// Start the goroutine that is responsible for
 	// printing the latest state on a regular cadence.
-	printCompleted := t.runPrintLoop(coll, stop)
+	printCompleted := t.runPrintLoop(coll, cols, stop)
 
 	// Make the collector start listening on the eventChannel.
 	done := coll.Listen(ch)
 
 	// Block until all the collector has shut down. This means the
 	// eventChannel has been closed and all events have been processed.
-	var err error
 	for msg := range done {
 		err = msg.err
 	}
@@ -71,9 +84,49 @@ func (t *Printer) Print(ch <-chan event.Event, _ common.DryRunStrategy, _ bool)
 	return printcommon.ResultErrorFromStats(coll.stats)
 }
 
-// columns defines the columns we want to print
-//TODO: We should have the number of columns and their widths be
-// dependent on the space available.
+// DefaultColumns are the names of the columns printed if no columns
+// are specified.
+var DefaultColumns = []string{
+	"namespace",
+	"resource",
+	"action",
+	"status",
+	"reconciled",
+	"conditions",
+	"age",
+	"message",
+}
+
+// SupportedColumns returns the names of all columns that can be printed.
+func SupportedColumns() []string {
+	return DefaultColumns
+}
+
+// ValidateColumns returns an error if any of the provided column names
+// is not supported.
+func ValidateColumns(names []string) error {
+	_, err := lookupColumns(names)
+	return err
+}
+
+// lookupColumns returns the column definitions for the provided
+// column names, or for DefaultColumns if no names are provided.
+func lookupColumns(names []string) ([]table.ColumnDefinition, error) {
+	if len(names) == 0 {
+		names = DefaultColumns
+	}
+	var cols []table.ColumnDefinition
+	for _, name := range names {
+		col, found := columns[strings.ToLower(name)]
+		if !found {
+			return nil, fmt.Errorf("unknown column %q, must be one of %s",
+				name, strings.Join(SupportedColumns(), ","))
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
+
 var (
 	actionColumnDef = table.ColumnDef{
 		// Column containing the resource type and name. Currently it does not
@@ -144,29 +197,111 @@ var (
 		},
 	}
 
-	columns = []table.ColumnDefinition{
-		table.MustColumn("namespace"),
-		table.MustColumn("resource"),
-		actionColumnDef,
-		table.MustColumn("status"),
-		reconciledColumnDef,
-		table.MustColumn("conditions"),
-		table.MustColumn("age"),
-		table.MustColumn("message"),
+	messageColumnDef = table.ColumnDef{
+		// Column containing the conflicts that failed the server-side
+		// apply of the resource, or else the message of its status.
+		ColumnName:   "message",
+		ColumnHeader: "MESSAGE",
+		ColumnWidth:  40,
+		PrintResourceFunc: func(w io.Writer, width int, r table.Resource) (int,
+			error) {
+			resInfo, ok := r.(*resourceInfo)
+			if !ok || len(resInfo.Conflicts) == 0 {
+				return table.MustColumn("message").PrintResource(w, width, r)
+			}
+
+			text := conflictsMessage(resInfo.Conflicts)
+			if len(text) > width {
+				text = text[:width]
+			}
+			_, err := fmt.Fprint(w, text)
+			return len(text), err
+		},
+	}
+
+	// columns maps the names of the supported columns to their
+	// definitions.
+	columns = map[string]table.ColumnDefinition{
+		"namespace":  table.MustColumn("namespace"),
+		"resource":   table.MustColumn("resource"),
+		"action":     actionColumnDef,
+		"status":     table.MustColumn("status"),
+		"reconciled": reconciledColumnDef,
+		"conditions": table.MustColumn("conditions"),
+		"age":        table.MustColumn("age"),
+		"message":    messageColumnDef,
 	}
 )
 
+// conflictsMessage returns a message listing the conflicting fields and
+// their field managers.
+func conflictsMessage(conflicts []event.FieldConflict) string {
+	fields := make([]string, len(conflicts))
+	for i, c := range conflicts {
+		fields[i] = fmt.Sprintf("%s (%s)", c.Field, c.Manager)
+	}
+	return "conflicts: " + strings.Join(fields, ", ")
+}
+
+// printWaitSummary prints the pending groups and terminating objects of
+// the wait summary below the table, and returns the number of lines
+// printed.
+func printWaitSummary(w io.Writer, summary *event.WaitSummaryEvent) int {
+	if summary == nil {
+		return 0
+	}
+	lines := 0
+	for _, group := range summary.Pending {
+		_, _ = fmt.Fprintf(w, "still waiting on %d %s (%s) after %s\n", len(group.Identifiers),
+			strings.ToLower(group.GroupKind.String()), group.Status, summary.Elapsed.Round(time.Second))
+		lines++
+	}
+	for _, obj := range summary.Terminating {
+		_, _ = fmt.Fprintf(w, "%s/%s terminating, blocked by finalizers: %s\n",
+			strings.ToLower(obj.Identifier.GroupKind.String()), obj.Identifier.Name, strings.Join(obj.Finalizers, ", "))
+		lines++
+	}
+	return lines
+}
+
+// terminalWidth returns a function that reports the current width of
+// the terminal that w writes to, or nil if w is not a terminal.
+func terminalWidth(w io.Writer) func() int {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return nil
+	}
+	return func() int {
+		width, _, err := term.GetSize(int(f.Fd()))
+		if err != nil {
+			return 0
+		}
+		return width
+	}
+}
+
 // runPrintLoop starts a new goroutine that will regularly fetch the
 // latest state from the collector and update the table.
-func (t *Printer) runPrintLoop(coll *resourceStateCollector, stop chan struct{}) chan struct{} {
+func (t *Printer) runPrintLoop(coll *resourceStateCollector, cols []table.ColumnDefinition,
+	stop chan struct{}) chan struct{} {
 	finished := make(chan struct{})
 
+	// Colors and fitting the table to the terminal only make sense when
+	// printing to a terminal.
+	termWidth := terminalWidth(t.IOStreams.Out)
 	baseTablePrinter := table.BaseTablePrinter{
-		IOStreams: t.IOStreams,
-		Columns:   columns,
+		IOStreams:     t.IOStreams,
+		Columns:       cols,
+		TerminalWidth: termWidth,
+		NoColor:       termWidth == nil,
 	}
 
-	linesPrinted := baseTablePrinter.PrintTable(coll.LatestState(), 0)
+	printState := func(moveUpCount int) int {
+		latestState := coll.LatestState()
+		lines := baseTablePrinter.PrintTable(latestState, moveUpCount)
+		return lines + printWaitSummary(t.IOStreams.Out, latestState.waitSummary)
+	}
+	linesPrinted := printState(0)
 
 	go func() {
 		defer close(finished)
@@ -175,13 +310,11 @@ func (t *Printer) runPrintLoop(coll *resourceStateCollector, stop chan struct{})
 			select {
 			case <-stop:
 				ticker.Stop()
-				latestState := coll.LatestState()
-				linesPrinted = baseTablePrinter.PrintTable(latestState, linesPrinted)
+				linesPrinted = printState(linesPrinted)
 				_, _ = fmt.Fprint(t.IOStreams.Out, "\n")
 				return
 			case <-ticker.C:
-				latestState := coll.LatestState()
-				linesPrinted = baseTablePrinter.PrintTable(latestState, linesPrinted)
+				linesPrinted = printState(linesPrinted)
 			}
 		}
 	}()
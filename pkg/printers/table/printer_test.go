@@ -5,10 +5,17 @@ package table
 
 import (
 	"bytes"
+	"strings"
 	"testing"
+	"time"
 
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	pe "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/print/table"
 	"sigs.k8s.io/cli-utils/pkg/printers/printer"
 	printertesting "sigs.k8s.io/cli-utils/pkg/printers/testutil"
@@ -71,6 +78,59 @@ func TestActionColumnDef(t *testing.T) {
 	}
 }
 
+func TestMessageColumnDef(t *testing.T) {
+	testCases := map[string]struct {
+		resource       table.Resource
+		columnWidth    int
+		expectedOutput string
+	}{
+		"status message": {
+			resource: &resourceInfo{
+				resourceStatus: &pe.ResourceStatus{Message: "Deployment is available"},
+			},
+			columnWidth:    40,
+			expectedOutput: "Deployment is available",
+		},
+		"conflicts": {
+			resource: &resourceInfo{
+				resourceStatus: &pe.ResourceStatus{Message: "Deployment is available"},
+				ResourceAction: event.ApplyAction,
+				ApplyStatus:    event.ApplyFailed,
+				Conflicts: []event.FieldConflict{
+					{Field: ".spec.replicas", Manager: "hpa"},
+					{Field: ".spec.paused", Manager: "kubectl"},
+				},
+			},
+			columnWidth:    60,
+			expectedOutput: "conflicts: .spec.replicas (hpa), .spec.paused (kubectl)",
+		},
+		"trimmed conflicts": {
+			resource: &resourceInfo{
+				resourceStatus: &pe.ResourceStatus{},
+				Conflicts: []event.FieldConflict{
+					{Field: ".spec.replicas", Manager: "hpa"},
+				},
+			},
+			columnWidth:    20,
+			expectedOutput: "conflicts: .spec.rep",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			var buf bytes.Buffer
+			_, err := messageColumnDef.PrintResource(&buf, tc.columnWidth, tc.resource)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if want, got := tc.expectedOutput, buf.String(); want != got {
+				t.Errorf("expected %q, but got %q", want, got)
+			}
+		})
+	}
+}
+
 func TestPrint(t *testing.T) {
 	printertesting.PrintResultErrorTest(t, func() printer.Printer {
 		ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
@@ -79,3 +139,128 @@ func TestPrint(t *testing.T) {
 		}
 	})
 }
+
+func TestLookupColumns(t *testing.T) {
+	testCases := map[string]struct {
+		names         []string
+		expectedNames []string
+		expectedErr   string
+	}{
+		"default columns": {
+			expectedNames: DefaultColumns,
+		},
+		"custom columns": {
+			names:         []string{"Namespace", "resource", "age", "message"},
+			expectedNames: []string{"namespace", "resource", "age", "message"},
+		},
+		"unknown column": {
+			names:       []string{"resource", "owner"},
+			expectedErr: `unknown column "owner"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			cols, err := lookupColumns(tc.names)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected error containing %q, but got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, col := range cols {
+				names = append(names, col.Name())
+			}
+			if want, got := strings.Join(tc.expectedNames, ","), strings.Join(names, ","); want != got {
+				t.Errorf("expected columns %q, but got %q", want, got)
+			}
+		})
+	}
+}
+
+func TestPrintColumns(t *testing.T) {
+	ioStreams, _, outBuffer, _ := genericclioptions.NewTestIOStreams()
+	p := &Printer{
+		IOStreams: ioStreams,
+		Columns:   []string{"resource", "message"},
+	}
+
+	ch := make(chan event.Event)
+	go func() {
+		ch <- event.Event{
+			Type:      event.InitType,
+			InitEvent: event.InitEvent{},
+		}
+		close(ch)
+	}()
+	if err := p.Print(ch, common.DryRunNone, true); err != nil {
+		t.Fatal(err)
+	}
+
+	header := strings.SplitN(outBuffer.String(), "\n", 2)[0]
+	if want, got := "RESOURCE", strings.Fields(header)[0]; want != got {
+		t.Errorf("expected first column %q, but got %q", want, got)
+	}
+	if want, got := "MESSAGE", strings.Fields(header)[1]; want != got {
+		t.Errorf("expected second column %q, but got %q", want, got)
+	}
+	if strings.Contains(outBuffer.String(), "ACTION") {
+		t.Errorf("expected no ACTION column, but got %q", outBuffer.String())
+	}
+}
+
+func TestPrintWaitSummary(t *testing.T) {
+	depID := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Namespace: "default",
+		Name:      "foo",
+	}
+	testCases := map[string]struct {
+		summary        *event.WaitSummaryEvent
+		expectedLines  int
+		expectedOutput string
+	}{
+		"no summary": {
+			summary: nil,
+		},
+		"pending and terminating objects": {
+			summary: &event.WaitSummaryEvent{
+				GroupName: "wait-0",
+				Elapsed:   30 * time.Second,
+				Pending: []event.WaitSummaryGroup{
+					{
+						GroupKind:   depID.GroupKind,
+						Status:      status.InProgressStatus,
+						Identifiers: object.ObjMetadataSet{depID},
+					},
+				},
+				Terminating: []event.TerminatingObject{
+					{
+						Identifier: depID,
+						Finalizers: []string{"example.com/cleanup"},
+					},
+				},
+			},
+			expectedLines: 2,
+			expectedOutput: "still waiting on 1 deployment.apps (InProgress) after 30s\n" +
+				"deployment.apps/foo terminating, blocked by finalizers: example.com/cleanup\n",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			var buf bytes.Buffer
+			lines := printWaitSummary(&buf, tc.summary)
+			if want, got := tc.expectedLines, lines; want != got {
+				t.Errorf("expected %d lines, but got %d", want, got)
+			}
+			if want, got := tc.expectedOutput, buf.String(); want != got {
+				t.Errorf("expected %q, but got %q", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package testreport provides printers that output the result of each
+// object as a test case, in JUnit XML or TAP format, so that the results
+// show up in the test reports of CI systems.
+//
+// Every applied, pruned, deleted, and waited on object is a test case of the
+// action. It passes if the action was successful (or the object reconciled),
+// fails if the action failed (or the object failed to reconcile or timed
+// out), and is skipped if the action was skipped.
+//
+// The report is printed when all the events have been received. If the run
+// stops with an error, it is reported as a failed "run" test case in JUnit,
+// and with "Bail out!" in TAP.
+package testreport
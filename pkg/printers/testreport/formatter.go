@@ -0,0 +1,206 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package testreport
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/print/list"
+	"sigs.k8s.io/cli-utils/pkg/print/stats"
+)
+
+// result is the outcome of a test case.
+type result int
+
+const (
+	passed result = iota
+	failed
+	skipped
+)
+
+// testCase is the result of an action on an object.
+type testCase struct {
+	action   event.ResourceAction
+	id       object.ObjMetadata
+	result   result
+	message  string
+	duration time.Duration
+}
+
+// name returns the name of the object, prefixed by its namespace.
+func (tc testCase) name() string {
+	if tc.id.Namespace == "" {
+		return tc.id.Name
+	}
+	return fmt.Sprintf("%s/%s", tc.id.Namespace, tc.id.Name)
+}
+
+// caseKey identifies the test case of an action on an object.
+type caseKey struct {
+	action event.ResourceAction
+	id     object.ObjMetadata
+}
+
+// NewFormatter returns a formatter that collects the results of the objects,
+// and prints them as a test report in the given format once all the events
+// have been received.
+func NewFormatter(ioStreams genericclioptions.IOStreams, format Format) list.Formatter {
+	return &formatter{
+		ioStreams: ioStreams,
+		format:    format,
+		indexes:   make(map[caseKey]int),
+	}
+}
+
+type formatter struct {
+	ioStreams genericclioptions.IOStreams
+	format    Format
+	// cases are the test cases, in the order of their first event.
+	cases []testCase
+	// indexes are the indexes of the test cases in cases.
+	indexes map[caseKey]int
+	// printed is true once the report has been printed.
+	printed bool
+}
+
+// record adds or replaces the test case of an action on an object.
+func (f *formatter) record(tc testCase) {
+	key := caseKey{action: tc.action, id: tc.id}
+	if i, found := f.indexes[key]; found {
+		f.cases[i] = tc
+		return
+	}
+	f.indexes[key] = len(f.cases)
+	f.cases = append(f.cases, tc)
+}
+
+func (f *formatter) FormatValidationEvent(_ event.ValidationEvent) error {
+	// Invalid objects are reported by their skipped apply or delete event.
+	return nil
+}
+
+func (f *formatter) FormatApplyEvent(e event.ApplyEvent) error {
+	switch e.Status {
+	case event.ApplySuccessful:
+		f.recordEvent(event.ApplyAction, e.Identifier, passed, e.Error, e.Timing)
+	case event.ApplySkipped:
+		f.recordEvent(event.ApplyAction, e.Identifier, skipped, e.Error, e.Timing)
+	case event.ApplyFailed:
+		f.recordEvent(event.ApplyAction, e.Identifier, failed, e.Error, e.Timing)
+	}
+	return nil
+}
+
+func (f *formatter) FormatStatusEvent(_ event.StatusEvent) error {
+	return nil
+}
+
+func (f *formatter) FormatPruneEvent(e event.PruneEvent) error {
+	switch e.Status {
+	case event.PruneSuccessful:
+		f.recordEvent(event.PruneAction, e.Identifier, passed, e.Error, e.Timing)
+	case event.PruneSkipped:
+		f.recordEvent(event.PruneAction, e.Identifier, skipped, e.Error, e.Timing)
+	case event.PruneFailed:
+		f.recordEvent(event.PruneAction, e.Identifier, failed, e.Error, e.Timing)
+	}
+	return nil
+}
+
+func (f *formatter) FormatDeleteEvent(e event.DeleteEvent) error {
+	switch e.Status {
+	case event.DeleteSuccessful:
+		f.recordEvent(event.DeleteAction, e.Identifier, passed, e.Error, e.Timing)
+	case event.DeleteSkipped:
+		f.recordEvent(event.DeleteAction, e.Identifier, skipped, e.Error, e.Timing)
+	case event.DeleteFailed:
+		f.recordEvent(event.DeleteAction, e.Identifier, failed, e.Error, e.Timing)
+	}
+	return nil
+}
+
+func (f *formatter) FormatWaitEvent(e event.WaitEvent) error {
+	switch e.Status {
+	case event.ReconcileSuccessful:
+		f.recordEvent(event.WaitAction, e.Identifier, passed, nil, e.Timing)
+	case event.ReconcileSkipped:
+		f.recordEvent(event.WaitAction, e.Identifier, skipped, nil, e.Timing)
+	case event.ReconcileTimeout:
+		f.recordEvent(event.WaitAction, e.Identifier, failed,
+			errors.New("timed out waiting for the object to reconcile"), e.Timing)
+	case event.ReconcileFailed:
+		f.recordEvent(event.WaitAction, e.Identifier, failed,
+			errors.New("the object failed to reconcile"), e.Timing)
+	}
+	return nil
+}
+
+// recordEvent records the test case of an event. Pending events are not
+// recorded, because they are followed by another event for the same object.
+func (f *formatter) recordEvent(action event.ResourceAction, id object.ObjMetadata,
+	r result, err error, timing event.Timing) {
+	tc := testCase{
+		action:   action,
+		id:       id,
+		result:   r,
+		duration: timing.Duration(),
+	}
+	if err != nil {
+		tc.message = err.Error()
+	}
+	f.record(tc)
+}
+
+func (f *formatter) FormatWaitSummaryEvent(_ event.WaitSummaryEvent) error {
+	return nil
+}
+
+func (f *formatter) FormatRetryEvent(_ event.RetryEvent) error {
+	return nil
+}
+
+func (f *formatter) FormatRateLimitEvent(_ event.RateLimitEvent) error {
+	return nil
+}
+
+func (f *formatter) FormatVerificationEvent(_ event.VerificationEvent) error {
+	return nil
+}
+
+func (f *formatter) FormatErrorEvent(e event.ErrorEvent) error {
+	return f.print(e.Err)
+}
+
+func (f *formatter) FormatActionGroupEvent(
+	_ event.ActionGroupEvent,
+	_ []event.ActionGroup,
+	_ stats.Stats,
+	_ list.Collector,
+) error {
+	return nil
+}
+
+func (f *formatter) FormatSummary(_ stats.Stats, _ *apply.RunResult) error {
+	return f.print(nil)
+}
+
+// print prints the report once, with the error that stopped the run, if any.
+func (f *formatter) print(runErr error) error {
+	if f.printed {
+		return nil
+	}
+	f.printed = true
+	switch f.format {
+	case TAP:
+		return printTAP(f.ioStreams.Out, f.cases, runErr)
+	default:
+		return printJUnit(f.ioStreams.Out, f.cases, runErr)
+	}
+}
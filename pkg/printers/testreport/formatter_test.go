@@ -0,0 +1,165 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package testreport
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/print/list"
+	"sigs.k8s.io/cli-utils/pkg/print/stats"
+)
+
+var (
+	deploymentID = object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Namespace: "default",
+		Name:      "web",
+	}
+	configMapID = object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+		Namespace: "default",
+		Name:      "config",
+	}
+	namespaceID = object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "Namespace"},
+		Name:      "old",
+	}
+)
+
+// formatEvents formats an apply of a Deployment and a ConfigMap, and a prune
+// of a Namespace.
+func formatEvents(t *testing.T, formatter list.Formatter) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, formatter.FormatApplyEvent(event.ApplyEvent{
+		Identifier: deploymentID,
+		Status:     event.ApplySuccessful,
+		Timing:     event.Timing{Start: start, End: start.Add(1500 * time.Millisecond)},
+	}))
+	require.NoError(t, formatter.FormatApplyEvent(event.ApplyEvent{
+		Identifier: configMapID,
+		Status:     event.ApplySkipped,
+		Error:      errors.New("dependency not ready"),
+	}))
+	require.NoError(t, formatter.FormatWaitEvent(event.WaitEvent{
+		Identifier: deploymentID,
+		Status:     event.ReconcilePending,
+	}))
+	require.NoError(t, formatter.FormatWaitEvent(event.WaitEvent{
+		Identifier: deploymentID,
+		Status:     event.ReconcileTimeout,
+	}))
+	require.NoError(t, formatter.FormatPruneEvent(event.PruneEvent{
+		Identifier: namespaceID,
+		Status:     event.PruneFailed,
+		Error:      errors.New("forbidden"),
+	}))
+}
+
+func TestFormatter_JUnit(t *testing.T) {
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams() //nolint:dogsled
+	formatter := NewFormatter(ioStreams, JUnit)
+	formatEvents(t, formatter)
+	require.NoError(t, formatter.FormatSummary(stats.Stats{}, apply.NewRunResult()))
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="4" failures="2" skipped="1">
+  <testsuite name="Apply" tests="2" failures="0" skipped="1">
+    <testcase classname="Deployment.apps" name="default/web" time="1.500"></testcase>
+    <testcase classname="ConfigMap" name="default/config">
+      <skipped message="dependency not ready"></skipped>
+    </testcase>
+  </testsuite>
+  <testsuite name="Prune" tests="1" failures="1" skipped="0">
+    <testcase classname="Namespace" name="old">
+      <failure message="forbidden"></failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="Wait" tests="1" failures="1" skipped="0">
+    <testcase classname="Deployment.apps" name="default/web">
+      <failure message="timed out waiting for the object to reconcile"></failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	assert.Equal(t, expected, out.String())
+}
+
+func TestFormatter_TAP(t *testing.T) {
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams() //nolint:dogsled
+	formatter := NewFormatter(ioStreams, TAP)
+	formatEvents(t, formatter)
+	require.NoError(t, formatter.FormatSummary(stats.Stats{}, apply.NewRunResult()))
+
+	expected := `TAP version 13
+1..4
+ok 1 - Apply Deployment.apps default/web
+ok 2 - Apply ConfigMap default/config # SKIP dependency not ready
+not ok 3 - Wait Deployment.apps default/web
+  ---
+  message: timed out waiting for the object to reconcile
+  ...
+not ok 4 - Prune Namespace old
+  ---
+  message: forbidden
+  ...
+`
+	assert.Equal(t, expected, out.String())
+}
+
+func TestFormatter_RunError(t *testing.T) {
+	runErr := errors.New("inventory not found")
+	testCases := map[string]struct {
+		format   Format
+		expected string
+	}{
+		"junit": {
+			format: JUnit,
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="2" failures="1" skipped="0">
+  <testsuite name="Apply" tests="1" failures="0" skipped="0">
+    <testcase classname="Deployment.apps" name="default/web"></testcase>
+  </testsuite>
+  <testsuite name="Run" tests="1" failures="1" skipped="0">
+    <testcase classname="Run" name="run">
+      <failure message="inventory not found"></failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
+		},
+		"tap": {
+			format: TAP,
+			expected: `TAP version 13
+1..1
+ok 1 - Apply Deployment.apps default/web
+Bail out! inventory not found
+`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams() //nolint:dogsled
+			formatter := NewFormatter(ioStreams, tc.format)
+			require.NoError(t, formatter.FormatApplyEvent(event.ApplyEvent{
+				Identifier: deploymentID,
+				Status:     event.ApplySuccessful,
+			}))
+			require.NoError(t, formatter.FormatErrorEvent(event.ErrorEvent{Err: runErr}))
+			// The report is only printed once.
+			require.NoError(t, formatter.FormatSummary(stats.Stats{}, apply.NewRunResult()))
+
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
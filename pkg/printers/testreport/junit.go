@@ -0,0 +1,111 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package testreport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr,omitempty"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// junitActions are the actions with a test suite, in order.
+var junitActions = []event.ResourceAction{
+	event.ApplyAction,
+	event.PruneAction,
+	event.DeleteAction,
+	event.WaitAction,
+}
+
+// printJUnit prints the test cases as JUnit XML, with a test suite per
+// action, and a test case per object. The class name of the test cases is
+// the kind of the object. A run error is reported as a failed test case of a
+// "Run" test suite.
+func printJUnit(w io.Writer, cases []testCase, runErr error) error {
+	report := junitTestSuites{}
+	for _, action := range junitActions {
+		suite := junitTestSuite{
+			Name: action.String(),
+		}
+		for _, tc := range cases {
+			if tc.action != action {
+				continue
+			}
+			jc := junitTestCase{
+				ClassName: tc.id.GroupKind.String(),
+				Name:      tc.name(),
+			}
+			if tc.duration > 0 {
+				jc.Time = fmt.Sprintf("%.3f", tc.duration.Seconds())
+			}
+			switch tc.result {
+			case failed:
+				jc.Failure = &junitMessage{Message: tc.message}
+				suite.Failures++
+			case skipped:
+				jc.Skipped = &junitMessage{Message: tc.message}
+				suite.Skipped++
+			}
+			suite.Cases = append(suite.Cases, jc)
+		}
+		if len(suite.Cases) == 0 {
+			continue
+		}
+		suite.Tests = len(suite.Cases)
+		report.Suites = append(report.Suites, suite)
+	}
+	if runErr != nil {
+		report.Suites = append(report.Suites, junitTestSuite{
+			Name:     "Run",
+			Tests:    1,
+			Failures: 1,
+			Cases: []junitTestCase{{
+				ClassName: "Run",
+				Name:      "run",
+				Failure:   &junitMessage{Message: runErr.Error()},
+			}},
+		})
+	}
+	for _, suite := range report.Suites {
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+	}
+
+	b, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "%s%s\n", xml.Header, b)
+	return err
+}
@@ -0,0 +1,29 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package testreport
+
+import (
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/print/list"
+	"sigs.k8s.io/cli-utils/pkg/printers/printer"
+)
+
+// Format is the format of the test report.
+type Format int
+
+const (
+	// JUnit is the JUnit XML format.
+	JUnit Format = iota
+	// TAP is the Test Anything Protocol, version 13.
+	TAP
+)
+
+func NewPrinter(ioStreams genericclioptions.IOStreams, format Format) printer.Printer {
+	return &list.BaseListPrinter{
+		FormatterFactory: func(previewStrategy common.DryRunStrategy) list.Formatter {
+			return NewFormatter(ioStreams, format)
+		},
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package testreport
+
+import (
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/printers/printer"
+	printertesting "sigs.k8s.io/cli-utils/pkg/printers/testutil"
+)
+
+func TestPrint(t *testing.T) {
+	for _, format := range []Format{JUnit, TAP} {
+		printertesting.PrintResultErrorTest(t, func() printer.Printer {
+			ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+			return NewPrinter(ioStreams, format)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package testreport
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// printTAP prints the test cases in TAP version 13, with a test point per
+// action on an object. The message of failed test cases is printed in a YAML
+// block, and the message of skipped test cases is the reason of the SKIP
+// directive. A run error is printed with "Bail out!".
+func printTAP(w io.Writer, cases []testCase, runErr error) error {
+	var sb strings.Builder
+	sb.WriteString("TAP version 13\n")
+	fmt.Fprintf(&sb, "1..%d\n", len(cases))
+	for i, tc := range cases {
+		description := fmt.Sprintf("%s %s %s", tc.action, tc.id.GroupKind, tc.name())
+		switch tc.result {
+		case passed:
+			fmt.Fprintf(&sb, "ok %d - %s\n", i+1, description)
+		case skipped:
+			fmt.Fprintf(&sb, "ok %d - %s # SKIP %s\n", i+1, description, firstLine(tc.message))
+		case failed:
+			fmt.Fprintf(&sb, "not ok %d - %s\n", i+1, description)
+			if tc.message != "" {
+				b, err := yaml.Marshal(map[string]string{"message": tc.message})
+				if err != nil {
+					return fmt.Errorf("failed to encode TAP diagnostics: %w", err)
+				}
+				sb.WriteString("  ---\n")
+				for _, line := range strings.Split(strings.TrimRight(string(b), "\n"), "\n") {
+					fmt.Fprintf(&sb, "  %s\n", line)
+				}
+				sb.WriteString("  ...\n")
+			}
+		}
+	}
+	if runErr != nil {
+		fmt.Fprintf(&sb, "Bail out! %s\n", firstLine(runErr.Error()))
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// firstLine returns the first line of s, because TAP directives and bail
+// out reasons can't span lines.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
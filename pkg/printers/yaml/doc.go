@@ -0,0 +1,9 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package yaml provides a printer that outputs the eventstream as a stream
+// of YAML documents, one per event.
+//
+// The events have the same fields as the events of the json printer, which
+// are documented in the json package.
+package yaml
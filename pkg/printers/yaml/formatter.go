@@ -0,0 +1,51 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package yaml
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/print/list"
+	"sigs.k8s.io/cli-utils/pkg/printers/json"
+	"sigs.k8s.io/yaml"
+)
+
+// NewFormatter returns a formatter that prints the events of the json
+// formatter as YAML documents.
+func NewFormatter(ioStreams genericclioptions.IOStreams,
+	previewStrategy common.DryRunStrategy) list.Formatter {
+	jsonStreams := ioStreams
+	jsonStreams.Out = &documentWriter{out: ioStreams.Out}
+	return json.NewFormatter(jsonStreams, previewStrategy)
+}
+
+// documentWriter converts each line of JSON written to it into a YAML
+// document written to out.
+type documentWriter struct {
+	out io.Writer
+	// buf holds the written bytes that don't end with a newline yet.
+	buf bytes.Buffer
+}
+
+func (w *documentWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := w.buf.Next(i + 1)
+		b, err := yaml.JSONToYAML(line)
+		if err != nil {
+			return 0, fmt.Errorf("failed to convert event to YAML: %w", err)
+		}
+		if _, err := fmt.Fprintf(w.out, "---\n%s", b); err != nil {
+			return 0, err
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2022 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package yaml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/yaml"
+)
+
+func TestFormatter(t *testing.T) {
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams() //nolint:dogsled
+	formatter := NewFormatter(ioStreams, common.DryRunNone)
+
+	id := object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Namespace: "foo",
+		Name:      "bar",
+	}
+	err := formatter.FormatApplyEvent(event.ApplyEvent{
+		Identifier: id,
+		Status:     event.ApplySuccessful,
+		Operation:  event.ApplyCreated,
+	})
+	require.NoError(t, err)
+	err = formatter.FormatWaitEvent(event.WaitEvent{
+		Identifier: id,
+		Status:     event.ReconcileSuccessful,
+	})
+	require.NoError(t, err)
+
+	docs := strings.Split(out.String(), "---\n")
+	require.Len(t, docs, 3)
+	assert.Empty(t, docs[0])
+
+	expected := []map[string]interface{}{
+		{
+			"group":         "apps",
+			"kind":          "Deployment",
+			"namespace":     "foo",
+			"name":          "bar",
+			"status":        "Successful",
+			"operation":     "Created",
+			"schemaVersion": float64(1),
+			"type":          "apply",
+		},
+		{
+			"group":         "apps",
+			"kind":          "Deployment",
+			"namespace":     "foo",
+			"name":          "bar",
+			"status":        "Successful",
+			"schemaVersion": float64(1),
+			"type":          "wait",
+		},
+	}
+	for i, doc := range docs[1:] {
+		var m map[string]interface{}
+		err := yaml.Unmarshal([]byte(doc), &m)
+		require.NoError(t, err)
+		assert.Contains(t, m, "timestamp")
+		delete(m, "timestamp")
+		assert.Equal(t, expected[i], m)
+	}
+}
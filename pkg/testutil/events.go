@@ -5,7 +5,9 @@ package testutil
 
 import (
 	"fmt"
+	"reflect"
 	"sort"
+	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -99,6 +101,86 @@ func VerifyEvents(expEvents []ExpEvent, events []event.Event) error {
 	return fmt.Errorf("event %s not found", expEvents[expEventIndex].EventType)
 }
 
+// ExpectEvents fails the test if the expected events are not received in
+// order. Only the fields set in the expected events are compared, and the
+// received events that do not match any expected event are ignored.
+func ExpectEvents(t *testing.T, events []event.Event, expEvents ...ExpEvent) {
+	t.Helper() // print the caller's file:line, instead of this func, on failure
+	groups := make([][]ExpEvent, len(expEvents))
+	for i, ee := range expEvents {
+		groups[i] = []ExpEvent{ee}
+	}
+	ExpectEventGroups(t, events, groups...)
+}
+
+// ExpectEventGroups is like ExpectEvents, except that the events of each
+// group may be received in any order, like the events of objects applied
+// concurrently. The groups are received in order: the events of a group are
+// all received after the events of the previous group.
+func ExpectEventGroups(t *testing.T, events []event.Event, groups ...[]ExpEvent) {
+	t.Helper() // print the caller's file:line, instead of this func, on failure
+	if err := VerifyEventGroups(groups, events); err != nil {
+		t.Error(err)
+	}
+}
+
+// VerifyEventGroups returns an error if the groups of expected events are not
+// received in order. See ExpectEventGroups.
+func VerifyEventGroups(groups [][]ExpEvent, events []event.Event) error {
+	start := 0
+	for i, group := range groups {
+		matched := make(map[int]bool, len(group))
+		next := start
+		for _, ee := range group {
+			index := -1
+			for j := start; j < len(events); j++ {
+				if !matched[j] && isMatch(ee, events[j]) {
+					index = j
+					break
+				}
+			}
+			if index < 0 {
+				return fmt.Errorf("expected event of group %d not found: %s", i, expEventString(ee))
+			}
+			matched[index] = true
+			if index >= next {
+				next = index + 1
+			}
+		}
+		start = next
+	}
+	return nil
+}
+
+// expEventString returns the type and the expected fields of the event.
+func expEventString(ee ExpEvent) string {
+	var fields interface{}
+	switch ee.EventType {
+	case event.InitType:
+		fields = ee.InitEvent
+	case event.ErrorType:
+		fields = ee.ErrorEvent
+	case event.ActionGroupType:
+		fields = ee.ActionGroupEvent
+	case event.ApplyType:
+		fields = ee.ApplyEvent
+	case event.StatusType:
+		fields = ee.StatusEvent
+	case event.PruneType:
+		fields = ee.PruneEvent
+	case event.DeleteType:
+		fields = ee.DeleteEvent
+	case event.WaitType:
+		fields = ee.WaitEvent
+	case event.ValidationType:
+		fields = ee.ValidationEvent
+	}
+	if fields == nil || reflect.ValueOf(fields).IsNil() {
+		return ee.EventType.String()
+	}
+	return fmt.Sprintf("%s %+v", ee.EventType, fields)
+}
+
 // nolint:gocyclo
 // TODO(mortent): This function is pretty complex and with quite a bit of
 // duplication. We should see if there is a better way to provide a flexible
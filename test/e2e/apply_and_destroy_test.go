@@ -35,6 +35,7 @@ func applyAndDestroyTest(ctx context.Context, c client.Client, invConfig invconf
 	}
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inventoryInfo, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 2 * time.Minute,
 		EmitStatusEvents: true,
 	}))
@@ -195,7 +196,9 @@ func applyAndDestroyTest(ctx context.Context, c client.Client, invConfig invconf
 	By("Destroy resources")
 	destroyer := invConfig.DestroyerFactoryFunc()
 
-	options := apply.DestroyerOptions{InventoryPolicy: inventory.PolicyAdoptIfNoInventory}
+	options := apply.DestroyerOptions{
+		InventoryPolicy: inventory.PolicyAdoptIfNoInventory,
+	}
 	destroyerEvents := e2eutil.RunCollect(destroyer.Run(ctx, inventoryInfo, options))
 
 	expEvents = []testutil.ExpEvent{
@@ -37,7 +37,9 @@ func continueOnErrorTest(ctx context.Context, c client.Client, invConfig invconf
 		pod1Obj,
 	}
 
-	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inv, resources, apply.ApplierOptions{}))
+	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inv, resources, apply.ApplierOptions{
+		Prune: apply.PruneEnabled,
+	}))
 
 	expEvents := []testutil.ExpEvent{
 		{
@@ -37,6 +37,7 @@ func crdTest(ctx context.Context, _ client.Client, invConfig invconfig.Inventory
 	}
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inv, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 2 * time.Minute,
 		EmitStatusEvents: false,
 	}))
@@ -220,7 +221,9 @@ func crdTest(ctx context.Context, _ client.Client, invConfig invconfig.Inventory
 
 	By("destroy the resources, including the crd")
 	destroyer := invConfig.DestroyerFactoryFunc()
-	options := apply.DestroyerOptions{InventoryPolicy: inventory.PolicyAdoptIfNoInventory}
+	options := apply.DestroyerOptions{
+		InventoryPolicy: inventory.PolicyAdoptIfNoInventory,
+	}
 	destroyerEvents := e2eutil.RunCollect(destroyer.Run(ctx, inv, options))
 
 	expEvents = []testutil.ExpEvent{
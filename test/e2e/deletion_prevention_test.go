@@ -33,6 +33,7 @@ func deletionPreventionTest(ctx context.Context, c client.Client, invConfig invc
 	}
 
 	e2eutil.RunCollect(applier.Run(ctx, inventoryInfo, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 2 * time.Minute,
 	}))
 
@@ -57,6 +58,7 @@ func deletionPreventionTest(ctx context.Context, c client.Client, invConfig invc
 	}
 
 	e2eutil.RunCollect(applier.Run(ctx, inventoryInfo, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 2 * time.Minute,
 		DryRunStrategy:   common.DryRunClient,
 	}))
@@ -82,6 +84,7 @@ func deletionPreventionTest(ctx context.Context, c client.Client, invConfig invc
 	}
 
 	e2eutil.RunCollect(applier.Run(ctx, inventoryInfo, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 2 * time.Minute,
 	}))
 
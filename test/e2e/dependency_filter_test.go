@@ -46,6 +46,7 @@ func dependencyFilterTest(ctx context.Context, c client.Client, invConfig invcon
 	}(ctx, c)
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inv, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		EmitStatusEvents: false,
 	}))
 
@@ -246,6 +247,7 @@ func dependencyFilterTest(ctx context.Context, c client.Client, invConfig invcon
 	}
 
 	applierEvents = e2eutil.RunCollect(applier.Run(ctx, inv, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		EmitStatusEvents: false,
 		ValidationPolicy: validation.SkipInvalid,
 	}))
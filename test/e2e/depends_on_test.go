@@ -54,6 +54,7 @@ func dependsOnTest(ctx context.Context, c client.Client, invConfig invconfig.Inv
 	}
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inv, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		EmitStatusEvents: false,
 	}))
 
@@ -420,7 +421,9 @@ func dependsOnTest(ctx context.Context, c client.Client, invConfig invconfig.Inv
 
 	By("destroy resources in opposite order")
 	destroyer := invConfig.DestroyerFactoryFunc()
-	options := apply.DestroyerOptions{InventoryPolicy: inventory.PolicyAdoptIfNoInventory}
+	options := apply.DestroyerOptions{
+		InventoryPolicy: inventory.PolicyAdoptIfNoInventory,
+	}
 	destroyerEvents := e2eutil.RunCollect(destroyer.Run(ctx, inv, options))
 
 	expEvents = []testutil.ExpEvent{
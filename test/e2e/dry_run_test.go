@@ -44,6 +44,7 @@ func dryRunTest(ctx context.Context, c client.Client, invConfig invconfig.Invent
 	}
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inventoryInfo, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 2 * time.Minute,
 		EmitStatusEvents: true,
 		DryRunStrategy:   common.DryRunClient,
@@ -183,6 +184,7 @@ func dryRunTest(ctx context.Context, c client.Client, invConfig invconfig.Invent
 
 	By("Apply")
 	e2eutil.RunWithNoErr(applier.Run(ctx, inventoryInfo, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 2 * time.Minute,
 	}))
 
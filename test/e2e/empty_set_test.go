@@ -30,6 +30,7 @@ func emptySetTest(ctx context.Context, c client.Client, invConfig invconfig.Inve
 	resources := []*unstructured.Unstructured{}
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inventoryInfo, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		EmitStatusEvents: true,
 	}))
 
@@ -84,7 +85,9 @@ func emptySetTest(ctx context.Context, c client.Client, invConfig invconfig.Inve
 	By("Destroy zero resources")
 	destroyer := invConfig.DestroyerFactoryFunc()
 
-	options := apply.DestroyerOptions{InventoryPolicy: inventory.PolicyAdoptIfNoInventory}
+	options := apply.DestroyerOptions{
+		InventoryPolicy: inventory.PolicyAdoptIfNoInventory,
+	}
 	destroyerEvents := e2eutil.RunCollect(destroyer.Run(ctx, inventoryInfo, options))
 
 	expEvents = []testutil.ExpEvent{
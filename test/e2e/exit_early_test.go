@@ -43,6 +43,7 @@ func exitEarlyTest(ctx context.Context, c client.Client, invConfig invconfig.Inv
 	}
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inv, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		EmitStatusEvents: false,
 		ValidationPolicy: validation.ExitEarly,
 	}))
@@ -34,6 +34,7 @@ func inventoryPolicyMustMatchTest(ctx context.Context, c client.Client, invConfi
 	}
 
 	e2eutil.RunWithNoErr(applier.Run(ctx, firstInv, firstResources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 2 * time.Minute,
 		EmitStatusEvents: true,
 	}))
@@ -47,6 +48,7 @@ func inventoryPolicyMustMatchTest(ctx context.Context, c client.Client, invConfi
 	}
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, secondInv, secondResources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 2 * time.Minute,
 		EmitStatusEvents: true,
 		InventoryPolicy:  inventory.PolicyMustMatch,
@@ -208,6 +210,7 @@ func inventoryPolicyAdoptIfNoInventoryTest(ctx context.Context, c client.Client,
 	}
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inv, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 2 * time.Minute,
 		EmitStatusEvents: true,
 		InventoryPolicy:  inventory.PolicyAdoptIfNoInventory,
@@ -379,6 +382,7 @@ func inventoryPolicyAdoptAllTest(ctx context.Context, c client.Client, invConfig
 	}
 
 	e2eutil.RunWithNoErr(applier.Run(ctx, firstInv, firstResources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 2 * time.Minute,
 		EmitStatusEvents: true,
 	}))
@@ -392,6 +396,7 @@ func inventoryPolicyAdoptAllTest(ctx context.Context, c client.Client, invConfig
 	}
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, secondInv, secondResources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 2 * time.Minute,
 		EmitStatusEvents: true,
 		InventoryPolicy:  inventory.PolicyAdoptAll,
@@ -52,6 +52,7 @@ func mutationTest(ctx context.Context, c client.Client, invConfig invconfig.Inve
 	}
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inv, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		EmitStatusEvents: false,
 	}))
 
@@ -262,7 +263,9 @@ func mutationTest(ctx context.Context, c client.Client, invConfig invconfig.Inve
 
 	By("destroy resources in opposite order")
 	destroyer := invConfig.DestroyerFactoryFunc()
-	options := apply.DestroyerOptions{InventoryPolicy: inventory.PolicyAdoptIfNoInventory}
+	options := apply.DestroyerOptions{
+		InventoryPolicy: inventory.PolicyAdoptIfNoInventory,
+	}
 	destroyerEvents := e2eutil.RunCollect(destroyer.Run(ctx, inv, options))
 
 	expEvents = []testutil.ExpEvent{
@@ -29,6 +29,7 @@ func applyWithExistingInvTest(ctx context.Context, c client.Client, invConfig in
 	}
 
 	e2eutil.RunWithNoErr(applier.Run(ctx, orgApplyInv, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 2 * time.Minute,
 		EmitStatusEvents: true,
 	}))
@@ -41,6 +42,7 @@ func applyWithExistingInvTest(ctx context.Context, c client.Client, invConfig in
 	secondApplyInv := invConfig.InvWrapperFunc(invConfig.FactoryFunc(inventoryName, namespaceName, secondInventoryID))
 
 	err := e2eutil.Run(applier.Run(ctx, secondApplyInv, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 2 * time.Minute,
 		EmitStatusEvents: true,
 	}))
@@ -48,6 +48,7 @@ func namespaceFilterTest(ctx context.Context, c client.Client, invConfig invconf
 	}(ctx, c)
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inv, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		EmitStatusEvents: false,
 	}))
 
@@ -244,6 +245,7 @@ func namespaceFilterTest(ctx context.Context, c client.Client, invConfig invconf
 	}
 
 	applierEvents = e2eutil.RunCollect(applier.Run(ctx, inv, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		EmitStatusEvents: false,
 	}))
 
@@ -34,6 +34,7 @@ func pruneRetrieveErrorTest(ctx context.Context, c client.Client, invConfig invc
 	}
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inv, resource1, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		EmitStatusEvents: false,
 	}))
 
@@ -172,6 +173,7 @@ func pruneRetrieveErrorTest(ctx context.Context, c client.Client, invConfig invc
 	}
 
 	applierEvents = e2eutil.RunCollect(applier.Run(ctx, inv, resource2, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		EmitStatusEvents: false,
 	}))
 
@@ -306,7 +308,9 @@ func pruneRetrieveErrorTest(ctx context.Context, c client.Client, invConfig invc
 	By("Destroy resources")
 	destroyer := invConfig.DestroyerFactoryFunc()
 
-	options := apply.DestroyerOptions{InventoryPolicy: inventory.PolicyAdoptIfNoInventory}
+	options := apply.DestroyerOptions{
+		InventoryPolicy: inventory.PolicyAdoptIfNoInventory,
+	}
 	destroyerEvents := e2eutil.RunCollect(destroyer.Run(ctx, inv, options))
 
 	expEvents = []testutil.ExpEvent{
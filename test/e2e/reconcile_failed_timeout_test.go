@@ -32,6 +32,7 @@ func reconciliationFailed(ctx context.Context, invConfig invconfig.InventoryConf
 	}
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inventoryInfo, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 2 * time.Minute,
 		EmitStatusEvents: false,
 	}))
@@ -55,6 +56,7 @@ func reconciliationTimeout(ctx context.Context, invConfig invconfig.InventoryCon
 	}
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inventoryInfo, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 30 * time.Second,
 		EmitStatusEvents: false,
 	}))
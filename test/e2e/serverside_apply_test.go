@@ -30,6 +30,7 @@ func serversideApplyTest(ctx context.Context, c client.Client, invConfig invconf
 	}
 
 	e2eutil.RunWithNoErr(applier.Run(ctx, inv, firstResources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		ReconcileTimeout: 2 * time.Minute,
 		EmitStatusEvents: true,
 		ServerSideOptions: common.ServerSideOptions{
@@ -58,6 +58,7 @@ func skipInvalidTest(ctx context.Context, c client.Client, invConfig invconfig.I
 	}
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inv, resources, apply.ApplierOptions{
+		Prune:            apply.PruneEnabled,
 		EmitStatusEvents: false,
 		ValidationPolicy: validation.SkipInvalid,
 	}))
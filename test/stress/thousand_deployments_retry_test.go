@@ -72,6 +72,7 @@ func thousandDeploymentsRetryTest(ctx context.Context, c client.Client, invConfi
 		start := time.Now()
 
 		applierEvents = e2eutil.RunCollect(applier.Run(ctx, inventoryInfo, resources, apply.ApplierOptions{
+			Prune: apply.PruneEnabled,
 			// SSA reduces GET+PATCH to just PATCH, which is faster
 			ServerSideOptions: common.ServerSideOptions{
 				ServerSideApply: true,
@@ -87,6 +87,7 @@ func thousandNamespacesTest(ctx context.Context, c client.Client, invConfig invc
 	start := time.Now()
 
 	applierEvents := e2eutil.RunCollect(applier.Run(ctx, inventoryInfo, resources, apply.ApplierOptions{
+		Prune: apply.PruneEnabled,
 		// SSA reduces GET+PATCH to just PATCH, which is faster
 		ServerSideOptions: common.ServerSideOptions{
 			ServerSideApply: true,